@@ -103,3 +103,94 @@ func (h *Handler) APISurveyGraph(ctx context.Context, input *struct {
 	}
 	return &SurveyGraphOutput{Body: *graph}, nil
 }
+
+// SurveyHotspotsOutput is the response body for APISurveyHotspots.
+type SurveyHotspotsOutput struct {
+	Body struct {
+		Files     []survey.Hotspot `json:"files"`
+		Functions []survey.Hotspot `json:"functions"`
+	}
+}
+
+// APISurveyHotspots returns the hotspots analyzer's churn × complexity
+// ranking, riskiest first, up to limit per scope.
+func (h *Handler) APISurveyHotspots(ctx context.Context, input *struct {
+	Project string `path:"project"`
+	Limit   int    `query:"limit" minimum:"1" maximum:"200" default:"50"`
+}) (*SurveyHotspotsOutput, error) {
+	inst := h.findInstance(input.Project)
+	if inst == nil {
+		return nil, huma.Error404NotFound("instance not found")
+	}
+	ss := inst.SurveyStore()
+	if ss == nil {
+		return nil, huma.Error503ServiceUnavailable("instance not connected")
+	}
+
+	entries, err := ss.ListEntries(survey.SearchOptions{
+		Analyzer: survey.AnalyzerHotspots,
+		Kind:     survey.KindHotspot,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SurveyHotspotsOutput{}
+	out.Body.Files = []survey.Hotspot{}
+	out.Body.Functions = []survey.Hotspot{}
+	for _, hs := range survey.HotspotsFromEntries(entries, "") {
+		switch {
+		case hs.Scope == survey.HotspotScopeFile && len(out.Body.Files) < input.Limit:
+			out.Body.Files = append(out.Body.Files, hs)
+		case hs.Scope == survey.HotspotScopeFunction && len(out.Body.Functions) < input.Limit:
+			out.Body.Functions = append(out.Body.Functions, hs)
+		}
+	}
+	return out, nil
+}
+
+// SurveyHistoryOutput is the response body for APISurveyHistory.
+type SurveyHistoryOutput struct {
+	Body struct {
+		Points []survey.TrendPoint `json:"points"`
+		Drift  string              `json:"drift"`
+		Total  int                 `json:"total"`
+	}
+}
+
+// APISurveyHistory returns the module drift over the most recent per-commit
+// survey snapshots, oldest first.
+func (h *Handler) APISurveyHistory(ctx context.Context, input *struct {
+	Project string `path:"project"`
+	Limit   int    `query:"limit" minimum:"1" maximum:"100" default:"50"`
+}) (*SurveyHistoryOutput, error) {
+	inst := h.findInstance(input.Project)
+	if inst == nil {
+		return nil, huma.Error404NotFound("instance not found")
+	}
+	ss := inst.SurveyStore()
+	if ss == nil {
+		return nil, huma.Error503ServiceUnavailable("instance not connected")
+	}
+
+	entries, err := ss.ListEntries(survey.SearchOptions{
+		Analyzer: survey.AnalyzerHistory,
+		Kind:     survey.KindSnapshot,
+		Limit:    -1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snaps := survey.SnapshotsFromEntries(entries)
+	points := survey.SnapshotTrend(snaps)
+	if len(points) > input.Limit {
+		points = points[len(points)-input.Limit:]
+	}
+	out := &SurveyHistoryOutput{}
+	out.Body.Points = append([]survey.TrendPoint{}, points...)
+	out.Body.Drift = survey.Drift(points)
+	out.Body.Total = len(snaps)
+	return out, nil
+}
@@ -68,6 +68,8 @@ func (s *Server) setupRouter() {
 	huma.Post(api, "/api/instances/{project}/findings/accept", h.APIAcceptFindings)
 	huma.Get(api, "/api/instances/{project}/survey", h.APIListSurvey)
 	huma.Get(api, "/api/instances/{project}/survey/graph", h.APISurveyGraph)
+	huma.Get(api, "/api/instances/{project}/survey/hotspots", h.APISurveyHotspots)
+	huma.Get(api, "/api/instances/{project}/survey/history", h.APISurveyHistory)
 	huma.Get(api, "/api/instances/{project}/tokens/stats", h.APIGetTokenStats)
 	huma.Get(api, "/api/instances/{project}/tokens/events", h.APIListTokenEvents)
 	huma.Get(api, "/api/instances/{project}/observe/events", h.APIListObserveEvents)
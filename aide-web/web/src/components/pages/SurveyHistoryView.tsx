@@ -0,0 +1,254 @@
+import { api } from "@/lib/api";
+import { useApi } from "@/hooks/use-api";
+import { SortableTable, type Column } from "../shared/SortableTable";
+import { Badge } from "../shared/ExpandableCard";
+import type { SurveyTrendPoint } from "@/lib/types";
+
+interface Props {
+  project: string;
+}
+
+const WIDTH = 640;
+const HEIGHT = 120;
+const PAD = { top: 10, right: 12, bottom: 18, left: 36 };
+
+const shortCommit = (c: string) => c.slice(0, 8);
+const day = (iso: string) => iso.slice(0, 10);
+
+interface ChartProps {
+  points: SurveyTrendPoint[];
+  value: (p: SurveyTrendPoint) => number;
+  format: (v: number) => string;
+  color: string;
+  bars?: boolean;
+}
+
+// TrendChart plots one value per snapshot, evenly spaced oldest to newest:
+// snapshots are taken when someone runs the survey, not on a schedule, so a
+// time axis would bunch them up.
+function TrendChart({ points, value, format, color, bars }: ChartProps) {
+  const values = points.map(value);
+  const maxV = Math.max(...values, bars ? 1 : 0);
+  const minV = bars ? 0 : Math.min(...values);
+  const span = maxV - minV || 1;
+  const innerW = WIDTH - PAD.left - PAD.right;
+  const innerH = HEIGHT - PAD.top - PAD.bottom;
+  const step = points.length > 1 ? innerW / (points.length - 1) : 0;
+  const x = (i: number) =>
+    PAD.left + (points.length > 1 ? i * step : innerW / 2);
+  const y = (v: number) => PAD.top + innerH - ((v - minV) / span) * innerH;
+  const barW = Math.max(2, Math.min(16, step * 0.6 || 16));
+
+  return (
+    <svg
+      viewBox={`0 0 ${WIDTH} ${HEIGHT}`}
+      className="w-full bg-aide-surface border border-aide-border rounded-md"
+    >
+      {[maxV, minV].map((v) => (
+        <g key={v}>
+          <line
+            x1={PAD.left}
+            x2={WIDTH - PAD.right}
+            y1={y(v)}
+            y2={y(v)}
+            stroke="#262626"
+          />
+          <text
+            x={PAD.left - 6}
+            y={y(v) + 3}
+            textAnchor="end"
+            fontSize="9"
+            fill="#737373"
+          >
+            {format(v)}
+          </text>
+        </g>
+      ))}
+      {bars ? (
+        points.map((p, i) => (
+          <rect
+            key={p.commit}
+            x={x(i) - barW / 2}
+            y={y(values[i])}
+            width={barW}
+            height={PAD.top + innerH - y(values[i])}
+            fill={color}
+            opacity={0.7}
+          >
+            <title>{`${shortCommit(p.commit)} ${day(p.committed_at)}: ${format(values[i])}`}</title>
+          </rect>
+        ))
+      ) : (
+        <>
+          <polyline
+            points={values.map((v, i) => `${x(i)},${y(v)}`).join(" ")}
+            fill="none"
+            stroke={color}
+            strokeWidth={1.8}
+          />
+          {points.map((p, i) => (
+            <circle key={p.commit} cx={x(i)} cy={y(values[i])} r={3} fill={color}>
+              <title>{`${shortCommit(p.commit)} ${day(p.committed_at)}: ${format(values[i])}`}</title>
+            </circle>
+          ))}
+        </>
+      )}
+      {points.length > 0 && (
+        <>
+          <text x={x(0)} y={HEIGHT - 4} fontSize="9" fill="#737373">
+            {day(points[0].committed_at)}
+          </text>
+          {points.length > 1 && (
+            <text
+              x={x(points.length - 1)}
+              y={HEIGHT - 4}
+              textAnchor="end"
+              fontSize="9"
+              fill="#737373"
+            >
+              {day(points[points.length - 1].committed_at)}
+            </text>
+          )}
+        </>
+      )}
+    </svg>
+  );
+}
+
+const columns: Column<SurveyTrendPoint>[] = [
+  {
+    key: "committed_at",
+    label: "Commit",
+    render: (row) => (
+      <span className="flex items-baseline gap-2">
+        <code className="bg-transparent px-0">{shortCommit(row.commit)}</code>
+        <span className="text-aide-text-dim">{day(row.committed_at)}</span>
+      </span>
+    ),
+  },
+  {
+    key: "modules",
+    label: "Modules",
+    render: (row) => <span className="tabular-nums">{row.modules}</span>,
+  },
+  {
+    key: "files",
+    label: "Files",
+    render: (row) => <span className="tabular-nums">{row.files}</span>,
+  },
+  {
+    key: "largest_share",
+    label: "Largest module",
+    render: (row) => (
+      <span className="tabular-nums">{Math.round(row.largest_share * 100)}%</span>
+    ),
+  },
+  {
+    key: "moved",
+    label: "Moved",
+    render: (row) => (
+      <span
+        className="tabular-nums"
+        title={`${row.new} new, ${row.dissolved} dissolved modules`}
+      >
+        {row.moved}
+      </span>
+    ),
+  },
+  {
+    key: "entrypoints",
+    label: "Entrypoints",
+    render: (row) => <span className="tabular-nums">{row.entrypoints}</span>,
+  },
+  {
+    key: "dependencies",
+    label: "Dependencies",
+    render: (row) =>
+      row.backfilled ? (
+        <span className="text-aide-text-dim" title="Backfilled with --at: dependencies not surveyed">
+          —
+        </span>
+      ) : (
+        <span className="tabular-nums">{row.dependencies}</span>
+      ),
+  },
+];
+
+// The history view charts module drift across the per-commit snapshots each
+// survey run records, so a growing module count with shrinking modules reads
+// as fragmentation and the reverse as consolidation.
+export function SurveyHistoryView({ project }: Props) {
+  const { data, loading, error } = useApi(
+    () => api.surveyHistory(project),
+    [project]
+  );
+
+  if (loading) return <p className="text-aide-text-dim text-sm">Loading...</p>;
+  if (error) return <p className="text-aide-red text-sm">{error}</p>;
+
+  const points = data?.points ?? [];
+  if (points.length === 0) {
+    return (
+      <p className="text-aide-text-dim text-sm">
+        No survey history yet. Each <code>aide survey run</code> in a git
+        repository records a snapshot of its commit; <code>--at=&lt;ref&gt;</code>{" "}
+        backfills past releases.
+      </p>
+    );
+  }
+
+  const charts = [
+    {
+      title: "Modules",
+      value: (p: SurveyTrendPoint) => p.modules,
+      format: (v: number) => String(Math.round(v)),
+      color: "#22d3ee",
+    },
+    {
+      title: "Largest module share of files",
+      value: (p: SurveyTrendPoint) => p.largest_share,
+      format: (v: number) => `${Math.round(v * 100)}%`,
+      color: "#9085e9",
+    },
+    {
+      title: "Files moved between modules since the previous snapshot",
+      value: (p: SurveyTrendPoint) => p.moved,
+      format: (v: number) => String(Math.round(v)),
+      color: "#c98500",
+      bars: true,
+    },
+  ];
+
+  return (
+    <div className="flex flex-col gap-6">
+      <p className="flex items-center gap-2 text-sm text-aide-text-muted">
+        Module map
+        <Badge
+          label={data?.drift ?? "stable"}
+          variant={data?.drift === "stable" ? "muted" : "accent"}
+        />
+        over {points.length} of {data?.total ?? points.length} snapshots
+      </p>
+      {charts.map((c) => (
+        <div key={c.title}>
+          <h3 className="text-sm font-medium text-aide-text mb-2">{c.title}</h3>
+          <TrendChart
+            points={points}
+            value={c.value}
+            format={c.format}
+            color={c.color}
+            bars={c.bars}
+          />
+        </div>
+      ))}
+      <SortableTable
+        data={points}
+        columns={columns}
+        keyFn={(row) => row.commit}
+        defaultSortKey="committed_at"
+        defaultSortDir="desc"
+        pageSize={25}
+      />
+    </div>
+  );
+}
@@ -0,0 +1,148 @@
+import { api } from "@/lib/api";
+import { useApi } from "@/hooks/use-api";
+import { SortableTable, type Column } from "../shared/SortableTable";
+import { Badge } from "../shared/ExpandableCard";
+import type { SurveyHotspot } from "@/lib/types";
+
+interface Props {
+  project: string;
+}
+
+function RiskBar({ risk }: { risk: number }) {
+  return (
+    <div className="flex items-center gap-2">
+      <div className="w-20 h-2 bg-aide-surface rounded-sm overflow-hidden">
+        <div
+          className={`h-full rounded-sm ${risk >= 0.5 ? "bg-aide-red/70" : "bg-aide-accent-dim/70"}`}
+          style={{ width: `${risk * 100}%` }}
+        />
+      </div>
+      <span className="tabular-nums text-aide-text-muted">
+        {risk.toFixed(2)}
+      </span>
+    </div>
+  );
+}
+
+const shared: Column<SurveyHotspot>[] = [
+  {
+    key: "churn",
+    label: "Recent commits",
+    render: (row) => (
+      <span className="tabular-nums" title={`${row.commits} commits in all`}>
+        {row.churn.toFixed(1)}
+      </span>
+    ),
+  },
+  {
+    key: "complexity",
+    label: "Complexity",
+    render: (row) => <span className="tabular-nums">{row.complexity}</span>,
+  },
+];
+
+const fileColumns: Column<SurveyHotspot>[] = [
+  {
+    key: "risk",
+    label: "Risk",
+    render: (row) => <RiskBar risk={row.risk} />,
+  },
+  {
+    key: "file",
+    label: "File",
+    render: (row) => (
+      <code className="bg-transparent px-0 break-all">{row.file}</code>
+    ),
+  },
+  ...shared,
+];
+
+const functionColumns: Column<SurveyHotspot>[] = [
+  {
+    key: "risk",
+    label: "Risk",
+    render: (row) => <RiskBar risk={row.risk} />,
+  },
+  {
+    key: "function",
+    label: "Function",
+    render: (row) => (
+      <span className="flex items-center gap-2">
+        <span className="font-medium text-aide-text">{row.function}</span>
+        {row.flagged && <Badge label="over threshold" variant="muted" />}
+      </span>
+    ),
+  },
+  {
+    key: "file",
+    label: "Location",
+    sortValue: (row) => `${row.file}:${String(row.line ?? 0).padStart(6, "0")}`,
+    render: (row) => (
+      <code className="bg-transparent px-0 break-all">
+        {row.file}:{row.line}
+      </code>
+    ),
+  },
+  ...shared,
+];
+
+// The hotspots view ranks code by churn × complexity: what is both hard to
+// follow and keeps changing is where refactoring effort pays off first.
+export function SurveyHotspotsView({ project }: Props) {
+  const { data, loading, error } = useApi(
+    () => api.surveyHotspots(project),
+    [project]
+  );
+
+  if (loading) return <p className="text-aide-text-dim text-sm">Loading...</p>;
+  if (error) return <p className="text-aide-red text-sm">{error}</p>;
+
+  const files = data?.files ?? [];
+  const functions = data?.functions ?? [];
+  if (files.length === 0 && functions.length === 0) {
+    return (
+      <p className="text-aide-text-dim text-sm">
+        No hotspots yet. Run <code>aide findings run complexity</code>, then{" "}
+        <code>aide survey run --analyzer=churn</code> and{" "}
+        <code>aide survey run --analyzer=hotspots</code>.
+      </p>
+    );
+  }
+
+  return (
+    <div className="flex flex-col gap-6">
+      <div>
+        <h3 className="text-sm font-medium text-aide-text mb-2">
+          Files
+          <span className="text-aide-text-dim font-normal ml-2">
+            recent churn × total complexity, relative to the riskiest file
+          </span>
+        </h3>
+        <SortableTable
+          data={files}
+          columns={fileColumns}
+          keyFn={(row) => row.file}
+          defaultSortKey="risk"
+          defaultSortDir="desc"
+          pageSize={25}
+        />
+      </div>
+      <div>
+        <h3 className="text-sm font-medium text-aide-text mb-2">
+          Functions
+          <span className="text-aide-text-dim font-normal ml-2">
+            each function carries its file's churn
+          </span>
+        </h3>
+        <SortableTable
+          data={functions}
+          columns={functionColumns}
+          keyFn={(row) => `${row.file}:${row.line}:${row.function}`}
+          defaultSortKey="risk"
+          defaultSortDir="desc"
+          pageSize={25}
+        />
+      </div>
+    </div>
+  );
+}
@@ -8,6 +8,8 @@ import { Badge } from "../shared/ExpandableCard";
 import type { SurveyItem } from "@/lib/types";
 import { SurveyOverview } from "./SurveyOverview";
 import { SurveyGraphView } from "./SurveyGraphView";
+import { SurveyHotspotsView } from "./SurveyHotspotsView";
+import { SurveyHistoryView } from "./SurveyHistoryView";
 
 const columns: Column<SurveyItem>[] = [
   {
@@ -39,7 +41,7 @@ const columns: Column<SurveyItem>[] = [
   },
 ];
 
-const VIEWS = ["overview", "list", "graph"] as const;
+const VIEWS = ["overview", "list", "hotspots", "history", "graph"] as const;
 type View = (typeof VIEWS)[number];
 
 export function SurveyPage() {
@@ -175,6 +177,14 @@ export function SurveyPage() {
         </>
       )}
 
+      {!loading && !error && view === "hotspots" && (
+        <SurveyHotspotsView project={project!} />
+      )}
+
+      {!loading && !error && view === "history" && (
+        <SurveyHistoryView project={project!} />
+      )}
+
       {!loading && !error && view === "graph" && (
         <SurveyGraphView project={project!} modules={modules} />
       )}
@@ -8,6 +8,8 @@ import type {
   FindingItem,
   SurveyItem,
   SurveyCallGraph,
+  SurveyHotspot,
+  SurveyTrendPoint,
   TopReferencedSymbol,
   CodeSymbolHit,
   SearchResult,
@@ -177,6 +179,18 @@ export const api = {
       { symbol, direction, max_depth: String(maxDepth), max_nodes: String(maxNodes) }
     ),
 
+  surveyHotspots: (project: string, limit = 50) =>
+    get<{ files: SurveyHotspot[]; functions: SurveyHotspot[] }>(
+      `${BASE}/instances/${encodeURIComponent(project)}/survey/hotspots`,
+      { limit: String(limit) }
+    ),
+
+  surveyHistory: (project: string, limit = 50) =>
+    get<{ points: SurveyTrendPoint[]; drift: string; total: number }>(
+      `${BASE}/instances/${encodeURIComponent(project)}/survey/history`,
+      { limit: String(limit) }
+    ),
+
   listSurvey: (project: string, analyzer?: string, kind?: string, limit = 500) =>
     get<{ entries: SurveyItem[] }>(
       `${BASE}/instances/${encodeURIComponent(project)}/survey`,
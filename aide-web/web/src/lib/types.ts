@@ -129,6 +129,34 @@ export interface SurveyCallGraph {
   depth: number;
 }
 
+export interface SurveyHotspot {
+  scope: "file" | "function";
+  file: string;
+  function?: string;
+  line?: number;
+  rank: number;
+  risk: number;
+  churn: number;
+  commits: number;
+  complexity: number;
+  flagged?: boolean;
+  last_change?: string;
+}
+
+export interface SurveyTrendPoint {
+  commit: string;
+  committed_at: string;
+  modules: number;
+  files: number;
+  largest_share: number;
+  moved: number;
+  new: number;
+  dissolved: number;
+  entrypoints: number;
+  dependencies: number;
+  backfilled?: boolean; // surveyed with --at: dependencies unknown
+}
+
 export interface SearchResult {
   instance: string;
   type: string;
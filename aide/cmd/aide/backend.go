@@ -165,5 +165,5 @@ func (b *Backend) RetentionSweep() map[string]int {
 // openCodeStore opens the code store for direct access.
 func (b *Backend) openCodeStore() (store.CodeIndexStore, error) {
 	indexPath, searchPath := getCodeStorePaths(b.dbPath)
-	return store.NewCodeStore(indexPath, searchPath)
+	return store.NewCodeStore(indexPath, searchPath, codeStoreOptions()...)
 }
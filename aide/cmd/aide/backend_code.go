@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	"github.com/jmylchreest/aide/aide/pkg/code"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi/adapter"
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
 	"github.com/jmylchreest/aide/aide/pkg/store"
 	"github.com/jmylchreest/aide/aide/pkg/survey"
 )
@@ -80,6 +82,39 @@ func (b *Backend) SearchCode(query string, kind, language, filePath string, limi
 	return codeResults, nil
 }
 
+// errSemanticGRPC is returned for semantic searches while the daemon holds
+// the code index: the search embeds the query in-process and has no RPC.
+var errSemanticGRPC = errors.New("semantic search not supported in gRPC client mode — use code_search with semantic=true over MCP or stop the daemon")
+
+// SemanticSearchCode ranks symbols by the similarity of their signature, doc
+// comment and body to a natural-language query.
+func (b *Backend) SemanticSearchCode(query string, kind, language, filePath string, limit int) ([]*CodeSearchResult, error) {
+	if b.useGRPC {
+		return nil, errSemanticGRPC
+	}
+
+	codeStore, err := b.openCodeStore()
+	if err != nil {
+		return nil, err
+	}
+	defer codeStore.Close()
+
+	results, err := codeStore.SemanticSearchSymbols(query, code.SearchOptions{
+		Kind:     kind,
+		Language: language,
+		FilePath: filePath,
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	codeResults := make([]*CodeSearchResult, 0, len(results))
+	for _, r := range results {
+		codeResults = append(codeResults, &CodeSearchResult{Symbol: r.Symbol, Score: r.Score})
+	}
+	return codeResults, nil
+}
+
 func (b *Backend) GetFileSymbols(filePath string) ([]*code.Symbol, error) {
 	ctx, cancel := b.rpcCtx()
 	defer cancel()
@@ -165,6 +200,74 @@ func (b *Backend) SearchReferences(symbolName, kind, filePath string, limit int)
 	})
 }
 
+// errHierarchyGRPC is returned for type-hierarchy queries while the daemon
+// holds the code index: the hierarchy has no RPCs, so it is only reachable
+// directly or through the daemon's own code_implementations /
+// code_supertypes MCP tools.
+var errHierarchyGRPC = errors.New("type hierarchy queries not supported in gRPC client mode — use the code_implementations / code_supertypes MCP tools or stop the daemon")
+
+// FindTypeHierarchy returns the subtypes (sub) or supertypes of typeName.
+func (b *Backend) FindTypeHierarchy(typeName string, sub bool) ([]*code.TypeEdge, error) {
+	if b.useGRPC {
+		return nil, errHierarchyGRPC
+	}
+
+	codeStore, err := b.openCodeStore()
+	if err != nil {
+		return nil, err
+	}
+	defer codeStore.Close()
+
+	if sub {
+		return codeStore.FindImplementations(typeName)
+	}
+	return codeStore.FindSupertypes(typeName)
+}
+
+// errHistoryGRPC is returned for symbol history queries while the daemon
+// holds the code index: history is cached in the store and has no RPCs.
+var errHistoryGRPC = errors.New("symbol history not supported in gRPC client mode — use the code_symbol_history MCP tool or stop the daemon")
+
+// SymbolHistory returns the change history of the symbols name denotes,
+// optionally only those in files matching filePath.
+func (b *Backend) SymbolHistory(name, filePath string, limit int) ([]*code.SymbolHistory, error) {
+	if b.useGRPC {
+		return nil, errHistoryGRPC
+	}
+
+	codeStore, err := b.openCodeStore()
+	if err != nil {
+		return nil, err
+	}
+	defer codeStore.Close()
+
+	defs, err := findHistoryDefinitions(codeStore, name, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return symbolHistories(codeStore, store.ProjectRootFromDB(b.dbPath), defs, limit)
+}
+
+// errRepoMapGRPC is returned for repo maps while the daemon holds the code
+// index: the map ranks the whole reference graph, which has no RPC.
+var errRepoMapGRPC = errors.New("repo map not supported in gRPC client mode — use the code_repo_map MCP tool or stop the daemon")
+
+// RepoMap builds a ranked, token-budgeted outline of the most important
+// symbols, using the survey's entry points and modules when it has run.
+func (b *Backend) RepoMap(opts survey.RepoMapOptions) (*survey.RepoMap, error) {
+	if b.useGRPC {
+		return nil, errRepoMapGRPC
+	}
+
+	codeStore, err := b.openCodeStore()
+	if err != nil {
+		return nil, err
+	}
+	defer codeStore.Close()
+
+	return survey.BuildRepoMap(codeStore, repoMapEntries(b.ListSurvey), opts)
+}
+
 type CodeIndexResult struct {
 	FilesIndexed   int
 	SymbolsIndexed int
@@ -232,6 +335,7 @@ func (b *Backend) IndexCodeWithProgress(paths []string, force bool, progress fun
 	shouldSkip := ignore.WalkFunc(projRoot)
 
 	result := &CodeIndexResult{}
+	var indexed []string
 
 	for _, root := range paths {
 		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
@@ -272,13 +376,15 @@ func (b *Backend) IndexCodeWithProgress(paths []string, force bool, progress fun
 				return nil
 			}
 			refs, _ := parser.ParseFileReferences(path)
+			edges, _ := parser.ParseFileHierarchy(path)
 
-			if err := codeStore.IndexFileBatch(relPath, symbols, refs, info.ModTime(), info.Size()); err != nil {
+			if err := codeStore.IndexFileBatch(relPath, symbols, refs, edges, info.ModTime(), info.Size()); err != nil {
 				return nil
 			}
 
 			result.FilesIndexed++
 			result.SymbolsIndexed += len(symbols)
+			indexed = append(indexed, relPath)
 
 			if progress != nil {
 				progress(relPath, len(symbols))
@@ -291,6 +397,16 @@ func (b *Backend) IndexCodeWithProgress(paths []string, force bool, progress fun
 		}
 	}
 
+	// Resolve reference targets for what changed (everything on --force).
+	if len(indexed) > 0 {
+		if force {
+			indexed = nil
+		}
+		if _, err := codeStore.ResolveReferenceTargets(indexed, importresolve.New(projRoot).ResolveTarget); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/code"
 	"github.com/jmylchreest/aide/aide/pkg/findings"
@@ -15,7 +17,10 @@ import (
 // DeadCodeAnalysisOptions controls behaviour of RunDeadCodeAnalysis.
 type DeadCodeAnalysisOptions struct {
 	IncludeExported bool
-	Progress        func(checked, found int)
+	// ShowSuppressed keeps aide:ignore-suppressed findings with their reason.
+	// Only honoured in direct mode; the daemon always drops them.
+	ShowSuppressed bool
+	Progress       func(checked, found int)
 }
 
 // DeadCodeAnalysisResult is the CLI-facing result of a dead-code analyzer run.
@@ -58,6 +63,7 @@ func (b *Backend) RunDeadCodeAnalysis(opts DeadCodeAnalysisOptions) (*DeadCodeAn
 	}
 
 	registry := grammar.DefaultPackRegistry()
+	projectRoot := store.ProjectRootFromDB(b.dbPath)
 	cfg := findings.DeadCodeConfig{
 		GetAllSymbols: func() ([]*code.Symbol, error) {
 			return codeStore.ListAllSymbols(-1)
@@ -72,7 +78,7 @@ func (b *Backend) RunDeadCodeAnalysis(opts DeadCodeAnalysisOptions) (*DeadCodeAn
 			}
 			return len(refs), nil
 		},
-		ProjectRoot:        store.ProjectRootFromDB(b.dbPath),
+		ProjectRoot:        projectRoot,
 		ProgressFn:         opts.Progress,
 		PackProvider:       registry.Get,
 		IncludeExported:    opts.IncludeExported,
@@ -83,6 +89,7 @@ func (b *Backend) RunDeadCodeAnalysis(opts DeadCodeAnalysisOptions) (*DeadCodeAn
 	if err != nil {
 		return nil, err
 	}
+	ff = findings.SuppressDeadCode(ff, projectRoot, opts.ShowSuppressed)
 
 	if err := b.ReplaceFindingsForAnalyzer(findings.AnalyzerDeadCode, ff); err != nil {
 		return nil, fmt.Errorf("failed to store findings: %w", err)
@@ -116,6 +123,7 @@ func (b *Backend) SearchFindings(query string, opts findings.SearchOptions) ([]*
 			Severity: opts.Severity,
 			FilePath: opts.FilePath,
 			Category: opts.Category,
+			State:    opts.State,
 			Limit:    int32(opts.Limit),
 		})
 		if err != nil {
@@ -148,6 +156,7 @@ func (b *Backend) ListFindings(opts findings.SearchOptions) ([]*findings.Finding
 			Severity: opts.Severity,
 			FilePath: opts.FilePath,
 			Category: opts.Category,
+			State:    opts.State,
 			Limit:    int32(opts.Limit),
 		})
 		if err != nil {
@@ -173,7 +182,7 @@ func (b *Backend) GetFindingsStats(opts findings.SearchOptions) (*findings.Stats
 	ctx := context.Background()
 
 	if b.useGRPC {
-		resp, err := b.grpcClient.Findings.Stats(ctx, &grpcapi.FindingStatsRequest{})
+		resp, err := b.grpcClient.Findings.Stats(ctx, &grpcapi.FindingStatsRequest{State: opts.State})
 		if err != nil {
 			return nil, err
 		}
@@ -185,10 +194,18 @@ func (b *Backend) GetFindingsStats(opts findings.SearchOptions) (*findings.Stats
 		for k, v := range resp.BySeverity {
 			bySeverity[k] = int(v)
 		}
+		var byRule map[string]int
+		if len(resp.ByRule) > 0 {
+			byRule = make(map[string]int, len(resp.ByRule))
+			for k, v := range resp.ByRule {
+				byRule[k] = int(v)
+			}
+		}
 		return &findings.Stats{
 			Total:      int(resp.Total),
 			ByAnalyzer: byAnalyzer,
 			BySeverity: bySeverity,
+			ByRule:     byRule,
 		}, nil
 	}
 
@@ -287,6 +304,76 @@ func (b *Backend) ClearFindingsAnalyzer(analyzer string) (int, error) {
 	return fs.ClearAnalyzer(analyzer)
 }
 
+// TriageFinding records a triage transition for the finding identified by ref
+// (finding ID or fingerprint). ev.Fingerprint and ev.FindingID are filled in
+// from the resolved finding.
+func (b *Backend) TriageFinding(ref string, ev *findings.TriageEvent) (*findings.TriageStatus, *findings.Finding, error) {
+	ts, closeFn, err := b.openFindingsTriageStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeFn()
+
+	fp, f, err := ts.ResolveTriageRef(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	ev.Fingerprint = fp
+	if f != nil {
+		ev.FindingID = f.ID
+	}
+	st, err := ts.TriageFinding(ev)
+	return st, f, err
+}
+
+// TriageHistory returns the resolved fingerprint and its triage history for
+// ref (finding ID or fingerprint).
+func (b *Backend) TriageHistory(ref string) (string, []*findings.TriageEvent, error) {
+	ts, closeFn, err := b.openFindingsTriageStore()
+	if err != nil {
+		return "", nil, err
+	}
+	defer closeFn()
+
+	fp, _, err := ts.ResolveTriageRef(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	history, err := ts.TriageHistory(fp)
+	return fp, history, err
+}
+
+// ListTriageEvents returns triage transitions into state to (all states when
+// empty) recorded at or after since.
+func (b *Backend) ListTriageEvents(since time.Time, to findings.TriageState) ([]*findings.TriageEvent, error) {
+	ts, closeFn, err := b.openFindingsTriageStore()
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+
+	return ts.ListTriageEvents(since, to)
+}
+
+// openFindingsTriageStore opens the findings store and narrows it to the
+// triage surface. While the daemon runs, triage goes through its RPCs.
+func (b *Backend) openFindingsTriageStore() (store.FindingsTriageStore, func() error, error) {
+	if b.useGRPC {
+		a := adapter.NewFindingsAdapter(b.grpcClient)
+		return a, a.Close, nil
+	}
+	fs, err := b.openFindingsStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	ts, ok := fs.(store.FindingsTriageStore)
+	if !ok {
+		fs.Close()
+		return nil, nil, errors.New("findings store does not support triage")
+	}
+	return ts, fs.Close, nil
+}
+
 // AddFinding stores a single finding via gRPC or direct access.
 func (b *Backend) AddFinding(f *findings.Finding) error {
 	ctx := context.Background()
@@ -360,3 +447,49 @@ func (b *Backend) grpcFindingsReplaceForAnalyzer(analyzer string, ff []*findings
 	}
 	return nil
 }
+
+// ReplaceFindingsForAnalyzerAndFile replaces one analyzer's findings for a
+// single file, leaving the rest of the analyzer's findings untouched. Used by
+// diff-scoped runs that only re-analyse changed files.
+func (b *Backend) ReplaceFindingsForAnalyzerAndFile(analyzer, filePath string, ff []*findings.Finding) error {
+	if b.useGRPC {
+		return b.grpcFindingsReplaceForAnalyzerAndFile(analyzer, filePath, ff)
+	}
+
+	fs, err := b.openFindingsStore()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	return fs.ReplaceFindingsForAnalyzerAndFile(analyzer, filePath, ff)
+}
+
+// grpcFindingsReplaceForAnalyzerAndFile is the per-file counterpart of
+// grpcFindingsReplaceForAnalyzer and shares its non-atomic delete-then-add
+// behaviour.
+func (b *Backend) grpcFindingsReplaceForAnalyzerAndFile(analyzer, filePath string, ff []*findings.Finding) error {
+	ctx := context.Background()
+
+	resp, err := b.grpcClient.Findings.GetFileFindings(ctx, &grpcapi.FindingFileRequest{
+		FilePath: filePath,
+	})
+	if err != nil {
+		return err
+	}
+	for _, pf := range resp.Findings {
+		if pf.Analyzer != analyzer || pf.FilePath != filePath {
+			continue
+		}
+		if _, err := b.grpcClient.Findings.Delete(ctx, &grpcapi.FindingDeleteRequest{Id: pf.Id}); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range ff {
+		if err := b.AddFinding(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -183,11 +183,40 @@ func (b *Backend) SurveyRun(analyzer string) ([]surveyrun.Result, error) {
 		defer cs.Close()
 	}
 
+	var findingsStore store.FindingsStore
+	if fs, ferr := b.openFindingsStore(); ferr == nil {
+		findingsStore = fs
+		defer fs.Close()
+	}
+
+	var analyzers []string
+	if analyzer != "" {
+		analyzers = []string{analyzer}
+	}
+	return surveyrun.Run(store.ProjectRootFromDB(b.dbPath), analyzers, ss, codeStore, findingsStore), nil
+}
+
+// SurveyRunAt backfills a history snapshot for a past commit. The tree is
+// read and indexed in this process in both modes — the daemon's stores are
+// not involved beyond storing the snapshot, which the gRPC adapter can do.
+func (b *Backend) SurveyRunAt(ref, analyzer string) ([]surveyrun.Result, error) {
+	var ss store.SurveyStore
+	if b.useGRPC {
+		ss = adapter.NewSurveyAdapter(b.grpcClient)
+	} else {
+		direct, err := b.openSurveyStore()
+		if err != nil {
+			return nil, err
+		}
+		defer direct.Close()
+		ss = direct
+	}
+
 	var analyzers []string
 	if analyzer != "" {
 		analyzers = []string{analyzer}
 	}
-	return surveyrun.Run(store.ProjectRootFromDB(b.dbPath), analyzers, ss, codeStore), nil
+	return surveyrun.RunAt(store.ProjectRootFromDB(b.dbPath), ref, analyzers, ss, newGrammarLoader(b.dbPath, nil))
 }
 
 func (b *Backend) ReplaceSurveyForAnalyzer(analyzer string, entries []*survey.Entry) error {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/contextshare"
+	"github.com/jmylchreest/aide/aide/pkg/findings"
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi"
 	"github.com/jmylchreest/aide/aide/pkg/memory"
@@ -33,7 +34,14 @@ func startDaemonForTest(t *testing.T) string {
 	}
 	t.Cleanup(func() { st.Close() })
 
+	fs, err := store.NewFindingsStore(getFindingsStorePath(dbPath))
+	if err != nil {
+		t.Fatalf("NewFindingsStore: %v", err)
+	}
+	t.Cleanup(func() { fs.Close() })
+
 	srv := grpcapi.NewServer(st, dbPath, socketPath, grammar.NewCompositeLoader())
+	srv.SetFindingsStore(fs)
 	go func() { _ = srv.Start() }()
 	t.Cleanup(srv.Stop)
 
@@ -155,3 +163,100 @@ func TestBackendExportMaterialisesTombstone_DaemonMode(t *testing.T) {
 		t.Errorf("tombstone .md files = %d, want 1", mdCount)
 	}
 }
+
+// TestBackendFindingsTriage_DaemonMode asserts that triage, the triage log and
+// state filters work while the daemon holds the findings DB.
+func TestBackendFindingsTriage_DaemonMode(t *testing.T) {
+	dbPath := startDaemonForTest(t)
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	if !b.UsingGRPC() {
+		t.Fatal("expected Backend to use gRPC (daemon mode)")
+	}
+
+	f := &findings.Finding{Analyzer: findings.AnalyzerTodos, Severity: findings.SevInfo, FilePath: "a.go", Line: 3, Title: "TODO: split"}
+	if err := b.AddFinding(f); err != nil {
+		t.Fatalf("AddFinding: %v", err)
+	}
+	listed, err := b.ListFindings(findings.SearchOptions{})
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("ListFindings = %v, %v; want the added finding", listed, err)
+	}
+
+	st, got, err := b.TriageFinding(listed[0].ID, &findings.TriageEvent{To: findings.TriageAcknowledged, Assignee: "agent-1"})
+	if err != nil {
+		t.Fatalf("TriageFinding over gRPC: %v", err)
+	}
+	if st.State != findings.TriageAcknowledged || st.Assignee != "agent-1" || got == nil || got.Title != f.Title {
+		t.Errorf("TriageFinding = %+v, %+v", st, got)
+	}
+	if _, _, err := b.TriageFinding(listed[0].ID, &findings.TriageEvent{To: findings.TriageFixed}); err != nil {
+		t.Fatalf("second transition: %v", err)
+	}
+
+	fp, history, err := b.TriageHistory(listed[0].ID)
+	if err != nil {
+		t.Fatalf("TriageHistory over gRPC: %v", err)
+	}
+	if fp != listed[0].Fingerprint() || len(history) != 2 || history[1].To != findings.TriageFixed {
+		t.Errorf("TriageHistory = %q, %+v", fp, history)
+	}
+
+	fixed, err := b.ListTriageEvents(time.Now().Add(-time.Hour), findings.TriageFixed)
+	if err != nil {
+		t.Fatalf("ListTriageEvents over gRPC: %v", err)
+	}
+	if len(fixed) != 1 || fixed[0].Title != f.Title {
+		t.Errorf("ListTriageEvents = %+v, want the fixed transition", fixed)
+	}
+
+	byState, err := b.ListFindings(findings.SearchOptions{State: string(findings.TriageFixed)})
+	if err != nil || len(byState) != 1 {
+		t.Errorf("ListFindings(state=fixed) = %v, %v; want 1", byState, err)
+	}
+	if open, err := b.ListFindings(findings.SearchOptions{}); err != nil || len(open) != 0 {
+		t.Errorf("ListFindings() = %v, %v; fixed finding should be hidden", open, err)
+	}
+	stats, err := b.GetFindingsStats(findings.SearchOptions{State: string(findings.TriageFixed)})
+	if err != nil || stats.Total != 1 {
+		t.Errorf("GetFindingsStats(state=fixed) = %+v, %v; want 1", stats, err)
+	}
+}
+
+// TestBackendFindingsStatsByRule_DaemonMode checks that per-rule counts of
+// custom findings survive the gRPC round trip.
+func TestBackendFindingsStatsByRule_DaemonMode(t *testing.T) {
+	dbPath := startDaemonForTest(t)
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		t.Fatalf("NewBackend: %v", err)
+	}
+	t.Cleanup(func() { b.Close() })
+	if !b.UsingGRPC() {
+		t.Fatal("expected Backend to use gRPC (daemon mode)")
+	}
+
+	custom := func(rule string, line int) *findings.Finding {
+		return &findings.Finding{
+			Analyzer: findings.AnalyzerCustom, Severity: findings.SevWarning, FilePath: "a.go", Line: line,
+			Title: rule, Metadata: map[string]string{"rule_id": rule},
+		}
+	}
+	ff := []*findings.Finding{custom("no-println", 1), custom("no-println", 2), custom("no-panic", 3)}
+	if err := b.ReplaceFindingsForAnalyzer(findings.AnalyzerCustom, ff); err != nil {
+		t.Fatalf("ReplaceFindingsForAnalyzer: %v", err)
+	}
+
+	stats, err := b.GetFindingsStats(findings.SearchOptions{})
+	if err != nil {
+		t.Fatalf("GetFindingsStats over gRPC: %v", err)
+	}
+	if stats.ByRule["no-println"] != 2 || stats.ByRule["no-panic"] != 1 {
+		t.Errorf("ByRule = %v, want no-println 2, no-panic 1", stats.ByRule)
+	}
+}
@@ -2,15 +2,20 @@ package main
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/aideignore"
 	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/config"
+	"github.com/jmylchreest/aide/aide/pkg/embed"
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
 	"github.com/jmylchreest/aide/aide/pkg/observe"
 	"github.com/jmylchreest/aide/aide/pkg/store"
 )
@@ -22,10 +27,18 @@ func cmdCodeDispatcher(dbPath string, args []string) error {
 		{name: "search", handler: func(a []string) error { return cmdCodeSearch(dbPath, a) }},
 		{name: "symbols", handler: func(a []string) error { return cmdCodeSymbols(dbPath, a) }},
 		{name: "references", aliases: []string{"refs"}, handler: func(a []string) error { return cmdCodeReferences(dbPath, a) }},
+		{name: "implementations", aliases: []string{"impls"}, handler: func(a []string) error { return cmdCodeHierarchy(dbPath, a, true) }},
+		{name: "supertypes", handler: func(a []string) error { return cmdCodeHierarchy(dbPath, a, false) }},
+		{name: "history", handler: func(a []string) error { return cmdCodeHistory(dbPath, a) }},
+		{name: "impact", handler: func(a []string) error { return cmdCodeImpact(dbPath, a) }},
+		{name: "repo-map", handler: func(a []string) error { return cmdCodeRepoMap(dbPath, a) }},
+		{name: "cochange", handler: func(a []string) error { return cmdCodeCoChange(dbPath, a) }},
 		{name: "read-check", handler: func(a []string) error { return cmdCodeReadCheck(dbPath, a) }},
 		{name: "clear", handler: func(a []string) error { return cmdCodeClear(dbPath) }},
 		{name: "stats", handler: func(a []string) error { return cmdCodeStats(dbPath) }},
 		{name: "reconcile", handler: func(a []string) error { return cmdCodeReconcile(dbPath) }},
+		{name: "import-scip", handler: func(a []string) error { return cmdCodeImportSCIP(dbPath, a) }},
+		{name: "export-scip", handler: func(a []string) error { return cmdCodeExportSCIP(dbPath, a) }},
 	})
 }
 
@@ -40,7 +53,7 @@ func cmdCodeReconcile(dbPath string) error {
 		return fmt.Errorf("a daemon is currently holding the code index; stop the MCP server first, then run `aide code reconcile` (or run `aide code clear && aide code index` for a full rebuild)")
 	}
 
-	cs, err := backend.openCodeStore()
+	cs, err := openIndexingCodeStore(dbPath)
 	if err != nil {
 		return fmt.Errorf("failed to open code store: %w", err)
 	}
@@ -67,16 +80,28 @@ Subcommands:
   search     Search for symbols by name/signature
   symbols    List symbols in a file
   references Find all call sites/usages of a symbol
+  implementations
+             List types that implement, extend or embed a type
+  supertypes List what a type extends, implements or embeds
+  history    Show the commits that changed a symbol
+  impact     Show the symbols, entry points and tests a change may affect
+  repo-map   Outline the most important symbols within a token budget
+  cochange   List the files that historically change together with a file
   read-check Check if a file is indexed and unchanged
   clear      Clear the code index
   stats      Show indexing statistics
   reconcile  Drop stale index entries (deleted files, newly-ignored paths) and refresh modified ones
+  import-scip
+             Load a SCIP index (precise symbols and references) into the code index
+  export-scip
+             Write the code index as a SCIP index
 
 Options:
   index [paths...]:
     --force      Re-index even if file hasn't changed
 
   search <query>:
+    --semantic     Rank by meaning over signatures, doc comments and bodies
     --kind=TYPE    Filter by kind (function, method, class, interface, type)
     --lang=LANG    Filter by language (typescript, go, python)
     --file=PATH    Filter by file path pattern
@@ -92,17 +117,55 @@ Options:
     --limit=N      Max results (default 50)
     --json         Output as JSON
 
+  implementations <type>, supertypes <type>:
+    --json         Output as JSON
+
+  history <symbol>:
+    --file=PATH    Only definitions in files matching PATH
+    --limit=N      Max commits per symbol (default 10)
+    --json         Output as JSON
+
+  impact [file[:start[-end]]...]:
+    --diff=FILE    Read changes from a unified diff (- for stdin); default: uncommitted changes
+    --depth=N      Max caller hops from each changed symbol (default 3)
+    --max-nodes=N  Max callers walked per changed symbol (default 50)
+    --exact        Only follow references resolved to the changed definitions
+    --json         Output as JSON
+
+  repo-map [focus files...]:
+    --tokens=N     Token budget (default 1024)
+    --json         Output as JSON
+
+  cochange <file>:
+    --min-confidence=F  Only partners in at least this share of the file's commits (0-1)
+    --limit=N      Max partners (default 10)
+    --json         Output as JSON
+
   read-check <file>:
     --json         Output as JSON
 
+  import-scip <file>:
+    --prefix=DIR   Directory the index's paths are relative to (default: project root)
+
+  export-scip:
+    --output=PATH  Output file (default index.scip)
+
 Examples:
   aide code index                     # Index current directory
   aide code index src/ lib/           # Index specific directories
   aide code search "getUser"          # Search for symbols
   aide code search "User" --kind=interface
+  aide code search "where do we retry HTTP calls" --semantic
   aide code symbols src/auth.ts       # List symbols in file
   aide code refs getUserById          # Find all calls to getUserById
+  aide code impls Reader              # Find implementations of Reader
+  aide code history CodeStore.Close   # When did Close last change, and why?
+  aide code impact                    # What do my uncommitted changes affect?
+  git diff main | aide code impact --diff=-
+  aide code repo-map --tokens=2048    # Most important symbols, ranked
+  aide code cochange aidememory.proto # What else changes with the proto?
   aide code read-check src/auth.ts    # Check if file is indexed and fresh
+  aide code import-scip index.scip    # Use a CI-built SCIP index
   aide code clear                     # Clear all indexed data`)
 }
 
@@ -113,6 +176,40 @@ func getCodeStorePaths(dbPath string) (string, string) {
 	return filepath.Join(codeDir, "index.db"), filepath.Join(codeDir, "search.bleve")
 }
 
+// codeStoreOptions returns the options every code-store open shares, so each
+// opens it with the same embedder. A bad embeddings config is logged and
+// leaves semantic search off rather than failing the open.
+func codeStoreOptions() []store.CodeStoreOption {
+	cfg := config.Get().Code
+	e, err := embed.New(embed.Config{
+		Provider: cfg.Embeddings,
+		URL:      cfg.EmbeddingsURL,
+		Model:    cfg.EmbeddingsModel,
+		APIKey:   cfg.EmbeddingsAPIKey,
+	})
+	if err != nil {
+		log.Printf("code: semantic search disabled: %v", err)
+	}
+	return []store.CodeStoreOption{store.WithEmbedder(e)}
+}
+
+// openIndexingCodeStore opens the code store for a process that indexes it
+// (the daemon, `aide code index`), reconciling the stored vectors with the
+// configured embedder. Read-only openers use store.NewCodeStore directly so
+// they never discard vectors the daemon is serving.
+func openIndexingCodeStore(dbPath string) (*store.CodeStore, error) {
+	indexPath, searchPath := getCodeStorePaths(dbPath)
+	cs, err := store.NewCodeStore(indexPath, searchPath, codeStoreOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	if err := cs.ReconcileEmbedder(); err != nil {
+		cs.Close()
+		return nil, fmt.Errorf("code embedder check failed: %w", err)
+	}
+	return cs, nil
+}
+
 func cmdCodeIndex(dbPath string, args []string) error {
 	force := hasFlag(args, "--force")
 
@@ -164,7 +261,7 @@ func cmdCodeIndex(dbPath string, args []string) error {
 
 func cmdCodeSearch(dbPath string, args []string) error {
 	if len(args) < 1 {
-		return fmt.Errorf("usage: aide code search <query> [--kind=TYPE] [--lang=LANG] [--limit=N]")
+		return fmt.Errorf("usage: aide code search <query> [--semantic] [--kind=TYPE] [--lang=LANG] [--limit=N]")
 	}
 
 	// Parse query (first non-flag argument)
@@ -188,6 +285,7 @@ func cmdCodeSearch(dbPath string, args []string) error {
 		return err
 	}
 	jsonOutput := hasFlag(args, "--json")
+	semantic := hasFlag(args, "--semantic")
 
 	// Create backend (uses gRPC if daemon is running)
 	backend, err := NewBackend(dbPath)
@@ -197,13 +295,19 @@ func cmdCodeSearch(dbPath string, args []string) error {
 	defer backend.Close()
 
 	// Auto-wrap simple queries with wildcards for substring matching
-	// Skip if query already contains Bleve syntax characters
-	if query != "" && !containsBleveSyntax(query) {
+	// Skip if query already contains Bleve syntax characters or is a
+	// qualified name (store.CodeStore.Close), which the store resolves
+	if !semantic && !containsBleveSyntax(query) && !code.IsQualifiedName(query) {
 		query = "*" + query + "*"
 	}
 
 	// Search
-	results, err := backend.SearchCode(query, kind, language, filePath, limit)
+	var results []*CodeSearchResult
+	if semantic {
+		results, err = backend.SemanticSearchCode(query, kind, language, filePath, limit)
+	} else {
+		results, err = backend.SearchCode(query, kind, language, filePath, limit)
+	}
 	if err != nil {
 		return fmt.Errorf("search failed: %w", err)
 	}
@@ -349,6 +453,69 @@ func cmdCodeReferences(dbPath string, args []string) error {
 	return nil
 }
 
+// cmdCodeHierarchy lists a type's implementations (sub) or supertypes.
+func cmdCodeHierarchy(dbPath string, args []string, sub bool) error {
+	var typeName string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			typeName = arg
+			break
+		}
+	}
+	if typeName == "" {
+		if sub {
+			return fmt.Errorf("usage: aide code implementations <type> [--json]")
+		}
+		return fmt.Errorf("usage: aide code supertypes <type> [--json]")
+	}
+	jsonOutput := hasFlag(args, "--json")
+
+	backend, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer backend.Close()
+
+	edges, err := backend.FindTypeHierarchy(typeName, sub)
+	if err != nil {
+		return fmt.Errorf("hierarchy query failed: %w", err)
+	}
+
+	if jsonOutput {
+		return printJSON(edges)
+	}
+	if len(edges) == 0 {
+		if sub {
+			fmt.Printf("No implementations found for '%s'\n", typeName)
+		} else {
+			fmt.Printf("No supertypes found for '%s'\n", typeName)
+		}
+		return nil
+	}
+
+	if sub {
+		fmt.Printf("Implementations of '%s' (%d found):\n", typeName, len(edges))
+	} else {
+		fmt.Printf("Supertypes of '%s' (%d found):\n", typeName, len(edges))
+	}
+	for _, e := range edges {
+		other := e.SuperName
+		if sub {
+			other = e.TypeName
+		}
+		kind := e.Kind
+		if e.Structural {
+			kind += "*"
+		}
+		kindPad := padString(fmt.Sprintf("[%s]", kind), 14)
+		fmt.Printf("  %s %s %s:%d\n", kindPad, padString(other, 30), e.FilePath, e.Line)
+	}
+	if slices.ContainsFunc(edges, func(e *code.TypeEdge) bool { return e.Structural }) {
+		fmt.Println("\n  * structural: method set satisfies the interface (Go)")
+	}
+	return nil
+}
+
 func cmdCodeClear(dbPath string) error {
 	// Create backend (uses gRPC if daemon is running)
 	backend, err := NewBackend(dbPath)
@@ -444,14 +611,14 @@ func cmdCodeReadCheck(dbPath string, args []string) error {
 type Indexer struct {
 	store   store.CodeIndexStore
 	parser  *code.Parser
-	rootDir string // absolute project root for relative path computation
+	rootDir string          // absolute project root for relative path computation
+	trees   *code.TreeCache // parsed trees for incremental re-parsing (nil = off)
 }
 
 // NewIndexer creates a new indexer by opening a new code store.
 // Prefer NewIndexerFromStore when a code store is already open.
 func NewIndexer(dbPath string) (*Indexer, error) {
-	indexPath, searchPath := getCodeStorePaths(dbPath)
-	codeStore, err := store.NewCodeStore(indexPath, searchPath)
+	codeStore, err := openIndexingCodeStore(dbPath)
 	if err != nil {
 		return nil, err
 	}
@@ -473,12 +640,21 @@ func NewIndexerFromStore(cs store.CodeIndexStore, loader grammar.Loader, rootDir
 	}
 }
 
+// EnableTreeCache keeps up to capacity parsed trees so files re-indexed
+// after an edit are reparsed incrementally. Meant for long-lived indexers
+// such as the watcher's; one-shot runs parse each file once anyway.
+func (idx *Indexer) EnableTreeCache(capacity int) {
+	idx.trees = code.NewTreeCache(capacity)
+}
+
 // Close closes the indexer.
 func (idx *Indexer) Close() error {
+	idx.trees.Close()
 	return idx.store.Close()
 }
 
-// IndexFile indexes a single file (symbols and references).
+// IndexFile indexes a single file (symbols, references and type edges),
+// rewriting only the records that changed since it was last indexed.
 func (idx *Indexer) IndexFile(filePath string) (int, error) {
 	span := observe.Start("Indexer.IndexFile", observe.KindSpan).Category("indexer").Subtype("index_file").FilePath(filePath)
 	defer span.End()
@@ -490,11 +666,11 @@ func (idx *Indexer) IndexFile(filePath string) (int, error) {
 		}
 	}
 
-	symbols, err := idx.parser.ParseFile(filePath)
+	parsed, err := idx.parser.ParseFileCached(filePath, idx.trees)
 	if err != nil {
 		return 0, err
 	}
-	refs, _ := idx.parser.ParseFileReferences(filePath)
+	span.Attr("incremental", strconv.FormatBool(parsed.Reused))
 
 	info, _ := os.Stat(filePath)
 	modTime := time.Now()
@@ -504,10 +680,12 @@ func (idx *Indexer) IndexFile(filePath string) (int, error) {
 		sizeBytes = info.Size()
 	}
 
-	if err := idx.store.IndexFileBatch(relPath, symbols, refs, modTime, sizeBytes); err != nil {
+	delta, err := idx.store.IndexFileDelta(relPath, parsed.Symbols, parsed.Refs, parsed.Edges, modTime, sizeBytes)
+	if err != nil {
 		return 0, err
 	}
-	return len(symbols), nil
+	span.Attr("symbols_written", strconv.Itoa(delta.SymbolsWritten))
+	return len(parsed.Symbols), nil
 }
 
 // ReconcileResult summarises an Indexer.Reconcile pass.
@@ -597,6 +775,12 @@ func (idx *Indexer) Reconcile() (ReconcileResult, error) {
 		}
 	}
 
+	if res.Refreshed > 0 || res.Removed > 0 {
+		if _, err := idx.ResolveTargets(nil); err != nil {
+			res.Errors++
+		}
+	}
+
 	return res, nil
 }
 
@@ -740,6 +924,8 @@ func (idx *Indexer) RemoveFile(filePath string) error {
 		}
 	}
 
+	idx.trees.Remove(filePath)
+
 	// Clear references from this file (call sites, type refs, etc.).
 	if err := idx.store.ClearFileReferences(relPath); err != nil {
 		return fmt.Errorf("clear references: %w", err)
@@ -748,3 +934,29 @@ func (idx *Indexer) RemoveFile(filePath string) error {
 	// Clear symbols and file tracking info.
 	return idx.store.ClearFile(relPath)
 }
+
+// ResolveTargets re-resolves reference targets after a batch of index
+// changes: references in the given files (absolute or project-relative) and
+// references to names they define. nil re-resolves the whole index. The
+// import resolver is rebuilt per call so manifest edits are picked up.
+func (idx *Indexer) ResolveTargets(filePaths []string) (int, error) {
+	span := observe.Start("Indexer.ResolveTargets", observe.KindSpan).Category("indexer").Subtype("resolve_targets")
+	defer span.End()
+
+	var rels []string
+	if filePaths != nil {
+		rels = make([]string, 0, len(filePaths))
+		for _, p := range filePaths {
+			rel := p
+			if abs, err := filepath.Abs(p); err == nil {
+				if r, err := filepath.Rel(idx.rootDir, abs); err == nil {
+					rel = r
+				}
+			}
+			rels = append(rels, rel)
+		}
+	}
+	n, err := idx.store.ResolveReferenceTargets(rels, importresolve.New(idx.rootDir).ResolveTarget)
+	span.Attr("resolved", strconv.Itoa(n))
+	return n, err
+}
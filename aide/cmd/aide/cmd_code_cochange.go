@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// defaultCoChangeLimit caps the partners listed for a file.
+const defaultCoChangeLimit = 10
+
+// coChangeReport is the co-change view shared by 'aide code cochange' and
+// the code_cochange MCP tool.
+type coChangeReport struct {
+	File     string                   `json:"file"`
+	Partners []survey.CoChangePartner `json:"partners"`
+	Surveyed bool                     `json:"surveyed"` // false when the cochange analyzer has not run
+}
+
+// buildCoChangeReport finds the files that change with file among the
+// stored cochange entries.
+func buildCoChangeReport(list func(survey.SearchOptions) ([]*survey.Entry, error), file string, minConfidence float64, limit int) (*coChangeReport, error) {
+	entries, err := list(survey.SearchOptions{Analyzer: survey.AnalyzerCoChange, Kind: survey.KindCoChange, Limit: -1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read co-change data: %w", err)
+	}
+	if limit <= 0 {
+		limit = defaultCoChangeLimit
+	}
+	partners := survey.CoChangePartners(entries, file, minConfidence)
+	if len(partners) > limit {
+		partners = partners[:limit]
+	}
+	return &coChangeReport{File: file, Partners: partners, Surveyed: len(entries) > 0}, nil
+}
+
+// formatCoChangeReport renders the report as text.
+func formatCoChangeReport(r *coChangeReport) string {
+	if !r.Surveyed {
+		return "No co-change data — run 'aide survey run --analyzer=cochange' (or survey_run analyzer=cochange) first.\n"
+	}
+	if len(r.Partners) == 0 {
+		return fmt.Sprintf("No files regularly change together with %s.\n", r.File)
+	}
+	var sb strings.Builder
+	of := r.Partners[0].Of
+	fmt.Fprintf(&sb, "Files that change together with %s:\n", of)
+	for _, p := range r.Partners {
+		prefix := ""
+		if p.Of != of {
+			prefix = "(with " + p.Of + ") "
+		}
+		fmt.Fprintf(&sb, "  %s%s — %d of %d commits (%.0f%%), last together %s",
+			prefix, p.File, p.Support, p.Commits, p.Confidence*100, p.Last)
+		if p.SameModule == "false" {
+			sb.WriteString(", other module")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nWhen changing this file, check whether these need updating or regenerating too.\n")
+	return sb.String()
+}
+
+// cmdCodeCoChange lists the files that historically change in the same
+// commits as a file.
+func cmdCodeCoChange(dbPath string, args []string) error {
+	var file string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			file = arg
+			break
+		}
+	}
+	if file == "" {
+		return fmt.Errorf("usage: aide code cochange <file> [--min-confidence=0.5] [--limit=N] [--json]")
+	}
+	minConfidence := 0.0
+	if v := parseFlag(args, "--min-confidence="); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid --min-confidence= value %q: %w", v, err)
+		}
+		minConfidence = f
+	}
+	limit, err := parseIntFlag(args, "--limit=", defaultCoChangeLimit)
+	if err != nil {
+		return err
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	report, err := buildCoChangeReport(b.ListSurvey, file, minConfidence, limit)
+	if err != nil {
+		return err
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(report)
+	}
+	fmt.Print(formatCoChangeReport(report))
+	return nil
+}
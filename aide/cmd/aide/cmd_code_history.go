@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// errNotGitRepo is returned for history queries outside a git repository.
+var errNotGitRepo = errors.New("symbol history needs a git repository")
+
+// maxHistoryDefinitions caps how many same-named definitions a history
+// query reports.
+const maxHistoryDefinitions = 5
+
+// cmdCodeHistory prints the commits that changed a symbol.
+func cmdCodeHistory(dbPath string, args []string) error {
+	var name string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			name = arg
+			break
+		}
+	}
+	if name == "" {
+		return fmt.Errorf("usage: aide code history <symbol> [--file=PATH] [--limit=N] [--json]")
+	}
+	limit, err := parseIntFlag(args, "--limit=", 10)
+	if err != nil {
+		return err
+	}
+
+	backend, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer backend.Close()
+
+	histories, err := backend.SymbolHistory(name, parseFlag(args, "--file="), limit)
+	if err != nil {
+		return fmt.Errorf("history query failed: %w", err)
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(histories)
+	}
+	if len(histories) == 0 {
+		fmt.Printf("No symbol found for '%s'\n", name)
+		return nil
+	}
+	for i, h := range histories {
+		if i > 0 {
+			fmt.Println()
+		}
+		sym := h.Symbol
+		fmt.Printf("%s [%s] %s:%d-%d\n", symbolDisplayName(sym), sym.Kind, sym.FilePath, sym.StartLine, sym.EndLine)
+		fmt.Printf("  %d commits, %d lines changed%s\n", h.Churn, h.LinesChanged, formatHistoryAuthors(h.Authors))
+		for _, c := range h.Commits {
+			fmt.Printf("  %s %s %s %s %s\n", shortHash(c.Hash), c.When.Format("2006-01-02"),
+				padString(c.Author, 20), padString(fmt.Sprintf("+%d -%d", c.Added, c.Deleted), 10), c.Subject)
+		}
+	}
+	return nil
+}
+
+// findHistoryDefinitions returns up to maxHistoryDefinitions definitions
+// of name, restricted to files containing filePath when it is set.
+func findHistoryDefinitions(cs store.CodeIndexStore, name, filePath string) ([]*code.Symbol, error) {
+	defs, err := findDefinitions(cs, name, 50)
+	if err != nil {
+		return nil, err
+	}
+	var out []*code.Symbol
+	for _, d := range defs {
+		if filePath != "" && !strings.Contains(d.FilePath, filePath) {
+			continue
+		}
+		out = append(out, d)
+		if len(out) == maxHistoryDefinitions {
+			break
+		}
+	}
+	return out, nil
+}
+
+// symbolHistories returns the history of each of defs, walking git once per
+// file and caching the result in cs.
+func symbolHistories(cs store.CodeIndexStore, root string, defs []*code.Symbol, limit int) ([]*code.SymbolHistory, error) {
+	repo, err := survey.OpenGitRepo(root)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, errNotGitRepo
+	}
+	repoRoot, err := repo.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*code.FileHistory)
+	out := make([]*code.SymbolHistory, 0, len(defs))
+	for _, d := range defs {
+		h, ok := files[d.FilePath]
+		if !ok {
+			if h, err = fileSymbolHistory(cs, repo, root, repoRoot, d.FilePath); err != nil {
+				return nil, fmt.Errorf("%s: %w", d.FilePath, err)
+			}
+			files[d.FilePath] = h
+		}
+		out = append(out, h.For(d, limit))
+	}
+	return out, nil
+}
+
+// fileSymbolHistory brings the stored history of filePath up to date with
+// HEAD and the file's indexed symbols, storing it back when it changed.
+func fileSymbolHistory(cs store.CodeIndexStore, repo *survey.GitRepo, root, repoRoot, filePath string) (*code.FileHistory, error) {
+	abs := filePath
+	if !filepath.IsAbs(abs) {
+		abs = filepath.Join(root, filePath)
+	}
+	current, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(resolvePath(repoRoot), resolvePath(abs))
+	if err != nil {
+		return nil, err
+	}
+
+	syms, err := cs.GetFileSymbols(filePath)
+	if err != nil {
+		return nil, err
+	}
+	spans := make([]survey.LineSpan, 0, len(syms))
+	for _, s := range syms {
+		spans = append(spans, survey.LineSpan{Key: code.HistoryKey(s), Start: s.StartLine, End: s.EndLine})
+	}
+
+	prev, err := cs.GetFileHistory(filePath)
+	if err != nil {
+		return nil, err
+	}
+	h, err := repo.UpdateSymbolHistory(prev, filepath.ToSlash(rel), current, spans, 0)
+	if err != nil {
+		return nil, err
+	}
+	if h != prev {
+		h.FilePath = filePath
+		if err := cs.PutFileHistory(h); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// resolvePath resolves symlinks in p so it can be made relative to the
+// repository root, falling back to p itself.
+func resolvePath(p string) string {
+	if r, err := filepath.EvalSymlinks(p); err == nil {
+		return r
+	}
+	return p
+}
+
+// symbolDisplayName prefers a symbol's qualified name.
+func symbolDisplayName(s *code.Symbol) string {
+	if s.QualifiedName != "" {
+		return s.QualifiedName
+	}
+	return s.Name
+}
+
+func formatHistoryAuthors(authors []code.AuthorChanges) string {
+	if len(authors) == 0 {
+		return ""
+	}
+	parts := make([]string, len(authors))
+	for i, a := range authors {
+		parts[i] = fmt.Sprintf("%s (%d)", a.Author, a.Commits)
+	}
+	return "; authors: " + strings.Join(parts, ", ")
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// TestSymbolHistories indexes a small repository, traces a function's
+// history and checks the per-file history is cached in the code store.
+func TestSymbolHistories(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	when := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(author, msg, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, "main.go"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add("main.go"); err != nil {
+			t.Fatal(err)
+		}
+		when = when.Add(time.Hour)
+		if _, err := wt.Commit(msg, &git.CommitOptions{Author: &object.Signature{Name: author, Email: author + "@example.com", When: when}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit("ann", "add main", "package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {}\n")
+	commit("bob", "run does work", "package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {\n\tprintln(\"hi\")\n}\n")
+
+	dbPath := filepath.Join(root, ".aide", "memory", "memory.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	indexPath, searchPath := getCodeStorePaths(dbPath)
+	cs, err := store.NewCodeStore(indexPath, searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+	if _, err := NewIndexerFromStore(cs, newGrammarLoader(dbPath, nil), root).Reconcile(); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	defs, err := findHistoryDefinitions(cs, "run", "")
+	if err != nil || len(defs) != 1 {
+		t.Fatalf("definitions of run = %v, %v", defs, err)
+	}
+	hs, err := symbolHistories(cs, root, defs, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := hs[0]
+	if h.Churn != 2 || len(h.Commits) != 2 || h.Commits[0].Subject != "run does work" || h.Commits[0].Author != "bob" {
+		t.Errorf("history of run = %+v", h)
+	}
+	if len(h.Authors) != 2 {
+		t.Errorf("authors = %+v, want ann and bob", h.Authors)
+	}
+
+	cached, err := cs.GetFileHistory("main.go")
+	if err != nil || cached == nil {
+		t.Fatalf("history not cached: %v", err)
+	}
+	head, _ := repo.Head()
+	if cached.Head != head.Hash().String() {
+		t.Errorf("cached head = %s, want %s", cached.Head, head.Hash())
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// maxImpactEntrypoints bounds how many survey entry points an impact query
+// considers.
+const maxImpactEntrypoints = 1000
+
+// cmdCodeImpact reports the symbols, entry points and tests a change may
+// affect.
+func cmdCodeImpact(dbPath string, args []string) error {
+	var specs []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			specs = append(specs, arg)
+		}
+	}
+	depth, err := parseIntFlag(args, "--depth=", survey.DefaultImpactDepth)
+	if err != nil {
+		return err
+	}
+	maxNodes, err := parseIntFlag(args, "--max-nodes=", 0)
+	if err != nil {
+		return err
+	}
+
+	var diff string
+	switch src := parseFlag(args, "--diff="); src {
+	case "":
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read diff: %w", err)
+		}
+		diff = string(data)
+	default:
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return fmt.Errorf("failed to read diff: %w", err)
+		}
+		diff = string(data)
+	}
+
+	root := store.ProjectRootFromDB(dbPath)
+	changes, err := impactChanges(root, diff, specs)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Println("No changes to analyse")
+		return nil
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	cg, cleanup, err := b.CodeGrapher()
+	if err != nil {
+		return fmt.Errorf("code index not available — run 'aide code index' first: %w", err)
+	}
+	defer cleanup()
+
+	// Entry points are optional: without a survey the report just has none.
+	entrypoints, _ := b.ListSurvey(survey.SearchOptions{Kind: survey.KindEntrypoint, Limit: maxImpactEntrypoints})
+
+	report, err := survey.AnalyzeImpact(cg, root, changes, entrypoints, survey.ImpactOptions{
+		MaxDepth: depth,
+		MaxNodes: maxNodes,
+		Exact:    hasFlag(args, "--exact"),
+	})
+	if err != nil {
+		return fmt.Errorf("impact analysis failed: %w", err)
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(report)
+	}
+	fmt.Print(formatImpactReport(report))
+	return nil
+}
+
+// impactChanges collects the changes to analyse, with paths relative to the
+// project root: those in diff and specs ("path[:start[-end]]"), or without
+// either, the working tree's uncommitted changes.
+func impactChanges(root, diff string, specs []string) ([]survey.Change, error) {
+	var changes []survey.Change
+	for _, spec := range specs {
+		c, err := survey.ParseChangeSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		if filepath.IsAbs(c.FilePath) {
+			if rel, err := filepath.Rel(resolvePath(root), resolvePath(c.FilePath)); err == nil {
+				c.FilePath = filepath.ToSlash(rel)
+			}
+		}
+		changes = append(changes, c)
+	}
+	if diff == "" && len(specs) > 0 {
+		return changes, nil
+	}
+
+	repo, err := survey.OpenGitRepo(root)
+	if err != nil {
+		return nil, err
+	}
+	var repoRoot string
+	if repo != nil {
+		if repoRoot, err = repo.Root(); err != nil {
+			return nil, err
+		}
+	}
+	var found []survey.Change
+	switch {
+	case diff != "":
+		found = survey.ParseUnifiedDiff(diff)
+	case repo == nil:
+		return nil, fmt.Errorf("no changes given and %s is not a git repository", root)
+	default:
+		if found, err = repo.WorktreeChanges(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Git paths are relative to the repository root, which may lie above
+	// the project; changes outside the project are dropped.
+	for _, c := range found {
+		if repoRoot != "" {
+			rel, err := filepath.Rel(resolvePath(root), filepath.Join(resolvePath(repoRoot), filepath.FromSlash(c.FilePath)))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			c.FilePath = filepath.ToSlash(rel)
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
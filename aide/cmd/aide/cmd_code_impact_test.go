@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// TestImpactChanges checks changes found through git are made relative to a
+// project nested inside the repository, and those outside it are dropped.
+func TestImpactChanges(t *testing.T) {
+	repoRoot := t.TempDir()
+	root := filepath.Join(repoRoot, "svc")
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(repoRoot, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	repo, err := git.PlainInit(repoRoot, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("svc/main.go", "package main\n\nfunc main() {}\n")
+	write("other.go", "package other\n")
+	if _, err := wt.Add("."); err != nil {
+		t.Fatal(err)
+	}
+	sig := &object.Signature{Name: "ann", Email: "ann@example.com", When: time.Now()}
+	if _, err := wt.Commit("init", &git.CommitOptions{Author: sig}); err != nil {
+		t.Fatal(err)
+	}
+	write("svc/main.go", "package main\n\nfunc main() {\n\tprintln()\n}\n")
+	write("other.go", "package other\n\nvar x = 1\n")
+
+	got, err := impactChanges(root, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []survey.Change{{FilePath: "main.go", Start: 3, End: 5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("worktree changes = %+v, want %+v", got, want)
+	}
+
+	diff := "--- a/svc/main.go\n+++ b/svc/main.go\n@@ -3 +3,3 @@\n-func main() {}\n+func main() {\n+\tprintln()\n+}\n"
+	got, err = impactChanges(root, diff, []string{"extra.go:7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []survey.Change{{FilePath: "extra.go", Start: 7, End: 7}, {FilePath: "main.go", Start: 3, End: 5}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff changes = %+v, want %+v", got, want)
+	}
+}
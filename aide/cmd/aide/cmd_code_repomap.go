@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// maxRepoMapEntries bounds how many survey entry points and modules a repo
+// map considers.
+const maxRepoMapEntries = 1000
+
+// repoMapEntries lists the survey entries a repo map uses: entry points and
+// module clusters. Both are optional, so errors leave them out.
+func repoMapEntries(list func(survey.SearchOptions) ([]*survey.Entry, error)) []*survey.Entry {
+	entries, _ := list(survey.SearchOptions{Kind: survey.KindEntrypoint, Limit: maxRepoMapEntries})
+	modules, _ := list(survey.SearchOptions{Analyzer: survey.AnalyzerModules, Limit: maxRepoMapEntries})
+	return append(entries, modules...)
+}
+
+// cmdCodeRepoMap prints a ranked, token-budgeted outline of the project's
+// most important symbols.
+func cmdCodeRepoMap(dbPath string, args []string) error {
+	tokens, err := parseIntFlag(args, "--tokens=", survey.DefaultRepoMapTokens)
+	if err != nil {
+		return err
+	}
+	var focus []string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			focus = append(focus, arg)
+		}
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	m, err := b.RepoMap(survey.RepoMapOptions{Tokens: tokens, Focus: focus})
+	if err != nil {
+		return err
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(m)
+	}
+	if m.TotalSymbols == 0 {
+		fmt.Println("No symbols indexed — run 'aide code index' first")
+		return nil
+	}
+	fmt.Print(m.Render())
+	return nil
+}
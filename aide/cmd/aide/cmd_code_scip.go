@@ -0,0 +1,240 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/internal/version"
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
+	"github.com/jmylchreest/aide/aide/pkg/scip"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// errSCIPGRPC is returned when a daemon holds the code index: SCIP import
+// and export read or rewrite the whole index, so they run against the store
+// directly.
+var errSCIPGRPC = fmt.Errorf("a daemon is currently holding the code index; stop the MCP server first, then re-run the command")
+
+// cmdCodeImportSCIP loads a SCIP index into the code store, replacing the
+// tree-sitter records of every file it covers.
+func cmdCodeImportSCIP(dbPath string, args []string) error {
+	var file string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			file = arg
+			break
+		}
+	}
+	if file == "" {
+		return fmt.Errorf("usage: aide code import-scip <file> [--prefix=DIR]")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read SCIP index: %w", err)
+	}
+	idx, err := scip.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+
+	cs, closeStore, err := openLocalCodeStore(dbPath)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	parser := code.NewParser(newGrammarLoader(dbPath, nil))
+	res, err := importSCIP(cs, parser, store.ProjectRootFromDB(dbPath), idx, parseFlag(args, "--prefix="))
+	if err != nil {
+		return err
+	}
+	tool := idx.Metadata.ToolName
+	if tool == "" {
+		tool = "unknown tool"
+	}
+	fmt.Printf("Imported %d files from %s: %d symbols, %d references (%d exact)\n",
+		res.Files, tool, res.Symbols, res.References, res.Exact)
+	if res.Skipped > 0 {
+		fmt.Printf("Skipped %d documents not found on disk\n", res.Skipped)
+	}
+	return nil
+}
+
+// cmdCodeExportSCIP writes the code index as a SCIP index.
+func cmdCodeExportSCIP(dbPath string, args []string) error {
+	out := parseFlag(args, "--output=")
+	if out == "" {
+		out = "index.scip"
+	}
+
+	cs, closeStore, err := openLocalCodeStore(dbPath)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	idx, err := exportSCIP(cs, store.ProjectRootFromDB(dbPath))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, scip.Marshal(idx), 0o644); err != nil {
+		return fmt.Errorf("failed to write SCIP index: %w", err)
+	}
+	fmt.Printf("Exported %d documents to %s\n", len(idx.Documents), out)
+	return nil
+}
+
+// openLocalCodeStore opens the code store directly, refusing when a daemon
+// owns it.
+func openLocalCodeStore(dbPath string) (store.CodeIndexStore, func(), error) {
+	backend, err := NewBackend(dbPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create backend: %w", err)
+	}
+	if backend.UsingGRPC() {
+		backend.Close()
+		return nil, nil, errSCIPGRPC
+	}
+	cs, err := backend.openCodeStore()
+	if err != nil {
+		backend.Close()
+		return nil, nil, fmt.Errorf("failed to open code store: %w", err)
+	}
+	return cs, func() { cs.Close(); backend.Close() }, nil
+}
+
+// scipImportResult summarises an import.
+type scipImportResult struct {
+	Files      int
+	Skipped    int
+	Symbols    int
+	References int
+	Exact      int
+}
+
+// importSCIP writes idx's documents into cs. Document paths are relative to
+// root joined with prefix (for indexes produced in a monorepo subproject).
+// Documents whose files are missing are skipped, as are documents with no
+// symbols or references aide tracks, which keep their tree-sitter records.
+// Import references and declared type edges still come from tree-sitter:
+// SCIP import occurrences name packages, not the import strings the
+// resolver matches on.
+func importSCIP(cs store.CodeIndexStore, parser *code.Parser, root string, idx *scip.Index, prefix string) (scipImportResult, error) {
+	var res scipImportResult
+	prefix = strings.Trim(filepath.ToSlash(prefix), "/")
+	for _, doc := range idx.Documents {
+		if prefix != "" {
+			doc.RelativePath = path.Join(prefix, doc.RelativePath)
+		}
+	}
+
+	var imported []string
+	for _, f := range scip.ToAide(idx, func(rel string) []string { return readSourceLines(root, rel) }) {
+		abs := filepath.Join(root, filepath.FromSlash(f.Path))
+		info, err := os.Stat(abs)
+		if err != nil || info.IsDir() {
+			res.Skipped++
+			continue
+		}
+		if len(f.Symbols) == 0 && len(f.Refs) == 0 {
+			continue
+		}
+
+		refs := f.Refs
+		if parsed, err := parser.ParseFileReferences(abs); err == nil {
+			for _, ref := range parsed {
+				if ref.Kind == code.RefKindImport {
+					refs = append(refs, ref)
+				}
+			}
+		}
+		edges := f.Edges
+		if parsed, err := parser.ParseFileHierarchy(abs); err == nil {
+			seen := make(map[string]bool, len(edges))
+			for _, e := range edges {
+				seen[e.TypeName+"\x00"+e.SuperName] = true
+			}
+			for _, e := range parsed {
+				if !seen[e.TypeName+"\x00"+e.SuperName] {
+					edges = append(edges, e)
+				}
+			}
+		}
+
+		if err := cs.IndexFileBatch(f.Path, f.Symbols, refs, edges, info.ModTime(), info.Size()); err != nil {
+			return res, fmt.Errorf("failed to index %s: %w", f.Path, err)
+		}
+		imported = append(imported, f.Path)
+		res.Files++
+		res.Symbols += len(f.Symbols)
+		res.References += len(f.Refs)
+		for _, ref := range f.Refs {
+			if ref.Confidence == code.ConfidenceExact {
+				res.Exact++
+			}
+		}
+	}
+
+	// Exact targets survive; everything else (import refs, references to
+	// symbols outside the index) is resolved as after a normal index.
+	if len(imported) > 0 {
+		if _, err := cs.ResolveReferenceTargets(imported, importresolve.New(root).ResolveTarget); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// exportSCIP builds a SCIP index from every indexed file.
+func exportSCIP(cs store.CodeIndexStore, root string) (*scip.Index, error) {
+	infos, err := cs.ListAllFileInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed files: %w", err)
+	}
+	files := make([]*scip.File, 0, len(infos))
+	for _, info := range infos {
+		syms, err := cs.GetFileSymbols(info.Path)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := cs.GetFileReferences(info.Path)
+		if err != nil {
+			return nil, err
+		}
+		edges, err := cs.GetFileTypeEdges(info.Path)
+		if err != nil {
+			return nil, err
+		}
+		f := &scip.File{Path: filepath.ToSlash(info.Path), Symbols: syms, Refs: refs, Edges: edges}
+		if len(syms) > 0 {
+			f.Language = syms[0].Language
+		} else {
+			f.Language = code.DetectLanguage(info.Path, nil)
+		}
+		files = append(files, f)
+	}
+
+	rootURI := (&url.URL{Scheme: "file", Path: filepath.ToSlash(root)}).String()
+	meta := scip.Metadata{
+		ToolName:     "aide",
+		ToolVersion:  version.Short(),
+		ProjectRoot:  rootURI,
+		TextEncoding: scip.TextEncodingUTF8,
+	}
+	return scip.FromAide(files, func(rel string) []string { return readSourceLines(root, rel) }, meta), nil
+}
+
+// readSourceLines returns a project file's lines, or nil if it cannot be
+// read.
+func readSourceLines(root, rel string) []string {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/scip"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// TestSCIPExportImport exports a tree-sitter index, re-imports it and checks
+// the imported records are precise and their exact targets survive
+// resolution.
+func TestSCIPExportImport(t *testing.T) {
+	root := t.TempDir()
+	aideDir := filepath.Join(root, ".aide", "memory")
+	if err := os.MkdirAll(aideDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(aideDir, "memory.db")
+	files := map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {}\n",
+		"util.go": "package main\n\nfunc helper() {\n\trun()\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	indexPath, searchPath := getCodeStorePaths(dbPath)
+	cs, err := store.NewCodeStore(indexPath, searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+	loader := newGrammarLoader(dbPath, nil)
+	if _, err := NewIndexerFromStore(cs, loader, root).Reconcile(); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	idx, err := exportSCIP(cs, root)
+	if err != nil {
+		t.Fatalf("exportSCIP: %v", err)
+	}
+	if len(idx.Documents) != 2 || idx.Metadata.ToolName != "aide" {
+		t.Fatalf("exported %d documents, metadata %+v", len(idx.Documents), idx.Metadata)
+	}
+	decoded, err := scip.Unmarshal(scip.Marshal(idx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded.Documents = append(decoded.Documents, &scip.Document{RelativePath: "gone.go"})
+
+	res, err := importSCIP(cs, code.NewParser(loader), root, decoded, "")
+	if err != nil {
+		t.Fatalf("importSCIP: %v", err)
+	}
+	if res.Files != 2 || res.Skipped != 1 || res.Exact != 2 {
+		t.Errorf("import result = %+v, want 2 files, 1 skipped, 2 exact references", res)
+	}
+
+	syms, err := cs.GetFileSymbols("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var run *code.Symbol
+	for _, s := range syms {
+		if !s.Precise {
+			t.Errorf("symbol %s not marked precise", s.Name)
+		}
+		if s.Name == "run" {
+			run = s
+		}
+	}
+	if run == nil {
+		t.Fatalf("run not imported: %+v", syms)
+	}
+	refs, err := cs.SearchReferences(code.ReferenceSearchOptions{SymbolName: "run", TargetSymbolID: run.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refs) != 2 {
+		t.Fatalf("references to run = %d, want 2", len(refs))
+	}
+	for _, r := range refs {
+		if r.Confidence != code.ConfidenceExact {
+			t.Errorf("reference %s:%d confidence = %q, want exact", r.FilePath, r.Line, r.Confidence)
+		}
+	}
+}
@@ -136,8 +136,7 @@ func cmdDaemon(dbPath string, args []string) error {
 	defer st.Close()
 
 	// Open code store for code indexing
-	indexPath, searchPath := getCodeStorePaths(dbPath)
-	codeStore, err := store.NewCodeStore(indexPath, searchPath)
+	codeStore, err := openIndexingCodeStore(dbPath)
 	if err != nil {
 		fmt.Printf("WARNING: failed to open code store: %v (code tools disabled)\n", err)
 	} else {
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/aideignore"
 	"github.com/jmylchreest/aide/aide/pkg/findings"
@@ -24,7 +26,11 @@ func cmdFindingsDispatcher(dbPath string, args []string) error {
 		{name: "search", handler: func(a []string) error { return cmdFindingsSearch(dbPath, a) }},
 		{name: "list", handler: func(a []string) error { return cmdFindingsList(dbPath, a) }},
 		{name: "stats", handler: func(a []string) error { return cmdFindingsStats(dbPath, a) }},
+		{name: "rules", handler: func(a []string) error { return cmdFindingsRules(dbPath, a) }},
+		{name: "advisories", handler: func(a []string) error { return cmdFindingsAdvisories(dbPath, a) }},
 		{name: "accept", handler: func(a []string) error { return cmdFindingsAccept(dbPath, a) }},
+		{name: "triage", handler: func(a []string) error { return cmdFindingsTriage(dbPath, a) }},
+		{name: "fixed", handler: func(a []string) error { return cmdFindingsFixed(dbPath, a) }},
 		{name: "clear", handler: func(a []string) error { return cmdFindingsClear(dbPath, a) }},
 	})
 }
@@ -40,12 +46,16 @@ Subcommands:
   search     Search findings by keyword
   list       List findings with optional filters
   stats      Show finding statistics
+  rules      List and validate custom rules from .aide/rules
+  advisories Show or import the offline OSV advisory database (vulns)
   accept     Mark findings as accepted/acknowledged
+  triage     Move a finding through the triage workflow, or show its history
+  fixed      Report findings marked fixed, grouped by session
   clear      Clear findings (all or by analyser)
 
 Options:
   run <analyser> [paths...]:
-    Analysers: complexity, coupling, secrets, clones, security, deadcode, todos, all
+    Analysers: complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license, all
     --threshold=N    Complexity threshold (default %d)
     --fan-out=N      Coupling fan-out threshold (default %d)
     --fan-in=N       Coupling fan-in threshold (default %d)
@@ -60,14 +70,40 @@ Options:
                         language pack (default skips them; exported symbols are
                         public API and can be referenced from outside the index)
     --no-validate       Secrets: skip live validation (default)
+    --show-suppressed   Keep findings silenced by inline aide:ignore comments,
+                        annotated with the suppression reason
+    --since=REF         Diff-scoped run: per-file analysers only visit files
+                        changed since the git ref (committed, staged, unstaged
+                        or untracked), and the report only lists findings that
+                        touch changed lines
+
+  Inline suppression:
+    Any analyser's finding can be silenced in source with a comment on the
+    finding's line or in the comment block directly above it:
+      // aide:ignore <analyser>[:<rule>] <reason>
+    <rule> narrows the match to a rule ID (security, secrets) or category
+    (coupling fan-out, todos fixme, ...). Directives that match nothing are
+    reported as info findings in the unused-suppression category.
+
+  Custom rules:
+    .aide/rules/*.json (or .yaml) files define project-specific checks run as
+    the 'custom' analyser, by the watcher and by 'run all':
+      {"rules": [{"id": "no-println", "pattern": "fmt\\.Println\\(",
+                  "severity": "info", "languages": ["go"],
+                  "message": "Use the logger instead of {{match}}"}]}
+    A rule has either a regex "pattern" (matched per line) or a tree-sitter
+    "query" (needs "languages"); "paths"/"exclude" take globs. {{name}} in the
+    message expands to a named capture, {{match}} to the matched text.
 
   search <query>:
-    --analyser=NAME     Filter by analyser (complexity, coupling, secrets, clones, security, deadcode, todos)
+    --analyser=NAME     Filter by analyser (complexity, coupling, secrets, clones, security, deadcode, todos, vulns, license)
     --severity=LEVEL    Filter by severity (critical, warning, info)
     --file=PATH         Filter by file path pattern (substring)
     --category=CAT      Filter by category
+    --state=STATE       Filter by triage state (open, acknowledged, snoozed, wont-fix, fixed)
     --limit=N           Max results (default %d, 0 for no limit)
-    --include-accepted  Include accepted findings (hidden by default)
+    --include-accepted  Include accepted, snoozed, wont-fix and fixed findings
+                        (hidden by default)
     --json              Output as JSON
 
   list:
@@ -75,12 +111,37 @@ Options:
     --severity=LEVEL    Filter by severity
     --file=PATH         Filter by file path pattern
     --category=CAT      Filter by category
+    --state=STATE       Filter by triage state (open, acknowledged, snoozed, wont-fix, fixed)
     --limit=N           Max results (default %d, 0 for no limit)
-    --include-accepted  Include accepted findings (hidden by default)
+    --include-accepted  Include accepted, snoozed, wont-fix and fixed findings
+                        (hidden by default)
     --json              Output as JSON
 
   stats:
-    --include-accepted  Include accepted findings in counts
+    --state=STATE       Only count findings in this triage state
+    --include-accepted  Include accepted, snoozed, wont-fix and fixed findings in counts
+    Custom analyser findings are also broken down per rule.
+
+  rules:
+    --json              Output as JSON
+
+  advisories [import <osv.zip>...]:
+    The vulns analyser matches the versions recorded by the dependencies
+    survey analyser against OSV advisories in .aide/cache/advisories/,
+    without network access. 'import' extracts an OSV export zip (e.g.
+    https://osv-vulnerabilities.storage.googleapis.com/Go/all.zip) into a
+    per-ecosystem subdirectory, replacing the previous import. Without
+    arguments, lists the imported advisories. 'run all' includes vulns only
+    when advisories have been imported. For Go, the code index narrows
+    findings to vulnerable symbols the project references.
+
+  run license:
+    Evaluates the license files recorded by the licenses survey analyser
+    against the "findings.licenses" policy in .aide/config/aide.json
+    ({"allow": [...], "deny": [...]} of SPDX ids, globs like "GPL-*" or
+    categories like "strong-copyleft"). Without a policy, licenses more
+    restrictive than both the project's own license and weak copyleft are
+    denied. 'run all' includes license only when licenses have been surveyed.
 
   accept [IDs...]:
     Accept (acknowledge) findings so they are hidden from list/search/stats.
@@ -90,6 +151,26 @@ Options:
     --file=PATH         Accept findings matching this file path
     --category=CAT      Accept findings matching this category
 
+  triage <ID|fingerprint>:
+    Triage state is keyed by the finding's fingerprint, so it survives re-runs.
+    Transitions: open -> acknowledged/snoozed/wont-fix/fixed; acknowledged and
+    snoozed may move anywhere; wont-fix and fixed can only be reopened.
+    --state=STATE       New state: open, acknowledged, snoozed, wont-fix, fixed
+    --reason=TEXT       Why the state changed
+    --actor=NAME        Who made the change (default $USER)
+    --assignee=NAME     Who is handling the finding
+    --until=WHEN        Snooze expiry: a duration (7d, 12h) or date (2006-01-02)
+    --history           Show the fingerprint's triage history instead
+    --json              Output as JSON
+
+  fixed:
+    Findings the watcher stopped reporting are marked fixed automatically,
+    stamped with HEAD and the active session; fixed findings that reappear are
+    reopened. Manual 'triage --state=fixed' entries are included too.
+    --since=DUR         Look-back window (default 7d)
+    --session=ID        Only fixes attributed to this session
+    --json              Output as JSON
+
   clear [--analyser=NAME]:
     Clears all findings, or only findings for the specified analyser.
 
@@ -99,12 +180,22 @@ Examples:
   aide findings run complexity .
   aide findings run all src/
   aide findings run secrets --no-validate .
+  aide findings run all --since=main
+  aide findings run custom
+  aide findings advisories import ~/Downloads/all.zip
+  aide findings run vulns
+  aide findings run license
+  aide findings rules
   aide findings stats
   aide findings list --analyser=complexity --severity=critical
   aide findings search "cyclomatic"
   aide findings list --file=src/auth
   aide findings accept ABCDEF123456 GHIJKL789012
   aide findings accept --all --analyser=complexity
+  aide findings triage ABCDEF123456 --state=snoozed --until=14d --reason="after release"
+  aide findings triage ABCDEF123456 --history
+  aide findings list --state=acknowledged
+  aide findings fixed --since=7d
   aide findings clear --analyser=secrets
 `, findings.DefaultComplexityThreshold, findings.DefaultFanOutThreshold, findings.DefaultFanInThreshold,
 		clone.DefaultWindowSize, clone.DefaultMinCloneLines, clone.DefaultMinMatchCount,
@@ -123,6 +214,8 @@ type findingsRunOpts struct {
 	minSimilarity   float64
 	minSeverity     string
 	includeExported bool
+	showSuppressed  bool
+	since           string
 }
 
 func parseFindingsRunOpts(subargs []string, cfg findingsConfig) (findingsRunOpts, error) {
@@ -157,6 +250,8 @@ func parseFindingsRunOpts(subargs []string, cfg findingsConfig) (findingsRunOpts
 		return o, err
 	}
 	o.includeExported = hasFlag(subargs, "--include-exported")
+	o.showSuppressed = hasFlag(subargs, "--show-suppressed")
+	o.since = parseFlag(subargs, "--since=")
 	return o, nil
 }
 
@@ -201,6 +296,10 @@ func cmdFindingsRun(dbPath string, args []string) error {
 			findings.AnalyzerSecurity,
 			findings.AnalyzerDeadCode,
 			findings.AnalyzerTodos,
+			findings.AnalyzerCustom,
+		}
+		if _, err := os.Stat(findings.AdvisoriesPath(projectRoot)); err == nil {
+			analyzers = append(analyzers, findings.AnalyzerVulns)
 		}
 	}
 
@@ -210,6 +309,9 @@ func cmdFindingsRun(dbPath string, args []string) error {
 		return fmt.Errorf("failed to create backend: %w", err)
 	}
 	defer backend.Close()
+	if analyzerName == "all" && hasSurveyedLicenses(backend) {
+		analyzers = append(analyzers, findings.AnalyzerLicense)
+	}
 
 	// Load .aideignore from project root.
 	ignore, err := aideignore.New(projectRoot)
@@ -220,69 +322,209 @@ func cmdFindingsRun(dbPath string, args []string) error {
 	// Create a properly-configured grammar loader for analysers that need tree-sitter.
 	loader := newGrammarLoader(dbPath, nil)
 
+	// --since narrows the run to files changed relative to a git ref.
+	sink := &findingsSink{backend: backend}
+	filePaths := paths
+	if opts.since != "" {
+		scope, err := findings.ComputeDiffScope(projectRoot, opts.since, ignore)
+		if err != nil {
+			return fmt.Errorf("failed to compute diff since %s: %w", opts.since, err)
+		}
+		scope = scope.Under(paths)
+		fmt.Printf("Diff scope: %d changed, %d deleted files since %s (%.12s)\n",
+			len(scope.Files), len(scope.Deleted), scope.Ref, scope.Commit)
+		if scope.Empty() {
+			fmt.Println("No changes to analyse")
+			return nil
+		}
+		sink.scope = scope
+		filePaths = scope.Paths()
+	}
+
+	// Inline aide:ignore directives are collected once and shared by every
+	// analyser in this run.
+	sup := findings.NewSuppressor(findings.SuppressionConfig{
+		ProjectRoot:    projectRoot,
+		ShowSuppressed: opts.showSuppressed,
+	})
+	if err := sup.Walk(paths, ignore); err != nil {
+		return fmt.Errorf("failed to scan suppressions: %w", err)
+	}
+
 	totalFindings := 0
 
 	for _, name := range analyzers {
+		// Only deletions in scope: per-file analysers just clear the removed
+		// files instead of walking an empty path list (which means ".").
+		if sink.scope != nil && len(filePaths) == 0 && findings.IsPerFileAnalyzer(name) {
+			if _, err := sink.store(name, nil); err != nil {
+				return fmt.Errorf("%s analyser failed: %w", name, err)
+			}
+			continue
+		}
+
 		switch name {
 		case findings.AnalyzerComplexity:
-			n, err := runComplexityAnalyzer(backend, paths, opts.threshold, ignore, loader, projectRoot)
+			n, err := runComplexityAnalyzer(sink, sup, filePaths, opts.threshold, ignore, loader, projectRoot)
 			if err != nil {
 				return fmt.Errorf("complexity analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerCoupling:
-			n, err := runCouplingAnalyzer(backend, paths, opts.fanOut, opts.fanIn, ignore, projectRoot)
+			n, err := runCouplingAnalyzer(sink, sup, paths, opts.fanOut, opts.fanIn, ignore, projectRoot)
 			if err != nil {
 				return fmt.Errorf("coupling analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerSecrets:
-			n, err := runSecretsAnalyzer(backend, paths, ignore)
+			n, err := runSecretsAnalyzer(sink, sup, filePaths, ignore)
 			if err != nil {
 				return fmt.Errorf("secrets analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerClones:
-			n, err := runClonesAnalyzer(backend, paths, opts.windowSize, opts.minCloneLines, opts.minMatchCount, opts.maxBucket, opts.minSimilarity, opts.minSeverity, ignore, loader)
+			n, err := runClonesAnalyzer(sink, sup, paths, opts.windowSize, opts.minCloneLines, opts.minMatchCount, opts.maxBucket, opts.minSimilarity, opts.minSeverity, ignore, loader)
 			if err != nil {
 				return fmt.Errorf("clones analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerSecurity:
-			n, err := runSecurityAnalyzer(backend, paths, ignore, projectRoot)
+			n, err := runSecurityAnalyzer(sink, sup, filePaths, ignore, projectRoot)
 			if err != nil {
 				return fmt.Errorf("security analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerDeadCode:
-			n, err := runDeadCodeAnalyzer(backend, opts.includeExported)
+			n, err := runDeadCodeAnalyzer(sink, opts.includeExported, opts.showSuppressed)
 			if err != nil {
 				return fmt.Errorf("deadcode analyser failed: %w", err)
 			}
 			totalFindings += n
 
 		case findings.AnalyzerTodos:
-			n, err := runTodosAnalyzer(backend, paths, ignore, projectRoot)
+			n, err := runTodosAnalyzer(sink, sup, filePaths, ignore, projectRoot)
 			if err != nil {
 				return fmt.Errorf("todos analyser failed: %w", err)
 			}
 			totalFindings += n
 
+		case findings.AnalyzerCustom:
+			n, err := runCustomAnalyzer(sink, sup, filePaths, ignore, loader, projectRoot)
+			if err != nil {
+				return fmt.Errorf("custom analyser failed: %w", err)
+			}
+			totalFindings += n
+
+		case findings.AnalyzerVulns:
+			n, err := runVulnsAnalyzer(sink, sup, projectRoot)
+			if err != nil {
+				return fmt.Errorf("vulns analyser failed: %w", err)
+			}
+			totalFindings += n
+
+		case findings.AnalyzerLicense:
+			n, err := runLicenseAnalyzer(sink, sup, cfg.Licenses)
+			if err != nil {
+				return fmt.Errorf("license analyser failed: %w", err)
+			}
+			totalFindings += n
+
 		default:
-			return fmt.Errorf("unknown analyser: %s (valid: complexity, coupling, secrets, clones, security, deadcode, todos, all)", name)
+			return fmt.Errorf("unknown analyser: %s (valid: complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license, all)", name)
+		}
+	}
+
+	if sink.scope != nil {
+		fmt.Printf("\nTotal: %d findings touching changes since %s\n", totalFindings, sink.scope.Ref)
+		for _, f := range sink.inDiff {
+			printFindingLine(f)
 		}
+		return nil
 	}
 
 	fmt.Printf("\nTotal: %d findings stored\n", totalFindings)
 	return nil
 }
 
-func runComplexityAnalyzer(backend *Backend, paths []string, threshold int, ignore *aideignore.Matcher, loader grammar.Loader, root string) (int, error) {
+// findingsSink stores one analyser's output. In a diff-scoped run (scope set)
+// per-file analysers only replace findings for the changed and deleted files,
+// project-wide analysers still replace their whole result set, and the
+// returned count covers only findings that touch a changed hunk.
+type findingsSink struct {
+	backend *Backend
+	scope   *findings.DiffScope
+	inDiff  []*findings.Finding
+}
+
+func (s *findingsSink) store(analyzer string, ff []*findings.Finding) (int, error) {
+	if s.scope == nil {
+		if err := s.backend.ReplaceFindingsForAnalyzer(analyzer, ff); err != nil {
+			return 0, fmt.Errorf("failed to store findings: %w", err)
+		}
+		return len(ff), nil
+	}
+
+	if !findings.IsPerFileAnalyzer(analyzer) {
+		if err := s.backend.ReplaceFindingsForAnalyzer(analyzer, ff); err != nil {
+			return 0, fmt.Errorf("failed to store findings: %w", err)
+		}
+		return s.collect(ff), nil
+	}
+
+	byFile := make(map[string][]*findings.Finding)
+	for _, f := range ff {
+		if s.scope.HasFile(f.FilePath) {
+			byFile[f.FilePath] = append(byFile[f.FilePath], f)
+		}
+	}
+	for rel := range s.scope.Files {
+		if _, ok := byFile[rel]; !ok {
+			byFile[rel] = nil // clear findings fixed by the change
+		}
+	}
+	for _, rel := range s.scope.Deleted {
+		byFile[rel] = nil
+	}
+	var kept []*findings.Finding
+	for file, fileFindings := range byFile {
+		if err := s.backend.ReplaceFindingsForAnalyzerAndFile(analyzer, file, fileFindings); err != nil {
+			return 0, fmt.Errorf("failed to store findings for %s: %w", file, err)
+		}
+		kept = append(kept, fileFindings...)
+	}
+	return s.collect(kept), nil
+}
+
+// stored reports an analyser that persisted its own findings (deadcode runs
+// through the backend). In a diff-scoped run the stored set is read back and
+// narrowed to the diff.
+func (s *findingsSink) stored(analyzer string, count int) (int, error) {
+	if s.scope == nil {
+		return count, nil
+	}
+	ff, err := s.backend.ListFindings(findings.SearchOptions{Analyzer: analyzer, Limit: -1})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read back %s findings: %w", analyzer, err)
+	}
+	return s.collect(ff), nil
+}
+
+// collect records the findings that touch the diff and returns their count.
+func (s *findingsSink) collect(ff []*findings.Finding) int {
+	touching := s.scope.Filter(ff)
+	if len(touching) > 0 {
+		fmt.Printf("  %d of %d findings touch changed lines\n", len(touching), len(ff))
+	}
+	s.inDiff = append(s.inDiff, touching...)
+	return len(touching)
+}
+
+func runComplexityAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, threshold int, ignore *aideignore.Matcher, loader grammar.Loader, root string) (int, error) {
 	fmt.Printf("Running complexity analyser (threshold=%d)...\n", threshold)
 
 	cfg := findings.ComplexityConfig{
@@ -306,14 +548,12 @@ func runComplexityAnalyzer(backend *Backend, paths []string, threshold int, igno
 	fmt.Printf("  Analysed %d files, skipped %d, found %d issues (%s)\n",
 		result.FilesAnalyzed, result.FilesSkipped, result.FindingsCount, result.Duration.Round(1_000_000))
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerComplexity, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
-	}
+	ff = suppressFindings(sup, findings.AnalyzerComplexity, ff)
 
-	return len(ff), nil
+	return sink.store(findings.AnalyzerComplexity, ff)
 }
 
-func runCouplingAnalyzer(backend *Backend, paths []string, fanOut, fanIn int, ignore *aideignore.Matcher, root string) (int, error) {
+func runCouplingAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, fanOut, fanIn int, ignore *aideignore.Matcher, root string) (int, error) {
 	fmt.Printf("Running coupling analyser (fan-out=%d, fan-in=%d)...\n", fanOut, fanIn)
 
 	cfg := findings.CouplingConfig{
@@ -332,14 +572,12 @@ func runCouplingAnalyzer(backend *Backend, paths []string, fanOut, fanIn int, ig
 	fmt.Printf("  Analysed %d files, found %d issues, %d cycles (%s)\n",
 		result.FilesAnalyzed, result.FindingsCount, result.CyclesFound, result.Duration.Round(1_000_000))
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerCoupling, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
-	}
+	ff = suppressFindings(sup, findings.AnalyzerCoupling, ff)
 
-	return len(ff), nil
+	return sink.store(findings.AnalyzerCoupling, ff)
 }
 
-func runSecretsAnalyzer(backend *Backend, paths []string, ignore *aideignore.Matcher) (int, error) {
+func runSecretsAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, ignore *aideignore.Matcher) (int, error) {
 	fmt.Printf("Running secrets analyser...\n")
 
 	cfg := findings.SecretsConfig{
@@ -356,14 +594,12 @@ func runSecretsAnalyzer(backend *Backend, paths []string, ignore *aideignore.Mat
 	fmt.Printf("  Scanned %d files (skipped %d), %d rules, found %d secrets (%s)\n",
 		result.FilesScanned, result.FilesSkipped, result.RulesLoaded, result.FindingsCount, result.Duration.Round(1_000_000))
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerSecrets, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
-	}
+	ff = suppressFindings(sup, findings.AnalyzerSecrets, ff)
 
-	return len(ff), nil
+	return sink.store(findings.AnalyzerSecrets, ff)
 }
 
-func runTodosAnalyzer(backend *Backend, paths []string, ignore *aideignore.Matcher, root string) (int, error) {
+func runTodosAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, ignore *aideignore.Matcher, root string) (int, error) {
 	fmt.Printf("Running todos analyser...\n")
 
 	cfg := findings.TodosConfig{
@@ -380,14 +616,12 @@ func runTodosAnalyzer(backend *Backend, paths []string, ignore *aideignore.Match
 	fmt.Printf("  Analysed %d files (skipped %d), found %d todos (%s)\n",
 		result.FilesAnalyzed, result.FilesSkipped, result.FindingsCount, result.Duration.Round(1_000_000))
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerTodos, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
-	}
+	ff = suppressFindings(sup, findings.AnalyzerTodos, ff)
 
-	return len(ff), nil
+	return sink.store(findings.AnalyzerTodos, ff)
 }
 
-func runSecurityAnalyzer(backend *Backend, paths []string, ignore *aideignore.Matcher, root string) (int, error) {
+func runSecurityAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, ignore *aideignore.Matcher, root string) (int, error) {
 	fmt.Printf("Running security analyser...\n")
 
 	cfg := findings.SecurityConfig{
@@ -409,14 +643,49 @@ func runSecurityAnalyzer(backend *Backend, paths []string, ignore *aideignore.Ma
 	fmt.Printf("  Analysed %d files (skipped %d), found %d issues (%s)\n",
 		result.FilesAnalyzed, result.FilesSkipped, result.FindingsCount, result.Duration.Round(1_000_000))
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerSecurity, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
+	ff = suppressFindings(sup, findings.AnalyzerSecurity, ff)
+
+	return sink.store(findings.AnalyzerSecurity, ff)
+}
+
+func runCustomAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, ignore *aideignore.Matcher, loader grammar.Loader, root string) (int, error) {
+	rules, err := findings.LoadCustomRules(filepath.Join(root, findings.CustomRulesDir))
+	if err != nil {
+		return 0, err
 	}
+	defer rules.Close()
+	fmt.Printf("Running custom analyser (%d rules)...\n", rules.Len())
 
-	return len(ff), nil
+	cfg := findings.CustomConfig{
+		Paths:       paths,
+		ProjectRoot: root,
+		Rules:       rules,
+		Loader:      loader,
+		Ignore:      ignore,
+		ProgressFn: func(path string, count int) {
+			if count > 0 {
+				fmt.Printf("  %s: %d findings\n", path, count)
+			}
+		},
+	}
+
+	ff, result, err := findings.AnalyzeCustom(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("  Analysed %d files (skipped %d), found %d issues (%s)\n",
+		result.FilesAnalyzed, result.FilesSkipped, result.FindingsCount, result.Duration.Round(1_000_000))
+	for _, id := range findings.SortedRuleCounts(result.ByRule) {
+		fmt.Printf("    %-24s %d\n", id, result.ByRule[id])
+	}
+
+	ff = suppressFindings(sup, findings.AnalyzerCustom, ff)
+
+	return sink.store(findings.AnalyzerCustom, ff)
 }
 
-func runClonesAnalyzer(backend *Backend, paths []string, windowSize, minLines, minMatchCount, maxBucket int, minSimilarity float64, minSeverity string, ignore *aideignore.Matcher, loader grammar.Loader) (int, error) {
+func runClonesAnalyzer(sink *findingsSink, sup *findings.Suppressor, paths []string, windowSize, minLines, minMatchCount, maxBucket int, minSimilarity float64, minSeverity string, ignore *aideignore.Matcher, loader grammar.Loader) (int, error) {
 	// Show effective values (clone.Config.defaults() resolves zero → default).
 	effWindow, effMinLines := windowSize, minLines
 	if effWindow <= 0 {
@@ -464,11 +733,9 @@ func runClonesAnalyzer(backend *Backend, paths []string, windowSize, minLines, m
 			result.BucketsSkipped, result.CollisionsFiltered)
 	}
 
-	if err := backend.ReplaceFindingsForAnalyzer(findings.AnalyzerClones, ff); err != nil {
-		return 0, fmt.Errorf("failed to store findings: %w", err)
-	}
+	ff = suppressFindings(sup, findings.AnalyzerClones, ff)
 
-	return len(ff), nil
+	return sink.store(findings.AnalyzerClones, ff)
 }
 
 func cmdFindingsSearch(dbPath string, args []string) error {
@@ -497,6 +764,10 @@ func cmdFindingsSearch(dbPath string, args []string) error {
 	if err != nil {
 		return err
 	}
+	state, err := parseTriageStateFlag(args)
+	if err != nil {
+		return err
+	}
 	jsonOutput := hasFlag(args, "--json")
 	includeAccepted := hasFlag(args, "--include-accepted")
 
@@ -519,6 +790,7 @@ func cmdFindingsSearch(dbPath string, args []string) error {
 		Severity:        severity,
 		FilePath:        filePath,
 		Category:        category,
+		State:           state,
 		Limit:           storeLimit,
 		IncludeAccepted: includeAccepted,
 	}
@@ -567,6 +839,10 @@ func cmdFindingsList(dbPath string, args []string) error {
 	if err != nil {
 		return err
 	}
+	state, err := parseTriageStateFlag(args)
+	if err != nil {
+		return err
+	}
 	jsonOutput := hasFlag(args, "--json")
 	includeAccepted := hasFlag(args, "--include-accepted")
 
@@ -589,6 +865,7 @@ func cmdFindingsList(dbPath string, args []string) error {
 		Severity:        severity,
 		FilePath:        filePath,
 		Category:        category,
+		State:           state,
 		Limit:           storeLimit,
 		IncludeAccepted: includeAccepted,
 	}
@@ -626,6 +903,10 @@ func cmdFindingsList(dbPath string, args []string) error {
 
 func cmdFindingsStats(dbPath string, args []string) error {
 	includeAccepted := hasFlag(args, "--include-accepted")
+	state, err := parseTriageStateFlag(args)
+	if err != nil {
+		return err
+	}
 
 	backend, err := NewBackend(dbPath)
 	if err != nil {
@@ -633,7 +914,7 @@ func cmdFindingsStats(dbPath string, args []string) error {
 	}
 	defer backend.Close()
 
-	stats, err := backend.GetFindingsStats(findings.SearchOptions{IncludeAccepted: includeAccepted})
+	stats, err := backend.GetFindingsStats(findings.SearchOptions{State: state, IncludeAccepted: includeAccepted})
 	if err != nil {
 		return fmt.Errorf("failed to get stats: %w", err)
 	}
@@ -656,6 +937,62 @@ func cmdFindingsStats(dbPath string, args []string) error {
 		}
 	}
 
+	if byRule := customRuleCounts(store.ProjectRootFromDB(dbPath), stats); len(byRule) > 0 {
+		fmt.Printf("\n  By custom rule:\n")
+		for _, id := range findings.SortedRuleCounts(byRule) {
+			fmt.Printf("    %-24s %d\n", id, byRule[id])
+		}
+	}
+
+	return nil
+}
+
+// customRuleCounts merges the stored per-rule counts with the rules currently
+// defined in .aide/rules, so rules that match nothing show up with zero.
+func customRuleCounts(projectRoot string, stats *findings.Stats) map[string]int {
+	byRule := make(map[string]int, len(stats.ByRule))
+	for id, n := range stats.ByRule {
+		byRule[id] = n
+	}
+	if rules, err := findings.LoadCustomRules(filepath.Join(projectRoot, findings.CustomRulesDir)); err == nil {
+		for _, r := range rules.Rules() {
+			if _, ok := byRule[r.ID]; !ok {
+				byRule[r.ID] = 0
+			}
+		}
+	}
+	return byRule
+}
+
+// cmdFindingsRules lists the custom rules in .aide/rules, failing with the
+// first validation error so rule files can be checked before a run.
+func cmdFindingsRules(dbPath string, args []string) error {
+	dir := filepath.Join(store.ProjectRootFromDB(dbPath), findings.CustomRulesDir)
+	rules, err := findings.LoadCustomRules(dir)
+	if err != nil {
+		return err
+	}
+	defer rules.Close()
+
+	if hasFlag(args, "--json") {
+		return printJSON(rules.Rules())
+	}
+	if rules.Len() == 0 {
+		fmt.Printf("No custom rules in %s\n", dir)
+		return nil
+	}
+	fmt.Printf("%d custom rules in %s:\n\n", rules.Len(), dir)
+	for _, r := range rules.Rules() {
+		kind := "pattern"
+		if r.Query != "" {
+			kind = "query"
+		}
+		langs := "all"
+		if len(r.Languages) > 0 {
+			langs = strings.Join(r.Languages, ",")
+		}
+		fmt.Printf("  %-24s %-8s %-7s %-12s %s\n", r.ID, r.Severity, kind, langs, filepath.Base(r.Source))
+	}
 	return nil
 }
 
@@ -716,6 +1053,222 @@ func cmdFindingsAccept(dbPath string, args []string) error {
 	return nil
 }
 
+func cmdFindingsTriage(dbPath string, args []string) error {
+	var ref string
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "--") {
+			ref = arg
+			break
+		}
+	}
+	stateFlag := parseFlag(args, "--state=")
+	showHistory := hasFlag(args, "--history")
+	if ref == "" || (stateFlag == "" && !showHistory) {
+		return fmt.Errorf("usage: aide findings triage <ID|fingerprint> --state=STATE [--reason=TEXT] [--actor=NAME] [--assignee=NAME] [--until=7d] | --history")
+	}
+	jsonOutput := hasFlag(args, "--json")
+
+	backend, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer backend.Close()
+
+	if showHistory {
+		fp, history, err := backend.TriageHistory(ref)
+		if err != nil {
+			return err
+		}
+		if jsonOutput {
+			return printJSON(history)
+		}
+		if len(history) == 0 {
+			fmt.Printf("No triage history for %s (state: open)\n", fp)
+			return nil
+		}
+		fmt.Printf("Triage history for %s:\n\n", fp)
+		for _, ev := range history {
+			printTriageEvent(ev)
+		}
+		return nil
+	}
+
+	to, err := findings.ParseTriageState(stateFlag)
+	if err != nil {
+		return err
+	}
+	until, err := parseTriageUntil(parseFlag(args, "--until="), time.Now())
+	if err != nil {
+		return err
+	}
+	actor := parseFlag(args, "--actor=")
+	if actor == "" {
+		actor = os.Getenv("USER")
+	}
+
+	ev := &findings.TriageEvent{
+		To:       to,
+		Reason:   parseFlag(args, "--reason="),
+		Actor:    actor,
+		Assignee: parseFlag(args, "--assignee="),
+		Until:    until,
+	}
+	st, f, err := backend.TriageFinding(ref, ev)
+	if err != nil {
+		return fmt.Errorf("triage failed: %w", err)
+	}
+	if jsonOutput {
+		return printJSON(st)
+	}
+
+	target := st.Fingerprint
+	if f != nil {
+		target = fmt.Sprintf("%s (%s)", f.Title, st.Fingerprint)
+	}
+	fmt.Printf("%s: %s -> %s\n", target, ev.From, st.State)
+	if !st.Until.IsZero() {
+		fmt.Printf("  until %s\n", st.Until.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func cmdFindingsFixed(dbPath string, args []string) error {
+	sinceStr := parseFlag(args, "--since=")
+	if sinceStr == "" {
+		sinceStr = "7d"
+	}
+	window, err := parseDurationDays(sinceStr)
+	if err != nil {
+		return fmt.Errorf("invalid --since %q: %w", sinceStr, err)
+	}
+	session := parseFlag(args, "--session=")
+	jsonOutput := hasFlag(args, "--json")
+
+	backend, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer backend.Close()
+
+	events, err := backend.ListTriageEvents(time.Now().Add(-window), findings.TriageFixed)
+	if err != nil {
+		return err
+	}
+	if session != "" {
+		filtered := events[:0]
+		for _, ev := range events {
+			if ev.SessionID == session {
+				filtered = append(filtered, ev)
+			}
+		}
+		events = filtered
+	}
+
+	if jsonOutput {
+		return printJSON(events)
+	}
+	if len(events) == 0 {
+		fmt.Printf("No findings fixed in the last %s\n", sinceStr)
+		return nil
+	}
+
+	// Group by session, preserving first-seen order.
+	var order []string
+	bySession := make(map[string][]*findings.TriageEvent)
+	for _, ev := range events {
+		key := ev.SessionID
+		if _, ok := bySession[key]; !ok {
+			order = append(order, key)
+		}
+		bySession[key] = append(bySession[key], ev)
+	}
+
+	fmt.Printf("%d findings fixed in the last %s:\n", len(events), sinceStr)
+	for _, key := range order {
+		label := key
+		if label == "" {
+			label = "(no session)"
+		}
+		group := bySession[key]
+		fmt.Printf("\nSession %s (%d):\n", label, len(group))
+		for _, ev := range group {
+			printFixedEvent(ev)
+		}
+	}
+	return nil
+}
+
+func printFixedEvent(ev *findings.TriageEvent) {
+	loc := padString(ev.FilePath, 40)
+	line := fmt.Sprintf("  %s  %s %s (%s)", ev.CreatedAt.Local().Format("2006-01-02 15:04"), loc, ev.Title, ev.Analyzer)
+	if ev.Commit != "" {
+		line += " @" + shortCommit(ev.Commit)
+	}
+	if ev.Assignee != "" {
+		line += " assignee=" + ev.Assignee
+	}
+	if ev.Actor != "" && ev.Actor != findings.FixDetectorActor {
+		line += " by " + ev.Actor
+	}
+	fmt.Println(line)
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// parseTriageStateFlag reads and validates --state= for list/search/stats.
+func parseTriageStateFlag(args []string) (string, error) {
+	v := parseFlag(args, "--state=")
+	if v == "" {
+		return "", nil
+	}
+	st, err := findings.ParseTriageState(v)
+	if err != nil {
+		return "", err
+	}
+	return string(st), nil
+}
+
+// parseTriageUntil parses a snooze expiry given as a duration relative to now
+// (7d, 12h) or an absolute date (2006-01-02 or RFC 3339).
+func parseTriageUntil(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := parseDurationDays(s); err == nil {
+		return now.Add(d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --until %q: use a duration (7d, 12h) or a date (2006-01-02)", s)
+}
+
+func printTriageEvent(ev *findings.TriageEvent) {
+	line := fmt.Sprintf("  %s  %-12s -> %-12s", ev.CreatedAt.Local().Format("2006-01-02 15:04"), ev.From, ev.To)
+	if ev.Actor != "" {
+		line += " by " + ev.Actor
+	}
+	if ev.Assignee != "" {
+		line += " (assignee: " + ev.Assignee + ")"
+	}
+	if !ev.Until.IsZero() {
+		line += " until " + ev.Until.Local().Format("2006-01-02 15:04")
+	}
+	if ev.Reason != "" {
+		line += ": " + ev.Reason
+	}
+	fmt.Println(line)
+}
+
 func cmdFindingsClear(dbPath string, args []string) error {
 	analyzer := parseFlag(args, "--analyzer=")
 
@@ -741,7 +1294,7 @@ func cmdFindingsClear(dbPath string, args []string) error {
 	return nil
 }
 
-func runDeadCodeAnalyzer(backend *Backend, includeExported bool) (int, error) {
+func runDeadCodeAnalyzer(sink *findingsSink, includeExported, showSuppressed bool) (int, error) {
 	if includeExported {
 		fmt.Printf("Running dead code analyser (including exported symbols)...\n")
 	} else {
@@ -750,12 +1303,13 @@ func runDeadCodeAnalyzer(backend *Backend, includeExported bool) (int, error) {
 
 	opts := DeadCodeAnalysisOptions{
 		IncludeExported: includeExported,
+		ShowSuppressed:  showSuppressed,
 		Progress: func(checked, found int) {
 			fmt.Printf("  checked %d symbols, %d unreferenced so far\n", checked, found)
 		},
 	}
 
-	result, err := backend.RunDeadCodeAnalysis(opts)
+	result, err := sink.backend.RunDeadCodeAnalysis(opts)
 	if err != nil {
 		return 0, err
 	}
@@ -763,7 +1317,18 @@ func runDeadCodeAnalyzer(backend *Backend, includeExported bool) (int, error) {
 	fmt.Printf("  Checked %d symbols (skipped %d), found %d unreferenced (%dms)\n",
 		result.SymbolsChecked, result.SymbolsSkipped, result.FindingsCount, result.DurationMs)
 
-	return result.FindingsCount, nil
+	return sink.stored(findings.AnalyzerDeadCode, result.FindingsCount)
+}
+
+// suppressFindings applies inline aide:ignore directives to one analyser's
+// output and prints a summary line when anything was filtered.
+func suppressFindings(sup *findings.Suppressor, analyzer string, ff []*findings.Finding) []*findings.Finding {
+	out, res := sup.Apply(analyzer, ff)
+	if res.Suppressed > 0 || res.Unused > 0 {
+		fmt.Printf("  Suppressed %d findings via %s (%d unused suppressions)\n",
+			res.Suppressed, findings.SuppressionDirective, res.Unused)
+	}
+	return out
 }
 
 // printFindingLine prints a human-readable single-line summary of a finding.
@@ -775,5 +1340,16 @@ func printFindingLine(f *findings.Finding) {
 	}
 	sevPad := padString(fmt.Sprintf("[%s]", sev), 12)
 	locPad := padString(loc, 40)
-	fmt.Printf("  %s %s %s (%s)\n", sevPad, locPad, f.Title, f.Analyzer)
+	fmt.Printf("  %s %s %s (%s)%s\n", sevPad, locPad, f.Title, f.Analyzer, suppressedSuffix(f))
+}
+
+// suppressedSuffix annotates findings kept with --show-suppressed.
+func suppressedSuffix(f *findings.Finding) string {
+	if f.Metadata[findings.MetaSuppressed] != "true" {
+		return ""
+	}
+	if reason := f.Metadata[findings.MetaSuppressionReason]; reason != "" {
+		return fmt.Sprintf(" [suppressed: %s]", reason)
+	}
+	return " [suppressed]"
 }
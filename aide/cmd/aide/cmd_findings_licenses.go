@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// runLicenseAnalyzer evaluates the surveyed license files against the
+// configured policy. Licenses come from the survey store, so the licenses
+// survey analyser must have run.
+func runLicenseAnalyzer(sink *findingsSink, sup *findings.Suppressor, policy survey.LicensePolicy) (int, error) {
+	fmt.Printf("Running license analyser...\n")
+
+	entries, err := sink.backend.ListSurvey(survey.SearchOptions{
+		Analyzer: survey.AnalyzerLicenses,
+		Kind:     survey.KindLicense,
+		Limit:    -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read surveyed licenses: %w", err)
+	}
+	if len(entries) == 0 {
+		return 0, fmt.Errorf("no surveyed licenses — run 'aide survey run --analyzer=licenses' first")
+	}
+
+	ff, result, err := findings.AnalyzeLicenses(findings.LicensesConfig{Entries: entries, Policy: policy})
+	if err != nil {
+		return 0, err
+	}
+
+	source := "configured"
+	if policy.IsZero() {
+		source = "default"
+	}
+	fmt.Printf("  Checked %d license files against the %s policy (%s), found %d violations (%s)\n",
+		result.Checked, source, describeLicensePolicy(result.Policy), result.FindingsCount, result.Duration.Round(1_000_000))
+
+	ff = suppressFindings(sup, findings.AnalyzerLicense, ff)
+
+	return sink.store(findings.AnalyzerLicense, ff)
+}
+
+// hasSurveyedLicenses reports whether the licenses survey analyser has
+// recorded anything, which is what 'findings run all' needs to include the
+// license analyser.
+func hasSurveyedLicenses(b *Backend) bool {
+	entries, err := b.ListSurvey(survey.SearchOptions{Analyzer: survey.AnalyzerLicenses, Limit: 1})
+	return err == nil && len(entries) > 0
+}
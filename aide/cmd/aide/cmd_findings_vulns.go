@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// vulnsReferenceLimit bounds one reference lookup during Go reachability.
+const vulnsReferenceLimit = 10000
+
+// runVulnsAnalyzer audits the surveyed dependencies against the offline
+// advisory database. Dependencies come from the survey store, so the
+// dependencies survey analyser must have run.
+func runVulnsAnalyzer(sink *findingsSink, sup *findings.Suppressor, root string) (int, error) {
+	fmt.Printf("Running vulns analyser...\n")
+
+	entries, err := sink.backend.ListSurvey(survey.SearchOptions{
+		Analyzer: survey.AnalyzerDependencies,
+		Kind:     survey.KindDependency,
+		Limit:    -1,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read surveyed dependencies: %w", err)
+	}
+	if len(entries) == 0 {
+		return 0, fmt.Errorf("no surveyed dependencies — run 'aide survey run --analyzer=dependencies' first")
+	}
+	deps := make([]findings.VulnDependency, 0, len(entries))
+	for _, e := range entries {
+		deps = append(deps, findings.VulnDependency{
+			Ecosystem: e.Metadata["ecosystem"],
+			Name:      e.Name,
+			Version:   e.Metadata["version"],
+			Manifest:  e.FilePath,
+			Direct:    e.Metadata["direct"] == "true",
+		})
+	}
+
+	cfg := findings.VulnsConfig{ProjectRoot: root, Dependencies: deps}
+	if stats, err := sink.backend.GetCodeStats(); err == nil && stats.Symbols > 0 {
+		cfg.References = func(symbol, kind string) ([]*code.Reference, error) {
+			return sink.backend.SearchReferences(symbol, kind, "", vulnsReferenceLimit)
+		}
+	} else {
+		fmt.Printf("  Code index not available — Go findings are not narrowed to reachable symbols\n")
+	}
+
+	ff, result, err := findings.AnalyzeVulns(cfg)
+	if err != nil {
+		return 0, err
+	}
+
+	fmt.Printf("  Audited %d dependencies (%d without a resolved version) against %d advisories, found %d vulnerabilities, %d reachable (%s)\n",
+		result.Audited, result.Unversioned, result.AdvisoriesRead, result.FindingsCount, result.Reachable, result.Duration.Round(1_000_000))
+
+	ff = suppressFindings(sup, findings.AnalyzerVulns, ff)
+
+	return sink.store(findings.AnalyzerVulns, ff)
+}
+
+// cmdFindingsAdvisories shows or imports the offline OSV advisory database.
+func cmdFindingsAdvisories(dbPath string, args []string) error {
+	dir := findings.AdvisoriesPath(store.ProjectRootFromDB(dbPath))
+	if len(args) > 0 && args[0] == "import" {
+		if len(args) < 2 {
+			return fmt.Errorf("usage: aide findings advisories import <osv.zip>...")
+		}
+		for _, zipPath := range args[1:] {
+			name, n, err := findings.ImportAdvisoryZip(zipPath, dir)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Imported %d advisories into %s\n", n, filepath.Join(dir, name))
+		}
+		return nil
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("usage: aide findings advisories [import <osv.zip>...]")
+	}
+
+	counts := make(map[string]int)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, filepath.Dir(p))
+		counts[rel]++
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(counts) == 0 {
+		fmt.Printf("No advisories in %s\n", dir)
+		fmt.Println("Download an OSV export, e.g. https://osv-vulnerabilities.storage.googleapis.com/Go/all.zip,")
+		fmt.Println("then run 'aide findings advisories import all.zip'.")
+		return nil
+	}
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("Advisories in %s:\n", dir)
+	for _, name := range names {
+		fmt.Printf("  %-16s %d\n", name, counts[name])
+	}
+	return nil
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi/adapter"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi/registry"
+	"github.com/jmylchreest/aide/aide/pkg/lsp"
 	"github.com/jmylchreest/aide/aide/pkg/observe"
 	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
 	"github.com/jmylchreest/aide/aide/pkg/watcher"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -46,7 +48,8 @@ type MCPServer struct {
 	grpcServer     *grpcapi.Server
 	grpcClient     *grpcapi.Client // non-nil in client mode: attached to another process's daemon
 	grammarLoader  *grammar.CompositeLoader
-	dbPath         string // path to the memory database; used to derive project root
+	lsp            *lsp.Manager // language servers for precise code navigation; never nil after Run starts
+	dbPath         string       // path to the memory database; used to derive project root
 
 	unifiedWatcher   *watcher.Watcher
 	findingsRunner   *findings.Runner
@@ -115,12 +118,20 @@ var mcpToolTaxonomy = map[string]struct {
 	"code_read_symbol": {"consume", "symbol"},
 
 	// code (navigate)
-	"code_search":         {"navigate", "sym_search"},
-	"code_symbols":        {"navigate", "file_syms"},
-	"code_references":     {"navigate", "refs"},
-	"code_top_references": {"navigate", "top_refs"},
-	"code_read_check":     {"navigate", "read_check"},
-	"code_stats":          {"navigate", "stats"},
+	"code_search":          {"navigate", "sym_search"},
+	"code_symbols":         {"navigate", "file_syms"},
+	"code_references":      {"navigate", "refs"},
+	"code_definition":      {"navigate", "definition"},
+	"code_rename_impact":   {"navigate", "rename_impact"},
+	"code_top_references":  {"navigate", "top_refs"},
+	"code_implementations": {"navigate", "implementations"},
+	"code_supertypes":      {"navigate", "supertypes"},
+	"code_symbol_history":  {"navigate", "symbol_history"},
+	"code_impact":          {"navigate", "impact"},
+	"code_repo_map":        {"navigate", "repo_map"},
+	"code_cochange":        {"navigate", "cochange"},
+	"code_read_check":      {"navigate", "read_check"},
+	"code_stats":           {"navigate", "stats"},
 
 	// memory / decisions / state / findings / survey (knowledge)
 	"memory_add":       {"knowledge", "memory_add"},
@@ -137,11 +148,15 @@ var mcpToolTaxonomy = map[string]struct {
 	"findings_list":    {"knowledge", "findings_list"},
 	"findings_stats":   {"knowledge", "findings_stats"},
 	"findings_accept":  {"knowledge", "findings_accept"},
+	"findings_run":     {"knowledge", "findings_run"},
+	"findings_triage":  {"knowledge", "findings_triage"},
 	"survey_search":    {"knowledge", "survey_search"},
 	"survey_list":      {"knowledge", "survey_list"},
 	"survey_stats":     {"knowledge", "survey_stats"},
 	"survey_run":       {"knowledge", "survey_run"},
 	"survey_graph":     {"knowledge", "survey_graph"},
+	"survey_licenses":  {"knowledge", "survey_licenses"},
+	"survey_hotspots":  {"knowledge", "survey_hotspots"},
 
 	// coordination
 	"task_create":   {"coordinate", "task_create"},
@@ -272,10 +287,10 @@ func (s *MCPServer) initMCPCodeStore(dbPath string, cfg *mcpConfig, grpcServer *
 		return nil
 	}
 
-	indexPath, searchPath := getCodeStorePaths(dbPath)
+	indexPath, _ := getCodeStorePaths(dbPath)
 	openCodeStore := func() (*store.CodeStore, error) {
 		codeStart := time.Now()
-		cs, err := store.NewCodeStore(indexPath, searchPath)
+		cs, err := openIndexingCodeStore(dbPath)
 		if err != nil {
 			return nil, err
 		}
@@ -437,6 +452,7 @@ func (s *MCPServer) startCodeWatcher(dbPath string, cfg *mcpConfig) {
 				return
 			}
 		}
+		indexer.EnableTreeCache(DefaultTreeCacheSize)
 
 		debounceDelay := watcher.DefaultDebounceDelay
 		if cfg.codeWatchDelayStr != "" {
@@ -481,6 +497,7 @@ func (s *MCPServer) startCodeWatcher(dbPath string, cfg *mcpConfig) {
 				CloneMaxBucketSize:  fcfg.Clones.MaxBucketSize,
 				CloneMinSimilarity:  fcfg.Clones.MinSimilarity,
 				CloneMinSeverity:    fcfg.Clones.MinSeverity,
+				RulesDir:            filepath.Join(projectRoot, findings.CustomRulesDir),
 			}
 			findingsRunner = findings.NewRunner(s.findingsStore, runnerConfig, s.grammarLoader)
 			findingsRunner.SetClonesRunner(func(ctx context.Context, paths []string, cfg findings.ClonesRunnerConfig) ([]*findings.Finding, error) {
@@ -498,6 +515,15 @@ func (s *MCPServer) startCodeWatcher(dbPath string, cfg *mcpConfig) {
 				f, _, err := clone.DetectClones(cloneCfg)
 				return f, err
 			})
+			findingsRunner.SetFixDetection(findings.FixDetection{
+				Attribute: func() findings.FixAttribution {
+					return findings.FixAttribution{
+						Commit:  survey.HeadCommit(projectRoot),
+						Session: s.latestSessionID(),
+					}
+				},
+				Notify: s.publishTriage,
+			})
 			handlers = append(handlers, findingsRunner)
 		} else {
 			mcpLog.Printf("WARNING: findings store not available, findings analysis disabled in watcher")
@@ -569,7 +595,9 @@ type codeIndexHandler struct {
 }
 
 func (h *codeIndexHandler) OnChanges(files map[string]fsnotify.Op) {
+	changed := make([]string, 0, len(files))
 	for path, op := range files {
+		changed = append(changed, path)
 		if watcher.IsRemove(op) {
 			if err := h.indexer.RemoveFile(path); err != nil {
 				mcpLog.Printf("failed to remove %s: %v", path, err)
@@ -594,6 +622,13 @@ func (h *codeIndexHandler) OnChanges(files map[string]fsnotify.Op) {
 			}
 		}
 	}
+	// Targets are resolved once per batch: resolution reads every symbol,
+	// so per-file passes would multiply that cost by the batch size.
+	if len(changed) > 0 {
+		if _, err := h.indexer.ResolveTargets(changed); err != nil {
+			mcpLog.Printf("failed to resolve reference targets: %v", err)
+		}
+	}
 }
 
 // rescanForGrammar walks the project tree and re-indexes files matching
@@ -659,6 +694,11 @@ func rescanForGrammar(name string, indexer *Indexer, runner *findings.Runner, ro
 	})
 
 	mcpLog.Printf("re-scan complete for %s: %d files indexed", name, count)
+	if count > 0 {
+		if _, err := indexer.ResolveTargets(nil); err != nil {
+			mcpLog.Printf("re-scan: failed to resolve reference targets: %v", err)
+		}
+	}
 
 	// Notify findings runner about the re-scanned files.
 	if runner != nil && len(findingsFiles) > 0 {
@@ -838,6 +878,8 @@ func (s *MCPServer) Run() error {
 		nil, // Use default capabilities
 	)
 	s.server = srv
+	s.lsp = s.newLSPManager()
+	defer s.lsp.Close()
 
 	// Track tool execution counts + emit observe events
 	srv.AddReceivingMiddleware(s.toolCountMiddleware())
@@ -890,19 +932,31 @@ func mcpToolList() []*grpcapi.StatusMCPTool {
 		{Name: "code_symbols", Category: "code"},
 		{Name: "code_stats", Category: "code"},
 		{Name: "code_references", Category: "code"},
+		{Name: "code_definition", Category: "code"},
+		{Name: "code_rename_impact", Category: "code"},
 		{Name: "code_outline", Category: "code"},
 		{Name: "code_top_references", Category: "code"},
+		{Name: "code_implementations", Category: "code"},
+		{Name: "code_supertypes", Category: "code"},
+		{Name: "code_symbol_history", Category: "code"},
+		{Name: "code_impact", Category: "code"},
+		{Name: "code_repo_map", Category: "code"},
+		{Name: "code_cochange", Category: "code"},
 		{Name: "code_read_symbol", Category: "code"},
 		{Name: "code_read_check", Category: "code"},
 		{Name: "findings_search", Category: "findings"},
 		{Name: "findings_list", Category: "findings"},
 		{Name: "findings_stats", Category: "findings"},
 		{Name: "findings_accept", Category: "findings"},
+		{Name: "findings_run", Category: "findings"},
+		{Name: "findings_triage", Category: "findings"},
 		{Name: "survey_search", Category: "survey"},
 		{Name: "survey_list", Category: "survey"},
 		{Name: "survey_stats", Category: "survey"},
 		{Name: "survey_run", Category: "survey"},
 		{Name: "survey_graph", Category: "survey"},
+		{Name: "survey_licenses", Category: "survey"},
+		{Name: "survey_hotspots", Category: "survey"},
 		{Name: "instance_info", Category: "instance"},
 		{Name: "token_stats", Category: "token"},
 	}
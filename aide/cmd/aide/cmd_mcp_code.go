@@ -11,6 +11,7 @@ import (
 	"github.com/jmylchreest/aide/aide/pkg/code"
 	"github.com/jmylchreest/aide/aide/pkg/observe"
 	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -19,11 +20,12 @@ import (
 // ============================================================================
 
 type CodeSearchInput struct {
-	Query    string `json:"query" jsonschema:"Search query for symbol names or signatures. Supports Bleve query syntax."`
+	Query    string `json:"query" jsonschema:"Search query for symbol names or signatures. Supports Bleve query syntax. A qualified name (e.g. 'store.CodeStore.Close' or 'CodeStore.Close') matches that exact symbol."`
 	Kind     string `json:"kind,omitempty" jsonschema:"Filter by symbol kind: function, method, class, interface, type"`
 	Language string `json:"lang,omitempty" jsonschema:"Filter by language: typescript, javascript, go, python"`
 	FilePath string `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum results (default 20)"`
+	Semantic bool   `json:"semantic,omitempty" jsonschema:"Treat query as a natural-language description (e.g. 'where do we retry HTTP calls') and rank symbols by the similarity of their signature, doc comment and body"`
 }
 
 type CodeSymbolsInput struct {
@@ -33,10 +35,11 @@ type CodeSymbolsInput struct {
 type CodeStatsInput struct{}
 
 type CodeReferencesInput struct {
-	SymbolName  string   `json:"symbol" jsonschema:"Name of the symbol to find references for (e.g., 'getUserById'). Required if symbols is empty."`
+	SymbolName  string   `json:"symbol" jsonschema:"Name of the symbol to find references for (e.g., 'getUserById', or qualified as 'CodeStore.Close'). Required if symbols is empty."`
 	SymbolNames []string `json:"symbols,omitempty" jsonschema:"Batch mode: list of symbol names to find references for (max 10). If set, symbol is ignored."`
 	Kind        string   `json:"kind,omitempty" jsonschema:"Filter by reference kind: call, type_ref"`
 	FilePath    string   `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
+	Exact       bool     `json:"exact,omitempty" jsonschema:"Only return references resolved to the symbol's definition, dropping same-name matches elsewhere. Implied when the symbol name is qualified."`
 	Limit       int      `json:"limit,omitempty" jsonschema:"Maximum results per symbol (default 50)"`
 }
 
@@ -50,12 +53,54 @@ type CodeTopReferencesInput struct {
 	Kind  string `json:"kind,omitempty" jsonschema:"Filter by symbol kind: function, method, class, interface, type"`
 }
 
+type CodeHierarchyInput struct {
+	Type string `json:"type" jsonschema:"Type, interface, trait or class name (e.g. 'Reader', or qualified as 'io.Reader' — only the last segment is matched). Required."`
+}
+
+type CodeSymbolHistoryInput struct {
+	Symbol string `json:"symbol" jsonschema:"Symbol to trace (e.g. 'getUser', or qualified as 'CodeStore.Close'). Required."`
+	File   string `json:"file,omitempty" jsonschema:"Only definitions in files matching this path (substring match)"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum commits listed per symbol (default 10)"`
+}
+
+type CodeImpactInput struct {
+	Diff     string   `json:"diff,omitempty" jsonschema:"Unified diff to analyse (e.g. git diff output). Paths are relative to the repository root."`
+	Files    []string `json:"files,omitempty" jsonschema:"Changed files or lines as path, path:line or path:start-end, relative to the project root"`
+	Depth    int      `json:"depth,omitempty" jsonschema:"Max caller hops from each changed symbol (default 3)"`
+	MaxNodes int      `json:"max_nodes,omitempty" jsonschema:"Max callers walked per changed symbol (default 50)"`
+	Exact    bool     `json:"exact,omitempty" jsonschema:"Only follow references resolved to the changed definitions"`
+}
+
+type CodeRepoMapInput struct {
+	Tokens int      `json:"tokens,omitempty" jsonschema:"Token budget for the map (default 1024)"`
+	Focus  []string `json:"focus,omitempty" jsonschema:"Files to centre the map on, such as those being edited, relative to the project root"`
+}
+
+type CodeCoChangeInput struct {
+	File          string  `json:"file" jsonschema:"File you are about to change, relative to the project root; a bare file name matches by suffix. Required."`
+	MinConfidence float64 `json:"min_confidence,omitempty" jsonschema:"Only partners changed in at least this share of the file's commits (0-1, default 0)"`
+	Limit         int     `json:"limit,omitempty" jsonschema:"Maximum partners listed (default 10)"`
+}
+
+type CodeDefinitionInput struct {
+	File   string `json:"file" jsonschema:"File containing the identifier (relative or absolute). Required."`
+	Line   int    `json:"line" jsonschema:"1-indexed line the identifier is on. Required."`
+	Symbol string `json:"symbol" jsonschema:"The identifier as written on that line (e.g. 'getUser'); its first occurrence on the line is used. Required."`
+}
+
+type CodeRenameImpactInput struct {
+	Symbol  string `json:"symbol" jsonschema:"Symbol to rename (e.g. 'getUser', or qualified as 'CodeStore.Close'). Required."`
+	File    string `json:"file,omitempty" jsonschema:"File of one occurrence of the symbol; with line, picks the symbol when several share its name."`
+	Line    int    `json:"line,omitempty" jsonschema:"1-indexed line of that occurrence."`
+	NewName string `json:"new_name,omitempty" jsonschema:"Proposed new name, passed to the language server. Nothing is written. Default: <symbol>_renamed."`
+}
+
 type CodeReadCheckInput struct {
 	File string `json:"file" jsonschema:"Path to the file to check (relative or absolute). Required."`
 }
 
 type CodeReadSymbolInput struct {
-	Symbol  string   `json:"symbol" jsonschema:"Name of the symbol to read (e.g., 'getUserById', 'AuthConfig'). Required if symbols is empty."`
+	Symbol  string   `json:"symbol" jsonschema:"Name of the symbol to read (e.g., 'getUserById', 'AuthConfig', or qualified as 'CodeStore.Close'). Required if symbols is empty."`
 	Symbols []string `json:"symbols,omitempty" jsonschema:"Batch mode: list of symbol names to read (max 10). If set, symbol is ignored."`
 	Kind    string   `json:"kind,omitempty" jsonschema:"Filter by symbol kind: function, method, class, interface, type"`
 }
@@ -83,9 +128,11 @@ Symbols are extracted from source files using tree-sitter parsing:
 - Filter by kind (function, method, class, interface, type)
 - Filter by language (typescript, javascript, go, python)
 - Filter by file path pattern
+- Qualified names: "store.CodeStore.Close" (or a trailing part like "CodeStore.Close") picks one symbol out of many same-named ones
+- semantic=true: describe what the code does ("where do we retry HTTP calls") and get symbols ranked by the similarity of their signature, doc comment and the start of their body
 
 **What is NOT indexed** (use Grep for these):
-- Code inside function bodies (loops, conditionals, error handling)
+- Code inside function bodies, except through semantic=true (loops, conditionals, error handling)
 - Method call chains (.map, .forEach, .filter)
 - String literals, SQL queries, error messages
 - Import/require statements
@@ -132,9 +179,52 @@ References are places where a symbol is used, indexed by tree-sitter:
 **Batch mode:** Pass multiple names in the "symbols" array (max 10) to find
 references for several symbols in a single call.
 
+**Exact targets:** References are resolved to their definition at index time
+(same file, same package, or via the file's imports) and tagged with a
+confidence level. A qualified name ("CodeStore.Close") or "exact": true
+returns only references resolved to that definition — no same-name noise.
+
+**Language server:** When code.lsp_servers configures a server (gopls,
+typescript-language-server, pyright, ...) for the symbol's language, the
+references come from it instead and are marked "resolved: exact". Without
+one, or if it fails, the index answers.
+
 **Note:** Run 'aide code index' to index your codebase first.`,
 	}, s.handleCodeReferences)
 
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_definition",
+		Description: `Go to definition: find where the identifier at a file and line is defined.
+
+Pass the file, the 1-indexed line and the identifier as written there.
+
+**How it resolves:**
+- A language server configured in code.lsp_servers for the file's language
+  answers precisely (handles shadowing, imports, overloads)
+- Otherwise the index: the reference's resolved target when it has one, the
+  symbol declared on that line, or every same-named definition (candidates)
+
+**Note:** The index fallback needs 'aide code index'.`,
+	}, s.handleCodeDefinition)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_rename_impact",
+		Description: `Preview what renaming a symbol would touch — every edit site, grouped by file. Nothing is written.
+
+Name the symbol (qualify it, or pass file + line of an occurrence, when the
+name is shared).
+
+**How it resolves:**
+- A language server configured in code.lsp_servers computes the real rename
+  edit (definition, references, interface implementations it knows of)
+- Otherwise the index lists the definition plus references resolved to it,
+  and separately the unresolved same-name references that might be affected
+
+**Use cases:**
+- Size a rename or signature change before starting it
+- Find every file a refactor will touch`,
+	}, s.handleCodeRenameImpact)
+
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name: "code_outline",
 		Description: `Get a collapsed outline of a file with bodies replaced by { ... }.
@@ -173,6 +263,113 @@ the symbol name, reference count, and definition location when available.
 **Note:** Run 'aide code index' to index your codebase first.`,
 	}, s.handleCodeTopReferences)
 
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_implementations",
+		Description: `Find the types that implement, extend or embed a type — "who implements this interface?"
+
+Returns direct subtypes from the type-hierarchy index: classes extending a
+class, classes implementing an interface, Rust trait impls, interfaces
+extending an interface, and Go struct/interface embedding. For Go interfaces
+it also lists types whose method sets satisfy the interface (marked
+"structural"), since Go implementation is implicit.
+
+**Use cases:**
+- Find every implementation before changing an interface method
+- Locate the concrete types behind an abstraction
+- Trace a class's subclasses
+
+**Note:** Matching is by type name; run 'aide code index' first. Use
+code_supertypes for the opposite direction.`,
+	}, s.handleCodeImplementations)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_supertypes",
+		Description: `Find what a type extends, implements or embeds.
+
+Returns direct supertypes from the type-hierarchy index (base classes,
+implemented interfaces and traits, embedded types) and, for Go types, the
+interfaces the type's method set satisfies (marked "structural").
+
+**Note:** Matching is by type name; run 'aide code index' first. Use
+code_implementations for the opposite direction.`,
+	}, s.handleCodeSupertypes)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_symbol_history",
+		Description: `Show the commits that changed a symbol — "when did this function last change, and why?"
+
+Maps git history onto the symbol's current lines: each commit whose diff
+added, rewrote or deleted lines inside the symbol is listed newest first
+with author, date, subject and lines changed, plus the symbol's churn (how
+many commits touched it) and its authors.
+
+**Use cases:**
+- Find the commit (and message) behind a behaviour before changing it
+- Spot unstable, frequently-rewritten functions
+- Find who to ask about a piece of code
+
+**Note:** Follows first parents from HEAD; renames are not followed.
+History is cached per file and refreshed when HEAD moves.`,
+	}, s.handleCodeSymbolHistory)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_impact",
+		Description: `Show what a change may break — "what does this diff affect?"
+
+Maps changed lines onto the symbols containing them, then walks their
+callers, and the users of changed types, transitively. Reports:
+- Changed symbols
+- Affected symbols, nearest and most widely-dependent first, with the
+  changed symbols each one reaches
+- Entry points (from the survey) inside impacted code
+- Test files among the changed and affected code — the tests to run
+
+Pass a unified diff, a list of changed files/lines, or neither to analyse
+the working tree's uncommitted changes.
+
+**Note:** Matching is by name unless exact is set, so the report may
+over-approximate. Run 'aide code index' (and 'aide survey run' for entry
+points) first.`,
+	}, s.handleCodeImpact)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_repo_map",
+		Description: `Get a ranked outline of the most important symbols across the project, trimmed to a token budget.
+
+Symbols are ranked by PageRank over the reference graph: code that much
+other code calls or uses ranks highest, boosted for the most-referenced
+names, entry points and any focus files. The best that fit the budget are
+listed with their line and signature, file by file, grouped by the survey's
+module clusters.
+
+**Use cases:**
+- Orient in an unfamiliar codebase before searching or reading
+- See the API the files you are editing depend on (pass them as focus)
+
+**Note:** Run 'aide code index' first; 'aide survey run' adds entry points
+and module grouping.`,
+	}, s.handleCodeRepoMap)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "code_cochange",
+		Description: `List the files that historically change in the same commits as a file — temporal coupling.
+
+Catches dependencies no import shows: a .proto and its generated code, a
+config file and its loader, a schema and its migrations. For each partner:
+how many of the file's commits also changed it (confidence), in how many
+commits they changed together, when they last did, and whether it sits in
+another module.
+
+**Use cases:**
+- Before editing a file, learn what else usually needs updating or
+  regenerating with it
+- Review a change for a partner file it forgot
+
+**Note:** Reads the cochange survey analyzer's results (survey_run
+analyzer=cochange). Commits touching more than 30 files are ignored as
+noise; pairs need 3 shared commits.`,
+	}, s.handleCodeCoChange)
+
 	mcp.AddTool(s.server, &mcp.Tool{
 		Name: "code_read_symbol",
 		Description: `Read the full source code of a symbol by name — without reading the entire file.
@@ -200,6 +397,7 @@ symbols in a single call, eliminating round-trip overhead.
 - Single: {"symbol": "getUserById"}
 - Batch: {"symbols": ["getUserById", "createUser", "deleteUser"]}
 - Filtered: {"symbol": "handle", "kind": "method"}
+- Qualified: {"symbol": "store.CodeStore.Close"} (container path disambiguates same-named methods)
 
 **Note:** Requires the code index (run 'aide code index'). If the symbol isn't found
 in the index, check the name with code_search first.`,
@@ -226,7 +424,7 @@ or code_symbols over a full Read to save context window tokens.`,
 }
 
 func (s *MCPServer) handleCodeSearch(_ context.Context, _ *mcp.CallToolRequest, input CodeSearchInput) (*mcp.CallToolResult, any, error) {
-	mcpLog.Printf("tool: code_search query=%q kind=%s lang=%s", input.Query, input.Kind, input.Language)
+	mcpLog.Printf("tool: code_search query=%q kind=%s lang=%s semantic=%v", input.Query, input.Kind, input.Language, input.Semantic)
 
 	codeStore := s.getCodeStore()
 	if codeStore == nil {
@@ -238,9 +436,25 @@ func (s *MCPServer) handleCodeSearch(_ context.Context, _ *mcp.CallToolRequest,
 		limit = DefaultCodeSearchLimit
 	}
 
-	// Auto-wrap simple queries with wildcards for substring matching
+	if input.Semantic {
+		results, err := codeStore.SemanticSearchSymbols(input.Query, code.SearchOptions{
+			Kind:     input.Kind,
+			Language: input.Language,
+			FilePath: input.FilePath,
+			Limit:    limit,
+		})
+		if err != nil {
+			mcpLog.Printf("  error: %v", err)
+			return errorResult(fmt.Sprintf("semantic search failed: %v", err)), nil, nil
+		}
+		mcpLog.Printf("  found: %d symbols", len(results))
+		return textResult(formatCodeSearchResults(results)), nil, nil
+	}
+
+	// Auto-wrap simple queries with wildcards for substring matching.
+	// Qualified names (store.CodeStore.Close) are resolved by the store.
 	query := input.Query
-	if query != "" && !containsBleveSyntax(query) {
+	if query != "" && !containsBleveSyntax(query) && !code.IsQualifiedName(query) {
 		query = "*" + query + "*"
 		mcpLog.Printf("  auto-wildcarded query: %q", query)
 	}
@@ -331,7 +545,7 @@ func (s *MCPServer) handleCodeStats(_ context.Context, _ *mcp.CallToolRequest, _
 	return textResult(fmt.Sprintf("Code Index Statistics:\n- Files indexed: %d\n- Symbols indexed: %d\n- References indexed: %d", stats.Files, stats.Symbols, stats.References)), nil, nil
 }
 
-func (s *MCPServer) handleCodeReferences(_ context.Context, _ *mcp.CallToolRequest, input CodeReferencesInput) (*mcp.CallToolResult, any, error) {
+func (s *MCPServer) handleCodeReferences(ctx context.Context, _ *mcp.CallToolRequest, input CodeReferencesInput) (*mcp.CallToolResult, any, error) {
 	// Resolve symbol names: batch mode takes precedence
 	names := input.SymbolNames
 	if len(names) == 0 && input.SymbolName != "" {
@@ -359,13 +573,7 @@ func (s *MCPServer) handleCodeReferences(_ context.Context, _ *mcp.CallToolReque
 
 	// Single-symbol mode: return as before
 	if len(names) == 1 {
-		opts := code.ReferenceSearchOptions{
-			SymbolName: names[0],
-			Kind:       input.Kind,
-			FilePath:   input.FilePath,
-			Limit:      limit,
-		}
-		refs, err := codeStore.SearchReferences(opts)
+		refs, err := s.findCodeReferences(ctx, codeStore, names[0], input, limit)
 		if err != nil {
 			mcpLog.Printf("  error: %v", err)
 			return errorResult(fmt.Sprintf("search failed: %v", err)), nil, nil
@@ -379,13 +587,7 @@ func (s *MCPServer) handleCodeReferences(_ context.Context, _ *mcp.CallToolReque
 	sb.WriteString("# Batch Reference Results\n\n")
 	totalRefs := 0
 	for _, name := range names {
-		opts := code.ReferenceSearchOptions{
-			SymbolName: name,
-			Kind:       input.Kind,
-			FilePath:   input.FilePath,
-			Limit:      limit,
-		}
-		refs, err := codeStore.SearchReferences(opts)
+		refs, err := s.findCodeReferences(ctx, codeStore, name, input, limit)
 		if err != nil {
 			fmt.Fprintf(&sb, "## `%s` — error: %v\n\n", name, err)
 			continue
@@ -397,6 +599,199 @@ func (s *MCPServer) handleCodeReferences(_ context.Context, _ *mcp.CallToolReque
 	return textResult(sb.String()), nil, nil
 }
 
+// searchCodeReferences finds references to name. A qualified name or
+// input.Exact restricts the results to references whose resolved target is
+// one of the matching definitions; otherwise references match by bare name.
+func searchCodeReferences(codeStore store.CodeIndexStore, name string, input CodeReferencesInput, limit int) ([]*code.Reference, error) {
+	opts := code.ReferenceSearchOptions{
+		SymbolName: name,
+		Kind:       input.Kind,
+		FilePath:   input.FilePath,
+		Limit:      limit,
+	}
+	if !input.Exact && !code.IsQualifiedName(name) {
+		return codeStore.SearchReferences(opts)
+	}
+
+	defs, err := findDefinitions(codeStore, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	var refs []*code.Reference
+	for _, d := range defs {
+		opts.SymbolName = d.Name
+		opts.TargetSymbolID = d.ID
+		opts.Limit = limit - len(refs)
+		if opts.Limit <= 0 {
+			break
+		}
+		found, err := codeStore.SearchReferences(opts)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, found...)
+	}
+	return refs, nil
+}
+
+// findDefinitions returns the indexed symbols name denotes: every symbol
+// with that bare name, or the one a qualified name picks out.
+func findDefinitions(codeStore store.CodeIndexStore, name string, limit int) ([]*code.Symbol, error) {
+	query := name
+	if !code.IsQualifiedName(query) {
+		query = "\"" + query + "\""
+	}
+	results, err := codeStore.SearchSymbols(query, code.SearchOptions{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	var defs []*code.Symbol
+	for _, r := range results {
+		if r.Symbol.MatchesName(name) {
+			defs = append(defs, r.Symbol)
+		}
+	}
+	return defs, nil
+}
+
+func (s *MCPServer) handleCodeImplementations(_ context.Context, _ *mcp.CallToolRequest, input CodeHierarchyInput) (*mcp.CallToolResult, any, error) {
+	return s.handleCodeHierarchy("code_implementations", input, true)
+}
+
+func (s *MCPServer) handleCodeSupertypes(_ context.Context, _ *mcp.CallToolRequest, input CodeHierarchyInput) (*mcp.CallToolResult, any, error) {
+	return s.handleCodeHierarchy("code_supertypes", input, false)
+}
+
+// handleCodeHierarchy serves code_implementations (sub) and code_supertypes.
+func (s *MCPServer) handleCodeHierarchy(tool string, input CodeHierarchyInput, sub bool) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: %s type=%q", tool, input.Type)
+
+	if input.Type == "" {
+		return errorResult("type is required"), nil, nil
+	}
+	codeStore := s.getCodeStore()
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+
+	find := codeStore.FindSupertypes
+	if sub {
+		find = codeStore.FindImplementations
+	}
+	edges, err := find(input.Type)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("query failed: %v", err)), nil, nil
+	}
+	mcpLog.Printf("  found: %d edges", len(edges))
+	return textResult(formatTypeHierarchy(input.Type, edges, sub)), nil, nil
+}
+
+func (s *MCPServer) handleCodeSymbolHistory(_ context.Context, _ *mcp.CallToolRequest, input CodeSymbolHistoryInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_symbol_history symbol=%q file=%s limit=%d", input.Symbol, input.File, input.Limit)
+
+	if input.Symbol == "" {
+		return errorResult("symbol is required"), nil, nil
+	}
+	codeStore := s.getCodeStore()
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	defs, err := findHistoryDefinitions(codeStore, input.Symbol, input.File)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("query failed: %v", err)), nil, nil
+	}
+	histories, err := symbolHistories(codeStore, store.ProjectRootFromDB(s.dbPath), defs, limit)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("history failed: %v", err)), nil, nil
+	}
+	mcpLog.Printf("  found: %d symbols", len(histories))
+	return textResult(formatSymbolHistories(input.Symbol, histories)), nil, nil
+}
+
+func (s *MCPServer) handleCodeImpact(_ context.Context, _ *mcp.CallToolRequest, input CodeImpactInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_impact diff=%d files=%d depth=%d exact=%v", len(input.Diff), len(input.Files), input.Depth, input.Exact)
+
+	codeStore := s.getCodeStore()
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+	root := store.ProjectRootFromDB(s.dbPath)
+	changes, err := impactChanges(root, input.Diff, input.Files)
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+	if len(changes) == 0 {
+		return textResult("No changes to analyse."), nil, nil
+	}
+
+	var entrypoints []*survey.Entry
+	if s.surveyStore != nil {
+		entrypoints, _ = s.surveyStore.ListEntries(survey.SearchOptions{Kind: survey.KindEntrypoint, Limit: maxImpactEntrypoints})
+	}
+	cg := &codeGrapherAdapter{codeSearcherAdapter: codeSearcherAdapter{store: codeStore}}
+	report, err := survey.AnalyzeImpact(cg, root, changes, entrypoints, survey.ImpactOptions{
+		MaxDepth: input.Depth,
+		MaxNodes: input.MaxNodes,
+		Exact:    input.Exact,
+	})
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("impact analysis failed: %v", err)), nil, nil
+	}
+	mcpLog.Printf("  found: %d changed, %d affected", len(report.Changed), len(report.Affected))
+	return textResult(formatImpactReport(report)), nil, nil
+}
+
+func (s *MCPServer) handleCodeRepoMap(_ context.Context, _ *mcp.CallToolRequest, input CodeRepoMapInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_repo_map tokens=%d focus=%d", input.Tokens, len(input.Focus))
+
+	codeStore := s.getCodeStore()
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+
+	var entries []*survey.Entry
+	if s.surveyStore != nil {
+		entries = repoMapEntries(s.surveyStore.ListEntries)
+	}
+	m, err := survey.BuildRepoMap(codeStore, entries, survey.RepoMapOptions{Tokens: input.Tokens, Focus: input.Focus})
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("repo map failed: %v", err)), nil, nil
+	}
+	if m.TotalSymbols == 0 {
+		return textResult("No symbols indexed. Run 'aide code index' to index the codebase."), nil, nil
+	}
+	mcpLog.Printf("  mapped: %d of %d symbols, ~%d tokens", m.Symbols, m.TotalSymbols, m.Tokens)
+	return textResult(m.Render()), nil, nil
+}
+
+func (s *MCPServer) handleCodeCoChange(_ context.Context, _ *mcp.CallToolRequest, input CodeCoChangeInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_cochange file=%s", input.File)
+
+	if input.File == "" {
+		return errorResult("file is required"), nil, nil
+	}
+	if s.surveyStore == nil {
+		return errorResult("survey store not available"), nil, nil
+	}
+	report, err := buildCoChangeReport(s.surveyStore.ListEntries, input.File, input.MinConfidence, input.Limit)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(err.Error()), nil, nil
+	}
+	mcpLog.Printf("  found: %d partners", len(report.Partners))
+	return textResult(formatCoChangeReport(report)), nil, nil
+}
+
 func (s *MCPServer) handleCodeTopReferences(_ context.Context, _ *mcp.CallToolRequest, input CodeTopReferencesInput) (*mcp.CallToolResult, any, error) {
 	mcpLog.Printf("tool: code_top_references limit=%d kind=%s", input.Limit, input.Kind)
 
@@ -603,7 +998,7 @@ func (s *MCPServer) handleCodeReadSymbol(ctx context.Context, _ *mcp.CallToolReq
 // Returns the matched symbol (nil if not found), token count, tokens saved, and formatted output.
 func (s *MCPServer) readOneSymbol(codeStore store.CodeIndexStore, root, name, kind string) (*code.Symbol, int, int, string) {
 	query := name
-	if !containsBleveSyntax(query) {
+	if !containsBleveSyntax(query) && !code.IsQualifiedName(query) {
 		query = "\"" + query + "\""
 	}
 	opts := code.SearchOptions{
@@ -617,7 +1012,7 @@ func (s *MCPServer) readOneSymbol(codeStore store.CodeIndexStore, root, name, ki
 
 	var match *code.Symbol
 	for _, r := range results {
-		if r.Symbol.Name == name {
+		if r.Symbol.MatchesName(name) {
 			match = r.Symbol
 			break
 		}
@@ -637,7 +1032,7 @@ func (s *MCPServer) readOneSymbol(codeStore store.CodeIndexStore, root, name, ki
 	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "## `%s` [%s]\n", match.Name, match.Kind)
+	fmt.Fprintf(&sb, "## `%s` [%s]\n", match.DisplayName(), match.Kind)
 	fmt.Fprintf(&sb, "**File:** `%s:%d-%d`", match.FilePath, match.StartLine, match.EndLine)
 	if match.Signature != "" {
 		fmt.Fprintf(&sb, " | **Signature:** `%s`", match.Signature)
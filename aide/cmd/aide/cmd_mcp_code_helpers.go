@@ -10,6 +10,7 @@ import (
 
 	"github.com/jmylchreest/aide/aide/pkg/code"
 	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
 )
 
 // ============================================================================
@@ -26,7 +27,11 @@ func formatCodeSearchResults(results []*store.CodeSearchResult) string {
 
 	for _, r := range results {
 		sym := r.Symbol
-		fmt.Fprintf(&sb, "## `%s` [%s]\n", sym.Name, sym.Kind)
+		kind := sym.Kind
+		if sym.Precise {
+			kind += ", precise"
+		}
+		fmt.Fprintf(&sb, "## `%s` [%s]\n", sym.DisplayName(), kind)
 		fmt.Fprintf(&sb, "**File:** `%s:%d`\n", sym.FilePath, sym.StartLine)
 		fmt.Fprintf(&sb, "**Signature:** `%s`\n", sym.Signature)
 		if sym.DocComment != "" {
@@ -80,6 +85,124 @@ func formatCodeSymbols(filePath string, symbols []*code.Symbol) string {
 	return sb.String()
 }
 
+// formatTypeHierarchy renders hierarchy edges for code_implementations
+// (sub) or code_supertypes, one line per related type with its location.
+func formatTypeHierarchy(name string, edges []*code.TypeEdge, sub bool) string {
+	if len(edges) == 0 {
+		if sub {
+			return fmt.Sprintf("No implementations or subtypes found for '%s'", name)
+		}
+		return fmt.Sprintf("No supertypes found for '%s'", name)
+	}
+
+	var sb strings.Builder
+	if sub {
+		fmt.Fprintf(&sb, "# Implementations of '%s' (%d)\n\n", name, len(edges))
+	} else {
+		fmt.Fprintf(&sb, "# Supertypes of '%s' (%d)\n\n", name, len(edges))
+	}
+	for _, e := range edges {
+		other := e.SuperName
+		if sub {
+			other = e.TypeName
+		}
+		kind := e.Kind
+		if e.Structural {
+			kind += ", structural"
+		}
+		fmt.Fprintf(&sb, "- **%s** [%s] `%s:%d`\n", other, kind, e.FilePath, e.Line)
+	}
+	return sb.String()
+}
+
+func formatSymbolHistories(name string, histories []*code.SymbolHistory) string {
+	if len(histories) == 0 {
+		return fmt.Sprintf("No symbol found for `%s`.\n\nTip: check the name with code_search.", name)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# History of `%s`\n", name)
+	for _, h := range histories {
+		sym := h.Symbol
+		fmt.Fprintf(&sb, "\n## %s [%s] `%s:%d-%d`\n\n", symbolDisplayName(sym), sym.Kind, sym.FilePath, sym.StartLine, sym.EndLine)
+		if h.Churn == 0 {
+			sb.WriteString("No commits found (uncommitted, or beyond the history walked).\n")
+			continue
+		}
+		fmt.Fprintf(&sb, "_%d commits, %d lines changed%s_\n\n", h.Churn, h.LinesChanged, formatHistoryAuthors(h.Authors))
+		for _, c := range h.Commits {
+			fmt.Fprintf(&sb, "- `%s` %s %s (+%d -%d): %s\n", shortHash(c.Hash), c.When.Format("2006-01-02"), c.Author, c.Added, c.Deleted, c.Subject)
+		}
+		if more := h.Churn - len(h.Commits); more > 0 {
+			fmt.Fprintf(&sb, "- … %d older commits\n", more)
+		}
+	}
+	return sb.String()
+}
+
+// maxImpactListed caps the affected symbols an impact report lists.
+const maxImpactListed = 50
+
+func formatImpactReport(r *survey.ImpactReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Change impact\n\n_%d changed regions, %d changed symbols, %d affected symbols, %d entry points, %d test files_\n",
+		len(r.Changes), len(r.Changed), len(r.Affected), len(r.Entrypoints), len(r.TestFiles))
+	if len(r.Changed) == 0 {
+		sb.WriteString("\nNo indexed symbol contains a changed line.\n\nTip: run `aide code index` if the changed files are new.\n")
+	}
+
+	writeSym := func(s *survey.ImpactSymbol) {
+		name := s.QualifiedName
+		if name == "" {
+			name = s.Name
+		}
+		fmt.Fprintf(&sb, "- `%s` [%s] `%s:%d`", name, s.Kind, s.FilePath, s.Line)
+		if s.Depth > 0 {
+			fmt.Fprintf(&sb, " depth %d via %s", s.Depth, strings.Join(s.Via, ", "))
+		}
+		if s.Test {
+			sb.WriteString(" (test)")
+		}
+		sb.WriteString("\n")
+	}
+	if len(r.Changed) > 0 {
+		sb.WriteString("\n## Changed\n\n")
+		for _, s := range r.Changed {
+			writeSym(s)
+		}
+	}
+	if len(r.Affected) > 0 {
+		sb.WriteString("\n## Affected\n\n")
+		for i, s := range r.Affected {
+			if i == maxImpactListed {
+				fmt.Fprintf(&sb, "- … %d more\n", len(r.Affected)-i)
+				break
+			}
+			writeSym(s)
+		}
+	}
+	if len(r.Entrypoints) > 0 {
+		sb.WriteString("\n## Entry points\n\n")
+		for _, e := range r.Entrypoints {
+			loc := e.FilePath
+			if line := e.Metadata["line"]; line != "" {
+				loc += ":" + line
+			}
+			fmt.Fprintf(&sb, "- %s `%s`\n", e.Name, loc)
+		}
+	}
+	if len(r.TestFiles) > 0 {
+		sb.WriteString("\n## Tests to run\n\n")
+		for _, f := range r.TestFiles {
+			fmt.Fprintf(&sb, "- `%s`\n", f)
+		}
+	}
+	if len(r.Unmapped) > 0 {
+		fmt.Fprintf(&sb, "\n_Changed but not mapped to a symbol: %s_\n", strings.Join(r.Unmapped, ", "))
+	}
+	return sb.String()
+}
+
 func formatCodeReferences(symbolName string, refs []*code.Reference) string {
 	if len(refs) == 0 {
 		return fmt.Sprintf("No references found for `%s`.\n\nTip: Run `aide code index` to index your codebase.", symbolName)
@@ -104,6 +227,9 @@ func formatCodeReferences(symbolName string, refs []*code.Reference) string {
 			case code.RefKindTypeRef:
 				kindTag = "[type]"
 			}
+			if ref.Confidence != "" {
+				kindTag += " (resolved: " + ref.Confidence + ")"
+			}
 			fmt.Fprintf(&sb, "- **Line %d** %s: `%s`\n", ref.Line, kindTag, ref.Context)
 		}
 		sb.WriteString("\n")
@@ -0,0 +1,457 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/config"
+	"github.com/jmylchreest/aide/aide/pkg/lsp"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ============================================================================
+// Language-server bridge for code navigation tools
+// ============================================================================
+
+// newLSPManager builds the language-server manager from code.lsp_servers.
+// Servers start on first use; results are cached in the code store once it
+// is open.
+func (s *MCPServer) newLSPManager() *lsp.Manager {
+	cfg := config.Get().Code
+	servers := make([]lsp.ServerConfig, 0, len(cfg.LSPServers))
+	for _, sc := range cfg.LSPServers {
+		servers = append(servers, lsp.ServerConfig{Languages: sc.Languages, Command: sc.Command})
+	}
+	if len(servers) > 0 {
+		mcpLog.Printf("lsp: %d language server(s) configured", len(servers))
+	}
+	return lsp.NewManager(lsp.Options{
+		Root:    store.ProjectRootFromDB(s.dbPath),
+		Servers: servers,
+		Timeout: cfg.LSPTimeoutDuration(lsp.DefaultTimeout),
+		Cache: func() lsp.Cache {
+			if cs := s.getCodeStore(); cs != nil {
+				return cs
+			}
+			return nil
+		},
+	})
+}
+
+// findCodeReferences answers code_references through a language server when
+// one is configured for every definition of name, and from the index
+// otherwise or when the server fails.
+func (s *MCPServer) findCodeReferences(ctx context.Context, codeStore store.CodeIndexStore, name string, input CodeReferencesInput, limit int) ([]*code.Reference, error) {
+	if refs, ok := s.lspReferences(ctx, codeStore, name, input, limit); ok {
+		return refs, nil
+	}
+	return searchCodeReferences(codeStore, name, input, limit)
+}
+
+// lspReferences asks the language server for the references to each
+// indexed definition of name. Results carry ConfidenceExact and borrow their
+// kind from the index entry on the same line, so kind filters still apply.
+func (s *MCPServer) lspReferences(ctx context.Context, codeStore store.CodeIndexStore, name string, input CodeReferencesInput, limit int) ([]*code.Reference, bool) {
+	defs, err := findDefinitions(codeStore, name, limit)
+	if err != nil || len(defs) == 0 {
+		return nil, false
+	}
+	for _, d := range defs {
+		if !s.lsp.Enabled(d.Language) {
+			return nil, false
+		}
+	}
+
+	root := store.ProjectRootFromDB(s.dbPath)
+	kinds := newIndexedKinds(codeStore)
+	var refs []*code.Reference
+	for _, d := range defs {
+		line, col, ok := symbolNamePosition(root, d)
+		if !ok {
+			return nil, false
+		}
+		sites, err := s.lsp.References(ctx, lsp.Query{Language: d.Language, File: d.FilePath, Line: line, Column: col})
+		if err != nil {
+			mcpLog.Printf("  lsp references failed, using index: %v", err)
+			return nil, false
+		}
+		for _, site := range sites {
+			if input.FilePath != "" && !strings.Contains(site.File, input.FilePath) {
+				continue
+			}
+			kind := kinds.at(site.File, site.Line, d.Name)
+			if input.Kind != "" && kind != input.Kind {
+				continue
+			}
+			refs = append(refs, &code.Reference{
+				SymbolName:     d.Name,
+				Kind:           kind,
+				FilePath:       site.File,
+				Line:           site.Line,
+				Column:         site.Column,
+				Context:        site.Text,
+				Language:       d.Language,
+				TargetSymbolID: d.ID,
+				Confidence:     code.ConfidenceExact,
+			})
+			if len(refs) >= limit {
+				return refs, true
+			}
+		}
+	}
+	mcpLog.Printf("  lsp: %d references", len(refs))
+	return refs, true
+}
+
+// indexedKinds looks up the kind the index recorded for a reference,
+// loading each file's references once.
+type indexedKinds struct {
+	codeStore store.CodeIndexStore
+	files     map[string][]*code.Reference
+}
+
+func newIndexedKinds(codeStore store.CodeIndexStore) *indexedKinds {
+	return &indexedKinds{codeStore: codeStore, files: make(map[string][]*code.Reference)}
+}
+
+func (k *indexedKinds) at(file string, line int, name string) string {
+	refs, ok := k.files[file]
+	if !ok {
+		refs, _ = k.codeStore.GetFileReferences(file)
+		k.files[file] = refs
+	}
+	for _, r := range refs {
+		if r.Line == line && r.SymbolName == name {
+			return r.Kind
+		}
+	}
+	return ""
+}
+
+// symbolNamePosition locates sym's name in its declaration: the first
+// whole-word occurrence within a few lines of StartLine, which may sit on a
+// decorator, annotation or doc comment rather than the name itself.
+func symbolNamePosition(root string, sym *code.Symbol) (line, col int, ok bool) {
+	end := sym.StartLine + 5
+	if sym.EndLine >= sym.StartLine && sym.EndLine < end {
+		end = sym.EndLine
+	}
+	lines, err := readFileLines(absProjectPath(root, sym.FilePath), sym.StartLine, end)
+	if err != nil {
+		return 0, 0, false
+	}
+	for i, text := range lines {
+		if c := code.IdentColumn(text, sym.Name); c >= 0 {
+			return sym.StartLine + i, c, true
+		}
+	}
+	return 0, 0, false
+}
+
+func absProjectPath(root, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(root, path)
+}
+
+// relProjectPath returns path relative to root when it lies inside it.
+func relProjectPath(root, path string) string {
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// lineText returns line n (1-indexed) of path.
+func lineText(path string, n int) (string, bool) {
+	lines, err := readFileLines(path, n, n)
+	if err != nil || len(lines) == 0 {
+		return "", false
+	}
+	return lines[0], true
+}
+
+// symbolSite describes an indexed symbol as a navigation result.
+func symbolSite(sym *code.Symbol) lsp.Site {
+	return lsp.Site{File: sym.FilePath, Line: sym.StartLine, Text: sym.Signature}
+}
+
+// ============================================================================
+// code_definition
+// ============================================================================
+
+func (s *MCPServer) handleCodeDefinition(ctx context.Context, _ *mcp.CallToolRequest, input CodeDefinitionInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_definition file=%s line=%d symbol=%q", input.File, input.Line, input.Symbol)
+
+	if input.File == "" || input.Line <= 0 || input.Symbol == "" {
+		return errorResult("file, line and symbol are required"), nil, nil
+	}
+	root := store.ProjectRootFromDB(s.dbPath)
+	abs := absProjectPath(root, input.File)
+	rel := relProjectPath(root, input.File)
+	text, ok := lineText(abs, input.Line)
+	if !ok {
+		return errorResult(fmt.Sprintf("cannot read line %d of %s", input.Line, input.File)), nil, nil
+	}
+	col := code.IdentColumn(text, input.Symbol)
+	if col < 0 {
+		return errorResult(fmt.Sprintf("`%s` does not occur on line %d of %s", input.Symbol, input.Line, input.File)), nil, nil
+	}
+
+	if lang := code.DetectLanguage(abs, nil); s.lsp.Enabled(lang) {
+		sites, err := s.lsp.Definition(ctx, lsp.Query{Language: lang, File: rel, Line: input.Line, Column: col})
+		if err == nil {
+			mcpLog.Printf("  lsp: %d definitions", len(sites))
+			return textResult(formatDefinitions(input.Symbol, sites, "language server")), nil, nil
+		}
+		mcpLog.Printf("  lsp definition failed, using index: %v", err)
+	}
+
+	codeStore := s.getCodeStore()
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+	sites, source, err := indexDefinition(codeStore, rel, input.Line, input.Symbol)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("lookup failed: %v", err)), nil, nil
+	}
+	mcpLog.Printf("  index: %d definitions", len(sites))
+	return textResult(formatDefinitions(input.Symbol, sites, source)), nil, nil
+}
+
+// indexDefinition resolves name at file:line from the index: the resolved
+// target of the reference there when it has one, the symbol declared there,
+// or else every same-named definition.
+func indexDefinition(codeStore store.CodeIndexStore, file string, line int, name string) ([]lsp.Site, string, error) {
+	refs, err := codeStore.GetFileReferences(file)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, r := range refs {
+		if r.Line != line || r.SymbolName != name || r.TargetSymbolID == "" {
+			continue
+		}
+		if sym, err := codeStore.GetSymbol(r.TargetSymbolID); err == nil && sym != nil {
+			return []lsp.Site{symbolSite(sym)}, "index, resolved: " + r.Confidence, nil
+		}
+	}
+	if syms, err := codeStore.GetFileSymbols(file); err == nil {
+		for _, sym := range syms {
+			if sym.Name == name && sym.StartLine <= line && line <= sym.StartLine+5 {
+				return []lsp.Site{symbolSite(sym)}, "index, declared here", nil
+			}
+		}
+	}
+	defs, err := findDefinitions(codeStore, name, DefaultCodeSearchLimit)
+	if err != nil {
+		return nil, "", err
+	}
+	sites := make([]lsp.Site, 0, len(defs))
+	for _, d := range defs {
+		sites = append(sites, symbolSite(d))
+	}
+	return sites, "index, name match", nil
+}
+
+func formatDefinitions(name string, sites []lsp.Site, source string) string {
+	if len(sites) == 0 {
+		return fmt.Sprintf("No definition found for `%s` (%s).", name, source)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Definition of `%s`\n\n", name)
+	fmt.Fprintf(&sb, "_Source: %s_", source)
+	if len(sites) > 1 {
+		fmt.Fprintf(&sb, " — %d candidates", len(sites))
+	}
+	sb.WriteString("\n\n")
+	for _, site := range sites {
+		fmt.Fprintf(&sb, "- `%s:%d`", site.File, site.Line)
+		if site.Text != "" {
+			fmt.Fprintf(&sb, " — `%s`", site.Text)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ============================================================================
+// code_rename_impact
+// ============================================================================
+
+func (s *MCPServer) handleCodeRenameImpact(ctx context.Context, _ *mcp.CallToolRequest, input CodeRenameImpactInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: code_rename_impact symbol=%q file=%s line=%d", input.Symbol, input.File, input.Line)
+
+	if input.Symbol == "" {
+		return errorResult("symbol is required"), nil, nil
+	}
+	if (input.File == "") != (input.Line <= 0) {
+		return errorResult("file and line must be given together"), nil, nil
+	}
+	codeStore := s.getCodeStore()
+	root := store.ProjectRootFromDB(s.dbPath)
+	bare := code.QualifiedLeaf(input.Symbol)
+	newName := input.NewName
+	if newName == "" {
+		newName = bare + "_renamed"
+	}
+
+	// Anchor the rename on the given occurrence, or on the one definition
+	// the name denotes.
+	var def *code.Symbol
+	var q lsp.Query
+	if input.File != "" {
+		abs := absProjectPath(root, input.File)
+		text, ok := lineText(abs, input.Line)
+		if !ok {
+			return errorResult(fmt.Sprintf("cannot read line %d of %s", input.Line, input.File)), nil, nil
+		}
+		col := code.IdentColumn(text, bare)
+		if col < 0 {
+			return errorResult(fmt.Sprintf("`%s` does not occur on line %d of %s", bare, input.Line, input.File)), nil, nil
+		}
+		q = lsp.Query{Language: code.DetectLanguage(abs, nil), File: relProjectPath(root, input.File), Line: input.Line, Column: col}
+	} else {
+		if codeStore == nil {
+			return errorResult("code store not available (still initializing or disabled)"), nil, nil
+		}
+		defs, err := findDefinitions(codeStore, input.Symbol, DefaultCodeSearchLimit)
+		if err != nil {
+			return errorResult(fmt.Sprintf("lookup failed: %v", err)), nil, nil
+		}
+		switch len(defs) {
+		case 0:
+			return errorResult(fmt.Sprintf("no definition of `%s` is indexed", input.Symbol)), nil, nil
+		case 1:
+			def = defs[0]
+		default:
+			sites := make([]lsp.Site, 0, len(defs))
+			for _, d := range defs {
+				sites = append(sites, symbolSite(d))
+			}
+			return textResult(formatDefinitions(input.Symbol, sites, "ambiguous — pass a qualified name, or file and line")), nil, nil
+		}
+		line, col, ok := symbolNamePosition(root, def)
+		if !ok {
+			return errorResult(fmt.Sprintf("cannot locate `%s` in %s", def.Name, def.FilePath)), nil, nil
+		}
+		q = lsp.Query{Language: def.Language, File: def.FilePath, Line: line, Column: col}
+	}
+
+	if s.lsp.Enabled(q.Language) {
+		sites, err := s.lsp.RenameSites(ctx, q, newName)
+		if err == nil {
+			mcpLog.Printf("  lsp: %d edits", len(sites))
+			return textResult(formatRenameImpact(input.Symbol, newName, sites, nil, "language server")), nil, nil
+		}
+		mcpLog.Printf("  lsp rename failed, using index: %v", err)
+	}
+
+	if codeStore == nil {
+		return errorResult("code store not available (still initializing or disabled)"), nil, nil
+	}
+	if def == nil {
+		sites, _, err := indexDefinition(codeStore, q.File, q.Line, bare)
+		if err != nil {
+			return errorResult(fmt.Sprintf("lookup failed: %v", err)), nil, nil
+		}
+		if len(sites) != 1 {
+			return textResult(formatDefinitions(bare, sites, "ambiguous in the index — configure a language server for exact results")), nil, nil
+		}
+		defs, _ := codeStore.GetFileSymbols(sites[0].File)
+		for _, d := range defs {
+			if d.Name == bare && d.StartLine == sites[0].Line {
+				def = d
+				break
+			}
+		}
+		if def == nil {
+			return errorResult(fmt.Sprintf("cannot resolve `%s` to an indexed definition", bare)), nil, nil
+		}
+	}
+	exact, possible, err := indexRenameSites(codeStore, root, def)
+	if err != nil {
+		mcpLog.Printf("  error: %v", err)
+		return errorResult(fmt.Sprintf("search failed: %v", err)), nil, nil
+	}
+	mcpLog.Printf("  index: %d edits, %d possible", len(exact), len(possible))
+	return textResult(formatRenameImpact(input.Symbol, newName, exact, possible, "index")), nil, nil
+}
+
+// indexRenameSites collects a rename's edits from the index: the
+// definition plus references resolved to it, and separately the unresolved
+// same-name references that may or may not point at it.
+func indexRenameSites(codeStore store.CodeIndexStore, root string, def *code.Symbol) (exact, possible []lsp.Site, err error) {
+	if line, col, ok := symbolNamePosition(root, def); ok {
+		text, _ := lineText(absProjectPath(root, def.FilePath), line)
+		exact = append(exact, lsp.Site{File: def.FilePath, Line: line, Column: col, Text: strings.TrimSpace(text)})
+	}
+	refs, err := codeStore.SearchReferences(code.ReferenceSearchOptions{SymbolName: def.Name, Limit: DefaultRenameImpactLimit})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, r := range refs {
+		site := lsp.Site{File: r.FilePath, Line: r.Line, Column: r.Column, Text: r.Context}
+		switch r.TargetSymbolID {
+		case def.ID:
+			exact = append(exact, site)
+		case "":
+			if r.Language == def.Language {
+				possible = append(possible, site)
+			}
+		}
+	}
+	return exact, possible, nil
+}
+
+func formatRenameImpact(name, newName string, sites, possible []lsp.Site, source string) string {
+	if len(sites) == 0 && len(possible) == 0 {
+		return fmt.Sprintf("Renaming `%s` would change nothing the %s knows of.", name, source)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Rename impact: `%s` → `%s`\n\n", name, newName)
+	fmt.Fprintf(&sb, "_%d edits in %d files (source: %s; nothing was changed)_\n\n", len(sites), countFiles(sites), source)
+	writeSitesByFile(&sb, sites)
+	if len(possible) > 0 {
+		fmt.Fprintf(&sb, "## Possibly affected (%d unresolved same-name references)\n\n", len(possible))
+		writeSitesByFile(&sb, possible)
+	}
+	return sb.String()
+}
+
+func countFiles(sites []lsp.Site) int {
+	files := make(map[string]bool)
+	for _, s := range sites {
+		files[s.File] = true
+	}
+	return len(files)
+}
+
+func writeSitesByFile(sb *strings.Builder, sites []lsp.Site) {
+	grouped := make(map[string][]lsp.Site)
+	for _, s := range sites {
+		grouped[s.File] = append(grouped[s.File], s)
+	}
+	files := make([]string, 0, len(grouped))
+	for f := range grouped {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		fmt.Fprintf(sb, "### `%s`\n\n", f)
+		for _, s := range grouped[f] {
+			fmt.Fprintf(sb, "- **Line %d**: `%s`\n", s.Line, s.Text)
+		}
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/lsp"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// TestCodeNavigationIndexFallback exercises code_definition and
+// code_rename_impact without a language server, where the index answers.
+func TestCodeNavigationIndexFallback(t *testing.T) {
+	root := t.TempDir()
+	aideDir := filepath.Join(root, ".aide", "memory")
+	if err := os.MkdirAll(aideDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	dbPath := filepath.Join(aideDir, "memory.db")
+	files := map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {}\n",
+		"util.go": "package main\n\nfunc helper() {\n\trun()\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	indexPath, searchPath := getCodeStorePaths(dbPath)
+	cs, err := store.NewCodeStore(indexPath, searchPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+	if _, err := NewIndexerFromStore(cs, newGrammarLoader(dbPath, nil), root).Reconcile(); err != nil {
+		t.Fatalf("Reconcile: %v", err)
+	}
+
+	s := &MCPServer{dbPath: dbPath, lsp: lsp.NewManager(lsp.Options{Root: root})}
+	s.setCodeStore(cs)
+	ctx := context.Background()
+
+	res, _, _ := s.handleCodeDefinition(ctx, nil, CodeDefinitionInput{File: "util.go", Line: 4, Symbol: "run"})
+	if got := extractText(res); !strings.Contains(got, "`main.go:7`") || !strings.Contains(got, "Source: index") {
+		t.Errorf("code_definition = %q, want main.go:7 from the index", got)
+	}
+
+	res, _, _ = s.handleCodeDefinition(ctx, nil, CodeDefinitionInput{File: "util.go", Line: 4, Symbol: "missing"})
+	if !res.IsError {
+		t.Errorf("expected an error for an identifier not on the line, got %q", extractText(res))
+	}
+
+	res, _, _ = s.handleCodeRenameImpact(ctx, nil, CodeRenameImpactInput{Symbol: "run", NewName: "exec"})
+	got := extractText(res)
+	for _, want := range []string{"`run` → `exec`", "3 edits in 2 files", "source: index", "### `main.go`", "### `util.go`"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("code_rename_impact missing %q:\n%s", want, got)
+		}
+	}
+}
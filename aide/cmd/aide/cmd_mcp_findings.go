@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jmylchreest/aide/aide/pkg/aideignore"
 	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/store"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -15,36 +18,53 @@ import (
 
 type FindingsSearchInput struct {
 	Query           string `json:"query" jsonschema:"Search query for finding titles and details. Supports Bleve query syntax."`
-	Analyzer        string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos"`
+	Analyzer        string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license"`
 	Severity        string `json:"severity,omitempty" jsonschema:"Filter by severity: critical, warning, info"`
 	FilePath        string `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Category        string `json:"category,omitempty" jsonschema:"Filter by category"`
+	State           string `json:"state,omitempty" jsonschema:"Filter by triage state: open, acknowledged, snoozed, wont-fix, fixed"`
 	Limit           int    `json:"limit,omitempty" jsonschema:"Maximum results (default 20)"`
-	IncludeAccepted bool   `json:"include_accepted,omitempty" jsonschema:"Include accepted/acknowledged findings (hidden by default)"`
+	IncludeAccepted bool   `json:"include_accepted,omitempty" jsonschema:"Include accepted, snoozed, wont-fix and fixed findings (hidden by default)"`
 }
 
 type FindingsListInput struct {
-	Analyzer        string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos"`
+	Analyzer        string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license"`
 	Severity        string `json:"severity,omitempty" jsonschema:"Filter by severity: critical, warning, info"`
 	FilePath        string `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Category        string `json:"category,omitempty" jsonschema:"Filter by category"`
+	State           string `json:"state,omitempty" jsonschema:"Filter by triage state: open, acknowledged, snoozed, wont-fix, fixed"`
 	Limit           int    `json:"limit,omitempty" jsonschema:"Maximum results (default 100)"`
-	IncludeAccepted bool   `json:"include_accepted,omitempty" jsonschema:"Include accepted/acknowledged findings (hidden by default)"`
+	IncludeAccepted bool   `json:"include_accepted,omitempty" jsonschema:"Include accepted, snoozed, wont-fix and fixed findings (hidden by default)"`
 }
 
 type FindingsStatsInput struct {
-	IncludeAccepted bool `json:"include_accepted,omitempty" jsonschema:"Include accepted/acknowledged findings in counts (hidden by default)"`
+	State           string `json:"state,omitempty" jsonschema:"Only count findings in this triage state: open, acknowledged, snoozed, wont-fix, fixed"`
+	IncludeAccepted bool   `json:"include_accepted,omitempty" jsonschema:"Include accepted, snoozed, wont-fix and fixed findings in counts (hidden by default)"`
 }
 
 type FindingsAcceptInput struct {
 	IDs      []string `json:"ids,omitempty" jsonschema:"List of finding IDs to accept"`
 	All      bool     `json:"all,omitempty" jsonschema:"Accept all findings (optionally filtered by analyzer, severity, file, category)"`
-	Analyzer string   `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos"`
+	Analyzer string   `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license"`
 	Severity string   `json:"severity,omitempty" jsonschema:"Filter by severity: critical, warning, info"`
 	FilePath string   `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Category string   `json:"category,omitempty" jsonschema:"Filter by category"`
 }
 
+type FindingsTriageInput struct {
+	ID       string `json:"id" jsonschema:"Finding ID or fingerprint to triage"`
+	State    string `json:"state,omitempty" jsonschema:"New triage state: open, acknowledged, snoozed, wont-fix, fixed. Omit with history=true to read history."`
+	Reason   string `json:"reason,omitempty" jsonschema:"Why the state changed"`
+	Actor    string `json:"actor,omitempty" jsonschema:"Who is making the change (agent or user name)"`
+	Assignee string `json:"assignee,omitempty" jsonschema:"Who is handling the finding"`
+	Until    string `json:"until,omitempty" jsonschema:"Snooze expiry: a duration (7d, 12h) or date (2006-01-02). Required for snoozed."`
+	History  bool   `json:"history,omitempty" jsonschema:"Return the finding's triage history instead of changing state"`
+}
+
+type FindingsRunInput struct {
+	Since string `json:"since,omitempty" jsonschema:"Git ref to diff against (e.g. main, HEAD~1). Only files changed since the ref are analysed and only findings touching changed lines are reported. Omit for a full run."`
+}
+
 // =============================================================================
 // Findings MCP Tool Registration
 // =============================================================================
@@ -64,7 +84,7 @@ about a specific function, pattern, or issue type by name.
 - "complexity" → finds high-complexity functions
 - "clone" → finds duplicated code regions
 
-Filter by analyzer (complexity, coupling, secrets, clones, security, deadcode, todos),
+Filter by analyzer (complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license),
 severity (critical, warning, info), file path, or category.
 
 **Tip:** Use findings_list instead when browsing by category without a specific keyword.
@@ -84,7 +104,7 @@ Does not require a search query — use this to browse or get all findings for a
 - "Any secrets in the codebase?" → filter by analyzer=secrets
 - "What's duplicated?" → filter by analyzer=clones
 
-**Analyzers:** complexity, coupling, secrets, clones, security, deadcode, todos
+**Analyzers:** complexity, coupling, secrets, clones, security, deadcode, todos, custom, vulns, license
 **Severities:** critical (act now), warning (should fix), info (consider)`,
 	}, s.handleFindingsList)
 
@@ -94,6 +114,7 @@ Does not require a search query — use this to browse or get all findings for a
 
 Returns total finding count with breakdowns by analyzer and severity.
 Analyzers detect: complexity hotspots, hardcoded secrets, code duplication, import coupling, security vulnerabilities, and dead code (unreferenced symbols).
+Project-specific checks from .aide/rules appear under the custom analyzer, broken down per rule.
 
 **Start here** — call this first when asked about code quality, technical debt,
 security concerns, or before a code review. Then use findings_list or findings_search
@@ -120,6 +141,46 @@ Use this to mark known issues as reviewed or intentionally accepted.
 - Accept all complexity: {"all": true, "analyzer": "complexity"}
 - Accept all critical: {"all": true, "severity": "critical"}`,
 	}, s.handleFindingsAccept)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "findings_run",
+		Description: `Run the per-file analysers (complexity, secrets, security, todos, and custom
+when .aide/rules defines rules) and the project-wide ones (coupling, clones)
+now, and wait for them to finish.
+
+With "since", the run is diff-scoped: per-file analysers only visit files
+changed since the git ref (committed, staged, unstaged or untracked; secrets
+also scans changed non-source files such as .env), and the result lists only
+findings that touch changed lines — including coupling and clone findings in
+changed hunks. Use this as a pre-commit check on your own
+edits before handing work back.
+
+**Examples:**
+- Check uncommitted work: {"since": "HEAD"}
+- Check a branch: {"since": "main"}
+- Full refresh: {}
+
+Requires the file watcher (findings are otherwise populated by 'aide findings run').`,
+	}, s.handleFindingsRun)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "findings_triage",
+		Description: `Move a finding through the triage workflow so teammates and other agents
+can see what is being handled, or read its triage history.
+
+**States:** open, acknowledged (someone is on it), snoozed (hidden until a
+date), wont-fix (intentional), fixed. Wont-fix and fixed can only be reopened.
+Snoozed, wont-fix and fixed findings are hidden from list/search/stats unless
+filtered by state or include_accepted is set.
+
+Triage is keyed by the finding's fingerprint, so it survives re-analysis.
+Pass the id shown by findings_list / findings_search.
+
+**Examples:**
+- Claim a finding: {"id": "01J...", "state": "acknowledged", "actor": "agent-1", "assignee": "agent-1"}
+- Snooze: {"id": "01J...", "state": "snoozed", "until": "14d", "reason": "after release"}
+- History: {"id": "01J...", "history": true}`,
+	}, s.handleFindingsTriage)
 }
 
 // =============================================================================
@@ -138,6 +199,7 @@ func (s *MCPServer) handleFindingsSearch(_ context.Context, _ *mcp.CallToolReque
 		Severity:        input.Severity,
 		FilePath:        input.FilePath,
 		Category:        input.Category,
+		State:           input.State,
 		Limit:           input.Limit,
 		IncludeAccepted: input.IncludeAccepted,
 	}
@@ -173,6 +235,7 @@ func (s *MCPServer) handleFindingsList(_ context.Context, _ *mcp.CallToolRequest
 		Severity:        input.Severity,
 		FilePath:        input.FilePath,
 		Category:        input.Category,
+		State:           input.State,
 		Limit:           input.Limit,
 		IncludeAccepted: input.IncludeAccepted,
 	}
@@ -202,7 +265,7 @@ func (s *MCPServer) handleFindingsStats(_ context.Context, _ *mcp.CallToolReques
 		return errorResult("findings store not available"), nil, nil
 	}
 
-	stats, err := s.findingsStore.Stats(findings.SearchOptions{IncludeAccepted: input.IncludeAccepted})
+	stats, err := s.findingsStore.Stats(findings.SearchOptions{State: input.State, IncludeAccepted: input.IncludeAccepted})
 	if err != nil {
 		return errorResult(fmt.Sprintf("stats failed: %v", err)), nil, nil
 	}
@@ -225,6 +288,13 @@ func (s *MCPServer) handleFindingsStats(_ context.Context, _ *mcp.CallToolReques
 		}
 	}
 
+	if byRule := customRuleCounts(store.ProjectRootFromDB(s.dbPath), stats); len(byRule) > 0 {
+		sb.WriteString("\nBy custom rule:\n")
+		for _, id := range findings.SortedRuleCounts(byRule) {
+			fmt.Fprintf(&sb, "  %-24s %d\n", id, byRule[id])
+		}
+	}
+
 	return textResult(sb.String()), nil, nil
 }
 
@@ -264,6 +334,182 @@ func (s *MCPServer) handleFindingsAccept(_ context.Context, _ *mcp.CallToolReque
 	return textResult(fmt.Sprintf("Accepted %d findings.", count)), nil, nil
 }
 
+func (s *MCPServer) handleFindingsRun(ctx context.Context, _ *mcp.CallToolRequest, input FindingsRunInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: findings_run since=%q", input.Since)
+
+	if s.grpcClient != nil {
+		return errorResult("findings_run not supported in gRPC client mode — run 'aide findings run' instead"), nil, nil
+	}
+	if s.findingsStore == nil {
+		return errorResult("findings store not available"), nil, nil
+	}
+
+	s.unifiedWatcherMu.Lock()
+	runner := s.findingsRunner
+	s.unifiedWatcherMu.Unlock()
+	if runner == nil {
+		return errorResult("findings runner not available — enable the file watcher (--code-watch) or run 'aide findings run'"), nil, nil
+	}
+
+	if input.Since == "" {
+		if err := runner.RunAll(ctx); err != nil {
+			return errorResult(fmt.Sprintf("run failed: %v", err)), nil, nil
+		}
+		runner.WaitAll()
+
+		stats, err := s.findingsStore.Stats(findings.SearchOptions{})
+		if err != nil {
+			return errorResult(fmt.Sprintf("stats failed: %v", err)), nil, nil
+		}
+		return textResult(fmt.Sprintf("Analysis complete: %d findings. Use findings_list to browse them.", stats.Total)), nil, nil
+	}
+
+	projectRoot := store.ProjectRootFromDB(s.dbPath)
+	ignore, err := aideignore.New(projectRoot)
+	if err != nil {
+		ignore = aideignore.NewFromDefaults()
+	}
+	scope, err := findings.ComputeDiffScope(projectRoot, input.Since, ignore)
+	if err != nil {
+		return errorResult(fmt.Sprintf("diff failed: %v", err)), nil, nil
+	}
+	if scope.Empty() {
+		return textResult(fmt.Sprintf("No changes since %s.", input.Since)), nil, nil
+	}
+
+	if err := runner.RunDiff(ctx, scope); err != nil {
+		return errorResult(fmt.Sprintf("run failed: %v", err)), nil, nil
+	}
+	runner.WaitAll()
+
+	all, err := s.findingsStore.ListFindings(findings.SearchOptions{Limit: -1})
+	if err != nil {
+		return errorResult(fmt.Sprintf("list failed: %v", err)), nil, nil
+	}
+	results := scope.Filter(all)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Diff since %s (%.12s): %d changed, %d deleted files.\n",
+		scope.Ref, scope.Commit, len(scope.Files), len(scope.Deleted))
+	if len(results) == 0 {
+		sb.WriteString("No findings touch the changed lines.")
+		return textResult(sb.String()), nil, nil
+	}
+	fmt.Fprintf(&sb, "%d findings touch the changed lines:\n\n", len(results))
+	for _, f := range results {
+		sb.WriteString(formatFindingLine(f))
+	}
+	return textResult(sb.String()), nil, nil
+}
+
+func (s *MCPServer) handleFindingsTriage(_ context.Context, _ *mcp.CallToolRequest, input FindingsTriageInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: findings_triage id=%s state=%s history=%v", input.ID, input.State, input.History)
+
+	if s.findingsStore == nil {
+		return errorResult("findings store not available"), nil, nil
+	}
+	ts, ok := s.findingsStore.(store.FindingsTriageStore)
+	if !ok {
+		return errorResult("findings store does not support triage"), nil, nil
+	}
+	if input.ID == "" {
+		return errorResult("id is required"), nil, nil
+	}
+
+	fp, f, err := ts.ResolveTriageRef(input.ID)
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+
+	if input.History {
+		history, err := ts.TriageHistory(fp)
+		if err != nil {
+			return errorResult(fmt.Sprintf("history failed: %v", err)), nil, nil
+		}
+		if len(history) == 0 {
+			return textResult(fmt.Sprintf("No triage history for %s (state: open).", fp)), nil, nil
+		}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Triage history for %s:\n\n", fp)
+		for _, ev := range history {
+			fmt.Fprintf(&sb, "%s %s -> %s", ev.CreatedAt.Format(time.RFC3339), ev.From, ev.To)
+			if ev.Actor != "" {
+				fmt.Fprintf(&sb, " by %s", ev.Actor)
+			}
+			if ev.Assignee != "" {
+				fmt.Fprintf(&sb, " (assignee: %s)", ev.Assignee)
+			}
+			if !ev.Until.IsZero() {
+				fmt.Fprintf(&sb, " until %s", ev.Until.Format(time.RFC3339))
+			}
+			if ev.Reason != "" {
+				fmt.Fprintf(&sb, ": %s", ev.Reason)
+			}
+			sb.WriteString("\n")
+		}
+		return textResult(sb.String()), nil, nil
+	}
+
+	to, err := findings.ParseTriageState(input.State)
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+	until, err := parseTriageUntil(input.Until, time.Now())
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+
+	ev := &findings.TriageEvent{
+		Fingerprint: fp,
+		To:          to,
+		Reason:      input.Reason,
+		Actor:       input.Actor,
+		Assignee:    input.Assignee,
+		Until:       until,
+	}
+	if f != nil {
+		ev.FindingID = f.ID
+	}
+	st, err := ts.TriageFinding(ev)
+	if err != nil {
+		return errorResult(fmt.Sprintf("triage failed: %v", err)), nil, nil
+	}
+	s.publishTriage(ev)
+
+	msg := fmt.Sprintf("%s: %s -> %s", fp, ev.From, st.State)
+	if f != nil {
+		msg = fmt.Sprintf("%s (%s)", msg, f.Title)
+	}
+	if !st.Until.IsZero() {
+		msg += " until " + st.Until.Format(time.RFC3339)
+	}
+	return textResult(msg + "."), nil, nil
+}
+
+// publishTriage fans a triage transition out on the daemon's triage bus.
+// No-op outside primary mode.
+func (s *MCPServer) publishTriage(ev *findings.TriageEvent) {
+	if s.grpcServer == nil {
+		return
+	}
+	if bus := s.grpcServer.TriageBus(); bus != nil {
+		bus.Publish(ev)
+	}
+}
+
+// latestSessionID returns the session of the most recent observe event, used
+// to attribute fixes the watcher detects. Empty when unknown.
+func (s *MCPServer) latestSessionID() string {
+	if s.store == nil {
+		return ""
+	}
+	events, err := s.store.ListObserveEvents(store.ObserveFilter{Limit: 1})
+	if err != nil || len(events) == 0 {
+		return ""
+	}
+	return events[0].SessionID
+}
+
 // =============================================================================
 // Formatting Helpers
 // =============================================================================
@@ -274,5 +520,5 @@ func formatFindingLine(f *findings.Finding) string {
 	if f.Line > 0 {
 		loc = fmt.Sprintf("%s:%d", f.FilePath, f.Line)
 	}
-	return fmt.Sprintf("[%s] %s - %s (%s)\n", severity, loc, f.Title, f.Analyzer)
+	return fmt.Sprintf("[%s] %s - %s (%s) id=%s%s\n", severity, loc, f.Title, f.Analyzer, f.ID, suppressedSuffix(f))
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// =============================================================================
+// MCP Handler Tests: handleFindingsTriage
+// =============================================================================
+
+func newTestFindingsServer(t *testing.T) (*MCPServer, *store.FindingsStoreImpl) {
+	t.Helper()
+	fs, err := store.NewFindingsStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create findings store: %v", err)
+	}
+	t.Cleanup(func() { fs.Close() })
+	return &MCPServer{findingsStore: fs}, fs
+}
+
+func TestHandleFindingsTriage_NilStore(t *testing.T) {
+	s := &MCPServer{}
+	result, _, err := s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{ID: "x", State: "acknowledged"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertIsError(t, result, "findings store not available")
+}
+
+func TestHandleFindingsTriage_Lifecycle(t *testing.T) {
+	s, fs := newTestFindingsServer(t)
+	f := &findings.Finding{Analyzer: findings.AnalyzerSecurity, Severity: findings.SevWarning, FilePath: "db.go", Line: 4, Title: "SQL concat"}
+	if err := fs.AddFinding(f); err != nil {
+		t.Fatalf("AddFinding: %v", err)
+	}
+
+	result, _, _ := s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{
+		ID: f.ID, State: "snoozed", Until: "7d", Actor: "agent-1", Reason: "after release",
+	})
+	assertTextContains(t, result, "open -> snoozed")
+
+	// Snoozed findings drop out of the default listing but match a state filter.
+	result, _, _ = s.handleFindingsList(context.Background(), nil, FindingsListInput{})
+	assertTextContains(t, result, "No findings found")
+	result, _, _ = s.handleFindingsList(context.Background(), nil, FindingsListInput{State: "snoozed"})
+	assertTextContains(t, result, "id="+f.ID)
+
+	result, _, _ = s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{ID: f.Fingerprint(), History: true})
+	assertTextContains(t, result, "open -> snoozed by agent-1")
+	assertTextContains(t, result, "after release")
+}
+
+func TestHandleFindingsTriage_InvalidTransition(t *testing.T) {
+	s, fs := newTestFindingsServer(t)
+	f := &findings.Finding{Analyzer: findings.AnalyzerTodos, FilePath: "a.go", Title: "TODO: tidy"}
+	if err := fs.AddFinding(f); err != nil {
+		t.Fatalf("AddFinding: %v", err)
+	}
+
+	result, _, _ := s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{ID: f.ID, State: "wont-fix"})
+	assertTextContains(t, result, "open -> wont-fix")
+
+	result, _, _ = s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{ID: f.ID, State: "acknowledged"})
+	assertIsError(t, result, "invalid triage transition")
+
+	result, _, _ = s.handleFindingsTriage(context.Background(), nil, FindingsTriageInput{ID: f.ID, State: "snoozed"})
+	assertIsError(t, result, "triage failed")
+}
+
+func TestParseTriageUntil(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{"", time.Time{}, false},
+		{"7d", now.Add(7 * 24 * time.Hour), false},
+		{"12h", now.Add(12 * time.Hour), false},
+		{"2026-02-01T00:00:00Z", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), false},
+		{"next week", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTriageUntil(tt.in, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTriageUntil(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTriageUntil(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -22,15 +22,15 @@ import (
 
 type SurveySearchInput struct {
 	Query    string `json:"query" jsonschema:"Search query for survey entry names, titles, and details. Supports Bleve query syntax."`
-	Analyzer string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: topology, entrypoints, churn"`
-	Kind     string `json:"kind,omitempty" jsonschema:"Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern"`
+	Analyzer string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history"`
+	Kind     string `json:"kind,omitempty" jsonschema:"Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern, license, ownership, cochange, hotspot, snapshot"`
 	FilePath string `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum results (default 20)"`
 }
 
 type SurveyListInput struct {
-	Analyzer string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: topology, entrypoints, churn"`
-	Kind     string `json:"kind,omitempty" jsonschema:"Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern"`
+	Analyzer string `json:"analyzer,omitempty" jsonschema:"Filter by analyzer: topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history"`
+	Kind     string `json:"kind,omitempty" jsonschema:"Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern, license, ownership, cochange, hotspot, snapshot"`
 	FilePath string `json:"file,omitempty" jsonschema:"Filter by file path pattern (substring match)"`
 	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum results (default 100)"`
 }
@@ -38,14 +38,25 @@ type SurveyListInput struct {
 type SurveyStatsInput struct{}
 
 type SurveyRunInput struct {
-	Analyzer string `json:"analyzer,omitempty" jsonschema:"Run a specific analyzer: topology, entrypoints, churn, modules. Omit to run all."`
+	Analyzer string `json:"analyzer,omitempty" jsonschema:"Run a specific analyzer: topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history. Omit to run all."`
+}
+
+type SurveyLicensesInput struct {
+	Check string `json:"check,omitempty" jsonschema:"SPDX license expression of a dependency you are about to add (e.g. 'GPL-3.0', 'MIT OR Apache-2.0'); returns whether the project's license policy allows it"`
+}
+
+type SurveyHotspotsInput struct {
+	Scope string `json:"scope,omitempty" jsonschema:"file, function or all (default all)"`
+	File  string `json:"file,omitempty" jsonschema:"Only hotspots whose path contains this (e.g. 'pkg/store/')"`
+	Limit int    `json:"limit,omitempty" jsonschema:"Maximum hotspots per scope (default 15)"`
 }
 
 type SurveyGraphInput struct {
-	Symbol    string `json:"symbol" jsonschema:"Name of the symbol to start traversal from (e.g. 'BuildCallGraph', 'handleSurveyRun')."`
+	Symbol    string `json:"symbol" jsonschema:"Name of the symbol to start traversal from (e.g. 'BuildCallGraph', 'handleSurveyRun'), optionally qualified to disambiguate (e.g. 'store.CodeStore.Close')."`
 	Direction string `json:"direction,omitempty" jsonschema:"Traversal direction: both (default), callers, callees"`
 	MaxDepth  int    `json:"max_depth,omitempty" jsonschema:"Maximum BFS hops from root (default 2)"`
 	MaxNodes  int    `json:"max_nodes,omitempty" jsonschema:"Maximum nodes in graph (default 50)"`
+	Exact     bool   `json:"exact,omitempty" jsonschema:"Only follow references the indexer resolved to a definition, dropping same-name guesses"`
 }
 
 // =============================================================================
@@ -71,7 +82,7 @@ for issues like complexity or security).
 - "React" → finds tech stack entries for React framework
 - "main" → finds main() entry points
 
-Filter by analyzer (topology, entrypoints, churn),
+Filter by analyzer (topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history),
 kind (module, entrypoint, dependency, tech_stack, churn, etc.), or file path.
 
 **Tip:** Use survey_list to browse by kind without a search keyword.
@@ -94,10 +105,15 @@ for code health issues).
 - "Where are the entry points?" → kind=entrypoint
 - "What technologies does this use?" → kind=tech_stack
 - "What files change most?" → kind=churn
+- "Do we already depend on a YAML library?" → kind=dependency (or survey_search "yaml")
+- "How is this codebase structured — layered, hexagonal, plugins?" → kind=arch_pattern
+- "Which licenses do our dependencies use?" → kind=license (or survey_licenses)
+- "Who should I ask about pkg/store?" → kind=ownership, file=pkg/store
+- "Which files change together across modules?" → kind=cochange (per file: code_cochange)
 - "What's in src/auth/?" → filter by file path
 
-**Kinds:** module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern
-**Analyzers:** topology (structure), entrypoints (entry points), churn (git history)`,
+**Kinds:** module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern, license, ownership, cochange, hotspot, snapshot
+**Analyzers:** topology (structure), entrypoints (entry points), churn (git history), modules (import-graph clusters), ownership (authors and bus factor from git history), cochange (files that change together), dependencies (manifests and lockfiles), architecture (layering, ports/adapters, plugin registries), licenses (LICENSE/COPYING files by SPDX id)`,
 	}, s.handleSurveyList)
 
 	mcp.AddTool(s.server, &mcp.Tool{
@@ -132,6 +148,34 @@ Analyzers scan the codebase to discover:
 - **modules**: Structural modules found by clustering the import/reference
   graph — what files actually BELONG together, which directory layout can
   hide. Requires the code index ('aide code index').
+- **ownership**: Who knows which code — primary author, bus factor and
+  recently active owners per module and per file, from git history weighted
+  by lines and recency, merged with CODEOWNERS. Modules with bus factor 1
+  carry bus_factor_risk=true. Uses the module clusters when present.
+- **cochange**: Temporal coupling — pairs of files that keep changing in
+  the same commits, with support and directional confidence; commits
+  touching more than 30 files are skipped as noise. Query per file with
+  code_cochange.
+- **hotspots**: Churn × complexity — the churn analyzer's files joined with
+  per-function complexity from the code index or complexity findings,
+  ranked by a risk score that favours recent change. Run after churn and
+  'aide findings run complexity'; query with survey_hotspots.
+- **dependencies**: External dependencies from manifests and lockfiles
+  (go.mod, package.json, Cargo.toml, pyproject, pom/gradle, csproj) with
+  version, scope, direct/transitive, and which files import each one (the
+  importers need the code index).
+- **architecture**: Architectural patterns — layered (handler → service →
+  repository), hexagonal ports/adapters, CQRS, MVC, plugin registries,
+  vertical slices — inferred from directory conventions, import direction
+  and the module clusters, each with a confidence and evidence files.
+  Requires the code index; run after modules.
+- **licenses**: LICENSE/COPYING files of the project and of vendored or
+  installed dependencies (vendor/, node_modules/, third_party/, ...),
+  classified by SPDX identifier against a bundled license corpus.
+- **history**: A compact snapshot of HEAD (module membership, entrypoints,
+  dependency count, busiest files), recorded automatically whenever modules,
+  entrypoints, dependencies or churn run in a git repository; compare
+  commits with 'aide survey history' and 'aide survey diff'.
 
 Run all analyzers (omit analyzer param) or a specific one.
 Results are cached and tagged with the git commit at run time — re-run to
@@ -171,6 +215,54 @@ full blast radius of a change. All transitive callers are potential breakage poi
 **Note:** Requires the code index to be populated (run 'aide code index').
 Computed on demand — not stored. Results reflect the current code index state.`,
 	}, s.handleSurveyGraph)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "survey_licenses",
+		Description: `Check a dependency license against the project's license policy.
+
+**Call this BEFORE adding a dependency** with check set to its SPDX license
+(e.g. check="GPL-3.0"). The verdict is allowed, denied, not-allowed (outside
+an explicit allow list) or unknown. Do not add a dependency whose license is
+denied or not allowed — pick an alternative or ask the user.
+
+Also returns the project's own license, the policy in force, the license
+inventory of vendored/installed dependencies grouped by SPDX id, and the
+current violations.
+
+The policy is "findings.licenses" in .aide/config/aide.json:
+{"allow": [...], "deny": [...]} of SPDX ids, globs ("GPL-*") or categories
+(public-domain, permissive, weak-copyleft, strong-copyleft,
+network-copyleft). Without one, categories more restrictive than both the
+project's license and weak copyleft are denied — an Apache-2.0 project
+refuses GPL and AGPL.
+
+**Note:** The inventory comes from the licenses survey analyzer (survey_run
+analyzer=licenses); check works even before it has run.`,
+	}, s.handleSurveyLicenses)
+
+	mcp.AddTool(s.server, &mcp.Tool{
+		Name: "survey_hotspots",
+		Description: `Rank files and functions by churn × complexity — where to refactor first.
+
+Code that is both complex and keeps changing is where defects concentrate;
+complex code nobody touches, and busy code that stays simple, rank low.
+Each hotspot has a risk score (0-1, relative to the worst in this
+repository), its recent churn (commits weighted by age, halving every 90
+days), its total commit count and its cyclomatic complexity. Functions
+carry their file's churn; "over threshold" marks those a complexity
+finding reports.
+
+**Use cases:**
+- "Where should refactoring effort go?" → scope=all
+- "Which functions are riskiest to touch?" → scope=function
+- "How risky is this package?" → file="pkg/store/"
+- Before a large change, to know which files need extra care and tests
+
+**Note:** Comes from the hotspots survey analyzer (survey_run
+analyzer=hotspots), which joins the churn analyzer's files with complexity
+from the code index or from complexity findings ('aide findings run
+complexity'); run those first.`,
+	}, s.handleSurveyHotspots)
 }
 
 // =============================================================================
@@ -251,6 +343,49 @@ func (s *MCPServer) handleSurveyList(ctx context.Context, _ *mcp.CallToolRequest
 	return textResult(respText), nil, nil
 }
 
+func (s *MCPServer) handleSurveyLicenses(_ context.Context, _ *mcp.CallToolRequest, input SurveyLicensesInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: survey_licenses check=%q", input.Check)
+
+	if s.surveyStore == nil {
+		return errorResult("survey store not available"), nil, nil
+	}
+
+	entries, err := s.surveyStore.ListEntries(survey.SearchOptions{
+		Analyzer: survey.AnalyzerLicenses,
+		Kind:     survey.KindLicense,
+		Limit:    -1,
+	})
+	if err != nil {
+		return errorResult(fmt.Sprintf("list failed: %v", err)), nil, nil
+	}
+
+	policy := loadFindingsConfig(store.ProjectRootFromDB(s.dbPath)).Licenses
+	report, err := buildLicenseReport(entries, policy, input.Check)
+	if err != nil {
+		return errorResult(fmt.Sprintf("license report failed: %v", err)), nil, nil
+	}
+
+	respText := formatLicenseReport(report)
+	if len(entries) == 0 {
+		respText += "\nNo licenses surveyed yet — run survey_run with analyzer=licenses for the inventory.\n"
+	}
+	return textResult(respText), nil, nil
+}
+
+func (s *MCPServer) handleSurveyHotspots(_ context.Context, _ *mcp.CallToolRequest, input SurveyHotspotsInput) (*mcp.CallToolResult, any, error) {
+	mcpLog.Printf("tool: survey_hotspots scope=%s file=%q limit=%d", input.Scope, input.File, input.Limit)
+
+	if s.surveyStore == nil {
+		return errorResult("survey store not available"), nil, nil
+	}
+
+	report, err := buildHotspotsReport(s.surveyStore.ListEntries, input.Scope, input.File, input.Limit)
+	if err != nil {
+		return errorResult(err.Error()), nil, nil
+	}
+	return textResult(formatHotspotsReport(report)), nil, nil
+}
+
 func (s *MCPServer) handleSurveyStats(_ context.Context, _ *mcp.CallToolRequest, input SurveyStatsInput) (*mcp.CallToolResult, any, error) {
 	mcpLog.Printf("tool: survey_stats")
 
@@ -355,7 +490,7 @@ func (s *MCPServer) handleSurveyRun(ctx context.Context, _ *mcp.CallToolRequest,
 	if input.Analyzer != "" {
 		analyzers = []string{input.Analyzer}
 	}
-	results := surveyrun.Run(store.ProjectRootFromDB(s.dbPath), analyzers, s.surveyStore, s.getCodeStore())
+	results := surveyrun.Run(store.ProjectRootFromDB(s.dbPath), analyzers, s.surveyStore, s.getCodeStore(), s.findingsStore)
 	return textResult(surveyrun.FormatResults(results)), nil, nil
 }
 
@@ -377,6 +512,7 @@ func (s *MCPServer) handleSurveyGraph(ctx context.Context, _ *mcp.CallToolReques
 		MaxDepth:  input.MaxDepth,
 		MaxNodes:  input.MaxNodes,
 		Direction: input.Direction,
+		Exact:     input.Exact,
 	}
 
 	graph, err := survey.BuildCallGraph(cg, input.Symbol, opts)
@@ -491,12 +627,14 @@ func (a *codeSearcherAdapter) FindSymbols(query string, kind string, limit int)
 	hits := make([]survey.SymbolHit, 0, len(results))
 	for _, r := range results {
 		hits = append(hits, survey.SymbolHit{
-			Name:     r.Symbol.Name,
-			Kind:     r.Symbol.Kind,
-			FilePath: r.Symbol.FilePath,
-			Line:     r.Symbol.StartLine,
-			EndLine:  r.Symbol.EndLine, // callee scans need the body range; 0 = empty range = no callees
-			Language: r.Symbol.Language,
+			ID:            r.Symbol.ID,
+			Name:          r.Symbol.Name,
+			QualifiedName: r.Symbol.QualifiedName,
+			Kind:          r.Symbol.Kind,
+			FilePath:      r.Symbol.FilePath,
+			Line:          r.Symbol.StartLine,
+			EndLine:       r.Symbol.EndLine, // callee scans need the body range; 0 = empty range = no callees
+			Language:      r.Symbol.Language,
 		})
 	}
 	return hits, nil
@@ -515,10 +653,12 @@ func (a *codeSearcherAdapter) FindReferences(symbolName string, kind string, lim
 	hits := make([]survey.ReferenceHit, 0, len(results))
 	for _, r := range results {
 		hits = append(hits, survey.ReferenceHit{
-			Symbol:   r.SymbolName,
-			Kind:     r.Kind,
-			FilePath: r.FilePath,
-			Line:     r.Line,
+			Symbol:     r.SymbolName,
+			Kind:       r.Kind,
+			FilePath:   r.FilePath,
+			Line:       r.Line,
+			TargetID:   r.TargetSymbolID,
+			Confidence: r.Confidence,
 		})
 	}
 	return hits, nil
@@ -543,10 +683,12 @@ func (a *codeGrapherAdapter) GetFileReferences(filePath string) ([]survey.Refere
 	hits := make([]survey.ReferenceHit, 0, len(refs))
 	for _, r := range refs {
 		hits = append(hits, survey.ReferenceHit{
-			Symbol:   r.SymbolName,
-			Kind:     r.Kind,
-			FilePath: r.FilePath,
-			Line:     r.Line,
+			Symbol:     r.SymbolName,
+			Kind:       r.Kind,
+			FilePath:   r.FilePath,
+			Line:       r.Line,
+			TargetID:   r.TargetSymbolID,
+			Confidence: r.Confidence,
 		})
 	}
 	return hits, nil
@@ -561,12 +703,14 @@ func (a *codeGrapherAdapter) GetContainingSymbol(filePath string, line int) (*su
 		return nil, nil
 	}
 	return &survey.SymbolHit{
-		Name:     sym.Name,
-		Kind:     sym.Kind,
-		FilePath: sym.FilePath,
-		Line:     sym.StartLine,
-		EndLine:  sym.EndLine,
-		Language: sym.Language,
+		ID:            sym.ID,
+		Name:          sym.Name,
+		QualifiedName: sym.QualifiedName,
+		Kind:          sym.Kind,
+		FilePath:      sym.FilePath,
+		Line:          sym.StartLine,
+		EndLine:       sym.EndLine,
+		Language:      sym.Language,
 	}, nil
 }
 
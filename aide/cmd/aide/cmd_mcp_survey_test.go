@@ -438,6 +438,62 @@ func TestFormatSurveyEntryLine(t *testing.T) {
 	}
 }
 
+func hotspotTestEntry(scope, file, function, risk, complexity string) *survey.Entry {
+	meta := map[string]string{"scope": scope, "risk": risk, "churn": "4.50", "commits": "12", "complexity": complexity}
+	name := file
+	if function != "" {
+		meta["function"] = function
+		meta["line"] = "42"
+		name = function
+	}
+	return &survey.Entry{Analyzer: survey.AnalyzerHotspots, Kind: survey.KindHotspot, Name: name, FilePath: file, Metadata: meta}
+}
+
+func TestHandleSurveyHotspots_NotSurveyed(t *testing.T) {
+	s := newTestMCPServer(newMockSurveyStore())
+	result, _, err := s.handleSurveyHotspots(context.Background(), nil, SurveyHotspotsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertTextContains(t, result, "analyzer=hotspots")
+}
+
+func TestHandleSurveyHotspots_Ranking(t *testing.T) {
+	ms := newMockSurveyStore()
+	ms.entries = []*survey.Entry{
+		hotspotTestEntry(survey.HotspotScopeFile, "pkg/store/code.go", "", "0.40", "120"),
+		hotspotTestEntry(survey.HotspotScopeFile, "cmd/aide/cmd_mcp.go", "", "0.90", "300"),
+		hotspotTestEntry(survey.HotspotScopeFunction, "cmd/aide/cmd_mcp.go", "runMCP", "0.75", "38"),
+	}
+	ms.entries[2].Metadata["flagged"] = "true"
+	s := newTestMCPServer(ms)
+
+	result, _, err := s.handleSurveyHotspots(context.Background(), nil, SurveyHotspotsInput{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(result)
+	for _, want := range []string{
+		"0.90     4.5    300  cmd/aide/cmd_mcp.go",
+		"0.75     4.5     38  runMCP  cmd/aide/cmd_mcp.go:42  [over threshold]",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "cmd_mcp.go\n") > strings.Index(text, "pkg/store/code.go") {
+		t.Errorf("files not ordered by risk:\n%s", text)
+	}
+
+	result, _, _ = s.handleSurveyHotspots(context.Background(), nil, SurveyHotspotsInput{Scope: "file", File: "pkg/store/"})
+	if text := extractText(result); strings.Contains(text, "cmd_mcp.go") || !strings.Contains(text, "pkg/store/code.go") {
+		t.Errorf("scope/file filters not applied:\n%s", text)
+	}
+
+	result, _, _ = s.handleSurveyHotspots(context.Background(), nil, SurveyHotspotsInput{Scope: "module"})
+	assertIsError(t, result, "invalid scope")
+}
+
 // =============================================================================
 // Test Helpers
 // =============================================================================
@@ -473,13 +529,30 @@ func (m *mockCodeIndexStore) GetFileSymbols(filePath string) ([]*code.Symbol, er
 func (m *mockCodeIndexStore) GetFileInfo(path string) (*code.FileInfo, error) { return nil, nil }
 func (m *mockCodeIndexStore) ListAllFileInfo() ([]*code.FileInfo, error)      { return nil, nil }
 func (m *mockCodeIndexStore) ClearFile(filePath string) error                 { return nil }
-func (m *mockCodeIndexStore) IndexFileBatch(filePath string, symbols []*code.Symbol, refs []*code.Reference, mtime time.Time, sizeBytes int64) error {
+func (m *mockCodeIndexStore) IndexFileBatch(filePath string, symbols []*code.Symbol, refs []*code.Reference, edges []*code.TypeEdge, mtime time.Time, sizeBytes int64) error {
 	return nil
 }
+func (m *mockCodeIndexStore) IndexFileDelta(filePath string, symbols []*code.Symbol, refs []*code.Reference, edges []*code.TypeEdge, mtime time.Time, sizeBytes int64) (store.FileDelta, error) {
+	return store.FileDelta{}, nil
+}
 func (m *mockCodeIndexStore) ClearFileReferences(filePath string) error { return nil }
-func (m *mockCodeIndexStore) Stats() (*code.IndexStats, error)          { return nil, nil }
-func (m *mockCodeIndexStore) Clear() error                              { return nil }
-func (m *mockCodeIndexStore) Close() error                              { return nil }
+func (m *mockCodeIndexStore) ResolveReferenceTargets([]string, code.TargetResolver) (int, error) {
+	return 0, nil
+}
+func (m *mockCodeIndexStore) GetFileTypeEdges(string) ([]*code.TypeEdge, error)    { return nil, nil }
+func (m *mockCodeIndexStore) FindImplementations(string) ([]*code.TypeEdge, error) { return nil, nil }
+func (m *mockCodeIndexStore) FindSupertypes(string) ([]*code.TypeEdge, error)      { return nil, nil }
+func (m *mockCodeIndexStore) GetLSPCache(string, string) ([]byte, error)           { return nil, nil }
+func (m *mockCodeIndexStore) PutLSPCache(string, string, []byte) error             { return nil }
+func (m *mockCodeIndexStore) GetFileHistory(string) (*code.FileHistory, error)     { return nil, nil }
+func (m *mockCodeIndexStore) PutFileHistory(*code.FileHistory) error               { return nil }
+func (m *mockCodeIndexStore) Stats() (*code.IndexStats, error)                     { return nil, nil }
+func (m *mockCodeIndexStore) Clear() error                                         { return nil }
+func (m *mockCodeIndexStore) Close() error                                         { return nil }
+
+func (m *mockCodeIndexStore) SemanticSearchSymbols(string, code.SearchOptions) ([]*store.CodeSearchResult, error) {
+	return nil, store.ErrEmbeddingsDisabled
+}
 
 func (m *mockCodeIndexStore) SearchSymbols(query string, opts code.SearchOptions) ([]*store.CodeSearchResult, error) {
 	if m.searchSymErr != nil {
@@ -755,6 +828,71 @@ func TestCodeGrapherAdapter_GetContainingSymbol_NotFound(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// MCP Handler Tests: handleCodeCoChange
+// =============================================================================
+
+func coChangeTestEntry(a, b, support, commitsA, commitsB, confAB, confBA string) *survey.Entry {
+	return &survey.Entry{
+		Analyzer: survey.AnalyzerCoChange,
+		Kind:     survey.KindCoChange,
+		Name:     a + " <-> " + b,
+		FilePath: a,
+		Metadata: map[string]string{
+			"file_a": a, "file_b": b, "support": support,
+			"commits_a": commitsA, "commits_b": commitsB,
+			"confidence_ab": confAB, "confidence_ba": confBA,
+			"last_together": "2026-10-01",
+		},
+	}
+}
+
+func TestHandleCodeCoChange_NotSurveyed(t *testing.T) {
+	s := newTestMCPServer(newMockSurveyStore())
+	result, _, err := s.handleCodeCoChange(context.Background(), nil, CodeCoChangeInput{File: "aidememory.proto"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertTextContains(t, result, "analyzer=cochange")
+}
+
+func TestHandleCodeCoChange_Partners(t *testing.T) {
+	ms := newMockSurveyStore()
+	ms.entries = []*survey.Entry{
+		coChangeTestEntry("aide/pkg/grpcapi/aidememory.pb.go", "aide/proto/aidememory.proto", "9", "10", "9", "0.90", "1.00"),
+		coChangeTestEntry("aide/pkg/grpcapi/adapter/proto.go", "aide/proto/aidememory.proto", "6", "20", "9", "0.30", "0.67"),
+		coChangeTestEntry("README.md", "docs/index.md", "5", "5", "5", "1.00", "1.00"),
+	}
+	ms.entries[1].Metadata["same_module"] = "false"
+	s := newTestMCPServer(ms)
+
+	result, _, err := s.handleCodeCoChange(context.Background(), nil, CodeCoChangeInput{File: "aidememory.proto"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := extractText(result)
+	for _, want := range []string{
+		"Files that change together with aide/proto/aidememory.proto:",
+		"aide/pkg/grpcapi/aidememory.pb.go — 9 of 9 commits (100%)",
+		"aide/pkg/grpcapi/adapter/proto.go — 6 of 9 commits (67%), last together 2026-10-01, other module",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "README") {
+		t.Errorf("unrelated pair listed:\n%s", text)
+	}
+	if strings.Index(text, "aidememory.pb.go") > strings.Index(text, "adapter/proto.go") {
+		t.Errorf("partners not ordered by confidence:\n%s", text)
+	}
+
+	result, _, _ = s.handleCodeCoChange(context.Background(), nil, CodeCoChangeInput{File: "aide/proto/aidememory.proto", MinConfidence: 0.8})
+	if text := extractText(result); strings.Contains(text, "adapter/proto.go") {
+		t.Errorf("min_confidence not applied:\n%s", text)
+	}
+}
+
 // =============================================================================
 // Test Helpers
 // =============================================================================
@@ -49,6 +49,10 @@ type SessionInitResult struct {
 	CodebaseMap     []SessionModule `json:"codebase_map,omitempty"`
 	CodebaseMapNote string          `json:"codebase_map_note,omitempty"` // freshness, e.g. "as of a1b2c3d4 — 3 commits behind"
 
+	// RepoMap — rendered code_repo_map outline, when code.repo_map_tokens
+	// is set. Direct mode only: the map reads the whole code index.
+	RepoMap string `json:"repo_map,omitempty"`
+
 	// Estate — parent projects from the anchor chain (upward) and direct
 	// child subprojects from survey (downward). Omitted for standalone
 	// repos with no surveyed children.
@@ -71,9 +75,11 @@ type SessionEstateNode struct {
 
 // SessionModule is one Codebase Map line for JSON output.
 type SessionModule struct {
-	Name string `json:"name"`
-	Size int    `json:"size"`
-	Hub  string `json:"hub"`
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Hub       string `json:"hub"`
+	Owner     string `json:"owner,omitempty"`      // primary author, from the ownership analyzer
+	BusFactor int    `json:"bus_factor,omitempty"` // 0 when ownership has not run
 }
 
 // sessionModuleLimit caps how many modules the Codebase Map carries — it is
@@ -687,8 +693,16 @@ func sessionFetchEstate(backend *Backend, result *SessionInitResult) {
 // sessionFetchCodebaseMap loads the module map produced by the survey
 // modules analyzer: largest modules first, capped, with a freshness note so
 // a stale map says so instead of being silently trusted. Absent entries
-// (analyzer never ran) leave the section empty — no nagging.
+// (analyzer never ran) leave the section empty — no nagging. Modules the
+// ownership analyzer has covered carry their primary owner and bus factor.
+// With code.repo_map_tokens set it also renders a repo map of that budget.
 func sessionFetchCodebaseMap(backend *Backend, result *SessionInitResult) {
+	if tokens := config.Get().Code.RepoMapTokens; tokens > 0 {
+		if m, err := backend.RepoMap(survey.RepoMapOptions{Tokens: tokens}); err == nil && m.Symbols > 0 {
+			result.RepoMap = m.Render()
+		}
+	}
+
 	entries, err := backend.ListSurvey(survey.SearchOptions{Analyzer: survey.AnalyzerModules, Limit: 1000})
 	if err != nil || len(entries) == 0 {
 		return
@@ -703,16 +717,30 @@ func sessionFetchCodebaseMap(backend *Backend, result *SessionInitResult) {
 		return entries[i].Name < entries[j].Name
 	})
 
+	owners := make(map[string]*survey.Entry)
+	if ownership, err := backend.ListSurvey(survey.SearchOptions{Analyzer: survey.AnalyzerOwnership, Limit: -1}); err == nil {
+		for _, e := range ownership {
+			if e.Metadata["scope"] == survey.OwnershipScopeModule {
+				owners[e.Name] = e
+			}
+		}
+	}
+
 	for _, e := range entries {
 		if len(result.CodebaseMap) >= sessionModuleLimit {
 			break
 		}
 		size, _ := strconv.Atoi(e.Metadata["size"])
-		result.CodebaseMap = append(result.CodebaseMap, SessionModule{
+		mod := SessionModule{
 			Name: e.Name,
 			Size: size,
 			Hub:  e.Metadata["hub"],
-		})
+		}
+		if o := owners[e.Name]; o != nil {
+			mod.Owner = o.Metadata["owner"]
+			mod.BusFactor, _ = strconv.Atoi(o.Metadata["bus_factor"])
+		}
+		result.CodebaseMap = append(result.CodebaseMap, mod)
 	}
 
 	if runCommit := survey.RunCommitForEntries(entries); runCommit != "" {
@@ -181,7 +181,7 @@ func cmdStatus(dbPath string, args []string) error {
 	if status.ServerState != serverStateSandboxed && status.Code == nil {
 		codeDBPath := filepath.Join(memoryDir, "code", "index.db")
 		codeSearchPath := filepath.Join(memoryDir, "code", "search.bleve")
-		if cs, err := store.NewCodeStore(codeDBPath, codeSearchPath); err == nil {
+		if cs, err := store.NewCodeStore(codeDBPath, codeSearchPath, codeStoreOptions()...); err == nil {
 			defer cs.Close()
 			if stats, err := cs.Stats(); err == nil && stats != nil {
 				status.Code = &CodeStatus{
@@ -25,6 +25,10 @@ func cmdSurveyDispatcher(dbPath string, args []string) error {
 		{name: "stats", handler: func(a []string) error { return cmdSurveyStats(dbPath, a) }},
 		{name: "run", handler: func(a []string) error { return cmdSurveyRun(dbPath, a) }},
 		{name: "graph", handler: func(a []string) error { return cmdSurveyGraph(dbPath, a) }},
+		{name: "licenses", handler: func(a []string) error { return cmdSurveyLicenses(dbPath, a) }},
+		{name: "hotspots", handler: func(a []string) error { return cmdSurveyHotspots(dbPath, a) }},
+		{name: "history", handler: func(a []string) error { return cmdSurveyHistory(dbPath, a) }},
+		{name: "diff", handler: func(a []string) error { return cmdSurveyDiff(dbPath, a) }},
 		{name: "clear", handler: func(a []string) error { return cmdSurveyClear(dbPath, a) }},
 	})
 }
@@ -244,7 +248,7 @@ func cmdSurveyGraph(dbPath string, args []string) error {
 		}
 	}
 	if symbol == "" {
-		return fmt.Errorf("usage: aide survey graph <symbol> [--direction=<both|callers|callees>] [--max-depth=<n>] [--max-nodes=<n>] [--json]")
+		return fmt.Errorf("usage: aide survey graph <symbol> [--direction=<both|callers|callees>] [--max-depth=<n>] [--max-nodes=<n>] [--exact] [--json]")
 	}
 
 	b, err := NewBackend(dbPath)
@@ -263,6 +267,7 @@ func cmdSurveyGraph(dbPath string, args []string) error {
 		MaxDepth:  maxDepth,
 		MaxNodes:  maxNodes,
 		Direction: direction,
+		Exact:     hasFlag(args, "--exact"),
 	}
 
 	graph, err := survey.BuildCallGraph(cg, symbol, opts)
@@ -318,6 +323,7 @@ func cmdSurveyGraph(dbPath string, args []string) error {
 // point produces identical results.
 func cmdSurveyRun(dbPath string, args []string) error {
 	analyzer := parseFlag(args, "--analyzer=")
+	at := parseFlag(args, "--at=")
 
 	b, err := NewBackend(dbPath)
 	if err != nil {
@@ -325,7 +331,12 @@ func cmdSurveyRun(dbPath string, args []string) error {
 	}
 	defer b.Close()
 
-	results, err := b.SurveyRun(analyzer)
+	var results []surveyrun.Result
+	if at != "" {
+		results, err = b.SurveyRunAt(at, analyzer)
+	} else {
+		results, err = b.SurveyRun(analyzer)
+	}
 	if err != nil {
 		return fmt.Errorf("survey run failed: %w", err)
 	}
@@ -346,14 +357,23 @@ Commands:
   list            List survey entries with optional filters
   stats           Show survey statistics
   graph <symbol>  Build a call graph for a symbol
+  licenses        Show the license inventory and dependency license policy
+  hotspots        Rank files and functions by churn × complexity
+  history         Show per-commit snapshots and module drift over time
+  diff <a> <b>    Compare the snapshots of two commits
   clear           Clear survey entries
 
 Flags (run):
-  --analyzer=<name>  Run only a specific analyzer: topology, entrypoints, churn, modules
+  --analyzer=<name>  Run only a specific analyzer: topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history
+  --at=<ref>         Backfill a snapshot of a past commit (hash, branch, tag or HEAD~n)
+                     from its git tree, leaving the worktree and current survey alone;
+                     runs topology, entrypoints and modules against a temporary code index
+  In a git repository, a run that refreshes modules, entrypoints, dependencies
+  or churn also records a snapshot of HEAD for 'history' and 'diff'.
 
 Flags (search, list):
-  --analyzer=<name>  Filter by analyzer: topology, entrypoints, churn
-  --kind=<kind>      Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern
+  --analyzer=<name>  Filter by analyzer: topology, entrypoints, churn, modules, ownership, cochange, hotspots, dependencies, architecture, licenses, history
+  --kind=<kind>      Filter by kind: module, entrypoint, dependency, tech_stack, churn, submodule, subproject, workspace, arch_pattern, license, ownership, cochange, hotspot, snapshot
   --file=<path>      Filter by file path pattern
   --limit=<n>        Maximum results
   --json             Output as JSON
@@ -363,6 +383,27 @@ Flags (graph):
   --direction=<dir>      Traversal direction: both (default), callers, callees
   --max-depth=<n>        Max BFS hops (default 2)
   --max-nodes=<n>        Max graph nodes (default 50)
+  --exact                Only follow references resolved to a definition
+  --json                 Output as JSON
+
+Flags (licenses):
+  --check=<spdx>         Verdict for adding a dependency under this license (e.g. GPL-3.0)
+  --json                 Output as JSON
+  The policy is "findings.licenses" in .aide/config/aide.json; without one,
+  licenses more restrictive than the project's own and weak copyleft are denied.
+
+Flags (hotspots):
+  --scope=<scope>        file, function or all (default all)
+  --file=<path>          Only hotspots whose path contains this
+  --limit=<n>            Maximum hotspots per scope (default 15)
+  --json                 Output as JSON
+
+Flags (history):
+  --limit=<n>            Most recent snapshots to show (default 20)
+  --json                 Output as JSON
+
+Flags (diff):
+  <a> <b>                Commits (hash prefix, branch, tag or HEAD~n) with a snapshot
   --json                 Output as JSON
 
 Flags (clear):
@@ -373,6 +414,7 @@ Note: --analyser= is accepted as an alias for --analyzer= in all commands.
 Examples:
   aide survey run
   aide survey run --analyzer=topology
+  aide survey run --at=v1.2.0
   aide survey search "auth"
   aide survey search "auth" --json
   aide survey list --analyzer=topology
@@ -380,6 +422,10 @@ Examples:
   aide survey stats
   aide survey graph BuildCallGraph
   aide survey graph --symbol=main --direction=callees --json
+  aide survey licenses --check=GPL-3.0
+  aide survey hotspots --scope=function
+  aide survey history
+  aide survey diff v1.2.0 HEAD
   aide survey clear --analyzer=churn
 `)
 }
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// defaultHistoryLimit caps the snapshots listed by 'aide survey history'.
+const defaultHistoryLimit = 20
+
+// historyReport is the module drift over the most recent snapshots.
+type historyReport struct {
+	Points []survey.TrendPoint `json:"points"` // oldest first
+	Drift  string              `json:"drift"`  // over the listed points
+	Total  int                 `json:"total"`  // snapshots stored
+}
+
+// listSnapshots reads every stored snapshot, oldest commit first.
+func listSnapshots(list func(survey.SearchOptions) ([]*survey.Entry, error)) ([]*survey.Snapshot, error) {
+	entries, err := list(survey.SearchOptions{Analyzer: survey.AnalyzerHistory, Kind: survey.KindSnapshot, Limit: -1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read survey history: %w", err)
+	}
+	return survey.SnapshotsFromEntries(entries), nil
+}
+
+// buildHistoryReport reduces the last limit snapshots to trend points.
+func buildHistoryReport(list func(survey.SearchOptions) ([]*survey.Entry, error), limit int) (*historyReport, error) {
+	snaps, err := listSnapshots(list)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	points := survey.SnapshotTrend(snaps)
+	if len(points) > limit {
+		points = points[len(points)-limit:]
+	}
+	return &historyReport{Points: points, Drift: survey.Drift(points), Total: len(snaps)}, nil
+}
+
+// formatHistoryReport renders the report as a table, newest first.
+func formatHistoryReport(r *historyReport) string {
+	if r.Total == 0 {
+		return "No survey history — each 'aide survey run' in a git repository records a snapshot of its commit.\n"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Survey history (%d of %d snapshots, newest first):\n", len(r.Points), r.Total)
+	fmt.Fprintf(&sb, "  %-8s  %-10s  %7s  %5s  %7s  %5s  %11s  %4s\n", "commit", "date", "modules", "files", "largest", "moved", "entrypoints", "deps")
+	for i := len(r.Points) - 1; i >= 0; i-- {
+		p := r.Points[i]
+		deps := strconv.Itoa(p.Dependencies)
+		if p.Backfilled {
+			deps = "-" // not surveyed by --at
+		}
+		fmt.Fprintf(&sb, "  %-8s  %-10s  %7d  %5d  %6.0f%%  %5d  %11d  %4s\n",
+			survey.ShortCommit(p.Commit), p.CommittedAt.Format("2006-01-02"), p.Modules, p.Files, p.LargestShare*100, p.Moved, p.Entrypoints, deps)
+	}
+	if len(r.Points) > 1 {
+		first, last := r.Points[0], r.Points[len(r.Points)-1]
+		fmt.Fprintf(&sb, "\nModule map: %s (%d -> %d modules, %s -> %s files per module).\n",
+			r.Drift, first.Modules, last.Modules, filesPerModule(first), filesPerModule(last))
+	}
+	return sb.String()
+}
+
+func filesPerModule(p survey.TrendPoint) string {
+	if p.Modules == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", float64(p.Files)/float64(p.Modules))
+}
+
+// findSnapshotRef finds the snapshot for a commit prefix, falling back to
+// resolving ref as a git revision (branch, tag, HEAD~3) in rootDir.
+func findSnapshotRef(snaps []*survey.Snapshot, rootDir, ref string) (*survey.Snapshot, error) {
+	s, err := survey.FindSnapshot(snaps, ref)
+	if err == nil {
+		return s, nil
+	}
+	if repo, openErr := survey.OpenGitRepo(rootDir); openErr == nil && repo != nil {
+		if commit, resolveErr := repo.ResolveCommit(ref); resolveErr == nil {
+			return survey.FindSnapshot(snaps, commit)
+		}
+	}
+	return nil, err
+}
+
+// formatSnapshotDiff renders a snapshot diff as text.
+func formatSnapshotDiff(d *survey.SnapshotDiff) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Survey diff %s -> %s\n", survey.ShortCommit(d.From), survey.ShortCommit(d.To))
+	changes := d.Modules.Summary()
+	if changes == "" {
+		changes = "no structural change"
+	}
+	fmt.Fprintf(&sb, "  modules: %d -> %d (%s)\n", d.ModulesFrom, d.ModulesTo, changes)
+	fmt.Fprintf(&sb, "  entrypoints: +%d -%d\n", len(d.EntrypointsAdded), len(d.EntrypointsRemoved))
+	for _, e := range d.EntrypointsAdded {
+		fmt.Fprintf(&sb, "    + %s\n", e)
+	}
+	for _, e := range d.EntrypointsRemoved {
+		fmt.Fprintf(&sb, "    - %s\n", e)
+	}
+	if len(d.TechAdded) > 0 || len(d.TechRemoved) > 0 {
+		sb.WriteString("  tech stack:")
+		if len(d.TechAdded) > 0 {
+			fmt.Fprintf(&sb, " +%s", strings.Join(d.TechAdded, ", +"))
+		}
+		if len(d.TechRemoved) > 0 {
+			fmt.Fprintf(&sb, " -%s", strings.Join(d.TechRemoved, ", -"))
+		}
+		sb.WriteString("\n")
+	}
+	if d.Backfilled {
+		sb.WriteString("  dependencies and churn: not compared (backfilled snapshot)\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "  dependencies: %d -> %d (%+d)\n", d.DependenciesFrom, d.DependenciesTo, d.DependenciesTo-d.DependenciesFrom)
+	if len(d.ChurnEntered) > 0 || len(d.ChurnLeft) > 0 {
+		sb.WriteString("  busiest files:")
+		if len(d.ChurnEntered) > 0 {
+			fmt.Fprintf(&sb, " entered %s", strings.Join(d.ChurnEntered, ", "))
+		}
+		if len(d.ChurnLeft) > 0 {
+			if len(d.ChurnEntered) > 0 {
+				sb.WriteString(";")
+			}
+			fmt.Fprintf(&sb, " left %s", strings.Join(d.ChurnLeft, ", "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// cmdSurveyHistory lists the recorded per-commit snapshots.
+func cmdSurveyHistory(dbPath string, args []string) error {
+	limit, err := parseIntFlag(args, "--limit=", defaultHistoryLimit)
+	if err != nil {
+		return err
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	report, err := buildHistoryReport(b.ListSurvey, limit)
+	if err != nil {
+		return err
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(report)
+	}
+	fmt.Print(formatHistoryReport(report))
+	return nil
+}
+
+// cmdSurveyDiff compares the snapshots of two commits.
+func cmdSurveyDiff(dbPath string, args []string) error {
+	refs := positionals(args)
+	if len(refs) != 2 {
+		return fmt.Errorf("usage: aide survey diff <commitA> <commitB> [--json]")
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	snaps, err := listSnapshots(b.ListSurvey)
+	if err != nil {
+		return err
+	}
+	rootDir := store.ProjectRootFromDB(dbPath)
+	from, err := findSnapshotRef(snaps, rootDir, refs[0])
+	if err != nil {
+		return err
+	}
+	to, err := findSnapshotRef(snaps, rootDir, refs[1])
+	if err != nil {
+		return err
+	}
+
+	d := survey.DiffSnapshots(from, to)
+	if hasFlag(args, "--json") {
+		return printJSON(d)
+	}
+	fmt.Print(formatSnapshotDiff(d))
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+func historyTestEntries() []*survey.Entry {
+	module := func(id, label string, members ...string) *survey.Entry {
+		m, _ := json.Marshal(members)
+		return &survey.Entry{Analyzer: survey.AnalyzerModules, Kind: survey.KindModule, Name: label,
+			Metadata: map[string]string{"community_id": id, "members": string(m)}}
+	}
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	return []*survey.Entry{
+		survey.BuildSnapshot("1111111111aaaa", day, day, []*survey.Entry{
+			module("0", "core", "core/a.go", "core/b.go", "core/c.go", "web/w.go"),
+		}).Entry(),
+		survey.BuildSnapshot("2222222222bbbb", day.AddDate(0, 0, 7), day, []*survey.Entry{
+			module("0", "core", "core/a.go", "core/b.go", "core/c.go"),
+			module("1", "web", "web/w.go"),
+			{Analyzer: survey.AnalyzerEntrypoints, Kind: survey.KindEntrypoint, Name: "web/w.go:main()"},
+			{Analyzer: survey.AnalyzerDependencies, Kind: survey.KindDependency, Name: "chi", Metadata: map[string]string{"ecosystem": "go", "direct": "true"}},
+		}).Entry(),
+	}
+}
+
+func TestBuildHistoryReport(t *testing.T) {
+	list := func(survey.SearchOptions) ([]*survey.Entry, error) { return historyTestEntries(), nil }
+
+	r, err := buildHistoryReport(list, 0)
+	if err != nil {
+		t.Fatalf("buildHistoryReport: %v", err)
+	}
+	if r.Total != 2 || len(r.Points) != 2 || r.Drift != survey.DriftFragmenting {
+		t.Fatalf("report = %+v, want 2 points fragmenting", r)
+	}
+	out := formatHistoryReport(r)
+	// Newest first.
+	if strings.Index(out, "22222222") > strings.Index(out, "11111111") {
+		t.Errorf("history not newest first:\n%s", out)
+	}
+	if !strings.Contains(out, "fragmenting (1 -> 2 modules, 4.0 -> 2.0 files per module)") {
+		t.Errorf("missing drift line:\n%s", out)
+	}
+
+	if r, _ := buildHistoryReport(list, 1); len(r.Points) != 1 || r.Points[0].Commit != "2222222222bbbb" || r.Drift != survey.DriftStable {
+		t.Errorf("limit 1 = %+v, want the newest snapshot only", r)
+	}
+
+	empty := func(survey.SearchOptions) ([]*survey.Entry, error) { return nil, nil }
+	if r, _ := buildHistoryReport(empty, 0); !strings.Contains(formatHistoryReport(r), "No survey history") {
+		t.Errorf("empty history = %q", formatHistoryReport(r))
+	}
+}
+
+func TestFormatSnapshotDiff(t *testing.T) {
+	snaps := survey.SnapshotsFromEntries(historyTestEntries())
+	from, err := findSnapshotRef(snaps, t.TempDir(), "1111")
+	if err != nil {
+		t.Fatalf("findSnapshotRef: %v", err)
+	}
+	to, err := findSnapshotRef(snaps, t.TempDir(), "2222222222bbbb")
+	if err != nil {
+		t.Fatalf("findSnapshotRef: %v", err)
+	}
+	if _, err := findSnapshotRef(snaps, t.TempDir(), "main"); err == nil {
+		t.Error("unknown ref outside a git repository should fail")
+	}
+
+	out := formatSnapshotDiff(survey.DiffSnapshots(from, to))
+	for _, want := range []string{
+		"Survey diff 11111111 -> 22222222",
+		"modules: 1 -> 2 (new: web; 1 files moved between modules)",
+		"+ web/w.go:main()",
+		"dependencies: 0 -> 1 (+1)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatSnapshotDiff_Backfilled(t *testing.T) {
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	release := survey.BuildSnapshot("3333333333cccc", day, day, []*survey.Entry{
+		{Analyzer: survey.AnalyzerTopology, Kind: survey.KindTechStack, Name: "go"},
+	})
+	release.Backfilled = true
+	snaps := append(survey.SnapshotsFromEntries(historyTestEntries()), release)
+
+	out := formatSnapshotDiff(survey.DiffSnapshots(release, snaps[1]))
+	if !strings.Contains(out, "tech stack: -go") || !strings.Contains(out, "not compared (backfilled snapshot)") || strings.Contains(out, "dependencies: 0") {
+		t.Errorf("backfilled diff:\n%s", out)
+	}
+
+	r := &historyReport{Points: survey.SnapshotTrend([]*survey.Snapshot{release}), Total: 1}
+	if line := strings.Split(formatHistoryReport(r), "\n")[2]; !strings.HasSuffix(line, "   -") {
+		t.Errorf("backfilled row %q, want unknown dependencies shown as -", line)
+	}
+}
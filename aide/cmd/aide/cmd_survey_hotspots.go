@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// defaultHotspotsLimit caps the hotspots listed per scope.
+const defaultHotspotsLimit = 15
+
+// hotspotsReport is the churn × complexity ranking shared by
+// 'aide survey hotspots' and the survey_hotspots MCP tool.
+type hotspotsReport struct {
+	Files     []survey.Hotspot `json:"files,omitempty"`
+	Functions []survey.Hotspot `json:"functions,omitempty"`
+	Surveyed  bool             `json:"surveyed"` // false when the hotspots analyzer has not run
+}
+
+// buildHotspotsReport reads the stored hotspots, keeping those in scope
+// ("file", "function", "" for both) whose path contains file, up to limit
+// per scope.
+func buildHotspotsReport(list func(survey.SearchOptions) ([]*survey.Entry, error), scope, file string, limit int) (*hotspotsReport, error) {
+	switch scope {
+	case "", "all":
+		scope = ""
+	case survey.HotspotScopeFile, survey.HotspotScopeFunction:
+	default:
+		return nil, fmt.Errorf("invalid scope %q: use file, function or all", scope)
+	}
+	entries, err := list(survey.SearchOptions{Analyzer: survey.AnalyzerHotspots, Kind: survey.KindHotspot, FilePath: file, Limit: -1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hotspots: %w", err)
+	}
+	if limit <= 0 {
+		limit = defaultHotspotsLimit
+	}
+	report := &hotspotsReport{Surveyed: len(entries) > 0}
+	for _, h := range survey.HotspotsFromEntries(entries, scope) {
+		switch {
+		case h.Scope == survey.HotspotScopeFile && len(report.Files) < limit:
+			report.Files = append(report.Files, h)
+		case h.Scope == survey.HotspotScopeFunction && len(report.Functions) < limit:
+			report.Functions = append(report.Functions, h)
+		}
+	}
+	return report, nil
+}
+
+// formatHotspotsReport renders the report as text.
+func formatHotspotsReport(r *hotspotsReport) string {
+	if !r.Surveyed {
+		return "No hotspot data — run 'aide survey run --analyzer=hotspots' (or survey_run analyzer=hotspots) after churn, with complexity findings ('aide findings run complexity') in place.\n"
+	}
+	if len(r.Files) == 0 && len(r.Functions) == 0 {
+		return "No hotspots match.\n"
+	}
+	var sb strings.Builder
+	if len(r.Files) > 0 {
+		sb.WriteString("Files (risk, recent commits, total complexity):\n")
+		for _, h := range r.Files {
+			fmt.Fprintf(&sb, "  %.2f  %6.1f  %5d  %s\n", h.Risk, h.Churn, h.Complexity, h.File)
+		}
+	}
+	if len(r.Functions) > 0 {
+		if len(r.Files) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("Functions (risk, recent commits, complexity):\n")
+		for _, h := range r.Functions {
+			flag := ""
+			if h.Flagged {
+				flag = "  [over threshold]"
+			}
+			fmt.Fprintf(&sb, "  %.2f  %6.1f  %5d  %s  %s:%d%s\n", h.Risk, h.Churn, h.Complexity, h.Function, h.File, h.Line, flag)
+		}
+	}
+	sb.WriteString("\nHigh risk = complex code that keeps changing; refactor from the top.\n")
+	return sb.String()
+}
+
+// cmdSurveyHotspots ranks files and functions by churn × complexity.
+func cmdSurveyHotspots(dbPath string, args []string) error {
+	limit, err := parseIntFlag(args, "--limit=", defaultHotspotsLimit)
+	if err != nil {
+		return err
+	}
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to create backend: %w", err)
+	}
+	defer b.Close()
+
+	report, err := buildHotspotsReport(b.ListSurvey, parseFlag(args, "--scope="), parseFlag(args, "--file="), limit)
+	if err != nil {
+		return err
+	}
+	if hasFlag(args, "--json") {
+		return printJSON(report)
+	}
+	fmt.Print(formatHotspotsReport(report))
+	return nil
+}
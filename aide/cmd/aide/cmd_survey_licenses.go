@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// maxLicensePackages caps the example packages listed per license.
+const maxLicensePackages = 5
+
+// licenseReport is the license inventory and policy view shared by
+// 'aide survey licenses' and the survey_licenses MCP tool.
+type licenseReport struct {
+	Project      []string             `json:"project"`
+	Policy       survey.LicensePolicy `json:"policy"`
+	PolicySource string               `json:"policySource"` // "config" or "default"
+	Inventory    []licenseGroup       `json:"inventory"`
+	Violations   []*findings.Finding  `json:"violations,omitempty"`
+	Check        *licenseCheck        `json:"check,omitempty"`
+}
+
+// licenseGroup counts the license files of one SPDX expression.
+type licenseGroup struct {
+	SPDX     string   `json:"spdx"`
+	Category string   `json:"category,omitempty"`
+	Files    int      `json:"files"`
+	Packages []string `json:"packages,omitempty"`
+}
+
+// licenseCheck is the policy verdict for a prospective dependency license.
+type licenseCheck struct {
+	License string `json:"license"`
+	Verdict string `json:"verdict"`
+	Reason  string `json:"reason"`
+}
+
+// buildLicenseReport groups the surveyed license entries, evaluates them
+// against the policy (the default policy when none is configured) and,
+// when check is set, the verdict for adding a dependency under that
+// license.
+func buildLicenseReport(entries []*survey.Entry, policy survey.LicensePolicy, check string) (*licenseReport, error) {
+	violations, result, err := findings.AnalyzeLicenses(findings.LicensesConfig{Entries: entries, Policy: policy})
+	if err != nil {
+		return nil, err
+	}
+	report := &licenseReport{
+		Project:      result.Project,
+		Policy:       result.Policy,
+		PolicySource: "config",
+		Violations:   violations,
+	}
+	if policy.IsZero() {
+		report.PolicySource = "default"
+	}
+
+	groups := make(map[string]*licenseGroup)
+	for _, e := range entries {
+		if e.Kind != survey.KindLicense {
+			continue
+		}
+		g := groups[e.Name]
+		if g == nil {
+			g = &licenseGroup{SPDX: e.Name, Category: e.Metadata["category"]}
+			groups[e.Name] = g
+		}
+		g.Files++
+		if pkg := e.Metadata["package"]; pkg != "" && len(g.Packages) < maxLicensePackages {
+			g.Packages = append(g.Packages, pkg)
+		}
+	}
+	for _, g := range groups {
+		report.Inventory = append(report.Inventory, *g)
+	}
+	sort.Slice(report.Inventory, func(i, j int) bool {
+		a, b := report.Inventory[i], report.Inventory[j]
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.SPDX < b.SPDX
+	})
+
+	if check != "" {
+		verdict, reason := report.Policy.Evaluate(check)
+		report.Check = &licenseCheck{License: check, Verdict: verdict, Reason: reason}
+	}
+	return report, nil
+}
+
+// formatLicenseReport renders the report as text.
+func formatLicenseReport(r *licenseReport) string {
+	var sb strings.Builder
+	if r.Check != nil {
+		fmt.Fprintf(&sb, "Check %s: %s — %s\n\n", r.Check.License, strings.ToUpper(r.Check.Verdict), r.Check.Reason)
+	}
+	project := "none found"
+	if len(r.Project) > 0 {
+		project = strings.Join(r.Project, ", ")
+	}
+	fmt.Fprintf(&sb, "Project license: %s\n", project)
+	fmt.Fprintf(&sb, "Policy (%s): %s\n", r.PolicySource, describeLicensePolicy(r.Policy))
+
+	if len(r.Inventory) > 0 {
+		sb.WriteString("\nInventory:\n")
+		for _, g := range r.Inventory {
+			category := g.Category
+			if category == "" {
+				category = "-"
+			}
+			fmt.Fprintf(&sb, "  %-24s %-17s %4d", g.SPDX, category, g.Files)
+			if len(g.Packages) > 0 {
+				more := ""
+				if g.Files > len(g.Packages) {
+					more = ", ..."
+				}
+				fmt.Fprintf(&sb, "  (%s%s)", strings.Join(g.Packages, ", "), more)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(r.Violations) == 0 {
+		sb.WriteString("\nNo policy violations.\n")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "\nViolations (%d):\n", len(r.Violations))
+	for _, f := range r.Violations {
+		fmt.Fprintf(&sb, "  [%s] %s (%s)\n", f.Severity, f.Title, f.FilePath)
+	}
+	return sb.String()
+}
+
+// describeLicensePolicy renders a policy on one line.
+func describeLicensePolicy(p survey.LicensePolicy) string {
+	var parts []string
+	if len(p.Allow) > 0 {
+		parts = append(parts, "allow "+strings.Join(p.Allow, ", "))
+	}
+	if len(p.Deny) > 0 {
+		parts = append(parts, "deny "+strings.Join(p.Deny, ", "))
+	}
+	if len(parts) == 0 {
+		return "allow all"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// cmdSurveyLicenses shows the license inventory, the policy and its
+// violations; --check evaluates a prospective dependency license.
+func cmdSurveyLicenses(dbPath string, args []string) error {
+	check := parseFlag(args, "--check=")
+	jsonOutput := hasFlag(args, "--json")
+
+	b, err := NewBackend(dbPath)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	entries, err := b.ListSurvey(survey.SearchOptions{
+		Analyzer: survey.AnalyzerLicenses,
+		Kind:     survey.KindLicense,
+		Limit:    -1,
+	})
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 && check == "" {
+		fmt.Println("No licenses surveyed — run 'aide survey run --analyzer=licenses' first.")
+		return nil
+	}
+
+	policy := loadFindingsConfig(store.ProjectRootFromDB(dbPath)).Licenses
+	report, err := buildLicenseReport(entries, policy, check)
+	if err != nil {
+		return err
+	}
+	if jsonOutput {
+		return printJSON(report)
+	}
+	fmt.Print(formatLicenseReport(report))
+	return nil
+}
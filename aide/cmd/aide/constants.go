@@ -91,6 +91,10 @@ const (
 	// before giving up on a lazy-init subsystem.
 	DefaultMCPPollCount = 50
 
+	// DefaultTreeCacheSize is how many parsed tree-sitter trees the code
+	// watcher keeps for incremental re-parsing of recently edited files.
+	DefaultTreeCacheSize = 64
+
 	// -------------------------------------------------------------------------
 	// MCP / CLI default limits
 	// -------------------------------------------------------------------------
@@ -106,4 +110,8 @@ const (
 	// DefaultCodeRefsLimit is the default result count for the
 	// code_references MCP tool.
 	DefaultCodeRefsLimit = 50
+
+	// DefaultRenameImpactLimit caps the references the code_rename_impact
+	// MCP tool collects from the index when no language server answers.
+	DefaultRenameImpactLimit = 500
 )
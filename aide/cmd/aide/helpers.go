@@ -15,6 +15,7 @@ import (
 	"github.com/jmylchreest/aide/aide/pkg/config"
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
 	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
 )
 
 // fatal prints an error message and exits with code 1.
@@ -199,6 +200,9 @@ type findingsConfig struct {
 		MinSimilarity float64 `json:"minSimilarity"`
 		MinSeverity   string  `json:"minSeverity"`
 	} `json:"clones"`
+	// Licenses is the dependency license policy; empty means
+	// survey.DefaultLicensePolicy for the project's own license.
+	Licenses survey.LicensePolicy `json:"licenses"`
 }
 
 // aideJSON is the top-level structure of .aide/config/aide.json.
@@ -3,6 +3,8 @@ package code
 import (
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
@@ -177,3 +179,77 @@ func BenchmarkParseFile(b *testing.B) {
 		}
 	})
 }
+
+// benchLargeGoSource repeats the Go fixture's functions under distinct
+// names, standing in for a large generated file.
+func benchLargeGoSource(copies int) string {
+	var sb strings.Builder
+	sb.WriteString("package benchpkg\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n")
+	body := benchGoSource[strings.Index(benchGoSource, "// User represents"):]
+	for i := 0; i < copies; i++ {
+		sfx := strconv.Itoa(i)
+		r := strings.NewReplacer("User", "User"+sfx, "Greeter", "Greeter"+sfx, "ValidName", "ValidName"+sfx, "SumAll", "SumAll"+sfx)
+		sb.WriteString(r.Replace(body))
+	}
+	return sb.String()
+}
+
+var benchParseSink *FileParse
+
+// BenchmarkReparseAfterEdit measures re-indexing a large file after a
+// one-line edit: a full parse versus an incremental reparse of the cached
+// tree. Each iteration toggles the edit so the content always changes.
+func BenchmarkReparseAfterEdit(b *testing.B) {
+	loader := grammar.NewCompositeLoader(grammar.WithAutoDownload(false))
+	p := NewParser(loader)
+	b.Cleanup(p.Close)
+
+	src := benchLargeGoSource(50)
+	edited := strings.Replace(src, "total += v\n", "total += v + 0\n", 1)
+	path := filepath.Join(b.TempDir(), "generated.go")
+	write := func(i int) {
+		content := src
+		if i%2 == 1 {
+			content = edited
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			b.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	b.Run("Full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			write(i)
+			b.StartTimer()
+			res, err := p.ParseFileCached(path, nil)
+			if err != nil {
+				b.Fatalf("ParseFileCached: %v", err)
+			}
+			benchParseSink = res
+		}
+	})
+
+	b.Run("Incremental", func(b *testing.B) {
+		cache := NewTreeCache(1)
+		defer cache.Close()
+		write(0)
+		if _, err := p.ParseFileCached(path, cache); err != nil {
+			b.Fatalf("ParseFileCached: %v", err)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			write(i + 1)
+			b.StartTimer()
+			res, err := p.ParseFileCached(path, cache)
+			if err != nil {
+				b.Fatalf("ParseFileCached: %v", err)
+			}
+			if !res.Reused {
+				b.Fatal("cached tree not reused")
+			}
+			benchParseSink = res
+		}
+	})
+}
@@ -0,0 +1,322 @@
+package code
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// hierarchyCaptureKinds maps hierarchy query capture names to edge kinds.
+var hierarchyCaptureKinds = map[string]string{
+	"extends":    EdgeKindExtends,
+	"implements": EdgeKindImplements,
+	"embeds":     EdgeKindEmbeds,
+	"requires":   EdgeKindRequires,
+}
+
+// ParseFileHierarchy parses a file and extracts its type-hierarchy edges.
+func (p *Parser) ParseFileHierarchy(filePath string) ([]*TypeEdge, error) {
+	content, lang, err := readAndDetectLang(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if lang == "" {
+		return nil, nil
+	}
+	return p.ParseContentHierarchy(content, lang, filePath)
+}
+
+// ParseContentHierarchy parses source code and extracts type-hierarchy
+// edges using the pack's hierarchy query. Languages without one yield none.
+func (p *Parser) ParseContentHierarchy(content []byte, lang, filePath string) ([]*TypeEdge, error) {
+	result, err := p.parseTree(content, lang, func(root *tree_sitter.Node) (interface{}, error) {
+		if query := p.getHierarchyQuery(lang); query != nil {
+			return extractHierarchy(query, root, content, filePath, lang), nil
+		}
+		return nil, nil
+	})
+	if result == nil {
+		return nil, err
+	}
+	return result.([]*TypeEdge), err
+}
+
+// extractHierarchy turns each match's @type capture and its supertype
+// captures into edges, one per supertype.
+func extractHierarchy(query *tree_sitter.Query, root *tree_sitter.Node, content []byte, filePath, lang string) []*TypeEdge {
+	var edges []*TypeEdge
+	seen := make(map[string]bool)
+
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	names := query.CaptureNames()
+	matches := cursor.Matches(query, root, content)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		var typeName string
+		for _, capture := range match.Captures {
+			if names[capture.Index] == "type" {
+				typeName = normalizeTypeName(capture.Node.Utf8Text(content))
+			}
+		}
+		if typeName == "" {
+			continue
+		}
+		for _, capture := range match.Captures {
+			kind, ok := hierarchyCaptureKinds[names[capture.Index]]
+			if !ok {
+				continue
+			}
+			super := normalizeTypeName(capture.Node.Utf8Text(content))
+			if super == "" || (super == typeName && kind != EdgeKindRequires) {
+				continue
+			}
+			line := int(capture.Node.StartPosition().Row) + 1
+			key := fmt.Sprintf("%s:%s:%s:%d", kind, typeName, super, line)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			edges = append(edges, &TypeEdge{
+				ID:        ulid.Make().String(),
+				Kind:      kind,
+				TypeName:  typeName,
+				SuperName: super,
+				FilePath:  filePath,
+				Line:      line,
+				Language:  lang,
+				CreatedAt: time.Now(),
+			})
+		}
+	}
+	return edges
+}
+
+// normalizeTypeName reduces a captured type expression to its bare name:
+// pointer and reference markers, generic arguments and package or module
+// qualifiers are dropped ("*pkg.Base[T]" and "java.util.List<String>"
+// become "Base" and "List").
+func normalizeTypeName(text string) string {
+	s := strings.TrimSpace(text)
+	s = strings.TrimLeft(s, "*&")
+	for _, prefix := range []string{"dyn ", "impl ", "mut "} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	if i := strings.IndexAny(s, "<[({"); i >= 0 {
+		s = s[:i]
+	}
+	return QualifiedLeaf(strings.TrimSpace(s))
+}
+
+// methodSets indexes Go hierarchy edges and method symbols for structural
+// implementation matching.
+type methodSets struct {
+	requires map[string]map[string]bool // interface -> declared methods
+	methods  map[string]map[string]bool // receiver type -> methods
+	embeds   map[string][]string        // type -> embedded type names
+	defs     map[string]*Symbol         // type name -> definition
+	firstM   map[string]*Symbol         // receiver type -> a method, for types with no indexed definition
+}
+
+func newMethodSets(edges []*TypeEdge, symbols []*Symbol) *methodSets {
+	m := &methodSets{
+		requires: make(map[string]map[string]bool),
+		methods:  make(map[string]map[string]bool),
+		embeds:   make(map[string][]string),
+		defs:     make(map[string]*Symbol),
+		firstM:   make(map[string]*Symbol),
+	}
+	add := func(sets map[string]map[string]bool, key, name string) {
+		if sets[key] == nil {
+			sets[key] = make(map[string]bool)
+		}
+		sets[key][name] = true
+	}
+	for _, e := range edges {
+		if e.Language != "go" {
+			continue
+		}
+		switch e.Kind {
+		case EdgeKindRequires:
+			add(m.requires, e.TypeName, e.SuperName)
+		case EdgeKindEmbeds:
+			m.embeds[e.TypeName] = append(m.embeds[e.TypeName], e.SuperName)
+		}
+	}
+	for _, sym := range symbols {
+		if sym.Language != "go" {
+			continue
+		}
+		switch sym.Kind {
+		case KindMethod:
+			if sym.Container == "" {
+				continue
+			}
+			add(m.methods, sym.Container, sym.Name)
+			if m.firstM[sym.Container] == nil {
+				m.firstM[sym.Container] = sym
+			}
+		case KindClass, KindInterface, KindType:
+			// Prefer the struct/interface capture over the generic type one.
+			if prev, ok := m.defs[sym.Name]; !ok || (prev.Kind == KindType && sym.Kind != KindType) {
+				m.defs[sym.Name] = sym
+			}
+		}
+	}
+	return m
+}
+
+// isInterface reports whether name is a Go interface.
+func (m *methodSets) isInterface(name string) bool {
+	if d, ok := m.defs[name]; ok {
+		return d.Kind == KindInterface
+	}
+	return len(m.requires[name]) > 0
+}
+
+// methodSet returns name's full method set: its own methods or declared
+// interface methods, plus those promoted from embedded types.
+func (m *methodSets) methodSet(name string) map[string]bool {
+	set := make(map[string]bool)
+	visited := make(map[string]bool)
+	var walk func(string)
+	walk = func(n string) {
+		if visited[n] {
+			return
+		}
+		visited[n] = true
+		for meth := range m.methods[n] {
+			set[meth] = true
+		}
+		for meth := range m.requires[n] {
+			set[meth] = true
+		}
+		for _, e := range m.embeds[n] {
+			walk(e)
+		}
+	}
+	walk(name)
+	return set
+}
+
+// interfaces returns every Go interface with at least one method.
+func (m *methodSets) interfaces() []string {
+	seen := make(map[string]bool)
+	for name := range m.requires {
+		seen[name] = true
+	}
+	for name := range m.embeds {
+		if m.isInterface(name) {
+			seen[name] = true
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// concreteTypes returns every non-interface Go type with methods or
+// embedded fields.
+func (m *methodSets) concreteTypes() []string {
+	seen := make(map[string]bool)
+	for name := range m.methods {
+		seen[name] = true
+	}
+	for name := range m.embeds {
+		seen[name] = true
+	}
+	for name := range seen {
+		if m.isInterface(name) {
+			delete(seen, name)
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// location returns where name is defined, falling back to one of its
+// methods when the type itself is not indexed.
+func (m *methodSets) location(name string) (string, int) {
+	if d, ok := m.defs[name]; ok {
+		return d.FilePath, d.StartLine
+	}
+	if s, ok := m.firstM[name]; ok {
+		return s.FilePath, s.StartLine
+	}
+	return "", 0
+}
+
+func (m *methodSets) edge(typeName, iface string, at string) *TypeEdge {
+	file, line := m.location(at)
+	return &TypeEdge{
+		Kind:       EdgeKindImplements,
+		TypeName:   typeName,
+		SuperName:  iface,
+		FilePath:   file,
+		Line:       line,
+		Language:   "go",
+		Structural: true,
+	}
+}
+
+// StructuralImplementations returns the Go types whose method sets cover
+// every method iface declares (embedded interfaces included), as
+// implements edges marked Structural. edges are the indexed hierarchy edges
+// and symbols the indexed Go methods and type definitions. Types are matched
+// by bare name: pointer and value receivers are not told apart, and
+// same-named types in different packages share one method set.
+func StructuralImplementations(iface string, edges []*TypeEdge, symbols []*Symbol) []*TypeEdge {
+	m := newMethodSets(edges, symbols)
+	want := m.methodSet(iface)
+	if len(want) == 0 || !m.isInterface(iface) {
+		return nil
+	}
+	var out []*TypeEdge
+	for _, t := range m.concreteTypes() {
+		if covers(m.methodSet(t), want) {
+			out = append(out, m.edge(t, iface, t))
+		}
+	}
+	return out
+}
+
+// StructuralSupertypes returns the Go interfaces typeName satisfies by
+// method set, as implements edges marked Structural. See
+// StructuralImplementations for the matching rules.
+func StructuralSupertypes(typeName string, edges []*TypeEdge, symbols []*Symbol) []*TypeEdge {
+	m := newMethodSets(edges, symbols)
+	if m.isInterface(typeName) {
+		return nil
+	}
+	have := m.methodSet(typeName)
+	if len(have) == 0 {
+		return nil
+	}
+	var out []*TypeEdge
+	for _, iface := range m.interfaces() {
+		if want := m.methodSet(iface); len(want) > 0 && covers(have, want) {
+			out = append(out, m.edge(typeName, iface, iface))
+		}
+	}
+	return out
+}
+
+// covers reports whether have contains every key of want.
+func covers(have, want map[string]bool) bool {
+	for k := range want {
+		if !have[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,198 @@
+package code
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+// edgeStrings renders edges as "kind type->super" for order-free comparison.
+func edgeStrings(edges []*TypeEdge) []string {
+	out := make([]string, 0, len(edges))
+	for _, e := range edges {
+		out = append(out, e.Kind+" "+e.TypeName+"->"+e.SuperName)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestParseContentHierarchy(t *testing.T) {
+	p := newTestParser()
+
+	cases := []struct {
+		lang    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			lang: "go", file: "store.go",
+			content: `package store
+
+type Reader interface {
+	Read(p []byte) (int, error)
+}
+
+type ReadCloser interface {
+	Reader
+	io.Closer
+	Close() error
+}
+
+type Base struct{}
+
+type Store struct {
+	Base
+	*log.Logger
+	name string
+}
+`,
+			want: []string{
+				"embeds ReadCloser->Closer",
+				"embeds ReadCloser->Reader",
+				"embeds Store->Base",
+				"embeds Store->Logger",
+				"requires ReadCloser->Close",
+				"requires Reader->Read",
+			},
+		},
+		{
+			lang: "java", file: "Dog.java",
+			content: `package zoo;
+public class Dog extends Animal implements Pet, Comparable<Dog> {}
+interface Pet extends Named {}
+enum Size implements Measured { S, M }
+`,
+			want: []string{
+				"extends Dog->Animal",
+				"extends Pet->Named",
+				"implements Dog->Comparable",
+				"implements Dog->Pet",
+				"implements Size->Measured",
+			},
+		},
+		{
+			lang: "typescript", file: "dog.ts",
+			content: `class Dog extends Animal implements Pet, Named {}
+abstract class Shape implements Drawable {}
+interface Pet extends Named, Fed<Food> {}
+`,
+			want: []string{
+				"extends Dog->Animal",
+				"extends Pet->Fed",
+				"extends Pet->Named",
+				"implements Dog->Named",
+				"implements Dog->Pet",
+				"implements Shape->Drawable",
+			},
+		},
+		{
+			lang: "javascript", file: "dog.js",
+			content: `class Dog extends zoo.Animal {}
+`,
+			want: []string{"extends Dog->Animal"},
+		},
+		{
+			lang: "python", file: "dog.py",
+			content: `class Dog(Animal, abc.ABC, Generic[T], metaclass=Meta):
+    pass
+`,
+			want: []string{"extends Dog->ABC", "extends Dog->Animal", "extends Dog->Generic"},
+		},
+		{
+			lang: "rust", file: "dog.rs",
+			content: `impl fmt::Display for Dog {}
+impl<T> Pet for Wrapper<T> {}
+impl Dog {}
+trait Pet: Named + Clone {}
+`,
+			want: []string{
+				"extends Pet->Clone",
+				"extends Pet->Named",
+				"implements Dog->Display",
+				"implements Wrapper->Pet",
+			},
+		},
+		{
+			lang: "cpp", file: "dog.cpp",
+			content: `class Dog : public Animal, private zoo::Pet {};
+struct Point : Base<int> {};
+`,
+			want: []string{"extends Dog->Animal", "extends Dog->Pet", "extends Point->Base"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.lang, func(t *testing.T) {
+			edges, err := p.ParseContentHierarchy([]byte(c.content), c.lang, c.file)
+			if err != nil {
+				t.Fatalf("ParseContentHierarchy: %v", err)
+			}
+			got := edgeStrings(edges)
+			if strings.Join(got, "\n") != strings.Join(c.want, "\n") {
+				t.Errorf("edges:\n  got  %v\n  want %v", got, c.want)
+			}
+			for _, e := range edges {
+				if e.ID == "" || e.FilePath != c.file || e.Line == 0 || e.Language != c.lang {
+					t.Errorf("edge %+v missing ID, file, line or language", e)
+				}
+			}
+		})
+	}
+}
+
+func TestStructuralHierarchy(t *testing.T) {
+	edges := []*TypeEdge{
+		{Kind: EdgeKindRequires, TypeName: "Reader", SuperName: "Read", Language: "go"},
+		{Kind: EdgeKindRequires, TypeName: "Closer", SuperName: "Close", Language: "go"},
+		{Kind: EdgeKindEmbeds, TypeName: "ReadCloser", SuperName: "Reader", Language: "go"},
+		{Kind: EdgeKindEmbeds, TypeName: "ReadCloser", SuperName: "Closer", Language: "go"},
+		{Kind: EdgeKindEmbeds, TypeName: "BufferedFile", SuperName: "File", Language: "go"},
+	}
+	symbols := []*Symbol{
+		{Name: "Reader", Kind: KindInterface, FilePath: "io.go", StartLine: 3, Language: "go"},
+		{Name: "Closer", Kind: KindInterface, FilePath: "io.go", StartLine: 7, Language: "go"},
+		{Name: "ReadCloser", Kind: KindInterface, FilePath: "io.go", StartLine: 11, Language: "go"},
+		{Name: "File", Kind: KindClass, FilePath: "file.go", StartLine: 5, Language: "go"},
+		{Name: "Read", Kind: KindMethod, Container: "File", FilePath: "file.go", StartLine: 9, Language: "go"},
+		{Name: "Close", Kind: KindMethod, Container: "File", FilePath: "file.go", StartLine: 12, Language: "go"},
+		{Name: "BufferedFile", Kind: KindClass, FilePath: "file.go", StartLine: 20, Language: "go"},
+		{Name: "Read", Kind: KindMethod, Container: "Buffer", FilePath: "buf.go", StartLine: 4, Language: "go"},
+		{Name: "Close", Kind: KindMethod, Container: "PyFile", FilePath: "f.py", StartLine: 1, Language: "python"},
+	}
+
+	got := edgeStrings(StructuralImplementations("ReadCloser", edges, symbols))
+	want := []string{"implements BufferedFile->ReadCloser", "implements File->ReadCloser"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("StructuralImplementations(ReadCloser) = %v, want %v", got, want)
+	}
+
+	impls := StructuralImplementations("Reader", edges, symbols)
+	if got := edgeStrings(impls); strings.Join(got, ",") != "implements Buffer->Reader,implements BufferedFile->Reader,implements File->Reader" {
+		t.Errorf("StructuralImplementations(Reader) = %v", got)
+	}
+	for _, e := range impls {
+		if !e.Structural {
+			t.Errorf("expected structural edge, got %+v", e)
+		}
+		if e.TypeName == "Buffer" && (e.FilePath != "buf.go" || e.Line != 4) {
+			t.Errorf("expected Buffer located at its method buf.go:4, got %s:%d", e.FilePath, e.Line)
+		}
+		if e.TypeName == "File" && (e.FilePath != "file.go" || e.Line != 5) {
+			t.Errorf("expected File located at its definition file.go:5, got %s:%d", e.FilePath, e.Line)
+		}
+	}
+
+	got = edgeStrings(StructuralSupertypes("File", edges, symbols))
+	want = []string{"implements File->Closer", "implements File->ReadCloser", "implements File->Reader"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("StructuralSupertypes(File) = %v, want %v", got, want)
+	}
+
+	if got := StructuralSupertypes("Reader", edges, symbols); len(got) != 0 {
+		t.Errorf("expected no structural supertypes for an interface, got %v", edgeStrings(got))
+	}
+	if got := StructuralImplementations("File", edges, symbols); len(got) != 0 {
+		t.Errorf("expected no implementations of a concrete type, got %v", edgeStrings(got))
+	}
+}
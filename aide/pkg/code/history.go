@@ -0,0 +1,92 @@
+package code
+
+import (
+	"sort"
+	"time"
+)
+
+// FileHistory is the commit history of one file's symbols: which commits
+// changed the lines each symbol occupies, followed back along first parents
+// from Head.
+type FileHistory struct {
+	FilePath string                    `json:"file"`
+	Head     string                    `json:"head"`    // Commit the history runs back from
+	Commits  map[string]*CommitInfo    `json:"commits"` // Keyed by hash
+	Symbols  map[string][]SymbolChange `json:"symbols"` // Keyed by HistoryKey, newest first
+}
+
+// CommitInfo describes a commit in a file's history.
+type CommitInfo struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email,omitempty"`
+	When    time.Time `json:"when"`
+	Subject string    `json:"subject"` // First line of the message
+}
+
+// SymbolChange records one commit touching a symbol.
+type SymbolChange struct {
+	Hash    string `json:"hash"`
+	Added   int    `json:"added"`   // Lines added or rewritten inside the symbol
+	Deleted int    `json:"deleted"` // Lines removed from inside the symbol
+}
+
+// SymbolHistory summarises the history of one symbol.
+type SymbolHistory struct {
+	Symbol       *Symbol         `json:"symbol"`
+	Churn        int             `json:"churn"` // Commits that touched the symbol
+	LinesChanged int             `json:"lines_changed"`
+	Authors      []AuthorChanges `json:"authors"` // Most commits first
+	Commits      []SymbolCommit  `json:"commits"` // Newest first
+}
+
+// AuthorChanges counts one author's commits to a symbol.
+type AuthorChanges struct {
+	Author  string `json:"author"`
+	Commits int    `json:"commits"`
+}
+
+// SymbolCommit is a commit in a symbol's history.
+type SymbolCommit struct {
+	CommitInfo
+	Added   int `json:"added"`
+	Deleted int `json:"deleted"`
+}
+
+// HistoryKey identifies a symbol across edits within its file: line ranges
+// move, but the kind and qualified name stay put until it is renamed.
+func HistoryKey(s *Symbol) string {
+	name := s.QualifiedName
+	if name == "" {
+		name = s.Name
+	}
+	return s.Kind + ":" + name
+}
+
+// For summarises sym's history, keeping at most limit commits (0 = all).
+func (h *FileHistory) For(sym *Symbol, limit int) *SymbolHistory {
+	res := &SymbolHistory{Symbol: sym, Authors: []AuthorChanges{}, Commits: []SymbolCommit{}}
+	authors := make(map[string]int)
+	for _, ch := range h.Symbols[HistoryKey(sym)] {
+		info := h.Commits[ch.Hash]
+		if info == nil {
+			continue
+		}
+		res.Churn++
+		res.LinesChanged += ch.Added + ch.Deleted
+		authors[info.Author]++
+		if limit <= 0 || len(res.Commits) < limit {
+			res.Commits = append(res.Commits, SymbolCommit{CommitInfo: *info, Added: ch.Added, Deleted: ch.Deleted})
+		}
+	}
+	for a, n := range authors {
+		res.Authors = append(res.Authors, AuthorChanges{Author: a, Commits: n})
+	}
+	sort.Slice(res.Authors, func(i, j int) bool {
+		if res.Authors[i].Commits != res.Authors[j].Commits {
+			return res.Authors[i].Commits > res.Authors[j].Commits
+		}
+		return res.Authors[i].Author < res.Authors[j].Author
+	})
+	return res
+}
@@ -0,0 +1,229 @@
+package code
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"sync"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// FileParse is everything the indexer extracts from one file, taken from a
+// single tree-sitter parse.
+type FileParse struct {
+	Language string
+	Symbols  []*Symbol
+	Refs     []*Reference
+	Edges    []*TypeEdge
+	Reused   bool // The previous tree was edited and reparsed incrementally
+}
+
+// TreeCache keeps the most recently parsed tree-sitter trees, with the
+// content they were parsed from, so that re-parsing a file after an edit can
+// hand tree-sitter the old tree and only re-scan what changed. Trees are
+// evicted least-recently-used beyond the capacity. A nil *TreeCache is valid
+// and caches nothing.
+type TreeCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Front is most recently used
+	entries  map[string]*list.Element
+}
+
+type cachedTree struct {
+	path    string
+	lang    string
+	content []byte
+	tree    *tree_sitter.Tree
+}
+
+// NewTreeCache creates a cache holding up to capacity trees.
+func NewTreeCache(capacity int) *TreeCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TreeCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of cached trees.
+func (c *TreeCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Remove drops the tree cached for path, e.g. when the file is deleted.
+func (c *TreeCache) Remove(path string) {
+	if t := c.take(path); t != nil {
+		t.tree.Close()
+	}
+}
+
+// Close frees every cached tree.
+func (c *TreeCache) Close() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		e.Value.(*cachedTree).tree.Close()
+	}
+	c.order.Init()
+	clear(c.entries)
+}
+
+// take removes and returns the entry for path. Trees are not safe for
+// concurrent use, so a parse owns its entry until it puts the new tree
+// back; a concurrent parse of the same file simply misses.
+func (c *TreeCache) take(path string) *cachedTree {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[path]
+	if !ok {
+		return nil
+	}
+	c.order.Remove(e)
+	delete(c.entries, path)
+	return e.Value.(*cachedTree)
+}
+
+// put stores t as the most recently used entry, evicting beyond capacity.
+func (c *TreeCache) put(t *cachedTree) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[t.path]; ok {
+		e.Value.(*cachedTree).tree.Close()
+		c.order.Remove(e)
+	}
+	c.entries[t.path] = c.order.PushFront(t)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		evicted := oldest.Value.(*cachedTree)
+		c.order.Remove(oldest)
+		delete(c.entries, evicted.path)
+		evicted.tree.Close()
+	}
+}
+
+// ParseFileCached parses filePath once and extracts its symbols, references
+// and type edges. When cache holds the file's previous tree, the edit between
+// the cached and current content is applied to it and the file is reparsed
+// incrementally; the new tree then replaces it in the cache.
+func (p *Parser) ParseFileCached(filePath string, cache *TreeCache) (*FileParse, error) {
+	content, lang, err := readAndDetectLang(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return p.parseCached(filePath, content, lang, cache)
+}
+
+// ParseFileContent parses content that is not on disk — a file read from a
+// git tree, say — the way ParseFileCached parses a file, without a tree
+// cache. The language is detected from filePath and, failing that, a
+// shebang; filePath is recorded on the extracted symbols as given.
+func (p *Parser) ParseFileContent(filePath string, content []byte) (*FileParse, error) {
+	return p.parseCached(filePath, content, DetectLanguage(filePath, content), nil)
+}
+
+// parseCached extracts symbols, references and type edges from content in
+// one parse, reusing and refreshing cache when it is non-nil.
+func (p *Parser) parseCached(filePath string, content []byte, lang string, cache *TreeCache) (*FileParse, error) {
+	res := &FileParse{Language: lang}
+	if lang == "" {
+		return res, nil
+	}
+	language := p.getLanguage(lang)
+	if language == nil {
+		return res, nil
+	}
+
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	if err := parser.SetLanguage(language); err != nil {
+		return nil, fmt.Errorf("set language %q: %w", lang, err)
+	}
+
+	var oldTree *tree_sitter.Tree
+	if old := cache.take(filePath); old != nil {
+		if old.lang == lang {
+			if edit := diffEdit(old.content, content); edit != nil {
+				old.tree.Edit(edit)
+			}
+			oldTree = old.tree
+			res.Reused = true
+		} else {
+			old.tree.Close()
+		}
+	}
+	tree := parser.Parse(content, oldTree)
+	oldTree.Close()
+	if tree == nil {
+		return res, nil
+	}
+
+	root := tree.RootNode()
+	if query := p.getTagQuery(lang); query != nil {
+		res.Symbols = p.extractWithQuery(query, root, content, filePath, lang)
+		qualifySymbols(res.Symbols, p.extractNamespace(lang, root, content))
+	}
+	if query := p.getRefQuery(lang); query != nil {
+		res.Refs = p.extractReferences(query, root, content, filePath, lang)
+	}
+	if query := p.getHierarchyQuery(lang); query != nil {
+		res.Edges = extractHierarchy(query, root, content, filePath, lang)
+	}
+
+	if cache != nil {
+		cache.put(&cachedTree{path: filePath, lang: lang, content: content, tree: tree})
+	} else {
+		tree.Close()
+	}
+	return res, nil
+}
+
+// diffEdit describes the change from old to new as a single tree-sitter edit
+// spanning everything between their common prefix and common suffix, or nil
+// when the content is unchanged.
+func diffEdit(old, new []byte) *tree_sitter.InputEdit {
+	if bytes.Equal(old, new) {
+		return nil
+	}
+	start := 0
+	for start < len(old) && start < len(new) && old[start] == new[start] {
+		start++
+	}
+	oldEnd, newEnd := len(old), len(new)
+	for oldEnd > start && newEnd > start && old[oldEnd-1] == new[newEnd-1] {
+		oldEnd--
+		newEnd--
+	}
+	return &tree_sitter.InputEdit{
+		StartByte:      uint(start),
+		OldEndByte:     uint(oldEnd),
+		NewEndByte:     uint(newEnd),
+		StartPosition:  pointAt(old, start),
+		OldEndPosition: pointAt(old, oldEnd),
+		NewEndPosition: pointAt(new, newEnd),
+	}
+}
+
+// pointAt returns the row and byte column of offset in content.
+func pointAt(content []byte, offset int) tree_sitter.Point {
+	before := content[:offset]
+	return tree_sitter.Point{
+		Row:    uint(bytes.Count(before, []byte{'\n'})),
+		Column: uint(offset - (bytes.LastIndexByte(before, '\n') + 1)),
+	}
+}
@@ -0,0 +1,125 @@
+package code
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+)
+
+func TestDiffEdit(t *testing.T) {
+	if diffEdit([]byte("same"), []byte("same")) != nil {
+		t.Error("expected no edit for identical content")
+	}
+
+	old := []byte("a\nbc\nd\n")
+	edit := diffEdit(old, []byte("a\nbXYc\nd\n"))
+	if edit.StartByte != 3 || edit.OldEndByte != 3 || edit.NewEndByte != 5 {
+		t.Errorf("insert edit bytes = %d/%d/%d, want 3/3/5", edit.StartByte, edit.OldEndByte, edit.NewEndByte)
+	}
+	if edit.StartPosition.Row != 1 || edit.StartPosition.Column != 1 || edit.NewEndPosition.Column != 3 {
+		t.Errorf("insert edit positions = %+v -> %+v", edit.StartPosition, edit.NewEndPosition)
+	}
+
+	edit = diffEdit(old, []byte("a\nd\n"))
+	if edit.StartByte != 2 || edit.OldEndByte != 5 || edit.NewEndByte != 2 || edit.OldEndPosition.Row != 2 {
+		t.Errorf("delete edit = %+v", edit)
+	}
+}
+
+// TestParseFileCached checks an incremental reparse extracts the same
+// records as a fresh parse of the edited file.
+func TestParseFileCached(t *testing.T) {
+	p := NewParser(grammar.NewCompositeLoader(grammar.WithAutoDownload(false)))
+	defer p.Close()
+	cache := NewTreeCache(4)
+	defer cache.Close()
+
+	path := filepath.Join(t.TempDir(), "bench.go")
+	if err := os.WriteFile(path, []byte(benchGoSource), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := p.ParseFileCached(path, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Reused || len(first.Symbols) == 0 || cache.Len() != 1 {
+		t.Fatalf("first parse: reused=%v symbols=%d cached=%d", first.Reused, len(first.Symbols), cache.Len())
+	}
+
+	edited := strings.Replace(benchGoSource, "// ValidName reports", "// Extra is new.\nfunc Extra() int { return SumAll(nil) }\n\n// ValidName reports", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := p.ParseFileCached(path, cache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Reused {
+		t.Error("expected the cached tree to be reused")
+	}
+
+	fresh, err := p.ParseFileCached(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	symKey := func(ss []*Symbol) string {
+		var parts []string
+		for _, s := range ss {
+			parts = append(parts, s.QualifiedName+"@"+strconv.Itoa(s.StartLine)+"-"+strconv.Itoa(s.EndLine))
+		}
+		return strings.Join(parts, ",")
+	}
+	refKey := func(rs []*Reference) string {
+		var parts []string
+		for _, r := range rs {
+			parts = append(parts, r.Kind+":"+r.SymbolName+"@"+strconv.Itoa(r.Line)+":"+strconv.Itoa(r.Column))
+		}
+		return strings.Join(parts, ",")
+	}
+	if got, want := symKey(second.Symbols), symKey(fresh.Symbols); got != want {
+		t.Errorf("incremental symbols differ:\n got %s\nwant %s", got, want)
+	}
+	if got, want := refKey(second.Refs), refKey(fresh.Refs); got != want {
+		t.Errorf("incremental refs differ:\n got %s\nwant %s", got, want)
+	}
+	if !strings.Contains(symKey(second.Symbols), "benchpkg.Extra@") {
+		t.Errorf("new function missing from %s", symKey(second.Symbols))
+	}
+}
+
+func TestTreeCacheEviction(t *testing.T) {
+	p := NewParser(grammar.NewCompositeLoader(grammar.WithAutoDownload(false)))
+	defer p.Close()
+	cache := NewTreeCache(1)
+	defer cache.Close()
+
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.go"), filepath.Join(dir, "b.go")
+	for _, f := range []string{a, b} {
+		if err := os.WriteFile(f, []byte(benchGoSource), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := p.ParseFileCached(f, cache); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("cache holds %d trees, want 1", cache.Len())
+	}
+	if res, _ := p.ParseFileCached(a, cache); res.Reused {
+		t.Error("a.go should have been evicted")
+	}
+	cache.Remove(a)
+	if cache.Len() != 0 {
+		t.Errorf("cache holds %d trees after Remove, want 0", cache.Len())
+	}
+
+	var nilCache *TreeCache
+	if res, err := p.ParseFileCached(b, nilCache); err != nil || res.Reused || len(res.Symbols) == 0 {
+		t.Errorf("nil cache parse = %+v, %v", res, err)
+	}
+}
@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
 	"github.com/oklog/ulid/v2"
@@ -27,6 +30,8 @@ type Parser struct {
 	languages  map[string]*tree_sitter.Language // Loaded grammars (cache)
 	queries    map[string]*tree_sitter.Query    // Compiled tag queries (cache)
 	refQueries map[string]*tree_sitter.Query    // Compiled reference queries (cache)
+	nsQueries  map[string]*tree_sitter.Query    // Compiled namespace queries (cache)
+	hQueries   map[string]*tree_sitter.Query    // Compiled hierarchy queries (cache)
 }
 
 // Close releases all cached tree-sitter queries. The Parser must not be used
@@ -43,6 +48,14 @@ func (p *Parser) Close() {
 		q.Close()
 		delete(p.refQueries, lang)
 	}
+	for lang, q := range p.nsQueries {
+		q.Close()
+		delete(p.nsQueries, lang)
+	}
+	for lang, q := range p.hQueries {
+		q.Close()
+		delete(p.hQueries, lang)
+	}
 }
 
 // NewParser creates a new code parser backed by the given grammar loader.
@@ -64,6 +77,8 @@ func NewParserWithRegistry(loader grammar.Loader, registry *grammar.PackRegistry
 		languages:  make(map[string]*tree_sitter.Language),
 		queries:    make(map[string]*tree_sitter.Query),
 		refQueries: make(map[string]*tree_sitter.Query),
+		nsQueries:  make(map[string]*tree_sitter.Query),
+		hQueries:   make(map[string]*tree_sitter.Query),
 	}
 }
 
@@ -136,6 +151,18 @@ func (p *Parser) getRefQuery(lang string) *tree_sitter.Query {
 	return p.getQuery(lang, p.refQueries, func(pack *grammar.Pack) string { return pack.Queries.Refs })
 }
 
+// getNamespaceQuery returns the compiled namespace query for a language, or
+// nil when the pack declares none.
+func (p *Parser) getNamespaceQuery(lang string) *tree_sitter.Query {
+	return p.getQuery(lang, p.nsQueries, func(pack *grammar.Pack) string { return pack.Queries.Namespace })
+}
+
+// getHierarchyQuery returns the compiled hierarchy query for a language, or
+// nil when the pack declares none.
+func (p *Parser) getHierarchyQuery(lang string) *tree_sitter.Query {
+	return p.getQuery(lang, p.hQueries, func(pack *grammar.Pack) string { return pack.Queries.Hierarchy })
+}
+
 // DetectLanguage determines the language for a file using multiple heuristics:
 // 1. File extension (fastest, covers ~95% of cases)
 // 2. Known filenames (Makefile, Jenkinsfile, etc.)
@@ -267,7 +294,9 @@ func (p *Parser) parseTree(content []byte, lang string, fn func(*tree_sitter.Nod
 func (p *Parser) ParseContent(content []byte, lang, filePath string) ([]*Symbol, error) {
 	result, err := p.parseTree(content, lang, func(root *tree_sitter.Node) (interface{}, error) {
 		if query := p.getTagQuery(lang); query != nil {
-			return p.extractWithQuery(query, root, content, filePath, lang), nil
+			symbols := p.extractWithQuery(query, root, content, filePath, lang)
+			qualifySymbols(symbols, p.extractNamespace(lang, root, content))
+			return symbols, nil
 		}
 		return nil, nil
 	})
@@ -279,9 +308,14 @@ func (p *Parser) ParseContent(content []byte, lang, filePath string) ([]*Symbol,
 
 // extractWithQuery extracts symbols using a tree-sitter query.
 // This is the preferred method as it uses standard tags.scm patterns.
+//
+// Besides @name and @definition.<kind>, a pattern may capture @container
+// for symbols whose owner is not lexically enclosing them (a Go method's
+// receiver type). Lexical containment is resolved afterwards from the
+// definition nodes' byte ranges, and ParentID / Container are set from it.
 func (p *Parser) extractWithQuery(query *tree_sitter.Query, root *tree_sitter.Node, content []byte, filePath, lang string) []*Symbol {
-	var symbols []*Symbol
-	seen := make(map[string]bool) // Dedupe by position
+	var defs []*symbolDef
+	seen := make(map[string]*symbolDef) // Dedupe by position
 
 	cursor := tree_sitter.NewQueryCursor()
 	defer cursor.Close()
@@ -289,12 +323,16 @@ func (p *Parser) extractWithQuery(query *tree_sitter.Query, root *tree_sitter.No
 	// Build capture name index
 	captureNames := query.CaptureNames()
 	nameIndex := -1
+	containerIndex := -1
 	defIndexes := make(map[uint32]string) // capture index -> kind (function, class, etc.)
 
 	for i, captureName := range captureNames {
-		if captureName == "name" {
+		switch {
+		case captureName == "name":
 			nameIndex = i
-		} else if strings.HasPrefix(captureName, "definition.") {
+		case captureName == "container":
+			containerIndex = i
+		case strings.HasPrefix(captureName, "definition."):
 			kind := strings.TrimPrefix(captureName, "definition.")
 			defIndexes[uint32(i)] = kind
 		}
@@ -302,13 +340,16 @@ func (p *Parser) extractWithQuery(query *tree_sitter.Query, root *tree_sitter.No
 
 	matches := cursor.Matches(query, root, content)
 	for match := matches.Next(); match != nil; match = matches.Next() {
-		var name string
+		var name, container string
 		var defNode *tree_sitter.Node
 		var kind string
 
 		for _, capture := range match.Captures {
-			if int(capture.Index) == nameIndex {
+			switch {
+			case int(capture.Index) == nameIndex:
 				name = capture.Node.Utf8Text(content)
+			case int(capture.Index) == containerIndex:
+				container = capture.Node.Utf8Text(content)
 			}
 			if k, ok := defIndexes[capture.Index]; ok {
 				node := capture.Node
@@ -321,12 +362,16 @@ func (p *Parser) extractWithQuery(query *tree_sitter.Query, root *tree_sitter.No
 			continue
 		}
 
-		// Dedupe by position
-		key := filePath + ":" + name + ":" + kind
-		if seen[key] {
+		// Dedupe by position. Several patterns may match the same node (a
+		// receiver-aware method pattern and its plain fallback); keep the
+		// first and take the container from whichever match has one.
+		key := fmt.Sprintf("%d:%s:%s", defNode.StartByte(), name, kind)
+		if prev, ok := seen[key]; ok {
+			if prev.container == "" {
+				prev.container = container
+			}
 			continue
 		}
-		seen[key] = true
 
 		// Map kind to our constants
 		symbolKind := mapQueryKindToSymbolKind(kind)
@@ -349,14 +394,146 @@ func (p *Parser) extractWithQuery(query *tree_sitter.Query, root *tree_sitter.No
 		if bodyNode != nil {
 			sym.BodyStartLine = int(bodyNode.StartPosition().Row) + 1
 			sym.BodyEndLine = int(bodyNode.EndPosition().Row) + 1
+			sym.Body = bodyText(bodyNode, content)
+		}
+
+		def := &symbolDef{sym: sym, start: defNode.StartByte(), end: defNode.EndByte(), container: container}
+		seen[key] = def
+		defs = append(defs, def)
+	}
+
+	return linkSymbolParents(defs)
+}
+
+// symbolDef is a symbol under construction with its definition node's byte
+// range and any explicitly captured container.
+type symbolDef struct {
+	sym        *Symbol
+	start, end uint
+	container  string
+	parent     *symbolDef
+}
+
+// linkSymbolParents sets each symbol's parent to the innermost definition
+// whose range strictly encloses it, then fills Container and ParentID.
+// Symbols with an explicit @container (and no lexical parent) are attached
+// to a same-file type of that name when one exists. Returns the symbols in
+// query-match order.
+func linkSymbolParents(defs []*symbolDef) []*Symbol {
+	sorted := make([]*symbolDef, len(defs))
+	copy(sorted, defs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].start != sorted[j].start {
+			return sorted[i].start < sorted[j].start
 		}
+		return sorted[i].end > sorted[j].end
+	})
 
-		symbols = append(symbols, sym)
+	var stack []*symbolDef
+	for _, d := range sorted {
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			// Pop ranges that end before d does, and identical ranges (the
+			// same node captured under two kinds is a sibling, not a parent).
+			if top.end < d.end || top.end <= d.start || (top.start == d.start && top.end == d.end) {
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			break
+		}
+		if len(stack) > 0 {
+			d.parent = stack[len(stack)-1]
+		}
+		stack = append(stack, d)
 	}
 
+	// Receiver targets, preferring the class/interface capture of a node
+	// over its generic type capture.
+	types := make(map[string]*symbolDef)
+	for _, d := range defs {
+		switch d.sym.Kind {
+		case KindClass, KindInterface, KindType:
+			if prev, ok := types[d.sym.Name]; !ok || (prev.sym.Kind == KindType && d.sym.Kind != KindType) {
+				types[d.sym.Name] = d
+			}
+		}
+	}
+
+	symbols := make([]*Symbol, 0, len(defs))
+	for _, d := range defs {
+		switch {
+		case d.parent != nil:
+			d.sym.ParentID = d.parent.sym.ID
+			d.sym.Container = d.parent.sym.Name
+		case d.container != "":
+			d.sym.Container = d.container
+			if t, ok := types[d.container]; ok && t != d {
+				d.sym.ParentID = t.sym.ID
+			}
+		}
+		symbols = append(symbols, d.sym)
+	}
 	return symbols
 }
 
+// extractNamespace returns the file's package or namespace name from the
+// pack's namespace query (the first @name capture), or "" when the language
+// declares none.
+func (p *Parser) extractNamespace(lang string, root *tree_sitter.Node, content []byte) string {
+	query := p.getNamespaceQuery(lang)
+	if query == nil {
+		return ""
+	}
+	cursor := tree_sitter.NewQueryCursor()
+	defer cursor.Close()
+
+	names := query.CaptureNames()
+	matches := cursor.Matches(query, root, content)
+	for match := matches.Next(); match != nil; match = matches.Next() {
+		for _, capture := range match.Captures {
+			if names[capture.Index] == "name" {
+				return NormalizeQualifiedName(capture.Node.Utf8Text(content))
+			}
+		}
+	}
+	return ""
+}
+
+// qualifySymbols fills QualifiedName from the namespace and each symbol's
+// container chain, and defaults Container to the namespace for top-level
+// symbols.
+func qualifySymbols(symbols []*Symbol, namespace string) {
+	byID := make(map[string]*Symbol, len(symbols))
+	for _, sym := range symbols {
+		byID[sym.ID] = sym
+	}
+	for _, sym := range symbols {
+		parts := []string{sym.Name}
+		switch {
+		case sym.ParentID != "":
+			// Walk the lexical chain; depth is bounded by the symbol count
+			// in case a receiver link ever closes a loop.
+			for p, n := byID[sym.ParentID], 0; p != nil && n < len(symbols); n++ {
+				parts = append(parts, p.Name)
+				if p.ParentID == "" && p.Container != "" && p.Container != namespace {
+					parts = append(parts, p.Container)
+				}
+				p = byID[p.ParentID]
+			}
+		case sym.Container != "":
+			parts = append(parts, sym.Container)
+		}
+		if namespace != "" {
+			parts = append(parts, namespace)
+			if sym.Container == "" {
+				sym.Container = namespace
+			}
+		}
+		slices.Reverse(parts)
+		sym.QualifiedName = NormalizeQualifiedName(strings.Join(parts, "."))
+	}
+}
+
 // mapQueryKindToSymbolKind maps tree-sitter query kinds to our symbol kinds.
 func mapQueryKindToSymbolKind(queryKind string) string {
 	switch queryKind {
@@ -395,6 +572,19 @@ func (p *Parser) nodeText(node *tree_sitter.Node, content []byte) string {
 	return string(content[start:end])
 }
 
+// bodyText returns the first MaxBodyText bytes of node's text, cut at a
+// UTF-8 boundary.
+func bodyText(node *tree_sitter.Node, content []byte) string {
+	start, end := node.StartByte(), min(node.EndByte(), uint(len(content)))
+	if end-start > MaxBodyText {
+		end = start + MaxBodyText
+		for end > start && !utf8.RuneStart(content[end]) {
+			end--
+		}
+	}
+	return string(content[start:end])
+}
+
 func (p *Parser) extractSignature(node *tree_sitter.Node, content []byte) string {
 	// Extract from start of node to start of body (or end if no body)
 	bodyNode := node.ChildByFieldName("body")
@@ -2,6 +2,7 @@ package code
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
@@ -386,6 +387,20 @@ void greet(const char* name) {
 	assertContains(t, names, "add", "function add")
 	assertContains(t, names, "greet", "function greet")
 	assertContains(t, names, "ErrorCode", "typedef ErrorCode")
+
+	refs, err := p.ParseContentReferences(append([]byte("#include \"point.h\"\n"), content...), "c", "main.c")
+	if err != nil {
+		t.Fatalf("ParseContentReferences: %v", err)
+	}
+	var includes []string
+	for _, r := range refs {
+		if r.Kind == RefKindImport {
+			includes = append(includes, r.SymbolName)
+		}
+	}
+	if strings.Join(includes, " ") != `"point.h" <stdio.h>` {
+		t.Errorf("include refs = %q, want the quoted and angle-bracket paths as written", includes)
+	}
 }
 
 func TestParseContentCPP(t *testing.T) {
@@ -695,6 +710,161 @@ func TestPackRegistryQueriesCompile(t *testing.T) {
 				}
 			})
 		}
+		if pack.Queries.Namespace != "" {
+			t.Run(name+"/namespace", func(t *testing.T) {
+				tsLang := p.getLanguage(name)
+				if tsLang == nil {
+					t.Skipf("grammar %q not available (dynamic grammar)", name)
+					return
+				}
+				if q := p.getNamespaceQuery(name); q == nil {
+					t.Errorf("pack namespace query for %q failed to compile", name)
+				}
+			})
+		}
+		if pack.Queries.Hierarchy != "" {
+			t.Run(name+"/hierarchy", func(t *testing.T) {
+				tsLang := p.getLanguage(name)
+				if tsLang == nil {
+					t.Skipf("grammar %q not available (dynamic grammar)", name)
+					return
+				}
+				if q := p.getHierarchyQuery(name); q == nil {
+					t.Errorf("pack hierarchy query for %q failed to compile", name)
+				}
+			})
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Qualified names and containers
+// ---------------------------------------------------------------------------
+
+func symbolsByQName(symbols []*Symbol) map[string]*Symbol {
+	m := make(map[string]*Symbol, len(symbols))
+	for _, s := range symbols {
+		m[s.QualifiedName+"/"+s.Kind] = s
+	}
+	return m
+}
+
+func TestParseContentQualifiedGo(t *testing.T) {
+	p := newTestParser()
+	src := []byte(`package store
+
+type CodeStore struct{}
+
+func (s *CodeStore) Close() error { return nil }
+
+type Handler struct{}
+
+func (h Handler) Close() error { return nil }
+
+func (s *Set[T]) Add(v T) {}
+
+func Open() *CodeStore { return nil }
+`)
+	symbols, err := p.ParseContent(src, "go", "store/code.go")
+	if err != nil {
+		t.Fatalf("ParseContent: %v", err)
+	}
+	byQ := symbolsByQName(symbols)
+
+	storeClose := byQ["store.CodeStore.Close/method"]
+	handlerClose := byQ["store.Handler.Close/method"]
+	if storeClose == nil || handlerClose == nil {
+		t.Fatalf("expected both Close methods to be qualified, got %v", mapKeys(qnameSet(symbols)))
+	}
+	if storeClose.Container != "CodeStore" || handlerClose.Container != "Handler" {
+		t.Errorf("unexpected containers: %q, %q", storeClose.Container, handlerClose.Container)
+	}
+	if typ := byQ["store.CodeStore/class"]; typ == nil || storeClose.ParentID != typ.ID {
+		t.Errorf("expected CodeStore.Close parent to be the CodeStore type")
+	}
+	if add := byQ["store.Set.Add/method"]; add == nil || add.Container != "Set" || add.ParentID != "" {
+		t.Errorf("expected generic receiver container without a same-file parent, got %+v", add)
+	}
+	if open := byQ["store.Open/function"]; open == nil || open.Container != "store" || open.ParentID != "" {
+		t.Errorf("expected top-level function in package container, got %+v", open)
+	}
+}
+
+func TestParseContentQualifiedNested(t *testing.T) {
+	p := newTestParser()
+
+	py := []byte(`class Outer:
+    class Inner:
+        def run(self):
+            pass
+
+    def run(self):
+        pass
+`)
+	symbols, err := p.ParseContent(py, "python", "m.py")
+	if err != nil {
+		t.Fatalf("ParseContent: %v", err)
+	}
+	byQ := symbolsByQName(symbols)
+	inner := byQ["Outer.Inner.run/function"]
+	outer := byQ["Outer.run/function"]
+	if inner == nil || outer == nil {
+		t.Fatalf("expected both run methods, got %v", mapKeys(qnameSet(symbols)))
+	}
+	if inner.Container != "Inner" || inner.ParentID != byQ["Outer.Inner/class"].ID {
+		t.Errorf("unexpected inner run: %+v", inner)
+	}
+
+	java := []byte(`package com.example.store;
+
+class CodeStore {
+    void close() {}
+}
+`)
+	symbols, err = p.ParseContent(java, "java", "CodeStore.java")
+	if err != nil {
+		t.Fatalf("ParseContent: %v", err)
+	}
+	if byQ := symbolsByQName(symbols); byQ["com.example.store.CodeStore.close/method"] == nil {
+		t.Errorf("expected package-qualified Java method, got %v", mapKeys(qnameSet(symbols)))
+	}
+
+	rs := []byte(`struct Store;
+
+impl Store {
+    fn close(&self) {}
+}
+`)
+	symbols, err = p.ParseContent(rs, "rust", "lib.rs")
+	if err != nil {
+		t.Fatalf("ParseContent: %v", err)
+	}
+	if byQ := symbolsByQName(symbols); byQ["Store.close/function"] == nil {
+		t.Errorf("expected impl-qualified Rust fn, got %v", mapKeys(qnameSet(symbols)))
+	}
+}
+
+func TestSymbolMatchesName(t *testing.T) {
+	sym := &Symbol{Name: "Close", QualifiedName: "store.CodeStore.Close"}
+	tests := []struct {
+		q    string
+		want bool
+	}{
+		{"Close", true},
+		{"store.CodeStore.Close", true},
+		{"CodeStore.Close", true},
+		{"CodeStore::Close", true},
+		{"Store.Close", false},
+		{"Handler.Close", false},
+		{"close", false},
+	}
+	for _, tt := range tests {
+		if got := sym.MatchesName(tt.q); got != tt.want {
+			t.Errorf("MatchesName(%q) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+	if IsQualifiedName("*Close*") || IsQualifiedName("Close") || !IsQualifiedName("Foo::bar") {
+		t.Error("unexpected IsQualifiedName result")
 	}
 }
 
@@ -702,6 +872,14 @@ func TestPackRegistryQueriesCompile(t *testing.T) {
 // Helpers
 // ---------------------------------------------------------------------------
 
+func qnameSet(symbols []*Symbol) map[string]bool {
+	m := make(map[string]bool, len(symbols))
+	for _, s := range symbols {
+		m[s.QualifiedName] = true
+	}
+	return m
+}
+
 func symbolNames(symbols []*Symbol) map[string]bool {
 	m := make(map[string]bool, len(symbols))
 	for _, s := range symbols {
@@ -0,0 +1,85 @@
+package code
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// qualifiedSeparators are the scope separators accepted in qualified symbol
+// names and rewritten to "." (C++/Rust/Ruby/PHP "::", Ruby "#", PHP "\").
+var qualifiedSeparators = strings.NewReplacer("::", ".", "#", ".", "\\", ".")
+
+// NormalizeQualifiedName rewrites language-specific scope separators to "."
+// so "store::CodeStore::Close", "CodeStore#close" and "store.CodeStore.Close"
+// all compare the same way.
+func NormalizeQualifiedName(q string) string {
+	return strings.Trim(qualifiedSeparators.Replace(q), ".")
+}
+
+// IsQualifiedName reports whether q names a symbol by container path (for
+// example "store.CodeStore.Close" or "Foo::bar") rather than by bare name or
+// a search expression.
+func IsQualifiedName(q string) bool {
+	n := NormalizeQualifiedName(strings.TrimSpace(q))
+	if !strings.Contains(n, ".") || strings.Contains(n, "..") {
+		return false
+	}
+	return !strings.ContainsAny(n, " \t*?\"()[]{}:^~+!|&")
+}
+
+// QualifiedLeaf returns the last segment of a qualified name (the bare
+// symbol name).
+func QualifiedLeaf(q string) string {
+	n := NormalizeQualifiedName(q)
+	if i := strings.LastIndexByte(n, '.'); i >= 0 {
+		return n[i+1:]
+	}
+	return n
+}
+
+// MatchesName reports whether sym is named q. A bare q matches Name; a
+// qualified q matches QualifiedName exactly or as a trailing path, so
+// "CodeStore.Close" matches "store.CodeStore.Close" but not
+// "store.OtherCodeStore.Close".
+func (s *Symbol) MatchesName(q string) bool {
+	if !IsQualifiedName(q) {
+		return s.Name == q
+	}
+	n := NormalizeQualifiedName(q)
+	return s.QualifiedName == n || strings.HasSuffix(s.QualifiedName, "."+n)
+}
+
+// DisplayName returns the qualified name when known, else the bare name.
+func (s *Symbol) DisplayName() string {
+	if s.QualifiedName != "" {
+		return s.QualifiedName
+	}
+	return s.Name
+}
+
+// IdentColumn returns the byte column of the first whole-word occurrence of
+// name in line, or -1.
+func IdentColumn(line, name string) int {
+	if name == "" {
+		return -1
+	}
+	for from := 0; from < len(line); {
+		i := strings.Index(line[from:], name)
+		if i < 0 {
+			return -1
+		}
+		i += from
+		before, _ := utf8.DecodeLastRuneInString(line[:i])
+		after, _ := utf8.DecodeRuneInString(line[i+len(name):])
+		if !isIdentRune(before) && !isIdentRune(after) {
+			return i
+		}
+		from = i + len(name)
+	}
+	return -1
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,22 @@
+package code
+
+import "testing"
+
+func TestIdentColumn(t *testing.T) {
+	cases := []struct {
+		line, name string
+		want       int
+	}{
+		{"func run() {}", "run", 5},
+		{"func runner() { run() }", "run", 16},
+		{"x := $run + run", "run", 12},
+		{"func (s *Store) Close() error", "Close", 16},
+		{"rerun()", "run", -1},
+		{"", "run", -1},
+	}
+	for _, c := range cases {
+		if got := IdentColumn(c.line, c.name); got != c.want {
+			t.Errorf("IdentColumn(%q, %q) = %d, want %d", c.line, c.name, got, c.want)
+		}
+	}
+}
@@ -8,6 +8,9 @@ type Symbol struct {
 	ID            string    `json:"id"`                   // ULID
 	Name          string    `json:"name"`                 // Symbol name (e.g., "getUser")
 	Kind          string    `json:"kind"`                 // function, method, class, interface, type
+	Container     string    `json:"container,omitempty"`  // Enclosing class, receiver type, module or package
+	QualifiedName string    `json:"qname,omitempty"`      // Dotted path, e.g. "store.CodeStore.Close"
+	ParentID      string    `json:"parent,omitempty"`     // ID of the enclosing symbol in the same file
 	Signature     string    `json:"signature"`            // Full signature (e.g., "async getUser(id: string): Promise<User>")
 	DocComment    string    `json:"doc,omitempty"`        // Leading doc comment
 	FilePath      string    `json:"file"`                 // Relative file path
@@ -17,22 +20,70 @@ type Symbol struct {
 	BodyEndLine   int       `json:"bodyEnd,omitempty"`    // Body end line (1-indexed, 0 if no body)
 	Complexity    int       `json:"complexity,omitempty"` // Cyclomatic complexity (0 = not computed)
 	Language      string    `json:"lang"`                 // typescript, javascript, go, python
+	Precise       bool      `json:"precise,omitempty"`    // Imported from a compiler-grade (SCIP) index
 	CreatedAt     time.Time `json:"createdAt"`
+
+	// Body is the start of the symbol's source, up to MaxBodyText bytes, for
+	// embedding. Set by the parser and never stored.
+	Body string `json:"-"`
 }
 
+// MaxBodyText bounds Symbol.Body.
+const MaxBodyText = 2048
+
 // Reference represents a usage/call site of a symbol.
 type Reference struct {
-	ID         string    `json:"id"`            // ULID
-	SymbolName string    `json:"symbol"`        // Name of the referenced symbol (e.g., "getUser")
-	Kind       string    `json:"kind"`          // call, type_ref, import
-	FilePath   string    `json:"file"`          // File where the reference occurs
-	Line       int       `json:"line"`          // Line number (1-indexed)
-	Column     int       `json:"col"`           // Column number (0-indexed)
-	Context    string    `json:"ctx,omitempty"` // Surrounding code context
-	Language   string    `json:"lang"`          // Language of the file
+	ID             string    `json:"id"`                   // ULID
+	SymbolName     string    `json:"symbol"`               // Name of the referenced symbol (e.g., "getUser")
+	Kind           string    `json:"kind"`                 // call, type_ref, import
+	FilePath       string    `json:"file"`                 // File where the reference occurs
+	Line           int       `json:"line"`                 // Line number (1-indexed)
+	Column         int       `json:"col"`                  // Column number (0-indexed)
+	Context        string    `json:"ctx,omitempty"`        // Surrounding code context
+	Language       string    `json:"lang"`                 // Language of the file
+	TargetSymbolID string    `json:"target,omitempty"`     // ID of the resolved definition ("" = unresolved)
+	Confidence     string    `json:"confidence,omitempty"` // How TargetSymbolID was resolved (Confidence* constants)
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Reference target confidence levels, strongest first.
+const (
+	ConfidenceExact  = "exact"  // Confirmed by a language server or a SCIP index
+	ConfidenceHigh   = "high"   // Single candidate in the same file or package/unit
+	ConfidenceMedium = "medium" // Single candidate among the file's resolved imports
+	ConfidenceLow    = "low"    // Single candidate project-wide, matched by name only
+)
+
+// TargetResolver picks the definition a reference points at from the
+// same-named candidate symbols. imports are the raw import strings of the
+// reference's file. It returns the target symbol ID and a Confidence*
+// level, or "" to leave the reference unresolved.
+type TargetResolver func(ref *Reference, imports []string, candidates []*Symbol) (targetID, confidence string)
+
+// TypeEdge is one type-hierarchy relation declared in source: TypeName
+// extends, implements or embeds SuperName. Go interfaces also record each
+// method they declare as a "requires" edge (SuperName is the method name),
+// which is what structural implementations are matched against.
+type TypeEdge struct {
+	ID         string    `json:"id"`                   // ULID
+	Kind       string    `json:"kind"`                 // extends, implements, embeds, requires
+	TypeName   string    `json:"type"`                 // Declaring type (bare name)
+	SuperName  string    `json:"super"`                // Supertype (bare name), or method name for requires
+	FilePath   string    `json:"file"`                 // File declaring the edge
+	Line       int       `json:"line"`                 // Line number (1-indexed)
+	Language   string    `json:"lang"`                 // Language of the file
+	Structural bool      `json:"structural,omitempty"` // Inferred from method sets rather than declared
 	CreatedAt  time.Time `json:"createdAt"`
 }
 
+// TypeEdge kind constants
+const (
+	EdgeKindExtends    = "extends"    // Class inheritance, interface/trait extension
+	EdgeKindImplements = "implements" // Interface implementation, Rust trait impl
+	EdgeKindEmbeds     = "embeds"     // Go struct/interface embedding
+	EdgeKindRequires   = "requires"   // Method declared by an interface (Go)
+)
+
 // ReferenceKind constants
 const (
 	RefKindCall    = "call"     // Function/method call
@@ -85,8 +136,9 @@ type SymbolRefCount struct {
 
 // ReferenceSearchOptions for filtering reference searches
 type ReferenceSearchOptions struct {
-	SymbolName string // Filter by symbol name (required)
-	Kind       string // Filter by reference kind (call, type_ref, import)
-	FilePath   string // Filter by file path pattern
-	Limit      int    // Max results (0 = default)
+	SymbolName     string // Filter by symbol name (required)
+	Kind           string // Filter by reference kind (call, type_ref, import)
+	FilePath       string // Filter by file path pattern
+	TargetSymbolID string // Only references resolved to this definition
+	Limit          int    // Max results (0 = default)
 }
@@ -224,6 +224,41 @@ type CodeConfig struct {
 	// Config when Load has not run, and a default-on feature must not read as
 	// off in that window. Resolve it with RespectGitignoreEnabled.
 	RespectGitignore *bool `koanf:"respect_gitignore"`
+	// LSPServers are locally installed language servers the MCP server may
+	// launch over stdio to answer code_references, code_definition and
+	// code_rename_impact precisely. Languages without one fall back to the
+	// tree-sitter index. File-only: a list of objects has no env form.
+	LSPServers []LSPServerConfig `koanf:"lsp_servers"`
+	// LSPTimeout bounds one language-server query, including the server's
+	// start-up on first use. Go duration string; empty = 30s.
+	// AIDE_CODE_LSP_TIMEOUT. Resolve it with LSPTimeoutDuration.
+	LSPTimeout string `koanf:"lsp_timeout"`
+	// Embeddings selects the embedder behind semantic code search: local
+	// (default; pure Go, no model or network), openai (any OpenAI-compatible
+	// /embeddings endpoint, e.g. Ollama) or off. Changing it re-embeds the
+	// index on the next pass. AIDE_CODE_EMBEDDINGS.
+	Embeddings string `koanf:"embeddings"`
+	// EmbeddingsURL is the base URL of the openai provider; empty =
+	// https://api.openai.com/v1. AIDE_CODE_EMBEDDINGS_URL.
+	EmbeddingsURL string `koanf:"embeddings_url"`
+	// EmbeddingsModel names the openai provider's model (required for it).
+	// AIDE_CODE_EMBEDDINGS_MODEL.
+	EmbeddingsModel string `koanf:"embeddings_model"`
+	// EmbeddingsAPIKey is sent as a bearer token by the openai provider.
+	// AIDE_CODE_EMBEDDINGS_API_KEY.
+	EmbeddingsAPIKey string `koanf:"embeddings_api_key"`
+	// RepoMapTokens, when positive, adds a repo map of about this many
+	// tokens (the code_repo_map outline) to the session-start context.
+	// 0 = off. AIDE_CODE_REPO_MAP_TOKENS.
+	RepoMapTokens int `koanf:"repo_map_tokens"`
+}
+
+// LSPServerConfig maps one or more aide language names (go, typescript,
+// tsx, python, ...) to the argv of a language server that speaks LSP over
+// stdio, e.g. {"languages": ["go"], "command": ["gopls"]}.
+type LSPServerConfig struct {
+	Languages []string `koanf:"languages"`
+	Command   []string `koanf:"command"`
 }
 
 // RespectGitignoreEnabled resolves RespectGitignore, defaulting to on.
@@ -231,6 +266,16 @@ func (c CodeConfig) RespectGitignoreEnabled() bool {
 	return resolveBool(c.RespectGitignore, true)
 }
 
+// LSPTimeoutDuration parses Code.LSPTimeout, returning fallback when it is
+// empty, unparseable or not positive.
+func (c CodeConfig) LSPTimeoutDuration(fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(c.LSPTimeout)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
 // PprofConfig groups pprof http server tunables (AIDE_PPROF_*).
 type PprofConfig struct {
 	Enable bool   `koanf:"enable"`
@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+	"time"
 )
 
 // isolateHome points HOME (and USERPROFILE on Windows) at a fresh temp dir so a
@@ -179,6 +181,44 @@ func TestLoad_FileOverriddenByEnv(t *testing.T) {
 	}
 }
 
+func TestLoad_LSPServers(t *testing.T) {
+	isolateHome(t)
+	dir := t.TempDir()
+	cfgDir := filepath.Join(dir, ".aide", "config")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	contents := []byte(`{"code":{"lsp_servers":[
+		{"languages":["go"],"command":["gopls"]},
+		{"languages":["typescript","tsx"],"command":["typescript-language-server","--stdio"]}
+	]}}`)
+	if err := os.WriteFile(filepath.Join(cfgDir, "aide.json"), contents, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("AIDE_CODE_LSP_TIMEOUT", "5s")
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	servers := cfg.Code.LSPServers
+	if len(servers) != 2 {
+		t.Fatalf("LSPServers = %+v, want 2 entries", servers)
+	}
+	if got := strings.Join(servers[1].Command, " "); got != "typescript-language-server --stdio" {
+		t.Errorf("LSPServers[1].Command = %q", got)
+	}
+	if got := strings.Join(servers[1].Languages, ","); got != "typescript,tsx" {
+		t.Errorf("LSPServers[1].Languages = %q", got)
+	}
+	if got := cfg.Code.LSPTimeoutDuration(time.Minute); got != 5*time.Second {
+		t.Errorf("LSPTimeoutDuration = %v, want 5s", got)
+	}
+	if got := (CodeConfig{}).LSPTimeoutDuration(time.Minute); got != time.Minute {
+		t.Errorf("unset LSPTimeoutDuration = %v, want the fallback", got)
+	}
+}
+
 func TestShareConfigDefaults(t *testing.T) {
 	// A zero-value ShareConfig (every *bool nil, every filter empty) must
 	// resolve to the documented type defaults.
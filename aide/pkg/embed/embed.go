@@ -0,0 +1,120 @@
+// Package embed turns code symbols and search queries into vectors, so the
+// code index can rank symbols by meaning rather than by name.
+package embed
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+// Provider names accepted by New.
+const (
+	ProviderLocal  = "local"
+	ProviderOpenAI = "openai"
+	ProviderOff    = "off"
+)
+
+// Embedder maps texts to unit-length vectors whose dot product measures
+// similarity.
+type Embedder interface {
+	// Name identifies the model and its settings. Vectors from embedders
+	// with different names are not comparable, so stored vectors are
+	// discarded when it changes.
+	Name() string
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// Config selects and configures an Embedder.
+type Config struct {
+	Provider string // local (default), openai or off
+	URL      string // Base URL of an OpenAI-compatible API (openai)
+	Model    string // Model name (openai)
+	APIKey   string // Bearer token (openai; optional for local servers)
+}
+
+// New returns the embedder cfg selects, or nil when embeddings are off.
+func New(cfg Config) (Embedder, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "", ProviderLocal:
+		return NewLocal(), nil
+	case ProviderOpenAI:
+		return NewOpenAI(cfg.URL, cfg.Model, cfg.APIKey)
+	case ProviderOff, "none", "false", "0":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown embeddings provider %q (want %s, %s or %s)", cfg.Provider, ProviderLocal, ProviderOpenAI, ProviderOff)
+	}
+}
+
+// SymbolText is the text embedded for a symbol: its kind and name, then its
+// signature, doc comment and (truncated) body.
+func SymbolText(sym *code.Symbol) string {
+	var sb strings.Builder
+	name := sym.QualifiedName
+	if name == "" {
+		name = sym.Name
+	}
+	fmt.Fprintf(&sb, "%s %s\n", sym.Kind, name)
+	if sym.Signature != "" {
+		sb.WriteString(sym.Signature)
+		sb.WriteByte('\n')
+	}
+	if sym.DocComment != "" {
+		sb.WriteString(sym.DocComment)
+		sb.WriteByte('\n')
+	}
+	sb.WriteString(sym.Body)
+	return sb.String()
+}
+
+// Normalize scales v to unit length in place; zero vectors are left as is.
+func Normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	inv := float32(1 / math.Sqrt(sum))
+	for i := range v {
+		v[i] *= inv
+	}
+}
+
+// Dot returns the dot product of a and b, which is their cosine similarity
+// for unit vectors. Vectors of different lengths score 0.
+func Dot(a, b []float32) float32 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var s float32
+	for i := range a {
+		s += a[i] * b[i]
+	}
+	return s
+}
+
+// Encode serialises a vector as little-endian float32s.
+func Encode(v []float32) []byte {
+	buf := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return buf
+}
+
+// Decode is the inverse of Encode.
+func Decode(data []byte) []float32 {
+	v := make([]float32, len(data)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return v
+}
@@ -0,0 +1,113 @@
+package embed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+func TestWords(t *testing.T) {
+	got := Words("func retryHTTPCalls(max_retries int) // Retrying the calls")
+	want := []string{"retry", "http", "call", "max", "retry", "retry", "call"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Words = %v, want %v", got, want)
+	}
+}
+
+func TestLocalRanksSharedVocabulary(t *testing.T) {
+	symbols := []*code.Symbol{
+		{Kind: "function", Name: "retry", QualifiedName: "httputil.retry", Signature: "func retry(req *http.Request, attempts int) (*http.Response, error)", DocComment: "retry re-sends a request on transient failures."},
+		{Kind: "function", Name: "parseConfig", QualifiedName: "config.parseConfig", Signature: "func parseConfig(path string) (*Config, error)", DocComment: "parseConfig reads the JSON config file."},
+		{Kind: "method", Name: "Close", QualifiedName: "store.Store.Close", Signature: "func (s *Store) Close() error", Body: "return s.db.Close()"},
+	}
+	texts := make([]string, len(symbols))
+	for i, s := range symbols {
+		texts[i] = SymbolText(s)
+	}
+	e := NewLocal()
+	vecs, err := e.Embed(context.Background(), append(texts, "where do we retry HTTP calls"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := vecs[len(vecs)-1]
+	best, bestScore := -1, float32(0)
+	for i := range symbols {
+		if s := Dot(query, vecs[i]); s > bestScore {
+			best, bestScore = i, s
+		}
+	}
+	if best != 0 {
+		t.Errorf("best match = %d (score %.3f), want httputil.retry", best, bestScore)
+	}
+	if d := Dot(vecs[0], vecs[0]); d < 0.999 || d > 1.001 {
+		t.Errorf("vectors should be unit length, |v|² = %f", d)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	v := []float32{0.5, -0.25, 1}
+	if got := Decode(Encode(v)); !reflect.DeepEqual(got, v) {
+		t.Errorf("Decode(Encode(v)) = %v", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if e, err := New(Config{}); err != nil || e == nil || e.Name() != "local-hash-v1" {
+		t.Errorf("default embedder = %v, %v", e, err)
+	}
+	if e, err := New(Config{Provider: "off"}); err != nil || e != nil {
+		t.Errorf("off = %v, %v", e, err)
+	}
+	if _, err := New(Config{Provider: "openai"}); err == nil {
+		t.Error("openai without a model should fail")
+	}
+	if _, err := New(Config{Provider: "magic"}); err == nil {
+		t.Error("unknown provider should fail")
+	}
+}
+
+func TestOpenAI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("request %s auth=%q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "m" {
+			t.Errorf("request = %+v, %v", req, err)
+		}
+		type datum struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		}
+		var data []datum
+		// Answer out of order to check vectors are placed by index.
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, datum{Index: i, Embedding: []float32{float32(i + 1), 0}})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	e, err := NewOpenAI(srv.URL+"/v1/", "m", "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name() != "openai:m" {
+		t.Errorf("Name = %q", e.Name())
+	}
+	vecs, err := e.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vecs) != 2 || vecs[0][0] != 1 || vecs[1][0] != 1 {
+		t.Errorf("vectors = %v, want two normalised vectors", vecs)
+	}
+}
@@ -0,0 +1,165 @@
+package embed
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// LocalDims is the vector length of the local embedder.
+const LocalDims = 256
+
+// trigramWeight scales character-trigram features against whole words, so
+// near spellings (http/https, retry/retrier) score some similarity without
+// drowning exact word matches.
+const trigramWeight = 0.35
+
+// Local is a pure-Go embedder that needs no model or network: texts are
+// split into words (identifiers broken at camelCase, snake_case and digits,
+// lightly stemmed, keywords and stop words dropped), and the words and their
+// character trigrams are hashed into a fixed-size, log-weighted vector.
+//
+// It captures shared vocabulary rather than meaning: "retry HTTP calls"
+// finds httputil.retry through the words retry and http, but not a
+// function that only says "backoff". Use an OpenAI-compatible provider for
+// true semantic similarity.
+type Local struct{}
+
+// NewLocal returns the local embedder.
+func NewLocal() *Local { return &Local{} }
+
+// Name implements Embedder.
+func (*Local) Name() string { return "local-hash-v1" }
+
+// Embed implements Embedder.
+func (*Local) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		out[i] = localVector(t)
+	}
+	return out, nil
+}
+
+func localVector(text string) []float32 {
+	counts := make(map[string]float64)
+	for _, w := range Words(text) {
+		counts[w]++
+		if len(w) > 3 {
+			padded := "^" + w + "$"
+			for i := 0; i+3 <= len(padded); i++ {
+				counts["#"+padded[i:i+3]] += trigramWeight
+			}
+		}
+	}
+	v := make([]float32, LocalDims)
+	h := fnv.New64a()
+	for f, n := range counts {
+		h.Reset()
+		h.Write([]byte(f))
+		sum := h.Sum64()
+		w := float32(1 + math.Log(n))
+		if n < 1 {
+			w = float32(n) // trigram-only features stay below a word
+		}
+		if sum>>63 == 1 {
+			w = -w
+		}
+		v[sum%LocalDims] += w
+	}
+	Normalize(v)
+	return v
+}
+
+// Words splits text into lowercase, stemmed words: identifiers are broken
+// at case changes, underscores and digits, and stop words and common
+// keywords are dropped.
+func Words(text string) []string {
+	var words []string
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		for _, part := range splitIdentifier(field) {
+			w := strings.ToLower(part)
+			if stopWords[w] {
+				continue
+			}
+			if w = stem(w); len(w) < 2 || stopWords[w] {
+				continue
+			}
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// splitIdentifier breaks camelCase, PascalCase and letter/digit runs:
+// "parseHTTPResponse2" -> parse, HTTP, Response.
+func splitIdentifier(s string) []string {
+	rs := []rune(s)
+	var parts []string
+	start := 0
+	for i := 1; i < len(rs); i++ {
+		prev, cur := rs[i-1], rs[i]
+		next := rune(0)
+		if i+1 < len(rs) {
+			next = rs[i+1]
+		}
+		boundary := unicode.IsLower(prev) && unicode.IsUpper(cur) ||
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && unicode.IsLower(next) ||
+			unicode.IsDigit(prev) != unicode.IsDigit(cur)
+		if boundary {
+			parts = append(parts, string(rs[start:i]))
+			start = i
+		}
+	}
+	parts = append(parts, string(rs[start:]))
+	out := parts[:0]
+	for _, p := range parts {
+		if !unicode.IsDigit([]rune(p)[0]) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// stem strips common English inflections so retries, retried and retrying
+// all become retry.
+func stem(w string) string {
+	switch {
+	case len(w) > 4 && (strings.HasSuffix(w, "ies") || strings.HasSuffix(w, "ied")):
+		return w[:len(w)-3] + "y"
+	case len(w) > 5 && strings.HasSuffix(w, "ing"):
+		w = w[:len(w)-3]
+	case len(w) > 4 && strings.HasSuffix(w, "ed"):
+		w = w[:len(w)-2]
+	case len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss"):
+		return w[:len(w)-1]
+	default:
+		return w
+	}
+	// Undouble a final consonant left by -ing/-ed: stopped -> stop.
+	if n := len(w); n > 2 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiousl", rune(w[n-1])) {
+		w = w[:n-1]
+	}
+	return w
+}
+
+// stopWords are English function words and keywords shared by most
+// languages; they say nothing about what a symbol does.
+var stopWords = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(`
+		a an and are as at be by do does for from has have how if in into is it its
+		of on or so that the their then there these this to was we were what when
+		where which while who why will with you your
+		async await bool break case catch class const continue def default defer
+		else enum err export extends false final fn for func function go
+		impl implements import int interface let match mod nil none null package
+		pass private protected pub public raise return self static string struct
+		super switch throw throws true try type undefined use val var void`) {
+		m[w] = true
+	}
+	return m
+}()
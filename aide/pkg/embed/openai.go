@@ -0,0 +1,110 @@
+package embed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/httputil"
+)
+
+// DefaultOpenAIURL is the API the openai provider calls without a URL.
+const DefaultOpenAIURL = "https://api.openai.com/v1"
+
+// openAIBatch bounds the texts sent per request.
+const openAIBatch = 64
+
+// OpenAI embeds through an OpenAI-compatible /embeddings endpoint — OpenAI
+// itself, or a local server such as Ollama (http://localhost:11434/v1) or
+// llama.cpp.
+type OpenAI struct {
+	url    string
+	model  string
+	apiKey string
+	client *httputil.Client
+}
+
+// NewOpenAI returns an embedder for model at baseURL (DefaultOpenAIURL when
+// empty). apiKey may be empty for servers that need none.
+func NewOpenAI(baseURL, model, apiKey string) (*OpenAI, error) {
+	if model == "" {
+		return nil, errors.New("the openai embeddings provider needs a model")
+	}
+	if baseURL == "" {
+		baseURL = DefaultOpenAIURL
+	}
+	return &OpenAI{
+		url:    strings.TrimSuffix(baseURL, "/") + "/embeddings",
+		model:  model,
+		apiKey: apiKey,
+		client: httputil.NewClient(),
+	}, nil
+}
+
+// Name implements Embedder.
+func (o *OpenAI) Name() string { return "openai:" + o.model }
+
+// Embed implements Embedder.
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += openAIBatch {
+		end := min(start+openAIBatch, len(texts))
+		vecs, err := o.embedBatch(ctx, texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vecs...)
+	}
+	return out, nil
+}
+
+func (o *OpenAI) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]any{"model": o.model, "input": texts})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+o.apiKey)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embeddings request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var res struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	if len(res.Data) != len(texts) {
+		return nil, fmt.Errorf("embeddings response has %d vectors for %d texts", len(res.Data), len(texts))
+	}
+	out := make([][]float32, len(texts))
+	for _, d := range res.Data {
+		if d.Index < 0 || d.Index >= len(out) {
+			return nil, fmt.Errorf("embeddings response index %d out of range", d.Index)
+		}
+		Normalize(d.Embedding)
+		out[d.Index] = d.Embedding
+	}
+	return out, nil
+}
@@ -0,0 +1,375 @@
+package findings
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Advisory is the subset of an OSV record (https://ossf.github.io/osv-schema/)
+// the vulns analyser reads.
+type Advisory struct {
+	ID               string             `json:"id"`
+	Aliases          []string           `json:"aliases"`
+	Summary          string             `json:"summary"`
+	Details          string             `json:"details"`
+	Withdrawn        string             `json:"withdrawn"`
+	Affected         []AdvisoryAffected `json:"affected"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"` // GHSA: CRITICAL, HIGH, MODERATE, LOW
+	} `json:"database_specific"`
+}
+
+// AdvisoryAffected is one affected package of an advisory.
+type AdvisoryAffected struct {
+	Package struct {
+		Ecosystem string `json:"ecosystem"`
+		Name      string `json:"name"`
+	} `json:"package"`
+	Ranges            []AdvisoryRange `json:"ranges"`
+	Versions          []string        `json:"versions"`
+	EcosystemSpecific struct {
+		// Imports lists the vulnerable Go packages and symbols (Go vulndb).
+		Imports []struct {
+			Path    string   `json:"path"`
+			Symbols []string `json:"symbols"`
+		} `json:"imports"`
+	} `json:"ecosystem_specific"`
+}
+
+// AdvisoryRange is an OSV version range: a type (SEMVER, ECOSYSTEM, GIT)
+// and ordered events, each one of introduced, fixed, last_affected or limit.
+type AdvisoryRange struct {
+	Type   string              `json:"type"`
+	Events []map[string]string `json:"events"`
+}
+
+// AdvisoriesPath returns the offline advisory directory of a project.
+func AdvisoriesPath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".aide", "cache", "advisories")
+}
+
+// osvEcosystems maps survey dependency ecosystems to OSV ecosystem names.
+var osvEcosystems = map[string]string{
+	"go":    "Go",
+	"npm":   "npm",
+	"cargo": "crates.io",
+	"pypi":  "PyPI",
+	"maven": "Maven",
+	"nuget": "NuGet",
+}
+
+// advisoryKey is the lookup key for a package: OSV ecosystem plus the name
+// normalised the way the ecosystem compares names.
+func advisoryKey(osvEcosystem, name string) string {
+	switch osvEcosystem {
+	case "PyPI":
+		name = pyNameSeparators.ReplaceAllString(strings.ToLower(name), "-")
+	case "NuGet":
+		name = strings.ToLower(name)
+	}
+	return osvEcosystem + "|" + name
+}
+
+var pyNameSeparators = regexp.MustCompile(`[-_.]+`)
+
+// LoadAdvisories reads every *.json OSV record under dir, keeping those that
+// affect a package for which want returns true, keyed by advisoryKey.
+// Withdrawn records are dropped. A missing dir is an error: there is
+// nothing to audit against.
+func LoadAdvisories(dir string, want func(key string) bool) (map[string][]*Advisory, int, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, 0, fmt.Errorf("no advisory database at %s — import one with 'aide findings advisories import <osv.zip>'", dir)
+	}
+	index := make(map[string][]*Advisory)
+	read := 0
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".json") {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		var adv Advisory
+		if json.Unmarshal(data, &adv) != nil || adv.ID == "" {
+			return nil
+		}
+		read++
+		if adv.Withdrawn != "" {
+			return nil
+		}
+		seen := make(map[string]bool)
+		for _, a := range adv.Affected {
+			key := advisoryKey(a.Package.Ecosystem, a.Package.Name)
+			if !seen[key] && want(key) {
+				seen[key] = true
+				index[key] = append(index[key], &adv)
+			}
+		}
+		return nil
+	})
+	return index, read, err
+}
+
+// ImportAdvisoryZip extracts the OSV records of an OSV export zip (such as
+// https://osv-vulnerabilities.storage.googleapis.com/<ecosystem>/all.zip)
+// into a subdirectory of dir named after the records' ecosystem, replacing
+// any previous import of that ecosystem. It returns the subdirectory name
+// and the number of records written.
+func ImportAdvisoryZip(zipPath, dir string) (string, int, error) {
+	zr, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return "", 0, fmt.Errorf("open %s: %w", zipPath, err)
+	}
+	defer zr.Close()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", 0, err
+	}
+	tmp, err := os.MkdirTemp(dir, ".import-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.RemoveAll(tmp)
+
+	name, n := "", 0
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !strings.HasSuffix(f.Name, ".json") {
+			continue
+		}
+		data, err := readZipFile(f)
+		if err != nil {
+			return "", 0, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		var adv Advisory
+		if json.Unmarshal(data, &adv) != nil || adv.ID == "" {
+			continue
+		}
+		if name == "" && len(adv.Affected) > 0 {
+			name = adv.Affected[0].Package.Ecosystem
+		}
+		// Only the base name is used, so entries cannot escape tmp.
+		if err := os.WriteFile(filepath.Join(tmp, filepath.Base(filepath.FromSlash(f.Name))), data, 0o644); err != nil {
+			return "", 0, err
+		}
+		n++
+	}
+	if n == 0 {
+		return "", 0, fmt.Errorf("%s contains no OSV records", zipPath)
+	}
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || strings.HasPrefix(name, ".") {
+		name = strings.TrimSuffix(filepath.Base(zipPath), filepath.Ext(zipPath))
+	}
+	dest := filepath.Join(dir, name)
+	if err := os.RemoveAll(dest); err != nil {
+		return "", 0, err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		return "", 0, err
+	}
+	return name, n, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// affects reports whether version falls in the affected entry, and the
+// fixed versions above it (lowest first) when it does.
+func (a *AdvisoryAffected) affects(version string) (bool, []string) {
+	hit := false
+	for _, v := range a.Versions {
+		if compareVersions(v, version) == 0 {
+			hit = true
+		}
+	}
+	var fixed []string
+	for _, r := range a.Ranges {
+		if r.Type == "GIT" {
+			continue // commit ranges need the dependency's source history
+		}
+		in, fix := r.contains(version)
+		if in {
+			hit = true
+			if fix != "" {
+				fixed = append(fixed, fix)
+			}
+		}
+	}
+	sort.Slice(fixed, func(i, j int) bool { return compareVersions(fixed[i], fixed[j]) < 0 })
+	return hit, fixed
+}
+
+// contains evaluates the range's events against version: each introduced
+// event opens an interval that the next fixed (exclusive) or last_affected
+// (inclusive) event closes. It returns the closing fixed version when the
+// version falls in an interval that has one.
+func (r *AdvisoryRange) contains(version string) (bool, string) {
+	type event struct{ kind, version string }
+	var events []event
+	for _, e := range r.Events {
+		for kind, v := range e {
+			if kind == "limit" {
+				continue
+			}
+			events = append(events, event{kind, v})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareVersions(events[i].version, events[j].version) < 0
+	})
+	open := ""
+	opened := false
+	for _, e := range events {
+		switch e.kind {
+		case "introduced":
+			if !opened {
+				open, opened = e.version, true
+			}
+		case "fixed":
+			if opened {
+				if compareVersions(version, open) >= 0 && compareVersions(version, e.version) < 0 {
+					return true, e.version
+				}
+				opened = false
+			}
+		case "last_affected":
+			if opened {
+				if compareVersions(version, open) >= 0 && compareVersions(version, e.version) <= 0 {
+					return true, ""
+				}
+				opened = false
+			}
+		}
+	}
+	return opened && compareVersions(version, open) >= 0, ""
+}
+
+// compareVersions orders two versions across the ecosystems' schemes well
+// enough for range checks: a leading "v" and "+build" metadata are ignored,
+// numeric runs compare numerically, trailing zero components are
+// insignificant (1.0 == 1.0.0), numeric pre-releases (1.0.0-1, Go
+// pseudo-versions) and pre-release qualifiers (dev, alpha, a, beta, b, rc,
+// pre, snapshot) sort before the release while post-release ones (post, sp)
+// sort after. "0" — OSV's "from the beginning" — is the minimum.
+func compareVersions(a, b string) int {
+	ta, tb := versionTokens(a), versionTokens(b)
+	for i := 0; i < len(ta) || i < len(tb); i++ {
+		var x, y string
+		if i < len(ta) {
+			x = ta[i]
+		}
+		if i < len(tb) {
+			y = tb[i]
+		}
+		if c := compareVersionToken(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func versionTokens(v string) []string {
+	v = strings.ToLower(strings.TrimSpace(v))
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexByte(v, '+'); i >= 0 {
+		v = v[:i]
+	}
+	var tokens []string
+	start := -1
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	isAlpha := func(c byte) bool { return c >= 'a' && c <= 'z' }
+	for i := 0; i <= len(v); i++ {
+		if start >= 0 && (i == len(v) || isDigit(v[i]) != isDigit(v[start]) || !(isDigit(v[i]) || isAlpha(v[i]))) {
+			tokens = append(tokens, v[start:i])
+			start = -1
+		}
+		// A hyphen before a number opens a semver pre-release
+		// (1.0.0-1, Go pseudo-versions), which sorts before the release.
+		if i+1 < len(v) && v[i] == '-' && isDigit(v[i+1]) && len(tokens) > 0 {
+			tokens = append(tokens, "pre")
+		}
+		if i < len(v) && start < 0 && (isDigit(v[i]) || isAlpha(v[i])) {
+			start = i
+		}
+	}
+	return tokens
+}
+
+// qualifierRank orders alphabetic version tokens relative to a release (0).
+func qualifierRank(t string) int {
+	switch t {
+	case "dev", "snapshot":
+		return -6
+	case "alpha", "a":
+		return -5
+	case "beta", "b":
+		return -4
+	case "milestone", "m":
+		return -3
+	case "rc", "c", "cr", "pre", "preview":
+		return -2
+	case "", "final", "ga", "release":
+		return 0
+	case "post", "sp", "p":
+		return 1
+	}
+	return -1 // unknown qualifiers are treated as pre-releases
+}
+
+// compareVersionToken compares one token position; "" is an absent token.
+func compareVersionToken(x, y string) int {
+	xNum, yNum := x != "" && x[0] >= '0' && x[0] <= '9', y != "" && y[0] >= '0' && y[0] <= '9'
+	switch {
+	case xNum && yNum:
+		x, y = strings.TrimLeft(x, "0"), strings.TrimLeft(y, "0")
+		if len(x) != len(y) {
+			return cmpInt(len(x), len(y))
+		}
+		return strings.Compare(x, y)
+	case xNum:
+		if y == "" && strings.TrimLeft(x, "0") == "" {
+			return 0 // 1.0 == 1
+		}
+		if y == "" || qualifierRank(y) < 0 {
+			return 1
+		}
+		return -1
+	case yNum:
+		return -compareVersionToken(y, x)
+	}
+	rx, ry := qualifierRank(x), qualifierRank(y)
+	if rx != ry {
+		return cmpInt(rx, ry)
+	}
+	return strings.Compare(x, y)
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,513 @@
+package findings
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/jmylchreest/aide/aide/pkg/aideignore"
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+	"github.com/jmylchreest/aide/aide/pkg/observe"
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+)
+
+// CustomRulesDir is where user rule packs live, relative to the project root.
+var CustomRulesDir = filepath.Join(".aide", "rules")
+
+// messageVar matches {{name}} placeholders in a rule's message template.
+var messageVar = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// compiledCustomRule is a validated rule with its regex compiled up front and
+// its tree-sitter query compiled lazily per language (grammars may need a
+// download, so compilation waits until a file of that language is seen).
+type compiledCustomRule struct {
+	rule  grammar.CustomRule
+	re    *regexp.Regexp
+	langs map[string]bool // Normalised language names; nil matches any
+
+	queries  map[string]*tree_sitter.Query // Guarded by CustomRuleSet.mu
+	queryErr map[string]error
+}
+
+// appliesTo reports whether the rule should run on a file.
+func (cr *compiledCustomRule) appliesTo(relPath, lang string) bool {
+	if cr.langs != nil && !cr.langs[lang] {
+		return false
+	}
+	slashed := filepath.ToSlash(relPath)
+	for _, p := range cr.rule.Exclude {
+		if ok, _ := doublestar.Match(p, slashed); ok {
+			return false
+		}
+	}
+	if len(cr.rule.Paths) == 0 {
+		return true
+	}
+	for _, p := range cr.rule.Paths {
+		if ok, _ := doublestar.Match(p, slashed); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomRuleSet is the compiled form of every rule file in a rules directory.
+// It is safe for concurrent use by the Runner's per-file goroutines.
+type CustomRuleSet struct {
+	dir   string
+	stamp string
+	rules []*compiledCustomRule
+
+	mu sync.Mutex
+}
+
+// LoadCustomRules loads and compiles every rule file in dir. A missing
+// directory yields an empty set. Invalid rules fail the whole load so a typo
+// is reported instead of silently disabling a check.
+func LoadCustomRules(dir string) (*CustomRuleSet, error) {
+	rs := &CustomRuleSet{dir: dir, stamp: rulesDirStamp(dir)}
+	rules, err := grammar.LoadRuleDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	reg := grammar.DefaultPackRegistry()
+	for _, r := range rules {
+		if r.Severity == "" {
+			r.Severity = SevWarning
+		}
+		if SeverityRank(r.Severity) < 0 {
+			return nil, fmt.Errorf("%s: rule %s: unknown severity %q", r.Source, r.ID, r.Severity)
+		}
+		cr := &compiledCustomRule{rule: r}
+		if r.Pattern != "" {
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s: rule %s: %w", r.Source, r.ID, err)
+			}
+			cr.re = re
+		} else {
+			cr.queries = make(map[string]*tree_sitter.Query)
+			cr.queryErr = make(map[string]error)
+		}
+		if len(r.Languages) > 0 {
+			cr.langs = make(map[string]bool, len(r.Languages))
+			for _, l := range r.Languages {
+				cr.langs[reg.NormaliseLang(strings.ToLower(l))] = true
+			}
+		}
+		for _, p := range append(append([]string(nil), r.Paths...), r.Exclude...) {
+			if !doublestar.ValidatePattern(p) {
+				return nil, fmt.Errorf("%s: rule %s: invalid glob %q", r.Source, r.ID, p)
+			}
+		}
+		rs.rules = append(rs.rules, cr)
+	}
+	return rs, nil
+}
+
+// Len returns the number of loaded rules.
+func (rs *CustomRuleSet) Len() int {
+	if rs == nil {
+		return 0
+	}
+	return len(rs.rules)
+}
+
+// Rules returns the loaded rule definitions in load order.
+func (rs *CustomRuleSet) Rules() []grammar.CustomRule {
+	if rs == nil {
+		return nil
+	}
+	out := make([]grammar.CustomRule, len(rs.rules))
+	for i, cr := range rs.rules {
+		out[i] = cr.rule
+	}
+	return out
+}
+
+// Stale reports whether the rules directory changed (files added, removed or
+// modified) since the set was loaded.
+func (rs *CustomRuleSet) Stale() bool {
+	return rulesDirStamp(rs.dir) != rs.stamp
+}
+
+// Close releases compiled tree-sitter queries.
+func (rs *CustomRuleSet) Close() {
+	if rs == nil {
+		return
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	for _, cr := range rs.rules {
+		for lang, q := range cr.queries {
+			q.Close()
+			delete(cr.queries, lang)
+		}
+	}
+}
+
+// rulesDirStamp summarises the rule files in dir by name, size and mtime.
+func rulesDirStamp(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, e := range entries {
+		if e.IsDir() || !grammar.IsRuleFile(e.Name()) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:%d:%d;", e.Name(), info.Size(), info.ModTime().UnixNano())
+	}
+	return sb.String()
+}
+
+// query returns cr's query compiled for lang, compiling it on first use.
+// Compile failures are cached and logged once.
+func (rs *CustomRuleSet) query(ctx context.Context, loader grammar.Loader, cr *compiledCustomRule, lang string) *tree_sitter.Query {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if q, ok := cr.queries[lang]; ok {
+		return q
+	}
+	if _, failed := cr.queryErr[lang]; failed {
+		return nil
+	}
+	q, err := grammar.CompileQuery(ctx, loader, lang, cr.rule.Query)
+	if err != nil {
+		cr.queryErr[lang] = err
+		runnerLog.Printf("custom rule %s (%s): %v", cr.rule.ID, cr.rule.Source, err)
+		return nil
+	}
+	cr.queries[lang] = q
+	return q
+}
+
+// AnalyzeFile runs every applicable rule against one file.
+func (rs *CustomRuleSet) AnalyzeFile(ctx context.Context, loader grammar.Loader, relPath string, content []byte) []*Finding {
+	if rs.Len() == 0 {
+		return nil
+	}
+	lang := code.DetectLanguage(relPath, content)
+
+	var regexRules, queryRules []*compiledCustomRule
+	for _, cr := range rs.rules {
+		if !cr.appliesTo(relPath, lang) {
+			continue
+		}
+		if cr.re != nil {
+			regexRules = append(regexRules, cr)
+		} else if lang != "" {
+			queryRules = append(queryRules, cr)
+		}
+	}
+
+	var ff []*Finding
+	if len(regexRules) > 0 {
+		ff = append(ff, matchCustomPatterns(regexRules, relPath, lang, content)...)
+	}
+	if len(queryRules) > 0 && loader != nil {
+		ff = append(ff, rs.matchCustomQueries(ctx, loader, queryRules, relPath, lang, content)...)
+	}
+	numberOccurrences(ff)
+	return ff
+}
+
+// matchCustomPatterns scans content line by line, skipping comment lines as
+// the security analyser does.
+func matchCustomPatterns(rules []*compiledCustomRule, relPath, lang string, content []byte) []*Finding {
+	var ff []*Finding
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if lang != "" && grammar.IsCommentLine(strings.TrimSpace(line), lang) {
+			continue
+		}
+		for _, cr := range rules {
+			m := cr.re.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			vars := map[string]string{"match": m[0]}
+			for i, name := range cr.re.SubexpNames() {
+				if i == 0 {
+					continue
+				}
+				vars[strconv.Itoa(i)] = m[i]
+				if name != "" {
+					vars[name] = m[i]
+				}
+			}
+			ff = append(ff, newCustomFinding(cr, relPath, lang, lineNum, 0, vars))
+		}
+	}
+	return ff
+}
+
+// matchCustomQueries parses content once and runs each query rule over the
+// tree. The reported node is the @match capture when the query defines one,
+// otherwise the match's first capture.
+func (rs *CustomRuleSet) matchCustomQueries(ctx context.Context, loader grammar.Loader, rules []*compiledCustomRule, relPath, lang string, content []byte) []*Finding {
+	sitterLang, err := loader.Load(ctx, lang)
+	if err != nil {
+		return nil
+	}
+	parser := tree_sitter.NewParser()
+	defer parser.Close()
+	if err := parser.SetLanguage(sitterLang); err != nil {
+		return nil
+	}
+	tree := parser.Parse(content, nil)
+	if tree == nil {
+		return nil
+	}
+	defer tree.Close()
+	root := tree.RootNode()
+
+	var ff []*Finding
+	for _, cr := range rules {
+		q := rs.query(ctx, loader, cr, lang)
+		if q == nil {
+			continue
+		}
+		names := q.CaptureNames()
+		seen := make(map[uint]bool)
+
+		cursor := tree_sitter.NewQueryCursor()
+		matches := cursor.Matches(q, root, content)
+		for match := matches.Next(); match != nil; match = matches.Next() {
+			if len(match.Captures) == 0 {
+				continue
+			}
+			node := match.Captures[0].Node
+			vars := make(map[string]string, len(match.Captures)+1)
+			for _, c := range match.Captures {
+				name := names[c.Index]
+				vars[name] = c.Node.Utf8Text(content)
+				if name == "match" {
+					node = c.Node
+				}
+			}
+			if seen[node.StartByte()] {
+				continue
+			}
+			seen[node.StartByte()] = true
+			if _, ok := vars["match"]; !ok {
+				vars["match"] = node.Utf8Text(content)
+			}
+			start := int(node.StartPosition().Row) + 1
+			end := int(node.EndPosition().Row) + 1
+			ff = append(ff, newCustomFinding(cr, relPath, lang, start, end, vars))
+		}
+		cursor.Close()
+	}
+	return ff
+}
+
+// newCustomFinding builds a finding for a rule match. The rule ID is kept in
+// rule_id metadata so aide:ignore custom:<id> and fingerprints work as they
+// do for security rules.
+func newCustomFinding(cr *compiledCustomRule, relPath, lang string, line, endLine int, vars map[string]string) *Finding {
+	r := cr.rule
+	name := r.Name
+	if name == "" {
+		name = r.ID
+	}
+	title := name
+	if r.Message != "" {
+		title = renderRuleMessage(r.Message, vars)
+	}
+	category := r.Category
+	if category == "" {
+		category = r.ID
+	}
+	if endLine == line {
+		endLine = 0
+	}
+	kind := "pattern"
+	if cr.re == nil {
+		kind = "query"
+	}
+	return &Finding{
+		Analyzer: AnalyzerCustom,
+		Severity: r.Severity,
+		Category: category,
+		FilePath: relPath,
+		Line:     line,
+		EndLine:  endLine,
+		Title:    title,
+		Detail:   r.Description,
+		Metadata: map[string]string{
+			"rule_id":     r.ID,
+			"rule_name":   name,
+			"rule_file":   filepath.Base(r.Source),
+			"match":       kind,
+			"language":    lang,
+			MetaMatchHash: MatchHash(vars["match"]),
+		},
+		CreatedAt: time.Now(),
+	}
+}
+
+// renderRuleMessage expands {{var}} placeholders. Values are collapsed to
+// their first line and truncated so a multi-line capture cannot blow up the
+// title. Unknown placeholders expand to nothing.
+func renderRuleMessage(tmpl string, vars map[string]string) string {
+	return messageVar.ReplaceAllStringFunc(tmpl, func(m string) string {
+		key := messageVar.FindStringSubmatch(m)[1]
+		v := strings.TrimSpace(vars[key])
+		if i := strings.IndexByte(v, '\n'); i >= 0 {
+			v = strings.TrimSpace(v[:i]) + " …"
+		}
+		return summarise(v, 80)
+	})
+}
+
+// CustomConfig holds configuration for standalone custom rule analysis (CLI).
+type CustomConfig struct {
+	Paths       []string
+	ProjectRoot string
+	// Rules is the loaded rule set. Nil loads <ProjectRoot>/.aide/rules.
+	Rules       *CustomRuleSet
+	Loader      grammar.Loader
+	Ignore      *aideignore.Matcher
+	MaxFileSize int64
+	ProgressFn  func(path string, findings int)
+}
+
+// CustomResult holds summary statistics from a custom rule run.
+type CustomResult struct {
+	FilesAnalyzed int
+	FilesSkipped  int
+	FindingsCount int
+	RulesLoaded   int
+	ByRule        map[string]int // Findings per rule ID, including rules with no matches
+	Duration      time.Duration
+}
+
+// AnalyzeCustom runs the user-defined rules in .aide/rules across all files
+// in the given paths.
+func AnalyzeCustom(cfg CustomConfig) ([]*Finding, *CustomResult, error) {
+	span := observe.Start("AnalyzeCustom", observe.KindSpan).Category("analyzer").Subtype("custom")
+	defer span.End()
+	if len(cfg.Paths) == 0 {
+		cfg.Paths = []string{"."}
+	}
+	maxFileSize := cfg.MaxFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = DefaultSecurityMaxFileSize
+	}
+	ignore := cfg.Ignore
+	if ignore == nil {
+		ignore = aideignore.NewFromDefaults()
+	}
+	rs := cfg.Rules
+	if rs == nil {
+		loaded, err := LoadCustomRules(filepath.Join(cfg.ProjectRoot, CustomRulesDir))
+		if err != nil {
+			return nil, nil, err
+		}
+		defer loaded.Close()
+		rs = loaded
+	}
+
+	start := time.Now()
+	result := &CustomResult{RulesLoaded: rs.Len(), ByRule: make(map[string]int, rs.Len())}
+	for _, r := range rs.Rules() {
+		result.ByRule[r.ID] = 0
+	}
+	if rs.Len() == 0 {
+		result.Duration = time.Since(start)
+		return nil, result, nil
+	}
+
+	var allFindings []*Finding
+	for _, root := range cfg.Paths {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return nil, nil, fmt.Errorf("abs path %s: %w", root, err)
+		}
+		shouldSkip := ignore.WalkFunc(absRoot)
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if skip, skipDir := shouldSkip(path, info); skip {
+				if skipDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if !code.SupportedFile(path) {
+				return nil
+			}
+			if info.Size() > maxFileSize {
+				result.FilesSkipped++
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+
+			relPath := toRelPath(cfg.ProjectRoot, path)
+			ff := rs.AnalyzeFile(context.Background(), cfg.Loader, relPath, content)
+			allFindings = append(allFindings, ff...)
+			result.FilesAnalyzed++
+			if cfg.ProgressFn != nil {
+				cfg.ProgressFn(relPath, len(ff))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("walk %s: %w", root, err)
+		}
+	}
+
+	for _, f := range allFindings {
+		result.ByRule[f.Metadata["rule_id"]]++
+	}
+	result.FindingsCount = len(allFindings)
+	result.Duration = time.Since(start)
+	span.Attr("files", strconv.Itoa(result.FilesAnalyzed)).
+		Attr("rules", strconv.Itoa(result.RulesLoaded)).
+		Attr("findings", strconv.Itoa(result.FindingsCount))
+	return allFindings, result, nil
+}
+
+// SortedRuleCounts returns rule IDs ordered by descending count, then ID.
+func SortedRuleCounts(byRule map[string]int) []string {
+	ids := make([]string, 0, len(byRule))
+	for id := range byRule {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if byRule[ids[i]] != byRule[ids[j]] {
+			return byRule[ids[i]] > byRule[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
@@ -0,0 +1,177 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+)
+
+// writeCustomRules writes content as a rule pack under root and returns the
+// rules directory.
+func writeCustomRules(t *testing.T, root, content string) string {
+	t.Helper()
+	writeFiles(t, root, map[string]string{filepath.Join(CustomRulesDir, "rules.json"): content})
+	return filepath.Join(root, CustomRulesDir)
+}
+
+const testCustomRules = `{"rules": [
+	{"id": "no-println", "pattern": "fmt\\.Println\\((?P<arg>[^)]*)\\)", "severity": "info",
+	 "languages": ["go"], "message": "Println of {{arg}}", "exclude": ["cmd/**"]},
+	{"id": "no-panic", "name": "Avoid panic", "languages": ["golang"], "category": "robustness",
+	 "query": "(call_expression function: (identifier) @fn (#eq? @fn \"panic\") arguments: (argument_list) @args) @match",
+	 "message": "panic{{args}}"}
+]}`
+
+const testCustomSource = `package a
+
+import "fmt"
+
+func f() {
+	// fmt.Println("commented out")
+	fmt.Println("hello")
+	panic("boom")
+}
+`
+
+func TestCustomRuleSet_AnalyzeFile(t *testing.T) {
+	rs, err := LoadCustomRules(writeCustomRules(t, t.TempDir(), testCustomRules))
+	if err != nil {
+		t.Fatalf("LoadCustomRules: %v", err)
+	}
+	defer rs.Close()
+	if rs.Len() != 2 {
+		t.Fatalf("expected 2 rules, got %d", rs.Len())
+	}
+
+	loader := grammar.NewCompositeLoader(grammar.WithAutoDownload(false))
+	ff := rs.AnalyzeFile(t.Context(), loader, "pkg/a.go", []byte(testCustomSource))
+	if len(ff) != 2 {
+		t.Fatalf("expected 2 findings, got %d: %v", len(ff), ff)
+	}
+
+	byRule := make(map[string]*Finding)
+	for _, f := range ff {
+		byRule[f.Metadata["rule_id"]] = f
+	}
+	p := byRule["no-println"]
+	if p == nil || p.Line != 7 || p.Title != `Println of "hello"` || p.Severity != SevInfo || p.Category != "no-println" {
+		t.Errorf("unexpected pattern finding: %+v", p)
+	}
+	q := byRule["no-panic"]
+	if q == nil || q.Line != 8 || q.Title != `panic("boom")` || q.Severity != SevWarning || q.Category != "robustness" {
+		t.Errorf("unexpected query finding: %+v", q)
+	}
+	if q != nil && (q.Analyzer != AnalyzerCustom || q.Metadata["match"] != "query") {
+		t.Errorf("expected custom query finding, got %+v", q)
+	}
+
+	// Excluded paths and other languages are skipped.
+	if ff := rs.AnalyzeFile(t.Context(), loader, "cmd/main.go", []byte(testCustomSource)); len(ff) != 1 {
+		t.Errorf("expected only the query rule under cmd/, got %d", len(ff))
+	}
+	if ff := rs.AnalyzeFile(t.Context(), loader, "a.py", []byte(`print("x")`)); len(ff) != 0 {
+		t.Errorf("expected no findings for python, got %d", len(ff))
+	}
+}
+
+func TestLoadCustomRules_BadSeverity(t *testing.T) {
+	dir := writeCustomRules(t, t.TempDir(), `{"rules":[{"id":"x","pattern":"a","severity":"urgent"}]}`)
+	if _, err := LoadCustomRules(dir); err == nil {
+		t.Error("expected unknown severity to be rejected")
+	}
+}
+
+func TestCustomRuleSet_Stale(t *testing.T) {
+	root := t.TempDir()
+	dir := writeCustomRules(t, root, testCustomRules)
+	rs, err := LoadCustomRules(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.Stale() {
+		t.Fatal("freshly loaded rules should not be stale")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "more.json"), []byte(`{"rules":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !rs.Stale() {
+		t.Error("expected adding a rule file to make the set stale")
+	}
+}
+
+func TestAnalyzeCustom(t *testing.T) {
+	root := t.TempDir()
+	writeCustomRules(t, root, testCustomRules)
+	writeFiles(t, root, map[string]string{"a.go": testCustomSource})
+
+	loader := grammar.NewCompositeLoader(grammar.WithAutoDownload(false))
+	ff, res, err := AnalyzeCustom(CustomConfig{Paths: []string{root}, ProjectRoot: root, Loader: loader})
+	if err != nil {
+		t.Fatalf("AnalyzeCustom: %v", err)
+	}
+	if len(ff) != 2 || res.RulesLoaded != 2 || res.FilesAnalyzed != 1 {
+		t.Fatalf("unexpected result: %d findings, %+v", len(ff), res)
+	}
+	if res.ByRule["no-println"] != 1 || res.ByRule["no-panic"] != 1 {
+		t.Errorf("unexpected per-rule counts: %v", res.ByRule)
+	}
+	if ff[0].FilePath != "a.go" {
+		t.Errorf("expected project-relative path, got %q", ff[0].FilePath)
+	}
+}
+
+func TestRenderRuleMessage(t *testing.T) {
+	got := renderRuleMessage("{{ fn }} called with {{args}}{{missing}}", map[string]string{
+		"fn":   "exec",
+		"args": "(a,\n b)",
+	})
+	if got != "exec called with (a, …" {
+		t.Errorf("unexpected message: %q", got)
+	}
+}
+
+func TestSortedRuleCounts(t *testing.T) {
+	got := SortedRuleCounts(map[string]int{"b": 1, "a": 1, "c": 3, "d": 0})
+	want := []string{"c", "a", "b", "d"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SortedRuleCounts = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRunnerCustomRules(t *testing.T) {
+	root := t.TempDir()
+	dir := writeCustomRules(t, root, testCustomRules)
+	writeFiles(t, root, map[string]string{"a.go": testCustomSource})
+
+	store := newMemFixStore()
+	runner := NewRunner(store, AnalyzerConfig{ProjectRoot: root, Paths: []string{root}, RulesDir: dir}, nil)
+	defer runner.Stop()
+
+	if err := runner.RunAll(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	runner.WaitAll()
+	custom, _ := store.ListFindings(SearchOptions{Analyzer: AnalyzerCustom})
+	if len(custom) != 2 {
+		t.Fatalf("expected 2 custom findings after RunAll, got %d", len(custom))
+	}
+
+	// Removing the rules clears their findings on the next change batch.
+	if err := os.Remove(filepath.Join(dir, "rules.json")); err != nil {
+		t.Fatal(err)
+	}
+	runner.OnChanges(nil)
+	runner.WaitAll()
+	if custom, _ := store.ListFindings(SearchOptions{Analyzer: AnalyzerCustom}); len(custom) != 0 {
+		t.Errorf("expected custom findings cleared after rules removed, got %d", len(custom))
+	}
+	for _, a := range runner.perFileAnalyzers() {
+		if a == AnalyzerCustom {
+			t.Error("custom analyzer should not run without rules")
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package findings
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/jmylchreest/aide/aide/pkg/aideignore"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// LineRange is an inclusive, 1-indexed span of lines in the working-tree
+// version of a file.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// DiffScope describes the files and line ranges that changed between a git
+// ref and the working tree (committed, staged, unstaged and untracked
+// changes). It drives diff-scoped analyser runs: per-file analysers only visit
+// Files, and project-wide analysers keep findings that Touch a changed hunk.
+type DiffScope struct {
+	Ref    string // Ref as given by the caller (e.g. "main", "HEAD~3")
+	Commit string // Resolved commit hash of Ref
+	Root   string // Absolute project root; all paths below are relative to it
+
+	// Files maps each changed file that still exists to its changed line
+	// ranges in the working tree. Pure deletions inside a file are recorded
+	// as a single-line range at the point of deletion.
+	Files map[string][]LineRange
+	// Deleted lists files present at Ref that no longer exist.
+	Deleted []string
+}
+
+// ComputeDiffScope diffs the working tree under projectRoot against ref using
+// go-git. Files matched by ignore (built-in defaults when nil) are left out.
+// Returns an error when projectRoot is not inside a git repository or ref
+// cannot be resolved.
+func ComputeDiffScope(projectRoot, ref string, ignore *aideignore.Matcher) (*DiffScope, error) {
+	if projectRoot == "" {
+		projectRoot = "."
+	}
+	if ignore == nil {
+		ignore = aideignore.NewFromDefaults()
+	}
+	absRoot, err := filepath.Abs(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("abs path %s: %w", projectRoot, err)
+	}
+
+	repo, err := git.PlainOpenWithOptions(absRoot, &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, fmt.Errorf("%s is not inside a git repository", absRoot)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repo: %w", err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %q: %w", ref, err)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit %s: %w", hash, err)
+	}
+	refTree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tree for %s: %w", hash, err)
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	repoRoot := wt.Filesystem.Root()
+
+	candidates, err := diffCandidates(repo, wt, refTree, *hash)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := &DiffScope{
+		Ref:    ref,
+		Commit: hash.String(),
+		Root:   absRoot,
+		Files:  make(map[string][]LineRange),
+	}
+
+	for repoPath := range candidates {
+		abs := filepath.Join(repoRoot, filepath.FromSlash(repoPath))
+		rel, err := filepath.Rel(absRoot, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue // outside the project root (monorepo sibling)
+		}
+		if ignore.ShouldIgnoreFile(rel) {
+			continue
+		}
+
+		var oldContent string
+		if f, err := refTree.File(repoPath); err == nil {
+			if oldContent, err = f.Contents(); err != nil {
+				return nil, fmt.Errorf("failed to read %s at %s: %w", repoPath, ref, err)
+			}
+		}
+
+		newBytes, err := os.ReadFile(abs)
+		if errors.Is(err, os.ErrNotExist) {
+			scope.Deleted = append(scope.Deleted, rel)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", abs, err)
+		}
+
+		if ranges := changedLineRanges(oldContent, string(newBytes)); len(ranges) > 0 {
+			scope.Files[rel] = ranges
+		}
+	}
+	sort.Strings(scope.Deleted)
+
+	return scope, nil
+}
+
+// diffCandidates collects repo-relative paths that may differ from refTree:
+// everything changed between ref and HEAD, plus anything the worktree status
+// reports as staged, modified or untracked.
+func diffCandidates(repo *git.Repository, wt *git.Worktree, refTree *object.Tree, refHash plumbing.Hash) (map[string]bool, error) {
+	candidates := make(map[string]bool)
+
+	if head, err := repo.Head(); err == nil && head.Hash() != refHash {
+		headCommit, err := repo.CommitObject(head.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load HEAD commit: %w", err)
+		}
+		headTree, err := headCommit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load HEAD tree: %w", err)
+		}
+		changes, err := object.DiffTree(refTree, headTree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff trees: %w", err)
+		}
+		for _, c := range changes {
+			if c.From.Name != "" {
+				candidates[c.From.Name] = true
+			}
+			if c.To.Name != "" {
+				candidates[c.To.Name] = true
+			}
+		}
+	}
+
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	for path, fs := range status {
+		if fs.Staging != git.Unmodified || fs.Worktree != git.Unmodified {
+			candidates[path] = true
+		}
+	}
+
+	return candidates, nil
+}
+
+// changedLineRanges returns the merged line ranges of newContent that differ
+// from oldContent.
+func changedLineRanges(oldContent, newContent string) []LineRange {
+	if oldContent == newContent {
+		return nil
+	}
+
+	var ranges []LineRange
+	add := func(start, end int) {
+		if start < 1 {
+			start = 1
+		}
+		if end < start {
+			end = start
+		}
+		if n := len(ranges); n > 0 && start <= ranges[n-1].End+1 {
+			if end > ranges[n-1].End {
+				ranges[n-1].End = end
+			}
+			return
+		}
+		ranges = append(ranges, LineRange{Start: start, End: end})
+	}
+
+	line := 1
+	for _, d := range diff.Do(oldContent, newContent) {
+		n := countLines(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			line += n
+		case diffmatchpatch.DiffInsert:
+			add(line, line+n-1)
+			line += n
+		case diffmatchpatch.DiffDelete:
+			// Removed lines leave no trace in the new file; mark the line
+			// that now occupies their position.
+			add(line, line)
+		}
+	}
+	return ranges
+}
+
+// countLines counts lines in a diff chunk, including a final unterminated one.
+func countLines(s string) int {
+	if s == "" {
+		return 0
+	}
+	n := strings.Count(s, "\n")
+	if !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
+
+// Paths returns the changed files as paths relative to the working directory
+// (falling back to absolute), suitable for analyser Paths configuration.
+func (s *DiffScope) Paths() []string {
+	cwd, _ := os.Getwd()
+	paths := make([]string, 0, len(s.Files))
+	for rel := range s.Files {
+		abs := filepath.Join(s.Root, rel)
+		if cwd != "" {
+			if p, err := filepath.Rel(cwd, abs); err == nil {
+				abs = p
+			}
+		}
+		paths = append(paths, abs)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Empty reports whether nothing changed since the ref.
+func (s *DiffScope) Empty() bool {
+	return len(s.Files) == 0 && len(s.Deleted) == 0
+}
+
+// HasFile reports whether path (absolute, or relative to the working
+// directory) changed since the ref and still exists.
+func (s *DiffScope) HasFile(path string) bool {
+	_, ok := s.Files[toRelPath(s.Root, path)]
+	return ok
+}
+
+// Touches reports whether a finding overlaps a changed hunk. File-level
+// findings (Line <= 1, e.g. coupling) touch the diff when their file changed
+// at all.
+func (s *DiffScope) Touches(f *Finding) bool {
+	ranges, ok := s.Files[toRelPath(s.Root, f.FilePath)]
+	if !ok {
+		return false
+	}
+	if f.Line <= 1 {
+		return true
+	}
+	end := f.EndLine
+	if end < f.Line {
+		end = f.Line
+	}
+	for _, r := range ranges {
+		if f.Line <= r.End && end >= r.Start {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the findings in ff that touch the diff.
+func (s *DiffScope) Filter(ff []*Finding) []*Finding {
+	out := make([]*Finding, 0, len(ff))
+	for _, f := range ff {
+		if s.Touches(f) {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Under returns a copy of the scope limited to files beneath one of roots
+// (absolute, or relative to the working directory). An empty roots list or
+// a root covering the whole project returns the scope unchanged.
+func (s *DiffScope) Under(roots []string) *DiffScope {
+	var prefixes []string
+	for _, root := range roots {
+		rel := toRelPath(s.Root, root)
+		if rel == "." {
+			return s
+		}
+		prefixes = append(prefixes, rel)
+	}
+	if len(prefixes) == 0 {
+		return s
+	}
+
+	under := func(path string) bool {
+		for _, p := range prefixes {
+			if path == p || strings.HasPrefix(path, p+string(filepath.Separator)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	out := &DiffScope{Ref: s.Ref, Commit: s.Commit, Root: s.Root, Files: make(map[string][]LineRange)}
+	for path, ranges := range s.Files {
+		if under(path) {
+			out.Files[path] = ranges
+		}
+	}
+	for _, path := range s.Deleted {
+		if under(path) {
+			out.Deleted = append(out.Deleted, path)
+		}
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestChangedLineRanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []LineRange
+	}{
+		{"identical", "a\nb\n", "a\nb\n", nil},
+		{"new file", "", "a\nb\nc\n", []LineRange{{1, 3}}},
+		{"modified middle", "a\nb\nc\n", "a\nB\nc\n", []LineRange{{2, 2}}},
+		{"inserted lines", "a\nd\n", "a\nb\nc\nd\n", []LineRange{{2, 3}}},
+		{"deleted line", "a\nb\nc\n", "a\nc\n", []LineRange{{2, 2}}},
+		{"two hunks", "a\nb\nc\nd\ne\n", "A\nb\nc\nd\nE\n", []LineRange{{1, 1}, {5, 5}}},
+		{"no trailing newline", "a\nb", "a\nc", []LineRange{{2, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := changedLineRanges(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("changedLineRanges() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffScopeTouches(t *testing.T) {
+	root := t.TempDir()
+	scope := &DiffScope{
+		Root:  root,
+		Files: map[string][]LineRange{"a.go": {{10, 12}}},
+	}
+	abs := filepath.Join(root, "a.go")
+
+	tests := []struct {
+		name string
+		f    *Finding
+		want bool
+	}{
+		{"inside hunk", &Finding{FilePath: abs, Line: 11}, true},
+		{"span overlaps hunk", &Finding{FilePath: abs, Line: 5, EndLine: 10}, true},
+		{"before hunk", &Finding{FilePath: abs, Line: 5, EndLine: 9}, false},
+		{"file-level", &Finding{FilePath: abs, Line: 1}, true},
+		{"unchanged file", &Finding{FilePath: filepath.Join(root, "b.go"), Line: 11}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scope.Touches(tt.f); got != tt.want {
+				t.Errorf("Touches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffScopeUnder(t *testing.T) {
+	root := t.TempDir()
+	scope := &DiffScope{
+		Root:    root,
+		Files:   map[string][]LineRange{"src/a.go": {{1, 1}}, "cmd/b.go": {{1, 1}}},
+		Deleted: []string{"src/old.go", "docs/x.md"},
+	}
+
+	if got := scope.Under([]string{root}); got != scope {
+		t.Error("expected project root to return the scope unchanged")
+	}
+
+	sub := scope.Under([]string{filepath.Join(root, "src")})
+	if len(sub.Files) != 1 || sub.Files["src/a.go"] == nil {
+		t.Errorf("expected only src/a.go, got %v", sub.Files)
+	}
+	if !reflect.DeepEqual(sub.Deleted, []string{"src/old.go"}) {
+		t.Errorf("expected only src/old.go deleted, got %v", sub.Deleted)
+	}
+}
+
+func TestComputeDiffScope(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("git.PlainInit: %v", err)
+	}
+
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit := func(msg string) string {
+		t.Helper()
+		wt, err := repo.Worktree()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := wt.Add("."); err != nil {
+			t.Fatal(err)
+		}
+		h, err := wt.Commit(msg, &git.CommitOptions{
+			Author: &object.Signature{Name: "Test", Email: "test@example.com", When: time.Now()},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h.String()
+	}
+
+	write("a.go", "package a\n\nfunc A() {}\n")
+	write("b.go", "package a\n\nfunc B() {}\n")
+	write("c.go", "package a\n\nfunc C() {}\n")
+	base := commit("initial")
+
+	// Committed change, unstaged deletion and an untracked file.
+	write("a.go", "package a\n\nfunc A() { panic(1) }\n")
+	commit("edit a")
+	if err := os.Remove(filepath.Join(dir, "c.go")); err != nil {
+		t.Fatal(err)
+	}
+	write("d.go", "package a\n\nfunc D() {}\n")
+	if err := os.MkdirAll(filepath.Join(dir, "node_modules"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write("node_modules/dep.js", "module.exports = 1\n")
+
+	scope, err := ComputeDiffScope(dir, base, nil)
+	if err != nil {
+		t.Fatalf("ComputeDiffScope: %v", err)
+	}
+	if scope.Commit != base {
+		t.Errorf("expected commit %s, got %s", base, scope.Commit)
+	}
+
+	want := map[string][]LineRange{
+		"a.go": {{3, 3}},
+		"d.go": {{1, 3}},
+	}
+	if !reflect.DeepEqual(scope.Files, want) {
+		t.Errorf("Files = %v, want %v", scope.Files, want)
+	}
+	if !reflect.DeepEqual(scope.Deleted, []string{"c.go"}) {
+		t.Errorf("Deleted = %v, want [c.go]", scope.Deleted)
+	}
+
+	// HEAD only sees the uncommitted changes.
+	scope, err = ComputeDiffScope(dir, "HEAD", nil)
+	if err != nil {
+		t.Fatalf("ComputeDiffScope(HEAD): %v", err)
+	}
+	if _, ok := scope.Files["a.go"]; ok {
+		t.Error("a.go was committed and should not be in the HEAD scope")
+	}
+	if _, ok := scope.Files["d.go"]; !ok {
+		t.Error("expected untracked d.go in the HEAD scope")
+	}
+}
+
+func TestComputeDiffScope_NotGitRepo(t *testing.T) {
+	if _, err := ComputeDiffScope(t.TempDir(), "HEAD", nil); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
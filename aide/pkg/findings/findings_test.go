@@ -515,6 +515,21 @@ func TestCouplingAnalyzer_NoFanInForExternalImports(t *testing.T) {
 	}
 }
 
+// writeFiles writes each file (slash-separated path relative to root) under
+// root, creating parent directories.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+}
+
 func TestCouplingAnalyzer_ResolvedUnits(t *testing.T) {
 	dir := t.TempDir()
 	files := map[string]string{
@@ -525,15 +540,7 @@ func TestCouplingAnalyzer_ResolvedUnits(t *testing.T) {
 		"web/src/cycle_x.ts": "import { y } from './cycle_y'\nexport const x = 1\n",
 		"web/src/cycle_y.ts": "import { x } from './cycle_x'\nexport const y = 2\n",
 	}
-	for rel, content := range files {
-		p := filepath.Join(dir, filepath.FromSlash(rel))
-		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
-			t.Fatalf("MkdirAll: %v", err)
-		}
-		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
-			t.Fatalf("WriteFile: %v", err)
-		}
-	}
+	writeFiles(t, dir, files)
 
 	findings, result, err := AnalyzeCoupling(CouplingConfig{
 		FanOutThreshold: 100, // suppress fan-out findings
@@ -0,0 +1,254 @@
+package findings
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/observe"
+)
+
+// FixDetectorActor is the actor recorded on triage transitions the runner
+// infers from re-analysis.
+const FixDetectorActor = "aide:watcher"
+
+// FixTracker is the triage surface the runner needs for fix detection. Stores
+// that implement it alongside ReplaceFindingsStore get fixed/regressed
+// transitions recorded automatically.
+type FixTracker interface {
+	ListFindings(opts SearchOptions) ([]*Finding, error)
+	FileFindings(analyzer, filePath string) ([]*Finding, error)
+	TriageStatuses() (map[string]*TriageStatus, error)
+	TriageFinding(ev *TriageEvent) (*TriageStatus, error)
+}
+
+// FixAttribution identifies the change a re-analysis observed.
+type FixAttribution struct {
+	Commit  string
+	Session string
+}
+
+// FixDetection configures automatic fix detection. After each successful
+// analyser run the runner diffs the previous findings in that run's scope
+// against the new ones by fingerprint: vanished findings are marked fixed,
+// and findings marked fixed that are reported again are reopened. Findings
+// silenced by an aide:ignore directive, or dropped because the analyser's
+// threshold changed, have not been fixed and are left alone.
+type FixDetection struct {
+	// Attribute returns the commit/session to stamp on inferred transitions.
+	// Optional.
+	Attribute func() FixAttribution
+	// Notify is called for every recorded transition, after it is stored.
+	// Optional.
+	Notify func(ev *TriageEvent)
+}
+
+// SetFixDetection enables fix detection. It is a no-op unless the runner's
+// store implements FixTracker.
+func (r *Runner) SetFixDetection(fd FixDetection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.store.(FixTracker); !ok {
+		return
+	}
+	r.fixDetection = &fd
+}
+
+// fixTracker returns the tracker and config when fix detection is enabled.
+func (r *Runner) fixTracker() (FixTracker, *FixDetection) {
+	r.mu.Lock()
+	fd := r.fixDetection
+	r.mu.Unlock()
+	if fd == nil {
+		return nil, nil
+	}
+	return r.store.(FixTracker), fd
+}
+
+// snapshotFindings lists the stored findings a run for key is about to
+// replace. Returns nil when fix detection is disabled or the listing fails.
+func (r *Runner) snapshotFindings(key RunKey) []*Finding {
+	tracker, _ := r.fixTracker()
+	if tracker == nil {
+		return nil
+	}
+	var ff []*Finding
+	var err error
+	if key.Scope == ScopeProject {
+		ff, err = tracker.ListFindings(SearchOptions{Analyzer: key.Analyzer, Limit: -1, IncludeAccepted: true})
+	} else {
+		ff, err = tracker.FileFindings(key.Analyzer, key.Scope)
+	}
+	if err != nil {
+		runnerLog.Printf("%s on %s: fix detection snapshot failed: %v", key.Analyzer, key.Scope, err)
+		return nil
+	}
+	return ff
+}
+
+// DiffFingerprints compares two finding sets by fingerprint. vanished holds
+// one representative per fingerprint present only in before; appeared holds
+// one per fingerprint present only in after.
+func DiffFingerprints(before, after []*Finding) (vanished, appeared []*Finding) {
+	return fingerprintsMissing(before, after), fingerprintsMissing(after, before)
+}
+
+// fingerprintsMissing returns one finding per fingerprint in from that is
+// absent from in.
+func fingerprintsMissing(from, in []*Finding) []*Finding {
+	present := make(map[string]bool, len(in))
+	for _, f := range in {
+		present[f.Fingerprint()] = true
+	}
+	var out []*Finding
+	for _, f := range from {
+		fp := f.Fingerprint()
+		if present[fp] {
+			continue
+		}
+		present[fp] = true
+		out = append(out, f)
+	}
+	return out
+}
+
+// detectFixes records fixed transitions for findings that vanished between
+// before and after, and reopens fixed findings that are reported again.
+// produced is the run's output before aide:ignore directives were applied,
+// so a finding that was silenced rather than fixed still counts as present.
+// Unused-suppression findings describe directives, not code, and are never
+// tracked.
+func (r *Runner) detectFixes(key RunKey, before, produced, after []*Finding) {
+	tracker, fd := r.fixTracker()
+	if tracker == nil {
+		return
+	}
+
+	vanished, _ := DiffFingerprints(before, append(slices.Clip(produced), after...))
+	vanished = slices.DeleteFunc(vanished, func(f *Finding) bool {
+		return f.Category == CategoryUnusedSuppression || r.filteredByThreshold(f)
+	})
+	_, appeared := DiffFingerprints(before, after)
+	appeared = slices.DeleteFunc(appeared, func(f *Finding) bool {
+		return f.Category == CategoryUnusedSuppression || f.Metadata[MetaSuppressed] == "true"
+	})
+	if len(vanished) == 0 && len(appeared) == 0 {
+		return
+	}
+
+	statuses, err := tracker.TriageStatuses()
+	if err != nil {
+		runnerLog.Printf("%s on %s: fix detection failed: %v", key.Analyzer, key.Scope, err)
+		return
+	}
+
+	var reopen []*Finding
+	for _, f := range appeared {
+		if st, ok := statuses[f.Fingerprint()]; ok && st.State == TriageFixed {
+			reopen = append(reopen, f)
+		}
+	}
+	if len(vanished) == 0 && len(reopen) == 0 {
+		return
+	}
+
+	var attr FixAttribution
+	if fd.Attribute != nil {
+		attr = fd.Attribute()
+	}
+	now := time.Now()
+
+	record := func(f *Finding, to TriageState, reason string) {
+		ev := &TriageEvent{
+			Fingerprint: f.Fingerprint(),
+			To:          to,
+			Reason:      reason,
+			Actor:       FixDetectorActor,
+			Commit:      attr.Commit,
+			SessionID:   attr.Session,
+		}
+		ev.Describe(f)
+		if st := statuses[ev.Fingerprint]; st != nil && to == TriageFixed {
+			ev.Assignee = st.Assignee // credit whoever was handling it
+		}
+		if _, err := tracker.TriageFinding(ev); err != nil {
+			runnerLog.Printf("%s on %s: failed to mark %s %s: %v", key.Analyzer, key.Scope, ev.Fingerprint, to, err)
+			return
+		}
+		emitTriageObserveEvent(ev)
+		if fd.Notify != nil {
+			fd.Notify(ev)
+		}
+	}
+
+	fixed := 0
+	for _, f := range vanished {
+		switch statuses[f.Fingerprint()].Effective(now) {
+		case TriageFixed, TriageWontFix:
+			continue
+		}
+		record(f, TriageFixed, fmt.Sprintf("no longer reported by %s", key.Analyzer))
+		fixed++
+	}
+	for _, f := range reopen {
+		record(f, TriageOpen, fmt.Sprintf("reported again by %s", key.Analyzer))
+	}
+	if fixed > 0 || len(reopen) > 0 {
+		runnerLog.Printf("%s on %s: %d fixed, %d reopened", key.Analyzer, key.Scope, fixed, len(reopen))
+	}
+}
+
+// filteredByThreshold reports whether f was reported under a different
+// threshold from the one the runner now applies, so its absence says the
+// bar moved rather than that the code improved.
+func (r *Runner) filteredByThreshold(f *Finding) bool {
+	recorded, err := strconv.Atoi(f.Metadata["threshold"])
+	if err != nil {
+		return false
+	}
+	var current, fallback int
+	switch {
+	case f.Analyzer == AnalyzerComplexity:
+		current, fallback = r.config.ComplexityThreshold, DefaultComplexityThreshold
+	case f.Analyzer == AnalyzerCoupling && f.Category == "fan-out":
+		current, fallback = r.config.FanOutThreshold, DefaultFanOutThreshold
+	case f.Analyzer == AnalyzerCoupling && f.Category == "fan-in":
+		current, fallback = r.config.FanInThreshold, DefaultFanInThreshold
+	default:
+		return false
+	}
+	if current <= 0 {
+		current = fallback
+	}
+	return recorded != current
+}
+
+// emitTriageObserveEvent records an inferred transition on the observe
+// stream (and, through the observe sink, its live event bus).
+func emitTriageObserveEvent(ev *TriageEvent) {
+	name := "finding_fixed"
+	if ev.To == TriageOpen {
+		name = "finding_reopened"
+	}
+	attrs := map[string]string{
+		"fingerprint": ev.Fingerprint,
+		"title":       ev.Title,
+		"reason":      ev.Reason,
+	}
+	if ev.Commit != "" {
+		attrs["commit"] = ev.Commit
+	}
+	if ev.Assignee != "" {
+		attrs["assignee"] = ev.Assignee
+	}
+	observe.Record(&observe.Event{
+		Kind:      observe.KindFinding,
+		Name:      name,
+		Category:  ev.Analyzer,
+		Subtype:   string(ev.To),
+		FilePath:  ev.FilePath,
+		SessionID: ev.SessionID,
+		Attrs:     attrs,
+	})
+}
@@ -0,0 +1,311 @@
+package findings
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memFixStore is an in-memory ReplaceFindingsStore + FixTracker.
+type memFixStore struct {
+	mu       sync.Mutex
+	findings []*Finding
+	statuses map[string]*TriageStatus
+	events   []*TriageEvent
+	loads    int // TriageStatuses calls
+}
+
+func newMemFixStore() *memFixStore {
+	return &memFixStore{statuses: make(map[string]*TriageStatus)}
+}
+
+func (m *memFixStore) ReplaceFindingsForAnalyzer(analyzer string, ff []*Finding) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.findings[:0]
+	for _, f := range m.findings {
+		if f.Analyzer != analyzer {
+			kept = append(kept, f)
+		}
+	}
+	m.findings = append(kept, ff...)
+	return nil
+}
+
+func (m *memFixStore) ReplaceFindingsForAnalyzerAndFile(analyzer, filePath string, ff []*Finding) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.findings[:0]
+	for _, f := range m.findings {
+		if f.Analyzer != analyzer || f.FilePath != filePath {
+			kept = append(kept, f)
+		}
+	}
+	m.findings = append(kept, ff...)
+	return nil
+}
+
+func (m *memFixStore) Stats(_ SearchOptions) (*Stats, error) { return &Stats{}, nil }
+
+func (m *memFixStore) ListFindings(opts SearchOptions) ([]*Finding, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*Finding
+	for _, f := range m.findings {
+		if opts.Analyzer == "" || f.Analyzer == opts.Analyzer {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (m *memFixStore) FileFindings(analyzer, filePath string) ([]*Finding, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []*Finding
+	for _, f := range m.findings {
+		if f.Analyzer == analyzer && f.FilePath == filePath {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (m *memFixStore) TriageStatuses() (map[string]*TriageStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loads++
+	out := make(map[string]*TriageStatus, len(m.statuses))
+	for k, v := range m.statuses {
+		cp := *v
+		out[k] = &cp
+	}
+	return out, nil
+}
+
+func (m *memFixStore) TriageFinding(ev *TriageEvent) (*TriageStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st, ok := m.statuses[ev.Fingerprint]
+	if !ok {
+		st = &TriageStatus{State: TriageOpen}
+		m.statuses[ev.Fingerprint] = st
+	}
+	if err := ValidateTransition(st.Effective(time.Now()), ev.To); err != nil {
+		return nil, err
+	}
+	ev.From = st.State
+	ev.CreatedAt = time.Now()
+	st.Apply(ev)
+	m.events = append(m.events, ev)
+	return st, nil
+}
+
+func TestDiffFingerprints(t *testing.T) {
+	a := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Title: "f has complexity 20"}
+	a2 := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Title: "f has complexity 25", Line: 40}
+	b := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Title: "g has complexity 20"}
+
+	c := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Title: "h has complexity 20"}
+
+	vanished, appeared := DiffFingerprints([]*Finding{a, b}, []*Finding{a2, c, c})
+	if len(vanished) != 1 || vanished[0] != b {
+		t.Errorf("expected only g to vanish, got %v", vanished)
+	}
+	if len(appeared) != 1 || appeared[0] != c {
+		t.Errorf("expected only h to appear, once, got %v", appeared)
+	}
+}
+
+func TestRunnerFixDetection(t *testing.T) {
+	store := newMemFixStore()
+	root := t.TempDir()
+	runner := NewRunner(store, AnalyzerConfig{ProjectRoot: root, Paths: []string{root}}, nil)
+	defer runner.Stop()
+
+	var notified []*TriageEvent
+	var notifyMu sync.Mutex
+	runner.SetFixDetection(FixDetection{
+		Attribute: func() FixAttribution { return FixAttribution{Commit: "abc123", Session: "s1"} },
+		Notify: func(ev *TriageEvent) {
+			notifyMu.Lock()
+			notified = append(notified, ev)
+			notifyMu.Unlock()
+		},
+	})
+
+	fanOut := &Finding{Analyzer: AnalyzerCoupling, Category: "fan-out", FilePath: "hub.go", Title: "High fan-out: 30 imports"}
+	cycle := &Finding{Analyzer: AnalyzerCoupling, Category: "cycle", FilePath: "a.go", Title: "Import cycle (2 files)"}
+	key := RunKey{Analyzer: AnalyzerCoupling, Scope: ScopeProject}
+	run := func(ff ...*Finding) {
+		t.Helper()
+		runner.runAnalyzer(key, func(context.Context) ([]*Finding, error) {
+			out := make([]*Finding, len(ff))
+			for i, f := range ff {
+				cp := *f
+				out[i] = &cp
+			}
+			return out, nil
+		})
+		runner.WaitAll()
+	}
+
+	run(fanOut, cycle)
+	if len(store.events) != 0 {
+		t.Fatalf("first run should record nothing, got %d events", len(store.events))
+	}
+
+	if _, err := store.TriageFinding(&TriageEvent{Fingerprint: fanOut.Fingerprint(), To: TriageAcknowledged, Assignee: "agent-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// fan-out disappears: marked fixed and credited to the assignee.
+	run(cycle)
+	st := store.statuses[fanOut.Fingerprint()]
+	if st == nil || st.State != TriageFixed {
+		t.Fatalf("expected fan-out finding to be fixed, got %+v", st)
+	}
+	ev := store.events[len(store.events)-1]
+	if ev.Commit != "abc123" || ev.SessionID != "s1" || ev.Actor != FixDetectorActor {
+		t.Errorf("unexpected attribution: %+v", ev)
+	}
+	if ev.Assignee != "agent-1" || ev.Title != fanOut.Title {
+		t.Errorf("expected assignee and finding snapshot on fix event, got %+v", ev)
+	}
+	if len(notified) != 1 {
+		t.Errorf("expected 1 notification, got %d", len(notified))
+	}
+
+	// Running again without the finding must not re-record the fix.
+	run(cycle)
+	if len(notified) != 1 {
+		t.Errorf("expected no new notifications, got %d", len(notified))
+	}
+
+	// The finding comes back: reopened.
+	run(fanOut, cycle)
+	if st := store.statuses[fanOut.Fingerprint()]; st.State != TriageOpen {
+		t.Errorf("expected regression to reopen the finding, got %s", st.State)
+	}
+
+	// Nothing vanished or appeared: triage state is not even loaded.
+	loads := store.loads
+	run(fanOut, cycle)
+	if store.loads != loads {
+		t.Errorf("unchanged run loaded triage statuses %d times", store.loads-loads)
+	}
+}
+
+func TestRunnerFixDetection_SuppressedOrFilteredNotFixed(t *testing.T) {
+	store := newMemFixStore()
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{"a.go": "package a\n\nfunc f() {}\n"})
+	runner := NewRunner(store, AnalyzerConfig{ProjectRoot: root, Paths: []string{root}, FanOutThreshold: 40}, nil)
+	defer runner.Stop()
+	runner.SetFixDetection(FixDetection{})
+
+	complexity := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 3, Title: "f has complexity 12"}
+	hub := &Finding{Analyzer: AnalyzerCoupling, Category: "fan-out", FilePath: "hub.go", Line: 1,
+		Title: "High fan-out: 30 imports", Metadata: map[string]string{"threshold": "20"}}
+	run := func(key RunKey, ff ...*Finding) {
+		t.Helper()
+		runner.runAnalyzer(key, func(context.Context) ([]*Finding, error) {
+			out := make([]*Finding, len(ff))
+			for i, f := range ff {
+				cp := *f
+				out[i] = &cp
+			}
+			return out, nil
+		})
+		runner.WaitAll()
+	}
+
+	// An aide:ignore added above f silences the finding; it is still there.
+	fileKey := RunKey{Analyzer: AnalyzerComplexity, Scope: "a.go"}
+	run(fileKey, complexity)
+	writeFiles(t, root, map[string]string{"a.go": "package a\n\n// aide:ignore complexity table-driven\nfunc f() {}\n"})
+	complexity.Line = 4
+	run(fileKey, complexity)
+	if len(store.findings) != 0 {
+		t.Fatalf("expected the finding to be suppressed, store has %v", store.findings)
+	}
+
+	// Stored under fan-out threshold 20; the runner now applies 40.
+	projectKey := RunKey{Analyzer: AnalyzerCoupling, Scope: ScopeProject}
+	if err := store.ReplaceFindingsForAnalyzer(AnalyzerCoupling, []*Finding{hub}); err != nil {
+		t.Fatal(err)
+	}
+	run(projectKey)
+
+	if len(store.events) != 0 {
+		t.Errorf("suppressed/filtered findings marked fixed: %+v", store.events)
+	}
+
+	// A fan-out that really went away under the current threshold is fixed.
+	hub.Metadata = map[string]string{"threshold": "40"}
+	if err := store.ReplaceFindingsForAnalyzer(AnalyzerCoupling, []*Finding{hub}); err != nil {
+		t.Fatal(err)
+	}
+	run(projectKey)
+	if st := store.statuses[hub.Fingerprint()]; st == nil || st.State != TriageFixed {
+		t.Errorf("expected the fan-out finding fixed, got %+v", st)
+	}
+}
+
+func TestRunnerFixDetection_UnusedSuppressionNotFixed(t *testing.T) {
+	store := newMemFixStore()
+	root := t.TempDir()
+	directive := "package a\n\n// aide:ignore complexity table-driven\nfunc f() {}\n"
+	writeFiles(t, root, map[string]string{"a.go": directive})
+	runner := NewRunner(store, AnalyzerConfig{ProjectRoot: root, Paths: []string{root}}, nil)
+	defer runner.Stop()
+	runner.SetFixDetection(FixDetection{})
+
+	key := RunKey{Analyzer: AnalyzerComplexity, Scope: "a.go"}
+	run := func(ff ...*Finding) {
+		t.Helper()
+		runner.runAnalyzer(key, func(context.Context) ([]*Finding, error) { return ff, nil })
+		runner.WaitAll()
+	}
+	unused := func() int {
+		n := 0
+		for _, f := range store.findings {
+			if f.Category == CategoryUnusedSuppression {
+				n++
+			}
+		}
+		return n
+	}
+
+	// The directive matches nothing, then starts matching again.
+	run()
+	if unused() != 1 {
+		t.Fatalf("expected an unused-suppression finding, store has %v", store.findings)
+	}
+	run(&Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 4, Title: "f has complexity 12"})
+	if unused() != 0 {
+		t.Fatalf("expected the directive in use, store has %v", store.findings)
+	}
+
+	// The directive goes stale again, then is deleted.
+	run()
+	writeFiles(t, root, map[string]string{"a.go": "package a\n\nfunc f() {}\n"})
+	run()
+	if unused() != 0 {
+		t.Fatalf("expected no unused-suppression finding, store has %v", store.findings)
+	}
+
+	if len(store.events) != 0 {
+		t.Errorf("unused-suppression findings tracked as fixes: %+v", store.events)
+	}
+}
+
+func TestRunnerFixDetection_DisabledWithoutTracker(t *testing.T) {
+	runner := NewRunner(&mockReplaceFindingsStore{}, AnalyzerConfig{}, nil)
+	defer runner.Stop()
+	runner.SetFixDetection(FixDetection{})
+	if tracker, _ := runner.fixTracker(); tracker != nil {
+		t.Error("fix detection should stay disabled for stores without triage support")
+	}
+}
@@ -0,0 +1,123 @@
+package findings
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/observe"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// LicensesConfig holds configuration for the license policy check.
+type LicensesConfig struct {
+	// Entries are the survey licenses analyser's KindLicense entries.
+	Entries []*survey.Entry
+	// Policy is the configured policy; a zero policy means
+	// survey.DefaultLicensePolicy for the project's root license files.
+	Policy survey.LicensePolicy
+}
+
+// LicensesResult holds summary statistics from a license policy check.
+type LicensesResult struct {
+	Project       []string // SPDX expressions of the project's root license files
+	Policy        survey.LicensePolicy
+	Checked       int // license files evaluated against the policy
+	FindingsCount int
+	Duration      time.Duration
+}
+
+// AnalyzeLicenses evaluates every license file below the project root —
+// dependency licenses and licenses of code copied into subdirectories —
+// against the policy, emitting a finding for each one that is denied
+// (critical), outside the allow list (warning) or unrecognised (info).
+func AnalyzeLicenses(cfg LicensesConfig) ([]*Finding, *LicensesResult, error) {
+	span := observe.Start("AnalyzeLicenses", observe.KindSpan).Category("analyzer").Subtype("license")
+	defer span.End()
+	start := time.Now()
+
+	result := &LicensesResult{Policy: cfg.Policy}
+	var checked []*survey.Entry
+	for _, e := range cfg.Entries {
+		if e.Kind != survey.KindLicense {
+			continue
+		}
+		if path.Dir(e.FilePath) == "." {
+			result.Project = append(result.Project, e.Name)
+			continue
+		}
+		checked = append(checked, e)
+	}
+	sort.Strings(result.Project)
+	if result.Policy.IsZero() {
+		result.Policy = survey.DefaultLicensePolicy(result.Project)
+	}
+	result.Checked = len(checked)
+
+	var out []*Finding
+	for _, e := range checked {
+		verdict, reason := result.Policy.Evaluate(e.Name)
+		if verdict == survey.LicenseAllowed {
+			continue
+		}
+		out = append(out, licenseFinding(e, verdict, reason, result.Project))
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if ri, rj := SeverityRank(out[i].Severity), SeverityRank(out[j].Severity); ri != rj {
+			return ri > rj
+		}
+		return out[i].FilePath < out[j].FilePath
+	})
+	result.FindingsCount = len(out)
+	result.Duration = time.Since(start)
+	return out, result, nil
+}
+
+func licenseFinding(e *survey.Entry, verdict, reason string, project []string) *Finding {
+	owner := e.Metadata["package"]
+	if owner == "" {
+		owner = path.Dir(e.FilePath)
+	}
+	sev, title := SevInfo, fmt.Sprintf("Unrecognised license for %s", owner)
+	switch verdict {
+	case survey.LicenseDenied:
+		sev, title = SevCritical, fmt.Sprintf("%s is licensed %s, which the license policy denies", owner, e.Name)
+	case survey.LicenseNotAllowed:
+		sev, title = SevWarning, fmt.Sprintf("%s is licensed %s, which the license policy does not allow", owner, e.Name)
+	}
+
+	detail := reason + "."
+	if len(project) > 0 {
+		detail += fmt.Sprintf(" The project is licensed %s.", strings.Join(project, ", "))
+	}
+	if verdict == survey.LicenseUnknownUse {
+		detail += " Review " + e.FilePath + " and add its license to the policy's allow or deny list."
+	} else {
+		detail += " Replace the dependency or obtain legal approval before shipping it."
+	}
+
+	meta := map[string]string{
+		"rule_id": e.Name,
+		"spdx":    e.Name,
+		"verdict": verdict,
+	}
+	for _, k := range []string{"category", "package", "scope"} {
+		if v := e.Metadata[k]; v != "" {
+			meta[k] = v
+		}
+	}
+	return &Finding{
+		Analyzer:  AnalyzerLicense,
+		Severity:  sev,
+		Category:  verdict,
+		FilePath:  e.FilePath,
+		Line:      1,
+		Title:     title,
+		Detail:    detail,
+		Metadata:  meta,
+		CreatedAt: time.Now(),
+	}
+}
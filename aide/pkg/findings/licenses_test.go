@@ -0,0 +1,78 @@
+package findings
+
+import (
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+func licenseEntry(file, spdx, pkg string) *survey.Entry {
+	return &survey.Entry{
+		Analyzer: survey.AnalyzerLicenses,
+		Kind:     survey.KindLicense,
+		Name:     spdx,
+		FilePath: file,
+		Metadata: map[string]string{"spdx": spdx, "package": pkg, "scope": survey.LicenseScopeDependency},
+	}
+}
+
+func TestAnalyzeLicenses(t *testing.T) {
+	entries := []*survey.Entry{
+		licenseEntry("LICENSE", "Apache-2.0", ""),
+		licenseEntry("node_modules/left-pad/LICENSE", "MIT", "left-pad"),
+		licenseEntry("node_modules/gpl-thing/COPYING", "GPL-3.0", "gpl-thing"),
+		licenseEntry("vendor/example.com/dual/LICENSE", "MIT OR GPL-2.0", "example.com/dual"),
+		licenseEntry("vendor/example.com/odd/LICENSE", survey.LicenseUnknown, "example.com/odd"),
+	}
+
+	t.Run("default policy", func(t *testing.T) {
+		ff, result, err := AnalyzeLicenses(LicensesConfig{Entries: entries})
+		if err != nil {
+			t.Fatalf("AnalyzeLicenses: %v", err)
+		}
+		if result.Checked != 4 || len(result.Project) != 1 || result.Project[0] != "Apache-2.0" {
+			t.Errorf("Checked/Project = %d/%v, want 4/[Apache-2.0]", result.Checked, result.Project)
+		}
+		if len(ff) != 2 {
+			t.Fatalf("got %d findings, want 2: %+v", len(ff), ff)
+		}
+		gpl := ff[0]
+		if gpl.FilePath != "node_modules/gpl-thing/COPYING" || gpl.Severity != SevCritical || gpl.Category != survey.LicenseDenied {
+			t.Errorf("first finding = %s %s %s, want denied GPL at critical", gpl.FilePath, gpl.Severity, gpl.Category)
+		}
+		if gpl.Analyzer != AnalyzerLicense || gpl.Metadata["rule_id"] != "GPL-3.0" || gpl.Metadata["package"] != "gpl-thing" {
+			t.Errorf("metadata = %v", gpl.Metadata)
+		}
+		if ff[1].Severity != SevInfo || ff[1].Category != survey.LicenseUnknownUse {
+			t.Errorf("second finding = %s %s, want unknown at info", ff[1].Severity, ff[1].Category)
+		}
+	})
+
+	t.Run("allow list", func(t *testing.T) {
+		ff, _, err := AnalyzeLicenses(LicensesConfig{
+			Entries: entries,
+			Policy:  survey.LicensePolicy{Allow: []string{"Apache-2.0"}, Deny: []string{"GPL-*"}},
+		})
+		if err != nil {
+			t.Fatalf("AnalyzeLicenses: %v", err)
+		}
+		got := make(map[string]string)
+		for _, f := range ff {
+			got[f.FilePath] = f.Category
+		}
+		want := map[string]string{
+			"node_modules/left-pad/LICENSE":   survey.LicenseNotAllowed,
+			"node_modules/gpl-thing/COPYING":  survey.LicenseDenied,
+			"vendor/example.com/dual/LICENSE": survey.LicenseNotAllowed,
+			"vendor/example.com/odd/LICENSE":  survey.LicenseUnknownUse,
+		}
+		if len(got) != len(want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		for file, category := range want {
+			if got[file] != category {
+				t.Errorf("%s: got %q, want %q", file, got[file], category)
+			}
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -49,6 +50,12 @@ type AnalyzerConfig struct {
 	// Ignore is the aideignore matcher used to filter files and directories.
 	// If nil, built-in defaults are used.
 	Ignore *aideignore.Matcher
+	// ShowSuppressed keeps findings silenced by aide:ignore directives,
+	// annotated with the directive's reason, instead of dropping them.
+	ShowSuppressed bool
+	// RulesDir holds user rule packs for the custom analyzer (normally
+	// <project>/.aide/rules). Empty disables the custom analyzer.
+	RulesDir string
 }
 
 type RunKey struct {
@@ -95,6 +102,10 @@ type Runner struct {
 	status        map[string]*AnalyzerStatus
 	runIDGen      int64
 	defaultIgnore *aideignore.Matcher // Cached default matcher (lazy-init)
+	fixDetection  *FixDetection       // nil = disabled (see SetFixDetection)
+	customRules   *CustomRuleSet      // Loaded from config.RulesDir (lazy, reloaded when stale)
+	customBad     string              // Rules dir stamp that last failed to load
+	suppressions  *SuppressionCache   // Parsed aide:ignore directives, reused across runs
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -126,6 +137,9 @@ func NewRunner(store ReplaceFindingsStore, config AnalyzerConfig, loader grammar
 		ctx:    ctx,
 		cancel: cancel,
 		sem:    make(chan struct{}, DefaultRunnerConcurrency), // Limit concurrent per-file goroutines
+		// Project-scope runs follow every watcher batch; without the cache
+		// each would re-read every source file looking for directives.
+		suppressions: NewSuppressionCache(),
 	}
 }
 
@@ -150,8 +164,112 @@ func (r *Runner) ignore() *aideignore.Matcher {
 	return r.defaultIgnore
 }
 
+// customRuleSet returns the custom analyzer's rules, reloading them when the
+// rules directory changed since the last load. changed reports a reload, so
+// callers can re-run the custom analyzer over the whole project. A rules
+// directory that fails to load keeps the previous rules in force.
+func (r *Runner) customRuleSet() (rs *CustomRuleSet, changed bool) {
+	if r.config.RulesDir == "" {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.customRules != nil && !r.customRules.Stale() {
+		return r.customRules, false
+	}
+	stamp := rulesDirStamp(r.config.RulesDir)
+	if r.customBad != "" && stamp == r.customBad && r.customRules != nil {
+		return r.customRules, false
+	}
+	loaded, err := LoadCustomRules(r.config.RulesDir)
+	if err != nil {
+		if stamp != r.customBad {
+			runnerLog.Printf("custom rules: %v (keeping previous rules)", err)
+		}
+		r.customBad = stamp
+		if r.customRules == nil {
+			r.customRules = &CustomRuleSet{dir: r.config.RulesDir, stamp: stamp}
+		}
+		return r.customRules, false
+	}
+	// The previous set is not closed: in-flight runs may still be using its
+	// compiled queries.
+	first := r.customRules == nil
+	r.customRules = loaded
+	r.customBad = ""
+	if loaded.Len() > 0 || !first {
+		runnerLog.Printf("custom rules: loaded %d rules from %s", loaded.Len(), r.config.RulesDir)
+	}
+	return loaded, !first
+}
+
+// AnalyzesFile reports whether a per-file analyzer applies to path. Secrets
+// scans any file it does not skip by extension (.env files, config); the
+// others only visit source files the parser supports.
+func AnalyzesFile(analyzer, path string) bool {
+	if analyzer == AnalyzerSecrets {
+		return secretsScannable(path)
+	}
+	return code.SupportedFile(path)
+}
+
+// clearedOnDelete returns the analyzers whose findings are cleared when a
+// file is deleted. Custom is included whenever a rules directory is
+// configured, even with no rules loaded, so findings from a since-removed
+// rule set do not outlive the file.
+func (r *Runner) clearedOnDelete() []string {
+	if r.config.RulesDir != "" {
+		return PerFileAnalyzers
+	}
+	return without(PerFileAnalyzers, AnalyzerCustom)
+}
+
+// perFileAnalyzers returns the analyzers run on each changed file:
+// PerFileAnalyzers, leaving out custom when no rules are loaded.
+func (r *Runner) perFileAnalyzers() []string {
+	if rs, _ := r.customRuleSet(); rs.Len() > 0 {
+		return PerFileAnalyzers
+	}
+	return without(PerFileAnalyzers, AnalyzerCustom)
+}
+
+// without returns a copy of analyzers with drop removed.
+func without(analyzers []string, drop string) []string {
+	return slices.DeleteFunc(slices.Clone(analyzers), func(a string) bool { return a == drop })
+}
+
+// rescanCustom re-runs the custom analyzer over every file after the rule
+// set changed, or clears its findings when no rules remain.
+func (r *Runner) rescanCustom(rs *CustomRuleSet) {
+	if rs.Len() == 0 {
+		if err := r.store.ReplaceFindingsForAnalyzer(AnalyzerCustom, nil); err != nil {
+			runnerLog.Printf("custom: failed to clear findings: %v", err)
+		}
+		return
+	}
+	err := r.walkFiles(func(path string) {
+		key := RunKey{Analyzer: AnalyzerCustom, Scope: toRelPath(r.config.ProjectRoot, path)}
+		r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
+			return r.runPerFileAnalyzer(ctx, AnalyzerCustom, path)
+		})
+	})
+	if err != nil {
+		runnerLog.Printf("custom: rescan failed: %v", err)
+	}
+}
+
 func (r *Runner) OnChanges(files map[string]fsnotify.Op) {
-	perFileAnalyzers := []string{AnalyzerComplexity, AnalyzerSecrets, AnalyzerSecurity}
+	// Rule files live under .aide/, which the watcher skips, so rule edits
+	// are picked up on the next batch of source changes.
+	rules, rulesChanged := r.customRuleSet()
+	if rulesChanged {
+		r.rescanCustom(rules)
+	}
+
+	// TODO comments are collected by explicit runs (the CLI, RunAll and
+	// RunDiff), not re-scanned on every save.
+	perFileAnalyzers := without(r.perFileAnalyzers(), AnalyzerTodos)
+	clearedOnDelete := without(r.clearedOnDelete(), AnalyzerTodos)
 	projectAnalyzers := []string{AnalyzerCoupling, AnalyzerClones}
 
 	ignore := r.ignore()
@@ -182,7 +300,7 @@ func (r *Runner) OnChanges(files map[string]fsnotify.Op) {
 		// When a file is deleted, clear its per-file findings instead of
 		// re-analysing (the file no longer exists on disk).
 		if op&fsnotify.Remove != 0 {
-			for _, analyzer := range perFileAnalyzers {
+			for _, analyzer := range clearedOnDelete {
 				if err := r.store.ReplaceFindingsForAnalyzerAndFile(analyzer, scopePath, nil); err != nil {
 					runnerLog.Printf("%s on %s: failed to clear findings for deleted file: %v", analyzer, scopePath, err)
 				} else {
@@ -278,6 +396,10 @@ func (r *Runner) runAnalyzer(key RunKey, run func(ctx context.Context) ([]*Findi
 			return
 		}
 
+		produced := findings
+		findings = r.applySuppressions(key, findings)
+		before := r.snapshotFindings(key)
+
 		if key.Scope == ScopeProject {
 			if err := r.store.ReplaceFindingsForAnalyzer(key.Analyzer, findings); err != nil {
 				runnerLog.Printf("%s: store failed: %v (keeping old findings)", key.Analyzer, err)
@@ -292,11 +414,40 @@ func (r *Runner) runAnalyzer(key RunKey, run func(ctx context.Context) ([]*Findi
 			}
 		}
 
+		r.detectFixes(key, before, produced, findings)
+
 		runnerLog.Printf("%s on %s: %d findings in %v", key.Analyzer, key.Scope, len(findings), duration)
 		r.updateStatus(key.Analyzer, key.Scope, "idle", len(findings), duration, "")
 	}()
 }
 
+// applySuppressions filters a completed run's findings through aide:ignore
+// directives. Per-file runs only consider directives in that file; project
+// runs walk the configured paths so unused directives anywhere are reported.
+func (r *Runner) applySuppressions(key RunKey, ff []*Finding) []*Finding {
+	sup := NewSuppressor(SuppressionConfig{
+		ProjectRoot:    r.config.ProjectRoot,
+		ShowSuppressed: r.config.ShowSuppressed,
+		Cache:          r.suppressions,
+	})
+	if key.Scope == ScopeProject {
+		paths := r.config.Paths
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+		if err := sup.Walk(paths, r.ignore()); err != nil {
+			runnerLog.Printf("%s: suppression scan failed: %v", key.Analyzer, err)
+		}
+	} else {
+		sup.Load(key.Scope)
+	}
+	out, res := sup.Apply(key.Analyzer, ff)
+	if res.Suppressed > 0 || res.Unused > 0 {
+		runnerLog.Printf("%s on %s: %d suppressed, %d unused suppressions", key.Analyzer, key.Scope, res.Suppressed, res.Unused)
+	}
+	return out
+}
+
 func (r *Runner) runPerFileAnalyzer(ctx context.Context, analyzer string, file string) ([]*Finding, error) {
 	select {
 	case <-ctx.Done():
@@ -315,9 +466,14 @@ func (r *Runner) runPerFileAnalyzer(ctx context.Context, analyzer string, file s
 	case AnalyzerComplexity:
 		return r.analyzeFileComplexity(ctx, relPath, content)
 	case AnalyzerSecrets:
-		return r.analyzeFileSecrets(ctx, relPath, content)
+		return r.analyzeFileSecrets(ctx, file, relPath)
 	case AnalyzerSecurity:
 		return r.analyzeFileSecurity(ctx, relPath, content)
+	case AnalyzerTodos:
+		return analyzeFileTodos(relPath, content), nil
+	case AnalyzerCustom:
+		rs, _ := r.customRuleSet()
+		return rs.AnalyzeFile(ctx, r.loader, relPath, content), nil
 	default:
 		return nil, fmt.Errorf("unknown analyzer: %s", analyzer)
 	}
@@ -404,20 +560,30 @@ func (r *Runner) analyzeFileComplexity(ctx context.Context, filePath string, con
 	return analyzeFileComplexity(ctx, r.loader, content, filePath, lang, langCfg, threshold), nil
 }
 
-func (r *Runner) analyzeFileSecrets(ctx context.Context, filePath string, _ []byte) ([]*Finding, error) {
+// analyzeFileSecrets scans file, reporting its findings against relPath:
+// AnalyzeSecrets resolves paths against the working directory, which need not
+// be the project root.
+func (r *Runner) analyzeFileSecrets(ctx context.Context, file, relPath string) ([]*Finding, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 	}
 
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
 	cfg := SecretsConfig{
-		Paths:          []string{filePath},
+		Paths:          []string{abs},
 		SkipValidation: true,
 		MaxFileSize:    DefaultRunnerSecretsMaxFileSize,
 	}
 
 	findings, _, err := AnalyzeSecrets(cfg)
+	for _, f := range findings {
+		f.FilePath = relPath
+	}
 	return findings, err
 }
 
@@ -484,11 +650,100 @@ func (r *Runner) Stop() {
 }
 
 // RunAll schedules analysis of all supported files in the configured paths.
-// Per-file analysers (complexity, secrets) are launched per file; project-wide
+// Per-file analysers (PerFileAnalyzers) are launched per file; project-wide
 // analysers (coupling, clones) are launched once. All analysers run
 // asynchronously via runAnalyzer — use WaitAll() to block until completion,
 // or Stop() to cancel and drain.
 func (r *Runner) RunAll(ctx context.Context) error {
+	perFileAnalyzers := r.perFileAnalyzers()
+	if rs, _ := r.customRuleSet(); r.config.RulesDir != "" && rs.Len() == 0 {
+		// Rules were removed: drop findings from the last rule set.
+		if err := r.store.ReplaceFindingsForAnalyzer(AnalyzerCustom, nil); err != nil {
+			runnerLog.Printf("custom: failed to clear findings: %v", err)
+		}
+	}
+
+	err := r.walkFiles(func(path string) {
+		scopePath := toRelPath(r.config.ProjectRoot, path)
+		for _, analyzer := range perFileAnalyzers {
+			key := RunKey{Analyzer: analyzer, Scope: scopePath}
+			r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
+				return r.runPerFileAnalyzer(ctx, analyzer, path)
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, analyzer := range []string{AnalyzerCoupling, AnalyzerClones} {
+		key := RunKey{Analyzer: analyzer, Scope: ScopeProject}
+		r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
+			return r.runProjectAnalyzer(ctx, analyzer)
+		})
+	}
+
+	return nil
+}
+
+// RunDiff schedules analysis scoped to a git diff: per-file analysers run only
+// on files changed since scope.Ref, findings for files deleted since the ref
+// are cleared, and project-wide analysers run once (their stored output stays
+// whole-project; callers narrow the report with scope.Filter). Like RunAll,
+// runs are asynchronous — use WaitAll() to block until completion.
+func (r *Runner) RunDiff(ctx context.Context, scope *DiffScope) error {
+	if scope == nil {
+		return fmt.Errorf("diff scope is required")
+	}
+	if scope.Empty() {
+		return nil
+	}
+
+	ignore := r.ignore()
+	perFileAnalyzers := r.perFileAnalyzers()
+
+	for _, rel := range scope.Deleted {
+		scopePath := toRelPath(r.config.ProjectRoot, filepath.Join(scope.Root, rel))
+		for _, analyzer := range r.clearedOnDelete() {
+			if err := r.store.ReplaceFindingsForAnalyzerAndFile(analyzer, scopePath, nil); err != nil {
+				runnerLog.Printf("%s on %s: failed to clear findings for deleted file: %v", analyzer, scopePath, err)
+			}
+		}
+	}
+
+	for rel := range scope.Files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if ignore.ShouldIgnoreFile(rel) {
+			continue
+		}
+		path := filepath.Join(scope.Root, rel)
+		scopePath := toRelPath(r.config.ProjectRoot, path)
+		for _, analyzer := range perFileAnalyzers {
+			if !AnalyzesFile(analyzer, rel) {
+				continue
+			}
+			key := RunKey{Analyzer: analyzer, Scope: scopePath}
+			r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
+				return r.runPerFileAnalyzer(ctx, analyzer, path)
+			})
+		}
+	}
+
+	for _, analyzer := range []string{AnalyzerCoupling, AnalyzerClones} {
+		key := RunKey{Analyzer: analyzer, Scope: ScopeProject}
+		r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
+			return r.runProjectAnalyzer(ctx, analyzer)
+		})
+	}
+
+	return nil
+}
+
+// walkFiles calls fn for every supported, non-ignored file under the
+// configured paths.
+func (r *Runner) walkFiles(fn func(path string)) error {
 	paths := r.config.Paths
 	if len(paths) == 0 {
 		paths = []string{"."}
@@ -510,33 +765,15 @@ func (r *Runner) RunAll(ctx context.Context) error {
 				}
 				return nil
 			}
-			if info.IsDir() {
-				return nil
-			}
-			if !code.SupportedFile(path) {
+			if info.IsDir() || !code.SupportedFile(path) {
 				return nil
 			}
-
-			scopePath := toRelPath(r.config.ProjectRoot, path)
-			for _, analyzer := range []string{AnalyzerComplexity, AnalyzerSecrets, AnalyzerSecurity} {
-				key := RunKey{Analyzer: analyzer, Scope: scopePath}
-				r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
-					return r.runPerFileAnalyzer(ctx, analyzer, path)
-				})
-			}
+			fn(path)
 			return nil
 		})
 		if err != nil {
 			return err
 		}
 	}
-
-	for _, analyzer := range []string{AnalyzerCoupling, AnalyzerClones} {
-		key := RunKey{Analyzer: analyzer, Scope: ScopeProject}
-		r.runAnalyzer(key, func(ctx context.Context) ([]*Finding, error) {
-			return r.runProjectAnalyzer(ctx, analyzer)
-		})
-	}
-
 	return nil
 }
@@ -1,6 +1,8 @@
 package findings
 
 import (
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -217,3 +219,48 @@ func TestOnChanges_UnsupportedFileIgnored(t *testing.T) {
 		t.Errorf("expected 0 calls for unsupported file, got %d", len(calls))
 	}
 }
+
+func TestRunDiff_ScansChangedNonSourceFiles(t *testing.T) {
+	secrets, err := os.ReadFile(filepath.Join(testdataDir(t), "secrets_embedded.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".env": string(secrets),
+		"a.go": "package a\n\n// TODO: handle errors\nfunc A() {}\n",
+	})
+
+	store := newMemFixStore()
+	runner := NewRunner(store, AnalyzerConfig{ProjectRoot: root, Paths: []string{root}}, nil)
+	defer runner.Stop()
+
+	scope := &DiffScope{Root: root, Files: map[string][]LineRange{
+		".env": {{Start: 1, End: 1}},
+		"a.go": {{Start: 1, End: 4}},
+	}}
+	if err := runner.RunDiff(t.Context(), scope); err != nil {
+		t.Fatal(err)
+	}
+	runner.WaitAll()
+
+	ff, _ := store.ListFindings(SearchOptions{Analyzer: AnalyzerSecrets})
+	if len(ff) == 0 {
+		t.Fatal("expected secrets findings in the changed .env file")
+	}
+	for _, f := range ff {
+		if filepath.Base(f.FilePath) != ".env" {
+			t.Errorf("secret reported in %s, want .env", f.FilePath)
+		}
+	}
+	todos, _ := store.ListFindings(SearchOptions{Analyzer: AnalyzerTodos})
+	if len(todos) != 1 {
+		t.Errorf("expected 1 todos finding from RunDiff, got %d", len(todos))
+	}
+	all, _ := store.ListFindings(SearchOptions{})
+	for _, f := range all {
+		if f.Analyzer != AnalyzerSecrets && filepath.Base(f.FilePath) == ".env" {
+			t.Errorf("%s ran on the non-source .env file", f.Analyzer)
+		}
+	}
+}
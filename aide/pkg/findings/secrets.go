@@ -70,6 +70,12 @@ var secretsSkipExtensions = map[string]bool{
 	".lock": true,
 }
 
+// secretsScannable reports whether the secrets analyzer scans path, i.e.
+// its extension is not in secretsSkipExtensions.
+func secretsScannable(path string) bool {
+	return !secretsSkipExtensions[strings.ToLower(filepath.Ext(path))]
+}
+
 // AnalyzeSecrets scans files for hardcoded secrets using Titus.
 // It returns the findings, a result summary, and any error.
 func AnalyzeSecrets(cfg SecretsConfig) ([]*Finding, *SecretsResult, error) {
@@ -125,8 +131,7 @@ func AnalyzeSecrets(cfg SecretsConfig) ([]*Finding, *SecretsResult, error) {
 			}
 
 			// Skip by extension.
-			ext := strings.ToLower(filepath.Ext(path))
-			if secretsSkipExtensions[ext] {
+			if !secretsScannable(path) {
 				result.FilesSkipped++
 				return nil
 			}
@@ -155,6 +160,7 @@ func AnalyzeSecrets(cfg SecretsConfig) ([]*Finding, *SecretsResult, error) {
 				relPath = path
 			}
 
+			fileFindings := make([]*Finding, 0, len(matches))
 			for _, match := range matches {
 				line := 0
 				if match.Location.Source.Start.Line > 0 {
@@ -172,6 +178,8 @@ func AnalyzeSecrets(cfg SecretsConfig) ([]*Finding, *SecretsResult, error) {
 				metadata := map[string]string{
 					"rule_id":   match.RuleID,
 					"rule_name": match.RuleName,
+					// Hash of the secret itself, never the secret.
+					MetaMatchHash: MatchHash(string(match.Snippet.Matching)),
 				}
 				if match.ValidationResult != nil {
 					metadata["validation"] = string(match.ValidationResult.Status)
@@ -192,8 +200,10 @@ func AnalyzeSecrets(cfg SecretsConfig) ([]*Finding, *SecretsResult, error) {
 					CreatedAt: time.Now(),
 				}
 
-				allFindings = append(allFindings, f)
+				fileFindings = append(fileFindings, f)
 			}
+			numberOccurrences(fileFindings)
+			allFindings = append(allFindings, fileFindings...)
 
 			if cfg.ProgressFn != nil {
 				cfg.ProgressFn(relPath, len(matches))
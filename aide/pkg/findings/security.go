@@ -221,9 +221,10 @@ func analyzeFileSecurity(_ context.Context, filePath string, content []byte) []*
 					Title:    cr.rule.Name,
 					Detail:   cr.rule.Description,
 					Metadata: map[string]string{
-						"rule_id":  cr.rule.ID,
-						"language": lang,
-						"pattern":  cr.rule.Pattern,
+						"rule_id":     cr.rule.ID,
+						"language":    lang,
+						"pattern":     cr.rule.Pattern,
+						MetaMatchHash: MatchHash(line),
 					},
 					CreatedAt: time.Now(),
 				}
@@ -232,6 +233,7 @@ func analyzeFileSecurity(_ context.Context, filePath string, content []byte) []*
 		}
 	}
 
+	numberOccurrences(findings)
 	return findings
 }
 
@@ -0,0 +1,447 @@
+package findings
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/aideignore"
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+)
+
+// SuppressionDirective is the marker recognised inside source comments to
+// silence a finding in place:
+//
+//	// aide:ignore complexity generated parser, refactoring not worthwhile
+//	// aide:ignore security:go-sql-concat query is built from constants only
+//
+// The directive applies to the line it sits on (trailing comment) or, when it
+// is part of a comment block, to the first finding line directly below that
+// block. File-level findings (coupling, Line <= 1) are matched by a directive
+// anywhere in the file's leading comment header.
+const SuppressionDirective = "aide:ignore"
+
+// CategoryUnusedSuppression is the category given to the info finding emitted
+// for an aide:ignore directive that matched nothing in the analyser run.
+const CategoryUnusedSuppression = "unused-suppression"
+
+// Metadata keys recorded on findings kept with ShowSuppressed.
+const (
+	MetaSuppressed        = "suppressed"
+	MetaSuppressionReason = "suppression_reason"
+	MetaSuppressionLine   = "suppression_line"
+)
+
+// suppressionLine captures "<analyzer>[:<rule>] reason" after the marker.
+var suppressionLine = regexp.MustCompile(`aide:ignore\s+([a-z][a-z0-9_-]*)(?::(\S+))?\s*(.*)$`)
+
+// Suppression is a single aide:ignore directive parsed from a source comment.
+type Suppression struct {
+	Analyzer string // Analyzer the directive targets (e.g. "complexity")
+	Rule     string // Optional rule/category narrowing; empty matches any
+	Reason   string // Free-text justification following the target
+	FilePath string // File containing the directive (as keyed by findings)
+	Line     int    // 1-indexed line of the directive
+}
+
+// Target returns the "<analyzer>[:<rule>]" form used in titles and logs.
+func (s *Suppression) Target() string {
+	if s.Rule == "" {
+		return s.Analyzer
+	}
+	return s.Analyzer + ":" + s.Rule
+}
+
+// matches reports whether the directive targets the given finding's analyzer
+// and (when narrowed) rule. The rule is compared against the finding's
+// rule_id metadata first (security, secrets) and its category otherwise
+// (coupling's "fan-out", todos' "fixme", clones' "duplicate", ...).
+func (s *Suppression) matches(f *Finding) bool {
+	if s.Analyzer != f.Analyzer {
+		return false
+	}
+	if s.Rule == "" {
+		return true
+	}
+	if id := f.Metadata["rule_id"]; id != "" && strings.EqualFold(id, s.Rule) {
+		return true
+	}
+	return strings.EqualFold(f.Category, s.Rule)
+}
+
+// SuppressionConfig configures a Suppressor.
+type SuppressionConfig struct {
+	// ProjectRoot resolves relative finding paths to files on disk. Empty
+	// means paths are read relative to the working directory.
+	ProjectRoot string
+	// ShowSuppressed keeps suppressed findings in the output, annotated with
+	// the directive's reason in Metadata, instead of dropping them.
+	ShowSuppressed bool
+	// Cache, when set, carries parsed directives across Suppressors so
+	// repeated runs only re-read files whose mtime or size changed.
+	Cache *SuppressionCache
+}
+
+// SuppressionCache holds parsed directives per file, validated against the
+// file's mtime and size. It is safe for concurrent use.
+type SuppressionCache struct {
+	mu    sync.Mutex
+	files map[string]cachedSuppressions
+}
+
+// cachedSuppressions is one file's parse result; sf is nil when the file
+// holds no directive.
+type cachedSuppressions struct {
+	mtime time.Time
+	size  int64
+	sf    *suppressedFile
+}
+
+// NewSuppressionCache creates an empty SuppressionCache.
+func NewSuppressionCache() *SuppressionCache {
+	return &SuppressionCache{files: make(map[string]cachedSuppressions)}
+}
+
+// lookup returns the cached parse for relPath when info still matches it.
+func (c *SuppressionCache) lookup(relPath string, info os.FileInfo) (*suppressedFile, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.files[relPath]
+	if !ok || !e.mtime.Equal(info.ModTime()) || e.size != info.Size() {
+		return nil, false
+	}
+	return e.sf, true
+}
+
+func (c *SuppressionCache) store(relPath string, info os.FileInfo, sf *suppressedFile) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.files[relPath] = cachedSuppressions{mtime: info.ModTime(), size: info.Size(), sf: sf}
+	c.mu.Unlock()
+}
+
+// SuppressionResult summarises one Apply call.
+type SuppressionResult struct {
+	Suppressed int // Findings matched by a directive
+	Unused     int // Directives for the analyzer that matched nothing
+}
+
+// Suppressor applies aide:ignore directives to analyzer output. Directives
+// are parsed lazily per file and cached, so a single Suppressor can be
+// shared across every analyzer in a run.
+type Suppressor struct {
+	cfg SuppressionConfig
+
+	mu    sync.Mutex
+	files map[string]*suppressedFile
+}
+
+// suppressedFile caches one file's directives plus the line classification
+// needed to attach comment-block directives to the code line they precede.
+type suppressedFile struct {
+	directives []*Suppression
+	comment    []bool // comment[i] is true when line i+1 is blank or comment-only
+}
+
+// NewSuppressor creates a Suppressor with an empty directive cache.
+func NewSuppressor(cfg SuppressionConfig) *Suppressor {
+	return &Suppressor{cfg: cfg, files: make(map[string]*suppressedFile)}
+}
+
+// Load parses directives from the given files (paths as used in
+// Finding.FilePath). Loaded files take part in unused-directive reporting
+// even when the analyzer produced no findings for them.
+func (s *Suppressor) Load(files ...string) {
+	for _, f := range files {
+		s.file(f)
+	}
+}
+
+// Walk loads every supported, non-ignored file under paths that contains a
+// directive. Use it before Apply for project-scope analyzers so directives
+// in files without findings are reported as unused.
+func (s *Suppressor) Walk(paths []string, ignore *aideignore.Matcher) error {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	if ignore == nil {
+		ignore = aideignore.NewFromDefaults()
+	}
+	marker := []byte(SuppressionDirective)
+
+	for _, root := range paths {
+		absRoot, err := filepath.Abs(root)
+		if err != nil {
+			return fmt.Errorf("abs path %s: %w", root, err)
+		}
+		shouldSkip := ignore.WalkFunc(absRoot)
+
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return nil
+			}
+			if skip, skipDir := shouldSkip(path, info); skip {
+				if skipDir {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() || !code.SupportedFile(path) {
+				return nil
+			}
+			relPath := toRelPath(s.cfg.ProjectRoot, path)
+			sf, ok := s.cfg.Cache.lookup(relPath, info)
+			if !ok {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					return nil
+				}
+				if bytes.Contains(content, marker) {
+					sf = parseSuppressions(relPath, content)
+				}
+				s.cfg.Cache.store(relPath, info, sf)
+			}
+			if sf == nil {
+				return nil
+			}
+			s.mu.Lock()
+			if _, ok := s.files[relPath]; !ok {
+				s.files[relPath] = sf
+			}
+			s.mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("walk %s: %w", root, err)
+		}
+	}
+	return nil
+}
+
+// Apply filters ff (all produced by analyzer) against loaded directives.
+// Suppressed findings are dropped, or kept with suppression metadata when
+// ShowSuppressed is set. Directives for analyzer that matched no finding are
+// returned as additional info findings with CategoryUnusedSuppression, so
+// stale escape hatches surface instead of silently accumulating.
+func (s *Suppressor) Apply(analyzer string, ff []*Finding) ([]*Finding, SuppressionResult) {
+	var res SuppressionResult
+	used := make(map[*Suppression]bool)
+	out := make([]*Finding, 0, len(ff))
+
+	for _, f := range ff {
+		dir := s.match(f)
+		if dir == nil {
+			out = append(out, f)
+			continue
+		}
+		used[dir] = true
+		res.Suppressed++
+		if !s.cfg.ShowSuppressed {
+			continue
+		}
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string)
+		}
+		f.Metadata[MetaSuppressed] = "true"
+		f.Metadata[MetaSuppressionReason] = dir.Reason
+		f.Metadata[MetaSuppressionLine] = strconv.Itoa(dir.Line)
+		out = append(out, f)
+	}
+
+	s.mu.Lock()
+	var unused []*Suppression
+	for _, sf := range s.files {
+		if sf == nil {
+			continue
+		}
+		for _, d := range sf.directives {
+			if d.Analyzer == analyzer && !used[d] {
+				unused = append(unused, d)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, d := range unused {
+		out = append(out, unusedSuppressionFinding(d))
+	}
+	res.Unused = len(unused)
+	return out, res
+}
+
+// match returns the directive that suppresses f, or nil.
+func (s *Suppressor) match(f *Finding) *Suppression {
+	if f.FilePath == "" || f.Category == CategoryUnusedSuppression {
+		return nil
+	}
+	sf := s.file(f.FilePath)
+	if sf == nil || len(sf.directives) == 0 {
+		return nil
+	}
+	for _, d := range sf.directives {
+		if d.matches(f) && sf.covers(d.Line, f.Line) {
+			return d
+		}
+	}
+	return nil
+}
+
+// covers reports whether a directive on dirLine applies to a finding starting
+// at findingLine: the same line, or a directive inside the contiguous
+// comment/blank block immediately above it. File-level findings (line <= 1)
+// accept any directive in the leading header block.
+func (sf *suppressedFile) covers(dirLine, findingLine int) bool {
+	if dirLine == findingLine {
+		return true
+	}
+	if findingLine <= 1 {
+		for i := 0; i < dirLine-1 && i < len(sf.comment); i++ {
+			if !sf.comment[i] {
+				return false
+			}
+		}
+		return true
+	}
+	if dirLine > findingLine {
+		return false
+	}
+	// Every line from the directive up to (not including) the finding must
+	// be comment or blank; otherwise the directive belongs to earlier code.
+	for l := dirLine; l < findingLine; l++ {
+		if l-1 >= len(sf.comment) || !sf.comment[l-1] {
+			return false
+		}
+	}
+	return true
+}
+
+// file returns the cached directive set for relPath, parsing it on first use.
+func (s *Suppressor) file(relPath string) *suppressedFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sf, ok := s.files[relPath]; ok {
+		return sf
+	}
+	abs := relPath
+	if !filepath.IsAbs(abs) && s.cfg.ProjectRoot != "" {
+		abs = filepath.Join(s.cfg.ProjectRoot, relPath)
+	}
+	info, err := os.Stat(abs)
+	if err != nil && abs != relPath {
+		abs = relPath
+		info, err = os.Stat(abs)
+	}
+	if err != nil {
+		s.files[relPath] = nil
+		return nil
+	}
+	if sf, ok := s.cfg.Cache.lookup(relPath, info); ok {
+		s.files[relPath] = sf
+		return sf
+	}
+	content, err := os.ReadFile(abs)
+	if err != nil {
+		s.files[relPath] = nil
+		return nil
+	}
+	var sf *suppressedFile
+	if bytes.Contains(content, []byte(SuppressionDirective)) {
+		sf = parseSuppressions(relPath, content)
+	}
+	s.cfg.Cache.store(relPath, info, sf)
+	s.files[relPath] = sf
+	return sf
+}
+
+// parseSuppressions extracts aide:ignore directives from comment text using
+// the language pack's comment delimiters, and records which lines are
+// comment-only so block directives can be attached to the following code.
+func parseSuppressions(relPath string, content []byte) *suppressedFile {
+	lang := code.DetectLanguage(relPath, content)
+	sf := &suppressedFile{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		raw := scanner.Text()
+		trimmed := strings.TrimSpace(raw)
+		sf.comment = append(sf.comment, trimmed == "" || grammar.IsCommentLine(trimmed, lang))
+
+		if !strings.Contains(raw, SuppressionDirective) {
+			continue
+		}
+		text := grammar.ExtractCommentText(raw, lang)
+		if text == "" {
+			continue
+		}
+		m := suppressionLine.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		sf.directives = append(sf.directives, &Suppression{
+			Analyzer: m[1],
+			Rule:     m[2],
+			Reason:   strings.TrimSpace(m[3]),
+			FilePath: relPath,
+			Line:     lineNum,
+		})
+	}
+	return sf
+}
+
+// unusedSuppressionFinding reports a directive that silenced nothing.
+func unusedSuppressionFinding(d *Suppression) *Finding {
+	detail := fmt.Sprintf("`%s %s` at %s:%d did not match any %s finding in the latest run. "+
+		"The underlying issue may have been fixed or the code moved; remove the directive "+
+		"or move it next to the line it is meant to cover.",
+		SuppressionDirective, d.Target(), d.FilePath, d.Line, d.Analyzer)
+	meta := map[string]string{
+		"target": d.Target(),
+	}
+	if d.Rule != "" {
+		meta["rule"] = d.Rule
+	}
+	if d.Reason != "" {
+		meta["reason"] = d.Reason
+	}
+	return &Finding{
+		Analyzer: d.Analyzer,
+		Severity: SevInfo,
+		Category: CategoryUnusedSuppression,
+		FilePath: d.FilePath,
+		Line:     d.Line,
+		Title:    fmt.Sprintf("Unused suppression: %s %s", SuppressionDirective, d.Target()),
+		Detail:   detail,
+		Metadata: meta,
+	}
+}
+
+// SuppressDeadCode applies aide:ignore directives to deadcode output. The
+// deadcode analyzer covers the whole code index rather than a path list, so
+// the directive scan walks the project root with its .aideignore rules.
+func SuppressDeadCode(ff []*Finding, projectRoot string, showSuppressed bool) []*Finding {
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: projectRoot, ShowSuppressed: showSuppressed})
+	if projectRoot != "" {
+		ignore, _ := aideignore.New(projectRoot)
+		if err := sup.Walk([]string{projectRoot}, ignore); err != nil {
+			runnerLog.Printf("deadcode: suppression scan failed: %v", err)
+		}
+	}
+	out, _ := sup.Apply(AnalyzerDeadCode, ff)
+	return out
+}
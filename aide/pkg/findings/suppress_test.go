@@ -0,0 +1,249 @@
+package findings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	content := []byte(`package main
+
+// aide:ignore complexity generated parser
+func parse() {}
+
+var token = "x" // aide:ignore secrets:np.generic.1 test fixture
+`)
+	sf := parseSuppressions("main.go", content)
+	if len(sf.directives) != 2 {
+		t.Fatalf("expected 2 directives, got %d", len(sf.directives))
+	}
+
+	d := sf.directives[0]
+	if d.Analyzer != AnalyzerComplexity || d.Rule != "" || d.Line != 3 {
+		t.Errorf("unexpected first directive: %+v", d)
+	}
+	if d.Reason != "generated parser" {
+		t.Errorf("expected reason 'generated parser', got %q", d.Reason)
+	}
+
+	d = sf.directives[1]
+	if d.Analyzer != AnalyzerSecrets || d.Rule != "np.generic.1" || d.Line != 6 {
+		t.Errorf("unexpected second directive: %+v", d)
+	}
+	if d.Target() != "secrets:np.generic.1" {
+		t.Errorf("expected target secrets:np.generic.1, got %q", d.Target())
+	}
+}
+
+func TestSuppressorApply(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.go": `package main
+
+// aide:ignore complexity generated parser
+// more context here
+func parse() {}
+
+func other() {}
+
+var token = "x" // aide:ignore secrets test fixture
+`})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir})
+	ff := []*Finding{
+		{Analyzer: AnalyzerComplexity, FilePath: "main.go", Line: 5, Title: "parse"},
+		{Analyzer: AnalyzerComplexity, FilePath: "main.go", Line: 7, Title: "other"},
+	}
+	out, res := sup.Apply(AnalyzerComplexity, ff)
+	if res.Suppressed != 1 || res.Unused != 0 {
+		t.Errorf("expected 1 suppressed, 0 unused, got %+v", res)
+	}
+	if len(out) != 1 || out[0].Title != "other" {
+		t.Fatalf("expected only 'other' to remain, got %v", out)
+	}
+
+	out, res = sup.Apply(AnalyzerSecrets, []*Finding{
+		{Analyzer: AnalyzerSecrets, FilePath: "main.go", Line: 9, Metadata: map[string]string{"rule_id": "np.generic.1"}},
+	})
+	if res.Suppressed != 1 || len(out) != 0 {
+		t.Errorf("expected trailing directive to suppress secret, got %+v / %d", res, len(out))
+	}
+}
+
+func TestSuppressorRuleNarrowing(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"db.go": `package db
+
+func query() {
+	// aide:ignore security:go-sql-concat constants only
+	run("SELECT " + table)
+}
+`})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir})
+	ff := []*Finding{
+		{Analyzer: AnalyzerSecurity, FilePath: "db.go", Line: 5, Metadata: map[string]string{"rule_id": "go-sql-concat"}},
+		{Analyzer: AnalyzerSecurity, FilePath: "db.go", Line: 5, Metadata: map[string]string{"rule_id": "go-exec"}},
+	}
+	out, res := sup.Apply(AnalyzerSecurity, ff)
+	if res.Suppressed != 1 {
+		t.Errorf("expected 1 suppressed, got %d", res.Suppressed)
+	}
+	if len(out) != 1 || out[0].Metadata["rule_id"] != "go-exec" {
+		t.Errorf("expected go-exec finding to remain, got %v", out)
+	}
+}
+
+func TestSuppressorShowSuppressed(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.go": "package a\n\nfunc f() {} // aide:ignore complexity intentional\n"})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir, ShowSuppressed: true})
+	out, res := sup.Apply(AnalyzerComplexity, []*Finding{
+		{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 3},
+	})
+	if res.Suppressed != 1 || len(out) != 1 {
+		t.Fatalf("expected suppressed finding to be kept, got %+v / %d", res, len(out))
+	}
+	if out[0].Metadata[MetaSuppressed] != "true" {
+		t.Error("expected suppressed metadata")
+	}
+	if out[0].Metadata[MetaSuppressionReason] != "intentional" {
+		t.Errorf("expected reason 'intentional', got %q", out[0].Metadata[MetaSuppressionReason])
+	}
+	if out[0].Metadata[MetaSuppressionLine] != "3" {
+		t.Errorf("expected suppression line 3, got %q", out[0].Metadata[MetaSuppressionLine])
+	}
+}
+
+func TestSuppressorUnusedDirective(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.go": `package a
+
+// aide:ignore complexity no longer needed
+func f() {}
+
+x := 1
+
+func g() {}
+`})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir})
+	// Finding on g() is separated from the directive by code, so not covered.
+	out, res := sup.Apply(AnalyzerComplexity, []*Finding{
+		{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 8, Title: "g"},
+	})
+	if res.Suppressed != 0 || res.Unused != 1 {
+		t.Fatalf("expected 0 suppressed, 1 unused, got %+v", res)
+	}
+	if len(out) != 2 {
+		t.Fatalf("expected original + unused finding, got %d", len(out))
+	}
+	u := out[1]
+	if u.Category != CategoryUnusedSuppression || u.Severity != SevInfo || u.Line != 3 {
+		t.Errorf("unexpected unused-suppression finding: %+v", u)
+	}
+	if u.Analyzer != AnalyzerComplexity {
+		t.Errorf("expected unused finding under complexity, got %s", u.Analyzer)
+	}
+
+	// Directives for other analyzers must not be reported.
+	_, res = sup.Apply(AnalyzerSecrets, nil)
+	if res.Unused != 0 {
+		t.Errorf("expected no unused secrets directives, got %d", res.Unused)
+	}
+}
+
+func TestSuppressorFileLevelHeader(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"hub.go": `// Package hub wires everything together.
+// aide:ignore coupling:fan-out composition root
+package hub
+`})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir})
+	out, res := sup.Apply(AnalyzerCoupling, []*Finding{
+		{Analyzer: AnalyzerCoupling, Category: "fan-out", FilePath: "hub.go", Line: 1},
+		{Analyzer: AnalyzerCoupling, Category: "fan-in", FilePath: "hub.go", Line: 1},
+	})
+	if res.Suppressed != 1 {
+		t.Errorf("expected 1 suppressed, got %d", res.Suppressed)
+	}
+	if len(out) != 1 || out[0].Category != "fan-in" {
+		t.Errorf("expected fan-in to remain, got %v", out)
+	}
+}
+
+func TestSuppressorWalk(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.go": "package a\n\n// aide:ignore clones copied on purpose\nfunc f() {}\n",
+		"b.go": "package a\n\nfunc g() {}\n",
+	})
+
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir})
+	if err := sup.Walk([]string{dir}, nil); err != nil {
+		t.Fatal(err)
+	}
+	out, res := sup.Apply(AnalyzerClones, nil)
+	if res.Unused != 1 || len(out) != 1 {
+		t.Fatalf("expected walked directive to be reported unused, got %+v", res)
+	}
+	if out[0].FilePath != "a.go" {
+		t.Errorf("expected relative path a.go, got %q", out[0].FilePath)
+	}
+}
+
+func TestSuppressionCacheRevalidatesByMtime(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"a.go": "package a\n\n// aide:ignore clones copied on purpose\nfunc f() {}\n"})
+	path := filepath.Join(dir, "a.go")
+	stamp := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(path, stamp, stamp); err != nil {
+		t.Fatal(err)
+	}
+	cache := NewSuppressionCache()
+	walk := func() SuppressionResult {
+		t.Helper()
+		sup := NewSuppressor(SuppressionConfig{ProjectRoot: dir, Cache: cache})
+		if err := sup.Walk([]string{dir}, nil); err != nil {
+			t.Fatal(err)
+		}
+		_, res := sup.Apply(AnalyzerClones, nil)
+		return res
+	}
+	if res := walk(); res.Unused != 1 {
+		t.Fatalf("first walk: %+v, want 1 unused", res)
+	}
+
+	// Same mtime and size: the cached parse is used and the file not re-read.
+	writeFiles(t, dir, map[string]string{"a.go": "package a\n\n// aide:ignore clonez copied on purpose\nfunc f() {}\n"})
+	if err := os.Chtimes(path, stamp, stamp); err != nil {
+		t.Fatal(err)
+	}
+	if res := walk(); res.Unused != 1 {
+		t.Fatalf("unchanged stamp: %+v, want the cached directive", res)
+	}
+
+	// A new mtime invalidates the entry.
+	later := stamp.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if res := walk(); res.Unused != 0 {
+		t.Fatalf("touched file: %+v, want the re-parsed directive, which no longer targets clones", res)
+	}
+}
+
+func TestSuppressorMissingFile(t *testing.T) {
+	sup := NewSuppressor(SuppressionConfig{ProjectRoot: t.TempDir()})
+	// Findings for files no longer on disk (deleted since the run) must pass
+	// through untouched.
+	out, res := sup.Apply(AnalyzerCoupling, []*Finding{
+		{Analyzer: AnalyzerCoupling, FilePath: "gone.go", Title: "High fan-out"},
+	})
+	if len(out) != 1 || res.Suppressed != 0 || res.Unused != 0 {
+		t.Errorf("expected finding kept with nothing suppressed, got %d findings, %+v", len(out), res)
+	}
+}
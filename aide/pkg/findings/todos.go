@@ -162,8 +162,9 @@ func analyzeFileTodos(filePath string, content []byte) []*Finding {
 		}
 
 		meta := map[string]string{
-			"keyword":  keyword,
-			"language": lang,
+			"keyword":     keyword,
+			"language":    lang,
+			MetaMatchHash: MatchHash(raw), // titles are truncated, and a bare TODO has none
 		}
 		if owner != "" {
 			meta["owner"] = owner
@@ -184,6 +185,7 @@ func analyzeFileTodos(filePath string, content []byte) []*Finding {
 			CreatedAt: time.Now(),
 		})
 	}
+	numberOccurrences(ff)
 	return ff
 }
 
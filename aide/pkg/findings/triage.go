@@ -0,0 +1,246 @@
+package findings
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TriageState is the lifecycle state of a finding as tracked by the triage
+// workflow. States are keyed by finding fingerprint, not ID, so they survive
+// the delete-and-replace cycle every analyser run performs.
+type TriageState string
+
+const (
+	TriageOpen         TriageState = "open"
+	TriageAcknowledged TriageState = "acknowledged"
+	TriageSnoozed      TriageState = "snoozed"
+	TriageWontFix      TriageState = "wont-fix"
+	TriageFixed        TriageState = "fixed"
+)
+
+// TriageStates lists every valid state in lifecycle order.
+var TriageStates = []TriageState{TriageOpen, TriageAcknowledged, TriageSnoozed, TriageWontFix, TriageFixed}
+
+// ParseTriageState validates s and returns the matching state. "wontfix" and
+// "wont_fix" are accepted as aliases for "wont-fix".
+func ParseTriageState(s string) (TriageState, error) {
+	norm := strings.ToLower(strings.TrimSpace(s))
+	switch norm {
+	case "wontfix", "wont_fix", "won't-fix", "won't fix":
+		norm = string(TriageWontFix)
+	}
+	for _, st := range TriageStates {
+		if string(st) == norm {
+			return st, nil
+		}
+	}
+	return "", fmt.Errorf("unknown triage state %q (valid: open, acknowledged, snoozed, wont-fix, fixed)", s)
+}
+
+// triageTransitions is the allowed-transition table. Acknowledged and snoozed
+// may transition to themselves (to reassign or extend a snooze); closed states
+// (wont-fix, fixed) can only be reopened.
+var triageTransitions = map[TriageState][]TriageState{
+	TriageOpen:         {TriageAcknowledged, TriageSnoozed, TriageWontFix, TriageFixed},
+	TriageAcknowledged: {TriageOpen, TriageAcknowledged, TriageSnoozed, TriageWontFix, TriageFixed},
+	TriageSnoozed:      {TriageOpen, TriageAcknowledged, TriageSnoozed, TriageWontFix, TriageFixed},
+	TriageWontFix:      {TriageOpen},
+	TriageFixed:        {TriageOpen},
+}
+
+// ValidateTransition reports whether a finding may move from one state to
+// another.
+func ValidateTransition(from, to TriageState) error {
+	for _, allowed := range triageTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid triage transition %s -> %s", from, to)
+}
+
+// TriageEvent is one entry in a fingerprint's append-only triage history.
+type TriageEvent struct {
+	ID          string      `json:"id"` // ULID
+	Fingerprint string      `json:"fingerprint"`
+	FindingID   string      `json:"findingId,omitempty"` // Finding ID at the time of triage (informational)
+	From        TriageState `json:"from"`
+	To          TriageState `json:"to"`
+	Reason      string      `json:"reason,omitempty"`
+	Actor       string      `json:"actor,omitempty"`    // Who made the change (user, agent name)
+	Assignee    string      `json:"assignee,omitempty"` // Who is handling the finding
+	Until       time.Time   `json:"until,omitzero"`     // Snooze expiry (snoozed only)
+
+	// Snapshot of the finding, so history stays readable after the finding
+	// itself is gone (fixed findings are removed on the next run).
+	Analyzer string `json:"analyzer,omitempty"`
+	FilePath string `json:"file,omitempty"`
+	Title    string `json:"title,omitempty"`
+
+	// Attribution for transitions inferred by re-analysis: HEAD at detection
+	// time and the most recently active session.
+	Commit    string `json:"commit,omitempty"`
+	SessionID string `json:"session,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Describe fills the finding snapshot fields from f.
+func (e *TriageEvent) Describe(f *Finding) {
+	e.FindingID = f.ID
+	e.Analyzer = f.Analyzer
+	e.FilePath = f.FilePath
+	e.Title = f.Title
+}
+
+// Validate checks the event is well-formed on its own: known target state and
+// a future expiry for snoozes. The transition itself is checked against the
+// current state by the store.
+func (e *TriageEvent) Validate(now time.Time) error {
+	if e.Fingerprint == "" {
+		return fmt.Errorf("triage event requires a fingerprint")
+	}
+	if _, err := ParseTriageState(string(e.To)); err != nil {
+		return err
+	}
+	if e.To == TriageSnoozed {
+		if e.Until.IsZero() {
+			return fmt.Errorf("snoozed requires an expiry")
+		}
+		if !e.Until.After(now) {
+			return fmt.Errorf("snooze expiry %s is in the past", e.Until.Format(time.RFC3339))
+		}
+	} else if !e.Until.IsZero() {
+		return fmt.Errorf("expiry is only valid for snoozed")
+	}
+	return nil
+}
+
+// TriageStatus is the current triage position of a fingerprint, folded from
+// its history.
+type TriageStatus struct {
+	Fingerprint string      `json:"fingerprint"`
+	State       TriageState `json:"state"`
+	Reason      string      `json:"reason,omitempty"`
+	Actor       string      `json:"actor,omitempty"`
+	Assignee    string      `json:"assignee,omitempty"`
+	Until       time.Time   `json:"until,omitzero"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+// Effective returns the state as of now: an expired snooze reads as open.
+func (s *TriageStatus) Effective(now time.Time) TriageState {
+	if s == nil {
+		return TriageOpen
+	}
+	if s.State == TriageSnoozed && !s.Until.After(now) {
+		return TriageOpen
+	}
+	return s.State
+}
+
+// Apply folds an event into the status.
+func (s *TriageStatus) Apply(e *TriageEvent) {
+	s.Fingerprint = e.Fingerprint
+	s.State = e.To
+	s.Reason = e.Reason
+	s.Actor = e.Actor
+	s.Until = e.Until
+	s.UpdatedAt = e.CreatedAt
+	switch e.To {
+	case TriageOpen:
+		s.Assignee = ""
+	default:
+		if e.Assignee != "" {
+			s.Assignee = e.Assignee
+		}
+	}
+}
+
+// Hidden reports whether findings in state st are excluded from default
+// listings. Acknowledged findings stay visible so others can see they are
+// being handled.
+func (st TriageState) Hidden() bool {
+	return st == TriageSnoozed || st == TriageWontFix || st == TriageFixed
+}
+
+// fingerprintDigits matches digit runs in titles ("complexity 17", "3 files"),
+// which change as code evolves without the finding changing identity.
+var fingerprintDigits = regexp.MustCompile(`\d+`)
+
+// MetaMatchHash is the metadata key for a short hash of the text a
+// pattern-based finding matched. Security, secrets and custom rule titles
+// are just the rule name, so without it every match of one rule in one file
+// would share a fingerprint.
+const MetaMatchHash = "match_hash"
+
+// MatchHash hashes matched text for MetaMatchHash. Whitespace runs are
+// collapsed so reindenting a line keeps the finding's identity.
+func MatchHash(text string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// MetaOccurrence is the metadata key numbering findings that would still
+// share a fingerprint because one rule matched identical text on several
+// lines of a file. The first match has none; later ones count up from 1 in
+// line order.
+const MetaOccurrence = "occurrence"
+
+// numberOccurrences sets MetaOccurrence on one file's findings so identical
+// matches are triaged separately. The first match keeps the fingerprint it
+// would have alone; deleting an earlier duplicate shifts the later ones.
+func numberOccurrences(ff []*Finding) {
+	if len(ff) < 2 {
+		return
+	}
+	sorted := slices.Clone(ff)
+	slices.SortStableFunc(sorted, func(a, b *Finding) int { return a.Line - b.Line })
+	seen := make(map[string]int, len(sorted))
+	for _, f := range sorted {
+		delete(f.Metadata, MetaOccurrence)
+		fp := f.Fingerprint()
+		n := seen[fp]
+		seen[fp] = n + 1
+		if n == 0 {
+			continue
+		}
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string)
+		}
+		f.Metadata[MetaOccurrence] = strconv.Itoa(n)
+	}
+}
+
+// Fingerprint returns a stable identity for the finding that survives
+// re-analysis: analyzer, category, file, rule ID, title with numbers
+// normalised and, for pattern matches, the matched text's hash and
+// occurrence. Line numbers are deliberately excluded so edits elsewhere in
+// the file do not orphan triage state.
+func (f *Finding) Fingerprint() string {
+	h := sha256.New()
+	parts := []string{
+		f.Analyzer,
+		f.Category,
+		f.FilePath,
+		f.Metadata["rule_id"],
+		fingerprintDigits.ReplaceAllString(f.Title, "#"),
+	}
+	if mh := f.Metadata[MetaMatchHash]; mh != "" {
+		parts = append(parts, mh)
+	}
+	if n := f.Metadata[MetaOccurrence]; n != "" {
+		parts = append(parts, n)
+	}
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
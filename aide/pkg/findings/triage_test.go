@@ -0,0 +1,158 @@
+package findings
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTriageState(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    TriageState
+		wantErr bool
+	}{
+		{"open", TriageOpen, false},
+		{"Acknowledged", TriageAcknowledged, false},
+		{"wontfix", TriageWontFix, false},
+		{"wont_fix", TriageWontFix, false},
+		{"closed", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTriageState(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTriageState(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTriageState(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to TriageState
+		ok       bool
+	}{
+		{TriageOpen, TriageAcknowledged, true},
+		{TriageOpen, TriageOpen, false},
+		{TriageAcknowledged, TriageAcknowledged, true},
+		{TriageSnoozed, TriageSnoozed, true},
+		{TriageWontFix, TriageOpen, true},
+		{TriageWontFix, TriageFixed, false},
+		{TriageFixed, TriageAcknowledged, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if err := ValidateTransition(tt.from, tt.to); (err == nil) != tt.ok {
+				t.Errorf("ValidateTransition(%s, %s) = %v, want ok=%v", tt.from, tt.to, err, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTriageStatusApply(t *testing.T) {
+	now := time.Now()
+	st := &TriageStatus{}
+	st.Apply(&TriageEvent{Fingerprint: "fp", To: TriageAcknowledged, Assignee: "bob", CreatedAt: now})
+	st.Apply(&TriageEvent{Fingerprint: "fp", To: TriageSnoozed, Until: now.Add(time.Hour), CreatedAt: now})
+	if st.Assignee != "bob" {
+		t.Errorf("expected assignee to carry across snooze, got %q", st.Assignee)
+	}
+	if got := st.Effective(now); got != TriageSnoozed {
+		t.Errorf("expected snoozed, got %s", got)
+	}
+	if got := st.Effective(now.Add(2 * time.Hour)); got != TriageOpen {
+		t.Errorf("expected expired snooze to read as open, got %s", got)
+	}
+	st.Apply(&TriageEvent{Fingerprint: "fp", To: TriageOpen, CreatedAt: now})
+	if st.Assignee != "" {
+		t.Errorf("expected reopen to clear assignee, got %q", st.Assignee)
+	}
+}
+
+func TestFindingFingerprint(t *testing.T) {
+	a := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 10, Title: "f has complexity 20"}
+	b := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Line: 42, Title: "f has complexity 23"}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Error("fingerprint should ignore line numbers and numeric title parts")
+	}
+	c := &Finding{Analyzer: AnalyzerComplexity, FilePath: "a.go", Title: "g has complexity 20"}
+	if a.Fingerprint() == c.Fingerprint() {
+		t.Error("different functions should not share a fingerprint")
+	}
+}
+
+// TestFingerprintSeparatesSameRuleMatches guards against triage on one match
+// of a rule hiding every other match of that rule in the same file.
+func TestFingerprintSeparatesSameRuleMatches(t *testing.T) {
+	src := "package main\n\nimport \"crypto/md5\"\n\nfunc a(b []byte) { md5.Sum(b) }\n\nfunc c(d []byte) { md5.Sum(d) }\n"
+	ff := analyzeFileSecurity(context.Background(), "main.go", []byte(src))
+	var md5 []*Finding
+	for _, f := range ff {
+		if f.Metadata["rule_id"] == "go-weak-crypto-md5" {
+			md5 = append(md5, f)
+		}
+	}
+	if len(md5) != 2 {
+		t.Fatalf("got %d md5 findings, want 2", len(md5))
+	}
+	if md5[0].Fingerprint() == md5[1].Fingerprint() {
+		t.Error("two matches of one rule in one file share a fingerprint")
+	}
+
+	// Moving the matches down the file (or reindenting them) keeps their
+	// identities.
+	moved := analyzeFileSecurity(context.Background(), "main.go", []byte("// header\n"+strings.ReplaceAll(src, "func ", "\tfunc  ")))
+	kept := 0
+	for _, f := range moved {
+		if f.Fingerprint() == md5[0].Fingerprint() || f.Fingerprint() == md5[1].Fingerprint() {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("%d of 2 fingerprints survived moving the matches", kept)
+	}
+}
+
+// TestFingerprintSeparatesIdenticalMatches covers one rule matching the same
+// text on several lines: each match is triaged on its own, and the first
+// keeps the fingerprint it has when it is the only one.
+func TestFingerprintSeparatesIdenticalMatches(t *testing.T) {
+	line := "func a(b []byte) { md5.Sum(b) }\n"
+	md5Prints := func(src string) []string {
+		var fps []string
+		for _, f := range analyzeFileSecurity(context.Background(), "main.go", []byte(src)) {
+			if f.Metadata["rule_id"] == "go-weak-crypto-md5" {
+				fps = append(fps, f.Fingerprint())
+			}
+		}
+		return fps
+	}
+
+	single := md5Prints("package main\n\n" + line)
+	dup := md5Prints("package main\n\n" + line + "\n" + line)
+	if len(single) != 1 || len(dup) != 2 {
+		t.Fatalf("got %d and %d md5 findings, want 1 and 2", len(single), len(dup))
+	}
+	if dup[0] == dup[1] {
+		t.Error("identical matches in one file share a fingerprint")
+	}
+	if dup[0] != single[0] {
+		t.Error("first identical match should keep its lone fingerprint")
+	}
+
+	// Re-analysing the same content is stable.
+	again := md5Prints("// header\npackage main\n\n" + line + "\n" + line)
+	if len(again) != 2 || again[0] != dup[0] || again[1] != dup[1] {
+		t.Errorf("fingerprints changed on re-analysis: %v vs %v", again, dup)
+	}
+
+	todos := analyzeFileTodos("a.go", []byte("// TODO: fix\nx := 1\n// TODO: fix\n"))
+	if len(todos) != 2 || todos[0].Fingerprint() == todos[1].Fingerprint() {
+		t.Error("identical TODOs in one file share a fingerprint")
+	}
+}
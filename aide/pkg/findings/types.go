@@ -1,7 +1,10 @@
 // Package findings defines types for static analysis findings.
 package findings
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 // Severity levels for findings.
 const (
@@ -34,8 +37,21 @@ const (
 	AnalyzerSecurity   = "security"
 	AnalyzerDeadCode   = "deadcode"
 	AnalyzerTodos      = "todos"
+	AnalyzerCustom     = "custom"  // User-defined rules from .aide/rules
+	AnalyzerVulns      = "vulns"   // Dependencies matched against offline OSV advisories
+	AnalyzerLicense    = "license" // Surveyed licenses evaluated against the allow/deny policy
 )
 
+// PerFileAnalyzers are the analyzers whose findings depend only on the file
+// they are reported in, so a run can be limited to changed files and their
+// stored findings replaced file by file.
+var PerFileAnalyzers = []string{AnalyzerComplexity, AnalyzerSecrets, AnalyzerSecurity, AnalyzerTodos, AnalyzerCustom}
+
+// IsPerFileAnalyzer reports whether analyzer is one of PerFileAnalyzers.
+func IsPerFileAnalyzer(analyzer string) bool {
+	return slices.Contains(PerFileAnalyzers, analyzer)
+}
+
 // Finding represents a single static analysis finding.
 type Finding struct {
 	ID        string            `json:"id"`                 // ULID
@@ -58,8 +74,9 @@ type SearchOptions struct {
 	Severity        string // Filter by severity
 	FilePath        string // Filter by file path pattern (substring)
 	Category        string // Filter by category
+	State           string // Filter by effective triage state (see TriageState)
 	Limit           int    // Max results (0 = default)
-	IncludeAccepted bool   // Include accepted findings (default: hide them)
+	IncludeAccepted bool   // Include accepted, snoozed, wont-fix and fixed findings (default: hide them)
 }
 
 // Stats holds aggregate counts of findings.
@@ -67,6 +84,7 @@ type Stats struct {
 	Total      int            `json:"total"`
 	ByAnalyzer map[string]int `json:"byAnalyzer"`
 	BySeverity map[string]int `json:"bySeverity"`
+	ByRule     map[string]int `json:"byRule,omitempty"` // Custom analyzer findings per rule ID
 }
 
 // SearchResult pairs a finding with its search relevance score.
@@ -0,0 +1,386 @@
+package findings
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/observe"
+)
+
+// Reachability of a vulnerable Go dependency, from the code index.
+const (
+	ReachReachable   = "reachable"    // a vulnerable symbol is referenced
+	ReachImported    = "imported"     // a vulnerable package is imported, its vulnerable symbols are not referenced
+	ReachNotImported = "not-imported" // no vulnerable package is imported
+	ReachUnknown     = "unknown"      // no code index, or the advisory names no packages
+)
+
+// maxVulnCallSites caps the call sites recorded per finding.
+const maxVulnCallSites = 10
+
+// VulnDependency is a dependency to audit, as recorded by the survey
+// dependencies analyser.
+type VulnDependency struct {
+	Ecosystem string // survey ecosystem: go, npm, cargo, pypi, maven, nuget
+	Name      string
+	Version   string // resolved version; ranges and empty versions are skipped
+	Manifest  string // project-relative manifest or lockfile declaring it
+	Direct    bool
+}
+
+// VulnsConfig holds configuration for the offline vulnerability audit.
+type VulnsConfig struct {
+	ProjectRoot  string
+	AdvisoryDir  string // default AdvisoriesPath(ProjectRoot)
+	Dependencies []VulnDependency
+	// References returns indexed references by symbol name and kind ("" =
+	// any). Nil means no code index: Go findings are not narrowed to
+	// reachable symbols.
+	References func(symbol, kind string) ([]*code.Reference, error)
+}
+
+// VulnsResult holds summary statistics from a vulnerability audit.
+type VulnsResult struct {
+	AdvisoriesRead int
+	Audited        int // dependencies with a comparable version
+	Unversioned    int // dependencies skipped for lack of a concrete version
+	FindingsCount  int
+	Reachable      int // Go findings with a referenced vulnerable symbol
+	Duration       time.Duration
+}
+
+// AnalyzeVulns matches dependency versions against the OSV advisories in
+// the offline advisory directory and emits one finding per vulnerable
+// dependency and advisory, reported at the dependency's line in its
+// manifest. Go findings are narrowed by reachability: a finding whose
+// vulnerable symbols are never referenced drops to info.
+func AnalyzeVulns(cfg VulnsConfig) ([]*Finding, *VulnsResult, error) {
+	span := observe.Start("AnalyzeVulns", observe.KindSpan).Category("analyzer").Subtype("vulns")
+	defer span.End()
+	start := time.Now()
+	dir := cfg.AdvisoryDir
+	if dir == "" {
+		dir = AdvisoriesPath(cfg.ProjectRoot)
+	}
+
+	result := &VulnsResult{}
+	wanted := make(map[string]bool)
+	var deps []VulnDependency
+	for _, d := range cfg.Dependencies {
+		eco, ok := osvEcosystems[d.Ecosystem]
+		if !ok {
+			continue
+		}
+		if !concreteVersion(d.Version) {
+			result.Unversioned++
+			continue
+		}
+		deps = append(deps, d)
+		wanted[advisoryKey(eco, d.Name)] = true
+	}
+	result.Audited = len(deps)
+
+	index, read, err := LoadAdvisories(dir, func(key string) bool { return wanted[key] })
+	if err != nil {
+		return nil, nil, err
+	}
+	result.AdvisoriesRead = read
+
+	lines := &manifestLines{root: cfg.ProjectRoot, cache: make(map[string][]string)}
+	var out []*Finding
+	for _, d := range deps {
+		eco := osvEcosystems[d.Ecosystem]
+		key := advisoryKey(eco, d.Name)
+		for _, adv := range index[key] {
+			hit, fixed, imports := false, []string(nil), map[string][]string{}
+			for i := range adv.Affected {
+				a := &adv.Affected[i]
+				if advisoryKey(a.Package.Ecosystem, a.Package.Name) != key {
+					continue
+				}
+				in, fix := a.affects(d.Version)
+				if !in {
+					continue
+				}
+				hit = true
+				fixed = append(fixed, fix...)
+				for _, imp := range a.EcosystemSpecific.Imports {
+					imports[imp.Path] = append(imports[imp.Path], imp.Symbols...)
+				}
+			}
+			if !hit {
+				continue
+			}
+			f := vulnFinding(d, adv, sortVersions(dedupeSorted(fixed)), lines)
+			if d.Ecosystem == "go" {
+				applyGoReachability(f, imports, cfg.References)
+				if f.Metadata["reachability"] == ReachReachable {
+					result.Reachable++
+				}
+			}
+			out = append(out, f)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if ri, rj := SeverityRank(out[i].Severity), SeverityRank(out[j].Severity); ri != rj {
+			return ri > rj
+		}
+		return out[i].FilePath < out[j].FilePath
+	})
+	result.FindingsCount = len(out)
+	result.Duration = time.Since(start)
+	return out, result, nil
+}
+
+// concreteVersion reports whether v is a single version rather than a
+// range or constraint a lockfile never resolved.
+func concreteVersion(v string) bool {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if v == "" || v[0] < '0' || v[0] > '9' {
+		return false
+	}
+	return !strings.ContainsAny(v, "^~<>=*|, []()")
+}
+
+func vulnFinding(d VulnDependency, adv *Advisory, fixed []string, lines *manifestLines) *Finding {
+	cve, ghsa := "", ""
+	for _, id := range append([]string{adv.ID}, adv.Aliases...) {
+		switch {
+		case cve == "" && strings.HasPrefix(id, "CVE-"):
+			cve = id
+		case ghsa == "" && strings.HasPrefix(id, "GHSA-"):
+			ghsa = id
+		}
+	}
+	label := adv.ID
+	if cve != "" && cve != adv.ID {
+		label += " (" + cve + ")"
+	}
+	summary := adv.Summary
+	if summary == "" {
+		summary = firstSentence(adv.Details)
+	}
+	title := fmt.Sprintf("%s: %s %s is vulnerable", label, d.Name, d.Version)
+	if summary != "" {
+		title += " — " + summary
+	}
+
+	var detail strings.Builder
+	if adv.Details != "" {
+		detail.WriteString(strings.TrimSpace(adv.Details))
+		detail.WriteString("\n\n")
+	}
+	kind := "Direct"
+	if !d.Direct {
+		kind = "Transitive"
+	}
+	fmt.Fprintf(&detail, "%s %s dependency %s@%s, declared in %s.", kind, d.Ecosystem, d.Name, d.Version, d.Manifest)
+	if len(fixed) > 0 {
+		fmt.Fprintf(&detail, " Fixed in %s — upgrade to %s or later.", strings.Join(fixed, ", "), fixed[0])
+	} else {
+		detail.WriteString(" No fixed version is published.")
+	}
+
+	meta := map[string]string{
+		"rule_id":   adv.ID,
+		"advisory":  adv.ID,
+		"ecosystem": d.Ecosystem,
+		"package":   d.Name,
+		"version":   d.Version,
+		"direct":    fmt.Sprint(d.Direct),
+	}
+	if len(adv.Aliases) > 0 {
+		meta["aliases"] = strings.Join(adv.Aliases, ",")
+	}
+	if cve != "" {
+		meta["cve"] = cve
+	}
+	if ghsa != "" {
+		meta["ghsa"] = ghsa
+	}
+	if len(fixed) > 0 {
+		meta["fixed"] = fixed[0]
+		meta["fixed_versions"] = strings.Join(fixed, ",")
+	}
+	return &Finding{
+		Analyzer:  AnalyzerVulns,
+		Severity:  advisorySeverity(adv),
+		Category:  "vulnerable-dependency",
+		FilePath:  d.Manifest,
+		Line:      lines.find(d.Manifest, d.Name),
+		Title:     title,
+		Detail:    detail.String(),
+		Metadata:  meta,
+		CreatedAt: time.Now(),
+	}
+}
+
+// advisorySeverity maps the GHSA severity label; advisories without one
+// (Go vulndb, PYSEC) default to warning.
+func advisorySeverity(adv *Advisory) string {
+	switch strings.ToUpper(adv.DatabaseSpecific.Severity) {
+	case "CRITICAL", "HIGH":
+		return SevCritical
+	case "LOW":
+		return SevInfo
+	default:
+		return SevWarning
+	}
+}
+
+// applyGoReachability narrows a Go finding with the code index: files that
+// import a vulnerable package and reference one of its vulnerable symbols
+// make it reachable; otherwise the finding drops to info. Symbols are
+// matched by their final name component, so a same-named function from
+// another package in an importing file counts — reachability errs towards
+// reporting.
+func applyGoReachability(f *Finding, imports map[string][]string, refs func(symbol, kind string) ([]*code.Reference, error)) {
+	if refs == nil || len(imports) == 0 {
+		f.Metadata["reachability"] = ReachUnknown
+		return
+	}
+	paths := make([]string, 0, len(imports))
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var importers, sites, symbols []string
+	for _, p := range paths {
+		importRefs, err := refs(`"`+p+`"`, code.RefKindImport)
+		if err != nil {
+			continue
+		}
+		files := make(map[string]bool)
+		for _, r := range importRefs {
+			files[r.FilePath] = true
+		}
+		if len(files) == 0 {
+			continue
+		}
+		importers = append(importers, sortedSet(files)...)
+		syms := dedupeSorted(imports[p])
+		if len(syms) == 0 { // the whole package is vulnerable
+			symbols = append(symbols, p)
+			for _, r := range importRefs {
+				sites = append(sites, fmt.Sprintf("%s:%d", r.FilePath, r.Line))
+			}
+			continue
+		}
+		for _, sym := range syms {
+			name := sym[strings.LastIndexByte(sym, '.')+1:]
+			symRefs, err := refs(name, "")
+			if err != nil {
+				continue
+			}
+			used := false
+			for _, r := range symRefs {
+				if r.Kind != code.RefKindImport && files[r.FilePath] {
+					sites = append(sites, fmt.Sprintf("%s:%d", r.FilePath, r.Line))
+					used = true
+				}
+			}
+			if used {
+				symbols = append(symbols, p+"."+sym)
+			}
+		}
+	}
+
+	switch {
+	case len(sites) > 0:
+		f.Metadata["reachability"] = ReachReachable
+		f.Metadata["symbols"] = strings.Join(symbols, ",")
+		sites = dedupeSorted(sites)
+		f.Metadata["call_sites"] = strings.Join(capList(sites, maxVulnCallSites), ",")
+		f.Detail += fmt.Sprintf("\n\nReachable: %s referenced at %s.", strings.Join(symbols, ", "), strings.Join(capList(sites, 3), ", "))
+	case len(importers) > 0:
+		f.Metadata["reachability"] = ReachImported
+		f.Severity = SevInfo
+		f.Detail += fmt.Sprintf("\n\nThe vulnerable package is imported (%s) but none of its vulnerable symbols are referenced.",
+			strings.Join(capList(dedupeSorted(importers), 3), ", "))
+	default:
+		f.Metadata["reachability"] = ReachNotImported
+		f.Severity = SevInfo
+		f.Detail += "\n\nNo indexed file imports the vulnerable package."
+	}
+}
+
+// manifestLines finds the line declaring a dependency in its manifest, so a
+// finding lands where the fix is made.
+type manifestLines struct {
+	root  string
+	cache map[string][]string
+}
+
+func (m *manifestLines) find(rel, name string) int {
+	lines, ok := m.cache[rel]
+	if !ok {
+		if fh, err := os.Open(filepath.Join(m.root, filepath.FromSlash(rel))); err == nil {
+			sc := bufio.NewScanner(fh)
+			sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+			for sc.Scan() {
+				lines = append(lines, sc.Text())
+			}
+			fh.Close()
+		}
+		m.cache[rel] = lines
+	}
+	// Maven coordinates appear as separate groupId/artifactId elements.
+	needle := name[strings.LastIndexByte(name, ':')+1:]
+	for i, l := range lines {
+		if strings.Contains(l, needle) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// sortVersions orders versions lowest first.
+func sortVersions(v []string) []string {
+	sort.Slice(v, func(i, j int) bool { return compareVersions(v[i], v[j]) < 0 })
+	return v
+}
+
+func firstSentence(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexAny(s, ".\n"); i > 0 {
+		s = s[:i]
+	}
+	if len(s) > 120 {
+		s = s[:117] + "..."
+	}
+	return s
+}
+
+func dedupeSorted(s []string) []string {
+	set := make(map[string]bool, len(s))
+	for _, v := range s {
+		if v != "" {
+			set[v] = true
+		}
+	}
+	return sortedSet(set)
+}
+
+func sortedSet(set map[string]bool) []string {
+	out := make([]string, 0, len(set))
+	for v := range set {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func capList(s []string, n int) []string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,262 @@
+package findings
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+// vulnsFixture is a project with a Go module and an npm package, and an
+// advisory directory covering both.
+func vulnsFixture(t *testing.T) (string, []VulnDependency) {
+	t.Helper()
+	root := t.TempDir()
+	adv := ".aide/cache/advisories/"
+	writeFiles(t, root, map[string]string{
+		"go.mod": "module example.com/app\n\nrequire (\n\tgopkg.in/yaml.v3 v3.0.0\n\tgolang.org/x/text v0.3.7\n)\n",
+		"web/package.json": `{
+  "dependencies": {
+    "lodash": "^4.17.0"
+  }
+}`,
+		adv + "Go/GO-2022-0603.json": `{
+  "id": "GO-2022-0603", "aliases": ["CVE-2022-28948", "GHSA-hp87-p4gw-j4gq"],
+  "summary": "Panic in gopkg.in/yaml.v3",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "gopkg.in/yaml.v3"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "3.0.0-20220521103104-8f96da9f5d5e"}, {"introduced": "3.0.0"}, {"fixed": "3.0.1"}]}],
+    "ecosystem_specific": {"imports": [{"path": "gopkg.in/yaml.v3", "symbols": ["Unmarshal", "Decoder.Decode"]}]}
+  }]
+}`,
+		adv + "Go/GO-2022-1059.json": `{
+  "id": "GO-2022-1059", "aliases": ["CVE-2022-32149"],
+  "summary": "Denial of service via crafted Accept-Language header",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "golang.org/x/text"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "0.3.8"}]}],
+    "ecosystem_specific": {"imports": [{"path": "golang.org/x/text/language", "symbols": ["ParseAcceptLanguage"]}]}
+  }]
+}`,
+		adv + "npm/GHSA-jf85-cpcp-j695.json": `{
+  "id": "GHSA-jf85-cpcp-j695", "aliases": ["CVE-2019-10744"],
+  "summary": "Prototype Pollution in lodash",
+  "database_specific": {"severity": "CRITICAL"},
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "lodash"},
+    "ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}, {"fixed": "4.17.12"}]}]
+  }]
+}`,
+		adv + "npm/GHSA-withdrawn.json": `{
+  "id": "GHSA-xxxx-withdrawn", "withdrawn": "2023-01-01T00:00:00Z",
+  "affected": [{"package": {"ecosystem": "npm", "name": "lodash"}, "versions": ["4.17.11"]}]
+}`,
+		adv + "npm/GHSA-other.json": `{
+  "id": "GHSA-other", "affected": [{"package": {"ecosystem": "npm", "name": "left-pad"}, "versions": ["1.0.0"]}]
+}`,
+	})
+	deps := []VulnDependency{
+		{Ecosystem: "go", Name: "gopkg.in/yaml.v3", Version: "v3.0.0", Manifest: "go.mod", Direct: true},
+		{Ecosystem: "go", Name: "golang.org/x/text", Version: "v0.3.7", Manifest: "go.mod", Direct: true},
+		{Ecosystem: "npm", Name: "lodash", Version: "4.17.11", Manifest: "web/package.json", Direct: true},
+		{Ecosystem: "npm", Name: "left-pad", Version: "^1.0.0", Manifest: "web/package.json", Direct: true},
+	}
+	return root, deps
+}
+
+// fakeReferences serves a yaml.v3 import and Unmarshal call from cfg.go,
+// and an unrelated ParseAcceptLanguage call from a file that never imports
+// golang.org/x/text/language.
+func fakeReferences(symbol, kind string) ([]*code.Reference, error) {
+	all := []*code.Reference{
+		{SymbolName: `"gopkg.in/yaml.v3"`, Kind: code.RefKindImport, FilePath: "internal/cfg/cfg.go", Line: 4},
+		{SymbolName: "Unmarshal", Kind: code.RefKindCall, FilePath: "internal/cfg/cfg.go", Line: 21},
+		{SymbolName: "Unmarshal", Kind: code.RefKindCall, FilePath: "other/json.go", Line: 9},
+		{SymbolName: `"golang.org/x/text/cases"`, Kind: code.RefKindImport, FilePath: "internal/ui/title.go", Line: 3},
+		{SymbolName: "ParseAcceptLanguage", Kind: code.RefKindCall, FilePath: "internal/http/lang.go", Line: 12},
+	}
+	var out []*code.Reference
+	for _, r := range all {
+		if r.SymbolName == symbol && (kind == "" || r.Kind == kind) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func vulnByAdvisory(t *testing.T, ff []*Finding, id string) *Finding {
+	t.Helper()
+	for _, f := range ff {
+		if f.Metadata["advisory"] == id {
+			return f
+		}
+	}
+	t.Fatalf("no finding for %s", id)
+	return nil
+}
+
+func TestAnalyzeVulns(t *testing.T) {
+	root, deps := vulnsFixture(t)
+	ff, result, err := AnalyzeVulns(VulnsConfig{ProjectRoot: root, Dependencies: deps, References: fakeReferences})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.AdvisoriesRead != 5 || result.Audited != 3 || result.Unversioned != 1 {
+		t.Errorf("result = %+v, want 5 read, 3 audited, 1 unversioned", result)
+	}
+	if len(ff) != 3 || result.Reachable != 1 {
+		t.Fatalf("findings = %d (reachable %d), want 3 (1)", len(ff), result.Reachable)
+	}
+
+	lodash := vulnByAdvisory(t, ff, "GHSA-jf85-cpcp-j695")
+	if lodash.Severity != SevCritical || lodash.FilePath != "web/package.json" || lodash.Line != 3 {
+		t.Errorf("lodash = %s %s:%d", lodash.Severity, lodash.FilePath, lodash.Line)
+	}
+	if lodash.Metadata["cve"] != "CVE-2019-10744" || lodash.Metadata["ghsa"] != "GHSA-jf85-cpcp-j695" || lodash.Metadata["fixed"] != "4.17.12" {
+		t.Errorf("lodash metadata = %v", lodash.Metadata)
+	}
+	if _, ok := lodash.Metadata["reachability"]; ok {
+		t.Error("reachability set on a non-Go finding")
+	}
+
+	yaml := vulnByAdvisory(t, ff, "GO-2022-0603")
+	if yaml.Metadata["reachability"] != ReachReachable || yaml.Severity != SevWarning {
+		t.Errorf("yaml reachability/severity = %s/%s", yaml.Metadata["reachability"], yaml.Severity)
+	}
+	if yaml.Metadata["call_sites"] != "internal/cfg/cfg.go:21" {
+		t.Errorf("yaml call sites = %q, want only the importing file", yaml.Metadata["call_sites"])
+	}
+	if yaml.Metadata["fixed"] != "3.0.1" || yaml.Line != 4 || !strings.Contains(yaml.Title, "CVE-2022-28948") {
+		t.Errorf("yaml = line %d, title %q, metadata %v", yaml.Line, yaml.Title, yaml.Metadata)
+	}
+
+	text := vulnByAdvisory(t, ff, "GO-2022-1059")
+	if text.Metadata["reachability"] != ReachNotImported || text.Severity != SevInfo {
+		t.Errorf("x/text reachability/severity = %s/%s, want not-imported/info", text.Metadata["reachability"], text.Severity)
+	}
+	if ff[0] != lodash {
+		t.Error("findings not ordered by severity")
+	}
+}
+
+func TestAnalyzeVulnsWithoutCodeIndex(t *testing.T) {
+	root, deps := vulnsFixture(t)
+	ff, _, err := AnalyzeVulns(VulnsConfig{ProjectRoot: root, Dependencies: deps})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := vulnByAdvisory(t, ff, "GO-2022-1059"); text.Metadata["reachability"] != ReachUnknown || text.Severity != SevWarning {
+		t.Errorf("x/text without index = %s/%s, want unknown/warning", text.Metadata["reachability"], text.Severity)
+	}
+}
+
+func TestAnalyzeVulnsMissingDatabase(t *testing.T) {
+	_, _, err := AnalyzeVulns(VulnsConfig{ProjectRoot: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "advisories import") {
+		t.Errorf("err = %v, want a pointer to 'advisories import'", err)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.3", "1.2.3", 0},
+		{"v1.2.3", "1.2.3", 0},
+		{"1.0", "1.0.0", 0},
+		{"1.2.10", "1.2.9", 1},
+		{"0", "0.0.1", -1},
+		{"1.0.0-rc1", "1.0.0", -1},
+		{"1.0.0-alpha", "1.0.0-beta", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.0a1", "1.0", -1},
+		{"1.0.post1", "1.0", 1},
+		{"1.0.0+build5", "1.0.0", 0},
+		{"3.0.0-20220521103104-8f96da9f5d5e", "3.0.0", -1},
+		{"2.0.0-SNAPSHOT", "2.0.0", -1},
+	}
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestAdvisoryRangeContains(t *testing.T) {
+	r := AdvisoryRange{Type: "ECOSYSTEM", Events: []map[string]string{
+		{"introduced": "1.0.0"}, {"fixed": "1.2.0"}, {"introduced": "2.0.0"}, {"last_affected": "2.1.0"},
+	}}
+	tests := []struct {
+		version string
+		in      bool
+		fixed   string
+	}{
+		{"0.9.0", false, ""},
+		{"1.0.0", true, "1.2.0"},
+		{"1.1.9", true, "1.2.0"},
+		{"1.2.0", false, ""},
+		{"2.1.0", true, ""},
+		{"2.1.1", false, ""},
+	}
+	for _, tt := range tests {
+		in, fixed := r.contains(tt.version)
+		if in != tt.in || fixed != tt.fixed {
+			t.Errorf("contains(%s) = %v %q, want %v %q", tt.version, in, fixed, tt.in, tt.fixed)
+		}
+	}
+	open := AdvisoryRange{Events: []map[string]string{{"introduced": "0"}}}
+	if in, _ := open.contains("9.9.9"); !in {
+		t.Error("open-ended range does not contain 9.9.9")
+	}
+}
+
+func TestImportAdvisoryZip(t *testing.T) {
+	tmp := t.TempDir()
+	zipPath := filepath.Join(tmp, "all.zip")
+	fh, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(fh)
+	for name, body := range map[string]string{
+		"PYSEC-2021-1.json":    `{"id": "PYSEC-2021-1", "affected": [{"package": {"ecosystem": "PyPI", "name": "PyYAML"}, "versions": ["5.3"]}]}`,
+		"../../escape.json":    `{"id": "PYSEC-2021-2", "affected": [{"package": {"ecosystem": "PyPI", "name": "jinja2"}}]}`,
+		"README.md":            "not an advisory",
+		"not-an-advisory.json": `{"hello": "world"}`,
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	zw.Close()
+	fh.Close()
+
+	dir := filepath.Join(tmp, "advisories")
+	writeFiles(t, dir, map[string]string{"PyPI/stale.json": "{}"})
+	name, n, err := ImportAdvisoryZip(zipPath, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "PyPI" || n != 2 {
+		t.Errorf("import = %s/%d, want PyPI/2", name, n)
+	}
+	for _, f := range []string{"PyPI/PYSEC-2021-1.json", "PyPI/escape.json"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("%s not imported: %v", f, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "PyPI/stale.json")); !os.IsNotExist(err) {
+		t.Error("previous import of the ecosystem not replaced")
+	}
+
+	index, _, err := LoadAdvisories(dir, func(key string) bool { return key == advisoryKey("PyPI", "pyyaml") })
+	if err != nil || len(index[advisoryKey("PyPI", "PyYAML")]) != 1 {
+		t.Errorf("PyYAML advisories = %v (err %v), want 1 via normalised name", index, err)
+	}
+}
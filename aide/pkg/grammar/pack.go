@@ -148,6 +148,14 @@ type PackMeta struct {
 type PackQueries struct {
 	Tags string `json:"tags,omitempty"`
 	Refs string `json:"refs,omitempty"`
+	// Namespace captures the file's package or namespace as @name (first
+	// match wins); it prefixes every symbol's qualified name.
+	Namespace string `json:"namespace,omitempty"`
+	// Hierarchy captures type-hierarchy edges: @type is the declaring type,
+	// and each @extends, @implements or @embeds capture names a supertype.
+	// @requires captures a method an interface declares, used to find
+	// structural (Go) implementations.
+	Hierarchy string `json:"hierarchy,omitempty"`
 }
 
 // PackComplexity holds complexity analysis configuration for a language.
@@ -506,6 +506,7 @@ func TestPacksWithImports(t *testing.T) {
 	langsWithImports := []string{
 		"go", "python", "typescript", "tsx", "javascript", "java", "rust",
 		"csharp", "kotlin", "scala", "ruby", "php", "lua", "elixir", "swift", "ocaml",
+		"c", "cpp", "dart",
 	}
 	for _, name := range langsWithImports {
 		p := reg.Get(name)
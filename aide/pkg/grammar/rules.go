@@ -0,0 +1,162 @@
+package grammar
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tree_sitter "github.com/tree-sitter/go-tree-sitter"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleFile is the on-disk form of a user rule pack, read from
+// .aide/rules/*.json (or *.yaml / *.yml). Each file holds any number of rules.
+type RuleFile struct {
+	Rules []CustomRule `json:"rules"`
+}
+
+// CustomRule is a user-defined check matched by regex (line by line, like
+// security rules) or by a tree-sitter query (structural, per language).
+// The shape mirrors SecurityRule so a pack rule can be copied into a rule
+// file and adjusted.
+type CustomRule struct {
+	ID       string `json:"id"`                 // Unique across all rule files, e.g. "no-fmt-println"
+	Name     string `json:"name,omitempty"`     // Human-readable name (defaults to ID)
+	Severity string `json:"severity,omitempty"` // "critical", "warning", "info" (default "warning")
+	Category string `json:"category,omitempty"` // Free-form grouping (defaults to ID)
+	Pattern  string `json:"pattern,omitempty"`  // Regex matched against each non-comment line
+	Query    string `json:"query,omitempty"`    // Tree-sitter S-expression query
+	// Message is the finding title template. {{match}} expands to the
+	// matched text; {{name}} to a named regex group or query capture; {{1}}
+	// to a numbered regex group. Empty means Name.
+	Message     string `json:"message,omitempty"`
+	Description string `json:"description,omitempty"` // Guidance for the LLM / developer
+	// Languages restricts the rule to these pack names or aliases. Required
+	// for query rules (a query is compiled against one grammar); empty means
+	// every language for regex rules.
+	Languages []string `json:"languages,omitempty"`
+	// Paths are doublestar globs (project-relative) the file must match.
+	// Empty means all files.
+	Paths []string `json:"paths,omitempty"`
+	// Exclude are doublestar globs for files to skip.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Source is the rule file the rule was loaded from.
+	Source string `json:"-"`
+}
+
+// Validate checks the rule is usable on its own: an ID and exactly one of
+// Pattern or Query, with Languages set for query rules.
+func (r *CustomRule) Validate() error {
+	if r.ID == "" {
+		return errors.New("rule id is required")
+	}
+	switch {
+	case r.Pattern == "" && r.Query == "":
+		return fmt.Errorf("rule %s: one of pattern or query is required", r.ID)
+	case r.Pattern != "" && r.Query != "":
+		return fmt.Errorf("rule %s: pattern and query are mutually exclusive", r.ID)
+	case r.Query != "" && len(r.Languages) == 0:
+		return fmt.Errorf("rule %s: query rules must list languages", r.ID)
+	}
+	return nil
+}
+
+// LoadRuleFile parses one rule file. JSON and YAML are both accepted, chosen
+// by extension; YAML is converted through JSON so the json tags above are
+// the single schema.
+func LoadRuleFile(path string) ([]CustomRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parsing rule file %s: %w", path, err)
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return nil, fmt.Errorf("parsing rule file %s: %w", path, err)
+		}
+	}
+	var rf RuleFile
+	if err := json.Unmarshal(data, &rf); err != nil {
+		return nil, fmt.Errorf("parsing rule file %s: %w", path, err)
+	}
+	for i := range rf.Rules {
+		rf.Rules[i].Source = path
+		if err := rf.Rules[i].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return rf.Rules, nil
+}
+
+// LoadRuleDir loads every rule file in dir in lexical order. A missing
+// directory yields no rules and no error. Rule IDs must be unique across
+// files.
+func LoadRuleDir(dir string) ([]CustomRule, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading rule dir %s: %w", dir, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !IsRuleFile(e.Name()) {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	var rules []CustomRule
+	seen := make(map[string]string)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		fileRules, err := LoadRuleFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range fileRules {
+			if prev, ok := seen[r.ID]; ok {
+				return nil, fmt.Errorf("duplicate rule id %s in %s (first defined in %s)", r.ID, path, prev)
+			}
+			seen[r.ID] = path
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// IsRuleFile reports whether name has a rule file extension.
+func IsRuleFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// CompileQuery loads lang's grammar through loader and compiles pattern
+// against it. The caller owns the returned query and must Close it.
+func CompileQuery(ctx context.Context, loader Loader, lang, pattern string) (*tree_sitter.Query, error) {
+	sitterLang, err := loader.Load(ctx, lang)
+	if err != nil {
+		return nil, err
+	}
+	q, qErr := tree_sitter.NewQuery(sitterLang, pattern)
+	if qErr != nil {
+		return nil, fmt.Errorf("compiling %s query: %s", lang, qErr.Error())
+	}
+	return q, nil
+}
@@ -0,0 +1,94 @@
+package grammar
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRuleFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRuleDir(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "a.json", `{"rules": [
+		{"id": "no-println", "pattern": "fmt\\.Println\\(", "severity": "info", "languages": ["go"]}
+	]}`)
+	writeRuleFile(t, dir, "b.yaml", `rules:
+  - id: no-panic
+    query: '(call_expression function: (identifier) @fn (#eq? @fn "panic")) @match'
+    languages: [go]
+    paths: ["internal/**"]
+`)
+	writeRuleFile(t, dir, "notes.txt", "ignored")
+
+	rules, err := LoadRuleDir(dir)
+	if err != nil {
+		t.Fatalf("LoadRuleDir: %v", err)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].ID != "no-println" || rules[0].Source != filepath.Join(dir, "a.json") {
+		t.Errorf("unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].ID != "no-panic" || rules[1].Query == "" || rules[1].Paths[0] != "internal/**" {
+		t.Errorf("unexpected YAML rule: %+v", rules[1])
+	}
+}
+
+func TestLoadRuleDir_Missing(t *testing.T) {
+	rules, err := LoadRuleDir(filepath.Join(t.TempDir(), "nope"))
+	if err != nil || rules != nil {
+		t.Errorf("expected no rules and no error for a missing dir, got %v, %v", rules, err)
+	}
+}
+
+func TestLoadRuleDir_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{"no matcher", map[string]string{"a.json": `{"rules":[{"id":"x"}]}`}, "one of pattern or query"},
+		{"both matchers", map[string]string{"a.json": `{"rules":[{"id":"x","pattern":"a","query":"(b)","languages":["go"]}]}`}, "mutually exclusive"},
+		{"query without languages", map[string]string{"a.json": `{"rules":[{"id":"x","query":"(b)"}]}`}, "must list languages"},
+		{"missing id", map[string]string{"a.json": `{"rules":[{"pattern":"a"}]}`}, "id is required"},
+		{"duplicate id", map[string]string{
+			"a.json": `{"rules":[{"id":"x","pattern":"a"}]}`,
+			"b.json": `{"rules":[{"id":"x","pattern":"b"}]}`,
+		}, "duplicate rule id x"},
+		{"bad json", map[string]string{"a.json": `{"rules":`}, "parsing rule file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeRuleFile(t, dir, name, content)
+			}
+			_, err := LoadRuleDir(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCompileQuery(t *testing.T) {
+	loader := NewCompositeLoader(WithAutoDownload(false))
+	q, err := CompileQuery(context.Background(), loader, "go", `(call_expression function: (identifier) @fn)`)
+	if err != nil {
+		t.Fatalf("CompileQuery: %v", err)
+	}
+	q.Close()
+
+	if _, err := CompileQuery(context.Background(), loader, "go", `(not_a_node)`); err == nil {
+		t.Error("expected an error for an invalid node type")
+	}
+}
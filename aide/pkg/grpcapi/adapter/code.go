@@ -166,10 +166,34 @@ func (a *CodeAdapter) GetSymbol(string) (*code.Symbol, error)     { return nil,
 func (a *CodeAdapter) DeleteSymbol(string) error                  { return errCodeClientMode }
 func (a *CodeAdapter) ListAllFileInfo() ([]*code.FileInfo, error) { return nil, errCodeClientMode }
 func (a *CodeAdapter) ClearFile(string) error                     { return errCodeClientMode }
-func (a *CodeAdapter) IndexFileBatch(string, []*code.Symbol, []*code.Reference, time.Time, int64) error {
+func (a *CodeAdapter) IndexFileBatch(string, []*code.Symbol, []*code.Reference, []*code.TypeEdge, time.Time, int64) error {
 	return errCodeClientMode
 }
-func (a *CodeAdapter) ClearFileReferences(string) error           { return errCodeClientMode }
+func (a *CodeAdapter) IndexFileDelta(string, []*code.Symbol, []*code.Reference, []*code.TypeEdge, time.Time, int64) (store.FileDelta, error) {
+	return store.FileDelta{}, errCodeClientMode
+}
+func (a *CodeAdapter) SemanticSearchSymbols(string, code.SearchOptions) ([]*store.CodeSearchResult, error) {
+	return nil, errCodeClientMode
+}
+func (a *CodeAdapter) ClearFileReferences(string) error { return errCodeClientMode }
+func (a *CodeAdapter) ResolveReferenceTargets([]string, code.TargetResolver) (int, error) {
+	return 0, errCodeClientMode
+}
+func (a *CodeAdapter) GetFileTypeEdges(string) ([]*code.TypeEdge, error) {
+	return nil, errCodeClientMode
+}
+func (a *CodeAdapter) FindImplementations(string) ([]*code.TypeEdge, error) {
+	return nil, errCodeClientMode
+}
+func (a *CodeAdapter) FindSupertypes(string) ([]*code.TypeEdge, error) {
+	return nil, errCodeClientMode
+}
+func (a *CodeAdapter) GetLSPCache(string, string) ([]byte, error) { return nil, errCodeClientMode }
+func (a *CodeAdapter) PutLSPCache(string, string, []byte) error   { return errCodeClientMode }
+func (a *CodeAdapter) GetFileHistory(string) (*code.FileHistory, error) {
+	return nil, errCodeClientMode
+}
+func (a *CodeAdapter) PutFileHistory(*code.FileHistory) error     { return errCodeClientMode }
 func (a *CodeAdapter) ListAllSymbols(int) ([]*code.Symbol, error) { return nil, errCodeClientMode }
 func (a *CodeAdapter) ListAllReferences(int) ([]*code.Reference, error) {
 	return nil, errCodeClientMode
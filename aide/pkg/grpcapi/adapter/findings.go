@@ -3,10 +3,12 @@ package adapter
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/findings"
 	"github.com/jmylchreest/aide/aide/pkg/grpcapi"
 	"github.com/jmylchreest/aide/aide/pkg/store"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // FindingsAdapter implements store.FindingsStore by delegating to a gRPC client.
@@ -14,8 +16,12 @@ type FindingsAdapter struct {
 	client *grpcapi.Client
 }
 
-// Compile-time check that FindingsAdapter implements store.FindingsStore.
-var _ store.FindingsStore = (*FindingsAdapter)(nil)
+// Compile-time checks that FindingsAdapter implements store.FindingsStore
+// and store.FindingsTriageStore.
+var (
+	_ store.FindingsStore       = (*FindingsAdapter)(nil)
+	_ store.FindingsTriageStore = (*FindingsAdapter)(nil)
+)
 
 // NewFindingsAdapter creates a new gRPC-backed findings adapter.
 func NewFindingsAdapter(client *grpcapi.Client) *FindingsAdapter {
@@ -85,6 +91,7 @@ func (g *FindingsAdapter) SearchFindings(query string, opts findings.SearchOptio
 		Severity: opts.Severity,
 		FilePath: opts.FilePath,
 		Category: opts.Category,
+		State:    opts.State,
 		Limit:    int32(opts.Limit),
 	})
 	if err != nil {
@@ -110,6 +117,7 @@ func (g *FindingsAdapter) ListFindings(opts findings.SearchOptions) ([]*findings
 		Severity: opts.Severity,
 		FilePath: opts.FilePath,
 		Category: opts.Category,
+		State:    opts.State,
 		Limit:    int32(opts.Limit),
 	})
 	if err != nil {
@@ -191,11 +199,11 @@ func (g *FindingsAdapter) AcceptFindingsByFilter(opts findings.SearchOptions) (i
 	return int(resp.Count), nil
 }
 
-func (g *FindingsAdapter) Stats(_ findings.SearchOptions) (*findings.Stats, error) {
+func (g *FindingsAdapter) Stats(opts findings.SearchOptions) (*findings.Stats, error) {
 	ctx, cancel := g.rpcCtx()
 	defer cancel()
 
-	resp, err := g.client.Findings.Stats(ctx, &grpcapi.FindingStatsRequest{})
+	resp, err := g.client.Findings.Stats(ctx, &grpcapi.FindingStatsRequest{State: opts.State})
 	if err != nil {
 		return nil, err
 	}
@@ -208,11 +216,19 @@ func (g *FindingsAdapter) Stats(_ findings.SearchOptions) (*findings.Stats, erro
 	for k, v := range resp.BySeverity {
 		bySeverity[k] = int(v)
 	}
+	var byRule map[string]int
+	if len(resp.ByRule) > 0 {
+		byRule = make(map[string]int, len(resp.ByRule))
+		for k, v := range resp.ByRule {
+			byRule[k] = int(v)
+		}
+	}
 
 	return &findings.Stats{
 		Total:      int(resp.Total),
 		ByAnalyzer: byAnalyzer,
 		BySeverity: bySeverity,
+		ByRule:     byRule,
 	}, nil
 }
 
@@ -224,6 +240,96 @@ func (g *FindingsAdapter) Clear() error {
 	return err
 }
 
+// ResolveTriageRef resolves a finding ID or fingerprint on the daemon.
+func (g *FindingsAdapter) ResolveTriageRef(ref string) (string, *findings.Finding, error) {
+	ctx, cancel := g.rpcCtx()
+	defer cancel()
+
+	resp, err := g.client.Findings.ResolveTriageRef(ctx, &grpcapi.FindingTriageRefRequest{Ref: ref})
+	if err != nil {
+		return "", nil, err
+	}
+	return resp.Fingerprint, ProtoToFinding(resp.Finding), nil
+}
+
+func (g *FindingsAdapter) TriageFinding(ev *findings.TriageEvent) (*findings.TriageStatus, error) {
+	ctx, cancel := g.rpcCtx()
+	defer cancel()
+
+	resp, err := g.client.Findings.Triage(ctx, &grpcapi.FindingTriageRequest{Event: triageEventToProto(ev)})
+	if err != nil {
+		return nil, err
+	}
+	return ProtoToTriageStatus(resp.Status), nil
+}
+
+func (g *FindingsAdapter) TriageHistory(fingerprint string) ([]*findings.TriageEvent, error) {
+	ctx, cancel := g.rpcCtx()
+	defer cancel()
+
+	resp, err := g.client.Findings.TriageHistory(ctx, &grpcapi.FindingTriageHistoryRequest{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+	return ProtoToTriageEvents(resp.Events), nil
+}
+
+func (g *FindingsAdapter) TriageStatuses() (map[string]*findings.TriageStatus, error) {
+	ctx, cancel := g.rpcCtx()
+	defer cancel()
+
+	resp, err := g.client.Findings.TriageStatuses(ctx, &grpcapi.FindingTriageStatusesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*findings.TriageStatus, len(resp.Statuses))
+	for fp, st := range resp.Statuses {
+		out[fp] = ProtoToTriageStatus(st)
+	}
+	return out, nil
+}
+
+func (g *FindingsAdapter) ListTriageEvents(since time.Time, to findings.TriageState) ([]*findings.TriageEvent, error) {
+	ctx, cancel := g.rpcCtx()
+	defer cancel()
+
+	req := &grpcapi.FindingTriageEventsRequest{To: string(to)}
+	if !since.IsZero() {
+		req.Since = timestamppb.New(since)
+	}
+	resp, err := g.client.Findings.ListTriageEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return ProtoToTriageEvents(resp.Events), nil
+}
+
+// triageEventToProto converts a triage event for the Triage RPC.
+func triageEventToProto(e *findings.TriageEvent) *grpcapi.FindingTriageEvent {
+	out := &grpcapi.FindingTriageEvent{
+		Id:          e.ID,
+		Fingerprint: e.Fingerprint,
+		FindingId:   e.FindingID,
+		From:        string(e.From),
+		To:          string(e.To),
+		Reason:      e.Reason,
+		Actor:       e.Actor,
+		Assignee:    e.Assignee,
+		Analyzer:    e.Analyzer,
+		FilePath:    e.FilePath,
+		Title:       e.Title,
+		Commit:      e.Commit,
+		SessionId:   e.SessionID,
+	}
+	if !e.Until.IsZero() {
+		out.Until = timestamppb.New(e.Until)
+	}
+	if !e.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(e.CreatedAt)
+	}
+	return out
+}
+
 func (g *FindingsAdapter) Close() error {
 	return nil
 }
@@ -231,6 +231,67 @@ func ProtoToFinding(pf *grpcapi.Finding) *findings.Finding {
 	}
 }
 
+// ProtoToTriageEvent converts a protobuf FindingTriageEvent to the domain
+// TriageEvent type.
+func ProtoToTriageEvent(p *grpcapi.FindingTriageEvent) *findings.TriageEvent {
+	if p == nil {
+		return nil
+	}
+	out := &findings.TriageEvent{
+		ID:          p.Id,
+		Fingerprint: p.Fingerprint,
+		FindingID:   p.FindingId,
+		From:        findings.TriageState(p.From),
+		To:          findings.TriageState(p.To),
+		Reason:      p.Reason,
+		Actor:       p.Actor,
+		Assignee:    p.Assignee,
+		Analyzer:    p.Analyzer,
+		FilePath:    p.FilePath,
+		Title:       p.Title,
+		Commit:      p.Commit,
+		SessionID:   p.SessionId,
+	}
+	if p.Until != nil {
+		out.Until = p.Until.AsTime()
+	}
+	if p.CreatedAt != nil {
+		out.CreatedAt = p.CreatedAt.AsTime()
+	}
+	return out
+}
+
+// ProtoToTriageEvents converts a slice of protobuf FindingTriageEvents.
+func ProtoToTriageEvents(ps []*grpcapi.FindingTriageEvent) []*findings.TriageEvent {
+	result := make([]*findings.TriageEvent, len(ps))
+	for i, p := range ps {
+		result[i] = ProtoToTriageEvent(p)
+	}
+	return result
+}
+
+// ProtoToTriageStatus converts a protobuf FindingTriageStatus to the domain
+// TriageStatus type.
+func ProtoToTriageStatus(p *grpcapi.FindingTriageStatus) *findings.TriageStatus {
+	if p == nil {
+		return nil
+	}
+	out := &findings.TriageStatus{
+		Fingerprint: p.Fingerprint,
+		State:       findings.TriageState(p.State),
+		Reason:      p.Reason,
+		Actor:       p.Actor,
+		Assignee:    p.Assignee,
+	}
+	if p.Until != nil {
+		out.Until = p.Until.AsTime()
+	}
+	if p.UpdatedAt != nil {
+		out.UpdatedAt = p.UpdatedAt.AsTime()
+	}
+	return out
+}
+
 // ProtoToSurveyEntry converts a protobuf SurveyEntry to the domain Entry type.
 func ProtoToSurveyEntry(pe *grpcapi.SurveyEntry) *survey.Entry {
 	if pe == nil {
@@ -5751,6 +5751,7 @@ type FindingSearchRequest struct {
 	FilePath      string                 `protobuf:"bytes,4,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"` // Optional: filter by file path pattern
 	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`                 // Optional: filter by category
 	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                      // Max results (default 20)
+	State         string                 `protobuf:"bytes,7,opt,name=state,proto3" json:"state,omitempty"`                       // Optional: filter by effective triage state
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -5827,6 +5828,13 @@ func (x *FindingSearchRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *FindingSearchRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 type FindingSearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Findings      []*Finding             `protobuf:"bytes,1,rep,name=findings,proto3" json:"findings,omitempty"`
@@ -5878,6 +5886,7 @@ type FindingListRequest struct {
 	FilePath      string                 `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"` // Optional: filter by file path pattern
 	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                 // Optional: filter by category
 	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                      // Max results (default 100)
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`                       // Optional: filter by effective triage state
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -5947,6 +5956,13 @@ func (x *FindingListRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *FindingListRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 type FindingFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FilePath      string                 `protobuf:"bytes,1,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
@@ -6081,6 +6097,7 @@ func (x *FindingClearAnalyzerResponse) GetCount() int32 {
 
 type FindingStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"` // Optional: count only findings in this triage state
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -6115,11 +6132,19 @@ func (*FindingStatsRequest) Descriptor() ([]byte, []int) {
 	return file_aidememory_proto_rawDescGZIP(), []int{108}
 }
 
+func (x *FindingStatsRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 type FindingStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
 	ByAnalyzer    map[string]int32       `protobuf:"bytes,2,rep,name=by_analyzer,json=byAnalyzer,proto3" json:"by_analyzer,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	BySeverity    map[string]int32       `protobuf:"bytes,3,rep,name=by_severity,json=bySeverity,proto3" json:"by_severity,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ByRule        map[string]int32       `protobuf:"bytes,4,rep,name=by_rule,json=byRule,proto3" json:"by_rule,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // custom analyzer findings per rule ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -6161,41 +6186,720 @@ func (x *FindingStatsResponse) GetTotal() int32 {
 	return 0
 }
 
-func (x *FindingStatsResponse) GetByAnalyzer() map[string]int32 {
-	if x != nil {
-		return x.ByAnalyzer
-	}
-	return nil
+func (x *FindingStatsResponse) GetByAnalyzer() map[string]int32 {
+	if x != nil {
+		return x.ByAnalyzer
+	}
+	return nil
+}
+
+func (x *FindingStatsResponse) GetBySeverity() map[string]int32 {
+	if x != nil {
+		return x.BySeverity
+	}
+	return nil
+}
+
+func (x *FindingStatsResponse) GetByRule() map[string]int32 {
+	if x != nil {
+		return x.ByRule
+	}
+	return nil
+}
+
+type FindingClearRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingClearRequest) Reset() {
+	*x = FindingClearRequest{}
+	mi := &file_aidememory_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingClearRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingClearRequest) ProtoMessage() {}
+
+func (x *FindingClearRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingClearRequest.ProtoReflect.Descriptor instead.
+func (*FindingClearRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{110}
+}
+
+type FindingClearResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingClearResponse) Reset() {
+	*x = FindingClearResponse{}
+	mi := &file_aidememory_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingClearResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingClearResponse) ProtoMessage() {}
+
+func (x *FindingClearResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingClearResponse.ProtoReflect.Descriptor instead.
+func (*FindingClearResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *FindingClearResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type FindingAcceptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // Finding IDs to accept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingAcceptRequest) Reset() {
+	*x = FindingAcceptRequest{}
+	mi := &file_aidememory_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingAcceptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingAcceptRequest) ProtoMessage() {}
+
+func (x *FindingAcceptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingAcceptRequest.ProtoReflect.Descriptor instead.
+func (*FindingAcceptRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *FindingAcceptRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type FindingAcceptByFilterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Analyzer      string                 `protobuf:"bytes,1,opt,name=analyzer,proto3" json:"analyzer,omitempty"`                 // Optional: filter by analyzer
+	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`                 // Optional: filter by severity
+	FilePath      string                 `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"` // Optional: filter by file path pattern
+	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                 // Optional: filter by category
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingAcceptByFilterRequest) Reset() {
+	*x = FindingAcceptByFilterRequest{}
+	mi := &file_aidememory_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingAcceptByFilterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingAcceptByFilterRequest) ProtoMessage() {}
+
+func (x *FindingAcceptByFilterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingAcceptByFilterRequest.ProtoReflect.Descriptor instead.
+func (*FindingAcceptByFilterRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *FindingAcceptByFilterRequest) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *FindingAcceptByFilterRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *FindingAcceptByFilterRequest) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *FindingAcceptByFilterRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type FindingAcceptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Number of findings accepted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingAcceptResponse) Reset() {
+	*x = FindingAcceptResponse{}
+	mi := &file_aidememory_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingAcceptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingAcceptResponse) ProtoMessage() {}
+
+func (x *FindingAcceptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingAcceptResponse.ProtoReflect.Descriptor instead.
+func (*FindingAcceptResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *FindingAcceptResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// FindingTriageEvent is one recorded triage transition, with a snapshot of
+// the finding it applied to.
+type FindingTriageEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fingerprint   string                 `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	FindingId     string                 `protobuf:"bytes,3,opt,name=finding_id,json=findingId,proto3" json:"finding_id,omitempty"`
+	From          string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	Assignee      string                 `protobuf:"bytes,8,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=until,proto3" json:"until,omitempty"` // Snooze expiry (snoozed only)
+	Analyzer      string                 `protobuf:"bytes,10,opt,name=analyzer,proto3" json:"analyzer,omitempty"`
+	FilePath      string                 `protobuf:"bytes,11,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Title         string                 `protobuf:"bytes,12,opt,name=title,proto3" json:"title,omitempty"`
+	Commit        string                 `protobuf:"bytes,13,opt,name=commit,proto3" json:"commit,omitempty"`
+	SessionId     string                 `protobuf:"bytes,14,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageEvent) Reset() {
+	*x = FindingTriageEvent{}
+	mi := &file_aidememory_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageEvent) ProtoMessage() {}
+
+func (x *FindingTriageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageEvent.ProtoReflect.Descriptor instead.
+func (*FindingTriageEvent) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *FindingTriageEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetFindingId() string {
+	if x != nil {
+		return x.FindingId
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *FindingTriageEvent) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *FindingTriageEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// FindingTriageStatus is a fingerprint's current triage state.
+type FindingTriageStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fingerprint   string                 `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	Assignee      string                 `protobuf:"bytes,5,opt,name=assignee,proto3" json:"assignee,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageStatus) Reset() {
+	*x = FindingTriageStatus{}
+	mi := &file_aidememory_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageStatus) ProtoMessage() {}
+
+func (x *FindingTriageStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageStatus.ProtoReflect.Descriptor instead.
+func (*FindingTriageStatus) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *FindingTriageStatus) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *FindingTriageStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *FindingTriageStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *FindingTriageStatus) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *FindingTriageStatus) GetAssignee() string {
+	if x != nil {
+		return x.Assignee
+	}
+	return ""
+}
+
+func (x *FindingTriageStatus) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *FindingTriageStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type FindingTriageRefRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ref           string                 `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"` // Finding ID or fingerprint
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageRefRequest) Reset() {
+	*x = FindingTriageRefRequest{}
+	mi := &file_aidememory_proto_msgTypes[117]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageRefRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageRefRequest) ProtoMessage() {}
+
+func (x *FindingTriageRefRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[117]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageRefRequest.ProtoReflect.Descriptor instead.
+func (*FindingTriageRefRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *FindingTriageRefRequest) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+type FindingTriageRefResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fingerprint   string                 `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	Finding       *Finding               `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"` // Unset when only triage history matches
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageRefResponse) Reset() {
+	*x = FindingTriageRefResponse{}
+	mi := &file_aidememory_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageRefResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageRefResponse) ProtoMessage() {}
+
+func (x *FindingTriageRefResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[118]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageRefResponse.ProtoReflect.Descriptor instead.
+func (*FindingTriageRefResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *FindingTriageRefResponse) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+func (x *FindingTriageRefResponse) GetFinding() *Finding {
+	if x != nil {
+		return x.Finding
+	}
+	return nil
+}
+
+type FindingTriageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *FindingTriageEvent    `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"` // id, from and created_at are filled in by the store
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageRequest) Reset() {
+	*x = FindingTriageRequest{}
+	mi := &file_aidememory_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageRequest) ProtoMessage() {}
+
+func (x *FindingTriageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[119]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageRequest.ProtoReflect.Descriptor instead.
+func (*FindingTriageRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *FindingTriageRequest) GetEvent() *FindingTriageEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type FindingTriageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *FindingTriageStatus   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingTriageResponse) Reset() {
+	*x = FindingTriageResponse{}
+	mi := &file_aidememory_proto_msgTypes[120]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingTriageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingTriageResponse) ProtoMessage() {}
+
+func (x *FindingTriageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[120]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingTriageResponse.ProtoReflect.Descriptor instead.
+func (*FindingTriageResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *FindingStatsResponse) GetBySeverity() map[string]int32 {
+func (x *FindingTriageResponse) GetStatus() *FindingTriageStatus {
 	if x != nil {
-		return x.BySeverity
+		return x.Status
 	}
 	return nil
 }
 
-type FindingClearRequest struct {
+type FindingTriageHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fingerprint   string                 `protobuf:"bytes,1,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindingClearRequest) Reset() {
-	*x = FindingClearRequest{}
-	mi := &file_aidememory_proto_msgTypes[110]
+func (x *FindingTriageHistoryRequest) Reset() {
+	*x = FindingTriageHistoryRequest{}
+	mi := &file_aidememory_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindingClearRequest) String() string {
+func (x *FindingTriageHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindingClearRequest) ProtoMessage() {}
+func (*FindingTriageHistoryRequest) ProtoMessage() {}
 
-func (x *FindingClearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[110]
+func (x *FindingTriageHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6206,33 +6910,39 @@ func (x *FindingClearRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindingClearRequest.ProtoReflect.Descriptor instead.
-func (*FindingClearRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{110}
+// Deprecated: Use FindingTriageHistoryRequest.ProtoReflect.Descriptor instead.
+func (*FindingTriageHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{121}
 }
 
-type FindingClearResponse struct {
+func (x *FindingTriageHistoryRequest) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
+type FindingTriageStatusesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindingClearResponse) Reset() {
-	*x = FindingClearResponse{}
-	mi := &file_aidememory_proto_msgTypes[111]
+func (x *FindingTriageStatusesRequest) Reset() {
+	*x = FindingTriageStatusesRequest{}
+	mi := &file_aidememory_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindingClearResponse) String() string {
+func (x *FindingTriageStatusesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindingClearResponse) ProtoMessage() {}
+func (*FindingTriageStatusesRequest) ProtoMessage() {}
 
-func (x *FindingClearResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[111]
+func (x *FindingTriageStatusesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6243,40 +6953,33 @@ func (x *FindingClearResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindingClearResponse.ProtoReflect.Descriptor instead.
-func (*FindingClearResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{111}
-}
-
-func (x *FindingClearResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use FindingTriageStatusesRequest.ProtoReflect.Descriptor instead.
+func (*FindingTriageStatusesRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{122}
 }
 
-type FindingAcceptRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"` // Finding IDs to accept
+type FindingTriageStatusesResponse struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Statuses      map[string]*FindingTriageStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Keyed by fingerprint
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindingAcceptRequest) Reset() {
-	*x = FindingAcceptRequest{}
-	mi := &file_aidememory_proto_msgTypes[112]
+func (x *FindingTriageStatusesResponse) Reset() {
+	*x = FindingTriageStatusesResponse{}
+	mi := &file_aidememory_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindingAcceptRequest) String() string {
+func (x *FindingTriageStatusesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindingAcceptRequest) ProtoMessage() {}
+func (*FindingTriageStatusesResponse) ProtoMessage() {}
 
-func (x *FindingAcceptRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[112]
+func (x *FindingTriageStatusesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6287,43 +6990,41 @@ func (x *FindingAcceptRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindingAcceptRequest.ProtoReflect.Descriptor instead.
-func (*FindingAcceptRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{112}
+// Deprecated: Use FindingTriageStatusesResponse.ProtoReflect.Descriptor instead.
+func (*FindingTriageStatusesResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *FindingAcceptRequest) GetIds() []string {
+func (x *FindingTriageStatusesResponse) GetStatuses() map[string]*FindingTriageStatus {
 	if x != nil {
-		return x.Ids
+		return x.Statuses
 	}
 	return nil
 }
 
-type FindingAcceptByFilterRequest struct {
+type FindingTriageEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Analyzer      string                 `protobuf:"bytes,1,opt,name=analyzer,proto3" json:"analyzer,omitempty"`                 // Optional: filter by analyzer
-	Severity      string                 `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`                 // Optional: filter by severity
-	FilePath      string                 `protobuf:"bytes,3,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"` // Optional: filter by file path pattern
-	Category      string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`                 // Optional: filter by category
+	Since         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"` // Optional: only transitions into this state
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindingAcceptByFilterRequest) Reset() {
-	*x = FindingAcceptByFilterRequest{}
-	mi := &file_aidememory_proto_msgTypes[113]
+func (x *FindingTriageEventsRequest) Reset() {
+	*x = FindingTriageEventsRequest{}
+	mi := &file_aidememory_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindingAcceptByFilterRequest) String() string {
+func (x *FindingTriageEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindingAcceptByFilterRequest) ProtoMessage() {}
+func (*FindingTriageEventsRequest) ProtoMessage() {}
 
-func (x *FindingAcceptByFilterRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[113]
+func (x *FindingTriageEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6334,61 +7035,47 @@ func (x *FindingAcceptByFilterRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindingAcceptByFilterRequest.ProtoReflect.Descriptor instead.
-func (*FindingAcceptByFilterRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{113}
-}
-
-func (x *FindingAcceptByFilterRequest) GetAnalyzer() string {
-	if x != nil {
-		return x.Analyzer
-	}
-	return ""
-}
-
-func (x *FindingAcceptByFilterRequest) GetSeverity() string {
-	if x != nil {
-		return x.Severity
-	}
-	return ""
+// Deprecated: Use FindingTriageEventsRequest.ProtoReflect.Descriptor instead.
+func (*FindingTriageEventsRequest) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *FindingAcceptByFilterRequest) GetFilePath() string {
+func (x *FindingTriageEventsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.FilePath
+		return x.Since
 	}
-	return ""
+	return nil
 }
 
-func (x *FindingAcceptByFilterRequest) GetCategory() string {
+func (x *FindingTriageEventsRequest) GetTo() string {
 	if x != nil {
-		return x.Category
+		return x.To
 	}
 	return ""
 }
 
-type FindingAcceptResponse struct {
+type FindingTriageEventsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Count         int32                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"` // Number of findings accepted
+	Events        []*FindingTriageEvent  `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FindingAcceptResponse) Reset() {
-	*x = FindingAcceptResponse{}
-	mi := &file_aidememory_proto_msgTypes[114]
+func (x *FindingTriageEventsResponse) Reset() {
+	*x = FindingTriageEventsResponse{}
+	mi := &file_aidememory_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FindingAcceptResponse) String() string {
+func (x *FindingTriageEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FindingAcceptResponse) ProtoMessage() {}
+func (*FindingTriageEventsResponse) ProtoMessage() {}
 
-func (x *FindingAcceptResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[114]
+func (x *FindingTriageEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aidememory_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6399,16 +7086,16 @@ func (x *FindingAcceptResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FindingAcceptResponse.ProtoReflect.Descriptor instead.
-func (*FindingAcceptResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{114}
+// Deprecated: Use FindingTriageEventsResponse.ProtoReflect.Descriptor instead.
+func (*FindingTriageEventsResponse) Descriptor() ([]byte, []int) {
+	return file_aidememory_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *FindingAcceptResponse) GetCount() int32 {
+func (x *FindingTriageEventsResponse) GetEvents() []*FindingTriageEvent {
 	if x != nil {
-		return x.Count
+		return x.Events
 	}
-	return 0
+	return nil
 }
 
 type SurveyRunRequest struct {
@@ -6420,7 +7107,7 @@ type SurveyRunRequest struct {
 
 func (x *SurveyRunRequest) Reset() {
 	*x = SurveyRunRequest{}
-	mi := &file_aidememory_proto_msgTypes[115]
+	mi := &file_aidememory_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6432,7 +7119,7 @@ func (x *SurveyRunRequest) String() string {
 func (*SurveyRunRequest) ProtoMessage() {}
 
 func (x *SurveyRunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[115]
+	mi := &file_aidememory_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6445,7 +7132,7 @@ func (x *SurveyRunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyRunRequest.ProtoReflect.Descriptor instead.
 func (*SurveyRunRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{115}
+	return file_aidememory_proto_rawDescGZIP(), []int{126}
 }
 
 func (x *SurveyRunRequest) GetAnalyzer() string {
@@ -6467,7 +7154,7 @@ type SurveyRunResult struct {
 
 func (x *SurveyRunResult) Reset() {
 	*x = SurveyRunResult{}
-	mi := &file_aidememory_proto_msgTypes[116]
+	mi := &file_aidememory_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6479,7 +7166,7 @@ func (x *SurveyRunResult) String() string {
 func (*SurveyRunResult) ProtoMessage() {}
 
 func (x *SurveyRunResult) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[116]
+	mi := &file_aidememory_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6492,7 +7179,7 @@ func (x *SurveyRunResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyRunResult.ProtoReflect.Descriptor instead.
 func (*SurveyRunResult) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{116}
+	return file_aidememory_proto_rawDescGZIP(), []int{127}
 }
 
 func (x *SurveyRunResult) GetAnalyzer() string {
@@ -6532,7 +7219,7 @@ type SurveyRunResponse struct {
 
 func (x *SurveyRunResponse) Reset() {
 	*x = SurveyRunResponse{}
-	mi := &file_aidememory_proto_msgTypes[117]
+	mi := &file_aidememory_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6544,7 +7231,7 @@ func (x *SurveyRunResponse) String() string {
 func (*SurveyRunResponse) ProtoMessage() {}
 
 func (x *SurveyRunResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[117]
+	mi := &file_aidememory_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6557,7 +7244,7 @@ func (x *SurveyRunResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyRunResponse.ProtoReflect.Descriptor instead.
 func (*SurveyRunResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{117}
+	return file_aidememory_proto_rawDescGZIP(), []int{128}
 }
 
 func (x *SurveyRunResponse) GetResults() []*SurveyRunResult {
@@ -6584,7 +7271,7 @@ type SurveyEntry struct {
 
 func (x *SurveyEntry) Reset() {
 	*x = SurveyEntry{}
-	mi := &file_aidememory_proto_msgTypes[118]
+	mi := &file_aidememory_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6596,7 +7283,7 @@ func (x *SurveyEntry) String() string {
 func (*SurveyEntry) ProtoMessage() {}
 
 func (x *SurveyEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[118]
+	mi := &file_aidememory_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6609,7 +7296,7 @@ func (x *SurveyEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyEntry.ProtoReflect.Descriptor instead.
 func (*SurveyEntry) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{118}
+	return file_aidememory_proto_rawDescGZIP(), []int{129}
 }
 
 func (x *SurveyEntry) GetId() string {
@@ -6690,7 +7377,7 @@ type SurveyAddRequest struct {
 
 func (x *SurveyAddRequest) Reset() {
 	*x = SurveyAddRequest{}
-	mi := &file_aidememory_proto_msgTypes[119]
+	mi := &file_aidememory_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6702,7 +7389,7 @@ func (x *SurveyAddRequest) String() string {
 func (*SurveyAddRequest) ProtoMessage() {}
 
 func (x *SurveyAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[119]
+	mi := &file_aidememory_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6715,7 +7402,7 @@ func (x *SurveyAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyAddRequest.ProtoReflect.Descriptor instead.
 func (*SurveyAddRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{119}
+	return file_aidememory_proto_rawDescGZIP(), []int{130}
 }
 
 func (x *SurveyAddRequest) GetAnalyzer() string {
@@ -6776,7 +7463,7 @@ type SurveyAddResponse struct {
 
 func (x *SurveyAddResponse) Reset() {
 	*x = SurveyAddResponse{}
-	mi := &file_aidememory_proto_msgTypes[120]
+	mi := &file_aidememory_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6788,7 +7475,7 @@ func (x *SurveyAddResponse) String() string {
 func (*SurveyAddResponse) ProtoMessage() {}
 
 func (x *SurveyAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[120]
+	mi := &file_aidememory_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6801,7 +7488,7 @@ func (x *SurveyAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyAddResponse.ProtoReflect.Descriptor instead.
 func (*SurveyAddResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{120}
+	return file_aidememory_proto_rawDescGZIP(), []int{131}
 }
 
 func (x *SurveyAddResponse) GetEntry() *SurveyEntry {
@@ -6820,7 +7507,7 @@ type SurveyGetRequest struct {
 
 func (x *SurveyGetRequest) Reset() {
 	*x = SurveyGetRequest{}
-	mi := &file_aidememory_proto_msgTypes[121]
+	mi := &file_aidememory_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6832,7 +7519,7 @@ func (x *SurveyGetRequest) String() string {
 func (*SurveyGetRequest) ProtoMessage() {}
 
 func (x *SurveyGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[121]
+	mi := &file_aidememory_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6845,7 +7532,7 @@ func (x *SurveyGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyGetRequest.ProtoReflect.Descriptor instead.
 func (*SurveyGetRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{121}
+	return file_aidememory_proto_rawDescGZIP(), []int{132}
 }
 
 func (x *SurveyGetRequest) GetId() string {
@@ -6865,7 +7552,7 @@ type SurveyGetResponse struct {
 
 func (x *SurveyGetResponse) Reset() {
 	*x = SurveyGetResponse{}
-	mi := &file_aidememory_proto_msgTypes[122]
+	mi := &file_aidememory_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6877,7 +7564,7 @@ func (x *SurveyGetResponse) String() string {
 func (*SurveyGetResponse) ProtoMessage() {}
 
 func (x *SurveyGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[122]
+	mi := &file_aidememory_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6890,7 +7577,7 @@ func (x *SurveyGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyGetResponse.ProtoReflect.Descriptor instead.
 func (*SurveyGetResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{122}
+	return file_aidememory_proto_rawDescGZIP(), []int{133}
 }
 
 func (x *SurveyGetResponse) GetEntry() *SurveyEntry {
@@ -6916,7 +7603,7 @@ type SurveyDeleteRequest struct {
 
 func (x *SurveyDeleteRequest) Reset() {
 	*x = SurveyDeleteRequest{}
-	mi := &file_aidememory_proto_msgTypes[123]
+	mi := &file_aidememory_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6928,7 +7615,7 @@ func (x *SurveyDeleteRequest) String() string {
 func (*SurveyDeleteRequest) ProtoMessage() {}
 
 func (x *SurveyDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[123]
+	mi := &file_aidememory_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6941,7 +7628,7 @@ func (x *SurveyDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyDeleteRequest.ProtoReflect.Descriptor instead.
 func (*SurveyDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{123}
+	return file_aidememory_proto_rawDescGZIP(), []int{134}
 }
 
 func (x *SurveyDeleteRequest) GetId() string {
@@ -6960,7 +7647,7 @@ type SurveyDeleteResponse struct {
 
 func (x *SurveyDeleteResponse) Reset() {
 	*x = SurveyDeleteResponse{}
-	mi := &file_aidememory_proto_msgTypes[124]
+	mi := &file_aidememory_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -6972,7 +7659,7 @@ func (x *SurveyDeleteResponse) String() string {
 func (*SurveyDeleteResponse) ProtoMessage() {}
 
 func (x *SurveyDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[124]
+	mi := &file_aidememory_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -6985,7 +7672,7 @@ func (x *SurveyDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyDeleteResponse.ProtoReflect.Descriptor instead.
 func (*SurveyDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{124}
+	return file_aidememory_proto_rawDescGZIP(), []int{135}
 }
 
 func (x *SurveyDeleteResponse) GetSuccess() bool {
@@ -7008,7 +7695,7 @@ type SurveySearchRequest struct {
 
 func (x *SurveySearchRequest) Reset() {
 	*x = SurveySearchRequest{}
-	mi := &file_aidememory_proto_msgTypes[125]
+	mi := &file_aidememory_proto_msgTypes[136]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7020,7 +7707,7 @@ func (x *SurveySearchRequest) String() string {
 func (*SurveySearchRequest) ProtoMessage() {}
 
 func (x *SurveySearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[125]
+	mi := &file_aidememory_proto_msgTypes[136]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7033,7 +7720,7 @@ func (x *SurveySearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveySearchRequest.ProtoReflect.Descriptor instead.
 func (*SurveySearchRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{125}
+	return file_aidememory_proto_rawDescGZIP(), []int{136}
 }
 
 func (x *SurveySearchRequest) GetQuery() string {
@@ -7080,7 +7767,7 @@ type SurveySearchResponse struct {
 
 func (x *SurveySearchResponse) Reset() {
 	*x = SurveySearchResponse{}
-	mi := &file_aidememory_proto_msgTypes[126]
+	mi := &file_aidememory_proto_msgTypes[137]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7092,7 +7779,7 @@ func (x *SurveySearchResponse) String() string {
 func (*SurveySearchResponse) ProtoMessage() {}
 
 func (x *SurveySearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[126]
+	mi := &file_aidememory_proto_msgTypes[137]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7105,7 +7792,7 @@ func (x *SurveySearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveySearchResponse.ProtoReflect.Descriptor instead.
 func (*SurveySearchResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{126}
+	return file_aidememory_proto_rawDescGZIP(), []int{137}
 }
 
 func (x *SurveySearchResponse) GetEntries() []*SurveyEntry {
@@ -7127,7 +7814,7 @@ type SurveyListRequest struct {
 
 func (x *SurveyListRequest) Reset() {
 	*x = SurveyListRequest{}
-	mi := &file_aidememory_proto_msgTypes[127]
+	mi := &file_aidememory_proto_msgTypes[138]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7139,7 +7826,7 @@ func (x *SurveyListRequest) String() string {
 func (*SurveyListRequest) ProtoMessage() {}
 
 func (x *SurveyListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[127]
+	mi := &file_aidememory_proto_msgTypes[138]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7152,7 +7839,7 @@ func (x *SurveyListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyListRequest.ProtoReflect.Descriptor instead.
 func (*SurveyListRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{127}
+	return file_aidememory_proto_rawDescGZIP(), []int{138}
 }
 
 func (x *SurveyListRequest) GetAnalyzer() string {
@@ -7192,7 +7879,7 @@ type SurveyFileRequest struct {
 
 func (x *SurveyFileRequest) Reset() {
 	*x = SurveyFileRequest{}
-	mi := &file_aidememory_proto_msgTypes[128]
+	mi := &file_aidememory_proto_msgTypes[139]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7204,7 +7891,7 @@ func (x *SurveyFileRequest) String() string {
 func (*SurveyFileRequest) ProtoMessage() {}
 
 func (x *SurveyFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[128]
+	mi := &file_aidememory_proto_msgTypes[139]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7217,7 +7904,7 @@ func (x *SurveyFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyFileRequest.ProtoReflect.Descriptor instead.
 func (*SurveyFileRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{128}
+	return file_aidememory_proto_rawDescGZIP(), []int{139}
 }
 
 func (x *SurveyFileRequest) GetFilePath() string {
@@ -7236,7 +7923,7 @@ type SurveyClearAnalyzerRequest struct {
 
 func (x *SurveyClearAnalyzerRequest) Reset() {
 	*x = SurveyClearAnalyzerRequest{}
-	mi := &file_aidememory_proto_msgTypes[129]
+	mi := &file_aidememory_proto_msgTypes[140]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7248,7 +7935,7 @@ func (x *SurveyClearAnalyzerRequest) String() string {
 func (*SurveyClearAnalyzerRequest) ProtoMessage() {}
 
 func (x *SurveyClearAnalyzerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[129]
+	mi := &file_aidememory_proto_msgTypes[140]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7261,7 +7948,7 @@ func (x *SurveyClearAnalyzerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyClearAnalyzerRequest.ProtoReflect.Descriptor instead.
 func (*SurveyClearAnalyzerRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{129}
+	return file_aidememory_proto_rawDescGZIP(), []int{140}
 }
 
 func (x *SurveyClearAnalyzerRequest) GetAnalyzer() string {
@@ -7280,7 +7967,7 @@ type SurveyClearAnalyzerResponse struct {
 
 func (x *SurveyClearAnalyzerResponse) Reset() {
 	*x = SurveyClearAnalyzerResponse{}
-	mi := &file_aidememory_proto_msgTypes[130]
+	mi := &file_aidememory_proto_msgTypes[141]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7292,7 +7979,7 @@ func (x *SurveyClearAnalyzerResponse) String() string {
 func (*SurveyClearAnalyzerResponse) ProtoMessage() {}
 
 func (x *SurveyClearAnalyzerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[130]
+	mi := &file_aidememory_proto_msgTypes[141]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7305,7 +7992,7 @@ func (x *SurveyClearAnalyzerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyClearAnalyzerResponse.ProtoReflect.Descriptor instead.
 func (*SurveyClearAnalyzerResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{130}
+	return file_aidememory_proto_rawDescGZIP(), []int{141}
 }
 
 func (x *SurveyClearAnalyzerResponse) GetCount() int32 {
@@ -7323,7 +8010,7 @@ type SurveyStatsRequest struct {
 
 func (x *SurveyStatsRequest) Reset() {
 	*x = SurveyStatsRequest{}
-	mi := &file_aidememory_proto_msgTypes[131]
+	mi := &file_aidememory_proto_msgTypes[142]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7335,7 +8022,7 @@ func (x *SurveyStatsRequest) String() string {
 func (*SurveyStatsRequest) ProtoMessage() {}
 
 func (x *SurveyStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[131]
+	mi := &file_aidememory_proto_msgTypes[142]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7348,7 +8035,7 @@ func (x *SurveyStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyStatsRequest.ProtoReflect.Descriptor instead.
 func (*SurveyStatsRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{131}
+	return file_aidememory_proto_rawDescGZIP(), []int{142}
 }
 
 type SurveyStatsResponse struct {
@@ -7362,7 +8049,7 @@ type SurveyStatsResponse struct {
 
 func (x *SurveyStatsResponse) Reset() {
 	*x = SurveyStatsResponse{}
-	mi := &file_aidememory_proto_msgTypes[132]
+	mi := &file_aidememory_proto_msgTypes[143]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7374,7 +8061,7 @@ func (x *SurveyStatsResponse) String() string {
 func (*SurveyStatsResponse) ProtoMessage() {}
 
 func (x *SurveyStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[132]
+	mi := &file_aidememory_proto_msgTypes[143]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7387,7 +8074,7 @@ func (x *SurveyStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyStatsResponse.ProtoReflect.Descriptor instead.
 func (*SurveyStatsResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{132}
+	return file_aidememory_proto_rawDescGZIP(), []int{143}
 }
 
 func (x *SurveyStatsResponse) GetTotal() int32 {
@@ -7419,7 +8106,7 @@ type SurveyClearRequest struct {
 
 func (x *SurveyClearRequest) Reset() {
 	*x = SurveyClearRequest{}
-	mi := &file_aidememory_proto_msgTypes[133]
+	mi := &file_aidememory_proto_msgTypes[144]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7431,7 +8118,7 @@ func (x *SurveyClearRequest) String() string {
 func (*SurveyClearRequest) ProtoMessage() {}
 
 func (x *SurveyClearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[133]
+	mi := &file_aidememory_proto_msgTypes[144]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7444,7 +8131,7 @@ func (x *SurveyClearRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyClearRequest.ProtoReflect.Descriptor instead.
 func (*SurveyClearRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{133}
+	return file_aidememory_proto_rawDescGZIP(), []int{144}
 }
 
 type SurveyClearResponse struct {
@@ -7456,7 +8143,7 @@ type SurveyClearResponse struct {
 
 func (x *SurveyClearResponse) Reset() {
 	*x = SurveyClearResponse{}
-	mi := &file_aidememory_proto_msgTypes[134]
+	mi := &file_aidememory_proto_msgTypes[145]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7468,7 +8155,7 @@ func (x *SurveyClearResponse) String() string {
 func (*SurveyClearResponse) ProtoMessage() {}
 
 func (x *SurveyClearResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[134]
+	mi := &file_aidememory_proto_msgTypes[145]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7481,7 +8168,7 @@ func (x *SurveyClearResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurveyClearResponse.ProtoReflect.Descriptor instead.
 func (*SurveyClearResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{134}
+	return file_aidememory_proto_rawDescGZIP(), []int{145}
 }
 
 func (x *SurveyClearResponse) GetSuccess() bool {
@@ -7502,7 +8189,7 @@ type Tombstone struct {
 
 func (x *Tombstone) Reset() {
 	*x = Tombstone{}
-	mi := &file_aidememory_proto_msgTypes[135]
+	mi := &file_aidememory_proto_msgTypes[146]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7514,7 +8201,7 @@ func (x *Tombstone) String() string {
 func (*Tombstone) ProtoMessage() {}
 
 func (x *Tombstone) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[135]
+	mi := &file_aidememory_proto_msgTypes[146]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7527,7 +8214,7 @@ func (x *Tombstone) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Tombstone.ProtoReflect.Descriptor instead.
 func (*Tombstone) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{135}
+	return file_aidememory_proto_rawDescGZIP(), []int{146}
 }
 
 func (x *Tombstone) GetId() string {
@@ -7560,7 +8247,7 @@ type TombstoneAddRequest struct {
 
 func (x *TombstoneAddRequest) Reset() {
 	*x = TombstoneAddRequest{}
-	mi := &file_aidememory_proto_msgTypes[136]
+	mi := &file_aidememory_proto_msgTypes[147]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7572,7 +8259,7 @@ func (x *TombstoneAddRequest) String() string {
 func (*TombstoneAddRequest) ProtoMessage() {}
 
 func (x *TombstoneAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[136]
+	mi := &file_aidememory_proto_msgTypes[147]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7585,7 +8272,7 @@ func (x *TombstoneAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneAddRequest.ProtoReflect.Descriptor instead.
 func (*TombstoneAddRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{136}
+	return file_aidememory_proto_rawDescGZIP(), []int{147}
 }
 
 func (x *TombstoneAddRequest) GetTombstone() *Tombstone {
@@ -7604,7 +8291,7 @@ type TombstoneAddResponse struct {
 
 func (x *TombstoneAddResponse) Reset() {
 	*x = TombstoneAddResponse{}
-	mi := &file_aidememory_proto_msgTypes[137]
+	mi := &file_aidememory_proto_msgTypes[148]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7616,7 +8303,7 @@ func (x *TombstoneAddResponse) String() string {
 func (*TombstoneAddResponse) ProtoMessage() {}
 
 func (x *TombstoneAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[137]
+	mi := &file_aidememory_proto_msgTypes[148]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7629,7 +8316,7 @@ func (x *TombstoneAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneAddResponse.ProtoReflect.Descriptor instead.
 func (*TombstoneAddResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{137}
+	return file_aidememory_proto_rawDescGZIP(), []int{148}
 }
 
 func (x *TombstoneAddResponse) GetTombstone() *Tombstone {
@@ -7649,7 +8336,7 @@ type TombstoneGetRequest struct {
 
 func (x *TombstoneGetRequest) Reset() {
 	*x = TombstoneGetRequest{}
-	mi := &file_aidememory_proto_msgTypes[138]
+	mi := &file_aidememory_proto_msgTypes[149]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7661,7 +8348,7 @@ func (x *TombstoneGetRequest) String() string {
 func (*TombstoneGetRequest) ProtoMessage() {}
 
 func (x *TombstoneGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[138]
+	mi := &file_aidememory_proto_msgTypes[149]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7674,7 +8361,7 @@ func (x *TombstoneGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneGetRequest.ProtoReflect.Descriptor instead.
 func (*TombstoneGetRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{138}
+	return file_aidememory_proto_rawDescGZIP(), []int{149}
 }
 
 func (x *TombstoneGetRequest) GetKind() string {
@@ -7701,7 +8388,7 @@ type TombstoneGetResponse struct {
 
 func (x *TombstoneGetResponse) Reset() {
 	*x = TombstoneGetResponse{}
-	mi := &file_aidememory_proto_msgTypes[139]
+	mi := &file_aidememory_proto_msgTypes[150]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7713,7 +8400,7 @@ func (x *TombstoneGetResponse) String() string {
 func (*TombstoneGetResponse) ProtoMessage() {}
 
 func (x *TombstoneGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[139]
+	mi := &file_aidememory_proto_msgTypes[150]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7726,7 +8413,7 @@ func (x *TombstoneGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneGetResponse.ProtoReflect.Descriptor instead.
 func (*TombstoneGetResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{139}
+	return file_aidememory_proto_rawDescGZIP(), []int{150}
 }
 
 func (x *TombstoneGetResponse) GetTombstone() *Tombstone {
@@ -7751,7 +8438,7 @@ type TombstoneListRequest struct {
 
 func (x *TombstoneListRequest) Reset() {
 	*x = TombstoneListRequest{}
-	mi := &file_aidememory_proto_msgTypes[140]
+	mi := &file_aidememory_proto_msgTypes[151]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7763,7 +8450,7 @@ func (x *TombstoneListRequest) String() string {
 func (*TombstoneListRequest) ProtoMessage() {}
 
 func (x *TombstoneListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[140]
+	mi := &file_aidememory_proto_msgTypes[151]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7776,7 +8463,7 @@ func (x *TombstoneListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneListRequest.ProtoReflect.Descriptor instead.
 func (*TombstoneListRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{140}
+	return file_aidememory_proto_rawDescGZIP(), []int{151}
 }
 
 type TombstoneListResponse struct {
@@ -7788,7 +8475,7 @@ type TombstoneListResponse struct {
 
 func (x *TombstoneListResponse) Reset() {
 	*x = TombstoneListResponse{}
-	mi := &file_aidememory_proto_msgTypes[141]
+	mi := &file_aidememory_proto_msgTypes[152]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7800,7 +8487,7 @@ func (x *TombstoneListResponse) String() string {
 func (*TombstoneListResponse) ProtoMessage() {}
 
 func (x *TombstoneListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[141]
+	mi := &file_aidememory_proto_msgTypes[152]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7813,7 +8500,7 @@ func (x *TombstoneListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneListResponse.ProtoReflect.Descriptor instead.
 func (*TombstoneListResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{141}
+	return file_aidememory_proto_rawDescGZIP(), []int{152}
 }
 
 func (x *TombstoneListResponse) GetTombstones() []*Tombstone {
@@ -7833,7 +8520,7 @@ type TombstoneDeleteRequest struct {
 
 func (x *TombstoneDeleteRequest) Reset() {
 	*x = TombstoneDeleteRequest{}
-	mi := &file_aidememory_proto_msgTypes[142]
+	mi := &file_aidememory_proto_msgTypes[153]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7845,7 +8532,7 @@ func (x *TombstoneDeleteRequest) String() string {
 func (*TombstoneDeleteRequest) ProtoMessage() {}
 
 func (x *TombstoneDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[142]
+	mi := &file_aidememory_proto_msgTypes[153]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7858,7 +8545,7 @@ func (x *TombstoneDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneDeleteRequest.ProtoReflect.Descriptor instead.
 func (*TombstoneDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{142}
+	return file_aidememory_proto_rawDescGZIP(), []int{153}
 }
 
 func (x *TombstoneDeleteRequest) GetKind() string {
@@ -7884,7 +8571,7 @@ type TombstoneDeleteResponse struct {
 
 func (x *TombstoneDeleteResponse) Reset() {
 	*x = TombstoneDeleteResponse{}
-	mi := &file_aidememory_proto_msgTypes[143]
+	mi := &file_aidememory_proto_msgTypes[154]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7896,7 +8583,7 @@ func (x *TombstoneDeleteResponse) String() string {
 func (*TombstoneDeleteResponse) ProtoMessage() {}
 
 func (x *TombstoneDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[143]
+	mi := &file_aidememory_proto_msgTypes[154]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7909,7 +8596,7 @@ func (x *TombstoneDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TombstoneDeleteResponse.ProtoReflect.Descriptor instead.
 func (*TombstoneDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{143}
+	return file_aidememory_proto_rawDescGZIP(), []int{154}
 }
 
 func (x *TombstoneDeleteResponse) GetSuccess() bool {
@@ -7927,7 +8614,7 @@ type HealthCheckRequest struct {
 
 func (x *HealthCheckRequest) Reset() {
 	*x = HealthCheckRequest{}
-	mi := &file_aidememory_proto_msgTypes[144]
+	mi := &file_aidememory_proto_msgTypes[155]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7939,7 +8626,7 @@ func (x *HealthCheckRequest) String() string {
 func (*HealthCheckRequest) ProtoMessage() {}
 
 func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[144]
+	mi := &file_aidememory_proto_msgTypes[155]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7952,7 +8639,7 @@ func (x *HealthCheckRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckRequest.ProtoReflect.Descriptor instead.
 func (*HealthCheckRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{144}
+	return file_aidememory_proto_rawDescGZIP(), []int{155}
 }
 
 type HealthCheckResponse struct {
@@ -7972,7 +8659,7 @@ type HealthCheckResponse struct {
 
 func (x *HealthCheckResponse) Reset() {
 	*x = HealthCheckResponse{}
-	mi := &file_aidememory_proto_msgTypes[145]
+	mi := &file_aidememory_proto_msgTypes[156]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -7984,7 +8671,7 @@ func (x *HealthCheckResponse) String() string {
 func (*HealthCheckResponse) ProtoMessage() {}
 
 func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[145]
+	mi := &file_aidememory_proto_msgTypes[156]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -7997,7 +8684,7 @@ func (x *HealthCheckResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthCheckResponse.ProtoReflect.Descriptor instead.
 func (*HealthCheckResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{145}
+	return file_aidememory_proto_rawDescGZIP(), []int{156}
 }
 
 func (x *HealthCheckResponse) GetHealthy() bool {
@@ -8057,7 +8744,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_aidememory_proto_msgTypes[146]
+	mi := &file_aidememory_proto_msgTypes[157]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8069,7 +8756,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[146]
+	mi := &file_aidememory_proto_msgTypes[157]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8082,7 +8769,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{146}
+	return file_aidememory_proto_rawDescGZIP(), []int{157}
 }
 
 type StatusResponse struct {
@@ -8113,7 +8800,7 @@ type StatusResponse struct {
 
 func (x *StatusResponse) Reset() {
 	*x = StatusResponse{}
-	mi := &file_aidememory_proto_msgTypes[147]
+	mi := &file_aidememory_proto_msgTypes[158]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8125,7 +8812,7 @@ func (x *StatusResponse) String() string {
 func (*StatusResponse) ProtoMessage() {}
 
 func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[147]
+	mi := &file_aidememory_proto_msgTypes[158]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8138,7 +8825,7 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{147}
+	return file_aidememory_proto_rawDescGZIP(), []int{158}
 }
 
 func (x *StatusResponse) GetVersion() string {
@@ -8232,7 +8919,7 @@ type StatusWatcher struct {
 
 func (x *StatusWatcher) Reset() {
 	*x = StatusWatcher{}
-	mi := &file_aidememory_proto_msgTypes[148]
+	mi := &file_aidememory_proto_msgTypes[159]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8244,7 +8931,7 @@ func (x *StatusWatcher) String() string {
 func (*StatusWatcher) ProtoMessage() {}
 
 func (x *StatusWatcher) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[148]
+	mi := &file_aidememory_proto_msgTypes[159]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8257,7 +8944,7 @@ func (x *StatusWatcher) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusWatcher.ProtoReflect.Descriptor instead.
 func (*StatusWatcher) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{148}
+	return file_aidememory_proto_rawDescGZIP(), []int{159}
 }
 
 func (x *StatusWatcher) GetEnabled() bool {
@@ -8315,7 +9002,7 @@ type StatusCodeIndexer struct {
 
 func (x *StatusCodeIndexer) Reset() {
 	*x = StatusCodeIndexer{}
-	mi := &file_aidememory_proto_msgTypes[149]
+	mi := &file_aidememory_proto_msgTypes[160]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8327,7 +9014,7 @@ func (x *StatusCodeIndexer) String() string {
 func (*StatusCodeIndexer) ProtoMessage() {}
 
 func (x *StatusCodeIndexer) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[149]
+	mi := &file_aidememory_proto_msgTypes[160]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8340,7 +9027,7 @@ func (x *StatusCodeIndexer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusCodeIndexer.ProtoReflect.Descriptor instead.
 func (*StatusCodeIndexer) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{149}
+	return file_aidememory_proto_rawDescGZIP(), []int{160}
 }
 
 func (x *StatusCodeIndexer) GetAvailable() bool {
@@ -8391,7 +9078,7 @@ type StatusFindings struct {
 
 func (x *StatusFindings) Reset() {
 	*x = StatusFindings{}
-	mi := &file_aidememory_proto_msgTypes[150]
+	mi := &file_aidememory_proto_msgTypes[161]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8403,7 +9090,7 @@ func (x *StatusFindings) String() string {
 func (*StatusFindings) ProtoMessage() {}
 
 func (x *StatusFindings) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[150]
+	mi := &file_aidememory_proto_msgTypes[161]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8416,7 +9103,7 @@ func (x *StatusFindings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusFindings.ProtoReflect.Descriptor instead.
 func (*StatusFindings) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{150}
+	return file_aidememory_proto_rawDescGZIP(), []int{161}
 }
 
 func (x *StatusFindings) GetAvailable() bool {
@@ -8467,7 +9154,7 @@ type StatusAnalyzer struct {
 
 func (x *StatusAnalyzer) Reset() {
 	*x = StatusAnalyzer{}
-	mi := &file_aidememory_proto_msgTypes[151]
+	mi := &file_aidememory_proto_msgTypes[162]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8479,7 +9166,7 @@ func (x *StatusAnalyzer) String() string {
 func (*StatusAnalyzer) ProtoMessage() {}
 
 func (x *StatusAnalyzer) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[151]
+	mi := &file_aidememory_proto_msgTypes[162]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8492,7 +9179,7 @@ func (x *StatusAnalyzer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusAnalyzer.ProtoReflect.Descriptor instead.
 func (*StatusAnalyzer) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{151}
+	return file_aidememory_proto_rawDescGZIP(), []int{162}
 }
 
 func (x *StatusAnalyzer) GetStatus() string {
@@ -8541,7 +9228,7 @@ type StatusMCPTool struct {
 
 func (x *StatusMCPTool) Reset() {
 	*x = StatusMCPTool{}
-	mi := &file_aidememory_proto_msgTypes[152]
+	mi := &file_aidememory_proto_msgTypes[163]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8553,7 +9240,7 @@ func (x *StatusMCPTool) String() string {
 func (*StatusMCPTool) ProtoMessage() {}
 
 func (x *StatusMCPTool) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[152]
+	mi := &file_aidememory_proto_msgTypes[163]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8566,7 +9253,7 @@ func (x *StatusMCPTool) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusMCPTool.ProtoReflect.Descriptor instead.
 func (*StatusMCPTool) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{152}
+	return file_aidememory_proto_rawDescGZIP(), []int{163}
 }
 
 func (x *StatusMCPTool) GetName() string {
@@ -8602,7 +9289,7 @@ type StatusSurvey struct {
 
 func (x *StatusSurvey) Reset() {
 	*x = StatusSurvey{}
-	mi := &file_aidememory_proto_msgTypes[153]
+	mi := &file_aidememory_proto_msgTypes[164]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8614,7 +9301,7 @@ func (x *StatusSurvey) String() string {
 func (*StatusSurvey) ProtoMessage() {}
 
 func (x *StatusSurvey) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[153]
+	mi := &file_aidememory_proto_msgTypes[164]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8627,7 +9314,7 @@ func (x *StatusSurvey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusSurvey.ProtoReflect.Descriptor instead.
 func (*StatusSurvey) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{153}
+	return file_aidememory_proto_rawDescGZIP(), []int{164}
 }
 
 func (x *StatusSurvey) GetAvailable() bool {
@@ -8669,7 +9356,7 @@ type StatusStore struct {
 
 func (x *StatusStore) Reset() {
 	*x = StatusStore{}
-	mi := &file_aidememory_proto_msgTypes[154]
+	mi := &file_aidememory_proto_msgTypes[165]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8681,7 +9368,7 @@ func (x *StatusStore) String() string {
 func (*StatusStore) ProtoMessage() {}
 
 func (x *StatusStore) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[154]
+	mi := &file_aidememory_proto_msgTypes[165]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8694,7 +9381,7 @@ func (x *StatusStore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusStore.ProtoReflect.Descriptor instead.
 func (*StatusStore) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{154}
+	return file_aidememory_proto_rawDescGZIP(), []int{165}
 }
 
 func (x *StatusStore) GetName() string {
@@ -8729,7 +9416,7 @@ type StatusGrammar struct {
 
 func (x *StatusGrammar) Reset() {
 	*x = StatusGrammar{}
-	mi := &file_aidememory_proto_msgTypes[155]
+	mi := &file_aidememory_proto_msgTypes[166]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8741,7 +9428,7 @@ func (x *StatusGrammar) String() string {
 func (*StatusGrammar) ProtoMessage() {}
 
 func (x *StatusGrammar) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[155]
+	mi := &file_aidememory_proto_msgTypes[166]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8754,7 +9441,7 @@ func (x *StatusGrammar) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusGrammar.ProtoReflect.Descriptor instead.
 func (*StatusGrammar) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{155}
+	return file_aidememory_proto_rawDescGZIP(), []int{166}
 }
 
 func (x *StatusGrammar) GetName() string {
@@ -8798,7 +9485,7 @@ type ObserveRecordRequest struct {
 
 func (x *ObserveRecordRequest) Reset() {
 	*x = ObserveRecordRequest{}
-	mi := &file_aidememory_proto_msgTypes[156]
+	mi := &file_aidememory_proto_msgTypes[167]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8810,7 +9497,7 @@ func (x *ObserveRecordRequest) String() string {
 func (*ObserveRecordRequest) ProtoMessage() {}
 
 func (x *ObserveRecordRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[156]
+	mi := &file_aidememory_proto_msgTypes[167]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8823,7 +9510,7 @@ func (x *ObserveRecordRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveRecordRequest.ProtoReflect.Descriptor instead.
 func (*ObserveRecordRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{156}
+	return file_aidememory_proto_rawDescGZIP(), []int{167}
 }
 
 func (x *ObserveRecordRequest) GetKind() string {
@@ -8919,7 +9606,7 @@ type ObserveRecordResponse struct {
 
 func (x *ObserveRecordResponse) Reset() {
 	*x = ObserveRecordResponse{}
-	mi := &file_aidememory_proto_msgTypes[157]
+	mi := &file_aidememory_proto_msgTypes[168]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8931,7 +9618,7 @@ func (x *ObserveRecordResponse) String() string {
 func (*ObserveRecordResponse) ProtoMessage() {}
 
 func (x *ObserveRecordResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[157]
+	mi := &file_aidememory_proto_msgTypes[168]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8944,7 +9631,7 @@ func (x *ObserveRecordResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveRecordResponse.ProtoReflect.Descriptor instead.
 func (*ObserveRecordResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{157}
+	return file_aidememory_proto_rawDescGZIP(), []int{168}
 }
 
 func (x *ObserveRecordResponse) GetId() string {
@@ -8969,7 +9656,7 @@ type ObserveListRequest struct {
 
 func (x *ObserveListRequest) Reset() {
 	*x = ObserveListRequest{}
-	mi := &file_aidememory_proto_msgTypes[158]
+	mi := &file_aidememory_proto_msgTypes[169]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -8981,7 +9668,7 @@ func (x *ObserveListRequest) String() string {
 func (*ObserveListRequest) ProtoMessage() {}
 
 func (x *ObserveListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[158]
+	mi := &file_aidememory_proto_msgTypes[169]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -8994,7 +9681,7 @@ func (x *ObserveListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveListRequest.ProtoReflect.Descriptor instead.
 func (*ObserveListRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{158}
+	return file_aidememory_proto_rawDescGZIP(), []int{169}
 }
 
 func (x *ObserveListRequest) GetKind() string {
@@ -9068,7 +9755,7 @@ type ObserveEvent struct {
 
 func (x *ObserveEvent) Reset() {
 	*x = ObserveEvent{}
-	mi := &file_aidememory_proto_msgTypes[159]
+	mi := &file_aidememory_proto_msgTypes[170]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9080,7 +9767,7 @@ func (x *ObserveEvent) String() string {
 func (*ObserveEvent) ProtoMessage() {}
 
 func (x *ObserveEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[159]
+	mi := &file_aidememory_proto_msgTypes[170]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9093,7 +9780,7 @@ func (x *ObserveEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveEvent.ProtoReflect.Descriptor instead.
 func (*ObserveEvent) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{159}
+	return file_aidememory_proto_rawDescGZIP(), []int{170}
 }
 
 func (x *ObserveEvent) GetId() string {
@@ -9203,7 +9890,7 @@ type ObserveListResponse struct {
 
 func (x *ObserveListResponse) Reset() {
 	*x = ObserveListResponse{}
-	mi := &file_aidememory_proto_msgTypes[160]
+	mi := &file_aidememory_proto_msgTypes[171]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9215,7 +9902,7 @@ func (x *ObserveListResponse) String() string {
 func (*ObserveListResponse) ProtoMessage() {}
 
 func (x *ObserveListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[160]
+	mi := &file_aidememory_proto_msgTypes[171]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9228,7 +9915,7 @@ func (x *ObserveListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveListResponse.ProtoReflect.Descriptor instead.
 func (*ObserveListResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{160}
+	return file_aidememory_proto_rawDescGZIP(), []int{171}
 }
 
 func (x *ObserveListResponse) GetEvents() []*ObserveEvent {
@@ -9249,7 +9936,7 @@ type InstinctEvidence struct {
 
 func (x *InstinctEvidence) Reset() {
 	*x = InstinctEvidence{}
-	mi := &file_aidememory_proto_msgTypes[161]
+	mi := &file_aidememory_proto_msgTypes[172]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9261,7 +9948,7 @@ func (x *InstinctEvidence) String() string {
 func (*InstinctEvidence) ProtoMessage() {}
 
 func (x *InstinctEvidence) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[161]
+	mi := &file_aidememory_proto_msgTypes[172]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9274,7 +9961,7 @@ func (x *InstinctEvidence) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctEvidence.ProtoReflect.Descriptor instead.
 func (*InstinctEvidence) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{161}
+	return file_aidememory_proto_rawDescGZIP(), []int{172}
 }
 
 func (x *InstinctEvidence) GetObserveEventIds() []string {
@@ -9310,7 +9997,7 @@ type InstinctProposedMemory struct {
 
 func (x *InstinctProposedMemory) Reset() {
 	*x = InstinctProposedMemory{}
-	mi := &file_aidememory_proto_msgTypes[162]
+	mi := &file_aidememory_proto_msgTypes[173]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9322,7 +10009,7 @@ func (x *InstinctProposedMemory) String() string {
 func (*InstinctProposedMemory) ProtoMessage() {}
 
 func (x *InstinctProposedMemory) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[162]
+	mi := &file_aidememory_proto_msgTypes[173]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9335,7 +10022,7 @@ func (x *InstinctProposedMemory) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctProposedMemory.ProtoReflect.Descriptor instead.
 func (*InstinctProposedMemory) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{162}
+	return file_aidememory_proto_rawDescGZIP(), []int{173}
 }
 
 func (x *InstinctProposedMemory) GetCategory() string {
@@ -9387,7 +10074,7 @@ type InstinctProposal struct {
 
 func (x *InstinctProposal) Reset() {
 	*x = InstinctProposal{}
-	mi := &file_aidememory_proto_msgTypes[163]
+	mi := &file_aidememory_proto_msgTypes[174]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9399,7 +10086,7 @@ func (x *InstinctProposal) String() string {
 func (*InstinctProposal) ProtoMessage() {}
 
 func (x *InstinctProposal) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[163]
+	mi := &file_aidememory_proto_msgTypes[174]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9412,7 +10099,7 @@ func (x *InstinctProposal) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctProposal.ProtoReflect.Descriptor instead.
 func (*InstinctProposal) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{163}
+	return file_aidememory_proto_rawDescGZIP(), []int{174}
 }
 
 func (x *InstinctProposal) GetId() string {
@@ -9518,7 +10205,7 @@ type InstinctListRequest struct {
 
 func (x *InstinctListRequest) Reset() {
 	*x = InstinctListRequest{}
-	mi := &file_aidememory_proto_msgTypes[164]
+	mi := &file_aidememory_proto_msgTypes[175]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9530,7 +10217,7 @@ func (x *InstinctListRequest) String() string {
 func (*InstinctListRequest) ProtoMessage() {}
 
 func (x *InstinctListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[164]
+	mi := &file_aidememory_proto_msgTypes[175]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9543,7 +10230,7 @@ func (x *InstinctListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctListRequest.ProtoReflect.Descriptor instead.
 func (*InstinctListRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{164}
+	return file_aidememory_proto_rawDescGZIP(), []int{175}
 }
 
 func (x *InstinctListRequest) GetStatus() string {
@@ -9583,7 +10270,7 @@ type InstinctListResponse struct {
 
 func (x *InstinctListResponse) Reset() {
 	*x = InstinctListResponse{}
-	mi := &file_aidememory_proto_msgTypes[165]
+	mi := &file_aidememory_proto_msgTypes[176]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9595,7 +10282,7 @@ func (x *InstinctListResponse) String() string {
 func (*InstinctListResponse) ProtoMessage() {}
 
 func (x *InstinctListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[165]
+	mi := &file_aidememory_proto_msgTypes[176]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9608,7 +10295,7 @@ func (x *InstinctListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctListResponse.ProtoReflect.Descriptor instead.
 func (*InstinctListResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{165}
+	return file_aidememory_proto_rawDescGZIP(), []int{176}
 }
 
 func (x *InstinctListResponse) GetProposals() []*InstinctProposal {
@@ -9627,7 +10314,7 @@ type InstinctGetRequest struct {
 
 func (x *InstinctGetRequest) Reset() {
 	*x = InstinctGetRequest{}
-	mi := &file_aidememory_proto_msgTypes[166]
+	mi := &file_aidememory_proto_msgTypes[177]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9639,7 +10326,7 @@ func (x *InstinctGetRequest) String() string {
 func (*InstinctGetRequest) ProtoMessage() {}
 
 func (x *InstinctGetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[166]
+	mi := &file_aidememory_proto_msgTypes[177]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9652,7 +10339,7 @@ func (x *InstinctGetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctGetRequest.ProtoReflect.Descriptor instead.
 func (*InstinctGetRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{166}
+	return file_aidememory_proto_rawDescGZIP(), []int{177}
 }
 
 func (x *InstinctGetRequest) GetId() string {
@@ -9672,7 +10359,7 @@ type InstinctGetResponse struct {
 
 func (x *InstinctGetResponse) Reset() {
 	*x = InstinctGetResponse{}
-	mi := &file_aidememory_proto_msgTypes[167]
+	mi := &file_aidememory_proto_msgTypes[178]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9684,7 +10371,7 @@ func (x *InstinctGetResponse) String() string {
 func (*InstinctGetResponse) ProtoMessage() {}
 
 func (x *InstinctGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[167]
+	mi := &file_aidememory_proto_msgTypes[178]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9697,7 +10384,7 @@ func (x *InstinctGetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctGetResponse.ProtoReflect.Descriptor instead.
 func (*InstinctGetResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{167}
+	return file_aidememory_proto_rawDescGZIP(), []int{178}
 }
 
 func (x *InstinctGetResponse) GetProposal() *InstinctProposal {
@@ -9723,7 +10410,7 @@ type InstinctAddRequest struct {
 
 func (x *InstinctAddRequest) Reset() {
 	*x = InstinctAddRequest{}
-	mi := &file_aidememory_proto_msgTypes[168]
+	mi := &file_aidememory_proto_msgTypes[179]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9735,7 +10422,7 @@ func (x *InstinctAddRequest) String() string {
 func (*InstinctAddRequest) ProtoMessage() {}
 
 func (x *InstinctAddRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[168]
+	mi := &file_aidememory_proto_msgTypes[179]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9748,7 +10435,7 @@ func (x *InstinctAddRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctAddRequest.ProtoReflect.Descriptor instead.
 func (*InstinctAddRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{168}
+	return file_aidememory_proto_rawDescGZIP(), []int{179}
 }
 
 func (x *InstinctAddRequest) GetProposal() *InstinctProposal {
@@ -9767,7 +10454,7 @@ type InstinctAddResponse struct {
 
 func (x *InstinctAddResponse) Reset() {
 	*x = InstinctAddResponse{}
-	mi := &file_aidememory_proto_msgTypes[169]
+	mi := &file_aidememory_proto_msgTypes[180]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9779,7 +10466,7 @@ func (x *InstinctAddResponse) String() string {
 func (*InstinctAddResponse) ProtoMessage() {}
 
 func (x *InstinctAddResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[169]
+	mi := &file_aidememory_proto_msgTypes[180]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9792,7 +10479,7 @@ func (x *InstinctAddResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctAddResponse.ProtoReflect.Descriptor instead.
 func (*InstinctAddResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{169}
+	return file_aidememory_proto_rawDescGZIP(), []int{180}
 }
 
 func (x *InstinctAddResponse) GetProposal() *InstinctProposal {
@@ -9814,7 +10501,7 @@ type InstinctUpdateStatusRequest struct {
 
 func (x *InstinctUpdateStatusRequest) Reset() {
 	*x = InstinctUpdateStatusRequest{}
-	mi := &file_aidememory_proto_msgTypes[170]
+	mi := &file_aidememory_proto_msgTypes[181]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9826,7 +10513,7 @@ func (x *InstinctUpdateStatusRequest) String() string {
 func (*InstinctUpdateStatusRequest) ProtoMessage() {}
 
 func (x *InstinctUpdateStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[170]
+	mi := &file_aidememory_proto_msgTypes[181]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9839,7 +10526,7 @@ func (x *InstinctUpdateStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctUpdateStatusRequest.ProtoReflect.Descriptor instead.
 func (*InstinctUpdateStatusRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{170}
+	return file_aidememory_proto_rawDescGZIP(), []int{181}
 }
 
 func (x *InstinctUpdateStatusRequest) GetId() string {
@@ -9879,7 +10566,7 @@ type InstinctUpdateStatusResponse struct {
 
 func (x *InstinctUpdateStatusResponse) Reset() {
 	*x = InstinctUpdateStatusResponse{}
-	mi := &file_aidememory_proto_msgTypes[171]
+	mi := &file_aidememory_proto_msgTypes[182]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9891,7 +10578,7 @@ func (x *InstinctUpdateStatusResponse) String() string {
 func (*InstinctUpdateStatusResponse) ProtoMessage() {}
 
 func (x *InstinctUpdateStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[171]
+	mi := &file_aidememory_proto_msgTypes[182]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9904,7 +10591,7 @@ func (x *InstinctUpdateStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctUpdateStatusResponse.ProtoReflect.Descriptor instead.
 func (*InstinctUpdateStatusResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{171}
+	return file_aidememory_proto_rawDescGZIP(), []int{182}
 }
 
 func (x *InstinctUpdateStatusResponse) GetProposal() *InstinctProposal {
@@ -9927,7 +10614,7 @@ type InstinctWatchRequest struct {
 
 func (x *InstinctWatchRequest) Reset() {
 	*x = InstinctWatchRequest{}
-	mi := &file_aidememory_proto_msgTypes[172]
+	mi := &file_aidememory_proto_msgTypes[183]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -9939,7 +10626,7 @@ func (x *InstinctWatchRequest) String() string {
 func (*InstinctWatchRequest) ProtoMessage() {}
 
 func (x *InstinctWatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[172]
+	mi := &file_aidememory_proto_msgTypes[183]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -9952,7 +10639,7 @@ func (x *InstinctWatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InstinctWatchRequest.ProtoReflect.Descriptor instead.
 func (*InstinctWatchRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{172}
+	return file_aidememory_proto_rawDescGZIP(), []int{183}
 }
 
 func (x *InstinctWatchRequest) GetStatus() string {
@@ -9997,7 +10684,7 @@ type ObserveWatchRequest struct {
 
 func (x *ObserveWatchRequest) Reset() {
 	*x = ObserveWatchRequest{}
-	mi := &file_aidememory_proto_msgTypes[173]
+	mi := &file_aidememory_proto_msgTypes[184]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10009,7 +10696,7 @@ func (x *ObserveWatchRequest) String() string {
 func (*ObserveWatchRequest) ProtoMessage() {}
 
 func (x *ObserveWatchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[173]
+	mi := &file_aidememory_proto_msgTypes[184]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10022,7 +10709,7 @@ func (x *ObserveWatchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObserveWatchRequest.ProtoReflect.Descriptor instead.
 func (*ObserveWatchRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{173}
+	return file_aidememory_proto_rawDescGZIP(), []int{184}
 }
 
 func (x *ObserveWatchRequest) GetKind() string {
@@ -10071,7 +10758,7 @@ type TokenStatsRequest struct {
 
 func (x *TokenStatsRequest) Reset() {
 	*x = TokenStatsRequest{}
-	mi := &file_aidememory_proto_msgTypes[174]
+	mi := &file_aidememory_proto_msgTypes[185]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10083,7 +10770,7 @@ func (x *TokenStatsRequest) String() string {
 func (*TokenStatsRequest) ProtoMessage() {}
 
 func (x *TokenStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[174]
+	mi := &file_aidememory_proto_msgTypes[185]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10096,7 +10783,7 @@ func (x *TokenStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenStatsRequest.ProtoReflect.Descriptor instead.
 func (*TokenStatsRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{174}
+	return file_aidememory_proto_rawDescGZIP(), []int{185}
 }
 
 func (x *TokenStatsRequest) GetSessionId() string {
@@ -10141,7 +10828,7 @@ type TokenStatsResponse struct {
 
 func (x *TokenStatsResponse) Reset() {
 	*x = TokenStatsResponse{}
-	mi := &file_aidememory_proto_msgTypes[175]
+	mi := &file_aidememory_proto_msgTypes[186]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10153,7 +10840,7 @@ func (x *TokenStatsResponse) String() string {
 func (*TokenStatsResponse) ProtoMessage() {}
 
 func (x *TokenStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[175]
+	mi := &file_aidememory_proto_msgTypes[186]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10166,7 +10853,7 @@ func (x *TokenStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenStatsResponse.ProtoReflect.Descriptor instead.
 func (*TokenStatsResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{175}
+	return file_aidememory_proto_rawDescGZIP(), []int{186}
 }
 
 func (x *TokenStatsResponse) GetTotalRead() int32 {
@@ -10270,7 +10957,7 @@ type TokenEventListRequest struct {
 
 func (x *TokenEventListRequest) Reset() {
 	*x = TokenEventListRequest{}
-	mi := &file_aidememory_proto_msgTypes[176]
+	mi := &file_aidememory_proto_msgTypes[187]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10282,7 +10969,7 @@ func (x *TokenEventListRequest) String() string {
 func (*TokenEventListRequest) ProtoMessage() {}
 
 func (x *TokenEventListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[176]
+	mi := &file_aidememory_proto_msgTypes[187]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10295,7 +10982,7 @@ func (x *TokenEventListRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenEventListRequest.ProtoReflect.Descriptor instead.
 func (*TokenEventListRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{176}
+	return file_aidememory_proto_rawDescGZIP(), []int{187}
 }
 
 func (x *TokenEventListRequest) GetSessionId() string {
@@ -10321,7 +11008,7 @@ type TokenEventListResponse struct {
 
 func (x *TokenEventListResponse) Reset() {
 	*x = TokenEventListResponse{}
-	mi := &file_aidememory_proto_msgTypes[177]
+	mi := &file_aidememory_proto_msgTypes[188]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10333,7 +11020,7 @@ func (x *TokenEventListResponse) String() string {
 func (*TokenEventListResponse) ProtoMessage() {}
 
 func (x *TokenEventListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[177]
+	mi := &file_aidememory_proto_msgTypes[188]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10346,7 +11033,7 @@ func (x *TokenEventListResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenEventListResponse.ProtoReflect.Descriptor instead.
 func (*TokenEventListResponse) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{177}
+	return file_aidememory_proto_rawDescGZIP(), []int{188}
 }
 
 func (x *TokenEventListResponse) GetEvents() []*TokenEventItem {
@@ -10372,7 +11059,7 @@ type TokenEventItem struct {
 
 func (x *TokenEventItem) Reset() {
 	*x = TokenEventItem{}
-	mi := &file_aidememory_proto_msgTypes[178]
+	mi := &file_aidememory_proto_msgTypes[189]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10384,7 +11071,7 @@ func (x *TokenEventItem) String() string {
 func (*TokenEventItem) ProtoMessage() {}
 
 func (x *TokenEventItem) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[178]
+	mi := &file_aidememory_proto_msgTypes[189]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10397,7 +11084,7 @@ func (x *TokenEventItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenEventItem.ProtoReflect.Descriptor instead.
 func (*TokenEventItem) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{178}
+	return file_aidememory_proto_rawDescGZIP(), []int{189}
 }
 
 func (x *TokenEventItem) GetId() string {
@@ -10466,7 +11153,7 @@ type SwarmWatchTasksRequest struct {
 
 func (x *SwarmWatchTasksRequest) Reset() {
 	*x = SwarmWatchTasksRequest{}
-	mi := &file_aidememory_proto_msgTypes[179]
+	mi := &file_aidememory_proto_msgTypes[190]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10478,7 +11165,7 @@ func (x *SwarmWatchTasksRequest) String() string {
 func (*SwarmWatchTasksRequest) ProtoMessage() {}
 
 func (x *SwarmWatchTasksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[179]
+	mi := &file_aidememory_proto_msgTypes[190]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10491,7 +11178,7 @@ func (x *SwarmWatchTasksRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwarmWatchTasksRequest.ProtoReflect.Descriptor instead.
 func (*SwarmWatchTasksRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{179}
+	return file_aidememory_proto_rawDescGZIP(), []int{190}
 }
 
 func (x *SwarmWatchTasksRequest) GetParentSessionId() string {
@@ -10519,7 +11206,7 @@ type SwarmWatchMessagesRequest struct {
 
 func (x *SwarmWatchMessagesRequest) Reset() {
 	*x = SwarmWatchMessagesRequest{}
-	mi := &file_aidememory_proto_msgTypes[180]
+	mi := &file_aidememory_proto_msgTypes[191]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10531,7 +11218,7 @@ func (x *SwarmWatchMessagesRequest) String() string {
 func (*SwarmWatchMessagesRequest) ProtoMessage() {}
 
 func (x *SwarmWatchMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[180]
+	mi := &file_aidememory_proto_msgTypes[191]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10544,7 +11231,7 @@ func (x *SwarmWatchMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwarmWatchMessagesRequest.ProtoReflect.Descriptor instead.
 func (*SwarmWatchMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{180}
+	return file_aidememory_proto_rawDescGZIP(), []int{191}
 }
 
 func (x *SwarmWatchMessagesRequest) GetParentSessionId() string {
@@ -10578,7 +11265,7 @@ type SwarmWatchStateRequest struct {
 
 func (x *SwarmWatchStateRequest) Reset() {
 	*x = SwarmWatchStateRequest{}
-	mi := &file_aidememory_proto_msgTypes[181]
+	mi := &file_aidememory_proto_msgTypes[192]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10590,7 +11277,7 @@ func (x *SwarmWatchStateRequest) String() string {
 func (*SwarmWatchStateRequest) ProtoMessage() {}
 
 func (x *SwarmWatchStateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[181]
+	mi := &file_aidememory_proto_msgTypes[192]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10603,7 +11290,7 @@ func (x *SwarmWatchStateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwarmWatchStateRequest.ProtoReflect.Descriptor instead.
 func (*SwarmWatchStateRequest) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{181}
+	return file_aidememory_proto_rawDescGZIP(), []int{192}
 }
 
 func (x *SwarmWatchStateRequest) GetAgentId() string {
@@ -10630,7 +11317,7 @@ type StateChange struct {
 
 func (x *StateChange) Reset() {
 	*x = StateChange{}
-	mi := &file_aidememory_proto_msgTypes[182]
+	mi := &file_aidememory_proto_msgTypes[193]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -10642,7 +11329,7 @@ func (x *StateChange) String() string {
 func (*StateChange) ProtoMessage() {}
 
 func (x *StateChange) ProtoReflect() protoreflect.Message {
-	mi := &file_aidememory_proto_msgTypes[182]
+	mi := &file_aidememory_proto_msgTypes[193]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -10655,7 +11342,7 @@ func (x *StateChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StateChange.ProtoReflect.Descriptor instead.
 func (*StateChange) Descriptor() ([]byte, []int) {
-	return file_aidememory_proto_rawDescGZIP(), []int{182}
+	return file_aidememory_proto_rawDescGZIP(), []int{193}
 }
 
 func (x *StateChange) GetState() *State {
@@ -11082,40 +11769,47 @@ const file_aidememory_proto_rawDesc = "" +
 	"\x14FindingDeleteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
 	"\x15FindingDeleteResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb3\x01\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xc9\x01\n" +
 	"\x14FindingSearchRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
 	"\banalyzer\x18\x02 \x01(\tR\banalyzer\x12\x1a\n" +
 	"\bseverity\x18\x03 \x01(\tR\bseverity\x12\x1b\n" +
 	"\tfile_path\x18\x04 \x01(\tR\bfilePath\x12\x1a\n" +
 	"\bcategory\x18\x05 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x06 \x01(\x05R\x05limit\"H\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05state\x18\a \x01(\tR\x05state\"H\n" +
 	"\x15FindingSearchResponse\x12/\n" +
-	"\bfindings\x18\x01 \x03(\v2\x13.aidememory.FindingR\bfindings\"\x9b\x01\n" +
+	"\bfindings\x18\x01 \x03(\v2\x13.aidememory.FindingR\bfindings\"\xb1\x01\n" +
 	"\x12FindingListRequest\x12\x1a\n" +
 	"\banalyzer\x18\x01 \x01(\tR\banalyzer\x12\x1a\n" +
 	"\bseverity\x18\x02 \x01(\tR\bseverity\x12\x1b\n" +
 	"\tfile_path\x18\x03 \x01(\tR\bfilePath\x12\x1a\n" +
 	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x14\n" +
-	"\x05limit\x18\x05 \x01(\x05R\x05limit\"1\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\"1\n" +
 	"\x12FindingFileRequest\x12\x1b\n" +
 	"\tfile_path\x18\x01 \x01(\tR\bfilePath\"9\n" +
 	"\x1bFindingClearAnalyzerRequest\x12\x1a\n" +
 	"\banalyzer\x18\x01 \x01(\tR\banalyzer\"4\n" +
 	"\x1cFindingClearAnalyzerResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x05R\x05count\"\x15\n" +
-	"\x13FindingStatsRequest\"\xd0\x02\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"+\n" +
+	"\x13FindingStatsRequest\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\"\xd2\x03\n" +
 	"\x14FindingStatsResponse\x12\x14\n" +
 	"\x05total\x18\x01 \x01(\x05R\x05total\x12Q\n" +
 	"\vby_analyzer\x18\x02 \x03(\v20.aidememory.FindingStatsResponse.ByAnalyzerEntryR\n" +
 	"byAnalyzer\x12Q\n" +
 	"\vby_severity\x18\x03 \x03(\v20.aidememory.FindingStatsResponse.BySeverityEntryR\n" +
-	"bySeverity\x1a=\n" +
+	"bySeverity\x12E\n" +
+	"\aby_rule\x18\x04 \x03(\v2,.aidememory.FindingStatsResponse.ByRuleEntryR\x06byRule\x1a=\n" +
 	"\x0fByAnalyzerEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a=\n" +
 	"\x0fBySeverityEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a9\n" +
+	"\vByRuleEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x15\n" +
 	"\x13FindingClearRequest\"0\n" +
 	"\x14FindingClearResponse\x12\x18\n" +
@@ -11128,7 +11822,58 @@ const file_aidememory_proto_rawDesc = "" +
 	"\tfile_path\x18\x03 \x01(\tR\bfilePath\x12\x1a\n" +
 	"\bcategory\x18\x04 \x01(\tR\bcategory\"-\n" +
 	"\x15FindingAcceptResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x05R\x05count\".\n" +
+	"\x05count\x18\x01 \x01(\x05R\x05count\"\xc6\x03\n" +
+	"\x12FindingTriageEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\x12\x1d\n" +
+	"\n" +
+	"finding_id\x18\x03 \x01(\tR\tfindingId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\a \x01(\tR\x05actor\x12\x1a\n" +
+	"\bassignee\x18\b \x01(\tR\bassignee\x120\n" +
+	"\x05until\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1a\n" +
+	"\banalyzer\x18\n" +
+	" \x01(\tR\banalyzer\x12\x1b\n" +
+	"\tfile_path\x18\v \x01(\tR\bfilePath\x12\x14\n" +
+	"\x05title\x18\f \x01(\tR\x05title\x12\x16\n" +
+	"\x06commit\x18\r \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x0e \x01(\tR\tsessionId\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x84\x02\n" +
+	"\x13FindingTriageStatus\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1a\n" +
+	"\bassignee\x18\x05 \x01(\tR\bassignee\x120\n" +
+	"\x05until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"+\n" +
+	"\x17FindingTriageRefRequest\x12\x10\n" +
+	"\x03ref\x18\x01 \x01(\tR\x03ref\"k\n" +
+	"\x18FindingTriageRefResponse\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\x12-\n" +
+	"\afinding\x18\x02 \x01(\v2\x13.aidememory.FindingR\afinding\"L\n" +
+	"\x14FindingTriageRequest\x124\n" +
+	"\x05event\x18\x01 \x01(\v2\x1e.aidememory.FindingTriageEventR\x05event\"P\n" +
+	"\x15FindingTriageResponse\x127\n" +
+	"\x06status\x18\x01 \x01(\v2\x1f.aidememory.FindingTriageStatusR\x06status\"?\n" +
+	"\x1bFindingTriageHistoryRequest\x12 \n" +
+	"\vfingerprint\x18\x01 \x01(\tR\vfingerprint\"\x1e\n" +
+	"\x1cFindingTriageStatusesRequest\"\xd2\x01\n" +
+	"\x1dFindingTriageStatusesResponse\x12S\n" +
+	"\bstatuses\x18\x01 \x03(\v27.aidememory.FindingTriageStatusesResponse.StatusesEntryR\bstatuses\x1a\\\n" +
+	"\rStatusesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.aidememory.FindingTriageStatusR\x05value:\x028\x01\"^\n" +
+	"\x1aFindingTriageEventsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"U\n" +
+	"\x1bFindingTriageEventsResponse\x126\n" +
+	"\x06events\x18\x01 \x03(\v2\x1e.aidememory.FindingTriageEventR\x06events\".\n" +
 	"\x10SurveyRunRequest\x12\x1a\n" +
 	"\banalyzer\x18\x01 \x01(\tR\banalyzer\"w\n" +
 	"\x0fSurveyRunResult\x12\x1a\n" +
@@ -11557,7 +12302,8 @@ const file_aidememory_proto_rawDesc = "" +
 	"\x13GetContainingSymbol\x12*.aidememory.CodeGetContainingSymbolRequest\x1a+.aidememory.CodeGetContainingSymbolResponse\x12V\n" +
 	"\vGetFileInfo\x12\".aidememory.CodeGetFileInfoRequest\x1a#.aidememory.CodeGetFileInfoResponse\x12P\n" +
 	"\tReadCheck\x12 .aidememory.CodeReadCheckRequest\x1a!.aidememory.CodeReadCheckResponse\x12n\n" +
-	"\x13RunDeadCodeAnalysis\x12*.aidememory.CodeRunDeadCodeAnalysisRequest\x1a+.aidememory.CodeRunDeadCodeAnalysisResponse2\x86\a\n" +
+	"\x13RunDeadCodeAnalysis\x12*.aidememory.CodeRunDeadCodeAnalysisRequest\x1a+.aidememory.CodeRunDeadCodeAnalysisResponse2\xe3\n" +
+	"\n" +
 	"\x0fFindingsService\x12D\n" +
 	"\x03Add\x12\x1d.aidememory.FindingAddRequest\x1a\x1e.aidememory.FindingAddResponse\x12D\n" +
 	"\x03Get\x12\x1d.aidememory.FindingGetRequest\x1a\x1e.aidememory.FindingGetResponse\x12M\n" +
@@ -11569,7 +12315,12 @@ const file_aidememory_proto_rawDesc = "" +
 	"\x05Stats\x12\x1f.aidememory.FindingStatsRequest\x1a .aidememory.FindingStatsResponse\x12J\n" +
 	"\x05Clear\x12\x1f.aidememory.FindingClearRequest\x1a .aidememory.FindingClearResponse\x12M\n" +
 	"\x06Accept\x12 .aidememory.FindingAcceptRequest\x1a!.aidememory.FindingAcceptResponse\x12]\n" +
-	"\x0eAcceptByFilter\x12(.aidememory.FindingAcceptByFilterRequest\x1a!.aidememory.FindingAcceptResponse2\x87\x06\n" +
+	"\x0eAcceptByFilter\x12(.aidememory.FindingAcceptByFilterRequest\x1a!.aidememory.FindingAcceptResponse\x12]\n" +
+	"\x10ResolveTriageRef\x12#.aidememory.FindingTriageRefRequest\x1a$.aidememory.FindingTriageRefResponse\x12M\n" +
+	"\x06Triage\x12 .aidememory.FindingTriageRequest\x1a!.aidememory.FindingTriageResponse\x12a\n" +
+	"\rTriageHistory\x12'.aidememory.FindingTriageHistoryRequest\x1a'.aidememory.FindingTriageEventsResponse\x12e\n" +
+	"\x0eTriageStatuses\x12(.aidememory.FindingTriageStatusesRequest\x1a).aidememory.FindingTriageStatusesResponse\x12c\n" +
+	"\x10ListTriageEvents\x12&.aidememory.FindingTriageEventsRequest\x1a'.aidememory.FindingTriageEventsResponse2\x87\x06\n" +
 	"\rSurveyService\x12B\n" +
 	"\x03Add\x12\x1c.aidememory.SurveyAddRequest\x1a\x1d.aidememory.SurveyAddResponse\x12B\n" +
 	"\x03Get\x12\x1c.aidememory.SurveyGetRequest\x1a\x1d.aidememory.SurveyGetResponse\x12K\n" +
@@ -11623,7 +12374,7 @@ func file_aidememory_proto_rawDescGZIP() []byte {
 	return file_aidememory_proto_rawDescData
 }
 
-var file_aidememory_proto_msgTypes = make([]protoimpl.MessageInfo, 203)
+var file_aidememory_proto_msgTypes = make([]protoimpl.MessageInfo, 216)
 var file_aidememory_proto_goTypes = []any{
 	(*Memory)(nil),                          // 0: aidememory.Memory
 	(*MemoryAddRequest)(nil),                // 1: aidememory.MemoryAddRequest
@@ -11740,371 +12491,406 @@ var file_aidememory_proto_goTypes = []any{
 	(*FindingAcceptRequest)(nil),            // 112: aidememory.FindingAcceptRequest
 	(*FindingAcceptByFilterRequest)(nil),    // 113: aidememory.FindingAcceptByFilterRequest
 	(*FindingAcceptResponse)(nil),           // 114: aidememory.FindingAcceptResponse
-	(*SurveyRunRequest)(nil),                // 115: aidememory.SurveyRunRequest
-	(*SurveyRunResult)(nil),                 // 116: aidememory.SurveyRunResult
-	(*SurveyRunResponse)(nil),               // 117: aidememory.SurveyRunResponse
-	(*SurveyEntry)(nil),                     // 118: aidememory.SurveyEntry
-	(*SurveyAddRequest)(nil),                // 119: aidememory.SurveyAddRequest
-	(*SurveyAddResponse)(nil),               // 120: aidememory.SurveyAddResponse
-	(*SurveyGetRequest)(nil),                // 121: aidememory.SurveyGetRequest
-	(*SurveyGetResponse)(nil),               // 122: aidememory.SurveyGetResponse
-	(*SurveyDeleteRequest)(nil),             // 123: aidememory.SurveyDeleteRequest
-	(*SurveyDeleteResponse)(nil),            // 124: aidememory.SurveyDeleteResponse
-	(*SurveySearchRequest)(nil),             // 125: aidememory.SurveySearchRequest
-	(*SurveySearchResponse)(nil),            // 126: aidememory.SurveySearchResponse
-	(*SurveyListRequest)(nil),               // 127: aidememory.SurveyListRequest
-	(*SurveyFileRequest)(nil),               // 128: aidememory.SurveyFileRequest
-	(*SurveyClearAnalyzerRequest)(nil),      // 129: aidememory.SurveyClearAnalyzerRequest
-	(*SurveyClearAnalyzerResponse)(nil),     // 130: aidememory.SurveyClearAnalyzerResponse
-	(*SurveyStatsRequest)(nil),              // 131: aidememory.SurveyStatsRequest
-	(*SurveyStatsResponse)(nil),             // 132: aidememory.SurveyStatsResponse
-	(*SurveyClearRequest)(nil),              // 133: aidememory.SurveyClearRequest
-	(*SurveyClearResponse)(nil),             // 134: aidememory.SurveyClearResponse
-	(*Tombstone)(nil),                       // 135: aidememory.Tombstone
-	(*TombstoneAddRequest)(nil),             // 136: aidememory.TombstoneAddRequest
-	(*TombstoneAddResponse)(nil),            // 137: aidememory.TombstoneAddResponse
-	(*TombstoneGetRequest)(nil),             // 138: aidememory.TombstoneGetRequest
-	(*TombstoneGetResponse)(nil),            // 139: aidememory.TombstoneGetResponse
-	(*TombstoneListRequest)(nil),            // 140: aidememory.TombstoneListRequest
-	(*TombstoneListResponse)(nil),           // 141: aidememory.TombstoneListResponse
-	(*TombstoneDeleteRequest)(nil),          // 142: aidememory.TombstoneDeleteRequest
-	(*TombstoneDeleteResponse)(nil),         // 143: aidememory.TombstoneDeleteResponse
-	(*HealthCheckRequest)(nil),              // 144: aidememory.HealthCheckRequest
-	(*HealthCheckResponse)(nil),             // 145: aidememory.HealthCheckResponse
-	(*StatusRequest)(nil),                   // 146: aidememory.StatusRequest
-	(*StatusResponse)(nil),                  // 147: aidememory.StatusResponse
-	(*StatusWatcher)(nil),                   // 148: aidememory.StatusWatcher
-	(*StatusCodeIndexer)(nil),               // 149: aidememory.StatusCodeIndexer
-	(*StatusFindings)(nil),                  // 150: aidememory.StatusFindings
-	(*StatusAnalyzer)(nil),                  // 151: aidememory.StatusAnalyzer
-	(*StatusMCPTool)(nil),                   // 152: aidememory.StatusMCPTool
-	(*StatusSurvey)(nil),                    // 153: aidememory.StatusSurvey
-	(*StatusStore)(nil),                     // 154: aidememory.StatusStore
-	(*StatusGrammar)(nil),                   // 155: aidememory.StatusGrammar
-	(*ObserveRecordRequest)(nil),            // 156: aidememory.ObserveRecordRequest
-	(*ObserveRecordResponse)(nil),           // 157: aidememory.ObserveRecordResponse
-	(*ObserveListRequest)(nil),              // 158: aidememory.ObserveListRequest
-	(*ObserveEvent)(nil),                    // 159: aidememory.ObserveEvent
-	(*ObserveListResponse)(nil),             // 160: aidememory.ObserveListResponse
-	(*InstinctEvidence)(nil),                // 161: aidememory.InstinctEvidence
-	(*InstinctProposedMemory)(nil),          // 162: aidememory.InstinctProposedMemory
-	(*InstinctProposal)(nil),                // 163: aidememory.InstinctProposal
-	(*InstinctListRequest)(nil),             // 164: aidememory.InstinctListRequest
-	(*InstinctListResponse)(nil),            // 165: aidememory.InstinctListResponse
-	(*InstinctGetRequest)(nil),              // 166: aidememory.InstinctGetRequest
-	(*InstinctGetResponse)(nil),             // 167: aidememory.InstinctGetResponse
-	(*InstinctAddRequest)(nil),              // 168: aidememory.InstinctAddRequest
-	(*InstinctAddResponse)(nil),             // 169: aidememory.InstinctAddResponse
-	(*InstinctUpdateStatusRequest)(nil),     // 170: aidememory.InstinctUpdateStatusRequest
-	(*InstinctUpdateStatusResponse)(nil),    // 171: aidememory.InstinctUpdateStatusResponse
-	(*InstinctWatchRequest)(nil),            // 172: aidememory.InstinctWatchRequest
-	(*ObserveWatchRequest)(nil),             // 173: aidememory.ObserveWatchRequest
-	(*TokenStatsRequest)(nil),               // 174: aidememory.TokenStatsRequest
-	(*TokenStatsResponse)(nil),              // 175: aidememory.TokenStatsResponse
-	(*TokenEventListRequest)(nil),           // 176: aidememory.TokenEventListRequest
-	(*TokenEventListResponse)(nil),          // 177: aidememory.TokenEventListResponse
-	(*TokenEventItem)(nil),                  // 178: aidememory.TokenEventItem
-	(*SwarmWatchTasksRequest)(nil),          // 179: aidememory.SwarmWatchTasksRequest
-	(*SwarmWatchMessagesRequest)(nil),       // 180: aidememory.SwarmWatchMessagesRequest
-	(*SwarmWatchStateRequest)(nil),          // 181: aidememory.SwarmWatchStateRequest
-	(*StateChange)(nil),                     // 182: aidememory.StateChange
-	nil,                                     // 183: aidememory.Finding.MetadataEntry
-	nil,                                     // 184: aidememory.FindingAddRequest.MetadataEntry
-	nil,                                     // 185: aidememory.FindingStatsResponse.ByAnalyzerEntry
-	nil,                                     // 186: aidememory.FindingStatsResponse.BySeverityEntry
-	nil,                                     // 187: aidememory.SurveyEntry.MetadataEntry
-	nil,                                     // 188: aidememory.SurveyAddRequest.MetadataEntry
-	nil,                                     // 189: aidememory.SurveyStatsResponse.ByAnalyzerEntry
-	nil,                                     // 190: aidememory.SurveyStatsResponse.ByKindEntry
-	nil,                                     // 191: aidememory.StatusFindings.ByAnalyzerEntry
-	nil,                                     // 192: aidememory.StatusFindings.BySeverityEntry
-	nil,                                     // 193: aidememory.StatusFindings.AnalyzersEntry
-	nil,                                     // 194: aidememory.StatusSurvey.ByAnalyzerEntry
-	nil,                                     // 195: aidememory.StatusSurvey.ByKindEntry
-	nil,                                     // 196: aidememory.ObserveRecordRequest.AttrsEntry
-	nil,                                     // 197: aidememory.ObserveEvent.AttrsEntry
-	nil,                                     // 198: aidememory.TokenStatsResponse.ByToolEntry
-	nil,                                     // 199: aidememory.TokenStatsResponse.BySavingTypeEntry
-	nil,                                     // 200: aidememory.TokenStatsResponse.ByDeliveryEntry
-	nil,                                     // 201: aidememory.TokenStatsResponse.CallsByToolEntry
-	nil,                                     // 202: aidememory.TokenStatsResponse.SavedByToolEntry
-	(*timestamppb.Timestamp)(nil),           // 203: google.protobuf.Timestamp
+	(*FindingTriageEvent)(nil),              // 115: aidememory.FindingTriageEvent
+	(*FindingTriageStatus)(nil),             // 116: aidememory.FindingTriageStatus
+	(*FindingTriageRefRequest)(nil),         // 117: aidememory.FindingTriageRefRequest
+	(*FindingTriageRefResponse)(nil),        // 118: aidememory.FindingTriageRefResponse
+	(*FindingTriageRequest)(nil),            // 119: aidememory.FindingTriageRequest
+	(*FindingTriageResponse)(nil),           // 120: aidememory.FindingTriageResponse
+	(*FindingTriageHistoryRequest)(nil),     // 121: aidememory.FindingTriageHistoryRequest
+	(*FindingTriageStatusesRequest)(nil),    // 122: aidememory.FindingTriageStatusesRequest
+	(*FindingTriageStatusesResponse)(nil),   // 123: aidememory.FindingTriageStatusesResponse
+	(*FindingTriageEventsRequest)(nil),      // 124: aidememory.FindingTriageEventsRequest
+	(*FindingTriageEventsResponse)(nil),     // 125: aidememory.FindingTriageEventsResponse
+	(*SurveyRunRequest)(nil),                // 126: aidememory.SurveyRunRequest
+	(*SurveyRunResult)(nil),                 // 127: aidememory.SurveyRunResult
+	(*SurveyRunResponse)(nil),               // 128: aidememory.SurveyRunResponse
+	(*SurveyEntry)(nil),                     // 129: aidememory.SurveyEntry
+	(*SurveyAddRequest)(nil),                // 130: aidememory.SurveyAddRequest
+	(*SurveyAddResponse)(nil),               // 131: aidememory.SurveyAddResponse
+	(*SurveyGetRequest)(nil),                // 132: aidememory.SurveyGetRequest
+	(*SurveyGetResponse)(nil),               // 133: aidememory.SurveyGetResponse
+	(*SurveyDeleteRequest)(nil),             // 134: aidememory.SurveyDeleteRequest
+	(*SurveyDeleteResponse)(nil),            // 135: aidememory.SurveyDeleteResponse
+	(*SurveySearchRequest)(nil),             // 136: aidememory.SurveySearchRequest
+	(*SurveySearchResponse)(nil),            // 137: aidememory.SurveySearchResponse
+	(*SurveyListRequest)(nil),               // 138: aidememory.SurveyListRequest
+	(*SurveyFileRequest)(nil),               // 139: aidememory.SurveyFileRequest
+	(*SurveyClearAnalyzerRequest)(nil),      // 140: aidememory.SurveyClearAnalyzerRequest
+	(*SurveyClearAnalyzerResponse)(nil),     // 141: aidememory.SurveyClearAnalyzerResponse
+	(*SurveyStatsRequest)(nil),              // 142: aidememory.SurveyStatsRequest
+	(*SurveyStatsResponse)(nil),             // 143: aidememory.SurveyStatsResponse
+	(*SurveyClearRequest)(nil),              // 144: aidememory.SurveyClearRequest
+	(*SurveyClearResponse)(nil),             // 145: aidememory.SurveyClearResponse
+	(*Tombstone)(nil),                       // 146: aidememory.Tombstone
+	(*TombstoneAddRequest)(nil),             // 147: aidememory.TombstoneAddRequest
+	(*TombstoneAddResponse)(nil),            // 148: aidememory.TombstoneAddResponse
+	(*TombstoneGetRequest)(nil),             // 149: aidememory.TombstoneGetRequest
+	(*TombstoneGetResponse)(nil),            // 150: aidememory.TombstoneGetResponse
+	(*TombstoneListRequest)(nil),            // 151: aidememory.TombstoneListRequest
+	(*TombstoneListResponse)(nil),           // 152: aidememory.TombstoneListResponse
+	(*TombstoneDeleteRequest)(nil),          // 153: aidememory.TombstoneDeleteRequest
+	(*TombstoneDeleteResponse)(nil),         // 154: aidememory.TombstoneDeleteResponse
+	(*HealthCheckRequest)(nil),              // 155: aidememory.HealthCheckRequest
+	(*HealthCheckResponse)(nil),             // 156: aidememory.HealthCheckResponse
+	(*StatusRequest)(nil),                   // 157: aidememory.StatusRequest
+	(*StatusResponse)(nil),                  // 158: aidememory.StatusResponse
+	(*StatusWatcher)(nil),                   // 159: aidememory.StatusWatcher
+	(*StatusCodeIndexer)(nil),               // 160: aidememory.StatusCodeIndexer
+	(*StatusFindings)(nil),                  // 161: aidememory.StatusFindings
+	(*StatusAnalyzer)(nil),                  // 162: aidememory.StatusAnalyzer
+	(*StatusMCPTool)(nil),                   // 163: aidememory.StatusMCPTool
+	(*StatusSurvey)(nil),                    // 164: aidememory.StatusSurvey
+	(*StatusStore)(nil),                     // 165: aidememory.StatusStore
+	(*StatusGrammar)(nil),                   // 166: aidememory.StatusGrammar
+	(*ObserveRecordRequest)(nil),            // 167: aidememory.ObserveRecordRequest
+	(*ObserveRecordResponse)(nil),           // 168: aidememory.ObserveRecordResponse
+	(*ObserveListRequest)(nil),              // 169: aidememory.ObserveListRequest
+	(*ObserveEvent)(nil),                    // 170: aidememory.ObserveEvent
+	(*ObserveListResponse)(nil),             // 171: aidememory.ObserveListResponse
+	(*InstinctEvidence)(nil),                // 172: aidememory.InstinctEvidence
+	(*InstinctProposedMemory)(nil),          // 173: aidememory.InstinctProposedMemory
+	(*InstinctProposal)(nil),                // 174: aidememory.InstinctProposal
+	(*InstinctListRequest)(nil),             // 175: aidememory.InstinctListRequest
+	(*InstinctListResponse)(nil),            // 176: aidememory.InstinctListResponse
+	(*InstinctGetRequest)(nil),              // 177: aidememory.InstinctGetRequest
+	(*InstinctGetResponse)(nil),             // 178: aidememory.InstinctGetResponse
+	(*InstinctAddRequest)(nil),              // 179: aidememory.InstinctAddRequest
+	(*InstinctAddResponse)(nil),             // 180: aidememory.InstinctAddResponse
+	(*InstinctUpdateStatusRequest)(nil),     // 181: aidememory.InstinctUpdateStatusRequest
+	(*InstinctUpdateStatusResponse)(nil),    // 182: aidememory.InstinctUpdateStatusResponse
+	(*InstinctWatchRequest)(nil),            // 183: aidememory.InstinctWatchRequest
+	(*ObserveWatchRequest)(nil),             // 184: aidememory.ObserveWatchRequest
+	(*TokenStatsRequest)(nil),               // 185: aidememory.TokenStatsRequest
+	(*TokenStatsResponse)(nil),              // 186: aidememory.TokenStatsResponse
+	(*TokenEventListRequest)(nil),           // 187: aidememory.TokenEventListRequest
+	(*TokenEventListResponse)(nil),          // 188: aidememory.TokenEventListResponse
+	(*TokenEventItem)(nil),                  // 189: aidememory.TokenEventItem
+	(*SwarmWatchTasksRequest)(nil),          // 190: aidememory.SwarmWatchTasksRequest
+	(*SwarmWatchMessagesRequest)(nil),       // 191: aidememory.SwarmWatchMessagesRequest
+	(*SwarmWatchStateRequest)(nil),          // 192: aidememory.SwarmWatchStateRequest
+	(*StateChange)(nil),                     // 193: aidememory.StateChange
+	nil,                                     // 194: aidememory.Finding.MetadataEntry
+	nil,                                     // 195: aidememory.FindingAddRequest.MetadataEntry
+	nil,                                     // 196: aidememory.FindingStatsResponse.ByAnalyzerEntry
+	nil,                                     // 197: aidememory.FindingStatsResponse.BySeverityEntry
+	nil,                                     // 198: aidememory.FindingStatsResponse.ByRuleEntry
+	nil,                                     // 199: aidememory.FindingTriageStatusesResponse.StatusesEntry
+	nil,                                     // 200: aidememory.SurveyEntry.MetadataEntry
+	nil,                                     // 201: aidememory.SurveyAddRequest.MetadataEntry
+	nil,                                     // 202: aidememory.SurveyStatsResponse.ByAnalyzerEntry
+	nil,                                     // 203: aidememory.SurveyStatsResponse.ByKindEntry
+	nil,                                     // 204: aidememory.StatusFindings.ByAnalyzerEntry
+	nil,                                     // 205: aidememory.StatusFindings.BySeverityEntry
+	nil,                                     // 206: aidememory.StatusFindings.AnalyzersEntry
+	nil,                                     // 207: aidememory.StatusSurvey.ByAnalyzerEntry
+	nil,                                     // 208: aidememory.StatusSurvey.ByKindEntry
+	nil,                                     // 209: aidememory.ObserveRecordRequest.AttrsEntry
+	nil,                                     // 210: aidememory.ObserveEvent.AttrsEntry
+	nil,                                     // 211: aidememory.TokenStatsResponse.ByToolEntry
+	nil,                                     // 212: aidememory.TokenStatsResponse.BySavingTypeEntry
+	nil,                                     // 213: aidememory.TokenStatsResponse.ByDeliveryEntry
+	nil,                                     // 214: aidememory.TokenStatsResponse.CallsByToolEntry
+	nil,                                     // 215: aidememory.TokenStatsResponse.SavedByToolEntry
+	(*timestamppb.Timestamp)(nil),           // 216: google.protobuf.Timestamp
 }
 var file_aidememory_proto_depIdxs = []int32{
-	203, // 0: aidememory.Memory.created_at:type_name -> google.protobuf.Timestamp
-	203, // 1: aidememory.Memory.updated_at:type_name -> google.protobuf.Timestamp
-	203, // 2: aidememory.Memory.last_accessed:type_name -> google.protobuf.Timestamp
-	203, // 3: aidememory.MemoryAddRequest.created_at:type_name -> google.protobuf.Timestamp
-	203, // 4: aidememory.MemoryAddRequest.updated_at:type_name -> google.protobuf.Timestamp
+	216, // 0: aidememory.Memory.created_at:type_name -> google.protobuf.Timestamp
+	216, // 1: aidememory.Memory.updated_at:type_name -> google.protobuf.Timestamp
+	216, // 2: aidememory.Memory.last_accessed:type_name -> google.protobuf.Timestamp
+	216, // 3: aidememory.MemoryAddRequest.created_at:type_name -> google.protobuf.Timestamp
+	216, // 4: aidememory.MemoryAddRequest.updated_at:type_name -> google.protobuf.Timestamp
 	0,   // 5: aidememory.MemoryAddResponse.memory:type_name -> aidememory.Memory
 	0,   // 6: aidememory.MemoryGetResponse.memory:type_name -> aidememory.Memory
 	0,   // 7: aidememory.MemorySearchResponse.memories:type_name -> aidememory.Memory
 	0,   // 8: aidememory.MemoryListResponse.memories:type_name -> aidememory.Memory
-	203, // 9: aidememory.State.updated_at:type_name -> google.protobuf.Timestamp
+	216, // 9: aidememory.State.updated_at:type_name -> google.protobuf.Timestamp
 	15,  // 10: aidememory.StateGetResponse.state:type_name -> aidememory.State
-	203, // 11: aidememory.StateSetRequest.updated_at:type_name -> google.protobuf.Timestamp
+	216, // 11: aidememory.StateSetRequest.updated_at:type_name -> google.protobuf.Timestamp
 	15,  // 12: aidememory.StateSetResponse.state:type_name -> aidememory.State
 	15,  // 13: aidememory.StateListResponse.states:type_name -> aidememory.State
-	203, // 14: aidememory.Decision.created_at:type_name -> google.protobuf.Timestamp
-	203, // 15: aidememory.DecisionSetRequest.created_at:type_name -> google.protobuf.Timestamp
+	216, // 14: aidememory.Decision.created_at:type_name -> google.protobuf.Timestamp
+	216, // 15: aidememory.DecisionSetRequest.created_at:type_name -> google.protobuf.Timestamp
 	28,  // 16: aidememory.DecisionSetResponse.decision:type_name -> aidememory.Decision
 	28,  // 17: aidememory.DecisionGetResponse.decision:type_name -> aidememory.Decision
 	28,  // 18: aidememory.DecisionListResponse.decisions:type_name -> aidememory.Decision
 	28,  // 19: aidememory.DecisionHistoryResponse.decisions:type_name -> aidememory.Decision
-	203, // 20: aidememory.Message.created_at:type_name -> google.protobuf.Timestamp
-	203, // 21: aidememory.Message.expires_at:type_name -> google.protobuf.Timestamp
+	216, // 20: aidememory.Message.created_at:type_name -> google.protobuf.Timestamp
+	216, // 21: aidememory.Message.expires_at:type_name -> google.protobuf.Timestamp
 	41,  // 22: aidememory.MessageSendResponse.message:type_name -> aidememory.Message
 	41,  // 23: aidememory.MessageListResponse.messages:type_name -> aidememory.Message
-	203, // 24: aidememory.Task.created_at:type_name -> google.protobuf.Timestamp
-	203, // 25: aidememory.Task.claimed_at:type_name -> google.protobuf.Timestamp
-	203, // 26: aidememory.Task.completed_at:type_name -> google.protobuf.Timestamp
+	216, // 24: aidememory.Task.created_at:type_name -> google.protobuf.Timestamp
+	216, // 25: aidememory.Task.claimed_at:type_name -> google.protobuf.Timestamp
+	216, // 26: aidememory.Task.completed_at:type_name -> google.protobuf.Timestamp
 	50,  // 27: aidememory.TaskCreateResponse.task:type_name -> aidememory.Task
 	50,  // 28: aidememory.TaskGetResponse.task:type_name -> aidememory.Task
 	50,  // 29: aidememory.TaskListResponse.tasks:type_name -> aidememory.Task
 	50,  // 30: aidememory.TaskClaimResponse.task:type_name -> aidememory.Task
 	50,  // 31: aidememory.TaskCompleteResponse.task:type_name -> aidememory.Task
 	50,  // 32: aidememory.TaskUpdateResponse.task:type_name -> aidememory.Task
-	203, // 33: aidememory.Symbol.created_at:type_name -> google.protobuf.Timestamp
+	216, // 33: aidememory.Symbol.created_at:type_name -> google.protobuf.Timestamp
 	67,  // 34: aidememory.CodeSearchResponse.symbols:type_name -> aidememory.Symbol
 	67,  // 35: aidememory.CodeSymbolsResponse.symbols:type_name -> aidememory.Symbol
 	76,  // 36: aidememory.CodeIndexEvent.progress:type_name -> aidememory.CodeIndexProgress
 	75,  // 37: aidememory.CodeIndexEvent.summary:type_name -> aidememory.CodeIndexResponse
 	82,  // 38: aidememory.CodeTopReferencesResponse.symbols:type_name -> aidememory.SymbolRefCount
-	203, // 39: aidememory.CodeReference.created_at:type_name -> google.protobuf.Timestamp
+	216, // 39: aidememory.CodeReference.created_at:type_name -> google.protobuf.Timestamp
 	83,  // 40: aidememory.CodeSearchReferencesResponse.references:type_name -> aidememory.CodeReference
 	67,  // 41: aidememory.CodeGetContainingSymbolResponse.symbol:type_name -> aidememory.Symbol
-	203, // 42: aidememory.CodeGetFileInfoResponse.mod_time:type_name -> google.protobuf.Timestamp
-	183, // 43: aidememory.Finding.metadata:type_name -> aidememory.Finding.MetadataEntry
-	203, // 44: aidememory.Finding.created_at:type_name -> google.protobuf.Timestamp
-	184, // 45: aidememory.FindingAddRequest.metadata:type_name -> aidememory.FindingAddRequest.MetadataEntry
+	216, // 42: aidememory.CodeGetFileInfoResponse.mod_time:type_name -> google.protobuf.Timestamp
+	194, // 43: aidememory.Finding.metadata:type_name -> aidememory.Finding.MetadataEntry
+	216, // 44: aidememory.Finding.created_at:type_name -> google.protobuf.Timestamp
+	195, // 45: aidememory.FindingAddRequest.metadata:type_name -> aidememory.FindingAddRequest.MetadataEntry
 	95,  // 46: aidememory.FindingAddResponse.finding:type_name -> aidememory.Finding
 	95,  // 47: aidememory.FindingGetResponse.finding:type_name -> aidememory.Finding
 	95,  // 48: aidememory.FindingSearchResponse.findings:type_name -> aidememory.Finding
-	185, // 49: aidememory.FindingStatsResponse.by_analyzer:type_name -> aidememory.FindingStatsResponse.ByAnalyzerEntry
-	186, // 50: aidememory.FindingStatsResponse.by_severity:type_name -> aidememory.FindingStatsResponse.BySeverityEntry
-	116, // 51: aidememory.SurveyRunResponse.results:type_name -> aidememory.SurveyRunResult
-	187, // 52: aidememory.SurveyEntry.metadata:type_name -> aidememory.SurveyEntry.MetadataEntry
-	203, // 53: aidememory.SurveyEntry.created_at:type_name -> google.protobuf.Timestamp
-	188, // 54: aidememory.SurveyAddRequest.metadata:type_name -> aidememory.SurveyAddRequest.MetadataEntry
-	118, // 55: aidememory.SurveyAddResponse.entry:type_name -> aidememory.SurveyEntry
-	118, // 56: aidememory.SurveyGetResponse.entry:type_name -> aidememory.SurveyEntry
-	118, // 57: aidememory.SurveySearchResponse.entries:type_name -> aidememory.SurveyEntry
-	189, // 58: aidememory.SurveyStatsResponse.by_analyzer:type_name -> aidememory.SurveyStatsResponse.ByAnalyzerEntry
-	190, // 59: aidememory.SurveyStatsResponse.by_kind:type_name -> aidememory.SurveyStatsResponse.ByKindEntry
-	203, // 60: aidememory.Tombstone.deleted_at:type_name -> google.protobuf.Timestamp
-	135, // 61: aidememory.TombstoneAddRequest.tombstone:type_name -> aidememory.Tombstone
-	135, // 62: aidememory.TombstoneAddResponse.tombstone:type_name -> aidememory.Tombstone
-	135, // 63: aidememory.TombstoneGetResponse.tombstone:type_name -> aidememory.Tombstone
-	135, // 64: aidememory.TombstoneListResponse.tombstones:type_name -> aidememory.Tombstone
-	148, // 65: aidememory.StatusResponse.watcher:type_name -> aidememory.StatusWatcher
-	149, // 66: aidememory.StatusResponse.code_indexer:type_name -> aidememory.StatusCodeIndexer
-	150, // 67: aidememory.StatusResponse.findings:type_name -> aidememory.StatusFindings
-	152, // 68: aidememory.StatusResponse.mcp_tools:type_name -> aidememory.StatusMCPTool
-	153, // 69: aidememory.StatusResponse.survey:type_name -> aidememory.StatusSurvey
-	154, // 70: aidememory.StatusResponse.stores:type_name -> aidememory.StatusStore
-	155, // 71: aidememory.StatusResponse.grammars:type_name -> aidememory.StatusGrammar
-	191, // 72: aidememory.StatusFindings.by_analyzer:type_name -> aidememory.StatusFindings.ByAnalyzerEntry
-	192, // 73: aidememory.StatusFindings.by_severity:type_name -> aidememory.StatusFindings.BySeverityEntry
-	193, // 74: aidememory.StatusFindings.analyzers:type_name -> aidememory.StatusFindings.AnalyzersEntry
-	194, // 75: aidememory.StatusSurvey.by_analyzer:type_name -> aidememory.StatusSurvey.ByAnalyzerEntry
-	195, // 76: aidememory.StatusSurvey.by_kind:type_name -> aidememory.StatusSurvey.ByKindEntry
-	196, // 77: aidememory.ObserveRecordRequest.attrs:type_name -> aidememory.ObserveRecordRequest.AttrsEntry
-	203, // 78: aidememory.ObserveEvent.timestamp:type_name -> google.protobuf.Timestamp
-	197, // 79: aidememory.ObserveEvent.attrs:type_name -> aidememory.ObserveEvent.AttrsEntry
-	159, // 80: aidememory.ObserveListResponse.events:type_name -> aidememory.ObserveEvent
-	159, // 81: aidememory.InstinctEvidence.snapshot:type_name -> aidememory.ObserveEvent
-	203, // 82: aidememory.InstinctProposal.proposed_at:type_name -> google.protobuf.Timestamp
-	161, // 83: aidememory.InstinctProposal.evidence:type_name -> aidememory.InstinctEvidence
-	162, // 84: aidememory.InstinctProposal.proposed_instinct:type_name -> aidememory.InstinctProposedMemory
-	203, // 85: aidememory.InstinctProposal.last_reproposal_at:type_name -> google.protobuf.Timestamp
-	203, // 86: aidememory.InstinctProposal.expires_at:type_name -> google.protobuf.Timestamp
-	163, // 87: aidememory.InstinctListResponse.proposals:type_name -> aidememory.InstinctProposal
-	163, // 88: aidememory.InstinctGetResponse.proposal:type_name -> aidememory.InstinctProposal
-	163, // 89: aidememory.InstinctAddRequest.proposal:type_name -> aidememory.InstinctProposal
-	163, // 90: aidememory.InstinctAddResponse.proposal:type_name -> aidememory.InstinctProposal
-	163, // 91: aidememory.InstinctUpdateStatusResponse.proposal:type_name -> aidememory.InstinctProposal
-	203, // 92: aidememory.TokenStatsRequest.since:type_name -> google.protobuf.Timestamp
-	203, // 93: aidememory.TokenStatsRequest.until:type_name -> google.protobuf.Timestamp
-	198, // 94: aidememory.TokenStatsResponse.by_tool:type_name -> aidememory.TokenStatsResponse.ByToolEntry
-	199, // 95: aidememory.TokenStatsResponse.by_saving_type:type_name -> aidememory.TokenStatsResponse.BySavingTypeEntry
-	200, // 96: aidememory.TokenStatsResponse.by_delivery:type_name -> aidememory.TokenStatsResponse.ByDeliveryEntry
-	201, // 97: aidememory.TokenStatsResponse.calls_by_tool:type_name -> aidememory.TokenStatsResponse.CallsByToolEntry
-	202, // 98: aidememory.TokenStatsResponse.saved_by_tool:type_name -> aidememory.TokenStatsResponse.SavedByToolEntry
-	178, // 99: aidememory.TokenEventListResponse.events:type_name -> aidememory.TokenEventItem
-	203, // 100: aidememory.TokenEventItem.timestamp:type_name -> google.protobuf.Timestamp
-	15,  // 101: aidememory.StateChange.state:type_name -> aidememory.State
-	151, // 102: aidememory.StatusFindings.AnalyzersEntry.value:type_name -> aidememory.StatusAnalyzer
-	1,   // 103: aidememory.MemoryService.Add:input_type -> aidememory.MemoryAddRequest
-	3,   // 104: aidememory.MemoryService.Get:input_type -> aidememory.MemoryGetRequest
-	5,   // 105: aidememory.MemoryService.Search:input_type -> aidememory.MemorySearchRequest
-	7,   // 106: aidememory.MemoryService.List:input_type -> aidememory.MemoryListRequest
-	9,   // 107: aidememory.MemoryService.Delete:input_type -> aidememory.MemoryDeleteRequest
-	11,  // 108: aidememory.MemoryService.Clear:input_type -> aidememory.MemoryClearRequest
-	13,  // 109: aidememory.MemoryService.Touch:input_type -> aidememory.MemoryTouchRequest
-	16,  // 110: aidememory.StateService.Get:input_type -> aidememory.StateGetRequest
-	18,  // 111: aidememory.StateService.Set:input_type -> aidememory.StateSetRequest
-	20,  // 112: aidememory.StateService.List:input_type -> aidememory.StateListRequest
-	22,  // 113: aidememory.StateService.Delete:input_type -> aidememory.StateDeleteRequest
-	24,  // 114: aidememory.StateService.Clear:input_type -> aidememory.StateClearRequest
-	26,  // 115: aidememory.StateService.Cleanup:input_type -> aidememory.StateCleanupRequest
-	29,  // 116: aidememory.DecisionService.Set:input_type -> aidememory.DecisionSetRequest
-	31,  // 117: aidememory.DecisionService.Get:input_type -> aidememory.DecisionGetRequest
-	33,  // 118: aidememory.DecisionService.List:input_type -> aidememory.DecisionListRequest
-	35,  // 119: aidememory.DecisionService.History:input_type -> aidememory.DecisionHistoryRequest
-	37,  // 120: aidememory.DecisionService.Delete:input_type -> aidememory.DecisionDeleteRequest
-	39,  // 121: aidememory.DecisionService.Clear:input_type -> aidememory.DecisionClearRequest
-	42,  // 122: aidememory.MessageService.Send:input_type -> aidememory.MessageSendRequest
-	44,  // 123: aidememory.MessageService.List:input_type -> aidememory.MessageListRequest
-	46,  // 124: aidememory.MessageService.Ack:input_type -> aidememory.MessageAckRequest
-	48,  // 125: aidememory.MessageService.Prune:input_type -> aidememory.MessagePruneRequest
-	51,  // 126: aidememory.TaskService.Create:input_type -> aidememory.TaskCreateRequest
-	53,  // 127: aidememory.TaskService.Get:input_type -> aidememory.TaskGetRequest
-	55,  // 128: aidememory.TaskService.List:input_type -> aidememory.TaskListRequest
-	57,  // 129: aidememory.TaskService.Claim:input_type -> aidememory.TaskClaimRequest
-	59,  // 130: aidememory.TaskService.Complete:input_type -> aidememory.TaskCompleteRequest
-	61,  // 131: aidememory.TaskService.Update:input_type -> aidememory.TaskUpdateRequest
-	63,  // 132: aidememory.TaskService.Delete:input_type -> aidememory.TaskDeleteRequest
-	65,  // 133: aidememory.TaskService.Clear:input_type -> aidememory.TaskClearRequest
-	68,  // 134: aidememory.CodeService.Search:input_type -> aidememory.CodeSearchRequest
-	70,  // 135: aidememory.CodeService.Symbols:input_type -> aidememory.CodeSymbolsRequest
-	72,  // 136: aidememory.CodeService.Stats:input_type -> aidememory.CodeStatsRequest
-	74,  // 137: aidememory.CodeService.Index:input_type -> aidememory.CodeIndexRequest
-	78,  // 138: aidememory.CodeService.Clear:input_type -> aidememory.CodeClearRequest
-	80,  // 139: aidememory.CodeService.TopReferences:input_type -> aidememory.CodeTopReferencesRequest
-	84,  // 140: aidememory.CodeService.SearchReferences:input_type -> aidememory.CodeSearchReferencesRequest
-	86,  // 141: aidememory.CodeService.GetFileReferences:input_type -> aidememory.CodeGetFileReferencesRequest
-	87,  // 142: aidememory.CodeService.GetContainingSymbol:input_type -> aidememory.CodeGetContainingSymbolRequest
-	89,  // 143: aidememory.CodeService.GetFileInfo:input_type -> aidememory.CodeGetFileInfoRequest
-	91,  // 144: aidememory.CodeService.ReadCheck:input_type -> aidememory.CodeReadCheckRequest
-	93,  // 145: aidememory.CodeService.RunDeadCodeAnalysis:input_type -> aidememory.CodeRunDeadCodeAnalysisRequest
-	96,  // 146: aidememory.FindingsService.Add:input_type -> aidememory.FindingAddRequest
-	98,  // 147: aidememory.FindingsService.Get:input_type -> aidememory.FindingGetRequest
-	100, // 148: aidememory.FindingsService.Delete:input_type -> aidememory.FindingDeleteRequest
-	102, // 149: aidememory.FindingsService.Search:input_type -> aidememory.FindingSearchRequest
-	104, // 150: aidememory.FindingsService.List:input_type -> aidememory.FindingListRequest
-	105, // 151: aidememory.FindingsService.GetFileFindings:input_type -> aidememory.FindingFileRequest
-	106, // 152: aidememory.FindingsService.ClearAnalyzer:input_type -> aidememory.FindingClearAnalyzerRequest
-	108, // 153: aidememory.FindingsService.Stats:input_type -> aidememory.FindingStatsRequest
-	110, // 154: aidememory.FindingsService.Clear:input_type -> aidememory.FindingClearRequest
-	112, // 155: aidememory.FindingsService.Accept:input_type -> aidememory.FindingAcceptRequest
-	113, // 156: aidememory.FindingsService.AcceptByFilter:input_type -> aidememory.FindingAcceptByFilterRequest
-	119, // 157: aidememory.SurveyService.Add:input_type -> aidememory.SurveyAddRequest
-	121, // 158: aidememory.SurveyService.Get:input_type -> aidememory.SurveyGetRequest
-	123, // 159: aidememory.SurveyService.Delete:input_type -> aidememory.SurveyDeleteRequest
-	125, // 160: aidememory.SurveyService.Search:input_type -> aidememory.SurveySearchRequest
-	127, // 161: aidememory.SurveyService.List:input_type -> aidememory.SurveyListRequest
-	128, // 162: aidememory.SurveyService.GetFileEntries:input_type -> aidememory.SurveyFileRequest
-	129, // 163: aidememory.SurveyService.ClearAnalyzer:input_type -> aidememory.SurveyClearAnalyzerRequest
-	131, // 164: aidememory.SurveyService.Stats:input_type -> aidememory.SurveyStatsRequest
-	133, // 165: aidememory.SurveyService.Clear:input_type -> aidememory.SurveyClearRequest
-	115, // 166: aidememory.SurveyService.Run:input_type -> aidememory.SurveyRunRequest
-	136, // 167: aidememory.TombstoneService.Add:input_type -> aidememory.TombstoneAddRequest
-	138, // 168: aidememory.TombstoneService.Get:input_type -> aidememory.TombstoneGetRequest
-	140, // 169: aidememory.TombstoneService.List:input_type -> aidememory.TombstoneListRequest
-	142, // 170: aidememory.TombstoneService.Delete:input_type -> aidememory.TombstoneDeleteRequest
-	144, // 171: aidememory.HealthService.Check:input_type -> aidememory.HealthCheckRequest
-	146, // 172: aidememory.StatusService.GetStatus:input_type -> aidememory.StatusRequest
-	174, // 173: aidememory.TokenService.GetTokenStats:input_type -> aidememory.TokenStatsRequest
-	176, // 174: aidememory.TokenService.ListTokenEvents:input_type -> aidememory.TokenEventListRequest
-	156, // 175: aidememory.ObserveService.RecordEvent:input_type -> aidememory.ObserveRecordRequest
-	158, // 176: aidememory.ObserveService.ListEvents:input_type -> aidememory.ObserveListRequest
-	173, // 177: aidememory.ObserveService.WatchEvents:input_type -> aidememory.ObserveWatchRequest
-	164, // 178: aidememory.InstinctService.List:input_type -> aidememory.InstinctListRequest
-	166, // 179: aidememory.InstinctService.Get:input_type -> aidememory.InstinctGetRequest
-	168, // 180: aidememory.InstinctService.Add:input_type -> aidememory.InstinctAddRequest
-	170, // 181: aidememory.InstinctService.UpdateStatus:input_type -> aidememory.InstinctUpdateStatusRequest
-	172, // 182: aidememory.InstinctService.Watch:input_type -> aidememory.InstinctWatchRequest
-	179, // 183: aidememory.SwarmService.WatchTasks:input_type -> aidememory.SwarmWatchTasksRequest
-	180, // 184: aidememory.SwarmService.WatchMessages:input_type -> aidememory.SwarmWatchMessagesRequest
-	181, // 185: aidememory.SwarmService.WatchState:input_type -> aidememory.SwarmWatchStateRequest
-	2,   // 186: aidememory.MemoryService.Add:output_type -> aidememory.MemoryAddResponse
-	4,   // 187: aidememory.MemoryService.Get:output_type -> aidememory.MemoryGetResponse
-	6,   // 188: aidememory.MemoryService.Search:output_type -> aidememory.MemorySearchResponse
-	8,   // 189: aidememory.MemoryService.List:output_type -> aidememory.MemoryListResponse
-	10,  // 190: aidememory.MemoryService.Delete:output_type -> aidememory.MemoryDeleteResponse
-	12,  // 191: aidememory.MemoryService.Clear:output_type -> aidememory.MemoryClearResponse
-	14,  // 192: aidememory.MemoryService.Touch:output_type -> aidememory.MemoryTouchResponse
-	17,  // 193: aidememory.StateService.Get:output_type -> aidememory.StateGetResponse
-	19,  // 194: aidememory.StateService.Set:output_type -> aidememory.StateSetResponse
-	21,  // 195: aidememory.StateService.List:output_type -> aidememory.StateListResponse
-	23,  // 196: aidememory.StateService.Delete:output_type -> aidememory.StateDeleteResponse
-	25,  // 197: aidememory.StateService.Clear:output_type -> aidememory.StateClearResponse
-	27,  // 198: aidememory.StateService.Cleanup:output_type -> aidememory.StateCleanupResponse
-	30,  // 199: aidememory.DecisionService.Set:output_type -> aidememory.DecisionSetResponse
-	32,  // 200: aidememory.DecisionService.Get:output_type -> aidememory.DecisionGetResponse
-	34,  // 201: aidememory.DecisionService.List:output_type -> aidememory.DecisionListResponse
-	36,  // 202: aidememory.DecisionService.History:output_type -> aidememory.DecisionHistoryResponse
-	38,  // 203: aidememory.DecisionService.Delete:output_type -> aidememory.DecisionDeleteResponse
-	40,  // 204: aidememory.DecisionService.Clear:output_type -> aidememory.DecisionClearResponse
-	43,  // 205: aidememory.MessageService.Send:output_type -> aidememory.MessageSendResponse
-	45,  // 206: aidememory.MessageService.List:output_type -> aidememory.MessageListResponse
-	47,  // 207: aidememory.MessageService.Ack:output_type -> aidememory.MessageAckResponse
-	49,  // 208: aidememory.MessageService.Prune:output_type -> aidememory.MessagePruneResponse
-	52,  // 209: aidememory.TaskService.Create:output_type -> aidememory.TaskCreateResponse
-	54,  // 210: aidememory.TaskService.Get:output_type -> aidememory.TaskGetResponse
-	56,  // 211: aidememory.TaskService.List:output_type -> aidememory.TaskListResponse
-	58,  // 212: aidememory.TaskService.Claim:output_type -> aidememory.TaskClaimResponse
-	60,  // 213: aidememory.TaskService.Complete:output_type -> aidememory.TaskCompleteResponse
-	62,  // 214: aidememory.TaskService.Update:output_type -> aidememory.TaskUpdateResponse
-	64,  // 215: aidememory.TaskService.Delete:output_type -> aidememory.TaskDeleteResponse
-	66,  // 216: aidememory.TaskService.Clear:output_type -> aidememory.TaskClearResponse
-	69,  // 217: aidememory.CodeService.Search:output_type -> aidememory.CodeSearchResponse
-	71,  // 218: aidememory.CodeService.Symbols:output_type -> aidememory.CodeSymbolsResponse
-	73,  // 219: aidememory.CodeService.Stats:output_type -> aidememory.CodeStatsResponse
-	77,  // 220: aidememory.CodeService.Index:output_type -> aidememory.CodeIndexEvent
-	79,  // 221: aidememory.CodeService.Clear:output_type -> aidememory.CodeClearResponse
-	81,  // 222: aidememory.CodeService.TopReferences:output_type -> aidememory.CodeTopReferencesResponse
-	85,  // 223: aidememory.CodeService.SearchReferences:output_type -> aidememory.CodeSearchReferencesResponse
-	85,  // 224: aidememory.CodeService.GetFileReferences:output_type -> aidememory.CodeSearchReferencesResponse
-	88,  // 225: aidememory.CodeService.GetContainingSymbol:output_type -> aidememory.CodeGetContainingSymbolResponse
-	90,  // 226: aidememory.CodeService.GetFileInfo:output_type -> aidememory.CodeGetFileInfoResponse
-	92,  // 227: aidememory.CodeService.ReadCheck:output_type -> aidememory.CodeReadCheckResponse
-	94,  // 228: aidememory.CodeService.RunDeadCodeAnalysis:output_type -> aidememory.CodeRunDeadCodeAnalysisResponse
-	97,  // 229: aidememory.FindingsService.Add:output_type -> aidememory.FindingAddResponse
-	99,  // 230: aidememory.FindingsService.Get:output_type -> aidememory.FindingGetResponse
-	101, // 231: aidememory.FindingsService.Delete:output_type -> aidememory.FindingDeleteResponse
-	103, // 232: aidememory.FindingsService.Search:output_type -> aidememory.FindingSearchResponse
-	103, // 233: aidememory.FindingsService.List:output_type -> aidememory.FindingSearchResponse
-	103, // 234: aidememory.FindingsService.GetFileFindings:output_type -> aidememory.FindingSearchResponse
-	107, // 235: aidememory.FindingsService.ClearAnalyzer:output_type -> aidememory.FindingClearAnalyzerResponse
-	109, // 236: aidememory.FindingsService.Stats:output_type -> aidememory.FindingStatsResponse
-	111, // 237: aidememory.FindingsService.Clear:output_type -> aidememory.FindingClearResponse
-	114, // 238: aidememory.FindingsService.Accept:output_type -> aidememory.FindingAcceptResponse
-	114, // 239: aidememory.FindingsService.AcceptByFilter:output_type -> aidememory.FindingAcceptResponse
-	120, // 240: aidememory.SurveyService.Add:output_type -> aidememory.SurveyAddResponse
-	122, // 241: aidememory.SurveyService.Get:output_type -> aidememory.SurveyGetResponse
-	124, // 242: aidememory.SurveyService.Delete:output_type -> aidememory.SurveyDeleteResponse
-	126, // 243: aidememory.SurveyService.Search:output_type -> aidememory.SurveySearchResponse
-	126, // 244: aidememory.SurveyService.List:output_type -> aidememory.SurveySearchResponse
-	126, // 245: aidememory.SurveyService.GetFileEntries:output_type -> aidememory.SurveySearchResponse
-	130, // 246: aidememory.SurveyService.ClearAnalyzer:output_type -> aidememory.SurveyClearAnalyzerResponse
-	132, // 247: aidememory.SurveyService.Stats:output_type -> aidememory.SurveyStatsResponse
-	134, // 248: aidememory.SurveyService.Clear:output_type -> aidememory.SurveyClearResponse
-	117, // 249: aidememory.SurveyService.Run:output_type -> aidememory.SurveyRunResponse
-	137, // 250: aidememory.TombstoneService.Add:output_type -> aidememory.TombstoneAddResponse
-	139, // 251: aidememory.TombstoneService.Get:output_type -> aidememory.TombstoneGetResponse
-	141, // 252: aidememory.TombstoneService.List:output_type -> aidememory.TombstoneListResponse
-	143, // 253: aidememory.TombstoneService.Delete:output_type -> aidememory.TombstoneDeleteResponse
-	145, // 254: aidememory.HealthService.Check:output_type -> aidememory.HealthCheckResponse
-	147, // 255: aidememory.StatusService.GetStatus:output_type -> aidememory.StatusResponse
-	175, // 256: aidememory.TokenService.GetTokenStats:output_type -> aidememory.TokenStatsResponse
-	177, // 257: aidememory.TokenService.ListTokenEvents:output_type -> aidememory.TokenEventListResponse
-	157, // 258: aidememory.ObserveService.RecordEvent:output_type -> aidememory.ObserveRecordResponse
-	160, // 259: aidememory.ObserveService.ListEvents:output_type -> aidememory.ObserveListResponse
-	159, // 260: aidememory.ObserveService.WatchEvents:output_type -> aidememory.ObserveEvent
-	165, // 261: aidememory.InstinctService.List:output_type -> aidememory.InstinctListResponse
-	167, // 262: aidememory.InstinctService.Get:output_type -> aidememory.InstinctGetResponse
-	169, // 263: aidememory.InstinctService.Add:output_type -> aidememory.InstinctAddResponse
-	171, // 264: aidememory.InstinctService.UpdateStatus:output_type -> aidememory.InstinctUpdateStatusResponse
-	163, // 265: aidememory.InstinctService.Watch:output_type -> aidememory.InstinctProposal
-	50,  // 266: aidememory.SwarmService.WatchTasks:output_type -> aidememory.Task
-	41,  // 267: aidememory.SwarmService.WatchMessages:output_type -> aidememory.Message
-	182, // 268: aidememory.SwarmService.WatchState:output_type -> aidememory.StateChange
-	186, // [186:269] is the sub-list for method output_type
-	103, // [103:186] is the sub-list for method input_type
-	103, // [103:103] is the sub-list for extension type_name
-	103, // [103:103] is the sub-list for extension extendee
-	0,   // [0:103] is the sub-list for field type_name
+	196, // 49: aidememory.FindingStatsResponse.by_analyzer:type_name -> aidememory.FindingStatsResponse.ByAnalyzerEntry
+	197, // 50: aidememory.FindingStatsResponse.by_severity:type_name -> aidememory.FindingStatsResponse.BySeverityEntry
+	198, // 51: aidememory.FindingStatsResponse.by_rule:type_name -> aidememory.FindingStatsResponse.ByRuleEntry
+	216, // 52: aidememory.FindingTriageEvent.until:type_name -> google.protobuf.Timestamp
+	216, // 53: aidememory.FindingTriageEvent.created_at:type_name -> google.protobuf.Timestamp
+	216, // 54: aidememory.FindingTriageStatus.until:type_name -> google.protobuf.Timestamp
+	216, // 55: aidememory.FindingTriageStatus.updated_at:type_name -> google.protobuf.Timestamp
+	95,  // 56: aidememory.FindingTriageRefResponse.finding:type_name -> aidememory.Finding
+	115, // 57: aidememory.FindingTriageRequest.event:type_name -> aidememory.FindingTriageEvent
+	116, // 58: aidememory.FindingTriageResponse.status:type_name -> aidememory.FindingTriageStatus
+	199, // 59: aidememory.FindingTriageStatusesResponse.statuses:type_name -> aidememory.FindingTriageStatusesResponse.StatusesEntry
+	216, // 60: aidememory.FindingTriageEventsRequest.since:type_name -> google.protobuf.Timestamp
+	115, // 61: aidememory.FindingTriageEventsResponse.events:type_name -> aidememory.FindingTriageEvent
+	127, // 62: aidememory.SurveyRunResponse.results:type_name -> aidememory.SurveyRunResult
+	200, // 63: aidememory.SurveyEntry.metadata:type_name -> aidememory.SurveyEntry.MetadataEntry
+	216, // 64: aidememory.SurveyEntry.created_at:type_name -> google.protobuf.Timestamp
+	201, // 65: aidememory.SurveyAddRequest.metadata:type_name -> aidememory.SurveyAddRequest.MetadataEntry
+	129, // 66: aidememory.SurveyAddResponse.entry:type_name -> aidememory.SurveyEntry
+	129, // 67: aidememory.SurveyGetResponse.entry:type_name -> aidememory.SurveyEntry
+	129, // 68: aidememory.SurveySearchResponse.entries:type_name -> aidememory.SurveyEntry
+	202, // 69: aidememory.SurveyStatsResponse.by_analyzer:type_name -> aidememory.SurveyStatsResponse.ByAnalyzerEntry
+	203, // 70: aidememory.SurveyStatsResponse.by_kind:type_name -> aidememory.SurveyStatsResponse.ByKindEntry
+	216, // 71: aidememory.Tombstone.deleted_at:type_name -> google.protobuf.Timestamp
+	146, // 72: aidememory.TombstoneAddRequest.tombstone:type_name -> aidememory.Tombstone
+	146, // 73: aidememory.TombstoneAddResponse.tombstone:type_name -> aidememory.Tombstone
+	146, // 74: aidememory.TombstoneGetResponse.tombstone:type_name -> aidememory.Tombstone
+	146, // 75: aidememory.TombstoneListResponse.tombstones:type_name -> aidememory.Tombstone
+	159, // 76: aidememory.StatusResponse.watcher:type_name -> aidememory.StatusWatcher
+	160, // 77: aidememory.StatusResponse.code_indexer:type_name -> aidememory.StatusCodeIndexer
+	161, // 78: aidememory.StatusResponse.findings:type_name -> aidememory.StatusFindings
+	163, // 79: aidememory.StatusResponse.mcp_tools:type_name -> aidememory.StatusMCPTool
+	164, // 80: aidememory.StatusResponse.survey:type_name -> aidememory.StatusSurvey
+	165, // 81: aidememory.StatusResponse.stores:type_name -> aidememory.StatusStore
+	166, // 82: aidememory.StatusResponse.grammars:type_name -> aidememory.StatusGrammar
+	204, // 83: aidememory.StatusFindings.by_analyzer:type_name -> aidememory.StatusFindings.ByAnalyzerEntry
+	205, // 84: aidememory.StatusFindings.by_severity:type_name -> aidememory.StatusFindings.BySeverityEntry
+	206, // 85: aidememory.StatusFindings.analyzers:type_name -> aidememory.StatusFindings.AnalyzersEntry
+	207, // 86: aidememory.StatusSurvey.by_analyzer:type_name -> aidememory.StatusSurvey.ByAnalyzerEntry
+	208, // 87: aidememory.StatusSurvey.by_kind:type_name -> aidememory.StatusSurvey.ByKindEntry
+	209, // 88: aidememory.ObserveRecordRequest.attrs:type_name -> aidememory.ObserveRecordRequest.AttrsEntry
+	216, // 89: aidememory.ObserveEvent.timestamp:type_name -> google.protobuf.Timestamp
+	210, // 90: aidememory.ObserveEvent.attrs:type_name -> aidememory.ObserveEvent.AttrsEntry
+	170, // 91: aidememory.ObserveListResponse.events:type_name -> aidememory.ObserveEvent
+	170, // 92: aidememory.InstinctEvidence.snapshot:type_name -> aidememory.ObserveEvent
+	216, // 93: aidememory.InstinctProposal.proposed_at:type_name -> google.protobuf.Timestamp
+	172, // 94: aidememory.InstinctProposal.evidence:type_name -> aidememory.InstinctEvidence
+	173, // 95: aidememory.InstinctProposal.proposed_instinct:type_name -> aidememory.InstinctProposedMemory
+	216, // 96: aidememory.InstinctProposal.last_reproposal_at:type_name -> google.protobuf.Timestamp
+	216, // 97: aidememory.InstinctProposal.expires_at:type_name -> google.protobuf.Timestamp
+	174, // 98: aidememory.InstinctListResponse.proposals:type_name -> aidememory.InstinctProposal
+	174, // 99: aidememory.InstinctGetResponse.proposal:type_name -> aidememory.InstinctProposal
+	174, // 100: aidememory.InstinctAddRequest.proposal:type_name -> aidememory.InstinctProposal
+	174, // 101: aidememory.InstinctAddResponse.proposal:type_name -> aidememory.InstinctProposal
+	174, // 102: aidememory.InstinctUpdateStatusResponse.proposal:type_name -> aidememory.InstinctProposal
+	216, // 103: aidememory.TokenStatsRequest.since:type_name -> google.protobuf.Timestamp
+	216, // 104: aidememory.TokenStatsRequest.until:type_name -> google.protobuf.Timestamp
+	211, // 105: aidememory.TokenStatsResponse.by_tool:type_name -> aidememory.TokenStatsResponse.ByToolEntry
+	212, // 106: aidememory.TokenStatsResponse.by_saving_type:type_name -> aidememory.TokenStatsResponse.BySavingTypeEntry
+	213, // 107: aidememory.TokenStatsResponse.by_delivery:type_name -> aidememory.TokenStatsResponse.ByDeliveryEntry
+	214, // 108: aidememory.TokenStatsResponse.calls_by_tool:type_name -> aidememory.TokenStatsResponse.CallsByToolEntry
+	215, // 109: aidememory.TokenStatsResponse.saved_by_tool:type_name -> aidememory.TokenStatsResponse.SavedByToolEntry
+	189, // 110: aidememory.TokenEventListResponse.events:type_name -> aidememory.TokenEventItem
+	216, // 111: aidememory.TokenEventItem.timestamp:type_name -> google.protobuf.Timestamp
+	15,  // 112: aidememory.StateChange.state:type_name -> aidememory.State
+	116, // 113: aidememory.FindingTriageStatusesResponse.StatusesEntry.value:type_name -> aidememory.FindingTriageStatus
+	162, // 114: aidememory.StatusFindings.AnalyzersEntry.value:type_name -> aidememory.StatusAnalyzer
+	1,   // 115: aidememory.MemoryService.Add:input_type -> aidememory.MemoryAddRequest
+	3,   // 116: aidememory.MemoryService.Get:input_type -> aidememory.MemoryGetRequest
+	5,   // 117: aidememory.MemoryService.Search:input_type -> aidememory.MemorySearchRequest
+	7,   // 118: aidememory.MemoryService.List:input_type -> aidememory.MemoryListRequest
+	9,   // 119: aidememory.MemoryService.Delete:input_type -> aidememory.MemoryDeleteRequest
+	11,  // 120: aidememory.MemoryService.Clear:input_type -> aidememory.MemoryClearRequest
+	13,  // 121: aidememory.MemoryService.Touch:input_type -> aidememory.MemoryTouchRequest
+	16,  // 122: aidememory.StateService.Get:input_type -> aidememory.StateGetRequest
+	18,  // 123: aidememory.StateService.Set:input_type -> aidememory.StateSetRequest
+	20,  // 124: aidememory.StateService.List:input_type -> aidememory.StateListRequest
+	22,  // 125: aidememory.StateService.Delete:input_type -> aidememory.StateDeleteRequest
+	24,  // 126: aidememory.StateService.Clear:input_type -> aidememory.StateClearRequest
+	26,  // 127: aidememory.StateService.Cleanup:input_type -> aidememory.StateCleanupRequest
+	29,  // 128: aidememory.DecisionService.Set:input_type -> aidememory.DecisionSetRequest
+	31,  // 129: aidememory.DecisionService.Get:input_type -> aidememory.DecisionGetRequest
+	33,  // 130: aidememory.DecisionService.List:input_type -> aidememory.DecisionListRequest
+	35,  // 131: aidememory.DecisionService.History:input_type -> aidememory.DecisionHistoryRequest
+	37,  // 132: aidememory.DecisionService.Delete:input_type -> aidememory.DecisionDeleteRequest
+	39,  // 133: aidememory.DecisionService.Clear:input_type -> aidememory.DecisionClearRequest
+	42,  // 134: aidememory.MessageService.Send:input_type -> aidememory.MessageSendRequest
+	44,  // 135: aidememory.MessageService.List:input_type -> aidememory.MessageListRequest
+	46,  // 136: aidememory.MessageService.Ack:input_type -> aidememory.MessageAckRequest
+	48,  // 137: aidememory.MessageService.Prune:input_type -> aidememory.MessagePruneRequest
+	51,  // 138: aidememory.TaskService.Create:input_type -> aidememory.TaskCreateRequest
+	53,  // 139: aidememory.TaskService.Get:input_type -> aidememory.TaskGetRequest
+	55,  // 140: aidememory.TaskService.List:input_type -> aidememory.TaskListRequest
+	57,  // 141: aidememory.TaskService.Claim:input_type -> aidememory.TaskClaimRequest
+	59,  // 142: aidememory.TaskService.Complete:input_type -> aidememory.TaskCompleteRequest
+	61,  // 143: aidememory.TaskService.Update:input_type -> aidememory.TaskUpdateRequest
+	63,  // 144: aidememory.TaskService.Delete:input_type -> aidememory.TaskDeleteRequest
+	65,  // 145: aidememory.TaskService.Clear:input_type -> aidememory.TaskClearRequest
+	68,  // 146: aidememory.CodeService.Search:input_type -> aidememory.CodeSearchRequest
+	70,  // 147: aidememory.CodeService.Symbols:input_type -> aidememory.CodeSymbolsRequest
+	72,  // 148: aidememory.CodeService.Stats:input_type -> aidememory.CodeStatsRequest
+	74,  // 149: aidememory.CodeService.Index:input_type -> aidememory.CodeIndexRequest
+	78,  // 150: aidememory.CodeService.Clear:input_type -> aidememory.CodeClearRequest
+	80,  // 151: aidememory.CodeService.TopReferences:input_type -> aidememory.CodeTopReferencesRequest
+	84,  // 152: aidememory.CodeService.SearchReferences:input_type -> aidememory.CodeSearchReferencesRequest
+	86,  // 153: aidememory.CodeService.GetFileReferences:input_type -> aidememory.CodeGetFileReferencesRequest
+	87,  // 154: aidememory.CodeService.GetContainingSymbol:input_type -> aidememory.CodeGetContainingSymbolRequest
+	89,  // 155: aidememory.CodeService.GetFileInfo:input_type -> aidememory.CodeGetFileInfoRequest
+	91,  // 156: aidememory.CodeService.ReadCheck:input_type -> aidememory.CodeReadCheckRequest
+	93,  // 157: aidememory.CodeService.RunDeadCodeAnalysis:input_type -> aidememory.CodeRunDeadCodeAnalysisRequest
+	96,  // 158: aidememory.FindingsService.Add:input_type -> aidememory.FindingAddRequest
+	98,  // 159: aidememory.FindingsService.Get:input_type -> aidememory.FindingGetRequest
+	100, // 160: aidememory.FindingsService.Delete:input_type -> aidememory.FindingDeleteRequest
+	102, // 161: aidememory.FindingsService.Search:input_type -> aidememory.FindingSearchRequest
+	104, // 162: aidememory.FindingsService.List:input_type -> aidememory.FindingListRequest
+	105, // 163: aidememory.FindingsService.GetFileFindings:input_type -> aidememory.FindingFileRequest
+	106, // 164: aidememory.FindingsService.ClearAnalyzer:input_type -> aidememory.FindingClearAnalyzerRequest
+	108, // 165: aidememory.FindingsService.Stats:input_type -> aidememory.FindingStatsRequest
+	110, // 166: aidememory.FindingsService.Clear:input_type -> aidememory.FindingClearRequest
+	112, // 167: aidememory.FindingsService.Accept:input_type -> aidememory.FindingAcceptRequest
+	113, // 168: aidememory.FindingsService.AcceptByFilter:input_type -> aidememory.FindingAcceptByFilterRequest
+	117, // 169: aidememory.FindingsService.ResolveTriageRef:input_type -> aidememory.FindingTriageRefRequest
+	119, // 170: aidememory.FindingsService.Triage:input_type -> aidememory.FindingTriageRequest
+	121, // 171: aidememory.FindingsService.TriageHistory:input_type -> aidememory.FindingTriageHistoryRequest
+	122, // 172: aidememory.FindingsService.TriageStatuses:input_type -> aidememory.FindingTriageStatusesRequest
+	124, // 173: aidememory.FindingsService.ListTriageEvents:input_type -> aidememory.FindingTriageEventsRequest
+	130, // 174: aidememory.SurveyService.Add:input_type -> aidememory.SurveyAddRequest
+	132, // 175: aidememory.SurveyService.Get:input_type -> aidememory.SurveyGetRequest
+	134, // 176: aidememory.SurveyService.Delete:input_type -> aidememory.SurveyDeleteRequest
+	136, // 177: aidememory.SurveyService.Search:input_type -> aidememory.SurveySearchRequest
+	138, // 178: aidememory.SurveyService.List:input_type -> aidememory.SurveyListRequest
+	139, // 179: aidememory.SurveyService.GetFileEntries:input_type -> aidememory.SurveyFileRequest
+	140, // 180: aidememory.SurveyService.ClearAnalyzer:input_type -> aidememory.SurveyClearAnalyzerRequest
+	142, // 181: aidememory.SurveyService.Stats:input_type -> aidememory.SurveyStatsRequest
+	144, // 182: aidememory.SurveyService.Clear:input_type -> aidememory.SurveyClearRequest
+	126, // 183: aidememory.SurveyService.Run:input_type -> aidememory.SurveyRunRequest
+	147, // 184: aidememory.TombstoneService.Add:input_type -> aidememory.TombstoneAddRequest
+	149, // 185: aidememory.TombstoneService.Get:input_type -> aidememory.TombstoneGetRequest
+	151, // 186: aidememory.TombstoneService.List:input_type -> aidememory.TombstoneListRequest
+	153, // 187: aidememory.TombstoneService.Delete:input_type -> aidememory.TombstoneDeleteRequest
+	155, // 188: aidememory.HealthService.Check:input_type -> aidememory.HealthCheckRequest
+	157, // 189: aidememory.StatusService.GetStatus:input_type -> aidememory.StatusRequest
+	185, // 190: aidememory.TokenService.GetTokenStats:input_type -> aidememory.TokenStatsRequest
+	187, // 191: aidememory.TokenService.ListTokenEvents:input_type -> aidememory.TokenEventListRequest
+	167, // 192: aidememory.ObserveService.RecordEvent:input_type -> aidememory.ObserveRecordRequest
+	169, // 193: aidememory.ObserveService.ListEvents:input_type -> aidememory.ObserveListRequest
+	184, // 194: aidememory.ObserveService.WatchEvents:input_type -> aidememory.ObserveWatchRequest
+	175, // 195: aidememory.InstinctService.List:input_type -> aidememory.InstinctListRequest
+	177, // 196: aidememory.InstinctService.Get:input_type -> aidememory.InstinctGetRequest
+	179, // 197: aidememory.InstinctService.Add:input_type -> aidememory.InstinctAddRequest
+	181, // 198: aidememory.InstinctService.UpdateStatus:input_type -> aidememory.InstinctUpdateStatusRequest
+	183, // 199: aidememory.InstinctService.Watch:input_type -> aidememory.InstinctWatchRequest
+	190, // 200: aidememory.SwarmService.WatchTasks:input_type -> aidememory.SwarmWatchTasksRequest
+	191, // 201: aidememory.SwarmService.WatchMessages:input_type -> aidememory.SwarmWatchMessagesRequest
+	192, // 202: aidememory.SwarmService.WatchState:input_type -> aidememory.SwarmWatchStateRequest
+	2,   // 203: aidememory.MemoryService.Add:output_type -> aidememory.MemoryAddResponse
+	4,   // 204: aidememory.MemoryService.Get:output_type -> aidememory.MemoryGetResponse
+	6,   // 205: aidememory.MemoryService.Search:output_type -> aidememory.MemorySearchResponse
+	8,   // 206: aidememory.MemoryService.List:output_type -> aidememory.MemoryListResponse
+	10,  // 207: aidememory.MemoryService.Delete:output_type -> aidememory.MemoryDeleteResponse
+	12,  // 208: aidememory.MemoryService.Clear:output_type -> aidememory.MemoryClearResponse
+	14,  // 209: aidememory.MemoryService.Touch:output_type -> aidememory.MemoryTouchResponse
+	17,  // 210: aidememory.StateService.Get:output_type -> aidememory.StateGetResponse
+	19,  // 211: aidememory.StateService.Set:output_type -> aidememory.StateSetResponse
+	21,  // 212: aidememory.StateService.List:output_type -> aidememory.StateListResponse
+	23,  // 213: aidememory.StateService.Delete:output_type -> aidememory.StateDeleteResponse
+	25,  // 214: aidememory.StateService.Clear:output_type -> aidememory.StateClearResponse
+	27,  // 215: aidememory.StateService.Cleanup:output_type -> aidememory.StateCleanupResponse
+	30,  // 216: aidememory.DecisionService.Set:output_type -> aidememory.DecisionSetResponse
+	32,  // 217: aidememory.DecisionService.Get:output_type -> aidememory.DecisionGetResponse
+	34,  // 218: aidememory.DecisionService.List:output_type -> aidememory.DecisionListResponse
+	36,  // 219: aidememory.DecisionService.History:output_type -> aidememory.DecisionHistoryResponse
+	38,  // 220: aidememory.DecisionService.Delete:output_type -> aidememory.DecisionDeleteResponse
+	40,  // 221: aidememory.DecisionService.Clear:output_type -> aidememory.DecisionClearResponse
+	43,  // 222: aidememory.MessageService.Send:output_type -> aidememory.MessageSendResponse
+	45,  // 223: aidememory.MessageService.List:output_type -> aidememory.MessageListResponse
+	47,  // 224: aidememory.MessageService.Ack:output_type -> aidememory.MessageAckResponse
+	49,  // 225: aidememory.MessageService.Prune:output_type -> aidememory.MessagePruneResponse
+	52,  // 226: aidememory.TaskService.Create:output_type -> aidememory.TaskCreateResponse
+	54,  // 227: aidememory.TaskService.Get:output_type -> aidememory.TaskGetResponse
+	56,  // 228: aidememory.TaskService.List:output_type -> aidememory.TaskListResponse
+	58,  // 229: aidememory.TaskService.Claim:output_type -> aidememory.TaskClaimResponse
+	60,  // 230: aidememory.TaskService.Complete:output_type -> aidememory.TaskCompleteResponse
+	62,  // 231: aidememory.TaskService.Update:output_type -> aidememory.TaskUpdateResponse
+	64,  // 232: aidememory.TaskService.Delete:output_type -> aidememory.TaskDeleteResponse
+	66,  // 233: aidememory.TaskService.Clear:output_type -> aidememory.TaskClearResponse
+	69,  // 234: aidememory.CodeService.Search:output_type -> aidememory.CodeSearchResponse
+	71,  // 235: aidememory.CodeService.Symbols:output_type -> aidememory.CodeSymbolsResponse
+	73,  // 236: aidememory.CodeService.Stats:output_type -> aidememory.CodeStatsResponse
+	77,  // 237: aidememory.CodeService.Index:output_type -> aidememory.CodeIndexEvent
+	79,  // 238: aidememory.CodeService.Clear:output_type -> aidememory.CodeClearResponse
+	81,  // 239: aidememory.CodeService.TopReferences:output_type -> aidememory.CodeTopReferencesResponse
+	85,  // 240: aidememory.CodeService.SearchReferences:output_type -> aidememory.CodeSearchReferencesResponse
+	85,  // 241: aidememory.CodeService.GetFileReferences:output_type -> aidememory.CodeSearchReferencesResponse
+	88,  // 242: aidememory.CodeService.GetContainingSymbol:output_type -> aidememory.CodeGetContainingSymbolResponse
+	90,  // 243: aidememory.CodeService.GetFileInfo:output_type -> aidememory.CodeGetFileInfoResponse
+	92,  // 244: aidememory.CodeService.ReadCheck:output_type -> aidememory.CodeReadCheckResponse
+	94,  // 245: aidememory.CodeService.RunDeadCodeAnalysis:output_type -> aidememory.CodeRunDeadCodeAnalysisResponse
+	97,  // 246: aidememory.FindingsService.Add:output_type -> aidememory.FindingAddResponse
+	99,  // 247: aidememory.FindingsService.Get:output_type -> aidememory.FindingGetResponse
+	101, // 248: aidememory.FindingsService.Delete:output_type -> aidememory.FindingDeleteResponse
+	103, // 249: aidememory.FindingsService.Search:output_type -> aidememory.FindingSearchResponse
+	103, // 250: aidememory.FindingsService.List:output_type -> aidememory.FindingSearchResponse
+	103, // 251: aidememory.FindingsService.GetFileFindings:output_type -> aidememory.FindingSearchResponse
+	107, // 252: aidememory.FindingsService.ClearAnalyzer:output_type -> aidememory.FindingClearAnalyzerResponse
+	109, // 253: aidememory.FindingsService.Stats:output_type -> aidememory.FindingStatsResponse
+	111, // 254: aidememory.FindingsService.Clear:output_type -> aidememory.FindingClearResponse
+	114, // 255: aidememory.FindingsService.Accept:output_type -> aidememory.FindingAcceptResponse
+	114, // 256: aidememory.FindingsService.AcceptByFilter:output_type -> aidememory.FindingAcceptResponse
+	118, // 257: aidememory.FindingsService.ResolveTriageRef:output_type -> aidememory.FindingTriageRefResponse
+	120, // 258: aidememory.FindingsService.Triage:output_type -> aidememory.FindingTriageResponse
+	125, // 259: aidememory.FindingsService.TriageHistory:output_type -> aidememory.FindingTriageEventsResponse
+	123, // 260: aidememory.FindingsService.TriageStatuses:output_type -> aidememory.FindingTriageStatusesResponse
+	125, // 261: aidememory.FindingsService.ListTriageEvents:output_type -> aidememory.FindingTriageEventsResponse
+	131, // 262: aidememory.SurveyService.Add:output_type -> aidememory.SurveyAddResponse
+	133, // 263: aidememory.SurveyService.Get:output_type -> aidememory.SurveyGetResponse
+	135, // 264: aidememory.SurveyService.Delete:output_type -> aidememory.SurveyDeleteResponse
+	137, // 265: aidememory.SurveyService.Search:output_type -> aidememory.SurveySearchResponse
+	137, // 266: aidememory.SurveyService.List:output_type -> aidememory.SurveySearchResponse
+	137, // 267: aidememory.SurveyService.GetFileEntries:output_type -> aidememory.SurveySearchResponse
+	141, // 268: aidememory.SurveyService.ClearAnalyzer:output_type -> aidememory.SurveyClearAnalyzerResponse
+	143, // 269: aidememory.SurveyService.Stats:output_type -> aidememory.SurveyStatsResponse
+	145, // 270: aidememory.SurveyService.Clear:output_type -> aidememory.SurveyClearResponse
+	128, // 271: aidememory.SurveyService.Run:output_type -> aidememory.SurveyRunResponse
+	148, // 272: aidememory.TombstoneService.Add:output_type -> aidememory.TombstoneAddResponse
+	150, // 273: aidememory.TombstoneService.Get:output_type -> aidememory.TombstoneGetResponse
+	152, // 274: aidememory.TombstoneService.List:output_type -> aidememory.TombstoneListResponse
+	154, // 275: aidememory.TombstoneService.Delete:output_type -> aidememory.TombstoneDeleteResponse
+	156, // 276: aidememory.HealthService.Check:output_type -> aidememory.HealthCheckResponse
+	158, // 277: aidememory.StatusService.GetStatus:output_type -> aidememory.StatusResponse
+	186, // 278: aidememory.TokenService.GetTokenStats:output_type -> aidememory.TokenStatsResponse
+	188, // 279: aidememory.TokenService.ListTokenEvents:output_type -> aidememory.TokenEventListResponse
+	168, // 280: aidememory.ObserveService.RecordEvent:output_type -> aidememory.ObserveRecordResponse
+	171, // 281: aidememory.ObserveService.ListEvents:output_type -> aidememory.ObserveListResponse
+	170, // 282: aidememory.ObserveService.WatchEvents:output_type -> aidememory.ObserveEvent
+	176, // 283: aidememory.InstinctService.List:output_type -> aidememory.InstinctListResponse
+	178, // 284: aidememory.InstinctService.Get:output_type -> aidememory.InstinctGetResponse
+	180, // 285: aidememory.InstinctService.Add:output_type -> aidememory.InstinctAddResponse
+	182, // 286: aidememory.InstinctService.UpdateStatus:output_type -> aidememory.InstinctUpdateStatusResponse
+	174, // 287: aidememory.InstinctService.Watch:output_type -> aidememory.InstinctProposal
+	50,  // 288: aidememory.SwarmService.WatchTasks:output_type -> aidememory.Task
+	41,  // 289: aidememory.SwarmService.WatchMessages:output_type -> aidememory.Message
+	193, // 290: aidememory.SwarmService.WatchState:output_type -> aidememory.StateChange
+	203, // [203:291] is the sub-list for method output_type
+	115, // [115:203] is the sub-list for method input_type
+	115, // [115:115] is the sub-list for extension type_name
+	115, // [115:115] is the sub-list for extension extendee
+	0,   // [0:115] is the sub-list for field type_name
 }
 
 func init() { file_aidememory_proto_init() }
@@ -12123,7 +12909,7 @@ func file_aidememory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_aidememory_proto_rawDesc), len(file_aidememory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   203,
+			NumMessages:   216,
 			NumExtensions: 0,
 			NumServices:   15,
 		},
@@ -2042,17 +2042,22 @@ var CodeService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	FindingsService_Add_FullMethodName             = "/aidememory.FindingsService/Add"
-	FindingsService_Get_FullMethodName             = "/aidememory.FindingsService/Get"
-	FindingsService_Delete_FullMethodName          = "/aidememory.FindingsService/Delete"
-	FindingsService_Search_FullMethodName          = "/aidememory.FindingsService/Search"
-	FindingsService_List_FullMethodName            = "/aidememory.FindingsService/List"
-	FindingsService_GetFileFindings_FullMethodName = "/aidememory.FindingsService/GetFileFindings"
-	FindingsService_ClearAnalyzer_FullMethodName   = "/aidememory.FindingsService/ClearAnalyzer"
-	FindingsService_Stats_FullMethodName           = "/aidememory.FindingsService/Stats"
-	FindingsService_Clear_FullMethodName           = "/aidememory.FindingsService/Clear"
-	FindingsService_Accept_FullMethodName          = "/aidememory.FindingsService/Accept"
-	FindingsService_AcceptByFilter_FullMethodName  = "/aidememory.FindingsService/AcceptByFilter"
+	FindingsService_Add_FullMethodName              = "/aidememory.FindingsService/Add"
+	FindingsService_Get_FullMethodName              = "/aidememory.FindingsService/Get"
+	FindingsService_Delete_FullMethodName           = "/aidememory.FindingsService/Delete"
+	FindingsService_Search_FullMethodName           = "/aidememory.FindingsService/Search"
+	FindingsService_List_FullMethodName             = "/aidememory.FindingsService/List"
+	FindingsService_GetFileFindings_FullMethodName  = "/aidememory.FindingsService/GetFileFindings"
+	FindingsService_ClearAnalyzer_FullMethodName    = "/aidememory.FindingsService/ClearAnalyzer"
+	FindingsService_Stats_FullMethodName            = "/aidememory.FindingsService/Stats"
+	FindingsService_Clear_FullMethodName            = "/aidememory.FindingsService/Clear"
+	FindingsService_Accept_FullMethodName           = "/aidememory.FindingsService/Accept"
+	FindingsService_AcceptByFilter_FullMethodName   = "/aidememory.FindingsService/AcceptByFilter"
+	FindingsService_ResolveTriageRef_FullMethodName = "/aidememory.FindingsService/ResolveTriageRef"
+	FindingsService_Triage_FullMethodName           = "/aidememory.FindingsService/Triage"
+	FindingsService_TriageHistory_FullMethodName    = "/aidememory.FindingsService/TriageHistory"
+	FindingsService_TriageStatuses_FullMethodName   = "/aidememory.FindingsService/TriageStatuses"
+	FindingsService_ListTriageEvents_FullMethodName = "/aidememory.FindingsService/ListTriageEvents"
 )
 
 // FindingsServiceClient is the client API for FindingsService service.
@@ -2070,6 +2075,13 @@ type FindingsServiceClient interface {
 	Clear(ctx context.Context, in *FindingClearRequest, opts ...grpc.CallOption) (*FindingClearResponse, error)
 	Accept(ctx context.Context, in *FindingAcceptRequest, opts ...grpc.CallOption) (*FindingAcceptResponse, error)
 	AcceptByFilter(ctx context.Context, in *FindingAcceptByFilterRequest, opts ...grpc.CallOption) (*FindingAcceptResponse, error)
+	// Triage lifecycle, mirroring store.FindingsTriageStore so triage works
+	// while the daemon holds the findings DB.
+	ResolveTriageRef(ctx context.Context, in *FindingTriageRefRequest, opts ...grpc.CallOption) (*FindingTriageRefResponse, error)
+	Triage(ctx context.Context, in *FindingTriageRequest, opts ...grpc.CallOption) (*FindingTriageResponse, error)
+	TriageHistory(ctx context.Context, in *FindingTriageHistoryRequest, opts ...grpc.CallOption) (*FindingTriageEventsResponse, error)
+	TriageStatuses(ctx context.Context, in *FindingTriageStatusesRequest, opts ...grpc.CallOption) (*FindingTriageStatusesResponse, error)
+	ListTriageEvents(ctx context.Context, in *FindingTriageEventsRequest, opts ...grpc.CallOption) (*FindingTriageEventsResponse, error)
 }
 
 type findingsServiceClient struct {
@@ -2190,6 +2202,56 @@ func (c *findingsServiceClient) AcceptByFilter(ctx context.Context, in *FindingA
 	return out, nil
 }
 
+func (c *findingsServiceClient) ResolveTriageRef(ctx context.Context, in *FindingTriageRefRequest, opts ...grpc.CallOption) (*FindingTriageRefResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindingTriageRefResponse)
+	err := c.cc.Invoke(ctx, FindingsService_ResolveTriageRef_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *findingsServiceClient) Triage(ctx context.Context, in *FindingTriageRequest, opts ...grpc.CallOption) (*FindingTriageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindingTriageResponse)
+	err := c.cc.Invoke(ctx, FindingsService_Triage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *findingsServiceClient) TriageHistory(ctx context.Context, in *FindingTriageHistoryRequest, opts ...grpc.CallOption) (*FindingTriageEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindingTriageEventsResponse)
+	err := c.cc.Invoke(ctx, FindingsService_TriageHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *findingsServiceClient) TriageStatuses(ctx context.Context, in *FindingTriageStatusesRequest, opts ...grpc.CallOption) (*FindingTriageStatusesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindingTriageStatusesResponse)
+	err := c.cc.Invoke(ctx, FindingsService_TriageStatuses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *findingsServiceClient) ListTriageEvents(ctx context.Context, in *FindingTriageEventsRequest, opts ...grpc.CallOption) (*FindingTriageEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindingTriageEventsResponse)
+	err := c.cc.Invoke(ctx, FindingsService_ListTriageEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FindingsServiceServer is the server API for FindingsService service.
 // All implementations must embed UnimplementedFindingsServiceServer
 // for forward compatibility.
@@ -2205,6 +2267,13 @@ type FindingsServiceServer interface {
 	Clear(context.Context, *FindingClearRequest) (*FindingClearResponse, error)
 	Accept(context.Context, *FindingAcceptRequest) (*FindingAcceptResponse, error)
 	AcceptByFilter(context.Context, *FindingAcceptByFilterRequest) (*FindingAcceptResponse, error)
+	// Triage lifecycle, mirroring store.FindingsTriageStore so triage works
+	// while the daemon holds the findings DB.
+	ResolveTriageRef(context.Context, *FindingTriageRefRequest) (*FindingTriageRefResponse, error)
+	Triage(context.Context, *FindingTriageRequest) (*FindingTriageResponse, error)
+	TriageHistory(context.Context, *FindingTriageHistoryRequest) (*FindingTriageEventsResponse, error)
+	TriageStatuses(context.Context, *FindingTriageStatusesRequest) (*FindingTriageStatusesResponse, error)
+	ListTriageEvents(context.Context, *FindingTriageEventsRequest) (*FindingTriageEventsResponse, error)
 	mustEmbedUnimplementedFindingsServiceServer()
 }
 
@@ -2248,6 +2317,21 @@ func (UnimplementedFindingsServiceServer) Accept(context.Context, *FindingAccept
 func (UnimplementedFindingsServiceServer) AcceptByFilter(context.Context, *FindingAcceptByFilterRequest) (*FindingAcceptResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AcceptByFilter not implemented")
 }
+func (UnimplementedFindingsServiceServer) ResolveTriageRef(context.Context, *FindingTriageRefRequest) (*FindingTriageRefResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolveTriageRef not implemented")
+}
+func (UnimplementedFindingsServiceServer) Triage(context.Context, *FindingTriageRequest) (*FindingTriageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Triage not implemented")
+}
+func (UnimplementedFindingsServiceServer) TriageHistory(context.Context, *FindingTriageHistoryRequest) (*FindingTriageEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriageHistory not implemented")
+}
+func (UnimplementedFindingsServiceServer) TriageStatuses(context.Context, *FindingTriageStatusesRequest) (*FindingTriageStatusesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TriageStatuses not implemented")
+}
+func (UnimplementedFindingsServiceServer) ListTriageEvents(context.Context, *FindingTriageEventsRequest) (*FindingTriageEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTriageEvents not implemented")
+}
 func (UnimplementedFindingsServiceServer) mustEmbedUnimplementedFindingsServiceServer() {}
 func (UnimplementedFindingsServiceServer) testEmbeddedByValue()                         {}
 
@@ -2467,6 +2551,96 @@ func _FindingsService_AcceptByFilter_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FindingsService_ResolveTriageRef_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindingTriageRefRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingsServiceServer).ResolveTriageRef(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingsService_ResolveTriageRef_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingsServiceServer).ResolveTriageRef(ctx, req.(*FindingTriageRefRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FindingsService_Triage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindingTriageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingsServiceServer).Triage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingsService_Triage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingsServiceServer).Triage(ctx, req.(*FindingTriageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FindingsService_TriageHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindingTriageHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingsServiceServer).TriageHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingsService_TriageHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingsServiceServer).TriageHistory(ctx, req.(*FindingTriageHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FindingsService_TriageStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindingTriageStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingsServiceServer).TriageStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingsService_TriageStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingsServiceServer).TriageStatuses(ctx, req.(*FindingTriageStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FindingsService_ListTriageEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindingTriageEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FindingsServiceServer).ListTriageEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FindingsService_ListTriageEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FindingsServiceServer).ListTriageEvents(ctx, req.(*FindingTriageEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FindingsService_ServiceDesc is the grpc.ServiceDesc for FindingsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -2518,6 +2692,26 @@ var FindingsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AcceptByFilter",
 			Handler:    _FindingsService_AcceptByFilter_Handler,
 		},
+		{
+			MethodName: "ResolveTriageRef",
+			Handler:    _FindingsService_ResolveTriageRef_Handler,
+		},
+		{
+			MethodName: "Triage",
+			Handler:    _FindingsService_Triage_Handler,
+		},
+		{
+			MethodName: "TriageHistory",
+			Handler:    _FindingsService_TriageHistory_Handler,
+		},
+		{
+			MethodName: "TriageStatuses",
+			Handler:    _FindingsService_TriageStatuses_Handler,
+		},
+		{
+			MethodName: "ListTriageEvents",
+			Handler:    _FindingsService_ListTriageEvents_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "aidememory.proto",
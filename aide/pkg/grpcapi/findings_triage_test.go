@@ -0,0 +1,51 @@
+package grpcapi
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+)
+
+// TestFindingsTriage_PublishesOnBus checks that a transition made over the
+// Triage RPC reaches triage bus subscribers, as in-process ones do.
+func TestFindingsTriage_PublishesOnBus(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := store.NewFindingsStore(filepath.Join(dir, "findings"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { fs.Close() })
+	f := &findings.Finding{Analyzer: findings.AnalyzerTodos, Severity: findings.SevInfo, FilePath: "a.go", Line: 3, Title: "TODO: split"}
+	if err := fs.AddFinding(f); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := NewServer(nil, filepath.Join(dir, "memory.db"), "", nil)
+	srv.SetFindingsStore(fs)
+	svc := &findingsServiceImpl{server: srv}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, unsubscribe := srv.TriageBus().Subscribe(ctx, nil)
+	defer unsubscribe()
+
+	_, err = svc.Triage(ctx, &FindingTriageRequest{Event: &FindingTriageEvent{
+		Fingerprint: f.Fingerprint(), To: string(findings.TriageAcknowledged), Actor: "agent-1",
+	}})
+	if err != nil {
+		t.Fatalf("Triage: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Fingerprint != f.Fingerprint() || ev.To != findings.TriageAcknowledged || ev.From != findings.TriageOpen {
+			t.Errorf("published %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no triage event published")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/jmylchreest/aide/aide/pkg/eventbus"
 	"github.com/jmylchreest/aide/aide/pkg/findings"
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
 	"github.com/jmylchreest/aide/aide/pkg/instinct"
 	"github.com/jmylchreest/aide/aide/pkg/memory"
 	"github.com/jmylchreest/aide/aide/pkg/observe"
@@ -78,6 +79,7 @@ type Server struct {
 	taskBus       *eventbus.Broadcaster[*memory.Task]
 	messageBus    *eventbus.Broadcaster[*memory.Message]
 	stateBus      *eventbus.Broadcaster[*StateChange]
+	triageBus     *eventbus.Broadcaster[*findings.TriageEvent]
 	dbPath        string
 	grpcServer    *grpc.Server
 	socketPath    string
@@ -112,6 +114,7 @@ func NewServer(st store.Store, dbPath, socketPath string, loader grammar.Loader)
 		taskBus:       eventbus.New[*memory.Task](64),
 		messageBus:    eventbus.New[*memory.Message](128),
 		stateBus:      eventbus.New[*StateChange](128),
+		triageBus:     eventbus.New[*findings.TriageEvent](64),
 		dbPath:        dbPath,
 		socketPath:    socketPath,
 		startTime:     time.Now(),
@@ -148,6 +151,13 @@ func (s *Server) InstinctBus() *eventbus.Broadcaster[*instinct.Proposal] {
 	return s.instinctBus
 }
 
+// TriageBus exposes the findings triage broadcaster. The findings runner
+// publishes inferred fixed/reopened transitions; findings_triage and the
+// Triage RPC publish manual ones.
+func (s *Server) TriageBus() *eventbus.Broadcaster[*findings.TriageEvent] {
+	return s.triageBus
+}
+
 // SetCodeStore sets the code store for code indexing services.
 func (s *Server) SetCodeStore(cs store.CodeIndexStore) {
 	s.storeMu.Lock()
@@ -1241,6 +1251,7 @@ type indexResult struct {
 	skipped   bool
 	symbols   []*code.Symbol
 	refs      []*code.Reference
+	edges     []*code.TypeEdge
 	mtime     time.Time
 	sizeBytes int64
 }
@@ -1318,11 +1329,13 @@ func (s *codeServiceImpl) Index(req *CodeIndexRequest, stream grpc.ServerStreami
 					continue
 				}
 				refs, _ := s.parser.ParseFileReferences(w.abs)
+				edges, _ := s.parser.ParseFileHierarchy(w.abs)
 				select {
 				case resultQueue <- indexResult{
 					rel:       w.rel,
 					symbols:   symbols,
 					refs:      refs,
+					edges:     edges,
 					mtime:     w.info.ModTime(),
 					sizeBytes: w.info.Size(),
 				}:
@@ -1345,6 +1358,7 @@ func (s *codeServiceImpl) Index(req *CodeIndexRequest, stream grpc.ServerStreami
 	var (
 		filesIndexed, symbolsIndexed, filesSkipped int32
 		writerErr                                  error
+		indexed                                    []string
 	)
 	writerDone := make(chan struct{})
 	go func() {
@@ -1367,9 +1381,10 @@ func (s *codeServiceImpl) Index(req *CodeIndexRequest, stream grpc.ServerStreami
 				}
 				continue
 			}
-			if err := cs.IndexFileBatch(r.rel, r.symbols, r.refs, r.mtime, r.sizeBytes); err != nil {
+			if err := cs.IndexFileBatch(r.rel, r.symbols, r.refs, r.edges, r.mtime, r.sizeBytes); err != nil {
 				continue
 			}
+			indexed = append(indexed, r.rel)
 			filesIndexed++
 			fileSymbols := int32(len(r.symbols))
 			symbolsIndexed += fileSymbols
@@ -1457,6 +1472,16 @@ func (s *codeServiceImpl) Index(req *CodeIndexRequest, stream grpc.ServerStreami
 		return cerr
 	}
 
+	// Resolve reference targets for what changed (everything on Force).
+	if len(indexed) > 0 {
+		if req.Force {
+			indexed = nil
+		}
+		if _, err := cs.ResolveReferenceTargets(indexed, importresolve.New(projRoot).ResolveTarget); err != nil {
+			return err
+		}
+	}
+
 	return stream.Send(&CodeIndexEvent{
 		Event: &CodeIndexEvent_Summary{Summary: &CodeIndexResponse{
 			FilesIndexed:   filesIndexed,
@@ -1696,6 +1721,7 @@ func (s *codeServiceImpl) RunDeadCodeAnalysis(ctx context.Context, req *CodeRunD
 	if err != nil {
 		return nil, err
 	}
+	ff = findings.SuppressDeadCode(ff, cfg.ProjectRoot, false)
 
 	if err := fs.ReplaceFindingsForAnalyzer(findings.AnalyzerDeadCode, ff); err != nil {
 		return nil, fmt.Errorf("failed to store findings: %w", err)
@@ -1883,6 +1909,7 @@ func (s *findingsServiceImpl) Search(ctx context.Context, req *FindingSearchRequ
 		Severity: req.Severity,
 		FilePath: req.FilePath,
 		Category: req.Category,
+		State:    req.State,
 		Limit:    int(req.Limit),
 	}
 
@@ -1910,6 +1937,7 @@ func (s *findingsServiceImpl) List(ctx context.Context, req *FindingListRequest)
 		Severity: req.Severity,
 		FilePath: req.FilePath,
 		Category: req.Category,
+		State:    req.State,
 		Limit:    int(req.Limit),
 	}
 
@@ -1967,7 +1995,7 @@ func (s *findingsServiceImpl) Stats(ctx context.Context, req *FindingStatsReques
 
 	// Note: FindingStatsRequest proto has no include_accepted field,
 	// so we default to hiding accepted findings (matching CLI/MCP behaviour).
-	stats, err := fs.Stats(findings.SearchOptions{})
+	stats, err := fs.Stats(findings.SearchOptions{State: req.State})
 	if err != nil {
 		return nil, err
 	}
@@ -1980,11 +2008,16 @@ func (s *findingsServiceImpl) Stats(ctx context.Context, req *FindingStatsReques
 	for k, v := range stats.BySeverity {
 		bySeverity[k] = int32(v)
 	}
+	byRule := make(map[string]int32, len(stats.ByRule))
+	for k, v := range stats.ByRule {
+		byRule[k] = int32(v)
+	}
 
 	return &FindingStatsResponse{
 		Total:      int32(stats.Total),
 		ByAnalyzer: byAnalyzer,
 		BySeverity: bySeverity,
+		ByRule:     byRule,
 	}, nil
 }
 
@@ -2036,6 +2069,111 @@ func (s *findingsServiceImpl) AcceptByFilter(ctx context.Context, req *FindingAc
 	return &FindingAcceptResponse{Count: int32(count)}, nil
 }
 
+// triageStore narrows the findings store to its triage surface.
+func (s *findingsServiceImpl) triageStore() (store.FindingsTriageStore, error) {
+	fs := s.server.GetFindingsStore()
+	if fs == nil {
+		return nil, fmt.Errorf("findings store not available")
+	}
+	ts, ok := fs.(store.FindingsTriageStore)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "findings store does not support triage")
+	}
+	return ts, nil
+}
+
+func (s *findingsServiceImpl) ResolveTriageRef(ctx context.Context, req *FindingTriageRefRequest) (*FindingTriageRefResponse, error) {
+	ts, err := s.triageStore()
+	if err != nil {
+		return nil, err
+	}
+
+	fp, f, err := ts.ResolveTriageRef(req.Ref)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	return &FindingTriageRefResponse{Fingerprint: fp, Finding: findingToProto(f)}, nil
+}
+
+func (s *findingsServiceImpl) Triage(ctx context.Context, req *FindingTriageRequest) (*FindingTriageResponse, error) {
+	ts, err := s.triageStore()
+	if err != nil {
+		return nil, err
+	}
+	if req.Event == nil || req.Event.Fingerprint == "" {
+		return nil, status.Error(codes.InvalidArgument, "triage event with a fingerprint required")
+	}
+
+	ev := protoToTriageEvent(req.Event)
+	st, err := ts.TriageFinding(ev)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	s.publishTriage(ev)
+
+	return &FindingTriageResponse{Status: triageStatusToProto(st)}, nil
+}
+
+func (s *findingsServiceImpl) publishTriage(ev *findings.TriageEvent) {
+	if s.server == nil {
+		return
+	}
+	if bus := s.server.TriageBus(); bus != nil {
+		bus.Publish(ev)
+	}
+}
+
+func (s *findingsServiceImpl) TriageHistory(ctx context.Context, req *FindingTriageHistoryRequest) (*FindingTriageEventsResponse, error) {
+	ts, err := s.triageStore()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := ts.TriageHistory(req.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FindingTriageEventsResponse{Events: triageEventsToProto(history)}, nil
+}
+
+func (s *findingsServiceImpl) TriageStatuses(ctx context.Context, req *FindingTriageStatusesRequest) (*FindingTriageStatusesResponse, error) {
+	ts, err := s.triageStore()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := ts.TriageStatuses()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]*FindingTriageStatus, len(statuses))
+	for fp, st := range statuses {
+		out[fp] = triageStatusToProto(st)
+	}
+	return &FindingTriageStatusesResponse{Statuses: out}, nil
+}
+
+func (s *findingsServiceImpl) ListTriageEvents(ctx context.Context, req *FindingTriageEventsRequest) (*FindingTriageEventsResponse, error) {
+	ts, err := s.triageStore()
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+	if req.Since != nil {
+		since = req.Since.AsTime()
+	}
+	events, err := ts.ListTriageEvents(since, findings.TriageState(req.To))
+	if err != nil {
+		return nil, err
+	}
+
+	return &FindingTriageEventsResponse{Events: triageEventsToProto(events)}, nil
+}
+
 // =============================================================================
 // Survey Service Implementation
 // =============================================================================
@@ -2057,7 +2195,7 @@ func (s *surveyServiceImpl) Run(ctx context.Context, req *SurveyRunRequest) (*Su
 	if req.Analyzer != "" {
 		analyzers = []string{req.Analyzer}
 	}
-	results := surveyrun.Run(store.ProjectRootFromDB(s.server.dbPath), analyzers, surveyStore, s.server.GetCodeStore())
+	results := surveyrun.Run(store.ProjectRootFromDB(s.server.dbPath), analyzers, surveyStore, s.server.GetCodeStore(), s.server.GetFindingsStore())
 
 	resp := &SurveyRunResponse{}
 	for _, r := range results {
@@ -2642,6 +2780,90 @@ func findingToProto(f *findings.Finding) *Finding {
 	}
 }
 
+func triageEventToProto(e *findings.TriageEvent) *FindingTriageEvent {
+	if e == nil {
+		return nil
+	}
+	out := &FindingTriageEvent{
+		Id:          e.ID,
+		Fingerprint: e.Fingerprint,
+		FindingId:   e.FindingID,
+		From:        string(e.From),
+		To:          string(e.To),
+		Reason:      e.Reason,
+		Actor:       e.Actor,
+		Assignee:    e.Assignee,
+		Analyzer:    e.Analyzer,
+		FilePath:    e.FilePath,
+		Title:       e.Title,
+		Commit:      e.Commit,
+		SessionId:   e.SessionID,
+	}
+	if !e.Until.IsZero() {
+		out.Until = timestamppb.New(e.Until)
+	}
+	if !e.CreatedAt.IsZero() {
+		out.CreatedAt = timestamppb.New(e.CreatedAt)
+	}
+	return out
+}
+
+func triageEventsToProto(events []*findings.TriageEvent) []*FindingTriageEvent {
+	out := make([]*FindingTriageEvent, len(events))
+	for i, e := range events {
+		out[i] = triageEventToProto(e)
+	}
+	return out
+}
+
+func protoToTriageEvent(p *FindingTriageEvent) *findings.TriageEvent {
+	if p == nil {
+		return nil
+	}
+	out := &findings.TriageEvent{
+		ID:          p.Id,
+		Fingerprint: p.Fingerprint,
+		FindingID:   p.FindingId,
+		From:        findings.TriageState(p.From),
+		To:          findings.TriageState(p.To),
+		Reason:      p.Reason,
+		Actor:       p.Actor,
+		Assignee:    p.Assignee,
+		Analyzer:    p.Analyzer,
+		FilePath:    p.FilePath,
+		Title:       p.Title,
+		Commit:      p.Commit,
+		SessionID:   p.SessionId,
+	}
+	if p.Until != nil {
+		out.Until = p.Until.AsTime()
+	}
+	if p.CreatedAt != nil {
+		out.CreatedAt = p.CreatedAt.AsTime()
+	}
+	return out
+}
+
+func triageStatusToProto(st *findings.TriageStatus) *FindingTriageStatus {
+	if st == nil {
+		return nil
+	}
+	out := &FindingTriageStatus{
+		Fingerprint: st.Fingerprint,
+		State:       string(st.State),
+		Reason:      st.Reason,
+		Actor:       st.Actor,
+		Assignee:    st.Assignee,
+	}
+	if !st.Until.IsZero() {
+		out.Until = timestamppb.New(st.Until)
+	}
+	if !st.UpdatedAt.IsZero() {
+		out.UpdatedAt = timestamppb.New(st.UpdatedAt)
+	}
+	return out
+}
+
 func surveyEntryToProto(e *survey.Entry) *SurveyEntry {
 	if e == nil {
 		return nil
@@ -96,6 +96,14 @@ func (c *Client) Do(req *http.Request) (*http.Response, error) {
 				return nil, req.Context().Err()
 			case <-time.After(delay):
 			}
+			// The failed attempt consumed the body; rewind it.
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				req.Body = body
+			}
 		}
 
 		resp, err := c.httpClient.Do(req)
@@ -0,0 +1,248 @@
+package importresolve
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// cIncludeFlags are the compiler flags that add an include directory, in
+// both the joined (-Iinc) and separate (-I inc) forms.
+var cIncludeFlags = []string{"-iquote", "-isystem", "-idirafter", "-I"}
+
+// cResolver maps C and C++ #include paths to header files, searching the
+// way the preprocessor does: a quoted include first tries the including
+// file's directory, then both forms walk the include path. The include path
+// comes from compile_commands.json (per source file, then merged as the
+// fallback for headers, which have no entry of their own) and
+// compile_flags.txt, then the conventional project layout: each build
+// file's directory and its include/. System and third-party headers appear
+// on none of those and resolve to "".
+type cResolver struct {
+	fs           *projectFS
+	fileIncludes map[string][]string // source file -> its include dirs from compile_commands.json
+	includes     []string            // include dirs from compilation databases, discovery order
+	conventional []string            // build-file dirs and their include/
+	buildDirs    []string            // build-file dirs, probed for an out-of-tree build/compile_commands.json
+	loaded       map[string]bool     // compilation databases already read
+}
+
+func newCResolver(pfs *projectFS) *cResolver {
+	c := &cResolver{
+		fs:           pfs,
+		fileIncludes: make(map[string][]string),
+		loaded:       make(map[string]bool),
+	}
+	c.conventional = appendDir(pfs, nil, "")
+	c.conventional = appendDir(pfs, c.conventional, "include")
+	return c
+}
+
+func (c *cResolver) languages() []string { return []string{"c", "cpp"} }
+
+func (c *cResolver) manifests() []string {
+	return []string{"compile_commands.json", "compile_flags.txt", "CMakeLists.txt", "meson.build", "Makefile"}
+}
+
+func (c *cResolver) addManifest(relDir, file string) {
+	switch path.Base(file) {
+	case "compile_commands.json":
+		c.loadCompileCommands(file)
+	case "compile_flags.txt":
+		c.loadCompileFlags(relDir, file)
+	default:
+		c.conventional = appendDir(c.fs, c.conventional, relDir)
+		c.conventional = appendDir(c.fs, c.conventional, path.Join(relDir, "include"))
+		c.buildDirs = append(c.buildDirs, relDir)
+	}
+}
+
+// finalize picks up compilation databases in build/, which the project scan
+// skips: CMake's -DCMAKE_EXPORT_COMPILE_COMMANDS writes one there.
+func (c *cResolver) finalize() {
+	for _, dir := range append([]string{""}, c.buildDirs...) {
+		if f := path.Join(dir, "build", "compile_commands.json"); c.fs.fileExists(f) {
+			c.loadCompileCommands(f)
+		}
+	}
+}
+
+func (c *cResolver) resolve(fromFile, imp string) string {
+	angled := strings.HasPrefix(imp, "<") && strings.HasSuffix(imp, ">")
+	imp = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(imp, "<"), ">"))
+	if imp == "" || path.IsAbs(imp) {
+		return ""
+	}
+	if !angled {
+		if f := path.Join(path.Dir(fromFile), imp); !strings.HasPrefix(f, "../") && c.fs.fileExists(f) {
+			return f
+		}
+	}
+	for _, dirs := range [][]string{c.fileIncludes[fromFile], c.includes, c.conventional} {
+		for _, dir := range dirs {
+			if f := path.Join(dir, imp); !strings.HasPrefix(f, "../") && c.fs.fileExists(f) {
+				return f
+			}
+		}
+	}
+	return ""
+}
+
+func (c *cResolver) unitOf(file string) string { return file }
+
+func (c *cResolver) resolveFiles(fromFile, imp string) []string {
+	if f := c.resolve(fromFile, imp); f != "" {
+		return []string{f}
+	}
+	return nil
+}
+
+// compileCommand is one entry of a JSON compilation database.
+type compileCommand struct {
+	Directory string   `json:"directory"`
+	File      string   `json:"file"`
+	Command   string   `json:"command"`
+	Arguments []string `json:"arguments"`
+}
+
+// loadCompileCommands reads the include dirs of every entry in a
+// compilation database. Its paths are absolute on the machine that wrote
+// it, so the project root's absolute path is recovered from the first
+// entry whose file exists in the project, and include dirs outside the
+// project are dropped.
+func (c *cResolver) loadCompileCommands(file string) {
+	if c.loaded[file] {
+		return
+	}
+	c.loaded[file] = true
+	data, err := c.fs.readFile(file)
+	if err != nil {
+		return
+	}
+	var entries []compileCommand
+	if json.Unmarshal(data, &entries) != nil {
+		return
+	}
+	root, known := "", false
+	for _, e := range entries {
+		dir := slashPath(e.Directory)
+		src := slashPath(e.File)
+		if !path.IsAbs(src) {
+			src = path.Join(dir, src)
+		}
+		if !known {
+			root, known = c.projectPrefix(src)
+			if !known {
+				continue
+			}
+		}
+		rel, ok := underRoot(root, src)
+		if !ok {
+			continue
+		}
+		args := e.Arguments
+		if len(args) == 0 {
+			args = strings.Fields(e.Command)
+		}
+		for _, inc := range cIncludeDirs(args) {
+			if !path.IsAbs(inc) {
+				inc = path.Join(dir, inc)
+			}
+			if incRel, ok := underRoot(root, inc); ok && c.fs.dirExists(incRel) {
+				c.fileIncludes[rel] = appendUnique(c.fileIncludes[rel], incRel)
+				c.includes = appendUnique(c.includes, incRel)
+			}
+		}
+	}
+}
+
+// loadCompileFlags reads clangd's compile_flags.txt: one argument per line,
+// include dirs relative to the file's directory.
+func (c *cResolver) loadCompileFlags(relDir, file string) {
+	data, err := c.fs.readFile(file)
+	if err != nil {
+		return
+	}
+	for _, inc := range cIncludeDirs(strings.Split(string(data), "\n")) {
+		if path.IsAbs(inc) {
+			continue
+		}
+		if rel := path.Join(relDir, inc); !strings.HasPrefix(rel, "../") {
+			c.includes = appendDir(c.fs, c.includes, rel)
+		}
+	}
+}
+
+// projectPrefix recovers the absolute project root from an absolute path
+// of a file in the project: the longest tail of abs that names an existing
+// project file is its project-relative path.
+func (c *cResolver) projectPrefix(abs string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(abs, "/"), "/")
+	for i := range parts {
+		if c.fs.fileExists(strings.Join(parts[i:], "/")) {
+			return "/" + strings.Join(parts[:i], "/"), true
+		}
+	}
+	return "", false
+}
+
+// cIncludeDirs extracts the include directories from compiler arguments.
+func cIncludeDirs(args []string) []string {
+	var dirs []string
+	for i := 0; i < len(args); i++ {
+		arg := strings.Trim(strings.TrimSpace(args[i]), `"'`)
+		for _, flag := range cIncludeFlags {
+			val, ok := strings.CutPrefix(arg, flag)
+			if !ok {
+				continue
+			}
+			if val == "" && i+1 < len(args) {
+				i++
+				val = strings.Trim(strings.TrimSpace(args[i]), `"'`)
+			}
+			if val != "" {
+				dirs = append(dirs, path.Clean(slashPath(val)))
+			}
+			break
+		}
+	}
+	return dirs
+}
+
+// slashPath converts a compilation-database path to slash form. Databases
+// written on Windows use backslashes whatever the reading platform.
+func slashPath(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// underRoot returns abs relative to the absolute project root, and false
+// when abs lies outside it.
+func underRoot(root, abs string) (string, bool) {
+	abs = path.Clean(abs)
+	if root == "/" {
+		return strings.TrimPrefix(abs, "/"), true
+	}
+	if abs == root {
+		return "", true
+	}
+	rel, ok := strings.CutPrefix(abs, root+"/")
+	return rel, ok
+}
+
+// appendDir appends rel to dirs if it is an existing directory not already
+// listed.
+func appendDir(pfs *projectFS, dirs []string, rel string) []string {
+	if rel != "" && !pfs.dirExists(rel) {
+		return dirs
+	}
+	return appendUnique(dirs, rel)
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, existing := range list {
+		if existing == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
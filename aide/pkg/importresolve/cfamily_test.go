@@ -0,0 +1,51 @@
+package importresolve
+
+import "testing"
+
+func TestResolveCInclude(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		"CMakeLists.txt": "project(proj C)\n",
+		// Written by the build on another machine: absolute paths, one
+		// entry with arguments, one with a command line and a relative file.
+		"build/compile_commands.json": `[
+  {"directory": "/home/dev/proj/build", "file": "/home/dev/proj/app/main.c",
+   "arguments": ["cc", "-I../libs/net/api", "-isystem", "/usr/include", "-c", "../app/main.c"]},
+  {"directory": "/home/dev/proj/build", "file": "../tools/gen.c",
+   "command": "cc -I ../third/inc -c ../tools/gen.c"}
+]`,
+		"app/main.c":                 "#include \"local.h\"\n",
+		"app/local.h":                "",
+		"libs/net/api/net/socket.h":  "",
+		"include/core/types.h":       "",
+		"third/inc/x.h":              "",
+		"tools/gen.c":                "",
+		"embedded/compile_flags.txt": "-xc\n-Iboard\n",
+		"embedded/board/pins.h":      "",
+		"embedded/fw.c":              "",
+	}))
+
+	cases := []struct {
+		lang, from, imp, want string
+	}{
+		{"c", "app/main.c", `"local.h"`, "app/local.h"},                            // quoted: includer's dir first
+		{"c", "app/main.c", "<local.h>", ""},                                       // angled: include path only
+		{"c", "app/main.c", "<net/socket.h>", "libs/net/api/net/socket.h"},         // -I from compile_commands.json
+		{"cpp", "app/main.c", "net/socket.h", "libs/net/api/net/socket.h"},         // C++ shares the resolver
+		{"c", "tools/gen.c", "x.h", "third/inc/x.h"},                               // -I <dir> in a command line
+		{"c", "include/core/types.h", "net/socket.h", "libs/net/api/net/socket.h"}, // header: merged include path
+		{"c", "app/main.c", "<core/types.h>", "include/core/types.h"},              // conventional include/
+		{"c", "tools/gen.c", "app/local.h", "app/local.h"},                         // project root
+		{"c", "embedded/fw.c", "pins.h", "embedded/board/pins.h"},                  // compile_flags.txt
+		{"c", "app/main.c", "<stdio.h>", ""},                                       // system header
+		{"c", "app/main.c", "../../etc/passwd", ""},                                // escapes the project
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit(c.lang, c.from, c.imp); got != c.want {
+			t.Errorf("ResolveUnit(%s, %q from %q) = %q, want %q", c.lang, c.imp, c.from, got, c.want)
+		}
+	}
+
+	if got := r.UnitOf("c", "app/local.h"); got != "app/local.h" {
+		t.Errorf("UnitOf(c) = %q, want the file itself", got)
+	}
+}
@@ -1,8 +1,7 @@
 package importresolve
 
 import (
-	"os"
-	"path/filepath"
+	"path"
 	"regexp"
 	"sort"
 	"strings"
@@ -44,12 +43,12 @@ func newCSResolver(pfs *projectFS) *csResolver {
 func (c *csResolver) languages() []string { return []string{"csharp"} }
 func (c *csResolver) manifests() []string { return []string{"*.cs"} }
 
-func (c *csResolver) addManifest(relDir, absPath string) {
-	base := filepath.Base(absPath)
+func (c *csResolver) addManifest(relDir, file string) {
+	base := path.Base(file)
 	if !strings.HasSuffix(base, ".cs") {
 		return
 	}
-	ns := csFileNamespace(absPath)
+	ns := csFileNamespace(c.fs, file)
 	if ns == "" {
 		return
 	}
@@ -135,8 +134,8 @@ func (c *csResolver) unitOf(file string) string {
 
 // csFileNamespace extracts the first namespace declaration from a .cs file
 // header.
-func csFileNamespace(absPath string) string {
-	f, err := os.Open(absPath)
+func csFileNamespace(pfs *projectFS, file string) string {
+	f, err := pfs.open(file)
 	if err != nil {
 		return ""
 	}
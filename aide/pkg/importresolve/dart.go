@@ -0,0 +1,70 @@
+package importresolve
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// pubspecNameRe matches the top-level package name in pubspec.yaml.
+var pubspecNameRe = regexp.MustCompile(`(?m)^name:\s*['"]?([A-Za-z0-9_]+)`)
+
+// dartResolver maps Dart import and export URIs to source files. A
+// `package:name/path.dart` URI lands in the lib/ directory of the project
+// package whose pubspec.yaml declares that name; a relative URI resolves
+// against the importing file. `dart:` libraries and packages not in the
+// project resolve to "".
+type dartResolver struct {
+	fs       *projectFS
+	packages map[string]string // pubspec name -> project-relative package dir
+}
+
+func newDartResolver(pfs *projectFS) *dartResolver {
+	return &dartResolver{fs: pfs, packages: make(map[string]string)}
+}
+
+func (d *dartResolver) languages() []string { return []string{"dart"} }
+func (d *dartResolver) manifests() []string { return []string{"pubspec.yaml"} }
+
+func (d *dartResolver) addManifest(relDir, file string) {
+	data, err := d.fs.readFile(file)
+	if err != nil {
+		return
+	}
+	if m := pubspecNameRe.FindSubmatch(data); m != nil {
+		if _, dup := d.packages[string(m[1])]; !dup {
+			d.packages[string(m[1])] = relDir
+		}
+	}
+}
+
+func (d *dartResolver) finalize() {}
+
+func (d *dartResolver) resolve(fromFile, imp string) string {
+	var cand string
+	if rest, ok := strings.CutPrefix(imp, "package:"); ok {
+		name, rel, found := strings.Cut(rest, "/")
+		dir, known := d.packages[name]
+		if !found || !known {
+			return ""
+		}
+		cand = path.Join(dir, "lib", rel)
+	} else if strings.Contains(imp, ":") {
+		return "" // dart:, http:, file: and other schemes
+	} else {
+		cand = path.Join(path.Dir(fromFile), imp)
+	}
+	if strings.HasPrefix(cand, "../") || !d.fs.fileExists(cand) {
+		return ""
+	}
+	return cand
+}
+
+func (d *dartResolver) unitOf(file string) string { return file }
+
+func (d *dartResolver) resolveFiles(fromFile, imp string) []string {
+	if f := d.resolve(fromFile, imp); f != "" {
+		return []string{f}
+	}
+	return nil
+}
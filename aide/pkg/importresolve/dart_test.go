@@ -0,0 +1,32 @@
+package importresolve
+
+import "testing"
+
+func TestResolveDart(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		"pubspec.yaml":                "name: shop\ndependencies:\n  http: ^1.0.0\n",
+		"lib/main.dart":               "",
+		"lib/src/cart.dart":           "",
+		"lib/src/models/item.dart":    "",
+		"packages/ui/pubspec.yaml":    "name: 'shop_ui'\n",
+		"packages/ui/lib/button.dart": "",
+		"test/cart_test.dart":         "",
+	}))
+
+	cases := []struct {
+		from, imp, want string
+	}{
+		{"lib/main.dart", "package:shop/src/cart.dart", "lib/src/cart.dart"},            // own package
+		{"lib/main.dart", "package:shop_ui/button.dart", "packages/ui/lib/button.dart"}, // workspace package
+		{"lib/src/cart.dart", "models/item.dart", "lib/src/models/item.dart"},           // relative
+		{"test/cart_test.dart", "../lib/src/cart.dart", "lib/src/cart.dart"},            // relative, parent dir
+		{"lib/main.dart", "package:http/http.dart", ""},                                 // pub dependency
+		{"lib/main.dart", "dart:async", ""},                                             // SDK library
+		{"lib/main.dart", "package:shop/src/missing.dart", ""},
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit("dart", c.from, c.imp); got != c.want {
+			t.Errorf("ResolveUnit(dart, %q from %q) = %q, want %q", c.imp, c.from, got, c.want)
+		}
+	}
+}
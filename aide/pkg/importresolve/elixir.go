@@ -0,0 +1,93 @@
+package importresolve
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// exDefmoduleRe matches a defmodule line, capturing its indentation and the
+// module name as written.
+var exDefmoduleRe = regexp.MustCompile(`^(\s*)defmodule\s+([A-Z][A-Za-z0-9_.]*)\s*(?:,|do\b)`)
+
+// exReadCap bounds how much of each .ex file is scanned for defmodule
+// declarations; modules past it go unindexed rather than making the scan
+// O(codebase).
+const exReadCap = 64 * 1024
+
+// exResolver maps Elixir alias/import/use/require module names to the file
+// defining the module. Elixir does not tie module names to paths — the
+// lib/my_app/accounts/user.ex convention is only a convention — so, as for
+// C# namespaces, resolution is driven by a pre-scan of defmodule
+// declarations rather than by probing. Nested modules are qualified by
+// their enclosing module, tracked by indentation. Modules from mix deps
+// (deps/, _build/) and the standard library resolve to "".
+type exResolver struct {
+	fs      *projectFS
+	modules map[string]string // module name -> defining file
+}
+
+func newElixirResolver(pfs *projectFS) *exResolver {
+	return &exResolver{fs: pfs, modules: make(map[string]string)}
+}
+
+func (e *exResolver) languages() []string { return []string{"elixir"} }
+func (e *exResolver) manifests() []string { return []string{"*.ex"} }
+
+func (e *exResolver) addManifest(_, file string) {
+	for _, seg := range strings.Split(path.Dir(file), "/") {
+		if seg == "deps" || seg == "_build" {
+			return
+		}
+	}
+	f, err := e.fs.open(file)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	type open struct {
+		indent int
+		name   string
+	}
+	var stack []open
+	sc := bufio.NewScanner(io.LimitReader(f, exReadCap))
+	for sc.Scan() {
+		m := exDefmoduleRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		indent := len(m[1])
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		name := m[2]
+		if len(stack) > 0 {
+			name = stack[len(stack)-1].name + "." + name
+		}
+		stack = append(stack, open{indent: indent, name: name})
+		if _, dup := e.modules[name]; !dup {
+			e.modules[name] = file
+		}
+	}
+}
+
+func (e *exResolver) finalize() {}
+
+// resolve looks the module up exactly. The multi-alias form
+// `alias MyApp.Accounts.{User, Team}` is captured as "MyApp.Accounts." and
+// lands on the parent module.
+func (e *exResolver) resolve(_ string, imp string) string {
+	return e.modules[strings.TrimSuffix(imp, ".")]
+}
+
+func (e *exResolver) unitOf(file string) string { return file }
+
+func (e *exResolver) resolveFiles(fromFile, imp string) []string {
+	if f := e.resolve(fromFile, imp); f != "" {
+		return []string{f}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package importresolve
+
+import "testing"
+
+func TestResolveElixir(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		"mix.exs":                      "defmodule Shop.MixProject do\nend\n",
+		"lib/shop/accounts/user.ex":    "defmodule Shop.Accounts.User do\n  use Ecto.Schema\n\n  defmodule Query do\n  end\nend\n",
+		"lib/shop/accounts.ex":         "defmodule Shop.Accounts do\n  alias Shop.Accounts.{User, Team}\nend\n",
+		"lib/shop/web/helpers.ex":      "defmodule ShopWeb.Helpers, do: nil\n", // name unrelated to path
+		"lib/shop/web/router.ex":       "defmodule ShopWeb.Router do\nend\n\ndefmodule ShopWeb.Plugs do\nend\n",
+		"deps/ecto/lib/ecto/schema.ex": "defmodule Ecto.Schema do\nend\n",
+	}))
+
+	cases := []struct {
+		imp, want string
+	}{
+		{"Shop.Accounts.User", "lib/shop/accounts/user.ex"},
+		{"Shop.Accounts.User.Query", "lib/shop/accounts/user.ex"}, // nested module, qualified by its parent
+		{"Shop.Accounts.", "lib/shop/accounts.ex"},                // multi-alias capture artifact
+		{"ShopWeb.Helpers", "lib/shop/web/helpers.ex"},            // found by declaration, not path
+		{"ShopWeb.Plugs", "lib/shop/web/router.ex"},               // second top-level module in a file
+		{"Query", ""},       // nested modules are not top-level
+		{"Ecto.Schema", ""}, // mix dependency
+		{"Enum", ""},        // stdlib
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit("elixir", "lib/shop/accounts.ex", c.imp); got != c.want {
+			t.Errorf("ResolveUnit(elixir, %q) = %q, want %q", c.imp, got, c.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package importresolve
 
 import (
 	"bufio"
-	"os"
 	"path"
 	"sort"
 	"strings"
@@ -28,8 +27,8 @@ func newGoResolver(pfs *projectFS) *goResolver {
 func (g *goResolver) languages() []string { return []string{"go"} }
 func (g *goResolver) manifests() []string { return []string{"go.mod"} }
 
-func (g *goResolver) addManifest(relDir, absPath string) {
-	if mod := goModulePath(absPath); mod != "" {
+func (g *goResolver) addManifest(relDir, file string) {
+	if mod := goModulePath(g.fs, file); mod != "" {
 		g.modules = append(g.modules, goModule{modPath: mod, dir: relDir})
 	}
 }
@@ -103,8 +102,8 @@ func (g *goResolver) dirHasGoFiles(rel string) bool {
 }
 
 // goModulePath extracts the module directive from a go.mod file.
-func goModulePath(goModFile string) string {
-	f, err := os.Open(goModFile)
+func goModulePath(pfs *projectFS, goModFile string) string {
+	f, err := pfs.open(goModFile)
 	if err != nil {
 		return ""
 	}
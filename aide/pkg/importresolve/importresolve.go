@@ -5,7 +5,9 @@
 //
 // A "unit" is the granularity at which a language couples:
 //   - Go: the package directory (imports and import cycles are package-level)
-//   - TypeScript/JavaScript, Python: the target source file
+//   - Swift: the SwiftPM target directory (imports name whole modules)
+//   - C#: the namespace
+//   - everything else: the target source file
 //
 // # Adding a language
 //
@@ -20,6 +22,8 @@ package importresolve
 
 import (
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
 	"strings"
 )
@@ -38,9 +42,10 @@ type languageResolver interface {
 	manifests() []string
 	// addManifest is called once per discovered manifest, with the
 	// project-relative directory containing it ("" = root) and its
-	// absolute path for reading. Parsing is best-effort: garbage in a
-	// manifest means skip it, never fail the scan.
-	addManifest(relDir, absPath string)
+	// project-relative path, read through the resolver's projectFS.
+	// Parsing is best-effort: garbage in a manifest means skip it, never
+	// fail the scan.
+	addManifest(relDir, file string)
 	// finalize is called after the scan completes, before any resolve call.
 	finalize()
 	// resolve maps a normalized import string in fromFile to a
@@ -65,12 +70,19 @@ func newLanguageResolvers(pfs *projectFS) []languageResolver {
 		newRustResolver(pfs),
 		newJVMResolver(pfs),
 		newCSResolver(pfs),
+		newCResolver(pfs),
+		newRubyResolver(pfs),
+		newPHPResolver(pfs),
+		newSwiftResolver(pfs),
+		newDartResolver(pfs),
+		newElixirResolver(pfs),
 	}
 }
 
 // Resolver resolves import strings against a scanned project layout.
 type Resolver struct {
-	byLang map[string]languageResolver
+	byLang      map[string]languageResolver
+	importCache map[string]map[string]bool // ResolveTarget: file+imports -> imported files
 }
 
 // Directories never descended into during project scanning. Dot-directories
@@ -87,12 +99,18 @@ var skipDirs = map[string]bool{
 // imports (relative TS specifiers, root-anchored Python modules).
 func New(rootDir string) *Resolver {
 	// A symlinked root (common: ~/src trees pointing at a data volume)
-	// breaks filepath.WalkDir, which lstats the root — resolve it first so
+	// breaks the walk, which lstats the root — resolve it first so
 	// manifest discovery sees the real directory.
 	if resolved, err := filepath.EvalSymlinks(rootDir); err == nil {
 		rootDir = resolved
 	}
-	pfs := newProjectFS(rootDir)
+	return NewFS(os.DirFS(rootDir))
+}
+
+// NewFS is New over an arbitrary project tree rooted at fsys — used to
+// resolve imports in a commit's tree without checking it out.
+func NewFS(fsys fs.FS) *Resolver {
+	pfs := newProjectFS(fsys)
 	resolvers := newLanguageResolvers(pfs)
 
 	byManifest := make(map[string][]languageResolver)
@@ -111,28 +129,24 @@ func New(rootDir string) *Resolver {
 		}
 	}
 
-	_ = filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, err error) error {
+	_ = fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
 		if d.IsDir() {
-			if p != rootDir && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
-				return filepath.SkipDir
+			if p != "." && (skipDirs[d.Name()] || strings.HasPrefix(d.Name(), ".")) {
+				return fs.SkipDir
 			}
 			return nil
 		}
 		interested := byManifest[d.Name()]
-		if ext := filepath.Ext(d.Name()); ext != "" {
+		if ext := path.Ext(d.Name()); ext != "" {
 			interested = append(interested, bySuffix[ext]...)
 		}
 		if len(interested) == 0 {
 			return nil
 		}
-		rel, relErr := filepath.Rel(rootDir, filepath.Dir(p))
-		if relErr != nil {
-			return nil
-		}
-		rel = filepath.ToSlash(rel)
+		rel := path.Dir(p)
 		if rel == "." {
 			rel = ""
 		}
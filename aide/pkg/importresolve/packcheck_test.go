@@ -1,6 +1,7 @@
 package importresolve
 
 import (
+	"os"
 	"testing"
 
 	"github.com/jmylchreest/aide/aide/pkg/grammar"
@@ -13,7 +14,7 @@ import (
 // no file will ever dispatch to it under that name.
 func TestResolverLanguagesMatchGrammarPacks(t *testing.T) {
 	reg := grammar.DefaultPackRegistry()
-	for _, lr := range newLanguageResolvers(newProjectFS(t.TempDir())) {
+	for _, lr := range newLanguageResolvers(newProjectFS(os.DirFS(t.TempDir()))) {
 		for _, lang := range lr.languages() {
 			if reg.Get(lang) == nil {
 				t.Errorf("resolver %T declares language %q, but no grammar pack has that name", lr, lang)
@@ -29,7 +30,7 @@ func TestResolverLanguagesMatchGrammarPacks(t *testing.T) {
 // its own "tsx" pack rather than under "typescript".
 func TestDispatchCoversDetectedLanguages(t *testing.T) {
 	registered := make(map[string]bool)
-	for _, lr := range newLanguageResolvers(newProjectFS(t.TempDir())) {
+	for _, lr := range newLanguageResolvers(newProjectFS(os.DirFS(t.TempDir()))) {
 		for _, lang := range lr.languages() {
 			registered[lang] = true
 		}
@@ -43,6 +44,12 @@ func TestDispatchCoversDetectedLanguages(t *testing.T) {
 		".rs",
 		".java", ".kt", ".kts", ".scala",
 		".cs",
+		".c", ".h", ".cpp", ".cc", ".hpp",
+		".rb",
+		".php",
+		".swift",
+		".dart",
+		".ex", ".exs",
 	}
 	for _, ext := range exts {
 		lang, ok := reg.LangForExtension(ext)
@@ -0,0 +1,143 @@
+package importresolve
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+)
+
+// phpResolver maps PHP use-statements to class files through composer.json
+// autoload maps, and require/include paths to the file they name. PSR-4
+// strips the namespace prefix and maps the rest onto the prefix's
+// directories (App\Models\User with "App\\": "src/" is src/Models/User.php);
+// PSR-0 maps the whole class name, with underscores in the class name
+// becoming directories too. Classes under no project prefix — vendor
+// packages, PHP builtins — resolve to "".
+type phpResolver struct {
+	fs       *projectFS
+	prefixes []phpPrefix // longest namespace first
+}
+
+// phpPrefix is one composer autoload mapping.
+type phpPrefix struct {
+	ns   string // namespace prefix with trailing backslash, "" = fallback
+	dir  string // project-relative directory
+	psr0 bool
+}
+
+func newPHPResolver(pfs *projectFS) *phpResolver {
+	return &phpResolver{fs: pfs}
+}
+
+func (p *phpResolver) languages() []string { return []string{"php"} }
+func (p *phpResolver) manifests() []string { return []string{"composer.json"} }
+
+// composerJSON is the autoload subset of composer.json.
+type composerJSON struct {
+	Autoload    composerAutoload `json:"autoload"`
+	AutoloadDev composerAutoload `json:"autoload-dev"`
+}
+
+type composerAutoload struct {
+	PSR4 map[string]json.RawMessage `json:"psr-4"`
+	PSR0 map[string]json.RawMessage `json:"psr-0"`
+}
+
+func (p *phpResolver) addManifest(relDir, file string) {
+	data, err := p.fs.readFile(file)
+	if err != nil {
+		return
+	}
+	var c composerJSON
+	if json.Unmarshal(data, &c) != nil {
+		return
+	}
+	for _, a := range []composerAutoload{c.Autoload, c.AutoloadDev} {
+		p.addPrefixes(relDir, a.PSR4, false)
+		p.addPrefixes(relDir, a.PSR0, true)
+	}
+}
+
+// addPrefixes records an autoload map; each prefix maps to one directory or
+// a list of them.
+func (p *phpResolver) addPrefixes(relDir string, m map[string]json.RawMessage, psr0 bool) {
+	for ns, raw := range m {
+		var dirs []string
+		var one string
+		if json.Unmarshal(raw, &one) == nil {
+			dirs = []string{one}
+		} else if json.Unmarshal(raw, &dirs) != nil {
+			continue
+		}
+		ns = strings.TrimLeft(ns, `\`)
+		for _, d := range dirs {
+			dir := path.Join(relDir, d)
+			if strings.HasPrefix(dir, "../") || !p.fs.dirExists(dir) {
+				continue
+			}
+			p.prefixes = append(p.prefixes, phpPrefix{ns: ns, dir: dir, psr0: psr0})
+		}
+	}
+}
+
+func (p *phpResolver) finalize() {
+	sort.SliceStable(p.prefixes, func(i, k int) bool {
+		if len(p.prefixes[i].ns) != len(p.prefixes[k].ns) {
+			return len(p.prefixes[i].ns) > len(p.prefixes[k].ns)
+		}
+		return p.prefixes[i].dir < p.prefixes[k].dir
+	})
+}
+
+func (p *phpResolver) resolve(fromFile, imp string) string {
+	if strings.Contains(imp, "/") || strings.HasSuffix(imp, ".php") {
+		return p.resolvePath(fromFile, imp)
+	}
+	class := strings.Trim(imp, `\`)
+	if class == "" {
+		return ""
+	}
+	for _, pre := range p.prefixes {
+		if !strings.HasPrefix(class, pre.ns) {
+			continue
+		}
+		var rel string
+		if pre.psr0 {
+			ns, name := "", class
+			if i := strings.LastIndexByte(class, '\\'); i >= 0 {
+				ns, name = class[:i+1], class[i+1:]
+			}
+			rel = strings.ReplaceAll(ns, `\`, "/") + strings.ReplaceAll(name, "_", "/")
+		} else {
+			rel = strings.ReplaceAll(class[len(pre.ns):], `\`, "/")
+		}
+		if f := path.Join(pre.dir, rel) + ".php"; p.fs.fileExists(f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// resolvePath resolves a require/include path against the including file's
+// directory, then the project root. Composer's vendor/ tree is external.
+func (p *phpResolver) resolvePath(fromFile, imp string) string {
+	for _, cand := range []string{path.Join(path.Dir(fromFile), imp), path.Clean(imp)} {
+		if strings.HasPrefix(cand, "../") || strings.HasPrefix(cand, "vendor/") || strings.Contains(cand, "/vendor/") {
+			continue
+		}
+		if p.fs.fileExists(cand) {
+			return cand
+		}
+	}
+	return ""
+}
+
+func (p *phpResolver) unitOf(file string) string { return file }
+
+func (p *phpResolver) resolveFiles(fromFile, imp string) []string {
+	if f := p.resolve(fromFile, imp); f != "" {
+		return []string{f}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package importresolve
+
+import "testing"
+
+func TestResolvePHP(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		"composer.json": `{
+  "autoload": {
+    "psr-4": {"App\\": "src/", "App\\Domain\\": ["domain/", "missing/"]},
+    "psr-0": {"Legacy_": "legacy/"}
+  },
+  "autoload-dev": {"psr-4": {"Tests\\": "tests/"}}
+}`,
+		"src/Models/User.php":           "<?php\nnamespace App\\Models;\n",
+		"src/Http/Kernel.php":           "",
+		"domain/Order.php":              "",
+		"legacy/Legacy/Mail/Sender.php": "",
+		"tests/UserTest.php":            "",
+		"public/index.php":              "<?php\nrequire 'bootstrap.php';\n",
+		"public/bootstrap.php":          "",
+		"config/app.php":                "",
+		"vendor/autoload.php":           "",
+	}))
+
+	cases := []struct {
+		from, imp, want string
+	}{
+		{"src/Http/Kernel.php", `App\Models\User`, "src/Models/User.php"},              // PSR-4
+		{"src/Http/Kernel.php", `\App\Models\User`, "src/Models/User.php"},             // fully qualified
+		{"src/Http/Kernel.php", `App\Domain\Order`, "domain/Order.php"},                // longest prefix, list of dirs
+		{"src/Http/Kernel.php", `Legacy_Mail_Sender`, "legacy/Legacy/Mail/Sender.php"}, // PSR-0 underscores
+		{"src/Http/Kernel.php", `Tests\UserTest`, "tests/UserTest.php"},                // autoload-dev
+		{"src/Http/Kernel.php", `App\Models\Missing`, ""},
+		{"src/Http/Kernel.php", `Illuminate\Support\Str`, ""},         // vendor package
+		{"public/index.php", "bootstrap.php", "public/bootstrap.php"}, // include beside the file
+		{"public/index.php", "config/app.php", "config/app.php"},      // include from the project root
+		{"public/index.php", "vendor/autoload.php", ""},               // composer's vendor tree is external
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit("php", c.from, c.imp); got != c.want {
+			t.Errorf("ResolveUnit(php, %q from %q) = %q, want %q", c.imp, c.from, got, c.want)
+		}
+	}
+}
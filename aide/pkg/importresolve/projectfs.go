@@ -1,33 +1,43 @@
 package importresolve
 
 import (
-	"os"
-	"path/filepath"
+	"io/fs"
+	"path"
+	"strings"
 )
 
-// projectFS answers existence and listing probes against the project root,
+// projectFS answers existence and listing probes against the project tree,
 // caching results — resolution probes the same paths and directories over
-// and over across imports.
+// and over across imports. The tree is any fs.FS rooted at the project: the
+// worktree via os.DirFS, or a commit's tree when surveying history.
 type projectFS struct {
-	root      string
+	fsys      fs.FS
 	fileCache map[string]bool
 	dirCache  map[string][]string // rel dir -> names of regular files, nil sentinel = missing dir
 }
 
-func newProjectFS(root string) *projectFS {
+func newProjectFS(fsys fs.FS) *projectFS {
 	return &projectFS{
-		root:      root,
+		fsys:      fsys,
 		fileCache: make(map[string]bool),
 		dirCache:  make(map[string][]string),
 	}
 }
 
+// fsPath maps a project-relative path ("" = root) to an fs.FS name.
+func fsPath(rel string) string {
+	if rel == "" {
+		return "."
+	}
+	return path.Clean(rel)
+}
+
 // fileExists reports whether rel is a regular file under the project root.
 func (p *projectFS) fileExists(rel string) bool {
 	if cached, ok := p.fileCache[rel]; ok {
 		return cached
 	}
-	info, err := os.Stat(filepath.Join(p.root, filepath.FromSlash(rel)))
+	info, err := fs.Stat(p.fsys, fsPath(rel))
 	exists := err == nil && info.Mode().IsRegular()
 	p.fileCache[rel] = exists
 	return exists
@@ -35,7 +45,7 @@ func (p *projectFS) fileExists(rel string) bool {
 
 // dirExists reports whether rel is a directory under the project root.
 func (p *projectFS) dirExists(rel string) bool {
-	info, err := os.Stat(filepath.Join(p.root, filepath.FromSlash(rel)))
+	info, err := fs.Stat(p.fsys, fsPath(rel))
 	return err == nil && info.IsDir()
 }
 
@@ -45,7 +55,7 @@ func (p *projectFS) listFiles(rel string) []string {
 	if cached, ok := p.dirCache[rel]; ok {
 		return cached
 	}
-	entries, err := os.ReadDir(filepath.Join(p.root, filepath.FromSlash(rel)))
+	entries, err := fs.ReadDir(p.fsys, fsPath(rel))
 	var names []string
 	if err == nil {
 		for _, e := range entries {
@@ -57,3 +67,51 @@ func (p *projectFS) listFiles(rel string) []string {
 	p.dirCache[rel] = names
 	return names
 }
+
+// open opens the project-relative file rel for reading.
+func (p *projectFS) open(rel string) (fs.File, error) {
+	return p.fsys.Open(fsPath(rel))
+}
+
+// readFile reads the whole project-relative file rel.
+func (p *projectFS) readFile(rel string) ([]byte, error) {
+	return fs.ReadFile(p.fsys, fsPath(rel))
+}
+
+// listDirs returns the names of subdirectories directly inside rel, skipping
+// dot-directories.
+func (p *projectFS) listDirs(rel string) []string {
+	entries, err := fs.ReadDir(p.fsys, fsPath(rel))
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// treeFiles returns the project-relative paths of every regular file under
+// rel, recursively and in lexical order, skipping dot-directories.
+func (p *projectFS) treeFiles(rel string) []string {
+	var files []string
+	_ = fs.WalkDir(p.fsys, fsPath(rel), func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if name != fsPath(rel) && strings.HasPrefix(d.Name(), ".") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files = append(files, name)
+		}
+		return nil
+	})
+	return files
+}
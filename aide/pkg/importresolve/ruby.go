@@ -0,0 +1,143 @@
+package importresolve
+
+import (
+	"path"
+	"strings"
+	"unicode"
+)
+
+// rbSkipAppDirs are app/ subdirectories Rails does not autoload.
+var rbSkipAppDirs = map[string]bool{"assets": true, "javascript": true, "views": true}
+
+// rbResolver maps Ruby requires and constant paths to source files.
+// require_relative paths resolve against the requiring file; require paths
+// are probed on the load path (each gem or app's lib/) and then beside the
+// requiring file, since the pack captures require and require_relative
+// alike. A constant path (`Billing::Invoice`) follows the Zeitwerk
+// convention — Billing::Invoice lives in billing/invoice.rb under an
+// autoload root: lib/, and every app/ subdirectory of a Rails app. Stdlib
+// and gem requires resolve to "".
+type rbResolver struct {
+	fs       *projectFS
+	loadPath []string // project-relative $LOAD_PATH entries
+	autoload []string // project-relative Zeitwerk autoload roots
+}
+
+func newRubyResolver(pfs *projectFS) *rbResolver {
+	return &rbResolver{fs: pfs}
+}
+
+func (r *rbResolver) languages() []string { return []string{"ruby"} }
+
+func (r *rbResolver) manifests() []string {
+	return []string{"Gemfile", "*.gemspec", "application.rb"}
+}
+
+func (r *rbResolver) addManifest(relDir, file string) {
+	if path.Base(file) != "application.rb" {
+		lib := path.Join(relDir, "lib")
+		r.loadPath = appendDir(r.fs, r.loadPath, lib)
+		r.autoload = appendDir(r.fs, r.autoload, lib)
+		return
+	}
+	if path.Base(relDir) != "config" {
+		return
+	}
+	// config/application.rb marks a Rails app: every app/ subdirectory is
+	// an autoload root, and so are the concerns/ dirs inside them.
+	app := path.Join(path.Dir(relDir), "app")
+	for _, sub := range r.fs.listDirs(app) {
+		if rbSkipAppDirs[sub] {
+			continue
+		}
+		dir := path.Join(app, sub)
+		r.autoload = appendDir(r.fs, r.autoload, dir)
+		r.autoload = appendDir(r.fs, r.autoload, path.Join(dir, "concerns"))
+	}
+}
+
+func (r *rbResolver) finalize() {}
+
+func (r *rbResolver) resolve(fromFile, imp string) string {
+	if isRubyConstant(imp) {
+		return r.resolveConstant(imp)
+	}
+	imp = strings.TrimSuffix(imp, ".rb")
+	fromDir := path.Dir(fromFile)
+	if strings.HasPrefix(imp, "./") || strings.HasPrefix(imp, "../") {
+		return r.probe(path.Join(fromDir, imp))
+	}
+	for _, root := range r.loadPath {
+		if f := r.probe(path.Join(root, imp)); f != "" {
+			return f
+		}
+	}
+	return r.probe(path.Join(fromDir, imp))
+}
+
+// resolveConstant maps a constant path through the Zeitwerk convention,
+// dropping trailing segments until a file matches — a nested class usually
+// lives in its namespace's file.
+func (r *rbResolver) resolveConstant(imp string) string {
+	segs := strings.Split(strings.TrimPrefix(imp, "::"), "::")
+	for i, s := range segs {
+		segs[i] = underscore(s)
+	}
+	for _, root := range r.autoload {
+		for k := len(segs); k >= 1; k-- {
+			if f := r.probe(path.Join(root, strings.Join(segs[:k], "/"))); f != "" {
+				return f
+			}
+		}
+	}
+	return ""
+}
+
+func (r *rbResolver) unitOf(file string) string { return file }
+
+func (r *rbResolver) resolveFiles(fromFile, imp string) []string {
+	if f := r.resolve(fromFile, imp); f != "" {
+		return []string{f}
+	}
+	return nil
+}
+
+func (r *rbResolver) probe(cand string) string {
+	if strings.HasPrefix(cand, "../") {
+		return ""
+	}
+	if f := cand + ".rb"; r.fs.fileExists(f) {
+		return f
+	}
+	return ""
+}
+
+// isRubyConstant reports whether imp is a constant path (Foo::Bar) rather
+// than a require path.
+func isRubyConstant(imp string) bool {
+	s := strings.TrimPrefix(imp, "::")
+	if s == "" || !unicode.IsUpper(rune(s[0])) {
+		return false
+	}
+	return !strings.ContainsAny(s, "/.-")
+}
+
+// underscore converts a CamelCase constant name to its snake_case file name
+// the way ActiveSupport's String#underscore and Elixir's Macro.underscore
+// do: "UsersController" → "users_controller", "HTTPClient" → "http_client".
+func underscore(s string) string {
+	rs := []rune(s)
+	var b strings.Builder
+	for i, c := range rs {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(rs[i-1]) || unicode.IsDigit(rs[i-1]) ||
+				(unicode.IsUpper(rs[i-1]) && i+1 < len(rs) && unicode.IsLower(rs[i+1]))) {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(c))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,55 @@
+package importresolve
+
+import "testing"
+
+func TestResolveRuby(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		// A Rails app with a gem-style lib/.
+		"Gemfile":                             "source \"https://rubygems.org\"\n",
+		"config/application.rb":               "module Shop\n  class Application < Rails::Application\n  end\nend\n",
+		"app/models/billing/invoice.rb":       "module Billing\n  class Invoice\n  end\nend\n",
+		"app/models/concerns/trackable.rb":    "module Trackable\nend\n",
+		"app/controllers/users_controller.rb": "class UsersController\nend\n",
+		"app/services/http_client.rb":         "class HTTPClient\nend\n",
+		"app/views/users/index.rb":            "",
+		"lib/shop/version.rb":                 "",
+		"lib/tasks/seed.rb":                   "require_relative \"helpers\"\n",
+		"lib/tasks/helpers.rb":                "",
+		"engines/pay/pay.gemspec":             "",
+		"engines/pay/lib/pay/gateway.rb":      "",
+	}))
+
+	cases := []struct {
+		from, imp, want string
+	}{
+		{"lib/tasks/seed.rb", "helpers", "lib/tasks/helpers.rb"},                           // require_relative beside the file
+		{"lib/tasks/seed.rb", "./helpers.rb", "lib/tasks/helpers.rb"},                      // explicit relative path
+		{"app/models/billing/invoice.rb", "shop/version", "lib/shop/version.rb"},           // load path: lib/
+		{"app/models/billing/invoice.rb", "pay/gateway", "engines/pay/lib/pay/gateway.rb"}, // gemspec lib/
+		{"lib/tasks/seed.rb", "Billing::Invoice", "app/models/billing/invoice.rb"},         // Zeitwerk: app/models
+		{"lib/tasks/seed.rb", "Billing::Invoice::Line", "app/models/billing/invoice.rb"},   // nested class in its namespace's file
+		{"lib/tasks/seed.rb", "UsersController", "app/controllers/users_controller.rb"},
+		{"lib/tasks/seed.rb", "HTTPClient", "app/services/http_client.rb"},     // acronym underscoring
+		{"lib/tasks/seed.rb", "Trackable", "app/models/concerns/trackable.rb"}, // concerns/ is a root
+		{"lib/tasks/seed.rb", "::Shop::Version", "lib/shop/version.rb"},        // top-level constant, lib/ root
+		{"lib/tasks/seed.rb", "Users::Index", ""},                              // views are not autoloaded
+		{"lib/tasks/seed.rb", "json", ""},                                      // stdlib
+		{"lib/tasks/seed.rb", "ActiveRecord::Base", ""},                        // gem constant
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit("ruby", c.from, c.imp); got != c.want {
+			t.Errorf("ResolveUnit(ruby, %q from %q) = %q, want %q", c.imp, c.from, got, c.want)
+		}
+	}
+}
+
+func TestUnderscore(t *testing.T) {
+	for in, want := range map[string]string{
+		"User": "user", "UsersController": "users_controller", "HTTPClient": "http_client",
+		"OAuth2Token": "o_auth2_token", "V1": "v1", "MyApp": "my_app",
+	} {
+		if got := underscore(in); got != want {
+			t.Errorf("underscore(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package importresolve
 
 import (
 	"bufio"
-	"os"
 	"path"
 	"sort"
 	"strings"
@@ -30,8 +29,8 @@ func newRustResolver(pfs *projectFS) *rustResolver {
 func (r *rustResolver) languages() []string { return []string{"rust"} }
 func (r *rustResolver) manifests() []string { return []string{"Cargo.toml"} }
 
-func (r *rustResolver) addManifest(relDir, absPath string) {
-	name := cargoPackageName(absPath)
+func (r *rustResolver) addManifest(relDir, file string) {
+	name := cargoPackageName(r.fs, file)
 	if name == "" {
 		return // workspace-only manifest
 	}
@@ -163,8 +162,8 @@ func (r *rustResolver) probeChain(base string, segs []string, allowRoot bool) st
 }
 
 // cargoPackageName extracts the name from a Cargo.toml [package] section.
-func cargoPackageName(cargoFile string) string {
-	f, err := os.Open(cargoFile)
+func cargoPackageName(pfs *projectFS, cargoFile string) string {
+	f, err := pfs.open(cargoFile)
 	if err != nil {
 		return ""
 	}
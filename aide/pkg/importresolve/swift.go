@@ -0,0 +1,134 @@
+package importresolve
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// swiftTargetRe matches the start of a target declaration in
+	// Package.swift. Binary targets are matched only to bound the
+	// declaration before them; they have no sources.
+	swiftTargetRe = regexp.MustCompile(`\.(target|executableTarget|testTarget|macro|plugin|systemLibrary|binaryTarget)\s*\(`)
+	swiftNameRe   = regexp.MustCompile(`\bname:\s*"([^"]+)"`)
+	swiftPathRe   = regexp.MustCompile(`\bpath:\s*"([^"]+)"`)
+)
+
+// swiftSourceDirs and swiftTestDirs are the directories SwiftPM looks in
+// for a target without an explicit path, in its order.
+var (
+	swiftSourceDirs = []string{"Sources", "Source", "src", "srcs"}
+	swiftTestDirs   = []string{"Tests", "Sources", "Source", "src", "srcs"}
+)
+
+// swiftResolver maps Swift imports to SwiftPM targets. Swift imports whole
+// modules, and a module is a package target — a directory of .swift files —
+// so the unit is the target directory (like Go packages), found by reading
+// the targets out of each Package.swift: an explicit `path:` or the SwiftPM
+// default Sources/<Target>. SDK frameworks (Foundation, UIKit) and
+// dependency products name no target in the project and resolve to "".
+type swiftResolver struct {
+	fs      *projectFS
+	targets map[string]string // module name -> project-relative target dir
+	dirs    []string          // target dirs, longest first
+}
+
+func newSwiftResolver(pfs *projectFS) *swiftResolver {
+	return &swiftResolver{fs: pfs, targets: make(map[string]string)}
+}
+
+func (s *swiftResolver) languages() []string { return []string{"swift"} }
+func (s *swiftResolver) manifests() []string { return []string{"Package.swift"} }
+
+func (s *swiftResolver) addManifest(relDir, file string) {
+	data, err := s.fs.readFile(file)
+	if err != nil {
+		return
+	}
+	src := string(data)
+	starts := swiftTargetRe.FindAllStringSubmatchIndex(src, -1)
+	for i, m := range starts {
+		end := len(src)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		kind, decl := src[m[2]:m[3]], src[m[1]:end]
+		if kind == "binaryTarget" {
+			continue
+		}
+		name := swiftNameRe.FindStringSubmatch(decl)
+		if name == nil || s.targets[name[1]] != "" {
+			continue
+		}
+		if dir := s.targetDir(relDir, kind, name[1], decl); dir != "" {
+			s.targets[name[1]] = dir
+			s.dirs = append(s.dirs, dir)
+		}
+	}
+}
+
+// targetDir locates a target's sources: its explicit path, else the first
+// SwiftPM default directory holding a <Target> subdirectory.
+func (s *swiftResolver) targetDir(relDir, kind, name, decl string) string {
+	if p := swiftPathRe.FindStringSubmatch(decl); p != nil {
+		dir := path.Join(relDir, p[1])
+		if strings.HasPrefix(dir, "../") || !s.fs.dirExists(dir) {
+			return ""
+		}
+		return dir
+	}
+	parents := swiftSourceDirs
+	if kind == "testTarget" {
+		parents = swiftTestDirs
+	}
+	for _, parent := range parents {
+		if dir := path.Join(relDir, parent, name); s.fs.dirExists(dir) {
+			return dir
+		}
+	}
+	return ""
+}
+
+func (s *swiftResolver) finalize() {
+	sort.Slice(s.dirs, func(i, k int) bool {
+		if len(s.dirs[i]) != len(s.dirs[k]) {
+			return len(s.dirs[i]) > len(s.dirs[k])
+		}
+		return s.dirs[i] < s.dirs[k]
+	})
+}
+
+// resolve maps `import Module` — or `import struct Module.Type`, whose
+// capture carries the symbol — to the module's target directory.
+func (s *swiftResolver) resolve(_ string, imp string) string {
+	module, _, _ := strings.Cut(imp, ".")
+	return s.targets[module]
+}
+
+// resolveFiles fans a target out to its .swift files, nested ones included.
+func (s *swiftResolver) resolveFiles(fromFile, imp string) []string {
+	dir := s.resolve(fromFile, imp)
+	if dir == "" {
+		return nil
+	}
+	var files []string
+	for _, f := range s.fs.treeFiles(dir) {
+		if strings.HasSuffix(f, ".swift") {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// unitOf returns the target directory containing the file, so imports
+// between files of one module collapse to self-edges.
+func (s *swiftResolver) unitOf(file string) string {
+	for _, dir := range s.dirs {
+		if strings.HasPrefix(file, dir+"/") {
+			return dir
+		}
+	}
+	return file
+}
@@ -0,0 +1,61 @@
+package importresolve
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResolveSwift(t *testing.T) {
+	r := New(writeFixture(t, map[string]string{
+		"Package.swift": `// swift-tools-version:5.9
+import PackageDescription
+
+let package = Package(
+    name: "Kit",
+    dependencies: [.package(url: "https://github.com/apple/swift-log", from: "1.0.0")],
+    targets: [
+        .executableTarget(name: "App", dependencies: ["Core"]),
+        .target(
+            name: "Core",
+            dependencies: [.product(name: "Logging", package: "swift-log")]
+        ),
+        .target(name: "Net", path: "Modules/Networking"),
+        .testTarget(name: "CoreTests", dependencies: ["Core"]),
+        .binaryTarget(name: "Blob", path: "Blob.xcframework"),
+    ]
+)
+`,
+		"Sources/App/main.swift":           "import Core\n",
+		"Sources/Core/Store.swift":         "",
+		"Sources/Core/Models/User.swift":   "",
+		"Sources/Core/README.md":           "",
+		"Modules/Networking/Client.swift":  "",
+		"Tests/CoreTests/StoreTests.swift": "",
+	}))
+
+	cases := []struct {
+		imp, want string
+	}{
+		{"Core", "Sources/Core"},         // default Sources/<Target>
+		{"Net", "Modules/Networking"},    // explicit path:
+		{"CoreTests", "Tests/CoreTests"}, // test target default
+		{"Core.Models", "Sources/Core"},  // `import struct Core.User` capture
+		{"Logging", ""},                  // dependency product
+		{"Foundation", ""},               // SDK
+		{"Blob", ""},                     // binary target has no sources
+	}
+	for _, c := range cases {
+		if got := r.ResolveUnit("swift", "Sources/App/main.swift", c.imp); got != c.want {
+			t.Errorf("ResolveUnit(swift, %q) = %q, want %q", c.imp, got, c.want)
+		}
+	}
+
+	files := r.ResolveFiles("swift", "Sources/App/main.swift", "Core")
+	if want := []string{"Sources/Core/Models/User.swift", "Sources/Core/Store.swift"}; !slices.Equal(files, want) {
+		t.Errorf("ResolveFiles(swift, Core) = %v, want %v", files, want)
+	}
+	// Units are targets: files of one module share it.
+	if got := r.UnitOf("swift", "Sources/Core/Models/User.swift"); got != "Sources/Core" {
+		t.Errorf("UnitOf(swift) = %q, want the target dir", got)
+	}
+}
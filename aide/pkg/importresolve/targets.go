@@ -0,0 +1,160 @@
+package importresolve
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+// ResolveTarget picks the definition a call or type reference points at,
+// implementing code.TargetResolver. Candidates are narrowed to kinds the
+// reference can name and to the reference's language family (a declaration
+// indexed as both a class or interface and a type counts once), then tried
+// in scope order:
+//
+//  1. the reference's own file               → code.ConfidenceHigh
+//  2. the file's unit (Go package, …)        → code.ConfidenceHigh
+//  3. files the file's imports resolve to    → code.ConfidenceMedium
+//  4. the whole project, for languages with
+//     no resolver                            → code.ConfidenceLow
+//
+// The first scope holding any candidate decides: one candidate resolves,
+// several leave the reference unresolved. Languages with a resolver never
+// fall through to the project-wide guess — a name that is neither local nor
+// imported is external, in keeping with the package's "missing edge, never a
+// guessed one" rule.
+func (r *Resolver) ResolveTarget(ref *code.Reference, imports []string, candidates []*code.Symbol) (string, string) {
+	var viable []*code.Symbol
+	for _, c := range candidates {
+		if refCanName(ref.Kind, c.Kind) && sameLanguageFamily(ref.Language, c.Language) {
+			viable = append(viable, c)
+		}
+	}
+	if len(viable) == 0 {
+		return "", ""
+	}
+	viable = collapseTypeCaptures(viable)
+
+	from := filepath.ToSlash(ref.FilePath)
+	if id, done := pickOne(viable, func(c *code.Symbol) bool {
+		return filepath.ToSlash(c.FilePath) == from
+	}); done {
+		return id, confidenceIf(id, code.ConfidenceHigh)
+	}
+
+	unit := r.UnitOf(ref.Language, from)
+	if id, done := pickOne(viable, func(c *code.Symbol) bool {
+		return r.UnitOf(c.Language, filepath.ToSlash(c.FilePath)) == unit
+	}); done {
+		return id, confidenceIf(id, code.ConfidenceHigh)
+	}
+
+	imported := r.importedFiles(ref.Language, from, imports)
+	if id, done := pickOne(viable, func(c *code.Symbol) bool {
+		return imported[filepath.ToSlash(c.FilePath)]
+	}); done {
+		return id, confidenceIf(id, code.ConfidenceMedium)
+	}
+
+	if _, ok := r.byLang[ref.Language]; ok || len(viable) != 1 {
+		return "", ""
+	}
+	return viable[0].ID, code.ConfidenceLow
+}
+
+// importedFiles returns the set of project files fromFile's imports land
+// on. Results are memoised per file and import list, since every reference
+// in a file asks the same question.
+func (r *Resolver) importedFiles(lang, fromFile string, imports []string) map[string]bool {
+	key := lang + "\x00" + fromFile + "\x00" + strings.Join(imports, "\x00")
+	if files, ok := r.importCache[key]; ok {
+		return files
+	}
+	files := make(map[string]bool)
+	for _, imp := range imports {
+		for _, f := range r.ResolveFiles(lang, fromFile, imp) {
+			files[f] = true
+		}
+	}
+	if r.importCache == nil {
+		r.importCache = make(map[string]map[string]bool)
+	}
+	r.importCache[key] = files
+	return files
+}
+
+// pickOne filters candidates by in. done is false when none match (try the
+// next scope); otherwise id is the single match, or "" when ambiguous.
+func pickOne(candidates []*code.Symbol, in func(*code.Symbol) bool) (id string, done bool) {
+	for _, c := range candidates {
+		if !in(c) {
+			continue
+		}
+		if done {
+			return "", true
+		}
+		id, done = c.ID, true
+	}
+	return id, done
+}
+
+// collapseTypeCaptures drops the generic type capture of a declaration that
+// is also indexed as a class or interface (Go indexes every struct and
+// interface under both kinds), so the one declaration is one candidate.
+func collapseTypeCaptures(candidates []*code.Symbol) []*code.Symbol {
+	type decl struct {
+		file, name string
+		line       int
+	}
+	named := make(map[decl]bool)
+	for _, c := range candidates {
+		if c.Kind == code.KindClass || c.Kind == code.KindInterface {
+			named[decl{filepath.ToSlash(c.FilePath), c.Name, c.StartLine}] = true
+		}
+	}
+	if len(named) == 0 {
+		return candidates
+	}
+	out := make([]*code.Symbol, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Kind == code.KindType && named[decl{filepath.ToSlash(c.FilePath), c.Name, c.StartLine}] {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func confidenceIf(id, confidence string) string {
+	if id == "" {
+		return ""
+	}
+	return confidence
+}
+
+// refCanName reports whether a reference of refKind can point at a symbol
+// of symKind: calls at functions, methods and classes (constructor calls),
+// type references at classes, interfaces and types.
+func refCanName(refKind, symKind string) bool {
+	switch refKind {
+	case code.RefKindCall:
+		return symKind == code.KindFunction || symKind == code.KindMethod || symKind == code.KindClass
+	case code.RefKindTypeRef:
+		return symKind == code.KindClass || symKind == code.KindInterface || symKind == code.KindType
+	}
+	return false
+}
+
+// sameLanguageFamily treats the JavaScript dialects as one language, since
+// TypeScript imports JavaScript and vice versa.
+func sameLanguageFamily(a, b string) bool {
+	family := func(lang string) string {
+		switch lang {
+		case "typescript", "tsx", "javascript":
+			return "js"
+		}
+		return lang
+	}
+	return family(a) == family(b)
+}
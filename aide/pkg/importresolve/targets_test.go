@@ -0,0 +1,154 @@
+package importresolve
+
+import (
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+)
+
+func TestResolveTarget(t *testing.T) {
+	r := New(fixtureProject(t))
+
+	sym := func(id, name, kind, file, lang string) *code.Symbol {
+		return &code.Symbol{ID: id, Name: name, Kind: kind, FilePath: file, Language: lang}
+	}
+	mainRun := sym("main-run", "Run", code.KindFunction, "main.go", "go")
+	storeRun := sym("store-run", "Run", code.KindFunction, "pkg/store/store.go", "go")
+	storeTestRun := sym("store-test-run", "Run", code.KindFunction, "pkg/store/bolt_test.go", "go")
+	serverRun := sym("server-run", "Run", code.KindFunction, "web/server/server.go", "go")
+	storeType := sym("store-type", "Run", code.KindType, "pkg/store/store.go", "go")
+	storeImport := []string{`"example.com/proj/pkg/store"`}
+
+	cases := []struct {
+		name       string
+		ref        *code.Reference
+		imports    []string
+		candidates []*code.Symbol
+		wantID     string
+		wantConf   string
+	}{
+		{
+			name:       "same file wins",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "main.go", Language: "go"},
+			imports:    storeImport,
+			candidates: []*code.Symbol{storeRun, mainRun},
+			wantID:     "main-run", wantConf: code.ConfidenceHigh,
+		},
+		{
+			name:       "same package",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "pkg/store/store.go", Language: "go"},
+			candidates: []*code.Symbol{serverRun, storeTestRun},
+			wantID:     "store-test-run", wantConf: code.ConfidenceHigh,
+		},
+		{
+			name:       "imported package",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "main.go", Language: "go"},
+			imports:    storeImport,
+			candidates: []*code.Symbol{serverRun, storeRun},
+			wantID:     "store-run", wantConf: code.ConfidenceMedium,
+		},
+		{
+			name:       "ambiguous within imported package",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "main.go", Language: "go"},
+			imports:    storeImport,
+			candidates: []*code.Symbol{storeRun, sym("store-method-run", "Run", code.KindMethod, "pkg/store/store.go", "go")},
+		},
+		{
+			name:       "not imported is external",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "main.go", Language: "go"},
+			candidates: []*code.Symbol{serverRun},
+		},
+		{
+			name:       "type ref skips functions",
+			ref:        &code.Reference{Kind: code.RefKindTypeRef, FilePath: "main.go", Language: "go"},
+			imports:    storeImport,
+			candidates: []*code.Symbol{storeRun, storeType},
+			wantID:     "store-type", wantConf: code.ConfidenceMedium,
+		},
+		{
+			name:       "other language ignored",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "main.go", Language: "go"},
+			candidates: []*code.Symbol{sym("py-run", "Run", code.KindFunction, "main.go", "python")},
+		},
+		{
+			name:       "unique project-wide guess without a resolver",
+			ref:        &code.Reference{Kind: code.RefKindCall, FilePath: "a.lua", Language: "lua"},
+			candidates: []*code.Symbol{sym("lua-run", "Run", code.KindFunction, "b.lua", "lua")},
+			wantID:     "lua-run", wantConf: code.ConfidenceLow,
+		},
+		{
+			name: "ambiguous project-wide guess",
+			ref:  &code.Reference{Kind: code.RefKindCall, FilePath: "a.lua", Language: "lua"},
+			candidates: []*code.Symbol{
+				sym("lua-run-b", "Run", code.KindFunction, "b.lua", "lua"),
+				sym("lua-run-c", "Run", code.KindFunction, "c.lua", "lua"),
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			id, conf := r.ResolveTarget(c.ref, c.imports, c.candidates)
+			if id != c.wantID || conf != c.wantConf {
+				t.Errorf("ResolveTarget = (%q, %q), want (%q, %q)", id, conf, c.wantID, c.wantConf)
+			}
+		})
+	}
+}
+
+// TestResolveTargetParsedGo resolves references against real parser output,
+// where every Go struct and interface is indexed as both its own kind and a
+// type.
+func TestResolveTargetParsedGo(t *testing.T) {
+	r := New(fixtureProject(t))
+	p := code.NewParser(grammar.NewCompositeLoader(grammar.WithAutoDownload(false)))
+
+	lib := []byte("package store\n\ntype Store struct{}\n\ntype Reader interface{ Read() }\n\nfunc Open() *Store { return nil }\n")
+	candidates, err := p.ParseContent(lib, "go", "pkg/store/store.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	main := []byte("package main\n\nimport \"example.com/proj/pkg/store\"\n\nfunc main() {\n\tvar s *store.Store\n\tvar r store.Reader\n\t_, _ = s, r\n}\n")
+	refs, err := p.ParseContentReferences(main, "go", "main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imports []string
+	for _, ref := range refs {
+		if ref.Kind == code.RefKindImport {
+			imports = append(imports, ref.SymbolName)
+		}
+	}
+
+	want := map[string]string{"Store": code.KindClass, "Reader": code.KindInterface}
+	for _, ref := range refs {
+		kind, ok := want[ref.SymbolName]
+		if !ok || ref.Kind != code.RefKindTypeRef {
+			continue
+		}
+		delete(want, ref.SymbolName)
+		// The store offers every same-named symbol as a candidate.
+		var named []*code.Symbol
+		for _, c := range candidates {
+			if c.Name == ref.SymbolName {
+				named = append(named, c)
+			}
+		}
+		id, conf := r.ResolveTarget(ref, imports, named)
+		if id == "" {
+			t.Errorf("%s: unresolved", ref.SymbolName)
+			continue
+		}
+		for _, c := range named {
+			if c.ID == id && c.Kind != kind {
+				t.Errorf("%s resolved to a %s, want the %s", ref.SymbolName, c.Kind, kind)
+			}
+		}
+		if conf != code.ConfidenceMedium {
+			t.Errorf("%s confidence = %q, want %q", ref.SymbolName, conf, code.ConfidenceMedium)
+		}
+	}
+	for name := range want {
+		t.Errorf("no type reference to %s parsed", name)
+	}
+}
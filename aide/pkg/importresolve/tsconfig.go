@@ -2,7 +2,6 @@ package importresolve
 
 import (
 	"encoding/json"
-	"os"
 	"path"
 	"strings"
 )
@@ -43,8 +42,8 @@ func (t *tsResolver) resolveAlias(fromFile, imp string) string {
 
 // parseTSConfig extracts baseUrl + paths from a tsconfig/jsconfig file.
 // Returns nil when the file has no paths mapping (nothing to resolve with).
-func parseTSConfig(relDir, absPath string) *tsConfig {
-	data, err := os.ReadFile(absPath)
+func parseTSConfig(pfs *projectFS, relDir, file string) *tsConfig {
+	data, err := pfs.readFile(file)
 	if err != nil {
 		return nil
 	}
@@ -2,7 +2,6 @@ package importresolve
 
 import (
 	"encoding/json"
-	"os"
 	"path"
 	"sort"
 	"strings"
@@ -59,9 +58,9 @@ func (t *tsResolver) manifests() []string {
 	return []string{"package.json", "tsconfig.json", "jsconfig.json"}
 }
 
-func (t *tsResolver) addManifest(relDir, absPath string) {
-	if strings.HasSuffix(absPath, "package.json") {
-		name := packageJSONName(absPath)
+func (t *tsResolver) addManifest(relDir, file string) {
+	if strings.HasSuffix(file, "package.json") {
+		name := packageJSONName(t.fs, file)
 		if name == "" {
 			return
 		}
@@ -70,7 +69,7 @@ func (t *tsResolver) addManifest(relDir, absPath string) {
 		}
 		return
 	}
-	if cfg := parseTSConfig(relDir, absPath); cfg != nil {
+	if cfg := parseTSConfig(t.fs, relDir, file); cfg != nil {
 		t.configs = append(t.configs, *cfg)
 	}
 }
@@ -178,8 +177,8 @@ func (t *tsResolver) probeEntry(pkgDir string) string {
 }
 
 // packageJSONName extracts the "name" field from a package.json.
-func packageJSONName(pkgJSONFile string) string {
-	data, err := os.ReadFile(pkgJSONFile)
+func packageJSONName(pfs *projectFS, pkgJSONFile string) string {
+	data, err := pfs.readFile(pkgJSONFile)
 	if err != nil {
 		return ""
 	}
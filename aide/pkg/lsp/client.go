@@ -0,0 +1,432 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned for calls on a client whose connection has ended.
+var ErrClosed = errors.New("lsp: connection closed")
+
+// ResponseError is a JSON-RPC error returned by the server.
+type ResponseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("lsp: %s (code %d)", e.Message, e.Code)
+}
+
+// message is any inbound JSON-RPC message: a response (ID, Result/Error),
+// a server-to-client request (ID, Method) or a notification (Method only).
+type message struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *ResponseError  `json:"error,omitempty"`
+}
+
+type request struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type reply struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+}
+
+// Client is a JSON-RPC 2.0 connection to one language server, framed with
+// LSP's Content-Length headers. Calls may be issued concurrently.
+type Client struct {
+	conn io.ReadWriteCloser
+	proc *exec.Cmd
+
+	writeMu sync.Mutex
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[int64]chan *message
+	err     error         // why the read loop stopped
+	done    chan struct{} // closed when the read loop stops
+
+	docMu sync.Mutex
+	open  map[string]time.Time // URI -> mtime of the text sent in didOpen
+}
+
+// NewClient wraps an established connection and starts reading from it.
+// The caller still has to Initialize it.
+func NewClient(conn io.ReadWriteCloser) *Client {
+	c := &Client{
+		conn:    conn,
+		pending: make(map[int64]chan *message),
+		done:    make(chan struct{}),
+		open:    make(map[string]time.Time),
+	}
+	go c.readLoop()
+	return c
+}
+
+// procConn joins a child process's stdout and stdin into one connection.
+type procConn struct {
+	io.ReadCloser
+	stdin io.WriteCloser
+}
+
+func (p procConn) Write(b []byte) (int, error) { return p.stdin.Write(b) }
+
+func (p procConn) Close() error {
+	err := p.stdin.Close()
+	if rerr := p.ReadCloser.Close(); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// Start launches command (argv form) in dir and connects to it over stdio.
+// The server's stderr is discarded.
+func Start(command []string, dir string) (*Client, error) {
+	if len(command) == 0 {
+		return nil, errors.New("lsp: empty server command")
+	}
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = dir
+	cmd.Stderr = io.Discard
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("lsp: start %s: %w", command[0], err)
+	}
+	c := NewClient(procConn{ReadCloser: stdout, stdin: stdin})
+	c.proc = cmd
+	return c, nil
+}
+
+// Done is closed once the connection has ended.
+func (c *Client) Done() <-chan struct{} { return c.done }
+
+// Initialize performs the initialize / initialized handshake for a
+// workspace rooted at root.
+func (c *Client) Initialize(ctx context.Context, root string) error {
+	rootURI := FileURI(root)
+	params := map[string]any{
+		"processId": os.Getpid(),
+		"rootUri":   rootURI,
+		"rootPath":  root,
+		"workspaceFolders": []map[string]string{
+			{"uri": rootURI, "name": filepath.Base(root)},
+		},
+		"capabilities": map[string]any{
+			"workspace": map[string]any{
+				"configuration":    true,
+				"workspaceFolders": true,
+			},
+			"textDocument": map[string]any{
+				"synchronization": map[string]any{},
+				"references":      map[string]any{},
+				"definition":      map[string]any{"linkSupport": true},
+				"rename":          map[string]any{},
+			},
+		},
+	}
+	if err := c.Call(ctx, "initialize", params, nil); err != nil {
+		return err
+	}
+	return c.Notify("initialized", map[string]any{})
+}
+
+// Sync makes sure the server sees path's current content: it sends didOpen
+// the first time and didClose + didOpen when mtime has moved since.
+func (c *Client) Sync(path, lang string, mtime time.Time) error {
+	uri := FileURI(path)
+	c.docMu.Lock()
+	defer c.docMu.Unlock()
+	prev, opened := c.open[uri]
+	if opened && prev.Equal(mtime) {
+		return nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if opened {
+		if err := c.Notify("textDocument/didClose", map[string]any{
+			"textDocument": map[string]string{"uri": uri},
+		}); err != nil {
+			return err
+		}
+	}
+	if err := c.Notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{
+			"uri":        uri,
+			"languageId": languageID(lang),
+			"version":    1,
+			"text":       string(text),
+		},
+	}); err != nil {
+		return err
+	}
+	c.open[uri] = mtime
+	return nil
+}
+
+func positionParams(uri string, pos Position) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     pos,
+	}
+}
+
+// References returns every reference to the symbol at pos, without its
+// declaration.
+func (c *Client) References(ctx context.Context, uri string, pos Position) ([]Location, error) {
+	params := positionParams(uri, pos)
+	params["context"] = map[string]bool{"includeDeclaration": false}
+	var raw json.RawMessage
+	if err := c.Call(ctx, "textDocument/references", params, &raw); err != nil {
+		return nil, err
+	}
+	return decodeLocations(raw)
+}
+
+// Definition returns where the identifier at pos is defined.
+func (c *Client) Definition(ctx context.Context, uri string, pos Position) ([]Location, error) {
+	var raw json.RawMessage
+	if err := c.Call(ctx, "textDocument/definition", positionParams(uri, pos), &raw); err != nil {
+		return nil, err
+	}
+	return decodeLocations(raw)
+}
+
+// Rename asks what renaming the symbol at pos to newName would edit. The
+// edit is returned, never applied.
+func (c *Client) Rename(ctx context.Context, uri string, pos Position, newName string) (*WorkspaceEdit, error) {
+	params := positionParams(uri, pos)
+	params["newName"] = newName
+	var edit WorkspaceEdit
+	if err := c.Call(ctx, "textDocument/rename", params, &edit); err != nil {
+		return nil, err
+	}
+	return &edit, nil
+}
+
+// Call sends a request and decodes its result into result (which may be
+// nil to discard it).
+func (c *Client) Call(ctx context.Context, method string, params, result any) error {
+	ch := make(chan *message, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mu.Unlock()
+		return err
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	if err := c.write(request{JSONRPC: "2.0", ID: &id, Method: method, Params: params}); err != nil {
+		c.forget(id)
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		c.forget(id)
+		return ctx.Err()
+	case msg, ok := <-ch:
+		if !ok {
+			return c.closedErr()
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if result == nil || len(msg.Result) == 0 {
+			return nil
+		}
+		return json.Unmarshal(msg.Result, result)
+	}
+}
+
+// Notify sends a notification.
+func (c *Client) Notify(method string, params any) error {
+	return c.write(request{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// Close shuts the server down politely, then closes the connection and
+// reaps the process, killing it if it does not exit in time.
+func (c *Client) Close() error {
+	select {
+	case <-c.done:
+	default:
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		if err := c.Call(ctx, "shutdown", nil, nil); err == nil {
+			_ = c.Notify("exit", nil)
+		}
+		cancel()
+	}
+	err := c.conn.Close()
+	if c.proc != nil {
+		exited := make(chan struct{})
+		go func() {
+			_ = c.proc.Wait()
+			close(exited)
+		}()
+		select {
+		case <-exited:
+		case <-time.After(2 * time.Second):
+			_ = c.proc.Process.Kill()
+			<-exited
+		}
+	}
+	<-c.done
+	return err
+}
+
+func (c *Client) forget(id int64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+func (c *Client) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	return ErrClosed
+}
+
+func (c *Client) write(v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if _, err := fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.conn.Write(body)
+	return err
+}
+
+// readLoop dispatches inbound messages until the connection fails, then
+// fails every pending call.
+func (c *Client) readLoop() {
+	r := bufio.NewReader(c.conn)
+	var err error
+	for {
+		var msg *message
+		if msg, err = readMessage(r); err != nil {
+			break
+		}
+		switch {
+		case msg.Method != "" && len(msg.ID) > 0:
+			// Reply off the read loop: a server that writes its next
+			// message before reading ours would otherwise deadlock us.
+			go c.answer(msg)
+		case msg.Method != "":
+			// Notifications (diagnostics, progress, log messages) are not needed.
+		default:
+			c.deliver(msg)
+		}
+	}
+
+	c.mu.Lock()
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, os.ErrClosed) {
+		err = ErrClosed
+	}
+	c.err = err
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.mu.Unlock()
+	close(c.done)
+}
+
+func (c *Client) deliver(msg *message) {
+	id, err := strconv.ParseInt(string(msg.ID), 10, 64)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	ch, ok := c.pending[id]
+	delete(c.pending, id)
+	c.mu.Unlock()
+	if ok {
+		ch <- msg
+	}
+}
+
+// answer replies to a server-to-client request. The client offers no
+// settings and accepts every registration, so null results suffice except
+// for workspace/configuration, which wants one entry per requested item.
+func (c *Client) answer(msg *message) {
+	var result any
+	if msg.Method == "workspace/configuration" {
+		var params struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		_ = json.Unmarshal(msg.Params, &params)
+		result = make([]any, len(params.Items))
+	}
+	_ = c.write(reply{JSONRPC: "2.0", ID: msg.ID, Result: result})
+}
+
+// readMessage reads one Content-Length framed message.
+func readMessage(r *bufio.Reader) (*message, error) {
+	length := -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return nil, fmt.Errorf("lsp: bad Content-Length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, errors.New("lsp: message without Content-Length")
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, fmt.Errorf("lsp: decode message: %w", err)
+	}
+	return &msg, nil
+}
@@ -0,0 +1,325 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer is an in-process language server on one end of a pipe.
+type fakeServer struct {
+	conn    net.Conn
+	handle  func(method string, params json.RawMessage) any
+	mu      sync.Mutex
+	calls   map[string]int
+	replies map[string]json.RawMessage // server-request id -> client reply
+}
+
+func newFakeServer(t *testing.T, handle func(method string, params json.RawMessage) any) (*fakeServer, *Client) {
+	t.Helper()
+	clientEnd, serverEnd := net.Pipe()
+	s := &fakeServer{conn: serverEnd, handle: handle, calls: make(map[string]int), replies: make(map[string]json.RawMessage)}
+	go s.serve()
+	c := NewClient(clientEnd)
+	t.Cleanup(func() { c.Close() })
+	return s, c
+}
+
+func (s *fakeServer) send(v any) {
+	body, _ := json.Marshal(v)
+	fmt.Fprintf(s.conn, "Content-Length: %d\r\n\r\n%s", len(body), body)
+}
+
+func (s *fakeServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *fakeServer) serve() {
+	defer s.conn.Close()
+	r := bufio.NewReader(s.conn)
+	for {
+		msg, err := readMessage(r)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if msg.Method == "" {
+			s.replies[string(msg.ID)] = msg.Result
+			s.mu.Unlock()
+			continue
+		}
+		s.calls[msg.Method]++
+		s.mu.Unlock()
+		if len(msg.ID) == 0 {
+			if msg.Method == "exit" {
+				return
+			}
+			continue
+		}
+		if msg.Method == "initialize" {
+			// Exercise server-to-client requests during the handshake.
+			s.send(map[string]any{"jsonrpc": "2.0", "id": "cfg", "method": "workspace/configuration",
+				"params": map[string]any{"items": []any{map[string]string{"section": "gopls"}}}})
+		}
+		var result any
+		if s.handle != nil {
+			result = s.handle(msg.Method, msg.Params)
+		}
+		if rerr, ok := result.(*ResponseError); ok {
+			s.send(map[string]any{"jsonrpc": "2.0", "id": msg.ID, "error": rerr})
+			continue
+		}
+		s.send(map[string]any{"jsonrpc": "2.0", "id": msg.ID, "result": result})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClientRequests(t *testing.T) {
+	root := t.TempDir()
+	main := filepath.Join(root, "main.go")
+	writeFile(t, main, "package main\n\nfunc main() { run() }\n\nfunc run() {}\n")
+	uri := FileURI(main)
+
+	srv, c := newFakeServer(t, func(method string, params json.RawMessage) any {
+		switch method {
+		case "initialize":
+			return map[string]any{"capabilities": map[string]any{}}
+		case "textDocument/references":
+			return []Location{{URI: uri, Range: Range{Start: Position{Line: 2, Character: 14}}}}
+		case "textDocument/definition":
+			return []map[string]any{{"targetUri": uri, "targetRange": Range{}, "targetSelectionRange": Range{Start: Position{Line: 4, Character: 5}}}}
+		case "textDocument/rename":
+			return map[string]any{"documentChanges": []any{
+				map[string]any{"textDocument": map[string]any{"uri": uri, "version": 1}, "edits": []TextEdit{
+					{Range: Range{Start: Position{Line: 2, Character: 14}}, NewText: "exec"},
+					{Range: Range{Start: Position{Line: 4, Character: 5}}, NewText: "exec"},
+				}},
+				map[string]any{"kind": "rename", "oldUri": uri, "newUri": uri + ".bak"},
+			}}
+		case "shutdown":
+			return nil
+		}
+		return &ResponseError{Code: -32601, Message: "method not found"}
+	})
+
+	ctx := context.Background()
+	if err := c.Initialize(ctx, root); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+	info, _ := os.Stat(main)
+	if err := c.Sync(main, "go", info.ModTime()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if err := c.Sync(main, "go", info.ModTime()); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	refs, err := c.References(ctx, uri, Position{Line: 4, Character: 5})
+	if err != nil || len(refs) != 1 || refs[0].Range.Start.Line != 2 {
+		t.Fatalf("References = %+v, %v", refs, err)
+	}
+	defs, err := c.Definition(ctx, uri, Position{Line: 2, Character: 14})
+	if err != nil || len(defs) != 1 || defs[0].URI != uri || defs[0].Range.Start.Line != 4 {
+		t.Fatalf("Definition = %+v, %v", defs, err)
+	}
+	edit, err := c.Rename(ctx, uri, Position{Line: 4, Character: 5}, "exec")
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if locs := edit.Locations(); len(locs) != 2 {
+		t.Errorf("Rename locations = %+v, want 2", locs)
+	}
+	var rerr *ResponseError
+	if err := c.Call(ctx, "textDocument/hover", nil, nil); !errors.As(err, &rerr) || rerr.Code != -32601 {
+		t.Errorf("unknown method error = %v", err)
+	}
+
+	if n := srv.count("textDocument/didOpen"); n != 1 {
+		t.Errorf("didOpen sent %d times, want 1 for an unchanged file", n)
+	}
+	srv.mu.Lock()
+	cfg := string(srv.replies[`"cfg"`])
+	srv.mu.Unlock()
+	if cfg != "[null]" {
+		t.Errorf("workspace/configuration reply = %s, want [null]", cfg)
+	}
+}
+
+func TestClientCallAfterClose(t *testing.T) {
+	srv, c := newFakeServer(t, nil)
+	srv.conn.Close()
+	<-c.Done()
+	if err := c.Call(context.Background(), "initialize", nil, nil); !errors.Is(err, ErrClosed) {
+		t.Errorf("Call after close = %v, want ErrClosed", err)
+	}
+}
+
+type memCache map[string][]byte
+
+func (m memCache) GetLSPCache(filePath, key string) ([]byte, error) {
+	return m[filePath+"\x00"+key], nil
+}
+
+func (m memCache) PutLSPCache(filePath, key string, value []byte) error {
+	m[filePath+"\x00"+key] = value
+	return nil
+}
+
+func TestManagerReferencesCachedByMtime(t *testing.T) {
+	root := t.TempDir()
+	lib := filepath.Join(root, "pkg", "lib.go")
+	user := filepath.Join(root, "main.go")
+	writeFile(t, lib, "package pkg\n\nfunc Run() {}\n")
+	writeFile(t, user, "package main\n\nfunc main() { pkg.Run() }\n")
+
+	var srv *fakeServer
+	cache := memCache{}
+	m := NewManager(Options{
+		Root:    root,
+		Servers: []ServerConfig{{Languages: []string{"go"}, Command: []string{"fake-gopls"}}},
+		Cache:   func() Cache { return cache },
+	})
+	m.start = func(cfg ServerConfig, _ string) (*Client, error) {
+		s, c := newFakeServer(t, func(method string, params json.RawMessage) any {
+			if method == "textDocument/references" {
+				var p struct {
+					Position Position `json:"position"`
+				}
+				_ = json.Unmarshal(params, &p)
+				if p.Position != (Position{Line: 2, Character: 5}) {
+					return []Location{}
+				}
+				return []Location{{URI: FileURI(user), Range: Range{Start: Position{Line: 2, Character: 18}}}}
+			}
+			return map[string]any{}
+		})
+		srv = s
+		return c, nil
+	}
+	defer m.Close()
+
+	if !m.Enabled("go") || m.Enabled("python") {
+		t.Fatalf("Enabled: go=%v python=%v", m.Enabled("go"), m.Enabled("python"))
+	}
+	if _, err := m.References(context.Background(), Query{Language: "python", File: "x.py", Line: 1}); !errors.Is(err, ErrNoServer) {
+		t.Fatalf("unconfigured language error = %v, want ErrNoServer", err)
+	}
+
+	q := Query{Language: "go", File: "pkg/lib.go", Line: 3, Column: 5}
+	sites, err := m.References(context.Background(), q)
+	if err != nil {
+		t.Fatalf("References: %v", err)
+	}
+	want := Site{File: "main.go", Line: 3, Column: 18, Text: "func main() { pkg.Run() }"}
+	if len(sites) != 1 || sites[0] != want {
+		t.Fatalf("References = %+v, want [%+v]", sites, want)
+	}
+
+	if _, err := m.References(context.Background(), q); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.count("textDocument/references"); n != 1 {
+		t.Errorf("server queried %d times, want 1 (second answer cached)", n)
+	}
+
+	// Touching a file the cached result points into invalidates it.
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(user, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.References(context.Background(), q); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.count("textDocument/references"); n != 2 {
+		t.Errorf("server queried %d times, want 2 after the result file changed", n)
+	}
+}
+
+func TestManagerStartFailureBacksOff(t *testing.T) {
+	starts := 0
+	var fail error = errors.New("executable file not found")
+	m := NewManager(Options{
+		Root:    t.TempDir(),
+		Servers: []ServerConfig{{Languages: []string{"go"}, Command: []string{"missing-gopls"}}},
+	})
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	m.start = func(ServerConfig, string) (*Client, error) {
+		starts++
+		if fail != nil {
+			return nil, fail
+		}
+		_, c := newFakeServer(t, func(string, json.RawMessage) any { return []Location{} })
+		return c, nil
+	}
+	defer m.Close()
+	writeFile(t, filepath.Join(m.root, "a.go"), "package a\n")
+	q := Query{Language: "go", File: "a.go", Line: 1}
+
+	for range 2 {
+		if _, err := m.Definition(context.Background(), q); err == nil {
+			t.Fatal("expected an error from a server that cannot start")
+		}
+	}
+	if starts != 1 {
+		t.Errorf("server started %d times within the backoff, want 1", starts)
+	}
+
+	// Past the backoff the next query retries; a second failure doubles it.
+	now = now.Add(retryMin)
+	if _, err := m.Definition(context.Background(), q); err == nil {
+		t.Fatal("expected the retry to fail")
+	}
+	now = now.Add(retryMin)
+	if _, err := m.Definition(context.Background(), q); err == nil || starts != 2 {
+		t.Fatalf("err = %v, starts = %d; want the doubled backoff to hold", err, starts)
+	}
+
+	// A cancelled caller does not start a backoff.
+	now = now.Add(retryMin)
+	fail = context.Canceled
+	for range 2 {
+		if _, err := m.Definition(context.Background(), q); !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want context.Canceled", err)
+		}
+	}
+	if starts != 4 {
+		t.Errorf("server started %d times, want 4 (cancellations retried at once)", starts)
+	}
+
+	fail = nil
+	if _, err := m.Definition(context.Background(), q); err != nil {
+		t.Fatalf("Definition after recovery: %v", err)
+	}
+}
+
+func TestColumnConversion(t *testing.T) {
+	line := "s := \"héllo😀\" + x"
+	byteCol := len("s := \"héllo😀\" + ")
+	u := utf16Offset(line, byteCol)
+	if u != 17 {
+		t.Errorf("utf16Offset = %d, want 17", u)
+	}
+	if b := byteOffset(line, u); b != byteCol {
+		t.Errorf("byteOffset = %d, want %d", b, byteCol)
+	}
+}
@@ -0,0 +1,407 @@
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoServer reports that no language server is configured for a language.
+var ErrNoServer = errors.New("lsp: no language server configured")
+
+// DefaultTimeout bounds a single query, including the server's start-up and
+// initial workspace load on first use.
+const DefaultTimeout = 30 * time.Second
+
+// retryMin and retryMax bound how long a server that failed to start is
+// left alone before the next query tries it again. The wait doubles with
+// each consecutive failure.
+const (
+	retryMin = 5 * time.Second
+	retryMax = 5 * time.Minute
+)
+
+// ServerConfig names the command that serves one or more languages, e.g.
+// {Languages: ["typescript", "tsx", "javascript"], Command:
+// ["typescript-language-server", "--stdio"]}.
+type ServerConfig struct {
+	Languages []string
+	Command   []string
+}
+
+// Cache persists query results between processes. Keys are scoped to the
+// queried file so the store can drop them when that file is re-indexed;
+// results pointing into other files are checked against their mtimes on
+// read. GetLSPCache returns nil on a miss.
+type Cache interface {
+	GetLSPCache(filePath, key string) ([]byte, error)
+	PutLSPCache(filePath, key string, value []byte) error
+}
+
+// Options configures a Manager.
+type Options struct {
+	Root    string // workspace root; relative query paths resolve against it
+	Servers []ServerConfig
+	Timeout time.Duration // per query; zero means DefaultTimeout
+	// Cache returns the result cache, or nil when none is available yet
+	// (the code store opens lazily).
+	Cache func() Cache
+}
+
+// Query identifies a position in a source file.
+type Query struct {
+	Language string
+	File     string // project-relative or absolute
+	Line     int    // 1-indexed
+	Column   int    // 0-indexed byte offset
+}
+
+// Site is a location in a result, in the code index's conventions.
+type Site struct {
+	File   string `json:"file"` // project-relative when under the root
+	Line   int    `json:"line"` // 1-indexed
+	Column int    `json:"col"`  // 0-indexed byte offset
+	Text   string `json:"text,omitempty"`
+}
+
+// Manager owns one lazily started client per configured server and answers
+// queries through them, caching results against file mtimes.
+type Manager struct {
+	root    string
+	timeout time.Duration
+	cache   func() Cache
+	servers map[string]*server // language -> server (shared across its languages)
+	start   func(cfg ServerConfig, root string) (*Client, error)
+	now     func() time.Time
+}
+
+// server is one configured language server and its running client. A server
+// that failed to start keeps returning its error until retryAt, so an
+// unusable command is not relaunched on every query, but a slow first
+// Initialize or a missing binary installed later does not disable it for
+// the life of the process.
+type server struct {
+	cfg      ServerConfig
+	mu       sync.Mutex
+	client   *Client
+	err      error
+	failures int       // consecutive start failures
+	retryAt  time.Time // when err expires
+}
+
+// NewManager returns a Manager for opts. No server is started until a query
+// needs it.
+func NewManager(opts Options) *Manager {
+	m := &Manager{
+		root:    opts.Root,
+		timeout: opts.Timeout,
+		cache:   opts.Cache,
+		servers: make(map[string]*server),
+		start:   startServer,
+		now:     time.Now,
+	}
+	if m.timeout <= 0 {
+		m.timeout = DefaultTimeout
+	}
+	for _, cfg := range opts.Servers {
+		if len(cfg.Command) == 0 {
+			continue
+		}
+		srv := &server{cfg: cfg}
+		for _, lang := range cfg.Languages {
+			if _, taken := m.servers[lang]; !taken {
+				m.servers[lang] = srv
+			}
+		}
+	}
+	return m
+}
+
+func startServer(cfg ServerConfig, root string) (*Client, error) {
+	return Start(cfg.Command, root)
+}
+
+// Enabled reports whether a server is configured for lang.
+func (m *Manager) Enabled(lang string) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.servers[lang]
+	return ok
+}
+
+// Close shuts down every running server.
+func (m *Manager) Close() error {
+	if m == nil {
+		return nil
+	}
+	var errs []error
+	seen := make(map[*server]bool)
+	for _, srv := range m.servers {
+		if seen[srv] {
+			continue
+		}
+		seen[srv] = true
+		srv.mu.Lock()
+		if srv.client != nil {
+			errs = append(errs, srv.client.Close())
+			srv.client = nil
+		}
+		srv.err = nil
+		srv.failures = 0
+		srv.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// client returns a running, initialized client for lang, starting the
+// server on first use and restarting it if its connection has dropped.
+func (m *Manager) client(ctx context.Context, lang string) (*Client, error) {
+	srv, ok := m.servers[lang]
+	if !ok {
+		return nil, ErrNoServer
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.err != nil && m.now().Before(srv.retryAt) {
+		return nil, srv.err
+	}
+	if srv.client != nil {
+		select {
+		case <-srv.client.Done():
+			_ = srv.client.Close()
+			srv.client = nil
+		default:
+			return srv.client, nil
+		}
+	}
+	c, err := m.start(srv.cfg, m.root)
+	if err == nil {
+		if err = c.Initialize(ctx, m.root); err != nil {
+			_ = c.Close()
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("lsp: %s: %w", srv.cfg.Command[0], err)
+		// The caller giving up is not the server's fault.
+		if errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		wait := retryMin << min(srv.failures, 10)
+		srv.failures++
+		srv.err, srv.retryAt = err, m.now().Add(min(wait, retryMax))
+		return nil, err
+	}
+	srv.client, srv.err, srv.failures = c, nil, 0
+	return c, nil
+}
+
+// References returns the references to the symbol at q, without its
+// declaration.
+func (m *Manager) References(ctx context.Context, q Query) ([]Site, error) {
+	return m.query(ctx, "references", q, func(ctx context.Context, c *Client, uri string, pos Position) ([]Location, error) {
+		return c.References(ctx, uri, pos)
+	})
+}
+
+// Definition returns where the identifier at q is defined.
+func (m *Manager) Definition(ctx context.Context, q Query) ([]Site, error) {
+	return m.query(ctx, "definition", q, func(ctx context.Context, c *Client, uri string, pos Position) ([]Location, error) {
+		return c.Definition(ctx, uri, pos)
+	})
+}
+
+// RenameSites returns every site a rename of the symbol at q to newName
+// would edit. Nothing is written.
+func (m *Manager) RenameSites(ctx context.Context, q Query, newName string) ([]Site, error) {
+	return m.query(ctx, "rename:"+newName, q, func(ctx context.Context, c *Client, uri string, pos Position) ([]Location, error) {
+		edit, err := c.Rename(ctx, uri, pos, newName)
+		if err != nil {
+			return nil, err
+		}
+		return edit.Locations(), nil
+	})
+}
+
+// cacheEntry is a cached result plus the mtimes of the files it points
+// into; an edit to any of them invalidates it.
+type cacheEntry struct {
+	Sites  []Site           `json:"sites"`
+	Mtimes map[string]int64 `json:"mtimes"`
+}
+
+type queryFunc func(ctx context.Context, c *Client, uri string, pos Position) ([]Location, error)
+
+func (m *Manager) query(ctx context.Context, method string, q Query, fn queryFunc) ([]Site, error) {
+	if !m.Enabled(q.Language) {
+		return nil, ErrNoServer
+	}
+	abs := m.abs(q.File)
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	rel := m.rel(abs)
+	key := method + "\x00" + strconv.Itoa(q.Line) + ":" + strconv.Itoa(q.Column) + "\x00" + strconv.FormatInt(info.ModTime().UnixNano(), 10)
+	if sites, ok := m.cached(rel, key); ok {
+		return sites, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	c, err := m.client(ctx, q.Language)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Sync(abs, q.Language, info.ModTime()); err != nil {
+		return nil, err
+	}
+	lines := newLineReader()
+	text, _ := lines.line(abs, q.Line)
+	pos := Position{Line: q.Line - 1, Character: utf16Offset(text, q.Column)}
+	locs, err := fn(ctx, c, FileURI(abs), pos)
+	if err != nil {
+		return nil, err
+	}
+
+	sites := m.sites(locs, lines)
+	m.store(rel, key, sites)
+	return sites, nil
+}
+
+// sites converts server locations to deduplicated, sorted Sites.
+func (m *Manager) sites(locs []Location, lines *lineReader) []Site {
+	seen := make(map[Site]bool)
+	out := make([]Site, 0, len(locs))
+	for _, loc := range locs {
+		path := URIPath(loc.URI)
+		if path == "" {
+			continue
+		}
+		line := loc.Range.Start.Line + 1
+		text, _ := lines.line(path, line)
+		s := Site{
+			File:   m.rel(path),
+			Line:   line,
+			Column: byteOffset(text, loc.Range.Start.Character),
+			Text:   strings.TrimSpace(text),
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		if out[i].Line != out[j].Line {
+			return out[i].Line < out[j].Line
+		}
+		return out[i].Column < out[j].Column
+	})
+	return out
+}
+
+func (m *Manager) cacheStore() Cache {
+	if m.cache == nil {
+		return nil
+	}
+	return m.cache()
+}
+
+// cached returns a cached result whose referenced files are all unchanged.
+// Cache errors count as misses.
+func (m *Manager) cached(rel, key string) ([]Site, bool) {
+	cache := m.cacheStore()
+	if cache == nil {
+		return nil, false
+	}
+	data, err := cache.GetLSPCache(rel, key)
+	if err != nil || data == nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	for file, mtime := range entry.Mtimes {
+		info, err := os.Stat(m.abs(file))
+		if err != nil || info.ModTime().UnixNano() != mtime {
+			return nil, false
+		}
+	}
+	return entry.Sites, true
+}
+
+func (m *Manager) store(rel, key string, sites []Site) {
+	cache := m.cacheStore()
+	if cache == nil {
+		return
+	}
+	entry := cacheEntry{Sites: sites, Mtimes: make(map[string]int64)}
+	for _, s := range sites {
+		if info, err := os.Stat(m.abs(s.File)); err == nil {
+			entry.Mtimes[s.File] = info.ModTime().UnixNano()
+		}
+	}
+	if data, err := json.Marshal(entry); err == nil {
+		_ = cache.PutLSPCache(rel, key, data)
+	}
+}
+
+func (m *Manager) abs(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(m.root, path)
+}
+
+// rel returns path relative to the root, or path itself when outside it.
+func (m *Manager) rel(path string) string {
+	if m.root == "" {
+		return path
+	}
+	rel, err := filepath.Rel(m.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// lineReader reads single lines from files, loading each file at most once.
+type lineReader struct {
+	files map[string][]string
+}
+
+func newLineReader() *lineReader {
+	return &lineReader{files: make(map[string][]string)}
+}
+
+func (r *lineReader) line(path string, n int) (string, bool) {
+	lines, ok := r.files[path]
+	if !ok {
+		if f, err := os.Open(path); err == nil {
+			sc := bufio.NewScanner(f)
+			sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+			for sc.Scan() {
+				lines = append(lines, sc.Text())
+			}
+			f.Close()
+		}
+		r.files[path] = lines
+	}
+	if n < 1 || n > len(lines) {
+		return "", false
+	}
+	return lines[n-1], true
+}
@@ -0,0 +1,184 @@
+// Package lsp is a minimal Language Server Protocol client. It launches a
+// locally installed language server (gopls, typescript-language-server,
+// pyright, ...) over stdio and asks it the few questions tree-sitter name
+// matching can only approximate: where a symbol is referenced, where an
+// identifier is defined, and what a rename would touch. Nothing here talks
+// to the network; a language that has no configured server simply reports
+// ErrNoServer and callers fall back to the code index.
+package lsp
+
+import (
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a zero-based line and UTF-16 code-unit offset, as LSP
+// specifies by default.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a half-open span between two positions.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Location is a range inside a document.
+type Location struct {
+	URI   string `json:"uri"`
+	Range Range  `json:"range"`
+}
+
+// locationLink is the richer definition result servers send when the
+// client advertises linkSupport.
+type locationLink struct {
+	TargetURI            string `json:"targetUri"`
+	TargetRange          Range  `json:"targetRange"`
+	TargetSelectionRange Range  `json:"targetSelectionRange"`
+}
+
+// TextEdit replaces a range of a document.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
+
+// WorkspaceEdit is the result of textDocument/rename. Servers use either
+// Changes or DocumentChanges; DocumentChanges may also carry file
+// create/rename/delete operations, which are ignored.
+type WorkspaceEdit struct {
+	Changes         map[string][]TextEdit `json:"changes,omitempty"`
+	DocumentChanges []json.RawMessage     `json:"documentChanges,omitempty"`
+}
+
+// textDocumentEdit is one DocumentChanges entry that edits text.
+type textDocumentEdit struct {
+	TextDocument struct {
+		URI string `json:"uri"`
+	} `json:"textDocument"`
+	Edits []TextEdit `json:"edits"`
+}
+
+// Locations flattens the edit into the locations it changes.
+func (e *WorkspaceEdit) Locations() []Location {
+	var out []Location
+	for uri, edits := range e.Changes {
+		for _, te := range edits {
+			out = append(out, Location{URI: uri, Range: te.Range})
+		}
+	}
+	for _, raw := range e.DocumentChanges {
+		var dc textDocumentEdit
+		if err := json.Unmarshal(raw, &dc); err != nil || dc.TextDocument.URI == "" {
+			continue
+		}
+		for _, te := range dc.Edits {
+			out = append(out, Location{URI: dc.TextDocument.URI, Range: te.Range})
+		}
+	}
+	return out
+}
+
+// decodeLocations accepts every shape a definition or references response
+// may take: null, a Location, a Location array or a LocationLink array.
+func decodeLocations(raw json.RawMessage) ([]Location, error) {
+	raw = json.RawMessage(strings.TrimSpace(string(raw)))
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if raw[0] == '{' {
+		var loc Location
+		if err := json.Unmarshal(raw, &loc); err != nil {
+			return nil, err
+		}
+		return []Location{loc}, nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	out := make([]Location, 0, len(items))
+	for _, item := range items {
+		var link locationLink
+		if err := json.Unmarshal(item, &link); err == nil && link.TargetURI != "" {
+			out = append(out, Location{URI: link.TargetURI, Range: link.TargetSelectionRange})
+			continue
+		}
+		var loc Location
+		if err := json.Unmarshal(item, &loc); err != nil {
+			return nil, err
+		}
+		out = append(out, loc)
+	}
+	return out, nil
+}
+
+// FileURI converts an absolute path to a file:// URI.
+func FileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// URIPath converts a file:// URI back to a local path. Other schemes yield "".
+func URIPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+// utf16Offset converts a byte offset within line to UTF-16 code units.
+func utf16Offset(line string, byteOff int) int {
+	if byteOff > len(line) {
+		byteOff = len(line)
+	}
+	n := 0
+	for _, r := range line[:byteOff] {
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// byteOffset converts a UTF-16 code-unit offset within line to bytes.
+func byteOffset(line string, utf16Off int) int {
+	n := 0
+	for i, r := range line {
+		if n >= utf16Off {
+			return i
+		}
+		if r == utf8.RuneError {
+			n++
+			continue
+		}
+		if r >= 0x10000 {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return len(line)
+}
+
+// languageIDs maps aide language names to LSP languageId values where the
+// two differ.
+var languageIDs = map[string]string{
+	"tsx": "typescriptreact",
+	"jsx": "javascriptreact",
+}
+
+// languageID returns the LSP languageId for an aide language name.
+func languageID(lang string) string {
+	if id, ok := languageIDs[lang]; ok {
+		return id
+	}
+	return lang
+}
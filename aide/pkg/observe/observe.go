@@ -17,6 +17,7 @@ const (
 	KindHook      Kind = "hook"
 	KindInjection Kind = "injection"
 	KindSession   Kind = "session"
+	KindFinding   Kind = "finding"
 )
 
 type Event struct {
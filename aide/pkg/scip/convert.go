@@ -0,0 +1,537 @@
+package scip
+
+import (
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/oklog/ulid/v2"
+)
+
+// File is one document's worth of aide code-index records.
+type File struct {
+	Path     string // Relative to the project root, forward slashes
+	Language string
+	Symbols  []*code.Symbol
+	Refs     []*code.Reference
+	Edges    []*code.TypeEdge
+}
+
+// LineSource returns a file's source lines, or nil when the file cannot be
+// read. Conversions use it for reference context and column positions.
+type LineSource func(relPath string) []string
+
+// scipLanguages maps aide language names to SCIP Language enum names.
+var scipLanguages = map[string]string{
+	"bash":       "ShellScript",
+	"c":          "C",
+	"cpp":        "CPP",
+	"csharp":     "CSharp",
+	"dart":       "Dart",
+	"elixir":     "Elixir",
+	"go":         "Go",
+	"java":       "Java",
+	"javascript": "JavaScript",
+	"jsx":        "JavaScriptReact",
+	"kotlin":     "Kotlin",
+	"lua":        "Lua",
+	"php":        "PHP",
+	"python":     "Python",
+	"ruby":       "Ruby",
+	"rust":       "Rust",
+	"scala":      "Scala",
+	"swift":      "Swift",
+	"tsx":        "TypeScriptReact",
+	"typescript": "TypeScript",
+}
+
+// aideLanguage picks the aide language for a document: by file name first,
+// so imported records match what tree-sitter would have produced, then by
+// the document's declared language.
+func aideLanguage(doc *Document) string {
+	if lang := code.DetectLanguage(doc.RelativePath, nil); lang != "" {
+		return lang
+	}
+	for aide, name := range scipLanguages {
+		if strings.EqualFold(name, doc.Language) {
+			return aide
+		}
+	}
+	return strings.ToLower(doc.Language)
+}
+
+// ToAide converts idx into per-file aide records. Definition occurrences
+// become symbols marked Precise; other occurrences of functions, methods and
+// types become call / type_ref references resolved with ConfidenceExact when
+// the index defines their target. is_implementation relationships between
+// types become type edges. Local symbols, fields, parameters and
+// namespaces are dropped: the code index does not track them.
+func ToAide(idx *Index, lines LineSource) []*File {
+	info := make(map[string]*SymbolInformation)
+	for _, s := range idx.ExternalSymbols {
+		info[s.Symbol] = s
+	}
+	for _, doc := range idx.Documents {
+		for _, s := range doc.Symbols {
+			info[s.Symbol] = s
+		}
+	}
+	parsed := make(map[string]*Symbol)
+	parse := func(s string) *Symbol {
+		if p, ok := parsed[s]; ok {
+			return p
+		}
+		var p *Symbol
+		if s != "" && !IsLocal(s) {
+			p, _ = ParseSymbol(s)
+		}
+		parsed[s] = p
+		return p
+	}
+
+	now := time.Now()
+	files := make([]*File, 0, len(idx.Documents))
+	defs := make(map[string]*code.Symbol)
+	docLines := make([][]string, len(idx.Documents))
+
+	// Definitions first, across every document, so references in one file
+	// can target symbols defined in another.
+	for i, doc := range idx.Documents {
+		f := &File{Path: path.Clean(doc.RelativePath), Language: aideLanguage(doc)}
+		files = append(files, f)
+		if doc.Text != "" {
+			docLines[i] = strings.Split(doc.Text, "\n")
+		} else if lines != nil {
+			docLines[i] = lines(f.Path)
+		}
+		var owners []string
+		for _, occ := range doc.Occurrences {
+			if occ.SymbolRoles&RoleDefinition == 0 || defs[occ.Symbol] != nil {
+				continue
+			}
+			p := parse(occ.Symbol)
+			if p == nil {
+				continue
+			}
+			kind := aideKind(info[occ.Symbol], p)
+			if kind == "" {
+				continue
+			}
+			line, _ := occ.Start()
+			end := line
+			if len(occ.EnclosingRange) > 0 {
+				end = endLine(occ.EnclosingRange)
+			}
+			si := info[occ.Symbol]
+			sym := &code.Symbol{
+				ID:        ulid.Make().String(),
+				Name:      p.Name(),
+				Kind:      kind,
+				FilePath:  f.Path,
+				StartLine: line + 1,
+				EndLine:   end + 1,
+				Language:  f.Language,
+				Precise:   true,
+				CreatedAt: now,
+			}
+			sym.Container, sym.QualifiedName = qualify(p)
+			sym.Signature, sym.DocComment = describe(si, sym.Name)
+			defs[occ.Symbol] = sym
+			f.Symbols = append(f.Symbols, sym)
+			if owner := p.Owner(); owner != nil {
+				owners = append(owners, owner.String())
+			} else {
+				owners = append(owners, "")
+			}
+		}
+		// Parents are only linked within the file, as the parser does.
+		for j, sym := range f.Symbols {
+			if parent := defs[owners[j]]; parent != nil && parent.FilePath == f.Path {
+				sym.ParentID = parent.ID
+			}
+		}
+	}
+
+	for i, doc := range idx.Documents {
+		f := files[i]
+		src := docLines[i]
+		for _, occ := range doc.Occurrences {
+			if occ.SymbolRoles&(RoleDefinition|RoleImport) != 0 {
+				continue
+			}
+			p := parse(occ.Symbol)
+			if p == nil {
+				continue
+			}
+			target := defs[occ.Symbol]
+			kind := aideKind(info[occ.Symbol], p)
+			if target != nil {
+				kind = target.Kind
+			}
+			refKind := ""
+			switch kind {
+			case code.KindFunction, code.KindMethod:
+				refKind = code.RefKindCall
+			case code.KindClass, code.KindInterface, code.KindType:
+				refKind = code.RefKindTypeRef
+			default:
+				continue
+			}
+			line, char := occ.Start()
+			text := ""
+			if line < len(src) {
+				text = src[line]
+			}
+			if doc.PositionEncoding != PositionEncodingUTF8 && doc.PositionEncoding != 0 && text != "" {
+				char = byteColumn(text, char, doc.PositionEncoding)
+			}
+			ref := &code.Reference{
+				ID:         ulid.Make().String(),
+				SymbolName: p.Name(),
+				Kind:       refKind,
+				FilePath:   f.Path,
+				Line:       line + 1,
+				Column:     char,
+				Context:    strings.TrimSpace(text),
+				Language:   f.Language,
+				CreatedAt:  now,
+			}
+			if target != nil {
+				ref.TargetSymbolID = target.ID
+				ref.Confidence = code.ConfidenceExact
+			}
+			f.Refs = append(f.Refs, ref)
+		}
+
+		for _, si := range doc.Symbols {
+			sub := defs[si.Symbol]
+			if sub == nil || !isTypeKind(sub.Kind) {
+				continue
+			}
+			for _, rel := range si.Relationships {
+				p := parse(rel.Symbol)
+				if !rel.IsImplementation || p == nil || p.Last().Suffix != SuffixType {
+					continue
+				}
+				kind := code.EdgeKindImplements
+				if super := defs[rel.Symbol]; super != nil && super.Kind == code.KindClass {
+					kind = code.EdgeKindExtends
+				}
+				f.Edges = append(f.Edges, &code.TypeEdge{
+					ID:        ulid.Make().String(),
+					Kind:      kind,
+					TypeName:  sub.Name,
+					SuperName: p.Name(),
+					FilePath:  f.Path,
+					Line:      sub.StartLine,
+					Language:  f.Language,
+					CreatedAt: now,
+				})
+			}
+		}
+	}
+	return files
+}
+
+// aideKind maps a SCIP symbol to an aide symbol kind, from its declared
+// kind when the index has one and its descriptor suffix otherwise. It
+// returns "" for symbols the code index does not track.
+func aideKind(si *SymbolInformation, p *Symbol) string {
+	owner := Descriptor{}
+	if o := p.Owner(); o != nil {
+		owner = o.Last()
+	}
+	if si != nil && si.Kind != 0 {
+		switch si.Kind {
+		case KindFunction:
+			return code.KindFunction
+		case KindMethod, KindAbstractMethod, KindStaticMethod, KindConstructor:
+			return code.KindMethod
+		case KindClass, KindStruct, KindEnum:
+			return code.KindClass
+		case KindInterface, KindTrait:
+			return code.KindInterface
+		case KindType, KindTypeAlias:
+			return code.KindType
+		case KindConstant:
+			return code.KindConstant
+		case KindVariable:
+			if owner.Suffix == SuffixNamespace {
+				return code.KindVariable
+			}
+		}
+		return ""
+	}
+	switch p.Last().Suffix {
+	case SuffixMethod:
+		if owner.Suffix == SuffixType {
+			return code.KindMethod
+		}
+		return code.KindFunction
+	case SuffixType:
+		return code.KindType
+	case SuffixTerm:
+		if owner.Suffix == SuffixNamespace {
+			return code.KindVariable
+		}
+	}
+	return ""
+}
+
+func isTypeKind(kind string) bool {
+	return kind == code.KindClass || kind == code.KindInterface || kind == code.KindType
+}
+
+// qualify derives Container and QualifiedName from a symbol's descriptors:
+// the nearest enclosing type (or, for top-level symbols, the last namespace
+// segment) and the dotted path from that namespace segment down.
+func qualify(p *Symbol) (container, qname string) {
+	var parts []string
+	for _, d := range p.Descriptors {
+		switch d.Suffix {
+		case SuffixNamespace:
+			parts = []string{namespaceLeaf(d.Name)}
+		case SuffixType, SuffixTerm, SuffixMethod:
+			parts = append(parts, d.Name)
+		}
+	}
+	if len(parts) > 1 {
+		container = parts[len(parts)-2]
+	}
+	return container, code.NormalizeQualifiedName(strings.Join(parts, "."))
+}
+
+// namespaceLeaf returns the last path segment of a namespace descriptor,
+// without a source-file extension.
+func namespaceLeaf(ns string) string {
+	leaf := path.Base(strings.TrimSuffix(ns, "/"))
+	if code.DetectLanguage(leaf, nil) != "" {
+		leaf = strings.TrimSuffix(leaf, path.Ext(leaf))
+	}
+	return leaf
+}
+
+// describe splits SymbolInformation into a signature and a doc comment.
+// Indexers commonly put the signature in a fenced code block as the first
+// documentation entry when signature_documentation is absent.
+func describe(si *SymbolInformation, name string) (signature, doc string) {
+	if si == nil {
+		return name, ""
+	}
+	signature = si.Signature
+	var docs []string
+	for _, d := range si.Documentation {
+		if body, ok := fencedCode(d); ok {
+			if signature == "" {
+				signature = body
+			}
+			continue
+		}
+		docs = append(docs, d)
+	}
+	if signature == "" {
+		signature = si.DisplayName
+	}
+	if signature == "" {
+		signature = name
+	}
+	return signature, strings.Join(docs, "\n\n")
+}
+
+func fencedCode(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") || len(s) < 6 {
+		return "", false
+	}
+	body := strings.TrimSuffix(s, "```")
+	if nl := strings.IndexByte(body, '\n'); nl >= 0 {
+		body = body[nl+1:]
+	} else {
+		body = strings.TrimPrefix(body, "```")
+	}
+	return strings.TrimSpace(body), true
+}
+
+// byteColumn converts a UTF-16 or UTF-32 column on line to a byte offset.
+func byteColumn(line string, col int, encoding int32) int {
+	units := 0
+	for i, r := range line {
+		if units >= col {
+			return i
+		}
+		if encoding == PositionEncodingUTF16 {
+			units += utf16.RuneLen(r)
+		} else {
+			units++
+		}
+	}
+	return len(line)
+}
+
+// FromAide builds a SCIP index from aide records. Symbols are named under
+// the "aide" scheme with Go symbols namespaced by directory and other
+// languages by file. Only references resolved to an exported symbol are
+// emitted; imports and unresolved name matches have no SCIP equivalent.
+func FromAide(files []*File, lines LineSource, meta Metadata) *Index {
+	if meta.TextEncoding == 0 {
+		meta.TextEncoding = TextEncodingUTF8
+	}
+	idx := &Index{Metadata: meta}
+
+	// Symbol strings for every definition, so references across files
+	// can be written.
+	names := make(map[string]string) // symbol ID -> SCIP symbol
+	byName := make(map[string][]string)
+	seen := make(map[string]int)
+	for _, f := range files {
+		for _, sym := range f.Symbols {
+			s := aideSymbol(f, sym)
+			if n := seen[s.String()]; n > 0 && s.Descriptors[len(s.Descriptors)-1].Suffix == SuffixMethod {
+				s.Descriptors[len(s.Descriptors)-1].Disambiguator = "+" + strconv.Itoa(n)
+			}
+			str := s.String()
+			seen[str]++
+			names[sym.ID] = str
+			if isTypeKind(sym.Kind) {
+				byName[sym.Name] = append(byName[sym.Name], str)
+			}
+		}
+	}
+
+	for _, f := range files {
+		var src []string
+		if lines != nil {
+			src = lines(f.Path)
+		}
+		doc := &Document{
+			RelativePath:     f.Path,
+			Language:         scipLanguages[f.Language],
+			PositionEncoding: PositionEncodingUTF8,
+		}
+		if doc.Language == "" {
+			doc.Language = f.Language
+		}
+		infos := make(map[string]*SymbolInformation)
+		for _, sym := range f.Symbols {
+			str := names[sym.ID]
+			line, col := definitionPosition(src, sym)
+			occ := &Occurrence{
+				Range:       []int32{int32(line), int32(col), int32(col + len(sym.Name))},
+				Symbol:      str,
+				SymbolRoles: RoleDefinition,
+			}
+			if sym.EndLine > sym.StartLine {
+				endChar := 0
+				if sym.EndLine-1 < len(src) {
+					endChar = len(src[sym.EndLine-1])
+				}
+				occ.EnclosingRange = []int32{int32(sym.StartLine - 1), 0, int32(sym.EndLine - 1), int32(endChar)}
+			}
+			doc.Occurrences = append(doc.Occurrences, occ)
+			si := &SymbolInformation{
+				Symbol:      str,
+				Kind:        scipKind(sym.Kind),
+				DisplayName: sym.Name,
+				Signature:   sym.Signature,
+			}
+			if sym.DocComment != "" {
+				si.Documentation = []string{sym.DocComment}
+			}
+			if sym.ParentID != "" {
+				si.EnclosingSymbol = names[sym.ParentID]
+			}
+			if infos[str] == nil {
+				infos[str] = si
+				doc.Symbols = append(doc.Symbols, si)
+			}
+		}
+		for _, e := range f.Edges {
+			if e.Kind != code.EdgeKindImplements && e.Kind != code.EdgeKindExtends {
+				continue
+			}
+			sub := typeSymbol(f, e.TypeName, names)
+			supers := byName[e.SuperName]
+			if sub == "" || len(supers) != 1 || infos[sub] == nil {
+				continue
+			}
+			infos[sub].Relationships = append(infos[sub].Relationships, &Relationship{Symbol: supers[0], IsImplementation: true})
+		}
+		for _, ref := range f.Refs {
+			str := names[ref.TargetSymbolID]
+			if str == "" || ref.Kind == code.RefKindImport {
+				continue
+			}
+			doc.Occurrences = append(doc.Occurrences, &Occurrence{
+				Range:  []int32{int32(ref.Line - 1), int32(ref.Column), int32(ref.Column + len(ref.SymbolName))},
+				Symbol: str,
+			})
+		}
+		idx.Documents = append(idx.Documents, doc)
+	}
+	return idx
+}
+
+// aideSymbol names an aide symbol under the "aide" scheme.
+func aideSymbol(f *File, sym *code.Symbol) *Symbol {
+	ns := f.Path
+	if f.Language == "go" {
+		ns = path.Dir(f.Path)
+	}
+	s := &Symbol{Scheme: "aide", Descriptors: []Descriptor{{Name: ns, Suffix: SuffixNamespace}}}
+	if sym.Container != "" && (sym.Kind == code.KindMethod || sym.ParentID != "") {
+		s.Descriptors = append(s.Descriptors, Descriptor{Name: sym.Container, Suffix: SuffixType})
+	}
+	d := Descriptor{Name: sym.Name, Suffix: SuffixTerm}
+	switch sym.Kind {
+	case code.KindFunction, code.KindMethod:
+		d.Suffix = SuffixMethod
+	case code.KindClass, code.KindInterface, code.KindType:
+		d.Suffix = SuffixType
+	}
+	s.Descriptors = append(s.Descriptors, d)
+	return s
+}
+
+func typeSymbol(f *File, name string, names map[string]string) string {
+	for _, sym := range f.Symbols {
+		if sym.Name == name && isTypeKind(sym.Kind) {
+			return names[sym.ID]
+		}
+	}
+	return ""
+}
+
+func scipKind(kind string) int32 {
+	switch kind {
+	case code.KindFunction:
+		return KindFunction
+	case code.KindMethod:
+		return KindMethod
+	case code.KindClass:
+		return KindClass
+	case code.KindInterface:
+		return KindInterface
+	case code.KindType:
+		return KindType
+	case code.KindVariable:
+		return KindVariable
+	case code.KindConstant:
+		return KindConstant
+	}
+	return 0
+}
+
+// definitionPosition finds the 0-based line and byte column of sym's name,
+// looking a few lines past StartLine since that may point at a decorator or
+// doc comment. Without source it falls back to column 0 of StartLine.
+func definitionPosition(src []string, sym *code.Symbol) (line, col int) {
+	for l := sym.StartLine - 1; l >= 0 && l < len(src) && l < sym.StartLine+5; l++ {
+		if c := code.IdentColumn(src[l], sym.Name); c >= 0 {
+			return l, c
+		}
+	}
+	return sym.StartLine - 1, 0
+}
@@ -0,0 +1,129 @@
+// Package scip reads and writes SCIP code-intelligence indexes
+// (https://github.com/sourcegraph/scip) and converts them to and from aide's
+// code index records.
+//
+// Only the parts of the schema aide uses are modelled; unknown fields are
+// skipped on decode. The protobuf wire format is handled directly with
+// protowire so no generated bindings are needed.
+package scip
+
+// Index is a whole SCIP index: metadata plus one Document per source file.
+type Index struct {
+	Metadata        Metadata
+	Documents       []*Document
+	ExternalSymbols []*SymbolInformation
+}
+
+// Metadata describes the tool that produced an index.
+type Metadata struct {
+	Version       int32
+	ToolName      string
+	ToolVersion   string
+	ToolArguments []string
+	ProjectRoot   string // URI, e.g. file:///src/project
+	TextEncoding  int32  // TextEncoding* constants
+}
+
+// Document holds the occurrences and symbol information for one file.
+type Document struct {
+	RelativePath     string // Relative to Metadata.ProjectRoot, forward slashes
+	Language         string
+	Occurrences      []*Occurrence
+	Symbols          []*SymbolInformation
+	Text             string // Optional file contents
+	PositionEncoding int32  // PositionEncoding* constants
+}
+
+// Occurrence is one appearance of a symbol in a document. Range is
+// [startLine, startChar, endChar] or [startLine, startChar, endLine,
+// endChar], all 0-based.
+type Occurrence struct {
+	Range          []int32
+	Symbol         string
+	SymbolRoles    int32 // Role* bit set
+	EnclosingRange []int32
+}
+
+// Start returns the occurrence's 0-based start line and character.
+func (o *Occurrence) Start() (line, char int) {
+	if len(o.Range) < 2 {
+		return 0, 0
+	}
+	return int(o.Range[0]), int(o.Range[1])
+}
+
+// endLine returns the 0-based line a range ends on.
+func endLine(r []int32) int {
+	switch len(r) {
+	case 3:
+		return int(r[0])
+	case 4:
+		return int(r[2])
+	}
+	return 0
+}
+
+// SymbolInformation is the metadata for a symbol defined in a document
+// (or, in Index.ExternalSymbols, one defined elsewhere).
+type SymbolInformation struct {
+	Symbol          string
+	Documentation   []string // Markdown
+	Relationships   []*Relationship
+	Kind            int32 // Kind* constants
+	DisplayName     string
+	Signature       string // signature_documentation.text
+	EnclosingSymbol string
+}
+
+// Relationship links a symbol to another, e.g. a type to the interface it
+// implements.
+type Relationship struct {
+	Symbol           string
+	IsReference      bool
+	IsImplementation bool
+	IsTypeDefinition bool
+	IsDefinition     bool
+}
+
+// SymbolRole bits.
+const (
+	RoleDefinition        int32 = 0x1
+	RoleImport            int32 = 0x2
+	RoleWriteAccess       int32 = 0x4
+	RoleReadAccess        int32 = 0x8
+	RoleGenerated         int32 = 0x10
+	RoleTest              int32 = 0x20
+	RoleForwardDefinition int32 = 0x40
+)
+
+// Text and position encodings.
+const (
+	TextEncodingUTF8  int32 = 1
+	TextEncodingUTF16 int32 = 2
+
+	PositionEncodingUTF8  int32 = 1 // Byte offsets from the line start
+	PositionEncodingUTF16 int32 = 2 // UTF-16 code units from the line start
+	PositionEncodingUTF32 int32 = 3 // Code points from the line start
+)
+
+// SymbolInformation.Kind values aide maps to and from its own kinds.
+const (
+	KindClass          int32 = 7
+	KindConstant       int32 = 8
+	KindConstructor    int32 = 9
+	KindEnum           int32 = 11
+	KindField          int32 = 15
+	KindFunction       int32 = 17
+	KindInterface      int32 = 21
+	KindMethod         int32 = 26
+	KindModule         int32 = 29
+	KindNamespace      int32 = 30
+	KindPackage        int32 = 35
+	KindStruct         int32 = 49
+	KindTrait          int32 = 53
+	KindType           int32 = 54
+	KindTypeAlias      int32 = 55
+	KindVariable       int32 = 61
+	KindAbstractMethod int32 = 66
+	KindStaticMethod   int32 = 80
+)
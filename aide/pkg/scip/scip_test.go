@@ -0,0 +1,211 @@
+package scip
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseSymbol(t *testing.T) {
+	cases := []struct {
+		in    string
+		name  string
+		descs int
+	}{
+		{"scip-go gomod github.com/x/aide v1 `github.com/x/aide/pkg/store`/CodeStore#Close().", "Close", 3},
+		{"scip-typescript npm pkg 1.0.0 src/`util.ts`/helper().", "helper", 3},
+		{"scip-java maven . . com/x/Foo#bar(+1).", "bar", 4},
+		{"rust-analyzer cargo crate 0.1 m/Trait#[T]", "T", 3},
+		{"aide . . . `a b`/f().(x)", "x", 3},
+	}
+	for _, c := range cases {
+		sym, err := ParseSymbol(c.in)
+		if err != nil {
+			t.Errorf("ParseSymbol(%q): %v", c.in, err)
+			continue
+		}
+		if sym.Name() != c.name || len(sym.Descriptors) != c.descs {
+			t.Errorf("ParseSymbol(%q) = %q with %d descriptors, want %q with %d", c.in, sym.Name(), len(sym.Descriptors), c.name, c.descs)
+		}
+		if got := sym.String(); got != c.in {
+			t.Errorf("round trip %q -> %q", c.in, got)
+		}
+	}
+
+	if sym, err := ParseSymbol("local 42"); err != nil || sym.Local != "42" {
+		t.Errorf("local symbol = %+v, %v", sym, err)
+	}
+	for _, bad := range []string{"scip-go gomod", "scip-go . . . Foo", "scip-go . . . `unterminated"} {
+		if _, err := ParseSymbol(bad); err == nil {
+			t.Errorf("ParseSymbol(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	idx := &Index{
+		Metadata: Metadata{ToolName: "aide", ToolVersion: "1.0", ToolArguments: []string{"export"}, ProjectRoot: "file:///src", TextEncoding: TextEncodingUTF8},
+		Documents: []*Document{{
+			RelativePath:     "a.go",
+			Language:         "Go",
+			PositionEncoding: PositionEncodingUTF8,
+			Occurrences: []*Occurrence{
+				{Range: []int32{2, 5, 8}, Symbol: "aide . . . ./run().", SymbolRoles: RoleDefinition, EnclosingRange: []int32{2, 0, 4, 1}},
+				{Range: []int32{7, 1, 4}, Symbol: "aide . . . ./run()."},
+			},
+			Symbols: []*SymbolInformation{{
+				Symbol:        "aide . . . ./run().",
+				Documentation: []string{"runs"},
+				Relationships: []*Relationship{{Symbol: "aide . . . ./I#", IsImplementation: true}},
+				Kind:          KindFunction,
+				DisplayName:   "run",
+				Signature:     "func run()",
+			}},
+		}},
+		ExternalSymbols: []*SymbolInformation{{Symbol: "aide . . . fmt/Println().", Kind: KindFunction}},
+	}
+	got, err := Unmarshal(Marshal(idx))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, idx) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", got, idx)
+	}
+}
+
+// TestUnmarshalUnpackedRange checks the decoder accepts ranges written as
+// unpacked repeated varints and skips unknown fields.
+func TestUnmarshalUnpackedRange(t *testing.T) {
+	var occ []byte
+	for _, v := range []uint64{3, 1, 4} {
+		occ = protowire.AppendTag(occ, 1, protowire.VarintType)
+		occ = protowire.AppendVarint(occ, v)
+	}
+	occ = protowire.AppendTag(occ, 99, protowire.Fixed64Type)
+	occ = protowire.AppendFixed64(occ, 7)
+	doc := appendString(nil, 1, "x.go")
+	doc = appendMessage(doc, 2, occ)
+	idx, err := Unmarshal(appendMessage(nil, 2, doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := idx.Documents[0].Occurrences[0].Range; !reflect.DeepEqual(r, []int32{3, 1, 4}) {
+		t.Errorf("range = %v", r)
+	}
+	if _, err := Unmarshal([]byte{0x12, 0x05, 0x01}); err == nil {
+		t.Error("expected an error for a truncated message")
+	}
+}
+
+func TestToAide(t *testing.T) {
+	const (
+		store = "scip-go gomod m v1 `m/store`/Store#"
+		close = "scip-go gomod m v1 `m/store`/Store#Close()."
+		iface = "scip-go gomod m v1 `m/store`/Closer#"
+		field = "scip-go gomod m v1 `m/store`/Store#db."
+	)
+	idx := &Index{Documents: []*Document{
+		{
+			RelativePath: "store/store.go",
+			Occurrences: []*Occurrence{
+				{Range: []int32{2, 5, 10}, Symbol: store, SymbolRoles: RoleDefinition, EnclosingRange: []int32{2, 0, 4, 1}},
+				{Range: []int32{3, 1, 3}, Symbol: field, SymbolRoles: RoleDefinition},
+				{Range: []int32{6, 16, 21}, Symbol: close, SymbolRoles: RoleDefinition},
+				{Range: []int32{8, 5, 11}, Symbol: iface, SymbolRoles: RoleDefinition},
+				{Range: []int32{10, 0, 1}, Symbol: "local 1", SymbolRoles: RoleDefinition},
+			},
+			Symbols: []*SymbolInformation{
+				{Symbol: store, Kind: KindStruct, Relationships: []*Relationship{{Symbol: iface, IsImplementation: true}}},
+				{Symbol: close, Documentation: []string{"```go\nfunc (s *Store) Close() error\n```", "Close releases the store."}},
+				{Symbol: iface, Kind: KindInterface},
+				{Symbol: field, Kind: KindField},
+			},
+		},
+		{
+			RelativePath:     "main.go",
+			PositionEncoding: PositionEncodingUTF16,
+			Occurrences: []*Occurrence{
+				{Range: []int32{0, 4, 9}, Symbol: close},
+				{Range: []int32{1, 0, 5}, Symbol: store},
+				{Range: []int32{1, 7, 9}, Symbol: field},
+			},
+		},
+	}}
+	lines := func(p string) []string {
+		if p == "main.go" {
+			return []string{"é.s.Close()", "Store{db: nil}"}
+		}
+		return nil
+	}
+	files := ToAide(idx, lines)
+	if len(files) != 2 {
+		t.Fatalf("got %d files", len(files))
+	}
+
+	syms := files[0].Symbols
+	if len(syms) != 3 {
+		t.Fatalf("symbols = %d, want Store, Close, Closer", len(syms))
+	}
+	st, cl := syms[0], syms[1]
+	if st.Kind != code.KindClass || st.StartLine != 3 || st.EndLine != 5 || !st.Precise || st.Language != "go" {
+		t.Errorf("Store = %+v", st)
+	}
+	if cl.Kind != code.KindMethod || cl.Container != "Store" || cl.QualifiedName != "store.Store.Close" || cl.ParentID != st.ID {
+		t.Errorf("Close = %+v", cl)
+	}
+	if cl.Signature != "func (s *Store) Close() error" || cl.DocComment != "Close releases the store." {
+		t.Errorf("Close signature/doc = %q / %q", cl.Signature, cl.DocComment)
+	}
+	if e := files[0].Edges; len(e) != 1 || e[0].TypeName != "Store" || e[0].SuperName != "Closer" || e[0].Kind != code.EdgeKindImplements {
+		t.Errorf("edges = %+v", e)
+	}
+
+	refs := files[1].Refs
+	if len(refs) != 2 {
+		t.Fatalf("refs = %+v, want Close call and Store type_ref", refs)
+	}
+	if r := refs[0]; r.Kind != code.RefKindCall || r.TargetSymbolID != cl.ID || r.Confidence != code.ConfidenceExact || r.Line != 1 || r.Column != 5 || r.Context != "é.s.Close()" {
+		t.Errorf("call ref = %+v", r)
+	}
+	if r := refs[1]; r.Kind != code.RefKindTypeRef || r.TargetSymbolID != st.ID {
+		t.Errorf("type ref = %+v", r)
+	}
+}
+
+func TestFromAideRoundTrip(t *testing.T) {
+	run := &code.Symbol{ID: "s1", Name: "run", Kind: code.KindFunction, FilePath: "cmd/main.go", StartLine: 3, EndLine: 5, Signature: "func run()", Language: "go"}
+	src := map[string][]string{"cmd/main.go": {"package main", "", "func run() {", "\trun()", "}"}}
+	files := []*File{{
+		Path:     "cmd/main.go",
+		Language: "go",
+		Symbols:  []*code.Symbol{run},
+		Refs: []*code.Reference{
+			{SymbolName: "run", Kind: code.RefKindCall, FilePath: "cmd/main.go", Line: 4, Column: 1, TargetSymbolID: "s1"},
+			{SymbolName: "fmt", Kind: code.RefKindImport, FilePath: "cmd/main.go", Line: 1},
+			{SymbolName: "other", Kind: code.RefKindCall, FilePath: "cmd/main.go", Line: 4},
+		},
+	}}
+	idx := FromAide(files, func(p string) []string { return src[p] }, Metadata{ToolName: "aide"})
+	data := Marshal(idx)
+	back, err := Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := back.Documents[0]
+	if doc.Language != "Go" || len(doc.Occurrences) != 2 {
+		t.Fatalf("document = %+v", doc)
+	}
+	if def := doc.Occurrences[0]; def.Symbol != "aide . . . cmd/run()." || !reflect.DeepEqual(def.Range, []int32{2, 5, 8}) {
+		t.Errorf("definition = %+v", def)
+	}
+
+	got := ToAide(back, nil)[0]
+	if len(got.Symbols) != 1 || got.Symbols[0].Name != "run" || got.Symbols[0].Kind != code.KindFunction || got.Symbols[0].Signature != "func run()" {
+		t.Fatalf("re-imported symbols = %+v", got.Symbols)
+	}
+	if len(got.Refs) != 1 || got.Refs[0].TargetSymbolID != got.Symbols[0].ID || got.Refs[0].Line != 4 {
+		t.Errorf("re-imported refs = %+v", got.Refs)
+	}
+}
@@ -0,0 +1,259 @@
+package scip
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Descriptor suffixes, as written after (or around) a descriptor name.
+const (
+	SuffixNamespace     = '/'
+	SuffixType          = '#'
+	SuffixTerm          = '.'
+	SuffixMethod        = '('
+	SuffixTypeParameter = '['
+	SuffixParameter     = ')'
+	SuffixMeta          = ':'
+	SuffixMacro         = '!'
+)
+
+// Descriptor is one step of a global symbol's path, e.g. "CodeStore#" or
+// "Close()." in "... store/CodeStore#Close().".
+type Descriptor struct {
+	Name          string
+	Disambiguator string // methods only, e.g. "+1" for an overload
+	Suffix        byte   // one of the Suffix* constants
+}
+
+// Symbol is a parsed SCIP symbol string: either a local symbol ("local 3")
+// or a global one made of a scheme, a package and a descriptor path.
+type Symbol struct {
+	Local       string // set for local symbols only
+	Scheme      string
+	Manager     string
+	Package     string
+	Version     string
+	Descriptors []Descriptor
+}
+
+// IsLocal reports whether the symbol string names a document-local symbol.
+func IsLocal(symbol string) bool {
+	return strings.HasPrefix(symbol, "local ")
+}
+
+// ParseSymbol parses a SCIP symbol string.
+func ParseSymbol(symbol string) (*Symbol, error) {
+	if IsLocal(symbol) {
+		return &Symbol{Local: strings.TrimPrefix(symbol, "local ")}, nil
+	}
+	p := &symbolParser{s: symbol}
+	sym := &Symbol{}
+	fields := []*string{&sym.Scheme, &sym.Manager, &sym.Package, &sym.Version}
+	for _, f := range fields {
+		v, err := p.spaceTerminated()
+		if err != nil {
+			return nil, fmt.Errorf("scip: symbol %q: %w", symbol, err)
+		}
+		if v == "." && f != &sym.Scheme {
+			v = ""
+		}
+		*f = v
+	}
+	for p.i < len(p.s) {
+		d, err := p.descriptor()
+		if err != nil {
+			return nil, fmt.Errorf("scip: symbol %q: %w", symbol, err)
+		}
+		sym.Descriptors = append(sym.Descriptors, d)
+	}
+	if len(sym.Descriptors) == 0 {
+		return nil, fmt.Errorf("scip: symbol %q has no descriptors", symbol)
+	}
+	return sym, nil
+}
+
+// String formats the symbol back into SCIP's string form.
+func (s *Symbol) String() string {
+	if s.Local != "" {
+		return "local " + s.Local
+	}
+	var sb strings.Builder
+	for _, f := range []string{s.Scheme, s.Manager, s.Package, s.Version} {
+		if f == "" {
+			f = "."
+		}
+		sb.WriteString(strings.ReplaceAll(f, " ", "  "))
+		sb.WriteByte(' ')
+	}
+	for _, d := range s.Descriptors {
+		sb.WriteString(d.String())
+	}
+	return sb.String()
+}
+
+// String formats one descriptor.
+func (d Descriptor) String() string {
+	name := escapeName(d.Name)
+	switch d.Suffix {
+	case SuffixMethod:
+		return name + "(" + d.Disambiguator + ")."
+	case SuffixTypeParameter:
+		return "[" + name + "]"
+	case SuffixParameter:
+		return "(" + name + ")"
+	default:
+		return name + string(d.Suffix)
+	}
+}
+
+// Name returns the last descriptor's name, or "" for local symbols.
+func (s *Symbol) Name() string {
+	if len(s.Descriptors) == 0 {
+		return ""
+	}
+	return s.Descriptors[len(s.Descriptors)-1].Name
+}
+
+// Last returns the last descriptor.
+func (s *Symbol) Last() Descriptor {
+	if len(s.Descriptors) == 0 {
+		return Descriptor{}
+	}
+	return s.Descriptors[len(s.Descriptors)-1]
+}
+
+// Owner returns the symbol one descriptor up (a method's type, a type's
+// namespace), or nil at the top.
+func (s *Symbol) Owner() *Symbol {
+	if len(s.Descriptors) < 2 {
+		return nil
+	}
+	owner := *s
+	owner.Descriptors = s.Descriptors[:len(s.Descriptors)-1]
+	return &owner
+}
+
+func isSimpleIdentRune(c byte) bool {
+	return c == '_' || c == '+' || c == '-' || c == '$' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// escapeName backtick-quotes a descriptor name unless it is a simple
+// identifier.
+func escapeName(name string) string {
+	simple := name != ""
+	for i := 0; i < len(name); i++ {
+		if !isSimpleIdentRune(name[i]) {
+			simple = false
+			break
+		}
+	}
+	if simple {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+type symbolParser struct {
+	s string
+	i int
+}
+
+// spaceTerminated reads a header field ending in a single space; a doubled
+// space is an escaped space.
+func (p *symbolParser) spaceTerminated() (string, error) {
+	var sb strings.Builder
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c == ' ' {
+			if p.i+1 < len(p.s) && p.s[p.i+1] == ' ' {
+				sb.WriteByte(' ')
+				p.i += 2
+				continue
+			}
+			p.i++
+			return sb.String(), nil
+		}
+		sb.WriteByte(c)
+		p.i++
+	}
+	return "", errors.New("truncated header")
+}
+
+func (p *symbolParser) name() (string, error) {
+	if p.i < len(p.s) && p.s[p.i] == '`' {
+		var sb strings.Builder
+		p.i++
+		for p.i < len(p.s) {
+			c := p.s[p.i]
+			if c == '`' {
+				if p.i+1 < len(p.s) && p.s[p.i+1] == '`' {
+					sb.WriteByte('`')
+					p.i += 2
+					continue
+				}
+				p.i++
+				return sb.String(), nil
+			}
+			sb.WriteByte(c)
+			p.i++
+		}
+		return "", errors.New("unterminated escaped name")
+	}
+	start := p.i
+	for p.i < len(p.s) && isSimpleIdentRune(p.s[p.i]) {
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+func (p *symbolParser) expect(c byte) error {
+	if p.i >= len(p.s) || p.s[p.i] != c {
+		return fmt.Errorf("expected %q at offset %d", c, p.i)
+	}
+	p.i++
+	return nil
+}
+
+func (p *symbolParser) descriptor() (Descriptor, error) {
+	switch p.s[p.i] {
+	case '[':
+		p.i++
+		n, err := p.name()
+		if err != nil {
+			return Descriptor{}, err
+		}
+		return Descriptor{Name: n, Suffix: SuffixTypeParameter}, p.expect(']')
+	case '(':
+		p.i++
+		n, err := p.name()
+		if err != nil {
+			return Descriptor{}, err
+		}
+		return Descriptor{Name: n, Suffix: SuffixParameter}, p.expect(')')
+	}
+	n, err := p.name()
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if p.i >= len(p.s) {
+		return Descriptor{}, errors.New("descriptor without suffix")
+	}
+	switch c := p.s[p.i]; c {
+	case SuffixNamespace, SuffixType, SuffixTerm, SuffixMeta, SuffixMacro:
+		p.i++
+		return Descriptor{Name: n, Suffix: c}, nil
+	case '(':
+		p.i++
+		end := strings.IndexByte(p.s[p.i:], ')')
+		if end < 0 {
+			return Descriptor{}, errors.New("unterminated method disambiguator")
+		}
+		d := Descriptor{Name: n, Disambiguator: p.s[p.i : p.i+end], Suffix: SuffixMethod}
+		p.i += end + 1
+		return d, p.expect('.')
+	default:
+		return Descriptor{}, fmt.Errorf("unexpected %q at offset %d", c, p.i)
+	}
+}
@@ -0,0 +1,327 @@
+package scip
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Marshal encodes idx in the SCIP protobuf wire format.
+func Marshal(idx *Index) []byte {
+	var b []byte
+	b = appendMessage(b, 1, appendMetadata(nil, &idx.Metadata))
+	for _, d := range idx.Documents {
+		b = appendMessage(b, 2, appendDocument(nil, d))
+	}
+	for _, s := range idx.ExternalSymbols {
+		b = appendMessage(b, 3, appendSymbolInformation(nil, s))
+	}
+	return b
+}
+
+// Unmarshal decodes a SCIP index.
+func Unmarshal(data []byte) (*Index, error) {
+	idx := &Index{}
+	err := walk(data, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		switch {
+		case num == 1 && typ == protowire.BytesType:
+			return decodeMetadata(v, &idx.Metadata)
+		case num == 2 && typ == protowire.BytesType:
+			d := &Document{}
+			if err := decodeDocument(v, d); err != nil {
+				return err
+			}
+			idx.Documents = append(idx.Documents, d)
+		case num == 3 && typ == protowire.BytesType:
+			s := &SymbolInformation{}
+			if err := decodeSymbolInformation(v, s); err != nil {
+				return err
+			}
+			idx.ExternalSymbols = append(idx.ExternalSymbols, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scip: %w", err)
+	}
+	return idx, nil
+}
+
+// --- encoding ---
+
+func appendMessage(b []byte, num protowire.Number, msg []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, msg)
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+func appendInt32(b []byte, num protowire.Number, v int32) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, uint64(int64(v)))
+}
+
+func appendBool(b []byte, num protowire.Number, v bool) []byte {
+	if !v {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, 1)
+}
+
+func appendPacked(b []byte, num protowire.Number, vs []int32) []byte {
+	if len(vs) == 0 {
+		return b
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, uint64(int64(v)))
+	}
+	return appendMessage(b, num, packed)
+}
+
+func appendMetadata(b []byte, m *Metadata) []byte {
+	b = appendInt32(b, 1, m.Version)
+	var tool []byte
+	tool = appendString(tool, 1, m.ToolName)
+	tool = appendString(tool, 2, m.ToolVersion)
+	for _, a := range m.ToolArguments {
+		tool = protowire.AppendTag(tool, 3, protowire.BytesType)
+		tool = protowire.AppendString(tool, a)
+	}
+	b = appendMessage(b, 2, tool)
+	b = appendString(b, 3, m.ProjectRoot)
+	return appendInt32(b, 4, m.TextEncoding)
+}
+
+func appendDocument(b []byte, d *Document) []byte {
+	b = appendString(b, 1, d.RelativePath)
+	for _, o := range d.Occurrences {
+		b = appendMessage(b, 2, appendOccurrence(nil, o))
+	}
+	for _, s := range d.Symbols {
+		b = appendMessage(b, 3, appendSymbolInformation(nil, s))
+	}
+	b = appendString(b, 4, d.Language)
+	b = appendString(b, 5, d.Text)
+	return appendInt32(b, 6, d.PositionEncoding)
+}
+
+func appendOccurrence(b []byte, o *Occurrence) []byte {
+	b = appendPacked(b, 1, o.Range)
+	b = appendString(b, 2, o.Symbol)
+	b = appendInt32(b, 3, o.SymbolRoles)
+	return appendPacked(b, 7, o.EnclosingRange)
+}
+
+func appendSymbolInformation(b []byte, s *SymbolInformation) []byte {
+	b = appendString(b, 1, s.Symbol)
+	for _, doc := range s.Documentation {
+		b = protowire.AppendTag(b, 3, protowire.BytesType)
+		b = protowire.AppendString(b, doc)
+	}
+	for _, r := range s.Relationships {
+		var rel []byte
+		rel = appendString(rel, 1, r.Symbol)
+		rel = appendBool(rel, 2, r.IsReference)
+		rel = appendBool(rel, 3, r.IsImplementation)
+		rel = appendBool(rel, 4, r.IsTypeDefinition)
+		rel = appendBool(rel, 5, r.IsDefinition)
+		b = appendMessage(b, 4, rel)
+	}
+	b = appendInt32(b, 5, s.Kind)
+	b = appendString(b, 6, s.DisplayName)
+	if s.Signature != "" {
+		b = appendMessage(b, 7, appendString(nil, 5, s.Signature))
+	}
+	return appendString(b, 8, s.EnclosingSymbol)
+}
+
+// --- decoding ---
+
+// walk calls fn for every field in msg. v is the payload of length-delimited
+// fields; n is the value of varint fields. Fixed-width and group fields are
+// skipped.
+func walk(msg []byte, fn func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(msg) > 0 {
+		num, typ, tagLen := protowire.ConsumeTag(msg)
+		if tagLen < 0 {
+			return protowire.ParseError(tagLen)
+		}
+		msg = msg[tagLen:]
+		var (
+			v   []byte
+			n   uint64
+			err error
+		)
+		length := 0
+		switch typ {
+		case protowire.BytesType:
+			v, length = protowire.ConsumeBytes(msg)
+		case protowire.VarintType:
+			n, length = protowire.ConsumeVarint(msg)
+		default:
+			length = protowire.ConsumeFieldValue(num, typ, msg)
+		}
+		if length < 0 {
+			return protowire.ParseError(length)
+		}
+		msg = msg[length:]
+		if typ == protowire.BytesType || typ == protowire.VarintType {
+			if err = fn(num, typ, v, n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendInt32s decodes a repeated int32 field in either packed or unpacked
+// form.
+func appendInt32s(dst []int32, typ protowire.Type, v []byte, n uint64) ([]int32, error) {
+	if typ == protowire.VarintType {
+		return append(dst, int32(n)), nil
+	}
+	for len(v) > 0 {
+		x, l := protowire.ConsumeVarint(v)
+		if l < 0 {
+			return nil, protowire.ParseError(l)
+		}
+		dst = append(dst, int32(x))
+		v = v[l:]
+	}
+	return dst, nil
+}
+
+func decodeMetadata(msg []byte, m *Metadata) error {
+	return walk(msg, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			m.Version = int32(n)
+		case 2:
+			return walk(v, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) error {
+				switch num {
+				case 1:
+					m.ToolName = string(v)
+				case 2:
+					m.ToolVersion = string(v)
+				case 3:
+					m.ToolArguments = append(m.ToolArguments, string(v))
+				}
+				return nil
+			})
+		case 3:
+			m.ProjectRoot = string(v)
+		case 4:
+			m.TextEncoding = int32(n)
+		}
+		return nil
+	})
+}
+
+func decodeDocument(msg []byte, d *Document) error {
+	return walk(msg, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			d.RelativePath = string(v)
+		case 2:
+			o := &Occurrence{}
+			if err := decodeOccurrence(v, o); err != nil {
+				return err
+			}
+			d.Occurrences = append(d.Occurrences, o)
+		case 3:
+			s := &SymbolInformation{}
+			if err := decodeSymbolInformation(v, s); err != nil {
+				return err
+			}
+			d.Symbols = append(d.Symbols, s)
+		case 4:
+			d.Language = string(v)
+		case 5:
+			d.Text = string(v)
+		case 6:
+			d.PositionEncoding = int32(n)
+		}
+		return nil
+	})
+}
+
+func decodeOccurrence(msg []byte, o *Occurrence) error {
+	err := walk(msg, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		var err error
+		switch num {
+		case 1:
+			o.Range, err = appendInt32s(o.Range, typ, v, n)
+		case 2:
+			o.Symbol = string(v)
+		case 3:
+			o.SymbolRoles = int32(n)
+		case 7:
+			o.EnclosingRange, err = appendInt32s(o.EnclosingRange, typ, v, n)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if len(o.Range) != 3 && len(o.Range) != 4 {
+		return errors.New("occurrence range must have 3 or 4 elements")
+	}
+	return nil
+}
+
+func decodeSymbolInformation(msg []byte, s *SymbolInformation) error {
+	return walk(msg, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch num {
+		case 1:
+			s.Symbol = string(v)
+		case 3:
+			s.Documentation = append(s.Documentation, string(v))
+		case 4:
+			r := &Relationship{}
+			if err := walk(v, func(num protowire.Number, _ protowire.Type, v []byte, n uint64) error {
+				switch num {
+				case 1:
+					r.Symbol = string(v)
+				case 2:
+					r.IsReference = n != 0
+				case 3:
+					r.IsImplementation = n != 0
+				case 4:
+					r.IsTypeDefinition = n != 0
+				case 5:
+					r.IsDefinition = n != 0
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			s.Relationships = append(s.Relationships, r)
+		case 5:
+			s.Kind = int32(n)
+		case 6:
+			s.DisplayName = string(v)
+		case 7:
+			return walk(v, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) error {
+				if num == 5 {
+					s.Signature = string(v)
+				}
+				return nil
+			})
+		case 8:
+			s.EnclosingSymbol = string(v)
+		}
+		return nil
+	})
+}
@@ -18,9 +18,12 @@ import (
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
 	"github.com/blevesearch/bleve/v2/analysis/token/edgengram"
 	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/single"
 	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/embed"
 	"github.com/oklog/ulid/v2"
 	bolt "go.etcd.io/bbolt"
 )
@@ -45,13 +48,31 @@ var (
 	// Replaces the previous JSON-slice format from schema v1.
 	BucketRefIndex = []byte("refindex")
 	BucketCodeMeta = []byte("code_meta")
+	// BucketTypeEdges stores code.TypeEdge records (type-hierarchy edges)
+	// keyed by edge ID. Edges are few relative to references, so hierarchy
+	// lookups scan the bucket rather than maintaining a name index.
+	BucketTypeEdges = []byte("type_edges")
+	// BucketTypeEdgesByFile is keyed by composeFileKey(filePath, edgeID) with
+	// nil value, so a file's edges are cleared in O(per-file).
+	BucketTypeEdgesByFile = []byte("type_edges_by_file")
+	// BucketLSPCache stores language-server query results keyed by
+	// composeFileKey(filePath, queryKey), where the query key carries the
+	// queried file's mtime. Entries for a file are dropped whenever it is
+	// cleared or re-indexed; results pointing into other files are checked
+	// against those files' mtimes when read.
+	BucketLSPCache = []byte("lsp_cache")
+	// BucketSymbolHistory stores a code.FileHistory per file path. Entries
+	// record the HEAD they were computed at and are refreshed from git on
+	// read, so they outlive re-indexing of the file.
+	BucketSymbolHistory = []byte("symbol_history")
 )
 
 // CodeStore provides symbol storage and search.
 type CodeStore struct {
-	db     *bolt.DB
-	search bleve.Index
-	dbPath string
+	db       *bolt.DB
+	search   bleve.Index
+	dbPath   string
+	embedder embed.Embedder // nil = no semantic search
 }
 
 // CodeSearchResult represents a symbol search match with score.
@@ -63,7 +84,7 @@ type CodeSearchResult struct {
 // NewCodeStore creates a new code store.
 // dbPath: path to BBolt database (e.g., .aide/code/index.db)
 // searchPath: path to Bleve index (e.g., .aide/code/search.bleve)
-func NewCodeStore(dbPath, searchPath string) (*CodeStore, error) {
+func NewCodeStore(dbPath, searchPath string, opts ...CodeStoreOption) (*CodeStore, error) {
 	// Ensure directories exist
 	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
 		return nil, fmt.Errorf("failed to create db directory: %w", err)
@@ -79,7 +100,7 @@ func NewCodeStore(dbPath, searchPath string) (*CodeStore, error) {
 
 	// Initialize buckets
 	err = db.Update(func(tx *bolt.Tx) error {
-		buckets := [][]byte{BucketSymbols, BucketReferences, BucketFileIndex, BucketRefIndex, BucketSymbolsByFile, BucketReferencesByFile, BucketCodeMeta}
+		buckets := [][]byte{BucketSymbols, BucketReferences, BucketFileIndex, BucketRefIndex, BucketSymbolsByFile, BucketReferencesByFile, BucketCodeMeta, BucketTypeEdges, BucketTypeEdgesByFile, BucketLSPCache, BucketSymbolHistory, BucketSymbolVectors}
 		for _, bucket := range buckets {
 			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
 				return err
@@ -109,6 +130,9 @@ func NewCodeStore(dbPath, searchPath string) (*CodeStore, error) {
 		search: index,
 		dbPath: dbPath,
 	}
+	for _, opt := range opts {
+		opt(cs)
+	}
 
 	if err := cs.ensureCodeSearchMapping(searchPath); err != nil {
 		index.Close()
@@ -164,6 +188,18 @@ func buildCodeIndexMapping() (mapping.IndexMapping, error) {
 		return nil, err
 	}
 
+	// Whole-value lowercase for qualified names
+	err = indexMapping.AddCustomAnalyzer("keyword_lower", map[string]interface{}{
+		"type":      custom.Name,
+		"tokenizer": single.Name,
+		"token_filters": []string{
+			lowercase.Name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Edge n-gram for prefix matching (get -> getUser)
 	err = indexMapping.AddCustomTokenFilter("edge_ngram_filter", map[string]interface{}{
 		"type": edgengram.Name,
@@ -226,6 +262,21 @@ func buildCodeIndexMapping() (mapping.IndexMapping, error) {
 	fileField.Analyzer = keyword.Name
 	symbolMapping.AddFieldMappingsAt("file", fileField)
 
+	// Container (class, receiver type, module) is searchable like a name.
+	containerField := bleve.NewTextFieldMapping()
+	containerField.Analyzer = "standard_lower"
+	containerField.Store = false
+	containerField.IncludeInAll = false
+	symbolMapping.AddFieldMappingsAt("container", containerField)
+
+	// Qualified name as one lowercased token, for exact and suffix lookups
+	// of "pkg.Type.Method".
+	qnameField := bleve.NewTextFieldMapping()
+	qnameField.Analyzer = "keyword_lower"
+	qnameField.Store = false
+	qnameField.IncludeInAll = false
+	symbolMapping.AddFieldMappingsAt("qname", qnameField)
+
 	indexMapping.AddDocumentMapping("symbol", symbolMapping)
 	indexMapping.DefaultMapping = symbolMapping
 
@@ -285,16 +336,7 @@ func (s *CodeStore) ensureCodeSearchMapping(searchPath string) error {
 			if err := json.Unmarshal(v, &sym); err != nil {
 				continue
 			}
-			doc := map[string]interface{}{
-				"name":      sym.Name,
-				"name_edge": sym.Name,
-				"signature": sym.Signature,
-				"doc":       sym.DocComment,
-				"kind":      sym.Kind,
-				"lang":      sym.Language,
-				"file":      sym.FilePath,
-			}
-			if err := s.search.Index(sym.ID, doc); err != nil {
+			if err := s.search.Index(sym.ID, buildSymbolBleveDoc(&sym)); err != nil {
 				return err
 			}
 		}
@@ -379,6 +421,8 @@ func buildSymbolBleveDoc(sym *code.Symbol) map[string]interface{} {
 		"kind":      sym.Kind,
 		"lang":      sym.Language,
 		"file":      sym.FilePath,
+		"container": sym.Container,
+		"qname":     sym.QualifiedName,
 	}
 }
 
@@ -456,6 +500,9 @@ func (s *CodeStore) DeleteSymbol(id string) error {
 		if err := b.Delete([]byte(id)); err != nil {
 			return err
 		}
+		if err := tx.Bucket(BucketSymbolVectors).Delete([]byte(id)); err != nil {
+			return err
+		}
 		return tx.Bucket(BucketSymbolsByFile).Delete(composeFileKey(sym.FilePath, id))
 	})
 	if err != nil {
@@ -464,13 +511,19 @@ func (s *CodeStore) DeleteSymbol(id string) error {
 	return s.search.Delete(id)
 }
 
-// SearchSymbols performs full-text search on symbols.
+// SearchSymbols performs full-text search on symbols. A qualified name
+// ("store.CodeStore.Close", "Foo::bar") is matched against the symbols'
+// qualified names instead, exactly or as a trailing path.
 func (s *CodeStore) SearchSymbols(query string, opts code.SearchOptions) ([]*CodeSearchResult, error) {
 	limit := opts.Limit
 	if limit <= 0 {
 		limit = 20
 	}
 
+	if code.IsQualifiedName(query) {
+		return s.searchQualifiedSymbols(query, opts, limit)
+	}
+
 	// Build query - try multiple strategies to find matches
 	lowerQuery := strings.ToLower(query)
 
@@ -492,7 +545,26 @@ func (s *CodeStore) SearchSymbols(query string, opts code.SearchOptions) ([]*Cod
 
 	// Combine with OR (any match)
 	q := bleve.NewDisjunctionQuery(prefixQuery, wildcardQuery, sigQuery, docQuery)
+	return s.runSymbolSearch(q, opts, limit)
+}
+
+// searchQualifiedSymbols matches a qualified name against the qname field:
+// an exact hit or any symbol whose qualified name ends in ".<query>".
+func (s *CodeStore) searchQualifiedSymbols(query string, opts code.SearchOptions, limit int) ([]*CodeSearchResult, error) {
+	lowerQuery := strings.ToLower(code.NormalizeQualifiedName(query))
 
+	exactQuery := bleve.NewTermQuery(lowerQuery)
+	exactQuery.SetField("qname")
+	exactQuery.SetBoost(2)
+
+	suffixQuery := bleve.NewWildcardQuery("*." + lowerQuery)
+	suffixQuery.SetField("qname")
+
+	return s.runSymbolSearch(bleve.NewDisjunctionQuery(exactQuery, suffixQuery), opts, limit)
+}
+
+// runSymbolSearch executes a symbol query and applies the option filters.
+func (s *CodeStore) runSymbolSearch(q query.Query, opts code.SearchOptions, limit int) ([]*CodeSearchResult, error) {
 	// Create search request
 	searchReq := bleve.NewSearchRequest(q)
 	searchReq.Size = limit * 2 // Request more since we filter after
@@ -629,6 +701,9 @@ func (s *CodeStore) SearchReferences(opts code.ReferenceSearchOptions) ([]*code.
 			if opts.FilePath != "" && !strings.Contains(ref.FilePath, opts.FilePath) {
 				continue
 			}
+			if opts.TargetSymbolID != "" && ref.TargetSymbolID != opts.TargetSymbolID {
+				continue
+			}
 			refs = append(refs, &ref)
 		}
 
@@ -642,6 +717,216 @@ func (s *CodeStore) SearchReferences(opts code.ReferenceSearchOptions) ([]*code.
 	return refs, nil
 }
 
+// ResolveReferenceTargets sets TargetSymbolID and Confidence on call and
+// type references using resolve, with every same-named symbol in the index
+// as candidates. With files nil every reference is re-resolved; otherwise
+// only references in those files plus references to names those files
+// define (their symbol IDs change on every re-index). Returns the number of
+// references that ended up resolved among those visited.
+//
+// Resolution runs in read transactions, looking candidates up by name in the
+// search index for an incremental pass; only references whose target changed
+// are written back.
+//
+// References whose target was deleted without a re-resolve keep a dangling
+// TargetSymbolID; readers treat a target that no longer exists as
+// unresolved. ConfidenceExact targets (from a SCIP import) are kept while
+// the target symbol exists: resolve only ever guesses by name.
+func (s *CodeStore) ResolveReferenceTargets(files []string, resolve code.TargetResolver) (int, error) {
+	var changed map[string]bool
+	if files != nil {
+		changed = make(map[string]bool, len(files))
+		for _, f := range files {
+			changed[f] = true
+		}
+	}
+
+	var refs []*code.Reference
+	imports := make(map[string][]string)
+	byName := make(map[string][]*code.Symbol)
+	exact := make(map[string]bool)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		refBucket := tx.Bucket(BucketReferences)
+		byFile := tx.Bucket(BucketReferencesByFile)
+		symBucket := tx.Bucket(BucketSymbols)
+
+		// Reference IDs to visit.
+		var refIDs [][]byte
+		if changed == nil {
+			if err := refBucket.ForEach(func(k, _ []byte) error {
+				refIDs = append(refIDs, k)
+				return nil
+			}); err != nil {
+				return err
+			}
+			if err := symBucket.ForEach(func(_, v []byte) error {
+				var sym code.Symbol
+				if json.Unmarshal(v, &sym) == nil {
+					byName[sym.Name] = append(byName[sym.Name], &sym)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		} else {
+			// Names the changed files define.
+			changedNames := make(map[string]bool)
+			symsByFile := tx.Bucket(BucketSymbolsByFile)
+			for f := range changed {
+				prefix := fileKeyPrefix(f)
+				c := symsByFile.Cursor()
+				for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+					var sym code.Symbol
+					if data := symBucket.Get(k[len(prefix):]); data != nil && json.Unmarshal(data, &sym) == nil {
+						changedNames[sym.Name] = true
+					}
+				}
+			}
+			seen := make(map[string]bool)
+			scan := func(b *bolt.Bucket, prefix []byte) {
+				c := b.Cursor()
+				for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+					id := k[len(prefix):]
+					if !seen[string(id)] {
+						seen[string(id)] = true
+						refIDs = append(refIDs, id)
+					}
+				}
+			}
+			for f := range changed {
+				scan(byFile, fileKeyPrefix(f))
+			}
+			for name := range changedNames {
+				scan(tx.Bucket(BucketRefIndex), nameRefKeyPrefix(name))
+			}
+		}
+
+		for _, id := range refIDs {
+			var ref code.Reference
+			data := refBucket.Get(id)
+			if data == nil || json.Unmarshal(data, &ref) != nil || ref.Kind == code.RefKindImport {
+				continue
+			}
+			if ref.Confidence == code.ConfidenceExact && symBucket.Get([]byte(ref.TargetSymbolID)) != nil {
+				exact[ref.ID] = true
+			}
+			refs = append(refs, &ref)
+			if _, ok := imports[ref.FilePath]; ok {
+				continue
+			}
+			var imps []string
+			prefix := fileKeyPrefix(ref.FilePath)
+			c := byFile.Cursor()
+			for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+				var imp code.Reference
+				if data := refBucket.Get(k[len(prefix):]); data != nil && json.Unmarshal(data, &imp) == nil && imp.Kind == code.RefKindImport {
+					imps = append(imps, imp.SymbolName)
+				}
+			}
+			imports[ref.FilePath] = imps
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve reference targets: %w", err)
+	}
+
+	if changed != nil {
+		names := make(map[string]bool)
+		for _, ref := range refs {
+			if !exact[ref.ID] {
+				names[ref.SymbolName] = true
+			}
+		}
+		for name := range names {
+			syms, err := s.symbolsNamed(name)
+			if err != nil {
+				return 0, fmt.Errorf("failed to resolve reference targets: %w", err)
+			}
+			byName[name] = syms
+		}
+	}
+
+	resolved := 0
+	var updates []*code.Reference
+	for _, ref := range refs {
+		if exact[ref.ID] {
+			resolved++
+			continue
+		}
+		var target, confidence string
+		if candidates := byName[ref.SymbolName]; len(candidates) > 0 {
+			target, confidence = resolve(ref, imports[ref.FilePath], candidates)
+		}
+		if target != "" {
+			resolved++
+		}
+		if target == ref.TargetSymbolID && confidence == ref.Confidence {
+			continue
+		}
+		ref.TargetSymbolID, ref.Confidence = target, confidence
+		updates = append(updates, ref)
+	}
+	if len(updates) == 0 {
+		return resolved, nil
+	}
+
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		refBucket := tx.Bucket(BucketReferences)
+		for _, ref := range updates {
+			// Skip references replaced by a re-index since the read pass.
+			if refBucket.Get([]byte(ref.ID)) == nil {
+				continue
+			}
+			data, err := json.Marshal(ref)
+			if err != nil {
+				return err
+			}
+			if err := refBucket.Put([]byte(ref.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve reference targets: %w", err)
+	}
+	return resolved, nil
+}
+
+// symbolsNamed returns every symbol named exactly name, found through the
+// search index's name field and checked against the stored symbol.
+func (s *CodeStore) symbolsNamed(name string) ([]*code.Symbol, error) {
+	q := bleve.NewMatchQuery(name)
+	q.SetField("name")
+	q.SetOperator(query.MatchQueryOperatorAnd)
+	req := bleve.NewSearchRequest(q)
+	req.Size = 1000
+	res, err := s.search.Search(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Total > uint64(len(res.Hits)) {
+		req.Size = int(res.Total)
+		if res, err = s.search.Search(req); err != nil {
+			return nil, err
+		}
+	}
+
+	var syms []*code.Symbol
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(BucketSymbols)
+		for _, hit := range res.Hits {
+			var sym code.Symbol
+			if data := b.Get([]byte(hit.ID)); data != nil && json.Unmarshal(data, &sym) == nil && sym.Name == name {
+				syms = append(syms, &sym)
+			}
+		}
+		return nil
+	})
+	return syms, err
+}
+
 // GetFileReferences returns all references in a given file. Range-scans
 // BucketReferencesByFile by the filePath prefix in O(per-file).
 func (s *CodeStore) GetFileReferences(filePath string) ([]*code.Reference, error) {
@@ -801,20 +1086,160 @@ func (s *CodeStore) clearFileTx(tx *bolt.Tx, filePath string) ([]string, error)
 	}
 
 	symBucket := tx.Bucket(BucketSymbols)
+	vectors := tx.Bucket(BucketSymbolVectors)
 	for i, id := range matchingIDs {
 		if err := symBucket.Delete([]byte(id)); err != nil {
 			return nil, err
 		}
+		if err := vectors.Delete([]byte(id)); err != nil {
+			return nil, err
+		}
 		if err := byFile.Delete(byFileKeys[i]); err != nil {
 			return nil, err
 		}
 	}
+	if err := clearFileTypeEdgesTx(tx, filePath); err != nil {
+		return nil, err
+	}
+	if err := clearFileLSPCacheTx(tx, filePath); err != nil {
+		return nil, err
+	}
 	if err := tx.Bucket(BucketFileIndex).Delete([]byte(filePath)); err != nil {
 		return nil, err
 	}
 	return matchingIDs, nil
 }
 
+// GetFileTypeEdges returns the type-hierarchy edges declared in filePath.
+func (s *CodeStore) GetFileTypeEdges(filePath string) ([]*code.TypeEdge, error) {
+	var out []*code.TypeEdge
+	err := s.db.View(func(tx *bolt.Tx) error {
+		edges := tx.Bucket(BucketTypeEdges)
+		prefix := fileKeyPrefix(filePath)
+		c := tx.Bucket(BucketTypeEdgesByFile).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			var edge code.TypeEdge
+			if data := edges.Get(k[len(prefix):]); data != nil && json.Unmarshal(data, &edge) == nil {
+				out = append(out, &edge)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file type edges: %w", err)
+	}
+	return out, nil
+}
+
+// clearFileTypeEdgesTx removes every type-hierarchy edge declared in
+// filePath, inside an existing tx.
+func clearFileTypeEdgesTx(tx *bolt.Tx, filePath string) error {
+	prefix := fileKeyPrefix(filePath)
+	byFile := tx.Bucket(BucketTypeEdgesByFile)
+	edges := tx.Bucket(BucketTypeEdges)
+	c := byFile.Cursor()
+
+	var byFileKeys [][]byte
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		byFileKeys = append(byFileKeys, append([]byte(nil), k...))
+	}
+	for _, k := range byFileKeys {
+		if err := edges.Delete(k[len(prefix):]); err != nil {
+			return err
+		}
+		if err := byFile.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearFileLSPCacheTx removes every cached language-server result for
+// queries made against filePath, inside an existing tx. Other files' entries
+// are left to the mtime check in lsp.Manager, so a save does not throw away
+// the whole cache.
+func clearFileLSPCacheTx(tx *bolt.Tx, filePath string) error {
+	prefix := fileKeyPrefix(filePath)
+	b := tx.Bucket(BucketLSPCache)
+	c := b.Cursor()
+
+	var keys [][]byte
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		keys = append(keys, append([]byte(nil), k...))
+	}
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLSPCache returns the cached language-server result stored under key
+// for filePath, or nil when there is none.
+func (s *CodeStore) GetLSPCache(filePath, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(BucketLSPCache).Get(composeFileKey(filePath, key)); v != nil {
+			value = append([]byte(nil), v...)
+		}
+		return nil
+	})
+	return value, err
+}
+
+// PutLSPCache caches a language-server result under key for filePath.
+func (s *CodeStore) PutLSPCache(filePath, key string, value []byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(BucketLSPCache).Put(composeFileKey(filePath, key), value)
+	})
+}
+
+// GetFileHistory returns the stored symbol history of filePath, or nil when
+// none has been computed.
+func (s *CodeStore) GetFileHistory(filePath string) (*code.FileHistory, error) {
+	var h *code.FileHistory
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(BucketSymbolHistory).Get([]byte(filePath))
+		if data == nil {
+			return nil
+		}
+		h = &code.FileHistory{}
+		return json.Unmarshal(data, h)
+	})
+	return h, err
+}
+
+// PutFileHistory stores h as the symbol history of h.FilePath.
+func (s *CodeStore) PutFileHistory(h *code.FileHistory) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(BucketSymbolHistory).Put([]byte(h.FilePath), data)
+	})
+}
+
+// addTypeEdgeTx stores a type-hierarchy edge and its per-file index entry
+// inside an existing tx.
+func addTypeEdgeTx(tx *bolt.Tx, edge *code.TypeEdge) error {
+	if edge.ID == "" {
+		edge.ID = ulid.Make().String()
+	}
+	if edge.CreatedAt.IsZero() {
+		edge.CreatedAt = time.Now()
+	}
+	data, err := json.Marshal(edge)
+	if err != nil {
+		return err
+	}
+	if err := tx.Bucket(BucketTypeEdges).Put([]byte(edge.ID), data); err != nil {
+		return err
+	}
+	return tx.Bucket(BucketTypeEdgesByFile).Put(composeFileKey(edge.FilePath, edge.ID), nil)
+}
+
 // ClearFile removes all symbols for a file (in bbolt and Bleve) and its
 // FileInfo entry.
 func (s *CodeStore) ClearFile(filePath string) error {
@@ -840,7 +1265,7 @@ func (s *CodeStore) ClearFile(filePath string) error {
 }
 
 // IndexFileBatch performs all the per-file write work — optional clear,
-// symbol writes, reference writes, FileInfo update — inside a single bbolt
+// symbol, reference and type-edge writes, FileInfo update — inside a single bbolt
 // transaction, and applies the corresponding Bleve search updates as a
 // single batch after the bbolt commit.
 //
@@ -861,8 +1286,23 @@ func (s *CodeStore) IndexFileBatch(
 	filePath string,
 	symbols []*code.Symbol,
 	refs []*code.Reference,
+	edges []*code.TypeEdge,
 	mtime time.Time,
 	sizeBytes int64,
+) error {
+	return s.indexFileBatch(filePath, symbols, refs, edges, mtime, sizeBytes, s.symbolVectors(filePath, symbols))
+}
+
+// indexFileBatch is IndexFileBatch with the symbols' vector records already
+// computed.
+func (s *CodeStore) indexFileBatch(
+	filePath string,
+	symbols []*code.Symbol,
+	refs []*code.Reference,
+	edges []*code.TypeEdge,
+	mtime time.Time,
+	sizeBytes int64,
+	vectors map[*code.Symbol][]byte,
 ) error {
 	var clearedIDs []string
 	var symbolIDs []string
@@ -895,6 +1335,18 @@ func (s *CodeStore) IndexFileBatch(
 				return err
 			}
 		}
+		for _, edge := range edges {
+			edge.FilePath = filePath
+			if err := addTypeEdgeTx(tx, edge); err != nil {
+				return err
+			}
+		}
+		if err := putSymbolVectorsTx(tx, symbols, vectors); err != nil {
+			return err
+		}
+		if err := clearFileLSPCacheTx(tx, filePath); err != nil {
+			return err
+		}
 		return s.setFileInfoTx(tx, &code.FileInfo{
 			Path:      filePath,
 			ModTime:   mtime,
@@ -923,11 +1375,277 @@ func (s *CodeStore) IndexFileBatch(
 	return nil
 }
 
+// FileDelta reports what IndexFileDelta wrote for one file.
+type FileDelta struct {
+	SymbolsKept    int // Unchanged symbols left as they were
+	SymbolsWritten int // New or changed symbols written
+	SymbolsRemoved int // Symbols no longer in the file
+	RefsKept       int // Unchanged references (and their resolved targets) left as they were
+	RefsWritten    int
+	RefsRemoved    int
+}
+
+// IndexFileDelta is IndexFileBatch for a file that is already indexed: it
+// diffs the new records against the stored ones and only rewrites what
+// changed. A symbol identical to a stored one (same name, kind, container,
+// signature, doc, ranges and parent) is kept as is; one with the same
+// identity but a moved range or new body keeps its stored ID and is
+// rewritten, so references resolved to it stay valid; the rest are added or
+// removed. References at the same position with the same text keep their row
+// and resolved target. Type edges are always replaced. Files not yet indexed
+// take the IndexFileBatch path.
+//
+// Matched symbols and references take the stored IDs, so callers see the
+// IDs that ended up in the index.
+func (s *CodeStore) IndexFileDelta(
+	filePath string,
+	symbols []*code.Symbol,
+	refs []*code.Reference,
+	edges []*code.TypeEdge,
+	mtime time.Time,
+	sizeBytes int64,
+) (FileDelta, error) {
+	var delta FileDelta
+	var removedIDs []string
+	var written []*code.Symbol // Symbols whose search document changed
+	indexed := true
+	vectors := s.symbolVectors(filePath, symbols)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(BucketFileIndex).Get([]byte(filePath)) == nil {
+			indexed = false
+			return nil
+		}
+
+		oldSyms := loadFileRecordsTx[code.Symbol](tx, BucketSymbolsByFile, BucketSymbols, filePath)
+		oldByID := make(map[string]*code.Symbol, len(oldSyms))
+		for _, o := range oldSyms {
+			oldByID[o.ID] = o
+		}
+		kept, removed := matchSymbols(oldSyms, symbols)
+		symbolIDs := make([]string, 0, len(symbols))
+		for _, sym := range symbols {
+			sym.FilePath = filePath
+			symbolIDs = append(symbolIDs, sym.ID)
+			if kept[sym] {
+				delta.SymbolsKept++
+				continue
+			}
+			if err := s.addSymbolTx(tx, sym); err != nil {
+				return err
+			}
+			delta.SymbolsWritten++
+			// The search document has no positions, so a symbol that only
+			// moved or changed its body needs no Bleve update.
+			if o := oldByID[sym.ID]; o == nil || o.Signature != sym.Signature || o.DocComment != sym.DocComment {
+				written = append(written, sym)
+			}
+		}
+		symBucket := tx.Bucket(BucketSymbols)
+		byFile := tx.Bucket(BucketSymbolsByFile)
+		vecBucket := tx.Bucket(BucketSymbolVectors)
+		for _, old := range removed {
+			if err := symBucket.Delete([]byte(old.ID)); err != nil {
+				return err
+			}
+			if err := vecBucket.Delete([]byte(old.ID)); err != nil {
+				return err
+			}
+			if err := byFile.Delete(composeFileKey(filePath, old.ID)); err != nil {
+				return err
+			}
+			removedIDs = append(removedIDs, old.ID)
+			delta.SymbolsRemoved++
+		}
+
+		oldRefs := loadFileRecordsTx[code.Reference](tx, BucketReferencesByFile, BucketReferences, filePath)
+		byKey := make(map[string][]*code.Reference, len(oldRefs))
+		for _, ref := range oldRefs {
+			k := refKey(ref)
+			byKey[k] = append(byKey[k], ref)
+		}
+		for _, ref := range refs {
+			ref.FilePath = filePath
+			k := refKey(ref)
+			if olds := byKey[k]; len(olds) > 0 {
+				ref.ID, ref.TargetSymbolID, ref.Confidence, ref.CreatedAt = olds[0].ID, olds[0].TargetSymbolID, olds[0].Confidence, olds[0].CreatedAt
+				byKey[k] = olds[1:]
+				delta.RefsKept++
+				continue
+			}
+			if err := s.addReferenceTx(tx, ref); err != nil {
+				return err
+			}
+			delta.RefsWritten++
+		}
+		refBucket := tx.Bucket(BucketReferences)
+		refsByFile := tx.Bucket(BucketReferencesByFile)
+		refIdx := tx.Bucket(BucketRefIndex)
+		for _, olds := range byKey {
+			for _, old := range olds {
+				if err := refBucket.Delete([]byte(old.ID)); err != nil {
+					return err
+				}
+				if err := refsByFile.Delete(composeFileKey(filePath, old.ID)); err != nil {
+					return err
+				}
+				if err := refIdx.Delete(composeNameRefKey(old.SymbolName, old.ID)); err != nil {
+					return err
+				}
+				delta.RefsRemoved++
+			}
+		}
+
+		if err := clearFileTypeEdgesTx(tx, filePath); err != nil {
+			return err
+		}
+		for _, edge := range edges {
+			edge.FilePath = filePath
+			if err := addTypeEdgeTx(tx, edge); err != nil {
+				return err
+			}
+		}
+		if err := putSymbolVectorsTx(tx, symbols, vectors); err != nil {
+			return err
+		}
+		if err := clearFileLSPCacheTx(tx, filePath); err != nil {
+			return err
+		}
+		return s.setFileInfoTx(tx, &code.FileInfo{
+			Path:      filePath,
+			ModTime:   mtime,
+			SymbolIDs: symbolIDs,
+			Tokens:    code.EstimateTokensFromSize(filePath, sizeBytes),
+			SizeBytes: sizeBytes,
+		})
+	})
+	if err != nil {
+		return delta, fmt.Errorf("failed to index file %q: %w", filePath, err)
+	}
+	if !indexed {
+		if err := s.indexFileBatch(filePath, symbols, refs, edges, mtime, sizeBytes, vectors); err != nil {
+			return delta, err
+		}
+		return FileDelta{SymbolsWritten: len(symbols), RefsWritten: len(refs)}, nil
+	}
+
+	if s.search == nil || (len(removedIDs) == 0 && len(written) == 0) {
+		return delta, nil
+	}
+	batch := s.search.NewBatch()
+	for _, id := range removedIDs {
+		batch.Delete(id)
+	}
+	for _, sym := range written {
+		batch.Index(sym.ID, buildSymbolBleveDoc(sym))
+	}
+	if err := s.search.Batch(batch); err != nil {
+		return delta, fmt.Errorf("failed to apply bleve batch for %q: %w", filePath, err)
+	}
+	return delta, nil
+}
+
+// loadFileRecordsTx decodes every record a file-keyed index lists for
+// filePath from the primary bucket.
+func loadFileRecordsTx[T any](tx *bolt.Tx, byFileBucket, bucket []byte, filePath string) []*T {
+	var out []*T
+	primary := tx.Bucket(bucket)
+	prefix := fileKeyPrefix(filePath)
+	c := tx.Bucket(byFileBucket).Cursor()
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		var rec T
+		if data := primary.Get(k[len(prefix):]); data != nil && json.Unmarshal(data, &rec) == nil {
+			out = append(out, &rec)
+		}
+	}
+	return out
+}
+
+// symbolIdentity is what makes two parses of a file name the same symbol.
+func symbolIdentity(sym *code.Symbol) string {
+	return strings.Join([]string{sym.Kind, sym.Name, sym.Container, sym.QualifiedName, sym.Language}, "\x00")
+}
+
+// symbolContent extends symbolIdentity with everything else that is stored,
+// bar IDs and timestamps.
+func symbolContent(sym *code.Symbol) string {
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d:%d:%d:%d:%d:%t", symbolIdentity(sym), sym.Signature, sym.DocComment,
+		sym.StartLine, sym.EndLine, sym.BodyStartLine, sym.BodyEndLine, sym.Complexity, sym.Precise)
+}
+
+func refKey(ref *code.Reference) string {
+	return fmt.Sprintf("%s\x00%s\x00%d:%d\x00%s\x00%s", ref.Kind, ref.SymbolName, ref.Line, ref.Column, ref.Context, ref.Language)
+}
+
+// matchSymbols pairs new symbols with stored ones: exact content matches
+// first, then same identity in order. Matched new symbols take the stored
+// ID (and ParentIDs are remapped to follow); kept holds those whose stored
+// row, parent included, is still accurate. removed are the stored symbols
+// left unmatched.
+func matchSymbols(old, new []*code.Symbol) (kept map[*code.Symbol]bool, removed []*code.Symbol) {
+	kept = make(map[*code.Symbol]bool)
+	used := make(map[*code.Symbol]bool, len(old))
+	stored := make(map[*code.Symbol]*code.Symbol, len(new))
+	byContent := make(map[string][]*code.Symbol, len(old))
+	byIdentity := make(map[string][]*code.Symbol, len(old))
+	for _, o := range old {
+		byContent[symbolContent(o)] = append(byContent[symbolContent(o)], o)
+		byIdentity[symbolIdentity(o)] = append(byIdentity[symbolIdentity(o)], o)
+	}
+	claim := func(pool map[string][]*code.Symbol, key string) *code.Symbol {
+		for _, o := range pool[key] {
+			if !used[o] {
+				used[o] = true
+				return o
+			}
+		}
+		return nil
+	}
+	for _, n := range new {
+		if o := claim(byContent, symbolContent(n)); o != nil {
+			stored[n] = o
+		}
+	}
+	for _, n := range new {
+		if stored[n] == nil {
+			if o := claim(byIdentity, symbolIdentity(n)); o != nil {
+				stored[n] = o
+			}
+		}
+	}
+
+	remap := make(map[string]string, len(stored))
+	for n, o := range stored {
+		remap[n.ID] = o.ID
+	}
+	for _, n := range new {
+		if id, ok := remap[n.ParentID]; ok {
+			n.ParentID = id
+		}
+	}
+	for _, n := range new {
+		o := stored[n]
+		if o == nil {
+			continue
+		}
+		n.ID, n.CreatedAt = o.ID, o.CreatedAt
+		if symbolContent(n) == symbolContent(o) && n.ParentID == o.ParentID {
+			kept[n] = true
+		}
+	}
+	for _, o := range old {
+		if !used[o] {
+			removed = append(removed, o)
+		}
+	}
+	return kept, removed
+}
+
 // Clear removes all symbols, references, and file tracking data.
 func (s *CodeStore) Clear() error {
 	// Clear BBolt buckets
 	err := s.db.Update(func(tx *bolt.Tx) error {
-		for _, bucket := range [][]byte{BucketSymbols, BucketReferences, BucketFileIndex, BucketRefIndex, BucketSymbolsByFile, BucketReferencesByFile} {
+		for _, bucket := range [][]byte{BucketSymbols, BucketReferences, BucketFileIndex, BucketRefIndex, BucketSymbolsByFile, BucketReferencesByFile, BucketTypeEdges, BucketTypeEdgesByFile, BucketLSPCache, BucketSymbolHistory, BucketSymbolVectors} {
 			b := tx.Bucket(bucket)
 			c := b.Cursor()
 			for k, _ := c.First(); k != nil; k, _ = c.Next() {
@@ -975,6 +1693,91 @@ func (s *CodeStore) Clear() error {
 	return nil
 }
 
+// FindImplementations returns the types that extend, implement or embed
+// name (a bare or qualified type name; only its last segment is matched):
+// declared edges first, then Go types whose method sets satisfy the
+// interface structurally.
+func (s *CodeStore) FindImplementations(name string) ([]*code.TypeEdge, error) {
+	return s.typeHierarchy(code.QualifiedLeaf(name), true)
+}
+
+// FindSupertypes returns what name extends, implements or embeds: declared
+// edges first, then the Go interfaces its method set satisfies.
+func (s *CodeStore) FindSupertypes(name string) ([]*code.TypeEdge, error) {
+	return s.typeHierarchy(code.QualifiedLeaf(name), false)
+}
+
+// typeHierarchy collects name's subtypes (sub) or supertypes. Go symbols
+// are only loaded when structural matching can apply.
+func (s *CodeStore) typeHierarchy(name string, sub bool) ([]*code.TypeEdge, error) {
+	var declared, goEdges []*code.TypeEdge
+	var goSymbols []*code.Symbol
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(BucketTypeEdges).ForEach(func(_, v []byte) error {
+			var edge code.TypeEdge
+			if err := json.Unmarshal(v, &edge); err != nil {
+				return nil
+			}
+			if edge.Language == "go" {
+				goEdges = append(goEdges, &edge)
+			}
+			if edge.Kind == code.EdgeKindRequires {
+				return nil
+			}
+			if (sub && edge.SuperName == name) || (!sub && edge.TypeName == name) {
+				declared = append(declared, &edge)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(goEdges) == 0 {
+			return nil
+		}
+		return tx.Bucket(BucketSymbols).ForEach(func(_, v []byte) error {
+			var sym code.Symbol
+			if err := json.Unmarshal(v, &sym); err != nil || sym.Language != "go" {
+				return nil
+			}
+			switch sym.Kind {
+			case code.KindMethod, code.KindClass, code.KindInterface, code.KindType:
+				goSymbols = append(goSymbols, &sym)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read type hierarchy: %w", err)
+	}
+
+	sort.Slice(declared, func(i, j int) bool {
+		if declared[i].FilePath != declared[j].FilePath {
+			return declared[i].FilePath < declared[j].FilePath
+		}
+		return declared[i].Line < declared[j].Line
+	})
+	if len(goEdges) == 0 {
+		return declared, nil
+	}
+
+	var structural []*code.TypeEdge
+	if sub {
+		structural = code.StructuralImplementations(name, goEdges, goSymbols)
+	} else {
+		structural = code.StructuralSupertypes(name, goEdges, goSymbols)
+	}
+	seen := make(map[string]bool, len(declared))
+	for _, e := range declared {
+		seen[e.TypeName+"\x00"+e.SuperName] = true
+	}
+	for _, e := range structural {
+		if !seen[e.TypeName+"\x00"+e.SuperName] {
+			declared = append(declared, e)
+		}
+	}
+	return declared, nil
+}
+
 // Stats returns indexing statistics.
 func (s *CodeStore) Stats() (*code.IndexStats, error) {
 	stats := &code.IndexStats{}
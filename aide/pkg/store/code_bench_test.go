@@ -80,7 +80,7 @@ func benchIndexFiles(b *testing.B, cs *CodeStore, n int) []string {
 	mtime := time.Now()
 	for i := 0; i < n; i++ {
 		filePath, symbols, refs := benchFileBatch(i)
-		if err := cs.IndexFileBatch(filePath, symbols, refs, mtime, 2048); err != nil {
+		if err := cs.IndexFileBatch(filePath, symbols, refs, nil, mtime, 2048); err != nil {
 			b.Fatalf("preload IndexFileBatch %d: %v", i, err)
 		}
 		paths[i] = filePath
@@ -106,7 +106,7 @@ func BenchmarkCodeIndexFileBatch(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		filePath, symbols, refs := benchFileBatch(i)
-		if err := cs.IndexFileBatch(filePath, symbols, refs, mtime, 2048); err != nil {
+		if err := cs.IndexFileBatch(filePath, symbols, refs, nil, mtime, 2048); err != nil {
 			b.Fatalf("IndexFileBatch: %v", err)
 		}
 	}
@@ -115,6 +115,41 @@ func BenchmarkCodeIndexFileBatch(b *testing.B) {
 	b.ReportMetric(float64(benchRefsPerFile), "refs/op-file")
 }
 
+// BenchmarkCodeReindexFile measures re-indexing an already indexed file
+// after an edit that changed one symbol's body: a full IndexFileBatch
+// rewrite versus IndexFileDelta, which leaves the unchanged records alone.
+func BenchmarkCodeReindexFile(b *testing.B) {
+	edited := func() (string, []*code.Symbol, []*code.Reference) {
+		filePath, symbols, refs := benchFileBatch(0)
+		symbols[benchSymbolsPerFile/2].EndLine++
+		return filePath, symbols, refs
+	}
+	for _, mode := range []string{"Batch", "Delta"} {
+		b.Run(mode, func(b *testing.B) {
+			cs := setupBenchCodeStore(b)
+			benchIndexFiles(b, cs, 1)
+			mtime := time.Now()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				filePath, symbols, refs := benchFileBatch(0)
+				if i%2 == 0 {
+					filePath, symbols, refs = edited()
+				}
+				var err error
+				if mode == "Batch" {
+					err = cs.IndexFileBatch(filePath, symbols, refs, nil, mtime, 2048)
+				} else {
+					_, err = cs.IndexFileDelta(filePath, symbols, refs, nil, mtime, 2048)
+				}
+				if err != nil {
+					b.Fatalf("%s: %v", mode, err)
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkAddSymbol measures the ad-hoc single-symbol write path (own bbolt
 // transaction + own bleve index call per symbol). A fixed pool of symbols is
 // rotated so each op rewrites an existing record (AddSymbol reuses a non-empty
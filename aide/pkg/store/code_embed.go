@@ -0,0 +1,225 @@
+// Package store provides storage backends for aide.
+// This file implements the symbol embeddings behind semantic code search.
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/embed"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BucketSymbolVectors stores one embedding per symbol ID: an 8-byte hash of
+// the embedded text followed by the vector (embed.Encode). The hash lets a
+// re-index reuse the vector of a symbol whose text has not changed.
+var BucketSymbolVectors = []byte("symbol_vectors")
+
+// embedderMetaKey records, in BucketCodeMeta, the name of the embedder the
+// stored vectors came from.
+var embedderMetaKey = []byte("embedder")
+
+// ErrEmbeddingsDisabled is returned by semantic search when the store has
+// no embedder.
+var ErrEmbeddingsDisabled = errors.New("semantic search is disabled (code.embeddings is off)")
+
+// ErrEmbedderMismatch is returned by semantic search when the stored vectors
+// came from a different embedder than the store's, and so cannot be
+// compared with its query vectors until the index is rebuilt.
+var ErrEmbedderMismatch = errors.New("code vectors were built by a different embedder; re-index to search them")
+
+// CodeStoreOption configures a CodeStore.
+type CodeStoreOption func(*CodeStore)
+
+// WithEmbedder makes the store embed symbols as they are indexed, enabling
+// SemanticSearchSymbols. A nil embedder leaves semantic search off.
+func WithEmbedder(e embed.Embedder) CodeStoreOption {
+	return func(s *CodeStore) { s.embedder = e }
+}
+
+// ReconcileEmbedder discards the stored vectors when the embedder has
+// changed since they were written, and zeroes file mtimes so the next index
+// pass re-parses (and so re-embeds) every file. Only the process that
+// indexes the store (the daemon, `aide code index`) calls it; other openers
+// leave the vectors as they are.
+func (s *CodeStore) ReconcileEmbedder() error {
+	name := ""
+	if s.embedder != nil {
+		name = s.embedder.Name()
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(BucketCodeMeta)
+		if string(meta.Get(embedderMetaKey)) == name {
+			return nil
+		}
+		if err := tx.DeleteBucket(BucketSymbolVectors); err != nil && !errors.Is(err, bolt.ErrBucketNotFound) {
+			return err
+		}
+		if _, err := tx.CreateBucket(BucketSymbolVectors); err != nil {
+			return err
+		}
+		if k, _ := tx.Bucket(BucketFileIndex).Cursor().First(); name != "" && k != nil {
+			log.Printf("store: code embedder is now %s, files will be re-embedded on the next index pass", name)
+			if err := zeroFileModTimesTx(tx); err != nil {
+				return err
+			}
+		}
+		return meta.Put(embedderMetaKey, []byte(name))
+	})
+}
+
+// symbolVectors returns the vector record to store for each of symbols,
+// keyed by symbol. Records already stored for filePath are reused when a
+// symbol's text is unchanged; the rest are embedded. Returns nil without an
+// embedder, or when embedding fails (the failure is logged and the symbols
+// go without vectors until the file is next indexed).
+func (s *CodeStore) symbolVectors(filePath string, symbols []*code.Symbol) map[*code.Symbol][]byte {
+	if s.embedder == nil || len(symbols) == 0 {
+		return nil
+	}
+	stored := make(map[uint64][]byte)
+	_ = s.db.View(func(tx *bolt.Tx) error {
+		vectors := tx.Bucket(BucketSymbolVectors)
+		prefix := fileKeyPrefix(filePath)
+		c := tx.Bucket(BucketSymbolsByFile).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			if rec := vectors.Get(k[len(prefix):]); len(rec) > 8 {
+				stored[binary.LittleEndian.Uint64(rec)] = bytes.Clone(rec)
+			}
+		}
+		return nil
+	})
+
+	out := make(map[*code.Symbol][]byte, len(symbols))
+	var pending []*code.Symbol
+	var texts []string
+	var sums []uint64
+	for _, sym := range symbols {
+		text := embed.SymbolText(sym)
+		sum := textHash(text)
+		if rec, ok := stored[sum]; ok {
+			out[sym] = rec
+			continue
+		}
+		pending = append(pending, sym)
+		texts = append(texts, text)
+		sums = append(sums, sum)
+	}
+	if len(pending) == 0 {
+		return out
+	}
+	vecs, err := s.embedder.Embed(context.Background(), texts)
+	if err != nil {
+		log.Printf("store: embedding %d symbols of %s failed: %v", len(texts), filePath, err)
+		return nil
+	}
+	for i, sym := range pending {
+		rec := make([]byte, 8, 8+4*len(vecs[i]))
+		binary.LittleEndian.PutUint64(rec, sums[i])
+		out[sym] = append(rec, embed.Encode(vecs[i])...)
+	}
+	return out
+}
+
+func textHash(text string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(text))
+	return h.Sum64()
+}
+
+// putSymbolVectorsTx stores the vector records of symbols (by their final
+// IDs), skipping those already stored as is.
+func putSymbolVectorsTx(tx *bolt.Tx, symbols []*code.Symbol, records map[*code.Symbol][]byte) error {
+	if len(records) == 0 {
+		return nil
+	}
+	b := tx.Bucket(BucketSymbolVectors)
+	for _, sym := range symbols {
+		rec, ok := records[sym]
+		if !ok || bytes.Equal(b.Get([]byte(sym.ID)), rec) {
+			continue
+		}
+		if err := b.Put([]byte(sym.ID), rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SemanticSearchSymbols ranks symbols by the similarity of their embedded
+// signature, doc comment and body to query, applying the option filters.
+func (s *CodeStore) SemanticSearchSymbols(query string, opts code.SearchOptions) ([]*CodeSearchResult, error) {
+	if s.embedder == nil {
+		return nil, ErrEmbeddingsDisabled
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	vecs, err := s.embedder.Embed(context.Background(), []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	q := vecs[0]
+
+	type scored struct {
+		id    string
+		score float32
+	}
+	var hits []scored
+	var results []*CodeSearchResult
+	err = s.db.View(func(tx *bolt.Tx) error {
+		if stored := tx.Bucket(BucketCodeMeta).Get(embedderMetaKey); len(stored) > 0 && string(stored) != s.embedder.Name() {
+			return fmt.Errorf("%w (stored %s, configured %s)", ErrEmbedderMismatch, stored, s.embedder.Name())
+		}
+		err := tx.Bucket(BucketSymbolVectors).ForEach(func(k, v []byte) error {
+			if len(v) <= 8 {
+				return nil
+			}
+			if score := embed.Dot(q, embed.Decode(v[8:])); score > 0 {
+				hits = append(hits, scored{id: string(k), score: score})
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		sort.Slice(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+		symBucket := tx.Bucket(BucketSymbols)
+		for _, h := range hits {
+			if len(results) == limit {
+				break
+			}
+			var sym code.Symbol
+			data := symBucket.Get([]byte(h.id))
+			if data == nil || json.Unmarshal(data, &sym) != nil {
+				continue
+			}
+			if opts.Kind != "" && sym.Kind != opts.Kind {
+				continue
+			}
+			if opts.Language != "" && sym.Language != opts.Language {
+				continue
+			}
+			if opts.FilePath != "" && !strings.Contains(sym.FilePath, opts.FilePath) {
+				continue
+			}
+			results = append(results, &CodeSearchResult{Symbol: &sym, Score: float64(h.score)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/embed"
+	"github.com/oklog/ulid/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// countingEmbedder wraps the local embedder, counting the texts it embeds.
+type countingEmbedder struct {
+	name  string
+	texts int
+}
+
+func (c *countingEmbedder) Name() string { return c.name }
+
+func (c *countingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	c.texts += len(texts)
+	return embed.NewLocal().Embed(ctx, texts)
+}
+
+func openEmbedTestStore(t *testing.T, dir string, e embed.Embedder) *CodeStore {
+	t.Helper()
+	cs, err := NewCodeStore(filepath.Join(dir, "index.db"), filepath.Join(dir, "search.bleve"), WithEmbedder(e))
+	if err != nil {
+		t.Fatalf("failed to create code store: %v", err)
+	}
+	return cs
+}
+
+func embedTestSymbols() []*code.Symbol {
+	return []*code.Symbol{
+		{
+			ID: ulid.Make().String(), Name: "Do", Kind: code.KindMethod, Container: "Client", Language: "go",
+			FilePath: "pkg/httputil/retry.go", StartLine: 10, EndLine: 40,
+			Signature:  "func (c *Client) Do(req *http.Request) (*http.Response, error)",
+			DocComment: "Do sends the request, retrying transient failures with exponential backoff.",
+			Body:       "for attempt := 0; attempt <= c.maxRetries; attempt++ { resp, err := c.http.Do(req); if retryable(resp, err) { sleep(backoff(attempt)); continue } }",
+		},
+		{
+			ID: ulid.Make().String(), Name: "ParseConfig", Kind: code.KindFunction, Language: "go",
+			FilePath: "pkg/httputil/retry.go", StartLine: 50, EndLine: 70,
+			Signature:  "func ParseConfig(path string) (*Config, error)",
+			DocComment: "ParseConfig reads the YAML configuration file.",
+			Body:       "data, err := os.ReadFile(path); yaml.Unmarshal(data, &cfg)",
+		},
+	}
+}
+
+func vectorCount(t *testing.T, cs *CodeStore) int {
+	t.Helper()
+	n := 0
+	if err := cs.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(BucketSymbolVectors).Stats().KeyN
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestSemanticSearchSymbols(t *testing.T) {
+	e := &countingEmbedder{name: "counting"}
+	cs := openEmbedTestStore(t, t.TempDir(), e)
+	defer cs.Close()
+
+	if err := cs.IndexFileBatch("pkg/httputil/retry.go", embedTestSymbols(), nil, nil, time.Now(), 100); err != nil {
+		t.Fatalf("IndexFileBatch failed: %v", err)
+	}
+	if got := vectorCount(t, cs); got != 2 {
+		t.Fatalf("vectors = %d, want 2", got)
+	}
+
+	results, err := cs.SemanticSearchSymbols("where do we retry HTTP calls", code.SearchOptions{})
+	if err != nil {
+		t.Fatalf("SemanticSearchSymbols failed: %v", err)
+	}
+	if len(results) == 0 || results[0].Symbol.Name != "Do" {
+		t.Fatalf("top result = %+v, want Do", results)
+	}
+	if results[0].Symbol.Body != "" {
+		t.Error("symbol body should not be stored")
+	}
+
+	results, err = cs.SemanticSearchSymbols("retry http", code.SearchOptions{Kind: code.KindFunction})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Symbol.Kind != code.KindFunction {
+			t.Errorf("kind filter let through %s", r.Symbol.Kind)
+		}
+	}
+
+	// Re-indexing unchanged symbols (with new IDs) reuses their vectors.
+	before := e.texts
+	if err := cs.IndexFileBatch("pkg/httputil/retry.go", embedTestSymbols(), nil, nil, time.Now(), 100); err != nil {
+		t.Fatal(err)
+	}
+	if e.texts != before {
+		t.Errorf("re-index embedded %d texts, want 0", e.texts-before)
+	}
+	if got := vectorCount(t, cs); got != 2 {
+		t.Errorf("vectors after re-index = %d, want 2", got)
+	}
+
+	if err := cs.ClearFile("pkg/httputil/retry.go"); err != nil {
+		t.Fatal(err)
+	}
+	if got := vectorCount(t, cs); got != 0 {
+		t.Errorf("vectors after ClearFile = %d, want 0", got)
+	}
+}
+
+func TestSemanticSearchEmbedderChange(t *testing.T) {
+	dir := t.TempDir()
+	cs := openEmbedTestStore(t, dir, &countingEmbedder{name: "one"})
+	if err := cs.ReconcileEmbedder(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.IndexFileBatch("pkg/httputil/retry.go", embedTestSymbols(), nil, nil, time.Now(), 100); err != nil {
+		t.Fatal(err)
+	}
+	cs.Close()
+
+	// Reconciling with the same embedder keeps the vectors and file state.
+	cs = openEmbedTestStore(t, dir, &countingEmbedder{name: "one"})
+	if err := cs.ReconcileEmbedder(); err != nil {
+		t.Fatal(err)
+	}
+	if got := vectorCount(t, cs); got != 2 {
+		t.Errorf("vectors after reopen = %d, want 2", got)
+	}
+	cs.Close()
+
+	// A read-only opener with a different embedder leaves the store alone
+	// and refuses to compare its query vectors with the stored ones.
+	cs = openEmbedTestStore(t, dir, &countingEmbedder{name: "two"})
+	if got := vectorCount(t, cs); got != 2 {
+		t.Errorf("vectors after read-only open = %d, want 2", got)
+	}
+	if _, err := cs.SemanticSearchSymbols("retry", code.SearchOptions{}); !errors.Is(err, ErrEmbedderMismatch) {
+		t.Errorf("err = %v, want ErrEmbedderMismatch", err)
+	}
+	info, err := cs.GetFileInfo("pkg/httputil/retry.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.ModTime.IsZero() {
+		t.Error("file mtime zeroed by a read-only open")
+	}
+
+	// Reconciling with it discards them and forces a re-index.
+	if err := cs.ReconcileEmbedder(); err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+	if got := vectorCount(t, cs); got != 0 {
+		t.Errorf("vectors after embedder change = %d, want 0", got)
+	}
+	info, err = cs.GetFileInfo("pkg/httputil/retry.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime.IsZero() {
+		t.Errorf("file mtime = %v, want zero so the file is re-indexed", info.ModTime)
+	}
+	if _, err := cs.SemanticSearchSymbols("retry", code.SearchOptions{}); err != nil {
+		t.Errorf("search after reconcile: %v", err)
+	}
+}
+
+func TestSemanticSearchDisabled(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	if err := cs.IndexFileBatch("a.go", embedTestSymbols(), nil, nil, time.Now(), 100); err != nil {
+		t.Fatal(err)
+	}
+	if got := vectorCount(t, cs); got != 0 {
+		t.Errorf("vectors without an embedder = %d, want 0", got)
+	}
+	if _, err := cs.SemanticSearchSymbols("retry", code.SearchOptions{}); !errors.Is(err, ErrEmbeddingsDisabled) {
+		t.Errorf("err = %v, want ErrEmbeddingsDisabled", err)
+	}
+}
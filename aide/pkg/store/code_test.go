@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/oklog/ulid/v2"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -281,6 +283,54 @@ func TestSymbolSearch(t *testing.T) {
 	})
 }
 
+func TestSymbolSearchQualified(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	symbols := []*code.Symbol{
+		{Name: "Close", QualifiedName: "store.CodeStore.Close", Container: "CodeStore", Kind: code.KindMethod, FilePath: "store/code.go", Language: "go"},
+		{Name: "Close", QualifiedName: "store.Store.Close", Container: "Store", Kind: code.KindMethod, FilePath: "store/store.go", Language: "go"},
+		{Name: "Close", QualifiedName: "main.Handler.Close", Container: "Handler", Kind: code.KindMethod, FilePath: "main.go", Language: "go"},
+	}
+	for _, sym := range symbols {
+		if err := cs.AddSymbol(sym); err != nil {
+			t.Fatalf("AddSymbol(%s) failed: %v", sym.QualifiedName, err)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"store.CodeStore.Close", "store.CodeStore.Close"},
+		{"CodeStore.Close", "store.CodeStore.Close"},
+		{"codestore::close", "store.CodeStore.Close"},
+		{"Handler.Close", "main.Handler.Close"},
+	}
+	for _, tt := range tests {
+		results, err := cs.SearchSymbols(tt.query, code.SearchOptions{})
+		if err != nil {
+			t.Fatalf("SearchSymbols(%q) failed: %v", tt.query, err)
+		}
+		if len(results) != 1 || results[0].Symbol.QualifiedName != tt.want {
+			var got []string
+			for _, r := range results {
+				got = append(got, r.Symbol.QualifiedName)
+			}
+			t.Errorf("SearchSymbols(%q) = %v, want [%s]", tt.query, got, tt.want)
+		}
+	}
+
+	// "Store.Close" must not match CodeStore.Close as a substring.
+	results, err := cs.SearchSymbols("Store.Close", code.SearchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Symbol.QualifiedName != "store.Store.Close" {
+		t.Errorf("expected only store.Store.Close for Store.Close, got %d results", len(results))
+	}
+}
+
 // =============================================================================
 // Reference Operations
 // =============================================================================
@@ -474,6 +524,370 @@ func TestClearFileReferences(t *testing.T) {
 	}
 }
 
+func TestResolveReferenceTargets(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	fooA := &code.Symbol{Name: "foo", Kind: code.KindFunction, FilePath: "a.go", Language: "go"}
+	fooB := &code.Symbol{Name: "foo", Kind: code.KindFunction, FilePath: "b.go", Language: "go"}
+	fooUpper := &code.Symbol{Name: "Foo", Kind: code.KindFunction, FilePath: "b.go", Language: "go"}
+	for _, sym := range []*code.Symbol{fooA, fooB, fooUpper} {
+		if err := cs.AddSymbol(sym); err != nil {
+			t.Fatalf("AddSymbol failed: %v", err)
+		}
+	}
+	refs := []*code.Reference{
+		{SymbolName: "foo", Kind: code.RefKindCall, FilePath: "a.go", Line: 10, Language: "go"},
+		{SymbolName: "foo", Kind: code.RefKindCall, FilePath: "b.go", Line: 20, Language: "go"},
+		{SymbolName: "foo", Kind: code.RefKindCall, FilePath: "c.go", Line: 30, Language: "go"},
+		{SymbolName: "fmt", Kind: code.RefKindImport, FilePath: "a.go", Line: 1, Language: "go"},
+	}
+	for _, ref := range refs {
+		if err := cs.AddReference(ref); err != nil {
+			t.Fatalf("AddReference failed: %v", err)
+		}
+	}
+
+	// Resolve to the same-file definition only; c.go stays unresolved.
+	var sawImports []string
+	sameFile := func(ref *code.Reference, imports []string, candidates []*code.Symbol) (string, string) {
+		if ref.FilePath == "a.go" {
+			sawImports = imports
+		}
+		for _, c := range candidates {
+			if c.FilePath == ref.FilePath {
+				return c.ID, code.ConfidenceHigh
+			}
+		}
+		return "", ""
+	}
+
+	n, err := cs.ResolveReferenceTargets(nil, sameFile)
+	if err != nil {
+		t.Fatalf("ResolveReferenceTargets failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 resolved references, got %d", n)
+	}
+	if len(sawImports) != 1 || sawImports[0] != "fmt" {
+		t.Errorf("expected a.go imports [fmt], got %v", sawImports)
+	}
+
+	got, err := cs.SearchReferences(code.ReferenceSearchOptions{SymbolName: "foo", TargetSymbolID: fooA.ID})
+	if err != nil {
+		t.Fatalf("SearchReferences failed: %v", err)
+	}
+	if len(got) != 1 || got[0].FilePath != "a.go" || got[0].Confidence != code.ConfidenceHigh {
+		t.Errorf("expected the a.go reference resolved with high confidence, got %+v", got)
+	}
+
+	all, _ := cs.SearchReferences(code.ReferenceSearchOptions{SymbolName: "foo"})
+	for _, ref := range all {
+		if ref.FilePath == "c.go" && (ref.TargetSymbolID != "" || ref.Confidence != "") {
+			t.Errorf("expected c.go reference unresolved, got target %q (%s)", ref.TargetSymbolID, ref.Confidence)
+		}
+	}
+
+	// Re-resolving only b.go visits its own references and those naming
+	// symbols it defines — here every foo reference — with candidates looked
+	// up by exact name.
+	visited := 0
+	counting := func(ref *code.Reference, imports []string, candidates []*code.Symbol) (string, string) {
+		visited++
+		if len(candidates) != 2 {
+			t.Errorf("expected the 2 foo candidates, got %d", len(candidates))
+		}
+		for _, c := range candidates {
+			if c.Name != "foo" {
+				t.Errorf("unexpected candidate %q", c.Name)
+			}
+		}
+		return sameFile(ref, imports, candidates)
+	}
+	if _, err := cs.ResolveReferenceTargets([]string{"b.go"}, counting); err != nil {
+		t.Fatalf("ResolveReferenceTargets(b.go) failed: %v", err)
+	}
+	if visited != 3 {
+		t.Errorf("expected 3 references re-resolved for b.go, got %d", visited)
+	}
+}
+
+// TestResolveReferenceTargetsKeepsExact checks that exact (SCIP) targets are
+// left alone while their symbol exists and re-resolved once it is gone.
+func TestResolveReferenceTargetsKeepsExact(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	mtime := time.Now()
+	fooA := &code.Symbol{Name: "foo", Kind: code.KindFunction, FilePath: "a.go", Language: "go"}
+	fooB := &code.Symbol{Name: "foo", Kind: code.KindFunction, FilePath: "b.go", Language: "go", Precise: true}
+	if err := cs.IndexFileBatch("a.go", []*code.Symbol{fooA}, nil, nil, mtime, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.IndexFileBatch("b.go", []*code.Symbol{fooB}, nil, nil, mtime, 10); err != nil {
+		t.Fatal(err)
+	}
+	ref := &code.Reference{SymbolName: "foo", Kind: code.RefKindCall, Line: 3, Language: "go", TargetSymbolID: fooB.ID, Confidence: code.ConfidenceExact}
+	if err := cs.IndexFileBatch("a.go", []*code.Symbol{fooA}, []*code.Reference{ref}, nil, mtime, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	first := func(_ *code.Reference, _ []string, candidates []*code.Symbol) (string, string) {
+		calls++
+		return candidates[0].ID, code.ConfidenceLow
+	}
+	n, err := cs.ResolveReferenceTargets(nil, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || calls != 0 {
+		t.Errorf("resolved %d with %d resolver calls, want 1 kept without calling the resolver", n, calls)
+	}
+	refs, _ := cs.GetFileReferences("a.go")
+	if len(refs) != 1 || refs[0].TargetSymbolID != fooB.ID || refs[0].Confidence != code.ConfidenceExact {
+		t.Fatalf("exact target not kept: %+v", refs)
+	}
+
+	// Re-indexing b.go replaces fooB, so the exact target dangles and the
+	// resolver takes over.
+	if err := cs.IndexFileBatch("b.go", []*code.Symbol{{Name: "foo", Kind: code.KindFunction, Language: "go"}}, nil, nil, mtime, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cs.ResolveReferenceTargets([]string{"b.go"}, first); err != nil {
+		t.Fatal(err)
+	}
+	refs, _ = cs.GetFileReferences("a.go")
+	if calls != 1 || len(refs) != 1 || refs[0].Confidence != code.ConfidenceLow {
+		t.Errorf("dangling exact target not re-resolved: calls %d, refs %+v", calls, refs)
+	}
+}
+
+func TestIndexFileDelta(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	mtime := time.Now()
+	parse := func(runEnd int) ([]*code.Symbol, []*code.Reference) {
+		store := &code.Symbol{ID: ulid.Make().String(), Name: "Store", Kind: code.KindClass, StartLine: 1, EndLine: 3, Language: "go"}
+		return []*code.Symbol{
+			store,
+			{ID: ulid.Make().String(), Name: "Close", Kind: code.KindMethod, Container: "Store", ParentID: store.ID, StartLine: 5, EndLine: 7, Language: "go"},
+			{ID: ulid.Make().String(), Name: "run", Kind: code.KindFunction, StartLine: 9, EndLine: runEnd, Language: "go"},
+		}, []*code.Reference{
+			{SymbolName: "run", Kind: code.RefKindCall, Line: 6, Column: 1, Context: "run()", Language: "go"},
+		}
+	}
+
+	// First index: nothing stored yet, so everything is written.
+	syms, refs := parse(10)
+	delta, err := cs.IndexFileDelta("a.go", syms, refs, nil, mtime, 10)
+	if err != nil {
+		t.Fatalf("IndexFileDelta failed: %v", err)
+	}
+	if delta.SymbolsWritten != 3 || delta.RefsWritten != 1 {
+		t.Errorf("first delta = %+v", delta)
+	}
+	ids := map[string]string{}
+	for _, s := range syms {
+		ids[s.Name] = s.ID
+	}
+	if _, err := cs.ResolveReferenceTargets(nil, func(_ *code.Reference, _ []string, c []*code.Symbol) (string, string) {
+		return c[0].ID, code.ConfidenceHigh
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// run grew a line: Store and Close are kept, run keeps its ID but is
+	// rewritten, and the unchanged reference keeps its resolved target.
+	syms, refs = parse(12)
+	delta, err = cs.IndexFileDelta("a.go", syms, refs, nil, mtime, 12)
+	if err != nil {
+		t.Fatalf("IndexFileDelta failed: %v", err)
+	}
+	if delta.SymbolsKept != 2 || delta.SymbolsWritten != 1 || delta.SymbolsRemoved != 0 || delta.RefsKept != 1 {
+		t.Errorf("second delta = %+v", delta)
+	}
+	for _, s := range syms {
+		if s.ID != ids[s.Name] {
+			t.Errorf("%s ID changed from %s to %s", s.Name, ids[s.Name], s.ID)
+		}
+	}
+	if syms[1].ParentID != ids["Store"] {
+		t.Errorf("Close parent = %s, want stored Store ID %s", syms[1].ParentID, ids["Store"])
+	}
+	run, err := cs.GetSymbol(ids["run"])
+	if err != nil || run.EndLine != 12 {
+		t.Errorf("run not rewritten: %+v, %v", run, err)
+	}
+	got, _ := cs.GetFileReferences("a.go")
+	if len(got) != 1 || got[0].TargetSymbolID != ids["run"] {
+		t.Errorf("reference target lost: %+v", got)
+	}
+
+	// Dropping run removes it from bbolt and search.
+	delta, err = cs.IndexFileDelta("a.go", syms[:2], nil, nil, mtime, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delta.SymbolsRemoved != 1 || delta.RefsRemoved != 1 {
+		t.Errorf("third delta = %+v", delta)
+	}
+	if _, err := cs.GetSymbol(ids["run"]); err == nil {
+		t.Error("run still stored")
+	}
+	if res, _ := cs.SearchSymbols("run", code.SearchOptions{}); len(res) != 0 {
+		t.Errorf("run still searchable: %d results", len(res))
+	}
+	if info, _ := cs.GetFileInfo("a.go"); info == nil || len(info.SymbolIDs) != 2 {
+		t.Errorf("file info = %+v", info)
+	}
+}
+
+// =============================================================================
+// Type Hierarchy
+// =============================================================================
+
+func TestTypeHierarchy(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	mtime := time.Now()
+	index := func(file string, symbols []*code.Symbol, edges []*code.TypeEdge) {
+		t.Helper()
+		if err := cs.IndexFileBatch(file, symbols, nil, edges, mtime, 100); err != nil {
+			t.Fatalf("IndexFileBatch(%s) failed: %v", file, err)
+		}
+	}
+	index("io.go",
+		[]*code.Symbol{{Name: "Reader", Kind: code.KindInterface, StartLine: 3, Language: "go"}},
+		[]*code.TypeEdge{{Kind: code.EdgeKindRequires, TypeName: "Reader", SuperName: "Read", Line: 4, Language: "go"}})
+	index("file.go",
+		[]*code.Symbol{
+			{Name: "File", Kind: code.KindClass, StartLine: 5, Language: "go"},
+			{Name: "Read", Kind: code.KindMethod, Container: "File", StartLine: 9, Language: "go"},
+			{Name: "LogFile", Kind: code.KindClass, StartLine: 15, Language: "go"},
+		},
+		[]*code.TypeEdge{{Kind: code.EdgeKindEmbeds, TypeName: "LogFile", SuperName: "File", Line: 16, Language: "go"}})
+	index("Dog.java", nil, []*code.TypeEdge{
+		{Kind: code.EdgeKindExtends, TypeName: "Dog", SuperName: "Animal", Line: 2, Language: "java"},
+		{Kind: code.EdgeKindImplements, TypeName: "Dog", SuperName: "Pet", Line: 2, Language: "java"},
+	})
+
+	names := func(edges []*code.TypeEdge, sub bool) string {
+		var out []string
+		for _, e := range edges {
+			n := e.SuperName
+			if sub {
+				n = e.TypeName
+			}
+			if e.Structural {
+				n += "*"
+			}
+			out = append(out, e.Kind+":"+n)
+		}
+		return strings.Join(out, ",")
+	}
+
+	impls, err := cs.FindImplementations("io.Reader")
+	if err != nil {
+		t.Fatalf("FindImplementations failed: %v", err)
+	}
+	if got := names(impls, true); got != "implements:File*,implements:LogFile*" {
+		t.Errorf("FindImplementations(Reader) = %s", got)
+	}
+
+	subs, _ := cs.FindImplementations("File")
+	if got := names(subs, true); got != "embeds:LogFile" {
+		t.Errorf("FindImplementations(File) = %s", got)
+	}
+
+	supers, _ := cs.FindSupertypes("Dog")
+	if got := names(supers, false); got != "extends:Animal,implements:Pet" {
+		t.Errorf("FindSupertypes(Dog) = %s", got)
+	}
+
+	supers, _ = cs.FindSupertypes("LogFile")
+	if got := names(supers, false); got != "embeds:File,implements:Reader*" {
+		t.Errorf("FindSupertypes(LogFile) = %s", got)
+	}
+
+	// Re-indexing and clearing a file drop its edges.
+	index("Dog.java", nil, []*code.TypeEdge{
+		{Kind: code.EdgeKindExtends, TypeName: "Dog", SuperName: "Wolf", Line: 2, Language: "java"},
+	})
+	supers, _ = cs.FindSupertypes("Dog")
+	if got := names(supers, false); got != "extends:Wolf" {
+		t.Errorf("FindSupertypes(Dog) after re-index = %s", got)
+	}
+	if err := cs.ClearFile("Dog.java"); err != nil {
+		t.Fatalf("ClearFile failed: %v", err)
+	}
+	if supers, _ = cs.FindSupertypes("Dog"); len(supers) != 0 {
+		t.Errorf("expected no supertypes after ClearFile, got %s", names(supers, false))
+	}
+}
+
+// =============================================================================
+// LSP Cache
+// =============================================================================
+
+func TestLSPCache(t *testing.T) {
+	cs, cleanup := setupTestCodeStore(t)
+	defer cleanup()
+
+	if err := cs.IndexFileBatch("a.go", nil, nil, nil, time.Now(), 10); err != nil {
+		t.Fatalf("IndexFileBatch failed: %v", err)
+	}
+	if err := cs.PutLSPCache("a.go", "references\x003:5\x001", []byte(`{"sites":[]}`)); err != nil {
+		t.Fatalf("PutLSPCache failed: %v", err)
+	}
+	if err := cs.PutLSPCache("a.go.bak", "references\x003:5\x001", []byte(`{}`)); err != nil {
+		t.Fatalf("PutLSPCache failed: %v", err)
+	}
+
+	got, err := cs.GetLSPCache("a.go", "references\x003:5\x001")
+	if err != nil || string(got) != `{"sites":[]}` {
+		t.Fatalf("GetLSPCache = %q, %v", got, err)
+	}
+	if got, _ := cs.GetLSPCache("a.go", "definition\x003:5\x001"); got != nil {
+		t.Errorf("expected a miss for another key, got %q", got)
+	}
+
+	// Re-indexing a file drops its cached results, and only its own.
+	if err := cs.IndexFileBatch("a.go", nil, nil, nil, time.Now(), 12); err != nil {
+		t.Fatalf("IndexFileBatch failed: %v", err)
+	}
+	if got, _ := cs.GetLSPCache("a.go", "references\x003:5\x001"); got != nil {
+		t.Errorf("expected cache cleared on re-index, got %q", got)
+	}
+	if got, _ := cs.GetLSPCache("a.go.bak", "references\x003:5\x001"); got == nil {
+		t.Error("re-indexing a.go cleared another file's cache entry")
+	}
+
+	// Indexing another file leaves a.go's results to the mtime check on
+	// read rather than dropping the whole cache on every save.
+	if err := cs.PutLSPCache("a.go", "references\x003:5\x001", []byte(`{"sites":[]}`)); err != nil {
+		t.Fatalf("PutLSPCache failed: %v", err)
+	}
+	if err := cs.IndexFileBatch("b.go", nil, nil, nil, time.Now(), 10); err != nil {
+		t.Fatalf("IndexFileBatch failed: %v", err)
+	}
+	if _, err := cs.IndexFileDelta("b.go", nil, nil, nil, time.Now(), 11); err != nil {
+		t.Fatalf("IndexFileDelta failed: %v", err)
+	}
+	if got, _ := cs.GetLSPCache("a.go", "references\x003:5\x001"); got == nil {
+		t.Error("indexing b.go cleared a.go's cache entry")
+	}
+
+	if _, err := cs.IndexFileDelta("a.go", nil, nil, nil, time.Now(), 13); err != nil {
+		t.Fatalf("IndexFileDelta failed: %v", err)
+	}
+	if got, _ := cs.GetLSPCache("a.go", "references\x003:5\x001"); got != nil {
+		t.Errorf("expected cache cleared by IndexFileDelta, got %q", got)
+	}
+}
+
 // =============================================================================
 // File Info (Tracking)
 // =============================================================================
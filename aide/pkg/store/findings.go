@@ -1,6 +1,11 @@
 package store
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,11 +17,18 @@ import (
 	"github.com/blevesearch/bleve/v2/mapping"
 	"github.com/blevesearch/bleve/v2/search/query"
 	"github.com/jmylchreest/aide/aide/pkg/findings"
+	"github.com/oklog/ulid/v2"
+	bolt "go.etcd.io/bbolt"
 )
 
 var (
 	BucketFindings     = []byte("findings")
 	BucketFindingsMeta = []byte("findings_meta")
+
+	// BucketFindingsTriage holds the append-only triage history, keyed by
+	// `<fingerprint>\x00<eventULID>` so a prefix scan yields one
+	// fingerprint's events in chronological order.
+	BucketFindingsTriage = []byte("findings_triage")
 )
 
 // FindingsStoreImpl implements FindingsStore using BoltDB + Bleve,
@@ -118,9 +130,12 @@ func findingToSearchDoc(f *findings.Finding) map[string]interface{} {
 }
 
 // findingsMatchFn returns a predicate that filters findings by the given options.
-func findingsMatchFn(opts findings.SearchOptions) func(*findings.Finding) bool {
+// statuses supplies triage state by fingerprint; nil treats every finding as
+// untriaged.
+func findingsMatchFn(opts findings.SearchOptions, statuses map[string]*findings.TriageStatus) func(*findings.Finding) bool {
+	now := time.Now()
 	return func(f *findings.Finding) bool {
-		if !opts.IncludeAccepted && f.Accepted {
+		if !triageVisible(f, opts, statuses, now) {
 			return false
 		}
 		if opts.Analyzer != "" && f.Analyzer != opts.Analyzer {
@@ -139,6 +154,45 @@ func findingsMatchFn(opts findings.SearchOptions) func(*findings.Finding) bool {
 	}
 }
 
+// triageVisible applies the accepted/triage-state part of the filter. An
+// explicit State matches the effective state only; otherwise accepted,
+// snoozed, wont-fix and fixed findings are hidden unless IncludeAccepted.
+func triageVisible(f *findings.Finding, opts findings.SearchOptions, statuses map[string]*findings.TriageStatus, now time.Time) bool {
+	if opts.State == "" && opts.IncludeAccepted {
+		return true
+	}
+	state := effectiveTriageState(f, statuses, now)
+	if opts.State != "" {
+		return string(state) == opts.State
+	}
+	return !f.Accepted && !state.Hidden()
+}
+
+// effectiveTriageState resolves a finding's current state. Findings with no
+// triage history read as acknowledged when the legacy Accepted flag is set.
+func effectiveTriageState(f *findings.Finding, statuses map[string]*findings.TriageStatus, now time.Time) findings.TriageState {
+	if st, ok := statuses[f.Fingerprint()]; ok {
+		return st.Effective(now)
+	}
+	if f.Accepted {
+		return findings.TriageAcknowledged
+	}
+	return findings.TriageOpen
+}
+
+// matchFn loads triage statuses when the options need them and returns the
+// combined predicate.
+func (s *FindingsStoreImpl) matchFn(opts findings.SearchOptions) (func(*findings.Finding) bool, error) {
+	if opts.State == "" && opts.IncludeAccepted {
+		return findingsMatchFn(opts, nil), nil
+	}
+	statuses, err := s.TriageStatuses()
+	if err != nil {
+		return nil, err
+	}
+	return findingsMatchFn(opts, statuses), nil
+}
+
 // Close closes the findings store.
 func (s *FindingsStoreImpl) Close() error {
 	return s.searchableStore.Close()
@@ -189,13 +243,21 @@ func (s *FindingsStoreImpl) SearchFindings(queryStr string, opts findings.Search
 		return nil, err
 	}
 
+	var statuses map[string]*findings.TriageStatus
+	if opts.State != "" || !opts.IncludeAccepted {
+		if statuses, err = s.TriageStatuses(); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+
 	var results []*findings.SearchResult
 	for _, hit := range hits {
 		f, err := s.Get(hit.ID)
 		if err != nil {
 			continue
 		}
-		if !opts.IncludeAccepted && f.Accepted {
+		if !triageVisible(f, opts, statuses, now) {
 			continue
 		}
 		results = append(results, &findings.SearchResult{
@@ -208,7 +270,11 @@ func (s *FindingsStoreImpl) SearchFindings(queryStr string, opts findings.Search
 
 // ListFindings returns findings filtered by options (no full-text search).
 func (s *FindingsStoreImpl) ListFindings(opts findings.SearchOptions) ([]*findings.Finding, error) {
-	return s.list(findingsMatchFn(opts), opts.Limit, findings.DefaultListLimit)
+	match, err := s.matchFn(opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(match, opts.Limit, findings.DefaultListLimit)
 }
 
 // GetFileFindings returns all findings for a specific file.
@@ -216,6 +282,28 @@ func (s *FindingsStoreImpl) GetFileFindings(filePath string) ([]*findings.Findin
 	return s.ListFindings(findings.SearchOptions{FilePath: filePath, Limit: 1000})
 }
 
+// FileFindings returns every finding analyzer reported for exactly
+// filePath, accepted and triaged ones included. It looks the file up through
+// the search index's keyword fields instead of scanning the whole bucket, so
+// per-file runs stay cheap on large projects.
+func (s *FindingsStoreImpl) FileFindings(analyzer, filePath string) ([]*findings.Finding, error) {
+	aq := bleve.NewTermQuery(analyzer)
+	aq.SetField("analyzer")
+	fq := bleve.NewTermQuery(filePath)
+	fq.SetField("file")
+	hits, err := s.searchBleve("", []query.Query{aq, fq}, -1, 0)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*findings.Finding, 0, len(hits))
+	for _, hit := range hits {
+		if f, err := s.Get(hit.ID); err == nil && f.Analyzer == analyzer && f.FilePath == filePath {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
 // ClearAnalyzer removes all findings for a specific analyzer.
 func (s *FindingsStoreImpl) ClearAnalyzer(analyzer string) (int, error) {
 	return s.searchableStore.ClearAnalyzer(analyzer)
@@ -265,7 +353,11 @@ func (s *FindingsStoreImpl) AcceptFindingsByFilter(opts findings.SearchOptions)
 
 // Stats returns aggregate finding counts, optionally filtering by SearchOptions.
 func (s *FindingsStoreImpl) Stats(opts findings.SearchOptions) (*findings.Stats, error) {
-	all, err := s.allMatching(findingsMatchFn(opts))
+	match, err := s.matchFn(opts)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.allMatching(match)
 	if err != nil {
 		return nil, err
 	}
@@ -278,6 +370,12 @@ func (s *FindingsStoreImpl) Stats(opts findings.SearchOptions) (*findings.Stats,
 		stats.Total++
 		stats.ByAnalyzer[f.Analyzer]++
 		stats.BySeverity[f.Severity]++
+		if f.Analyzer == findings.AnalyzerCustom {
+			if stats.ByRule == nil {
+				stats.ByRule = make(map[string]int)
+			}
+			stats.ByRule[f.Metadata["rule_id"]]++
+		}
 	}
 	return stats, nil
 }
@@ -303,3 +401,189 @@ func (s *FindingsStoreImpl) ReplaceFindingsForAnalyzerAndFile(analyzer, filePath
 func (s *FindingsStoreImpl) Clear() error {
 	return s.searchableStore.Clear()
 }
+
+// TriageFinding appends ev to its fingerprint's history after checking the
+// transition against the current effective state. ID, From and CreatedAt are
+// filled in by the store, as is the finding snapshot when ev.FindingID still
+// resolves. When the fingerprint has no history and ev.FindingID names an
+// accepted finding, the starting state is acknowledged.
+func (s *FindingsStoreImpl) TriageFinding(ev *findings.TriageEvent) (*findings.TriageStatus, error) {
+	now := time.Now()
+	if ev.CreatedAt.IsZero() {
+		ev.CreatedAt = now
+	}
+	if err := ev.Validate(now); err != nil {
+		return nil, err
+	}
+
+	from := findings.TriageOpen
+	var status *findings.TriageStatus
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(BucketFindingsTriage)
+		if b == nil {
+			return fmt.Errorf("findings triage bucket not found")
+		}
+
+		history := triageHistory(b, ev.Fingerprint)
+		if len(history) > 0 {
+			status = foldTriage(history)
+			from = status.Effective(now)
+		}
+		if ev.FindingID != "" {
+			if data := tx.Bucket(BucketFindings).Get([]byte(ev.FindingID)); data != nil {
+				var f findings.Finding
+				if err := json.Unmarshal(data, &f); err == nil {
+					if ev.Title == "" {
+						ev.Describe(&f)
+					}
+					if len(history) == 0 && f.Accepted {
+						from = findings.TriageAcknowledged
+					}
+				}
+			}
+		}
+		if err := findings.ValidateTransition(from, ev.To); err != nil {
+			return err
+		}
+
+		ev.ID = ulid.Make().String()
+		ev.From = from
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		if status == nil {
+			status = &findings.TriageStatus{}
+		}
+		status.Apply(ev)
+		return b.Put(triageKey(ev.Fingerprint, ev.ID), data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ResolveTriageRef maps a finding ID or fingerprint to the fingerprint triage
+// is keyed by. The returned finding is nil when ref is a fingerprint whose
+// finding no longer exists but still has triage history (e.g. reopening a
+// fixed finding).
+func (s *FindingsStoreImpl) ResolveTriageRef(ref string) (string, *findings.Finding, error) {
+	if f, err := s.Get(ref); err == nil && f != nil {
+		return f.Fingerprint(), f, nil
+	}
+
+	matches, err := s.list(func(f *findings.Finding) bool { return f.Fingerprint() == ref }, 1, 1)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(matches) > 0 {
+		return ref, matches[0], nil
+	}
+
+	history, err := s.TriageHistory(ref)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(history) > 0 {
+		return ref, nil, nil
+	}
+	return "", nil, fmt.Errorf("no finding or fingerprint matches %q", ref)
+}
+
+// TriageHistory returns a fingerprint's triage events, oldest first.
+func (s *FindingsStoreImpl) TriageHistory(fingerprint string) ([]*findings.TriageEvent, error) {
+	var history []*findings.TriageEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(BucketFindingsTriage); b != nil {
+			history = triageHistory(b, fingerprint)
+		}
+		return nil
+	})
+	return history, err
+}
+
+// TriageStatuses folds the whole triage history into the current status per
+// fingerprint.
+func (s *FindingsStoreImpl) TriageStatuses() (map[string]*findings.TriageStatus, error) {
+	statuses := make(map[string]*findings.TriageStatus)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(BucketFindingsTriage)
+		if b == nil {
+			return nil
+		}
+		// Keys sort by fingerprint then ULID, so events arrive in order.
+		return b.ForEach(func(k, v []byte) error {
+			var ev findings.TriageEvent
+			if err := json.Unmarshal(v, &ev); err != nil {
+				log.Printf("findings: skipping malformed triage event %q: %v", k, err)
+				return nil
+			}
+			st, ok := statuses[ev.Fingerprint]
+			if !ok {
+				st = &findings.TriageStatus{}
+				statuses[ev.Fingerprint] = st
+			}
+			st.Apply(&ev)
+			return nil
+		})
+	})
+	return statuses, err
+}
+
+// ListTriageEvents returns triage events created at or after since, oldest
+// first. A non-empty to keeps only transitions into that state.
+func (s *FindingsStoreImpl) ListTriageEvents(since time.Time, to findings.TriageState) ([]*findings.TriageEvent, error) {
+	var events []*findings.TriageEvent
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(BucketFindingsTriage)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			var ev findings.TriageEvent
+			if err := json.Unmarshal(v, &ev); err != nil {
+				log.Printf("findings: skipping malformed triage event %q: %v", k, err)
+				return nil
+			}
+			if ev.CreatedAt.Before(since) || (to != "" && ev.To != to) {
+				return nil
+			}
+			events = append(events, &ev)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
+
+func triageKey(fingerprint, id string) []byte {
+	return []byte(fingerprint + "\x00" + id)
+}
+
+// triageHistory prefix-scans b for fingerprint's events.
+func triageHistory(b *bolt.Bucket, fingerprint string) []*findings.TriageEvent {
+	prefix := []byte(fingerprint + "\x00")
+	var history []*findings.TriageEvent
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var ev findings.TriageEvent
+		if err := json.Unmarshal(v, &ev); err != nil {
+			log.Printf("findings: skipping malformed triage event %q: %v", k, err)
+			continue
+		}
+		history = append(history, &ev)
+	}
+	return history
+}
+
+func foldTriage(history []*findings.TriageEvent) *findings.TriageStatus {
+	st := &findings.TriageStatus{}
+	for _, ev := range history {
+		st.Apply(ev)
+	}
+	return st
+}
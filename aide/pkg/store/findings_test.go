@@ -0,0 +1,212 @@
+package store
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmylchreest/aide/aide/pkg/findings"
+)
+
+func setupTestFindingsStore(t *testing.T) (*FindingsStoreImpl, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "aide-findings-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	fs, err := NewFindingsStore(tmpDir)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("failed to create findings store: %v", err)
+	}
+
+	cleanup := func() {
+		fs.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return fs, cleanup
+}
+
+func TestFindingsStore_TriageTransitions(t *testing.T) {
+	fs, cleanup := setupTestFindingsStore(t)
+	defer cleanup()
+
+	f := &findings.Finding{Analyzer: findings.AnalyzerComplexity, FilePath: "a.go", Line: 10, Title: "f has complexity 20"}
+	if err := fs.AddFinding(f); err != nil {
+		t.Fatalf("AddFinding: %v", err)
+	}
+	fp := f.Fingerprint()
+
+	st, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: fp, FindingID: f.ID, To: findings.TriageAcknowledged, Actor: "alice", Assignee: "bob"})
+	if err != nil {
+		t.Fatalf("acknowledge: %v", err)
+	}
+	if st.State != findings.TriageAcknowledged || st.Assignee != "bob" {
+		t.Errorf("unexpected status after acknowledge: %+v", st)
+	}
+
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: fp, To: findings.TriageWontFix, Reason: "generated"}); err != nil {
+		t.Fatalf("wont-fix: %v", err)
+	}
+	// Closed states can only be reopened.
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: fp, To: findings.TriageAcknowledged}); err == nil {
+		t.Error("expected wont-fix -> acknowledged to be rejected")
+	}
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: fp, To: findings.TriageOpen, Reason: "regressed"}); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+
+	history, err := fs.TriageHistory(fp)
+	if err != nil {
+		t.Fatalf("TriageHistory: %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history events, got %d", len(history))
+	}
+	wantFrom := []findings.TriageState{findings.TriageOpen, findings.TriageAcknowledged, findings.TriageWontFix}
+	for i, ev := range history {
+		if ev.From != wantFrom[i] {
+			t.Errorf("event %d: expected from %s, got %s", i, wantFrom[i], ev.From)
+		}
+	}
+}
+
+func TestFindingsStore_TriageSnooze(t *testing.T) {
+	fs, cleanup := setupTestFindingsStore(t)
+	defer cleanup()
+
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: "abc", To: findings.TriageSnoozed}); err == nil {
+		t.Error("expected snooze without expiry to be rejected")
+	}
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: "abc", To: findings.TriageSnoozed, Until: time.Now().Add(-time.Hour)}); err == nil {
+		t.Error("expected snooze in the past to be rejected")
+	}
+
+	st := &findings.TriageStatus{State: findings.TriageSnoozed, Until: time.Now().Add(-time.Minute)}
+	if got := st.Effective(time.Now()); got != findings.TriageOpen {
+		t.Errorf("expected expired snooze to read as open, got %s", got)
+	}
+}
+
+func TestFindingsStore_FileFindings(t *testing.T) {
+	fs, cleanup := setupTestFindingsStore(t)
+	defer cleanup()
+
+	for _, f := range []*findings.Finding{
+		{Analyzer: findings.AnalyzerTodos, FilePath: "pkg/a.go", Title: "mine", Accepted: true},
+		{Analyzer: findings.AnalyzerTodos, FilePath: "pkg/a.go.orig", Title: "longer path"},
+		{Analyzer: findings.AnalyzerTodos, FilePath: "a.go", Title: "suffix only"},
+		{Analyzer: findings.AnalyzerSecrets, FilePath: "pkg/a.go", Title: "other analyzer"},
+	} {
+		if err := fs.AddFinding(f); err != nil {
+			t.Fatalf("AddFinding: %v", err)
+		}
+	}
+
+	got, err := fs.FileFindings(findings.AnalyzerTodos, "pkg/a.go")
+	if err != nil {
+		t.Fatalf("FileFindings: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "mine" {
+		t.Errorf("FileFindings = %v, want only the exact file's todos finding", got)
+	}
+}
+
+func TestFindingsStore_ListFilterByState(t *testing.T) {
+	fs, cleanup := setupTestFindingsStore(t)
+	defer cleanup()
+
+	mk := func(title string, accepted bool) *findings.Finding {
+		f := &findings.Finding{Analyzer: findings.AnalyzerTodos, FilePath: "a.go", Title: title, Accepted: accepted}
+		if err := fs.AddFinding(f); err != nil {
+			t.Fatalf("AddFinding: %v", err)
+		}
+		return f
+	}
+	open := mk("open one", false)
+	snoozed := mk("snoozed one", false)
+	wontfix := mk("wontfix one", false)
+	acked := mk("acked one", false)
+	legacy := mk("legacy accepted", true)
+
+	triage := func(f *findings.Finding, to findings.TriageState, until time.Time) {
+		t.Helper()
+		if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: f.Fingerprint(), To: to, Until: until}); err != nil {
+			t.Fatalf("triage %s -> %s: %v", f.Title, to, err)
+		}
+	}
+	triage(snoozed, findings.TriageSnoozed, time.Now().Add(time.Hour))
+	triage(wontfix, findings.TriageWontFix, time.Time{})
+	triage(acked, findings.TriageAcknowledged, time.Time{})
+
+	titles := func(opts findings.SearchOptions) map[string]bool {
+		t.Helper()
+		ff, err := fs.ListFindings(opts)
+		if err != nil {
+			t.Fatalf("ListFindings: %v", err)
+		}
+		out := make(map[string]bool)
+		for _, f := range ff {
+			out[f.Title] = true
+		}
+		return out
+	}
+
+	def := titles(findings.SearchOptions{})
+	if !def[open.Title] || !def[acked.Title] || len(def) != 2 {
+		t.Errorf("default listing should show open and acknowledged only, got %v", def)
+	}
+	if all := titles(findings.SearchOptions{IncludeAccepted: true}); len(all) != 5 {
+		t.Errorf("expected all 5 with IncludeAccepted, got %v", all)
+	}
+	ackd := titles(findings.SearchOptions{State: string(findings.TriageAcknowledged)})
+	if !ackd[acked.Title] || !ackd[legacy.Title] || len(ackd) != 2 {
+		t.Errorf("acknowledged filter should include legacy accepted, got %v", ackd)
+	}
+	if sn := titles(findings.SearchOptions{State: string(findings.TriageSnoozed)}); !sn[snoozed.Title] || len(sn) != 1 {
+		t.Errorf("expected only snoozed finding, got %v", sn)
+	}
+
+	stats, err := fs.Stats(findings.SearchOptions{State: string(findings.TriageWontFix)})
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.Total != 1 {
+		t.Errorf("expected 1 wont-fix finding in stats, got %d", stats.Total)
+	}
+}
+
+func TestFindingsStore_ListTriageEvents(t *testing.T) {
+	fs, cleanup := setupTestFindingsStore(t)
+	defer cleanup()
+
+	for _, fp := range []string{"aaa", "bbb"} {
+		if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: fp, To: findings.TriageFixed, Actor: findings.FixDetectorActor, Commit: "abc"}); err != nil {
+			t.Fatalf("fixed %s: %v", fp, err)
+		}
+	}
+	if _, err := fs.TriageFinding(&findings.TriageEvent{Fingerprint: "bbb", To: findings.TriageOpen}); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+
+	fixed, err := fs.ListTriageEvents(time.Now().Add(-time.Hour), findings.TriageFixed)
+	if err != nil {
+		t.Fatalf("ListTriageEvents: %v", err)
+	}
+	if len(fixed) != 2 || fixed[0].Fingerprint != "aaa" || fixed[0].Commit != "abc" {
+		t.Errorf("expected 2 fixed events in order, got %+v", fixed)
+	}
+	all, err := fs.ListTriageEvents(time.Now().Add(-time.Hour), "")
+	if err != nil {
+		t.Fatalf("ListTriageEvents: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 events without a state filter, got %d", len(all))
+	}
+	if later, _ := fs.ListTriageEvents(time.Now().Add(time.Hour), ""); len(later) != 0 {
+		t.Errorf("expected no events after the window start, got %d", len(later))
+	}
+}
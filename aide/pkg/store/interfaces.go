@@ -119,8 +119,9 @@ type Store interface {
 
 // CodeIndexStore provides code symbol indexing and search.
 //
-// IndexFileBatch is the only path interface consumers should use to write
-// new symbols / references / file metadata — it commits all of a file's
+// IndexFileBatch (or IndexFileDelta, its diffing form for re-indexing) is
+// the only path interface consumers should use to write new symbols /
+// references / file metadata — it commits all of a file's
 // records in a single bbolt tx and a single Bleve batch. The per-record
 // AddSymbol / AddReference / SetFileInfo methods remain on the concrete
 // *CodeStore (for in-package tests with direct access) but are intentionally
@@ -131,15 +132,25 @@ type CodeIndexStore interface {
 	GetSymbol(id string) (*code.Symbol, error)
 	DeleteSymbol(id string) error
 	SearchSymbols(query string, opts code.SearchOptions) ([]*CodeSearchResult, error)
+	SemanticSearchSymbols(query string, opts code.SearchOptions) ([]*CodeSearchResult, error)
 	GetFileSymbols(filePath string) ([]*code.Symbol, error)
 	GetContainingSymbol(filePath string, line int) (*code.Symbol, error)
 	GetFileInfo(path string) (*code.FileInfo, error)
 	ListAllFileInfo() ([]*code.FileInfo, error)
 	ClearFile(filePath string) error
-	IndexFileBatch(filePath string, symbols []*code.Symbol, refs []*code.Reference, mtime time.Time, sizeBytes int64) error
+	IndexFileBatch(filePath string, symbols []*code.Symbol, refs []*code.Reference, edges []*code.TypeEdge, mtime time.Time, sizeBytes int64) error
+	IndexFileDelta(filePath string, symbols []*code.Symbol, refs []*code.Reference, edges []*code.TypeEdge, mtime time.Time, sizeBytes int64) (FileDelta, error)
 	SearchReferences(opts code.ReferenceSearchOptions) ([]*code.Reference, error)
 	GetFileReferences(filePath string) ([]*code.Reference, error)
 	ClearFileReferences(filePath string) error
+	ResolveReferenceTargets(files []string, resolve code.TargetResolver) (int, error)
+	GetFileTypeEdges(filePath string) ([]*code.TypeEdge, error)
+	FindImplementations(name string) ([]*code.TypeEdge, error)
+	FindSupertypes(name string) ([]*code.TypeEdge, error)
+	GetLSPCache(filePath, key string) ([]byte, error)
+	PutLSPCache(filePath, key string, value []byte) error
+	GetFileHistory(filePath string) (*code.FileHistory, error)
+	PutFileHistory(h *code.FileHistory) error
 	TopReferencedSymbols(limit int, kind string) ([]*code.SymbolRefCount, error)
 	ListAllSymbols(limit int) ([]*code.Symbol, error)
 	ListAllReferences(limit int) ([]*code.Reference, error)
@@ -180,6 +191,22 @@ type FindingsStore interface {
 
 var _ FindingsStore = (*FindingsStoreImpl)(nil)
 
+// FindingsTriageStore is the triage-history surface of a findings store. It
+// is kept separate from FindingsStore; both the local store and the gRPC
+// FindingsAdapter implement it.
+type FindingsTriageStore interface {
+	ResolveTriageRef(ref string) (string, *findings.Finding, error)
+	TriageFinding(ev *findings.TriageEvent) (*findings.TriageStatus, error)
+	TriageHistory(fingerprint string) ([]*findings.TriageEvent, error)
+	TriageStatuses() (map[string]*findings.TriageStatus, error)
+	ListTriageEvents(since time.Time, to findings.TriageState) ([]*findings.TriageEvent, error)
+}
+
+var _ FindingsTriageStore = (*FindingsStoreImpl)(nil)
+
+// The findings runner records fixed/regressed transitions through it.
+var _ findings.FixTracker = (*FindingsStoreImpl)(nil)
+
 // SurveyStore manages codebase survey entries in a separate database.
 type SurveyStore interface {
 	AddEntry(e *survey.Entry) error
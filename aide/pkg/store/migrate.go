@@ -8,8 +8,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/jmylchreest/aide/aide/pkg/code"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -19,11 +21,11 @@ var SchemaVersion uint64 = 1
 
 // CodeSchemaVersion is the current schema version for the code store.
 // Increment this when adding new migrations to the codeMigrations slice.
-var CodeSchemaVersion uint64 = 2
+var CodeSchemaVersion uint64 = 4
 
 // FindingsSchemaVersion is the current schema version for the findings store.
 // Increment this when adding new migrations to the findingsMigrations slice.
-var FindingsSchemaVersion uint64 = 1
+var FindingsSchemaVersion uint64 = 2
 
 // SurveySchemaVersion is the current schema version for the survey store.
 // Increment this when adding new migrations to the surveyMigrations slice.
@@ -45,6 +47,8 @@ var migrations = []migration{
 var codeMigrations = []migration{
 	{version: 1, description: "baseline code schema stamp", migrate: func(tx *bolt.Tx) error { return nil }},
 	{version: 2, description: "backfill BucketSymbolsByFile / BucketReferencesByFile and convert BucketRefIndex to composite keys", migrate: migrateCodeV2},
+	{version: 3, description: "reset file mtimes so symbols are re-parsed with containers and qualified names", migrate: migrateCodeV3},
+	{version: 4, description: "add type-hierarchy edge buckets and reset file mtimes so edges are extracted", migrate: migrateCodeV4},
 }
 
 // migrateCodeV2 backfills the file-keyed secondary indexes from existing
@@ -145,9 +149,72 @@ func migrateCodeV2(tx *bolt.Tx) error {
 	return nil
 }
 
+// migrateCodeV3 zeroes the recorded mtime of every indexed file. Symbols
+// written before v3 carry no Container / QualifiedName / ParentID; with the
+// mtime cleared the next index pass treats every file as changed and
+// re-parses it, while the file entries stay in place so the old symbols are
+// cleared rather than orphaned.
+func migrateCodeV3(tx *bolt.Tx) error {
+	return zeroFileModTimesTx(tx)
+}
+
+// zeroFileModTimesTx zeroes the recorded mtime of every indexed file, so
+// the next index pass re-parses them all.
+func zeroFileModTimesTx(tx *bolt.Tx) error {
+	b := tx.Bucket(BucketFileIndex)
+	if b == nil {
+		return nil
+	}
+	updates := make(map[string][]byte)
+	if err := b.ForEach(func(k, v []byte) error {
+		var info code.FileInfo
+		if err := json.Unmarshal(v, &info); err != nil {
+			return nil
+		}
+		info.ModTime = time.Time{}
+		data, err := json.Marshal(&info)
+		if err != nil {
+			return err
+		}
+		updates[string(k)] = data
+		return nil
+	}); err != nil {
+		return fmt.Errorf("reset file mtimes: %w", err)
+	}
+	for k, data := range updates {
+		if err := b.Put([]byte(k), data); err != nil {
+			return fmt.Errorf("reset file mtime %q: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// migrateCodeV4 creates the type-hierarchy edge buckets and, as in v3,
+// zeroes file mtimes so the next index pass extracts edges for files
+// indexed before hierarchy queries existed.
+func migrateCodeV4(tx *bolt.Tx) error {
+	for _, name := range [][]byte{BucketTypeEdges, BucketTypeEdgesByFile} {
+		if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+			return fmt.Errorf("create bucket %s: %w", name, err)
+		}
+	}
+	return migrateCodeV3(tx)
+}
+
 // findingsMigrations is the ordered list of all findings store schema migrations.
 var findingsMigrations = []migration{
 	{version: 1, description: "baseline findings schema stamp", migrate: func(tx *bolt.Tx) error { return nil }},
+	{version: 2, description: "create BucketFindingsTriage for append-only triage history", migrate: migrateFindingsV2},
+}
+
+// migrateFindingsV2 creates the triage history bucket. Existing findings need
+// no backfill: a fingerprint with no history reads as open (or acknowledged
+// when the legacy Accepted flag is set).
+func migrateFindingsV2(tx *bolt.Tx) error {
+	if _, err := tx.CreateBucketIfNotExists(BucketFindingsTriage); err != nil {
+		return fmt.Errorf("create bucket %q: %w", string(BucketFindingsTriage), err)
+	}
+	return nil
 }
 
 // surveyMigrations is the ordered list of all survey store schema migrations.
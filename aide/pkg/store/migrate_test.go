@@ -7,10 +7,12 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/blevesearch/bleve/v2"
 	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
 	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/jmylchreest/aide/aide/pkg/code"
 	bolt "go.etcd.io/bbolt"
 )
 
@@ -900,6 +902,83 @@ func TestRunCodeMigrations_V2BackfillsByFileAndCompositeRefIndex(t *testing.T) {
 	}
 }
 
+func TestRunCodeMigrations_V3ResetsFileMtimes(t *testing.T) {
+	db, cleanup := setupCodeMigrateTestDB(t)
+	defer cleanup()
+
+	writeCodeSchemaVersion(t, db, 2)
+
+	mtime := time.Now().Truncate(time.Second)
+	err := db.Update(func(tx *bolt.Tx) error {
+		data, _ := json.Marshal(&code.FileInfo{Path: "a.go", ModTime: mtime, SymbolIDs: []string{"sym-A"}})
+		return tx.Bucket(BucketFileIndex).Put([]byte("a.go"), data)
+	})
+	if err != nil {
+		t.Fatalf("seed v2 data: %v", err)
+	}
+
+	if err := RunCodeMigrations(db); err != nil {
+		t.Fatalf("RunCodeMigrations: %v", err)
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		var info code.FileInfo
+		if err := json.Unmarshal(tx.Bucket(BucketFileIndex).Get([]byte("a.go")), &info); err != nil {
+			return err
+		}
+		if !info.ModTime.IsZero() || len(info.SymbolIDs) != 1 {
+			return fmt.Errorf("expected zero mtime with symbol IDs kept, got %+v", info)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("verify v3 layout: %v", err)
+	}
+}
+
+func TestRunCodeMigrations_V4AddsTypeEdgeBuckets(t *testing.T) {
+	db, cleanup := setupCodeMigrateTestDB(t)
+	defer cleanup()
+
+	writeCodeSchemaVersion(t, db, 3)
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{BucketTypeEdges, BucketTypeEdgesByFile} {
+			if tx.Bucket(name) != nil {
+				if err := tx.DeleteBucket(name); err != nil {
+					return err
+				}
+			}
+		}
+		data, _ := json.Marshal(&code.FileInfo{Path: "a.go", ModTime: time.Now()})
+		return tx.Bucket(BucketFileIndex).Put([]byte("a.go"), data)
+	})
+	if err != nil {
+		t.Fatalf("seed v3 data: %v", err)
+	}
+
+	if err := RunCodeMigrations(db); err != nil {
+		t.Fatalf("RunCodeMigrations: %v", err)
+	}
+
+	err = db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(BucketTypeEdges) == nil || tx.Bucket(BucketTypeEdgesByFile) == nil {
+			return fmt.Errorf("type edge buckets not created")
+		}
+		var info code.FileInfo
+		if err := json.Unmarshal(tx.Bucket(BucketFileIndex).Get([]byte("a.go")), &info); err != nil {
+			return err
+		}
+		if !info.ModTime.IsZero() {
+			return fmt.Errorf("expected zero mtime, got %v", info.ModTime)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("verify v4 layout: %v", err)
+	}
+}
+
 func TestSurveyStoreRunsMigrationsOnOpen(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "aide-survey-migrate-open-*")
 	if err != nil {
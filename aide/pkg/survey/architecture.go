@@ -0,0 +1,660 @@
+// Package survey: architecture.go infers architectural patterns — layering,
+// ports and adapters, CQRS, MVC, plugin registries, vertical slices — from
+// directory conventions, the direction of resolved imports between them, and
+// the module clusters. A convention alone is a claim; imports that respect
+// it are the evidence, and imports that break it lower the confidence.
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
+)
+
+// Architecture analyzer guards.
+const (
+	// MinArchPatternConfidence is the lowest confidence stored as an entry.
+	MinArchPatternConfidence = 0.5
+	// MaxArchPatternEvidence caps the evidence files stored per entry.
+	MaxArchPatternEvidence = 10
+	// minRegistryCallers is how many files must call a registration
+	// function before it counts as a plugin registry.
+	minRegistryCallers = 3
+	// maxRegistries caps the plugin-registry entries per run.
+	maxRegistries = 5
+)
+
+// Pattern names, recorded in the "pattern" metadata key.
+const (
+	PatternLayered        = "layered"
+	PatternHexagonal      = "hexagonal"
+	PatternCQRS           = "cqrs"
+	PatternMVC            = "mvc"
+	PatternPluginRegistry = "plugin_registry"
+	PatternVerticalSlices = "vertical_slices"
+)
+
+// Layer roles, ordered top (entry) to bottom (depended upon).
+const (
+	roleHandler    = "handler"
+	roleService    = "service"
+	roleRepository = "repository"
+	roleDomain     = "domain"
+)
+
+var layerRank = map[string]int{roleHandler: 0, roleService: 1, roleRepository: 2, roleDomain: 3}
+
+// Role vocabularies: a path word (directory or file-name token) maps to the
+// role it conventionally names. Each classifier has its own vocabulary so a
+// file can be a repository (layering) and an adapter (hexagonal) at once.
+var (
+	layerWords = roleVocabulary(map[string][]string{
+		roleHandler:    {"handler", "handlers", "controller", "controllers", "route", "routes", "router", "routers", "endpoint", "endpoints", "transport", "presentation"},
+		roleService:    {"service", "services", "usecase", "usecases", "interactor", "interactors", "application"},
+		roleRepository: {"repository", "repositories", "repo", "repos", "dao", "daos", "persistence", "store", "stores", "storage", "db", "database", "dal"},
+		roleDomain:     {"domain", "entity", "entities", "model", "models"},
+	})
+	hexWords = roleVocabulary(map[string][]string{
+		"port":    {"port", "ports"},
+		"adapter": {"adapter", "adapters", "infrastructure", "infra"},
+		"core":    {"domain", "core", "application", "usecase", "usecases"},
+	})
+	cqrsWords = roleVocabulary(map[string][]string{
+		"command": {"command", "commands"},
+		"query":   {"query", "queries"},
+	})
+	mvcWords = roleVocabulary(map[string][]string{
+		"controller": {"controller", "controllers"},
+		"model":      {"model", "models"},
+	})
+)
+
+func roleVocabulary(roles map[string][]string) map[string]string {
+	out := make(map[string]string)
+	for role, words := range roles {
+		for _, w := range words {
+			out[w] = role
+		}
+	}
+	return out
+}
+
+// registryFunc matches registration entry points: Register, MustRegister,
+// RegisterHandler, registerPlugin, AddPlugin, addExtension.
+var registryFunc = regexp.MustCompile(`^((Must)?Register|register)([A-Z_]\w*)?$|^[Aa]dd(Plugin|Extension|Provider)s?$`)
+
+// ArchitectureConfig configures an architecture run.
+type ArchitectureConfig struct {
+	RootDir  string
+	Source   ModulesSource
+	Resolver *importresolve.Resolver // nil = constructed from RootDir
+	Modules  []*Entry                // modules analyzer entries; nil = no cluster evidence
+}
+
+// ArchitectureResult is the outcome of an architecture run.
+type ArchitectureResult struct {
+	Entries    []*Entry
+	Files      int // non-test source files considered
+	Classified int // files with a layer role
+}
+
+// roleGraph records which files play each role under one classifier, and
+// which files import across roles.
+type roleGraph struct {
+	classify func(string) string
+	files    map[string][]string           // role -> files
+	edges    map[[2]string]map[string]bool // {from role, to role} -> importing files
+}
+
+func newRoleGraph(vocab map[string]string) *roleGraph {
+	return &roleGraph{
+		classify: func(p string) string { return pathRole(p, vocab) },
+		files:    make(map[string][]string),
+		edges:    make(map[[2]string]map[string]bool),
+	}
+}
+
+func (g *roleGraph) addImport(from, to string) {
+	rf, rt := g.classify(from), g.classify(to)
+	if rf == "" || rt == "" || rf == rt {
+		return
+	}
+	key := [2]string{rf, rt}
+	if g.edges[key] == nil {
+		g.edges[key] = make(map[string]bool)
+	}
+	g.edges[key][from] = true
+}
+
+// count returns the number of files importing from role a into role b.
+func (g *roleGraph) count(a, b string) int { return len(g.edges[[2]string{a, b}]) }
+
+// importers returns the sorted files importing from role a into role b.
+func (g *roleGraph) importers(a, b string) []string {
+	files := make([]string, 0, len(g.edges[[2]string{a, b}]))
+	for f := range g.edges[[2]string{a, b}] {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// RunArchitecture classifies source files by path convention, measures the
+// direction of resolved imports between the classes, and emits one
+// KindArchPattern entry per pattern whose confidence reaches
+// MinArchPatternConfidence.
+func RunArchitecture(cfg ArchitectureConfig) (*ArchitectureResult, error) {
+	if cfg.Source == nil {
+		return nil, fmt.Errorf("architecture analyzer requires the code index")
+	}
+	resolver := cfg.Resolver
+	if resolver == nil {
+		resolver = importresolve.New(cfg.RootDir)
+	}
+	all, err := cfg.Source.ListSourceFiles()
+	if err != nil {
+		return nil, fmt.Errorf("list source files: %w", err)
+	}
+	var files []ModuleFile
+	for _, f := range all {
+		if !isTestPath(f.Path) {
+			files = append(files, f)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	layers, hex, cqrs, mvc := newRoleGraph(layerWords), newRoleGraph(hexWords), newRoleGraph(cqrsWords), newRoleGraph(mvcWords)
+	graphs := []*roleGraph{layers, hex, cqrs, mvc}
+	result := &ArchitectureResult{Files: len(files)}
+	for _, f := range files {
+		for _, g := range graphs {
+			if role := g.classify(f.Path); role != "" {
+				g.files[role] = append(g.files[role], f.Path)
+			}
+		}
+	}
+	for _, fs := range layers.files {
+		result.Classified += len(fs)
+	}
+
+	// Registration calls: name -> calling files.
+	registryCalls := make(map[string]map[string]bool)
+	typeRefs := make(map[string]map[string]bool) // "Command"/"Query" -> referenced names
+	for _, f := range files {
+		refs, rerr := cfg.Source.FileReferences(f.Path)
+		if rerr != nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, ref := range refs {
+			switch {
+			case ref.Kind == "import":
+				for _, target := range resolver.ResolveFiles(f.Language, f.Path, ref.Symbol) {
+					if target == f.Path || seen[target] {
+						continue
+					}
+					seen[target] = true
+					for _, g := range graphs {
+						g.addImport(f.Path, target)
+					}
+				}
+			case ref.Kind == "call" && registryFunc.MatchString(ref.Symbol):
+				if registryCalls[ref.Symbol] == nil {
+					registryCalls[ref.Symbol] = make(map[string]bool)
+				}
+				registryCalls[ref.Symbol][f.Path] = true
+			default:
+				for _, suffix := range []string{"Command", "Query"} {
+					if strings.HasSuffix(ref.Symbol, suffix) && len(ref.Symbol) > len(suffix) {
+						if typeRefs[suffix] == nil {
+							typeRefs[suffix] = make(map[string]bool)
+						}
+						typeRefs[suffix][ref.Symbol] = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, detect := range []func() *Entry{
+		func() *Entry { return detectLayered(layers, cfg.Modules) },
+		func() *Entry { return detectHexagonal(hex) },
+		func() *Entry { return detectCQRS(cqrs, typeRefs) },
+		func() *Entry { return detectMVC(mvc, cfg.RootDir) },
+		func() *Entry { return detectVerticalSlices(cfg.Modules) },
+	} {
+		if e := detect(); e != nil {
+			result.Entries = append(result.Entries, e)
+		}
+	}
+	result.Entries = append(result.Entries, detectRegistries(registryCalls, cfg.Source)...)
+
+	sort.SliceStable(result.Entries, func(i, j int) bool {
+		return result.Entries[i].Metadata["confidence"] > result.Entries[j].Metadata["confidence"]
+	})
+	return result, nil
+}
+
+// isTestPath reports whether a file is a test by name or directory
+// convention. Tests import across every layer by design.
+func isTestPath(p string) bool {
+	if testSubject(p) != "" {
+		return true
+	}
+	for _, seg := range strings.Split(path.Dir(p), "/") {
+		switch seg {
+		case "test", "tests", "__tests__", "testdata", "spec":
+			return true
+		}
+	}
+	base := path.Base(p)
+	return strings.HasSuffix(strings.TrimSuffix(base, path.Ext(base)), "Test")
+}
+
+// pathRole returns the role of the path word nearest the file: its own name
+// first (user_service.go, UserRepository.java), then each enclosing
+// directory outward.
+func pathRole(p string, vocab map[string]string) string {
+	segs := strings.Split(p, "/")
+	base := segs[len(segs)-1]
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		base = base[:i]
+	}
+	segs[len(segs)-1] = base
+	for i := len(segs) - 1; i >= 0; i-- {
+		words := pathWords(segs[i])
+		for j := len(words) - 1; j >= 0; j-- {
+			if role, ok := vocab[words[j]]; ok {
+				return role
+			}
+			if j > 0 {
+				if role, ok := vocab[words[j-1]+words[j]]; ok { // use_cases
+					return role
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// pathWords splits a path segment on punctuation and camelCase boundaries,
+// lowercased: "UserRepositoryImpl" -> user, repository, impl.
+func pathWords(seg string) []string {
+	var words []string
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+	runes := []rune(seg)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) ||
+			(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))):
+			flush()
+			cur = append(cur, r)
+		default:
+			cur = append(cur, r)
+		}
+	}
+	flush()
+	return words
+}
+
+// detectLayered looks for handler → service → repository layering: at least
+// two of the three layers present, imports flowing down, few flowing up.
+// Domain sits below them all.
+func detectLayered(g *roleGraph, modules []*Entry) *Entry {
+	present := 0
+	for _, role := range []string{roleHandler, roleService, roleRepository} {
+		if len(g.files[role]) >= 2 {
+			present++
+		}
+	}
+	if present < 2 {
+		return nil
+	}
+	down, up := 0, 0
+	var evidence, violations []string
+	roles := []string{roleHandler, roleService, roleRepository, roleDomain}
+	for _, a := range roles {
+		for _, b := range roles {
+			switch {
+			case layerRank[a] < layerRank[b]:
+				down += g.count(a, b)
+				evidence = append(evidence, g.importers(a, b)...)
+			case layerRank[a] > layerRank[b]:
+				up += g.count(a, b)
+				violations = append(violations, g.importers(a, b)...)
+			}
+		}
+	}
+	if down < 2 {
+		return nil
+	}
+	confidence := float64(down) / float64(down+up)
+	if present == 2 {
+		confidence *= 0.75
+	}
+	if confidence < MinArchPatternConfidence {
+		return nil
+	}
+
+	var chain []string
+	layerDirs := make(map[string][]string)
+	for _, role := range roles {
+		if len(g.files[role]) == 0 {
+			continue
+		}
+		chain = append(chain, role)
+		layerDirs[role] = topDirs(g.files[role], 3)
+	}
+	detail := fmt.Sprintf("Files fall into %s layers by directory and file naming. %d cross-layer imports point down the stack, %d point up.",
+		strings.Join(chain, ", "), down, up)
+	if len(violations) > 0 {
+		detail += " Upward imports: " + strings.Join(capStrings(violations, 5), ", ") + "."
+	}
+	meta := map[string]string{
+		"edges_down": fmt.Sprint(down),
+		"edges_up":   fmt.Sprint(up),
+		"layers":     jsonString(layerDirs),
+	}
+	if byLayer := layerModules(g, modules); len(byLayer) > 0 {
+		meta["modules"] = jsonString(byLayer)
+	}
+	return archEntry(PatternLayered, "Layered architecture: "+strings.Join(chain, " → "), detail, confidence, evidence, meta)
+}
+
+// layerModules maps each layer to the module clusters holding most of its
+// files — whether the layers are also the structural modules, or cut
+// across them.
+func layerModules(g *roleGraph, modules []*Entry) map[string][]string {
+	memberOf := moduleMembership(modules)
+	if len(memberOf) == 0 {
+		return nil
+	}
+	out := make(map[string][]string)
+	for role, files := range g.files {
+		counts := make(map[string]int)
+		for _, f := range files {
+			if m := memberOf[f]; m != "" {
+				counts[m]++
+			}
+		}
+		var labels []string
+		for m, n := range counts {
+			if n*4 >= len(files) { // holds at least a quarter of the layer
+				labels = append(labels, m)
+			}
+		}
+		if len(labels) > 0 {
+			sort.Strings(labels)
+			out[role] = labels
+		}
+	}
+	return out
+}
+
+// detectHexagonal looks for ports and adapters: adapter code importing the
+// core (ports, domain, application) and the core never importing adapters.
+func detectHexagonal(g *roleGraph) *Entry {
+	if len(g.files["adapter"]) == 0 || (len(g.files["port"]) == 0 && len(g.files["core"]) < 2) {
+		return nil
+	}
+	inward := g.count("adapter", "port") + g.count("adapter", "core")
+	outward := g.count("port", "adapter") + g.count("core", "adapter")
+	if inward == 0 {
+		return nil
+	}
+	confidence := float64(inward) / float64(inward+outward)
+	if len(g.files["port"]) == 0 {
+		confidence *= 0.75
+	}
+	if confidence < MinArchPatternConfidence {
+		return nil
+	}
+	evidence := append(g.importers("adapter", "port"), g.importers("adapter", "core")...)
+	violations := append(g.importers("port", "adapter"), g.importers("core", "adapter")...)
+	detail := fmt.Sprintf("Adapters (%s) depend inward on the core (%s): %d inward imports, %d outward.",
+		strings.Join(topDirs(g.files["adapter"], 3), ", "),
+		strings.Join(topDirs(append(append([]string{}, g.files["port"]...), g.files["core"]...), 3), ", "),
+		inward, outward)
+	if len(violations) > 0 {
+		detail += " Core importing adapters: " + strings.Join(capStrings(violations, 5), ", ") + "."
+	}
+	return archEntry(PatternHexagonal, "Hexagonal architecture (ports and adapters)", detail, confidence, evidence, map[string]string{
+		"ports":    jsonString(topDirs(g.files["port"], 3)),
+		"adapters": jsonString(topDirs(g.files["adapter"], 3)),
+		"inward":   fmt.Sprint(inward),
+		"outward":  fmt.Sprint(outward),
+	})
+}
+
+// detectCQRS looks for separate command and query sides. Independence of
+// the two sides and *Command/*Query types raise the confidence.
+func detectCQRS(g *roleGraph, typeRefs map[string]map[string]bool) *Entry {
+	if len(g.files["command"]) < 2 || len(g.files["query"]) < 2 {
+		return nil
+	}
+	confidence := 0.6
+	cross := g.count("command", "query") + g.count("query", "command")
+	if cross == 0 {
+		confidence += 0.2
+	}
+	if len(typeRefs["Command"]) >= 2 && len(typeRefs["Query"]) >= 2 {
+		confidence += 0.2
+	}
+	detail := fmt.Sprintf("Commands (%s) and queries (%s) live apart; %d imports cross between the sides.",
+		strings.Join(topDirs(g.files["command"], 3), ", "), strings.Join(topDirs(g.files["query"], 3), ", "), cross)
+	evidence := append(capStrings(g.files["command"], MaxArchPatternEvidence/2), capStrings(g.files["query"], MaxArchPatternEvidence/2)...)
+	return archEntry(PatternCQRS, "CQRS: separate command and query sides", detail, confidence, evidence, map[string]string{
+		"commands": jsonString(topDirs(g.files["command"], 3)),
+		"queries":  jsonString(topDirs(g.files["query"], 3)),
+	})
+}
+
+// detectMVC looks for controllers driving models, with a views or
+// templates directory beside the controllers as corroboration (templates
+// are rarely indexed source).
+func detectMVC(g *roleGraph, rootDir string) *Entry {
+	if len(g.files["controller"]) < 2 || len(g.files["model"]) < 2 {
+		return nil
+	}
+	confidence := 0.6
+	edges := g.count("controller", "model")
+	if edges > 0 {
+		confidence += 0.2
+	}
+	views := ""
+	for _, dir := range topDirs(g.files["controller"], 3) {
+		for _, name := range []string{"views", "templates"} {
+			cand := path.Join(path.Dir(dir), name)
+			if fi, err := os.Stat(filepath.Join(rootDir, filepath.FromSlash(cand))); err == nil && fi.IsDir() && views == "" {
+				views = cand
+			}
+		}
+	}
+	if views != "" {
+		confidence += 0.2
+	}
+	detail := fmt.Sprintf("Controllers (%s) and models (%s); %d controller files import models.",
+		strings.Join(topDirs(g.files["controller"], 3), ", "), strings.Join(topDirs(g.files["model"], 3), ", "), edges)
+	if views != "" {
+		detail += " Views in " + views + "."
+	}
+	evidence := g.importers("controller", "model")
+	if len(evidence) == 0 {
+		evidence = capStrings(g.files["controller"], MaxArchPatternEvidence)
+	}
+	meta := map[string]string{"controller_model_imports": fmt.Sprint(edges)}
+	if views != "" {
+		meta["views"] = views
+	}
+	return archEntry(PatternMVC, "MVC: controllers, models and views", detail, confidence, evidence, meta)
+}
+
+// detectVerticalSlices looks for module clusters that each carry several
+// layers — code organised by feature rather than by layer.
+func detectVerticalSlices(modules []*Entry) *Entry {
+	layered, sliced := 0, 0
+	var evidence, names []string
+	for _, m := range modules {
+		var members []string
+		if json.Unmarshal([]byte(m.Metadata["members"]), &members) != nil {
+			continue
+		}
+		roles := make(map[string]bool)
+		for _, f := range members {
+			if isTestPath(f) {
+				continue
+			}
+			if role := pathRole(f, layerWords); role != "" && role != roleDomain {
+				roles[role] = true
+			}
+		}
+		if len(roles) == 0 {
+			continue
+		}
+		layered++
+		if len(roles) >= 2 {
+			sliced++
+			names = append(names, m.Name)
+			evidence = append(evidence, m.Metadata["hub"])
+		}
+	}
+	if sliced < 2 {
+		return nil
+	}
+	confidence := float64(sliced) / float64(layered)
+	if confidence < MinArchPatternConfidence {
+		return nil
+	}
+	detail := fmt.Sprintf("%d of %d module clusters holding layer code each span several layers: features own their handlers, services and storage. Modules: %s.",
+		sliced, layered, strings.Join(capStrings(names, 10), ", "))
+	return archEntry(PatternVerticalSlices, "Vertical slices: modules organised by feature", detail, confidence, evidence, map[string]string{
+		"modules": jsonString(names),
+	})
+}
+
+// detectRegistries finds registration functions called from many files in
+// several directories — the plugin-registry pattern (init()-time
+// self-registration, Register(name, factory) tables).
+func detectRegistries(calls map[string]map[string]bool, src ModulesSource) []*Entry {
+	type registry struct {
+		name, definer string
+		callers       []string
+		dirs          int
+	}
+	var found []registry
+	for name, callerSet := range calls {
+		definers, err := src.DefiningFiles(name)
+		if err != nil || len(definers) == 0 || len(definers) > MaxSymbolDefiners {
+			continue
+		}
+		sort.Strings(definers)
+		var callers []string
+		dirs := make(map[string]bool)
+		for f := range callerSet {
+			if f == definers[0] {
+				continue
+			}
+			callers = append(callers, f)
+			dirs[path.Dir(f)] = true
+		}
+		if len(callers) < minRegistryCallers || len(dirs) < 2 {
+			continue
+		}
+		sort.Strings(callers)
+		found = append(found, registry{name: name, definer: definers[0], callers: callers, dirs: len(dirs)})
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if len(found[i].callers) != len(found[j].callers) {
+			return len(found[i].callers) > len(found[j].callers)
+		}
+		return found[i].name < found[j].name
+	})
+	if len(found) > maxRegistries {
+		found = found[:maxRegistries]
+	}
+
+	var entries []*Entry
+	for _, r := range found {
+		confidence := 0.5 + 0.5*min(1, float64(r.dirs-1)/4)
+		for _, c := range r.callers {
+			for _, seg := range strings.Split(path.Dir(c), "/") {
+				if seg == "plugins" || seg == "plugin" || seg == "extensions" || seg == "addons" {
+					confidence = min(1, confidence+0.1)
+				}
+			}
+		}
+		detail := fmt.Sprintf("%s (defined in %s) is called from %d files in %d directories — components register themselves rather than being wired centrally.",
+			r.name, r.definer, len(r.callers), r.dirs)
+		e := archEntry(PatternPluginRegistry, fmt.Sprintf("Plugin registry: %s with %d registrants", r.name, len(r.callers)),
+			detail, confidence, append([]string{r.definer}, r.callers...), map[string]string{
+				"function":    r.name,
+				"registrants": fmt.Sprint(len(r.callers)),
+			})
+		e.Name = PatternPluginRegistry + ":" + r.name
+		e.FilePath = r.definer
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// archEntry builds a KindArchPattern entry. FilePath is the evidence's
+// common directory, or empty for repo-wide patterns.
+func archEntry(pattern, title, detail string, confidence float64, evidence []string, meta map[string]string) *Entry {
+	evidence = dedupeStrings(evidence)
+	meta["pattern"] = pattern
+	meta["confidence"] = fmt.Sprintf("%.2f", confidence)
+	meta["evidence"] = jsonString(capStrings(evidence, MaxArchPatternEvidence))
+	dir := commonDirPrefix(evidence)
+	if dir == "." {
+		dir = ""
+	}
+	return &Entry{
+		Analyzer: AnalyzerArchitecture,
+		Kind:     KindArchPattern,
+		Name:     pattern,
+		FilePath: dir,
+		Title:    fmt.Sprintf("%s (confidence %.2f)", title, confidence),
+		Detail:   detail,
+		Metadata: meta,
+	}
+}
+
+func jsonString(v any) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+func capStrings(s []string, n int) []string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func dedupeStrings(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := s[:0:0]
+	for _, v := range s {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,155 @@
+package survey
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// architectureFixture is a layered Go service with a self-registering
+// plugin table: handlers import services, services import repositories,
+// everything imports the domain, and three plugins call registry.Register.
+func architectureFixture(t *testing.T) (string, *fakeModulesSource) {
+	t.Helper()
+	root := t.TempDir()
+	files := []ModuleFile{
+		{Path: "internal/handler/user.go", Language: "go"},
+		{Path: "internal/handler/order.go", Language: "go"},
+		{Path: "internal/service/user.go", Language: "go"},
+		{Path: "internal/service/order.go", Language: "go"},
+		{Path: "internal/repository/user.go", Language: "go"},
+		{Path: "internal/repository/order.go", Language: "go"},
+		{Path: "internal/domain/types.go", Language: "go"},
+		{Path: "internal/registry/registry.go", Language: "go"},
+		{Path: "plugins/csv/csv.go", Language: "go"},
+		{Path: "plugins/json/json.go", Language: "go"},
+		{Path: "plugins/xml/xml.go", Language: "go"},
+		{Path: "internal/handler/user_test.go", Language: "go"},
+	}
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	for _, f := range files {
+		writeTestFile(t, root, f.Path, "package x\n")
+	}
+
+	imp := func(pkg string) ReferenceHit {
+		return ReferenceHit{Symbol: `"example.com/app/internal/` + pkg + `"`, Kind: "import"}
+	}
+	register := ReferenceHit{Symbol: "Register", Kind: "call"}
+	src := &fakeModulesSource{
+		files: files,
+		refs: map[string][]ReferenceHit{
+			"internal/handler/user.go":      {imp("service"), imp("domain")},
+			"internal/handler/order.go":     {imp("service")},
+			"internal/service/user.go":      {imp("repository"), imp("domain")},
+			"internal/service/order.go":     {imp("repository")},
+			"internal/repository/user.go":   {imp("domain")},
+			"internal/repository/order.go":  {imp("domain"), imp("service")}, // upward
+			"plugins/csv/csv.go":            {imp("registry"), register},
+			"plugins/json/json.go":          {imp("registry"), register},
+			"plugins/xml/xml.go":            {imp("registry"), register},
+			"internal/handler/user_test.go": {imp("repository")},
+		},
+		definers: map[string][]string{"Register": {"internal/registry/registry.go"}},
+	}
+	return root, src
+}
+
+func patternEntry(entries []*Entry, name string) *Entry {
+	for _, e := range entries {
+		if e.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestRunArchitecture(t *testing.T) {
+	root, src := architectureFixture(t)
+	result, err := RunArchitecture(ArchitectureConfig{RootDir: root, Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Files != 11 {
+		t.Errorf("files = %d, want 11 (test file excluded)", result.Files)
+	}
+
+	layered := patternEntry(result.Entries, PatternLayered)
+	if layered == nil {
+		t.Fatalf("no layered entry in %d entries", len(result.Entries))
+	}
+	if layered.Kind != KindArchPattern || layered.Analyzer != AnalyzerArchitecture {
+		t.Errorf("layered analyzer/kind = %s/%s", layered.Analyzer, layered.Kind)
+	}
+	if layered.Metadata["edges_up"] != "1" || layered.Metadata["edges_down"] != "8" {
+		t.Errorf("layered edges = %s down, %s up; want 8 down, 1 up",
+			layered.Metadata["edges_down"], layered.Metadata["edges_up"])
+	}
+	if layered.Metadata["confidence"] != "0.89" {
+		t.Errorf("layered confidence = %s, want 0.89", layered.Metadata["confidence"])
+	}
+	if layered.FilePath != "internal" {
+		t.Errorf("layered path = %q, want internal", layered.FilePath)
+	}
+	var evidence []string
+	if err := json.Unmarshal([]byte(layered.Metadata["evidence"]), &evidence); err != nil || len(evidence) == 0 {
+		t.Errorf("layered evidence = %q", layered.Metadata["evidence"])
+	}
+
+	registry := patternEntry(result.Entries, PatternPluginRegistry+":Register")
+	if registry == nil {
+		t.Fatal("no plugin registry entry")
+	}
+	if registry.FilePath != "internal/registry/registry.go" || registry.Metadata["registrants"] != "3" {
+		t.Errorf("registry = %s %v", registry.FilePath, registry.Metadata)
+	}
+
+	for _, absent := range []string{PatternHexagonal, PatternCQRS, PatternMVC, PatternVerticalSlices} {
+		if patternEntry(result.Entries, absent) != nil {
+			t.Errorf("unexpected %s pattern", absent)
+		}
+	}
+}
+
+func TestRunArchitectureVerticalSlices(t *testing.T) {
+	module := func(name string, members ...string) *Entry {
+		data, _ := json.Marshal(members)
+		return &Entry{Name: name, Metadata: map[string]string{"members": string(data), "hub": members[0]}}
+	}
+	modules := []*Entry{
+		module("users", "users/handler.go", "users/service.go", "users/repository.go"),
+		module("orders", "orders/handler.go", "orders/store.go"),
+		module("util", "util/strings.go"),
+	}
+	root, src := architectureFixture(t)
+	result, err := RunArchitecture(ArchitectureConfig{RootDir: root, Source: src, Modules: modules})
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices := patternEntry(result.Entries, PatternVerticalSlices)
+	if slices == nil {
+		t.Fatal("no vertical slices entry")
+	}
+	if slices.Metadata["modules"] != `["users","orders"]` || slices.Metadata["confidence"] != "1.00" {
+		t.Errorf("slices metadata = %v", slices.Metadata)
+	}
+}
+
+func TestPathRole(t *testing.T) {
+	tests := []struct{ path, want string }{
+		{"internal/user/handler.go", roleHandler},
+		{"src/main/java/com/acme/UserRepositoryImpl.java", roleRepository},
+		{"app/controllers/users_controller.rb", roleHandler},
+		{"pkg/store/survey.go", roleRepository},
+		{"src/use_cases/create_user.ts", roleService},
+		{"services/billing/models/invoice.py", roleDomain},
+		{"cmd/aide/main.go", ""},
+	}
+	for _, tt := range tests {
+		if got := pathRole(tt.path, layerWords); got != tt.want {
+			t.Errorf("pathRole(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+	if got := pathWords("HTTPUserController"); !reflect.DeepEqual(got, []string{"http", "user", "controller"}) {
+		t.Errorf("pathWords = %v", got)
+	}
+}
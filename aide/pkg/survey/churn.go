@@ -3,11 +3,16 @@ package survey
 import (
 	"fmt"
 	"log"
+	"time"
 )
 
 // DefaultTopChurnFiles is the default number of high-churn files to report.
 const DefaultTopChurnFiles = 50
 
+// DefaultChurnHalfLife is the age at which a commit counts half towards a
+// file's recent churn.
+const DefaultChurnHalfLife = 90 * 24 * time.Hour
+
 // ChurnResult holds the output of the churn analyzer.
 type ChurnResult struct {
 	Entries []*Entry
@@ -49,8 +54,10 @@ func RunChurn(rootDir string, maxCommits int, topN int) (*ChurnResult, error) {
 			Title:    fmt.Sprintf("High churn: %s (%d commits, %d lines changed)", cs.FilePath, cs.Commits, cs.LinesChanged),
 			Detail:   fmt.Sprintf("File changed in %d commits with %d total lines modified", cs.Commits, cs.LinesChanged),
 			Metadata: map[string]string{
-				"commits":       fmt.Sprintf("%d", cs.Commits),
-				"lines_changed": fmt.Sprintf("%d", cs.LinesChanged),
+				"commits":        fmt.Sprintf("%d", cs.Commits),
+				"lines_changed":  fmt.Sprintf("%d", cs.LinesChanged),
+				"recent_commits": fmt.Sprintf("%.2f", cs.Recent),
+				"last_change":    cs.Last.UTC().Format("2006-01-02"),
 			},
 		})
 	}
@@ -0,0 +1,270 @@
+// Package survey: cochange.go mines temporal coupling from git history —
+// pairs of files that keep changing in the same commits, whether or not any
+// import connects them (a .proto and its generated code, a config file and
+// its loader).
+package survey
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Co-change defaults.
+const (
+	// DefaultCoChangeMinSupport is the fewest commits a pair must share.
+	DefaultCoChangeMinSupport = 3
+	// DefaultCoChangeMinConfidence is the smallest share of one file's
+	// commits that must also touch the other, in either direction.
+	DefaultCoChangeMinConfidence = 0.5
+	// DefaultCoChangeMaxFiles skips commits touching more files than this:
+	// mass renames, reformatting and vendoring pair everything with
+	// everything and drown the real coupling.
+	DefaultCoChangeMaxFiles = 30
+
+	maxCoChangePairs = 2000 // entries kept, strongest first
+)
+
+// CoChangeConfig configures a cochange run.
+type CoChangeConfig struct {
+	RootDir           string
+	MaxCommits        int      // commits to walk (0 = DefaultMaxCommits)
+	MinSupport        int      // 0 = DefaultCoChangeMinSupport
+	MinConfidence     float64  // 0 = DefaultCoChangeMinConfidence
+	MaxFilesPerCommit int      // 0 = DefaultCoChangeMaxFiles
+	Modules           []*Entry // modules analyzer entries; marks pairs that cross modules
+}
+
+// CoChangeResult holds the output of the cochange analyzer.
+type CoChangeResult struct {
+	Entries     []*Entry
+	Commits     int // commits counted
+	Noisy       int // commits skipped for touching too many files
+	CrossModule int // pairs whose files sit in different modules
+}
+
+type coChangePair struct {
+	a, b    string
+	support int
+	last    time.Time
+}
+
+// RunCoChange counts, for every pair of files still in the worktree, the
+// commits that changed both, and records one KindCoChange entry per pair
+// with enough support and confidence. Confidence is directional:
+// confidence_ab is the share of a's commits that also changed b.
+// Returns an empty result if the directory is not a git repo.
+func RunCoChange(cfg CoChangeConfig) (*CoChangeResult, error) {
+	if cfg.MinSupport <= 0 {
+		cfg.MinSupport = DefaultCoChangeMinSupport
+	}
+	if cfg.MinConfidence <= 0 {
+		cfg.MinConfidence = DefaultCoChangeMinConfidence
+	}
+	if cfg.MaxFilesPerCommit <= 0 {
+		cfg.MaxFilesPerCommit = DefaultCoChangeMaxFiles
+	}
+
+	gitRepo, err := OpenGitRepo(cfg.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("cochange analyzer: %w", err)
+	}
+	if gitRepo == nil {
+		return &CoChangeResult{}, nil
+	}
+	root, err := gitRepo.Root()
+	if err != nil {
+		return nil, fmt.Errorf("cochange analyzer: %w", err)
+	}
+
+	commits, err := gitRepo.CommitFileSets(cfg.MaxCommits)
+	if err != nil {
+		log.Printf("survey: cochange analysis warning: %v", err)
+		return &CoChangeResult{}, nil
+	}
+
+	result := &CoChangeResult{}
+	exists := make(map[string]bool)
+	fileCommits := make(map[string]int)
+	pairs := make(map[[2]string]*coChangePair)
+
+	for _, c := range commits {
+		if len(c.Files) > cfg.MaxFilesPerCommit {
+			result.Noisy++
+			continue
+		}
+		result.Commits++
+		var files []string
+		for _, f := range c.Files {
+			ok, seen := exists[f]
+			if !seen {
+				info, err := os.Stat(filepath.Join(root, filepath.FromSlash(f)))
+				ok = err == nil && info.Mode().IsRegular()
+				exists[f] = ok
+			}
+			if ok {
+				files = append(files, f)
+			}
+		}
+		sort.Strings(files)
+		for i, a := range files {
+			fileCommits[a]++
+			for _, b := range files[i+1:] {
+				key := [2]string{a, b}
+				p := pairs[key]
+				if p == nil {
+					p = &coChangePair{a: a, b: b}
+					pairs[key] = p
+				}
+				p.support++
+				if c.When.After(p.last) {
+					p.last = c.When
+				}
+			}
+		}
+	}
+
+	var kept []*coChangePair
+	for _, p := range pairs {
+		if p.support < cfg.MinSupport {
+			continue
+		}
+		confAB := float64(p.support) / float64(fileCommits[p.a])
+		confBA := float64(p.support) / float64(fileCommits[p.b])
+		if confAB < cfg.MinConfidence && confBA < cfg.MinConfidence {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		if kept[i].support != kept[j].support {
+			return kept[i].support > kept[j].support
+		}
+		if kept[i].a != kept[j].a {
+			return kept[i].a < kept[j].a
+		}
+		return kept[i].b < kept[j].b
+	})
+	if len(kept) > maxCoChangePairs {
+		kept = kept[:maxCoChangePairs]
+	}
+
+	membership := moduleMembership(cfg.Modules)
+	for _, p := range kept {
+		e := coChangeEntry(p, fileCommits[p.a], fileCommits[p.b])
+		if len(membership) > 0 {
+			ma, okA := membership[p.a]
+			mb, okB := membership[p.b]
+			if okA && okB {
+				e.Metadata["same_module"] = strconv.FormatBool(ma == mb)
+				if ma != mb {
+					result.CrossModule++
+				}
+			}
+		}
+		result.Entries = append(result.Entries, e)
+	}
+	return result, nil
+}
+
+func coChangeEntry(p *coChangePair, commitsA, commitsB int) *Entry {
+	confAB := float64(p.support) / float64(commitsA)
+	confBA := float64(p.support) / float64(commitsB)
+	meta := map[string]string{
+		"file_a":        p.a,
+		"file_b":        p.b,
+		"support":       strconv.Itoa(p.support),
+		"commits_a":     strconv.Itoa(commitsA),
+		"commits_b":     strconv.Itoa(commitsB),
+		"confidence_ab": fmt.Sprintf("%.2f", confAB),
+		"confidence_ba": fmt.Sprintf("%.2f", confBA),
+		"last_together": p.last.UTC().Format("2006-01-02"),
+	}
+	if path.Dir(p.a) == path.Dir(p.b) {
+		meta["same_dir"] = "true"
+	}
+	return &Entry{
+		Analyzer: AnalyzerCoChange,
+		Kind:     KindCoChange,
+		Name:     p.a + " <-> " + p.b,
+		FilePath: p.a,
+		Title:    fmt.Sprintf("Co-change: %s <-> %s (%d commits)", p.a, p.b, p.support),
+		Detail: fmt.Sprintf("Changed together in %d commits: %.0f%% of %s's %d and %.0f%% of %s's %d. Last together %s.",
+			p.support, confAB*100, p.a, commitsA, confBA*100, p.b, commitsB, meta["last_together"]),
+		Metadata: meta,
+	}
+}
+
+// CoChangePartner is a file that tends to change with a queried file.
+type CoChangePartner struct {
+	File       string  `json:"file"`
+	Of         string  `json:"of"`         // the queried file, as matched
+	Support    int     `json:"support"`    // commits that changed both
+	Commits    int     `json:"commits"`    // commits that changed Of
+	Confidence float64 `json:"confidence"` // share of Of's commits that also changed File
+	Last       string  `json:"last_together"`
+	SameModule string  `json:"same_module,omitempty"` // "true"/"false" when module clusters were known
+}
+
+// CoChangePartners returns the co-change partners of file from cochange
+// entries, most dependable first, dropping those below minConfidence. file
+// is matched exactly, or failing that as a path suffix ("aidememory.proto"
+// finds "aide/proto/aidememory.proto").
+func CoChangePartners(entries []*Entry, file string, minConfidence float64) []CoChangePartner {
+	file = strings.TrimPrefix(path.Clean(filepath.ToSlash(file)), "./")
+	match := func(f string) bool { return f == file }
+	exact := false
+	for _, e := range entries {
+		if e.Metadata["file_a"] == file || e.Metadata["file_b"] == file {
+			exact = true
+			break
+		}
+	}
+	if !exact {
+		match = func(f string) bool { return strings.HasSuffix(f, "/"+file) }
+	}
+
+	var out []CoChangePartner
+	for _, e := range entries {
+		if e.Kind != KindCoChange {
+			continue
+		}
+		for _, side := range [][2]string{{"a", "b"}, {"b", "a"}} {
+			of, partner := e.Metadata["file_"+side[0]], e.Metadata["file_"+side[1]]
+			if !match(of) {
+				continue
+			}
+			conf, _ := strconv.ParseFloat(e.Metadata["confidence_"+side[0]+side[1]], 64)
+			if conf < minConfidence {
+				continue
+			}
+			support, _ := strconv.Atoi(e.Metadata["support"])
+			commits, _ := strconv.Atoi(e.Metadata["commits_"+side[0]])
+			out = append(out, CoChangePartner{
+				File:       partner,
+				Of:         of,
+				Support:    support,
+				Commits:    commits,
+				Confidence: conf,
+				Last:       e.Metadata["last_together"],
+				SameModule: e.Metadata["same_module"],
+			})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Confidence != out[j].Confidence {
+			return out[i].Confidence > out[j].Confidence
+		}
+		if out[i].Support != out[j].Support {
+			return out[i].Support > out[j].Support
+		}
+		return out[i].File < out[j].File
+	})
+	return out
+}
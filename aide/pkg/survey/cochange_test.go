@@ -0,0 +1,102 @@
+package survey
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRunCoChange(t *testing.T) {
+	dir, repo := initTestRepo(t)
+
+	// The proto and its generated code always change together; the adapter
+	// follows in three of the four proto changes.
+	for i := 0; i < 4; i++ {
+		writeTestFile(t, dir, "proto/api.proto", fmt.Sprintf("message M%d {}\n", i))
+		writeTestFile(t, dir, "pkg/api/api.pb.go", fmt.Sprintf("// gen %d\n", i))
+		if i < 3 {
+			writeTestFile(t, dir, "pkg/adapter/proto.go", fmt.Sprintf("// adapt %d\n", i))
+		}
+		commitAll(t, repo, "change api")
+	}
+	// The adapter also changes on its own, so the coupling is one-sided.
+	for i := 0; i < 3; i++ {
+		writeTestFile(t, dir, "pkg/adapter/proto.go", fmt.Sprintf("// solo %d\n", i))
+		commitAll(t, repo, "tweak adapter")
+	}
+	// A pair below the support threshold.
+	for i := 0; i < 2; i++ {
+		writeTestFile(t, dir, "a.go", fmt.Sprintf("// %d\n", i))
+		writeTestFile(t, dir, "b.go", fmt.Sprintf("// %d\n", i))
+		commitAll(t, repo, "a and b")
+	}
+	// A huge commit, three times over: noise, not coupling.
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 6; i++ {
+			writeTestFile(t, dir, fmt.Sprintf("bulk/f%d.go", i), fmt.Sprintf("// %d\n", round))
+		}
+		commitAll(t, repo, "reformat everything")
+	}
+
+	modules := []*Entry{
+		{Analyzer: AnalyzerModules, Kind: KindModule, Name: "api", Metadata: map[string]string{"members": `["pkg/api/api.pb.go"]`}},
+		{Analyzer: AnalyzerModules, Kind: KindModule, Name: "adapter", Metadata: map[string]string{"members": `["pkg/adapter/proto.go"]`}},
+	}
+	result, err := RunCoChange(CoChangeConfig{RootDir: dir, MaxFilesPerCommit: 5, Modules: modules})
+	if err != nil {
+		t.Fatalf("RunCoChange: %v", err)
+	}
+	if result.Noisy != 3 {
+		t.Errorf("Noisy = %d, want 3", result.Noisy)
+	}
+
+	byName := make(map[string]*Entry)
+	for _, e := range result.Entries {
+		if e.Kind != KindCoChange || e.Analyzer != AnalyzerCoChange {
+			t.Errorf("entry %s has kind/analyzer %s/%s", e.Name, e.Kind, e.Analyzer)
+		}
+		byName[e.Name] = e
+	}
+	if len(byName) != 3 {
+		t.Fatalf("got %d pairs, want 3: %v", len(byName), byName)
+	}
+
+	gen := byName["pkg/api/api.pb.go <-> proto/api.proto"]
+	if gen == nil || gen.Metadata["support"] != "4" || gen.Metadata["confidence_ab"] != "1.00" || gen.Metadata["confidence_ba"] != "1.00" {
+		t.Errorf("generated pair = %+v", gen)
+	}
+	adapter := byName["pkg/adapter/proto.go <-> proto/api.proto"]
+	if adapter == nil {
+		t.Fatal("missing adapter/proto pair")
+	}
+	if adapter.Metadata["support"] != "3" || adapter.Metadata["confidence_ab"] != "0.50" || adapter.Metadata["confidence_ba"] != "0.75" {
+		t.Errorf("adapter pair metadata = %v", adapter.Metadata)
+	}
+	cross := byName["pkg/adapter/proto.go <-> pkg/api/api.pb.go"]
+	if cross == nil || cross.Metadata["same_module"] != "false" || result.CrossModule != 1 {
+		t.Errorf("cross-module pair = %+v (CrossModule %d)", cross, result.CrossModule)
+	}
+
+	partners := CoChangePartners(result.Entries, "api.proto", 0)
+	if len(partners) != 2 {
+		t.Fatalf("partners of api.proto = %+v, want 2", partners)
+	}
+	if partners[0].File != "pkg/api/api.pb.go" || partners[0].Of != "proto/api.proto" || partners[0].Confidence != 1 {
+		t.Errorf("first partner = %+v", partners[0])
+	}
+	if partners[1].File != "pkg/adapter/proto.go" || partners[1].Confidence != 0.75 || partners[1].Commits != 4 {
+		t.Errorf("second partner = %+v", partners[1])
+	}
+	if got := CoChangePartners(result.Entries, "pkg/adapter/proto.go", 0.6); len(got) != 0 {
+		t.Errorf("adapter partners above 0.6 = %+v, want none", got)
+	}
+}
+
+func TestRunCoChange_NotGitRepo(t *testing.T) {
+	result, err := RunCoChange(CoChangeConfig{RootDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("RunCoChange: %v", err)
+	}
+	if len(result.Entries) != 0 {
+		t.Errorf("got %d entries outside a git repo", len(result.Entries))
+	}
+}
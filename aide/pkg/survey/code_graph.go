@@ -1,6 +1,10 @@
 package survey
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
 
 // DefaultGraphDepth is the maximum BFS depth when not specified.
 const DefaultGraphDepth = 2
@@ -16,10 +20,22 @@ type GraphOptions struct {
 	MaxNodes int
 	// Direction controls traversal: "both" (default), "callers", "callees".
 	Direction string
+	// Exact drops references the indexer could not resolve to a definition,
+	// so same-named symbols elsewhere never contribute edges. References
+	// resolved to a different definition are always dropped.
+	Exact bool
+	// TypeRefs also follows type references, so types link to the symbols
+	// that use them as well as call edges.
+	TypeRefs bool
+	// File restricts the root to a symbol defined in this file, for names
+	// several files define.
+	File string
 }
 
 // BuildCallGraph performs a BFS traversal starting from the given symbol name.
 // It uses the CodeGrapher to discover call relationships at each hop.
+// symbolName may be qualified ("store.CodeStore.Close") to pick one of
+// several same-named symbols.
 func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*CallGraph, error) {
 	if opts.MaxDepth <= 0 {
 		opts.MaxDepth = DefaultGraphDepth
@@ -40,28 +56,32 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 		return nil, fmt.Errorf("symbol %q not found in code index", symbolName)
 	}
 
-	// Pick the best match (exact name match preferred, then first hit).
+	// Pick the best match (exact or qualified name match preferred, then
+	// first hit).
 	root := hits[0]
 	for _, h := range hits {
-		if h.Name == symbolName {
+		if h.matchesName(symbolName) && (opts.File == "" || h.FilePath == opts.File) {
 			root = h
 			break
 		}
 	}
+	if opts.File != "" && root.FilePath != opts.File {
+		return nil, fmt.Errorf("symbol %q not found in %s", symbolName, opts.File)
+	}
 
 	graph := &CallGraph{
-		Root:  root.Name,
+		Root:  root.displayName(),
 		Depth: opts.MaxDepth,
 	}
 
-	// Track visited nodes by "file:name" to avoid duplicates.
+	// Track visited nodes by "file:qualified name" to avoid duplicates.
 	visited := make(map[string]bool)
-	nodeKey := func(name, filePath string) string {
-		return filePath + ":" + name
+	nodeKey := func(h SymbolHit) string {
+		return h.FilePath + ":" + h.displayName()
 	}
 
-	addNode := func(h SymbolHit) bool {
-		key := nodeKey(h.Name, h.FilePath)
+	addNode := func(h SymbolHit, depth int) bool {
+		key := nodeKey(h)
 		if visited[key] {
 			return false
 		}
@@ -69,12 +89,21 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 			return false
 		}
 		visited[key] = true
-		graph.Nodes = append(graph.Nodes, GraphNode(h))
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			Name:          h.Name,
+			QualifiedName: h.QualifiedName,
+			Kind:          h.Kind,
+			FilePath:      h.FilePath,
+			Line:          h.Line,
+			EndLine:       h.EndLine,
+			Language:      h.Language,
+			Depth:         depth,
+		})
 		return true
 	}
 
 	// Seed root.
-	addNode(root)
+	addNode(root, 0)
 
 	// BFS queue: each item is (SymbolHit, currentDepth).
 	type bfsItem struct {
@@ -93,7 +122,7 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 				FilePath: n.ref.FilePath,
 				Line:     n.ref.Line,
 			})
-			if n.node != nil && addNode(*n.node) {
+			if n.node != nil && addNode(*n.node, depth+1) {
 				queue = append(queue, bfsItem{sym: *n.node, depth: depth + 1})
 			}
 		}
@@ -109,7 +138,7 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 
 		// Find callees: references inside this symbol's body.
 		if opts.Direction == "both" || opts.Direction == "callees" {
-			callees, err := findCallees(cg, item.sym)
+			callees, err := findCallees(cg, item.sym, opts.Exact)
 			if err == nil {
 				processNeighbors(toCalleeNeighbors(item.sym, callees), item.depth)
 			}
@@ -117,7 +146,7 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 
 		// Find callers: references TO this symbol from other symbols.
 		if opts.Direction == "both" || opts.Direction == "callers" {
-			callers, err := findCallers(cg, item.sym)
+			callers, err := findCallers(cg, item.sym, opts.Exact, opts.TypeRefs)
 			if err == nil {
 				processNeighbors(toCallerNeighbors(item.sym, callers), item.depth)
 			}
@@ -131,6 +160,20 @@ func BuildCallGraph(cg CodeGrapher, symbolName string, opts GraphOptions) (*Call
 	return graph, nil
 }
 
+// displayName returns the qualified name when known, else the bare name.
+func (h SymbolHit) displayName() string {
+	if h.QualifiedName != "" {
+		return h.QualifiedName
+	}
+	return h.Name
+}
+
+// matchesName reports whether h is named q, bare or qualified (see
+// code.Symbol.MatchesName).
+func (h SymbolHit) matchesName(q string) bool {
+	return (&code.Symbol{Name: h.Name, QualifiedName: h.QualifiedName}).MatchesName(q)
+}
+
 // neighbor represents a discovered graph relationship (callee or caller).
 type neighbor struct {
 	edgeFrom string
@@ -143,9 +186,13 @@ type neighbor struct {
 func toCalleeNeighbors(sym SymbolHit, callees []calleeResult) []neighbor {
 	out := make([]neighbor, 0, len(callees))
 	for _, ce := range callees {
+		to := ce.ref.Symbol
+		if ce.target != nil && ce.target.Name == to {
+			to = ce.target.displayName()
+		}
 		out = append(out, neighbor{
-			edgeFrom: sym.Name,
-			edgeTo:   ce.ref.Symbol,
+			edgeFrom: sym.displayName(),
+			edgeTo:   to,
 			ref:      ce.ref,
 			node:     ce.target,
 		})
@@ -159,8 +206,8 @@ func toCallerNeighbors(sym SymbolHit, callers []callerResult) []neighbor {
 	for _, cl := range callers {
 		node := cl.caller // copy so we can take address
 		out = append(out, neighbor{
-			edgeFrom: cl.caller.Name,
-			edgeTo:   sym.Name,
+			edgeFrom: cl.caller.displayName(),
+			edgeTo:   sym.displayName(),
 			ref:      cl.ref,
 			node:     &node,
 		})
@@ -175,7 +222,9 @@ type calleeResult struct {
 }
 
 // findCallees returns the symbols called from within the given symbol's body.
-func findCallees(cg CodeGrapher, sym SymbolHit) ([]calleeResult, error) {
+// A reference with a resolved target links to that definition; otherwise the
+// first same-named symbol is assumed, unless exact is set.
+func findCallees(cg CodeGrapher, sym SymbolHit, exact bool) ([]calleeResult, error) {
 	if sym.EndLine <= 0 {
 		return nil, nil // Can't determine body range
 	}
@@ -197,6 +246,9 @@ func findCallees(cg CodeGrapher, sym SymbolHit) ([]calleeResult, error) {
 		if ref.Symbol == sym.Name || ref.Kind == "import" {
 			continue
 		}
+		if exact && ref.TargetID == "" {
+			continue
+		}
 		// Deduplicate by target symbol name.
 		if seen[ref.Symbol] {
 			continue
@@ -207,15 +259,7 @@ func findCallees(cg CodeGrapher, sym SymbolHit) ([]calleeResult, error) {
 		var target *SymbolHit
 		hits, err := cg.FindSymbols(ref.Symbol, "", 5)
 		if err == nil && len(hits) > 0 {
-			// Pick exact name match.
-			best := hits[0]
-			for _, h := range hits {
-				if h.Name == ref.Symbol {
-					best = h
-					break
-				}
-			}
-			target = &best
+			target = pickCallee(hits, ref, exact)
 		}
 
 		results = append(results, calleeResult{ref: ref, target: target})
@@ -224,18 +268,49 @@ func findCallees(cg CodeGrapher, sym SymbolHit) ([]calleeResult, error) {
 	return results, nil
 }
 
+// pickCallee chooses ref's definition among hits: the resolved target when
+// present, else (unless exact) the first exact name match or first hit.
+func pickCallee(hits []SymbolHit, ref ReferenceHit, exact bool) *SymbolHit {
+	if ref.TargetID != "" {
+		for i := range hits {
+			if hits[i].ID == ref.TargetID {
+				return &hits[i]
+			}
+		}
+	}
+	if exact {
+		return nil
+	}
+	for i := range hits {
+		if hits[i].Name == ref.Symbol {
+			return &hits[i]
+		}
+	}
+	return &hits[0]
+}
+
 // callerResult pairs a calling symbol with the reference site.
 type callerResult struct {
 	caller SymbolHit
 	ref    ReferenceHit
 }
 
-// findCallers returns the symbols that call the given symbol.
-func findCallers(cg CodeGrapher, sym SymbolHit) ([]callerResult, error) {
+// findCallers returns the symbols that call the given symbol, or with
+// typeRefs also those referring to it as a type. References the indexer
+// resolved to a different definition are skipped, as are unresolved ones
+// when exact is set.
+func findCallers(cg CodeGrapher, sym SymbolHit, exact, typeRefs bool) ([]callerResult, error) {
 	refs, err := cg.FindReferences(sym.Name, "call", 50)
 	if err != nil {
 		return nil, err
 	}
+	if typeRefs {
+		more, err := cg.FindReferences(sym.Name, "type_ref", 50)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, more...)
+	}
 
 	seen := make(map[string]bool)
 	var results []callerResult
@@ -245,6 +320,9 @@ func findCallers(cg CodeGrapher, sym SymbolHit) ([]callerResult, error) {
 		if ref.FilePath == sym.FilePath && ref.Line >= sym.Line && ref.Line <= sym.EndLine {
 			continue
 		}
+		if !targetsSymbol(ref, sym, exact) {
+			continue
+		}
 
 		// Resolve the calling symbol via GetContainingSymbol.
 		caller, err := cg.GetContainingSymbol(ref.FilePath, ref.Line)
@@ -253,7 +331,7 @@ func findCallers(cg CodeGrapher, sym SymbolHit) ([]callerResult, error) {
 		}
 
 		// Deduplicate by caller.
-		key := caller.FilePath + ":" + caller.Name
+		key := caller.FilePath + ":" + caller.displayName()
 		if seen[key] {
 			continue
 		}
@@ -264,3 +342,16 @@ func findCallers(cg CodeGrapher, sym SymbolHit) ([]callerResult, error) {
 
 	return results, nil
 }
+
+// targetsSymbol reports whether ref may point at sym: its resolved target is
+// sym, or it is unresolved and exact is not set. Without a symbol ID (e.g.
+// over gRPC) the resolution cannot be checked and every reference counts.
+func targetsSymbol(ref ReferenceHit, sym SymbolHit, exact bool) bool {
+	if sym.ID == "" {
+		return true
+	}
+	if ref.TargetID == "" {
+		return !exact
+	}
+	return ref.TargetID == sym.ID
+}
@@ -126,6 +126,32 @@ func TestBuildCallGraph_SingleNode(t *testing.T) {
 	}
 }
 
+func TestBuildCallGraph_QualifiedRoot(t *testing.T) {
+	cg := newMockCodeGrapher()
+	closers := []SymbolHit{
+		{Name: "Close", QualifiedName: "main.Handler.Close", Kind: "method", FilePath: "main.go", Line: 1, EndLine: 3, Language: "go"},
+		{Name: "Close", QualifiedName: "store.CodeStore.Close", Kind: "method", FilePath: "store/code.go", Line: 10, EndLine: 20, Language: "go"},
+	}
+	cg.symbols["CodeStore.Close"] = closers
+	cg.symbols["flush"] = []SymbolHit{
+		{Name: "flush", QualifiedName: "store.flush", Kind: "function", FilePath: "store/flush.go", Line: 1, EndLine: 5, Language: "go"},
+	}
+	cg.fileRefs["store/code.go"] = []ReferenceHit{
+		{Symbol: "flush", Kind: "call", FilePath: "store/code.go", Line: 12},
+	}
+
+	graph, err := BuildCallGraph(cg, "CodeStore.Close", GraphOptions{Direction: "callees", MaxDepth: 1})
+	if err != nil {
+		t.Fatalf("BuildCallGraph: %v", err)
+	}
+	if graph.Root != "store.CodeStore.Close" {
+		t.Errorf("expected qualified root, got %q", graph.Root)
+	}
+	if len(graph.Edges) != 1 || graph.Edges[0].From != "store.CodeStore.Close" || graph.Edges[0].To != "store.flush" {
+		t.Errorf("expected edge store.CodeStore.Close->store.flush, got %+v", graph.Edges)
+	}
+}
+
 func TestBuildCallGraph_Callees(t *testing.T) {
 	cg := newMockCodeGrapher()
 
@@ -195,6 +221,45 @@ func TestBuildCallGraph_Callers(t *testing.T) {
 	}
 }
 
+func TestBuildCallGraph_CallersResolvedTargets(t *testing.T) {
+	cg := newMockCodeGrapher()
+
+	cg.symbols["helper"] = []SymbolHit{
+		{ID: "util-helper", Name: "helper", Kind: "function", FilePath: "util.go", Line: 5, EndLine: 15, Language: "go"},
+	}
+	// main resolves to this helper, other to a different one, and the
+	// script reference was left unresolved.
+	cg.references["helper"] = []ReferenceHit{
+		{Symbol: "helper", Kind: "call", FilePath: "main.go", Line: 8, TargetID: "util-helper", Confidence: "medium"},
+		{Symbol: "helper", Kind: "call", FilePath: "other/other.go", Line: 4, TargetID: "other-helper", Confidence: "high"},
+		{Symbol: "helper", Kind: "call", FilePath: "script.go", Line: 3},
+	}
+	cg.containing["main.go:8"] = &SymbolHit{Name: "main", Kind: "function", FilePath: "main.go", Line: 1, EndLine: 20}
+	cg.containing["other/other.go:4"] = &SymbolHit{Name: "other", Kind: "function", FilePath: "other/other.go", Line: 1, EndLine: 10}
+	cg.containing["script.go:3"] = &SymbolHit{Name: "script", Kind: "function", FilePath: "script.go", Line: 1, EndLine: 10}
+
+	callers := func(opts GraphOptions) []string {
+		t.Helper()
+		opts.Direction, opts.MaxDepth = "callers", 1
+		graph, err := BuildCallGraph(cg, "helper", opts)
+		if err != nil {
+			t.Fatalf("BuildCallGraph: %v", err)
+		}
+		var from []string
+		for _, e := range graph.Edges {
+			from = append(from, e.From)
+		}
+		return from
+	}
+
+	if got := callers(GraphOptions{}); fmt.Sprint(got) != "[main script]" {
+		t.Errorf("expected callers [main script], got %v", got)
+	}
+	if got := callers(GraphOptions{Exact: true}); fmt.Sprint(got) != "[main]" {
+		t.Errorf("expected exact callers [main], got %v", got)
+	}
+}
+
 func TestBuildCallGraph_BothDirections(t *testing.T) {
 	cg := newMockCodeGrapher()
 
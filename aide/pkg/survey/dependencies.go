@@ -0,0 +1,551 @@
+// Package survey: dependencies.go inventories external dependencies from
+// manifests and lockfiles, and attributes each one to the source files that
+// import it. Manifests say what a workspace declares, lockfiles what version
+// actually resolved and what came along transitively; the import graph says
+// whether anything still uses it.
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
+)
+
+// Dependency ecosystems, recorded in the "ecosystem" metadata key. Names
+// follow the OSV ecosystem vocabulary, lowercased.
+const (
+	EcosystemGo    = "go"
+	EcosystemNpm   = "npm"
+	EcosystemCargo = "cargo"
+	EcosystemPyPI  = "pypi"
+	EcosystemMaven = "maven"
+	EcosystemNuGet = "nuget"
+)
+
+// Dependency scopes, recorded in the "scope" metadata key.
+const (
+	ScopeRuntime = "runtime"
+	ScopeBuild   = "build" // build scripts, annotation processors, provided
+	ScopeDev     = "dev"
+	ScopeTest    = "test"
+)
+
+// MaxDependencyImporters caps the importer list stored per entry; the full
+// count is kept in "importer_count".
+const MaxDependencyImporters = 20
+
+// DependenciesSource abstracts the code index for import attribution.
+// ModulesSource implementations satisfy it.
+type DependenciesSource interface {
+	// ListSourceFiles returns every indexed source file with its language.
+	ListSourceFiles() ([]ModuleFile, error)
+	// FileReferences returns all references made in a file.
+	FileReferences(filePath string) ([]ReferenceHit, error)
+}
+
+// DependenciesConfig configures a dependencies run.
+type DependenciesConfig struct {
+	RootDir  string
+	Source   DependenciesSource      // nil = no import attribution
+	Resolver *importresolve.Resolver // nil = constructed from RootDir
+}
+
+// DependenciesResult is the outcome of a dependencies run.
+type DependenciesResult struct {
+	Entries    []*Entry
+	Manifests  int // manifests and lockfiles parsed
+	Direct     int
+	Transitive int
+	Attributed bool // importers were attributed from the code index
+}
+
+// Dependency is one external dependency of one workspace.
+type Dependency struct {
+	Ecosystem  string
+	Name       string
+	Version    string // resolved version when a lockfile has it, else as declared
+	Constraint string // declared requirement, when it differs from Version
+	Scope      string
+	Direct     bool
+	Workspace  string // project-relative dir of the declaring manifest ("" = root)
+	Manifest   string // project-relative path of the declaring manifest or lockfile
+	Importers  []string
+}
+
+// RunDependencies parses every manifest and lockfile under RootDir (pruned
+// like topology: .aideignore, hidden and vendored directories) and emits one
+// KindDependency entry per external dependency per workspace.
+func RunDependencies(cfg DependenciesConfig) (*DependenciesResult, error) {
+	result := &DependenciesResult{}
+	var files []*manifestFile
+	walkFiles(cfg.RootDir, -1, nil, isDependencyFile, func(p string) {
+		m := parseDependencyFile(p)
+		if m == nil {
+			return
+		}
+		rel, err := filepath.Rel(cfg.RootDir, p)
+		if err != nil {
+			return
+		}
+		m.path = filepath.ToSlash(rel)
+		m.dir = path.Dir(m.path)
+		if m.dir == "." {
+			m.dir = ""
+		}
+		files = append(files, m)
+	})
+	result.Manifests = len(files)
+
+	deps := mergeDependencies(files)
+	if cfg.Source != nil {
+		resolver := cfg.Resolver
+		if resolver == nil {
+			resolver = importresolve.New(cfg.RootDir)
+		}
+		if err := attributeImporters(deps, cfg.Source, resolver); err != nil {
+			return nil, err
+		}
+		result.Attributed = true
+	}
+
+	for _, d := range deps {
+		if d.Direct {
+			result.Direct++
+		} else {
+			result.Transitive++
+		}
+		result.Entries = append(result.Entries, d.entry(result.Attributed))
+	}
+	return result, nil
+}
+
+// mergeDependencies combines manifests and lockfiles into one dependency
+// per (ecosystem, workspace, name). Declared dependencies take their
+// resolved version from the nearest lockfile at or above their workspace;
+// lockfile packages no workspace under the lockfile declares become
+// transitive entries of the lockfile's directory. Anything the project
+// itself provides (workspace members, path and local-replace references) is
+// dropped.
+func mergeDependencies(files []*manifestFile) []*Dependency {
+	internal := make(map[string]bool) // ecosystem|name
+	for _, m := range files {
+		for _, name := range m.self {
+			internal[m.ecosystem+"|"+name] = true
+		}
+		for name, p := range m.locked {
+			if p.local {
+				internal[m.ecosystem+"|"+name] = true
+			}
+		}
+	}
+
+	byKey := make(map[string]*Dependency)
+	var out []*Dependency
+	for _, m := range files {
+		if m.locked != nil {
+			continue
+		}
+		for _, dd := range m.deps {
+			if dd.local || internal[m.ecosystem+"|"+dd.name] {
+				continue
+			}
+			key := m.ecosystem + "|" + m.dir + "|" + dd.name
+			d, seen := byKey[key]
+			if !seen {
+				d = &Dependency{Ecosystem: m.ecosystem, Name: dd.name, Version: dd.version, Scope: dd.scope,
+					Direct: !dd.indirect, Workspace: m.dir, Manifest: m.path}
+				byKey[key] = d
+				out = append(out, d)
+				continue
+			}
+			// Declared twice (dependencies + devDependencies, pom + gradle):
+			// the broader scope and a direct declaration win.
+			if scopeRank(dd.scope) < scopeRank(d.Scope) {
+				d.Scope = dd.scope
+			}
+			if !dd.indirect {
+				d.Direct = true
+			}
+			if d.Version == "" {
+				d.Version = dd.version
+			}
+		}
+	}
+
+	// Resolve declared versions against the nearest lockfile. go.mod
+	// already pins the selected version; go.sum only adds what it omits.
+	for _, d := range out {
+		if d.Ecosystem == EcosystemGo {
+			continue
+		}
+		lock := nearestLock(files, d.Ecosystem, d.Workspace)
+		if lock == nil {
+			continue
+		}
+		if p, ok := lock.locked[d.Name]; ok && p.version != "" && p.version != d.Version {
+			d.Constraint = d.Version
+			d.Version = p.version
+		}
+	}
+
+	// Lockfile packages nobody under the lockfile declares are transitive.
+	for _, m := range files {
+		if m.locked == nil {
+			continue
+		}
+		declared := make(map[string]bool)
+		for _, d := range out {
+			if d.Ecosystem == m.ecosystem && pathWithin(d.Workspace, m.dir) {
+				declared[d.Name] = true
+			}
+		}
+		for _, name := range sortedKeys(m.locked) {
+			p := m.locked[name]
+			if p.local || declared[name] || internal[m.ecosystem+"|"+name] {
+				continue
+			}
+			key := m.ecosystem + "|" + m.dir + "|" + name
+			if _, seen := byKey[key]; seen {
+				continue
+			}
+			scope := ScopeRuntime
+			if p.dev {
+				scope = ScopeDev
+			}
+			d := &Dependency{Ecosystem: m.ecosystem, Name: name, Version: p.version, Scope: scope, Workspace: m.dir, Manifest: m.path}
+			byKey[key] = d
+			out = append(out, d)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Workspace != b.Workspace {
+			return a.Workspace < b.Workspace
+		}
+		if a.Direct != b.Direct {
+			return a.Direct
+		}
+		return a.Name < b.Name
+	})
+	return out
+}
+
+// scopeRank orders scopes broadest first.
+func scopeRank(scope string) int {
+	switch scope {
+	case ScopeRuntime:
+		return 0
+	case ScopeBuild:
+		return 1
+	case ScopeDev:
+		return 2
+	case ScopeTest:
+		return 3
+	}
+	return 4
+}
+
+// nearestLock returns the ecosystem's lockfile in the deepest directory at
+// or above dir.
+func nearestLock(files []*manifestFile, ecosystem, dir string) *manifestFile {
+	var best *manifestFile
+	for _, m := range files {
+		if m.locked == nil || m.ecosystem != ecosystem || !pathWithin(dir, m.dir) {
+			continue
+		}
+		if best == nil || len(m.dir) > len(best.dir) {
+			best = m
+		}
+	}
+	return best
+}
+
+// pathWithin reports whether the project-relative path p is dir or lies
+// beneath it ("" is the root and contains everything).
+func pathWithin(p, dir string) bool {
+	return dir == "" || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// languageEcosystems maps grammar-pack languages to the ecosystem their
+// imports come from.
+var languageEcosystems = map[string]string{
+	"go":         EcosystemGo,
+	"typescript": EcosystemNpm,
+	"tsx":        EcosystemNpm,
+	"javascript": EcosystemNpm,
+	"python":     EcosystemPyPI,
+	"rust":       EcosystemCargo,
+	"java":       EcosystemMaven,
+	"kotlin":     EcosystemMaven,
+	"scala":      EcosystemMaven,
+	"csharp":     EcosystemNuGet,
+}
+
+// pythonImportAliases maps distributions whose import name differs from the
+// distribution name. Everything else matches on the normalized name.
+var pythonImportAliases = map[string]string{
+	"pyyaml":          "yaml",
+	"beautifulsoup4":  "bs4",
+	"pillow":          "pil",
+	"scikit_learn":    "sklearn",
+	"python_dateutil": "dateutil",
+	"opencv_python":   "cv2",
+	"attrs":           "attr",
+	"pyjwt":           "jwt",
+	"psycopg2_binary": "psycopg2",
+	"protobuf":        "google",
+}
+
+// dependencyKeys returns the import-side lookup keys for a dependency.
+func dependencyKeys(d *Dependency) []string {
+	switch d.Ecosystem {
+	case EcosystemCargo:
+		return []string{strings.ReplaceAll(d.Name, "-", "_")}
+	case EcosystemPyPI:
+		norm := normalizePyName(d.Name)
+		if alias, ok := pythonImportAliases[norm]; ok {
+			return []string{norm, alias}
+		}
+		return []string{norm}
+	case EcosystemMaven:
+		group, _, _ := strings.Cut(d.Name, ":")
+		return []string{group}
+	case EcosystemNuGet:
+		return []string{strings.ToLower(d.Name)}
+	}
+	return []string{d.Name}
+}
+
+// importKeys returns the candidate lookup keys for an external import
+// string, most specific first.
+func importKeys(ecosystem, imp string) []string {
+	switch ecosystem {
+	case EcosystemGo:
+		return pathPrefixes(imp, "/")
+	case EcosystemNpm:
+		if strings.HasPrefix(imp, ".") || strings.HasPrefix(imp, "node:") {
+			return nil
+		}
+		parts := strings.SplitN(imp, "/", 3)
+		if strings.HasPrefix(imp, "@") && len(parts) >= 2 {
+			return []string{parts[0] + "/" + parts[1]}
+		}
+		return []string{parts[0]}
+	case EcosystemCargo:
+		first, _, _ := strings.Cut(imp, "::")
+		return []string{first}
+	case EcosystemPyPI:
+		if strings.HasPrefix(imp, ".") {
+			return nil
+		}
+		first, _, _ := strings.Cut(imp, ".")
+		return []string{normalizePyName(first)}
+	case EcosystemMaven:
+		return pathPrefixes(imp, ".")
+	case EcosystemNuGet:
+		return pathPrefixes(strings.ToLower(imp), ".")
+	}
+	return nil
+}
+
+// pathPrefixes returns s and each shorter sep-delimited prefix of it.
+func pathPrefixes(s, sep string) []string {
+	var out []string
+	for s != "" {
+		out = append(out, s)
+		i := strings.LastIndex(s, sep)
+		if i < 0 {
+			break
+		}
+		s = s[:i]
+	}
+	return out
+}
+
+// normalizePyName applies PEP 503 normalization, with underscores so
+// distribution and import names compare equal.
+func normalizePyName(name string) string {
+	name = strings.ToLower(name)
+	return strings.NewReplacer("-", "_", ".", "_").Replace(name)
+}
+
+// attributeImporters records, for each dependency, the source files with an
+// import the resolver classifies as external and that maps to the
+// dependency. A file is attributed to the deepest workspace containing it
+// that has a matching dependency.
+func attributeImporters(deps []*Dependency, src DependenciesSource, resolver *importresolve.Resolver) error {
+	index := make(map[string][]*Dependency) // ecosystem|key
+	for _, d := range deps {
+		for _, k := range dependencyKeys(d) {
+			index[d.Ecosystem+"|"+k] = append(index[d.Ecosystem+"|"+k], d)
+		}
+	}
+
+	files, err := src.ListSourceFiles()
+	if err != nil {
+		return fmt.Errorf("list source files: %w", err)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+
+	seen := make(map[*Dependency]map[string]bool)
+	for _, f := range files {
+		eco := languageEcosystems[f.Language]
+		if eco == "" {
+			continue
+		}
+		refs, rerr := src.FileReferences(f.Path)
+		if rerr != nil {
+			continue
+		}
+		for _, ref := range refs {
+			if ref.Kind != "import" {
+				continue
+			}
+			imp := strings.Trim(strings.TrimSpace(ref.Symbol), "\"'`")
+			if imp == "" || resolver.ResolveUnit(f.Language, f.Path, imp) != "" {
+				continue // internal
+			}
+			d := matchImport(index, eco, imp, f.Path)
+			if d == nil {
+				continue
+			}
+			if seen[d] == nil {
+				seen[d] = make(map[string]bool)
+			}
+			if !seen[d][f.Path] {
+				seen[d][f.Path] = true
+				d.Importers = append(d.Importers, f.Path)
+			}
+		}
+	}
+	return nil
+}
+
+// matchImport finds the dependency an external import comes from: the most
+// specific key with a dependency whose workspace contains the file, deepest
+// workspace first. Maven group matches prefer the artifact whose name
+// appears in the import (org.yaml:snakeyaml for org.yaml.snakeyaml.Yaml).
+func matchImport(index map[string][]*Dependency, eco, imp, file string) *Dependency {
+	for _, key := range importKeys(eco, imp) {
+		var best *Dependency
+		for _, d := range index[eco+"|"+key] {
+			if !pathWithin(file, d.Workspace) {
+				continue
+			}
+			if best == nil || len(d.Workspace) > len(best.Workspace) ||
+				(len(d.Workspace) == len(best.Workspace) && eco == EcosystemMaven && artifactInImport(d.Name, imp) && !artifactInImport(best.Name, imp)) {
+				best = d
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// artifactInImport reports whether the last hyphen-separated word of a Maven
+// artifactId is a segment of the import path.
+func artifactInImport(coord, imp string) bool {
+	_, artifact, _ := strings.Cut(coord, ":")
+	if i := strings.LastIndex(artifact, "-"); i >= 0 {
+		artifact = artifact[i+1:]
+	}
+	for _, seg := range strings.Split(imp, ".") {
+		if strings.EqualFold(seg, artifact) {
+			return true
+		}
+	}
+	return false
+}
+
+// entry renders a dependency as a survey entry. attributed reports whether
+// importer data exists at all — an empty list only means "unused" then.
+func (d *Dependency) entry(attributed bool) *Entry {
+	relation := "transitive"
+	if d.Direct {
+		relation = "direct"
+	}
+	title := fmt.Sprintf("%s dependency %s", d.Ecosystem, d.Name)
+	if d.Version != "" {
+		title += " " + d.Version
+	}
+	title += fmt.Sprintf(" (%s, %s)", relation, d.Scope)
+
+	workspace := d.Workspace
+	if workspace == "" {
+		workspace = "."
+	}
+	meta := map[string]string{
+		"ecosystem": d.Ecosystem,
+		"version":   d.Version,
+		"scope":     d.Scope,
+		"direct":    strconv.FormatBool(d.Direct),
+		"workspace": workspace,
+		"manifest":  d.Manifest,
+	}
+	if d.Constraint != "" {
+		meta["constraint"] = d.Constraint
+	}
+
+	detail := fmt.Sprintf("Declared in %s.", d.Manifest)
+	if !d.Direct {
+		detail = fmt.Sprintf("Pulled in transitively (%s).", d.Manifest)
+	}
+	if attributed {
+		meta["importer_count"] = strconv.Itoa(len(d.Importers))
+		importers := d.Importers
+		if len(importers) > MaxDependencyImporters {
+			importers = importers[:MaxDependencyImporters]
+		}
+		if len(importers) > 0 {
+			data, _ := json.Marshal(importers)
+			meta["importers"] = string(data)
+			detail += fmt.Sprintf(" Imported by %d file(s): %s", len(d.Importers), strings.Join(importers, ", "))
+			if len(d.Importers) > len(importers) {
+				detail += fmt.Sprintf(", +%d more", len(d.Importers)-len(importers))
+			}
+			detail += "."
+		} else if d.Direct {
+			detail += " No indexed source file imports it."
+		}
+	}
+
+	if terms := nameTerms(d.Name); terms != "" {
+		detail += " Terms: " + terms + "."
+	}
+
+	return &Entry{
+		Analyzer: AnalyzerDependencies,
+		Kind:     KindDependency,
+		Name:     d.Name,
+		FilePath: d.Manifest,
+		Title:    title,
+		Detail:   detail,
+		Metadata: meta,
+	}
+}
+
+// nameTerms splits a dotted dependency name into words. The survey index's
+// unicode tokenizer keeps "yaml.v3" or "Newtonsoft.Json" as single tokens,
+// so without these a search for "yaml" misses gopkg.in/yaml.v3. Empty for
+// names the tokenizer already splits.
+func nameTerms(name string) string {
+	if !strings.Contains(name, ".") {
+		return ""
+	}
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	return strings.Join(words, " ")
+}
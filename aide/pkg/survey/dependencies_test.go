@@ -0,0 +1,225 @@
+package survey
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// dependenciesFixture is a Go module alongside an npm workspace with a root
+// lockfile: web/ declares yaml and a workspace sibling, the lockfile adds a
+// transitive package.
+func dependenciesFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range map[string]string{
+		"go.mod": `module example.com/app
+
+go 1.22
+
+require (
+	gopkg.in/yaml.v3 v3.0.1
+	github.com/stretchr/testify v1.9.0 // indirect
+	example.com/shared v0.0.0
+)
+
+require github.com/google/uuid v1.6.0
+
+replace example.com/shared => ./shared
+`,
+		"go.sum": `gopkg.in/yaml.v3 v3.0.1 h1:x=
+gopkg.in/yaml.v3 v3.0.1/go.mod h1:y=
+github.com/davecgh/go-spew v1.1.0 h1:a=
+github.com/davecgh/go-spew v1.1.1 h1:b=
+github.com/old/only-gomod v1.0.0/go.mod h1:c=
+`,
+		"cmd/app/main.go":     "package main\n",
+		"internal/cfg/cfg.go": "package cfg\n",
+		"package.json":        `{"name": "root", "private": true, "workspaces": ["web", "lib"]}`,
+		"web/package.json": `{
+  "name": "@acme/web",
+  "dependencies": {"yaml": "^2.3.0", "@acme/lib": "workspace:*"},
+  "devDependencies": {"vitest": "^1.0.0"}
+}`,
+		"lib/package.json": `{"name": "@acme/lib"}`,
+		"package-lock.json": `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "root"},
+    "web": {"name": "@acme/web"},
+    "node_modules/@acme/lib": {"resolved": "lib", "link": true},
+    "node_modules/yaml": {"version": "2.4.1"},
+    "node_modules/vitest": {"version": "1.2.0", "dev": true},
+    "node_modules/tinypool": {"version": "0.8.0", "dev": true},
+    "node_modules/vitest/node_modules/tinypool": {"version": "0.7.0", "dev": true}
+  }
+}`,
+		"web/src/config.ts":              "",
+		"node_modules/yaml/package.json": `{"name": "yaml", "dependencies": {"never": "1"}}`,
+	} {
+		writeTestFile(t, root, rel, content)
+	}
+	return root
+}
+
+func dependencyByName(t *testing.T, entries []*Entry, name, workspace string) *Entry {
+	t.Helper()
+	for _, e := range entries {
+		if e.Name == name && e.Metadata["workspace"] == workspace {
+			return e
+		}
+	}
+	t.Fatalf("no dependency entry %s in workspace %s", name, workspace)
+	return nil
+}
+
+func TestRunDependencies(t *testing.T) {
+	root := dependenciesFixture(t)
+	src := &fakeModulesSource{
+		files: []ModuleFile{
+			{Path: "cmd/app/main.go", Language: "go"},
+			{Path: "internal/cfg/cfg.go", Language: "go"},
+			{Path: "web/src/config.ts", Language: "typescript"},
+		},
+		refs: map[string][]ReferenceHit{
+			"cmd/app/main.go": {
+				{Symbol: `"example.com/app/internal/cfg"`, Kind: "import"},
+				{Symbol: `"fmt"`, Kind: "import"},
+			},
+			"internal/cfg/cfg.go": {
+				{Symbol: `"gopkg.in/yaml.v3"`, Kind: "import"},
+				{Symbol: "Unmarshal", Kind: "call"},
+			},
+			"web/src/config.ts": {
+				{Symbol: "yaml", Kind: "import"},
+				{Symbol: "@acme/lib/util", Kind: "import"},
+			},
+		},
+	}
+
+	result, err := RunDependencies(DependenciesConfig{RootDir: root, Source: src})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Attributed {
+		t.Error("Attributed = false with a source")
+	}
+	if result.Manifests != 6 {
+		t.Errorf("manifests = %d, want 6 (node_modules is pruned)", result.Manifests)
+	}
+
+	names := make(map[string]bool)
+	for _, e := range result.Entries {
+		if e.Analyzer != AnalyzerDependencies || e.Kind != KindDependency {
+			t.Errorf("entry %s: analyzer/kind = %s/%s", e.Name, e.Analyzer, e.Kind)
+		}
+		names[e.Name] = true
+	}
+	for _, internal := range []string{"example.com/shared", "@acme/lib", "@acme/web", "never"} {
+		if names[internal] {
+			t.Errorf("project-internal or vendored %s recorded as a dependency", internal)
+		}
+	}
+
+	yamlGo := dependencyByName(t, result.Entries, "gopkg.in/yaml.v3", ".")
+	if yamlGo.Metadata["version"] != "v3.0.1" || yamlGo.Metadata["direct"] != "true" || yamlGo.Metadata["scope"] != ScopeRuntime {
+		t.Errorf("yaml.v3 metadata = %v", yamlGo.Metadata)
+	}
+	var importers []string
+	if err := json.Unmarshal([]byte(yamlGo.Metadata["importers"]), &importers); err != nil || len(importers) != 1 || importers[0] != "internal/cfg/cfg.go" {
+		t.Errorf("yaml.v3 importers = %q, want [internal/cfg/cfg.go]", yamlGo.Metadata["importers"])
+	}
+
+	if !strings.Contains(yamlGo.Detail, "Terms: gopkg in yaml v3.") {
+		t.Errorf("yaml.v3 detail lacks split name terms: %s", yamlGo.Detail)
+	}
+
+	uuid := dependencyByName(t, result.Entries, "github.com/google/uuid", ".")
+	if uuid.Metadata["importer_count"] != "0" || uuid.Metadata["importers"] != "" {
+		t.Errorf("unused uuid importers = %v", uuid.Metadata)
+	}
+	if testify := dependencyByName(t, result.Entries, "github.com/stretchr/testify", "."); testify.Metadata["direct"] != "false" {
+		t.Error("// indirect requirement recorded as direct")
+	}
+	spew := dependencyByName(t, result.Entries, "github.com/davecgh/go-spew", ".")
+	if spew.Metadata["direct"] != "false" || spew.Metadata["version"] != "v1.1.1" || spew.FilePath != "go.sum" {
+		t.Errorf("go.sum-only module = %+v %v", spew, spew.Metadata)
+	}
+	if names["github.com/old/only-gomod"] {
+		t.Error("module with only a /go.mod checksum recorded")
+	}
+
+	yamlNpm := dependencyByName(t, result.Entries, "yaml", "web")
+	if yamlNpm.Metadata["version"] != "2.4.1" || yamlNpm.Metadata["constraint"] != "^2.3.0" {
+		t.Errorf("npm yaml version/constraint = %v", yamlNpm.Metadata)
+	}
+	if yamlNpm.Metadata["importers"] != `["web/src/config.ts"]` {
+		t.Errorf("npm yaml importers = %q", yamlNpm.Metadata["importers"])
+	}
+	if vitest := dependencyByName(t, result.Entries, "vitest", "web"); vitest.Metadata["scope"] != ScopeDev {
+		t.Errorf("vitest scope = %s, want dev", vitest.Metadata["scope"])
+	}
+	tinypool := dependencyByName(t, result.Entries, "tinypool", ".")
+	if tinypool.Metadata["direct"] != "false" || tinypool.Metadata["version"] != "0.8.0" || tinypool.Metadata["scope"] != ScopeDev {
+		t.Errorf("transitive tinypool = %v, want hoisted 0.8.0, dev", tinypool.Metadata)
+	}
+}
+
+func TestRunDependenciesWithoutCodeIndex(t *testing.T) {
+	result, err := RunDependencies(DependenciesConfig{RootDir: dependenciesFixture(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Attributed {
+		t.Error("Attributed = true without a source")
+	}
+	if result.Direct == 0 || result.Transitive == 0 {
+		t.Errorf("direct/transitive = %d/%d, want both", result.Direct, result.Transitive)
+	}
+	e := dependencyByName(t, result.Entries, "github.com/google/uuid", ".")
+	if _, ok := e.Metadata["importer_count"]; ok {
+		t.Error("importer_count set without import attribution")
+	}
+}
+
+func TestMatchImport(t *testing.T) {
+	deps := []*Dependency{
+		{Ecosystem: EcosystemPyPI, Name: "PyYAML"},
+		{Ecosystem: EcosystemCargo, Name: "serde-json"},
+		{Ecosystem: EcosystemMaven, Name: "org.yaml:snakeyaml"},
+		{Ecosystem: EcosystemMaven, Name: "com.example:core-api"},
+		{Ecosystem: EcosystemMaven, Name: "com.example:core-util"},
+		{Ecosystem: EcosystemNuGet, Name: "Newtonsoft.Json"},
+		{Ecosystem: EcosystemNpm, Name: "@scope/pkg"},
+		{Ecosystem: EcosystemNpm, Name: "lodash", Workspace: "web"},
+	}
+	index := make(map[string][]*Dependency)
+	for _, d := range deps {
+		for _, k := range dependencyKeys(d) {
+			index[d.Ecosystem+"|"+k] = append(index[d.Ecosystem+"|"+k], d)
+		}
+	}
+	tests := []struct {
+		eco, imp, file, want string
+	}{
+		{EcosystemPyPI, "yaml", "app.py", "PyYAML"},
+		{EcosystemPyPI, "yaml.constructor", "app.py", "PyYAML"},
+		{EcosystemCargo, "serde_json::Value", "src/lib.rs", "serde-json"},
+		{EcosystemMaven, "org.yaml.snakeyaml.Yaml", "A.java", "org.yaml:snakeyaml"},
+		{EcosystemMaven, "com.example.util.Strings", "A.java", "com.example:core-util"},
+		{EcosystemNuGet, "Newtonsoft.Json.Linq", "A.cs", "Newtonsoft.Json"},
+		{EcosystemNpm, "@scope/pkg/sub/path", "index.ts", "@scope/pkg"},
+		{EcosystemNpm, "lodash/fp", "web/a.ts", "lodash"},
+		{EcosystemNpm, "lodash", "api/a.ts", ""}, // outside the declaring workspace
+		{EcosystemNpm, "./local", "index.ts", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if d := matchImport(index, tt.eco, tt.imp, tt.file); d != nil {
+			got = d.Name
+		}
+		if got != tt.want {
+			t.Errorf("matchImport(%s, %q, %s) = %q, want %q", tt.eco, tt.imp, tt.file, got, tt.want)
+		}
+	}
+}
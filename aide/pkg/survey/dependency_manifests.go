@@ -0,0 +1,1154 @@
+package survey
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// declaredDep is one dependency read from a manifest.
+type declaredDep struct {
+	name     string
+	version  string // declared version or constraint ("" if none)
+	scope    string
+	indirect bool // go.mod "// indirect" requirement
+	local    bool // path/workspace/file reference — part of the project, not external
+}
+
+// lockedPkg is one resolved package read from a lockfile.
+type lockedPkg struct {
+	version string
+	dev     bool
+	local   bool // workspace member or path package
+}
+
+// manifestFile is a parsed manifest or lockfile. Parsing is best-effort: a
+// file that cannot be read or decoded yields nil, never an error.
+type manifestFile struct {
+	ecosystem string
+	dir       string               // project-relative directory ("" = root)
+	path      string               // project-relative file path
+	self      []string             // names the manifest declares for its own project
+	deps      []declaredDep        // manifests only
+	locked    map[string]lockedPkg // lockfiles only
+}
+
+// isDependencyFile reports whether a filename is a manifest or lockfile the
+// dependencies analyzer parses.
+func isDependencyFile(name string) bool {
+	switch name {
+	case "go.mod", "go.sum",
+		"package.json", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lock",
+		"Cargo.toml", "Cargo.lock",
+		"pyproject.toml", "poetry.lock", "uv.lock",
+		"pom.xml", "build.gradle", "build.gradle.kts",
+		"packages.lock.json":
+		return true
+	}
+	switch filepath.Ext(name) {
+	case ".csproj", ".fsproj", ".vbproj":
+		return true
+	}
+	return isRequirementsFile(name)
+}
+
+// isRequirementsFile matches pip requirement files: requirements.txt and its
+// common variants (requirements-dev.txt, test-requirements.txt).
+func isRequirementsFile(name string) bool {
+	return strings.HasSuffix(name, ".txt") && strings.Contains(name, "requirements")
+}
+
+// parseDependencyFile dispatches on the filename. Returns nil for unreadable
+// or unrecognised files.
+func parseDependencyFile(absPath string) *manifestFile {
+	name := filepath.Base(absPath)
+	switch name {
+	case "go.mod":
+		return parseGoMod(absPath)
+	case "go.sum":
+		return parseGoSum(absPath)
+	case "package.json":
+		return parsePackageJSON(absPath)
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return parsePackageLock(absPath)
+	case "yarn.lock":
+		return parseYarnLock(absPath)
+	case "pnpm-lock.yaml":
+		return parsePnpmLock(absPath)
+	case "bun.lock":
+		return parseBunLock(absPath)
+	case "Cargo.toml":
+		return parseCargoToml(absPath)
+	case "Cargo.lock":
+		return parseTOMLLock(absPath, EcosystemCargo)
+	case "pyproject.toml":
+		return parsePyproject(absPath)
+	case "poetry.lock", "uv.lock":
+		return parseTOMLLock(absPath, EcosystemPyPI)
+	case "pom.xml":
+		return parsePom(absPath)
+	case "build.gradle", "build.gradle.kts":
+		return parseGradle(absPath)
+	case "packages.lock.json":
+		return parseNuGetLock(absPath)
+	}
+	switch filepath.Ext(name) {
+	case ".csproj", ".fsproj", ".vbproj":
+		return parseMSBuildProject(absPath)
+	}
+	if isRequirementsFile(name) {
+		return parseRequirements(absPath)
+	}
+	return nil
+}
+
+// scanLines calls fn for each line of a file. Returns false when the file
+// cannot be opened.
+func scanLines(absPath string, fn func(line string)) bool {
+	f, err := os.Open(absPath)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+	return true
+}
+
+// =============================================================================
+// Go
+// =============================================================================
+
+// parseGoMod reads require directives; "// indirect" marks a transitive
+// requirement. A module replaced by a local path is part of the project.
+func parseGoMod(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemGo}
+	localReplace := make(map[string]bool)
+	block := ""
+	ok := scanLines(absPath, func(raw string) {
+		line := strings.TrimSpace(raw)
+		indirect := strings.HasSuffix(line, "// indirect")
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" {
+			return
+		}
+		directive := block
+		if block != "" {
+			if line == ")" {
+				block = ""
+				return
+			}
+		} else {
+			var rest string
+			directive, rest, _ = strings.Cut(line, " ")
+			rest = strings.TrimSpace(rest)
+			switch directive {
+			case "module":
+				m.self = append(m.self, strings.Trim(rest, `"`))
+				return
+			case "require", "replace":
+				if rest == "(" {
+					block = directive
+					return
+				}
+				line = rest
+			default:
+				return
+			}
+		}
+		fields := strings.Fields(line)
+		switch directive {
+		case "require":
+			if len(fields) >= 2 {
+				m.deps = append(m.deps, declaredDep{name: fields[0], version: fields[1], scope: ScopeRuntime, indirect: indirect})
+			}
+		case "replace":
+			if i := indexOf(fields, "=>"); i > 0 && i+1 < len(fields) {
+				if target := fields[i+1]; strings.HasPrefix(target, ".") || strings.HasPrefix(target, "/") {
+					localReplace[fields[0]] = true
+				}
+			}
+		}
+	})
+	if !ok {
+		return nil
+	}
+	for i := range m.deps {
+		m.deps[i].local = localReplace[m.deps[i].name]
+	}
+	return m
+}
+
+// parseGoSum records the highest version of each module whose content (not
+// just its go.mod) is checksummed — the modules the build actually used.
+func parseGoSum(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemGo, locked: make(map[string]lockedPkg)}
+	ok := scanLines(absPath, func(line string) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasSuffix(fields[1], "/go.mod") {
+			return
+		}
+		if prev, seen := m.locked[fields[0]]; !seen || versionLess(prev.version, fields[1]) {
+			m.locked[fields[0]] = lockedPkg{version: fields[1]}
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// =============================================================================
+// npm
+// =============================================================================
+
+// parsePackageJSON reads the four dependency maps. workspace:, file:, link:
+// and portal: specifiers point inside the project.
+func parsePackageJSON(absPath string) *manifestFile {
+	var pkg struct {
+		Name                 string            `json:"name"`
+		Dependencies         map[string]string `json:"dependencies"`
+		DevDependencies      map[string]string `json:"devDependencies"`
+		PeerDependencies     map[string]string `json:"peerDependencies"`
+		OptionalDependencies map[string]string `json:"optionalDependencies"`
+	}
+	if !readJSON(absPath, &pkg) {
+		return nil
+	}
+	m := &manifestFile{ecosystem: EcosystemNpm}
+	if pkg.Name != "" {
+		m.self = append(m.self, pkg.Name)
+	}
+	for _, group := range []struct {
+		deps  map[string]string
+		scope string
+	}{
+		{pkg.Dependencies, ScopeRuntime},
+		{pkg.PeerDependencies, ScopeRuntime},
+		{pkg.OptionalDependencies, ScopeRuntime},
+		{pkg.DevDependencies, ScopeDev},
+	} {
+		for _, name := range sortedKeys(group.deps) {
+			spec := group.deps[name]
+			local := false
+			for _, p := range []string{"workspace:", "file:", "link:", "portal:"} {
+				if strings.HasPrefix(spec, p) {
+					local = true
+				}
+			}
+			m.deps = append(m.deps, declaredDep{name: name, version: spec, scope: group.scope, local: local})
+		}
+	}
+	return m
+}
+
+// lockV1Dep is a package-lock.json v1 "dependencies" node.
+type lockV1Dep struct {
+	Version      string               `json:"version"`
+	Dev          bool                 `json:"dev"`
+	Dependencies map[string]lockV1Dep `json:"dependencies"`
+}
+
+// parsePackageLock reads package-lock.json: the v2/v3 "packages" map, or the
+// v1 nested "dependencies" tree. A package installed at several depths keeps
+// its most-hoisted version.
+func parsePackageLock(absPath string) *manifestFile {
+	var lock struct {
+		Packages map[string]struct {
+			Version string `json:"version"`
+			Dev     bool   `json:"dev"`
+			Link    bool   `json:"link"`
+		} `json:"packages"`
+		Dependencies map[string]lockV1Dep `json:"dependencies"`
+	}
+	if !readJSON(absPath, &lock) {
+		return nil
+	}
+	m := &manifestFile{ecosystem: EcosystemNpm, locked: make(map[string]lockedPkg)}
+	if len(lock.Packages) > 0 {
+		depth := make(map[string]int)
+		for key, p := range lock.Packages {
+			i := strings.LastIndex(key, "node_modules/")
+			if i < 0 {
+				continue // the root or a workspace member's own directory
+			}
+			name := key[i+len("node_modules/"):]
+			d := strings.Count(key, "node_modules/")
+			if prev, seen := depth[name]; seen && prev <= d {
+				continue
+			}
+			depth[name] = d
+			m.locked[name] = lockedPkg{version: p.Version, dev: p.Dev, local: p.Link}
+		}
+		return m
+	}
+	var walk func(deps map[string]lockV1Dep)
+	walk = func(deps map[string]lockV1Dep) {
+		for _, name := range sortedKeys(deps) {
+			if _, seen := m.locked[name]; !seen {
+				m.locked[name] = lockedPkg{version: deps[name].Version, dev: deps[name].Dev}
+			}
+		}
+		for _, name := range sortedKeys(deps) {
+			walk(deps[name].Dependencies)
+		}
+	}
+	walk(lock.Dependencies)
+	return m
+}
+
+// parseYarnLock reads both the classic (version "x") and berry (version: x)
+// formats. Entry headers list one or more "name@range" specifiers.
+func parseYarnLock(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemNpm, locked: make(map[string]lockedPkg)}
+	name, local := "", false
+	ok := scanLines(absPath, func(line string) {
+		if line == "" || strings.HasPrefix(line, "#") {
+			return
+		}
+		if !strings.HasPrefix(line, " ") {
+			spec := strings.TrimSuffix(line, ":")
+			spec, _, _ = strings.Cut(spec, ",")
+			spec = strings.Trim(strings.TrimSpace(spec), `"`)
+			name, local = "", false
+			if i := strings.LastIndex(spec, "@"); i > 0 {
+				name = spec[:i]
+				rng := spec[i+1:]
+				local = strings.HasPrefix(rng, "workspace:") || strings.HasPrefix(rng, "file:") || strings.HasPrefix(rng, "link:")
+				// berry: "name@npm:^1.0.0" — the protocol sits after the @.
+				if j := strings.LastIndex(name, "@"); j > 0 && strings.HasPrefix(spec[j+1:], "npm:") {
+					name = spec[:j]
+				}
+			}
+			return
+		}
+		if name == "" {
+			return
+		}
+		field := strings.TrimSpace(line)
+		rest, ok := strings.CutPrefix(field, "version")
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != ':') {
+			return
+		}
+		version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(rest, ":")), `"`)
+		if _, seen := m.locked[name]; !seen {
+			m.locked[name] = lockedPkg{version: version, local: local}
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// parsePnpmLock reads the "packages" map. Keys are "/name@version" (v6),
+// "name@version" (v9) or "/name/version" (v5), optionally followed by a
+// parenthesised peer suffix.
+func parsePnpmLock(absPath string) *manifestFile {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil
+	}
+	var lock struct {
+		Packages map[string]struct {
+			Version string `yaml:"version"`
+			Dev     bool   `yaml:"dev"`
+		} `yaml:"packages"`
+	}
+	if yaml.Unmarshal(data, &lock) != nil {
+		return nil
+	}
+	m := &manifestFile{ecosystem: EcosystemNpm, locked: make(map[string]lockedPkg)}
+	for _, key := range sortedKeys(lock.Packages) {
+		spec := strings.TrimPrefix(key, "/")
+		if i := strings.Index(spec, "("); i > 0 {
+			spec = spec[:i]
+		}
+		var name, version string
+		if i := strings.LastIndex(spec, "@"); i > 0 {
+			name, version = spec[:i], spec[i+1:]
+		} else if i := strings.LastIndex(spec, "/"); i > 0 {
+			name, version = spec[:i], spec[i+1:]
+		} else {
+			continue
+		}
+		if v := lock.Packages[key].Version; v != "" {
+			version = v
+		}
+		if _, seen := m.locked[name]; !seen {
+			m.locked[name] = lockedPkg{version: version, dev: lock.Packages[key].Dev}
+		}
+	}
+	return m
+}
+
+// parseBunLock reads bun.lock's "packages" map, whose values are arrays led
+// by a "name@version" specifier. Keys of non-hoisted copies are prefixed with
+// their parent's path; the hoisted copy (key == name) wins.
+func parseBunLock(absPath string) *manifestFile {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil
+	}
+	var lock struct {
+		Packages map[string][]json.RawMessage `json:"packages"`
+	}
+	if json.Unmarshal(stripTrailingCommas(data), &lock) != nil {
+		return nil
+	}
+	m := &manifestFile{ecosystem: EcosystemNpm, locked: make(map[string]lockedPkg)}
+	for _, key := range sortedKeys(lock.Packages) {
+		entry := lock.Packages[key]
+		var spec string
+		if len(entry) == 0 || json.Unmarshal(entry[0], &spec) != nil {
+			continue
+		}
+		i := strings.LastIndex(spec, "@")
+		if i <= 0 {
+			continue
+		}
+		name, version := spec[:i], spec[i+1:]
+		if _, seen := m.locked[name]; seen && key != name {
+			continue
+		}
+		local := strings.HasPrefix(version, "workspace:") || strings.HasPrefix(version, "file:") || strings.HasPrefix(version, "link:")
+		m.locked[name] = lockedPkg{version: version, local: local}
+	}
+	return m
+}
+
+// stripTrailingCommas drops commas directly before a closing } or ] outside
+// strings, turning bun's JSONC-style lockfile into plain JSON.
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			out = append(out, c)
+			continue
+		}
+		if c == '"' {
+			inString = true
+		}
+		if c == ',' {
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// =============================================================================
+// TOML (Cargo, pyproject, Cargo.lock/poetry.lock/uv.lock)
+// =============================================================================
+
+// scanTOML is a line-level TOML reader: it reports each key = value pair
+// with its enclosing table name ("[[package]]" tables are reported as
+// "package", with arrayStart true on the header line's first key). Values
+// spanning lines (arrays) are joined. It does not unquote keys or values;
+// the manifests read here stay within that subset.
+func scanTOML(absPath string, fn func(table, key, value string, arrayStart bool)) bool {
+	table, pending, fresh := "", "", false
+	var key string
+	return scanLines(absPath, func(raw string) {
+		line := strings.TrimSpace(raw)
+		if pending != "" {
+			pending += " " + stripTOMLComment(line)
+			if bracketDepth(pending) <= 0 {
+				fn(table, key, pending, fresh)
+				pending, fresh = "", false
+			}
+			return
+		}
+		line = stripTOMLComment(line)
+		if line == "" {
+			return
+		}
+		// Outside a pending value only a table header starts with "[".
+		if strings.HasPrefix(line, "[") {
+			fresh = strings.HasPrefix(line, "[[")
+			table = strings.Trim(line, "[] ")
+			return
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return
+		}
+		key, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if bracketDepth(v) > 0 {
+			pending = v
+			return
+		}
+		fn(table, key, v, fresh)
+		fresh = false
+	})
+}
+
+// stripTOMLComment drops a trailing # comment outside quoted strings.
+func stripTOMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return line
+}
+
+// bracketDepth returns the count of unclosed [ and { outside quoted strings.
+func bracketDepth(s string) int {
+	depth := 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth
+}
+
+// tomlString unquotes a TOML string value.
+func tomlString(v string) string {
+	return strings.Trim(strings.TrimSpace(v), `"'`)
+}
+
+// tomlStrings returns the string elements of a TOML array value.
+func tomlStrings(v string) []string {
+	var out []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(v); i++ {
+		c := v[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				out = append(out, v[start:i])
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+			start = i + 1
+		}
+	}
+	return out
+}
+
+// tomlInlineField returns a field of a TOML inline table value
+// ({ version = "1", path = "../x" }), or "" if absent.
+func tomlInlineField(v, field string) string {
+	inner := strings.TrimSpace(v)
+	if !strings.HasPrefix(inner, "{") {
+		return ""
+	}
+	inner = strings.Trim(inner, "{}")
+	for _, part := range splitTopLevel(inner) {
+		k, val, ok := strings.Cut(part, "=")
+		if ok && strings.TrimSpace(k) == field {
+			return tomlString(val)
+		}
+	}
+	return ""
+}
+
+// splitTopLevel splits on commas outside quotes and brackets.
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// cargoScopes maps the Cargo dependency table suffix to a scope.
+var cargoScopes = map[string]string{
+	"dependencies":       ScopeRuntime,
+	"dev-dependencies":   ScopeDev,
+	"build-dependencies": ScopeBuild,
+}
+
+// parseCargoToml reads [dependencies], [dev-dependencies] and
+// [build-dependencies], including target-specific and [dependencies.name]
+// tables. Path dependencies are workspace crates. [workspace.dependencies]
+// only centralises versions — members still declare what they use.
+func parseCargoToml(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemCargo}
+	byName := make(map[string]int) // table-form deps: name -> index in m.deps
+	ok := scanTOML(absPath, func(table, key, value string, _ bool) {
+		if table == "package" && key == "name" {
+			m.self = append(m.self, tomlString(value))
+			return
+		}
+		if strings.HasPrefix(table, "workspace.") {
+			return
+		}
+		// [dependencies.serde] / [target.'cfg(unix)'.dev-dependencies.nix]
+		for suffix, scope := range cargoScopes {
+			if i := strings.LastIndex(table, suffix+"."); i >= 0 && (i == 0 || table[i-1] == '.') {
+				name := table[i+len(suffix)+1:]
+				idx, seen := byName[table]
+				if !seen {
+					idx = len(m.deps)
+					byName[table] = idx
+					m.deps = append(m.deps, declaredDep{name: name, scope: scope})
+				}
+				switch key {
+				case "version":
+					m.deps[idx].version = tomlString(value)
+				case "path":
+					m.deps[idx].local = true
+				case "package":
+					m.deps[idx].name = tomlString(value)
+				}
+				return
+			}
+		}
+		scope := ""
+		for suffix, s := range cargoScopes {
+			if table == suffix || strings.HasSuffix(table, "."+suffix) {
+				scope = s
+			}
+		}
+		if scope == "" {
+			return
+		}
+		name, _, _ := strings.Cut(key, ".") // serde.workspace = true
+		d := declaredDep{name: name, scope: scope}
+		if strings.HasPrefix(value, "{") {
+			d.version = tomlInlineField(value, "version")
+			d.local = tomlInlineField(value, "path") != ""
+			if pkg := tomlInlineField(value, "package"); pkg != "" {
+				d.name = pkg
+			}
+		} else if !strings.Contains(key, ".") {
+			d.version = tomlString(value)
+		}
+		m.deps = append(m.deps, d)
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// parseTOMLLock reads the [[package]] tables shared by Cargo.lock,
+// poetry.lock and uv.lock. Local packages: Cargo entries without a source
+// (workspace members), uv editable/virtual/directory sources, poetry
+// directory/file sources.
+func parseTOMLLock(absPath, ecosystem string) *manifestFile {
+	m := &manifestFile{ecosystem: ecosystem, locked: make(map[string]lockedPkg)}
+	type pkg struct {
+		name, version, source string
+		dev                   bool
+	}
+	var pkgs []pkg
+	ok := scanTOML(absPath, func(table, key, value string, arrayStart bool) {
+		switch {
+		case table == "package":
+			if arrayStart || len(pkgs) == 0 {
+				pkgs = append(pkgs, pkg{})
+			}
+			p := &pkgs[len(pkgs)-1]
+			switch key {
+			case "name":
+				p.name = tomlString(value)
+			case "version":
+				p.version = tomlString(value)
+			case "source":
+				p.source = value
+			case "category":
+				p.dev = tomlString(value) == "dev"
+			}
+		case table == "package.source" && key == "type" && len(pkgs) > 0:
+			pkgs[len(pkgs)-1].source = "{ " + tomlString(value) + " }"
+		}
+	})
+	if !ok {
+		return nil
+	}
+	for _, p := range pkgs {
+		if p.name == "" {
+			continue
+		}
+		local := false
+		if ecosystem == EcosystemCargo {
+			local = p.source == ""
+		} else {
+			for _, kind := range []string{"editable", "virtual", "directory", "path", "file"} {
+				if strings.Contains(p.source, kind) {
+					local = true
+				}
+			}
+		}
+		if _, seen := m.locked[p.name]; !seen {
+			m.locked[p.name] = lockedPkg{version: p.version, dev: p.dev, local: local}
+		}
+	}
+	return m
+}
+
+// =============================================================================
+// Python
+// =============================================================================
+
+// pep508Name matches the distribution name at the start of a PEP 508
+// requirement ("requests[socks]>=2.0; python_version<'3.8'").
+var pep508Name = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// parsePEP508 splits a requirement into name and version constraint. An
+// exact pin (==1.2.3) yields the bare version.
+func parsePEP508(req string) (declaredDep, bool) {
+	req = strings.TrimSpace(req)
+	spec, _, _ := strings.Cut(req, ";")
+	name := pep508Name.FindString(spec)
+	if name == "" {
+		return declaredDep{}, false
+	}
+	rest := strings.TrimSpace(spec[len(name):])
+	if strings.HasPrefix(rest, "[") {
+		if i := strings.Index(rest, "]"); i >= 0 {
+			rest = strings.TrimSpace(rest[i+1:])
+		}
+	}
+	d := declaredDep{name: name}
+	if url, ok := strings.CutPrefix(rest, "@"); ok {
+		url = strings.TrimSpace(url)
+		d.local = strings.HasPrefix(url, "file:") || strings.HasPrefix(url, ".")
+		return d, true
+	}
+	rest = strings.Trim(rest, "() ")
+	if pin, ok := strings.CutPrefix(rest, "=="); ok && !strings.ContainsAny(pin, ",*") {
+		rest = strings.TrimSpace(pin)
+	}
+	d.version = rest
+	return d, true
+}
+
+// pythonGroupScope maps an optional-dependency or dependency-group name to
+// a scope: test-ish groups are test, tooling groups dev, extras runtime.
+func pythonGroupScope(group string) string {
+	g := strings.ToLower(group)
+	switch {
+	case strings.Contains(g, "test"):
+		return ScopeTest
+	case g == "dev" || g == "develop" || g == "lint" || g == "docs" || g == "typing" || strings.HasPrefix(g, "dev"):
+		return ScopeDev
+	}
+	return ScopeRuntime
+}
+
+// parsePyproject reads PEP 621 [project] dependencies and optional
+// dependencies, PEP 735 [dependency-groups], and Poetry's dependency tables.
+func parsePyproject(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemPyPI}
+	addReqs := func(value, scope string) {
+		for _, req := range tomlStrings(value) {
+			if d, ok := parsePEP508(req); ok {
+				d.scope = scope
+				m.deps = append(m.deps, d)
+			}
+		}
+	}
+	ok := scanTOML(absPath, func(table, key, value string, _ bool) {
+		switch {
+		case (table == "project" || table == "tool.poetry") && key == "name":
+			m.self = append(m.self, tomlString(value))
+		case table == "project" && key == "dependencies":
+			addReqs(value, ScopeRuntime)
+		case table == "project.optional-dependencies" || table == "dependency-groups":
+			addReqs(value, pythonGroupScope(key))
+		case table == "tool.poetry.dependencies" || table == "tool.poetry.dev-dependencies" ||
+			(strings.HasPrefix(table, "tool.poetry.group.") && strings.HasSuffix(table, ".dependencies")):
+			if key == "python" {
+				return
+			}
+			scope := ScopeRuntime
+			if table == "tool.poetry.dev-dependencies" {
+				scope = ScopeDev
+			} else if group, ok := strings.CutPrefix(table, "tool.poetry.group."); ok {
+				scope = pythonGroupScope(strings.TrimSuffix(group, ".dependencies"))
+				if scope == ScopeRuntime {
+					scope = ScopeDev // non-main Poetry groups are not installed by default
+				}
+			}
+			d := declaredDep{name: tomlString(key), scope: scope}
+			if strings.HasPrefix(value, "{") {
+				d.version = tomlInlineField(value, "version")
+				d.local = tomlInlineField(value, "path") != ""
+			} else {
+				d.version = tomlString(value)
+			}
+			m.deps = append(m.deps, d)
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// parseRequirements reads a pip requirements file. Options (-r, -c, -e,
+// --index-url) are skipped; the filename sets the scope.
+func parseRequirements(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemPyPI}
+	scope := ScopeRuntime
+	if base := strings.ToLower(filepath.Base(absPath)); strings.Contains(base, "test") {
+		scope = ScopeTest
+	} else if strings.Contains(base, "dev") || strings.Contains(base, "lint") || strings.Contains(base, "docs") {
+		scope = ScopeDev
+	}
+	ok := scanLines(absPath, func(line string) {
+		line = strings.TrimSpace(line)
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			return
+		}
+		if d, ok := parsePEP508(line); ok {
+			d.scope = scope
+			m.deps = append(m.deps, d)
+		}
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// =============================================================================
+// JVM
+// =============================================================================
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+type pomProject struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Parent     struct {
+		GroupID string `xml:"groupId"`
+		Version string `xml:"version"`
+	} `xml:"parent"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies         []pomDependency `xml:"dependencies>dependency"`
+	DependencyManagement struct {
+		Dependencies []pomDependency `xml:"dependencies>dependency"`
+	} `xml:"dependencyManagement"`
+}
+
+// pomProperty matches a ${name} property reference.
+var pomProperty = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// parsePom reads a Maven pom's direct dependencies. Versions fall back to
+// the pom's own dependencyManagement and ${property} references are
+// substituted from its properties; a parent pom is not consulted.
+func parsePom(absPath string) *manifestFile {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil
+	}
+	var pom pomProject
+	if xml.Unmarshal(data, &pom) != nil {
+		return nil
+	}
+	group := pom.GroupID
+	if group == "" {
+		group = pom.Parent.GroupID
+	}
+	version := pom.Version
+	if version == "" {
+		version = pom.Parent.Version
+	}
+	props := map[string]string{"project.version": version, "project.groupId": group}
+	for _, e := range pom.Properties.Entries {
+		props[e.XMLName.Local] = strings.TrimSpace(e.Value)
+	}
+	expand := func(s string) string {
+		return pomProperty.ReplaceAllStringFunc(strings.TrimSpace(s), func(ref string) string {
+			if v, ok := props[ref[2:len(ref)-1]]; ok {
+				return v
+			}
+			return ref
+		})
+	}
+	managed := make(map[string]string)
+	for _, d := range pom.DependencyManagement.Dependencies {
+		managed[expand(d.GroupID)+":"+expand(d.ArtifactID)] = expand(d.Version)
+	}
+
+	m := &manifestFile{ecosystem: EcosystemMaven}
+	if group != "" && pom.ArtifactID != "" {
+		m.self = append(m.self, group+":"+pom.ArtifactID)
+	}
+	for _, d := range pom.Dependencies {
+		name := expand(d.GroupID) + ":" + expand(d.ArtifactID)
+		v := expand(d.Version)
+		if v == "" {
+			v = managed[name]
+		}
+		scope := ScopeRuntime
+		switch strings.TrimSpace(d.Scope) {
+		case "test":
+			scope = ScopeTest
+		case "provided":
+			scope = ScopeBuild
+		}
+		m.deps = append(m.deps, declaredDep{name: name, version: v, scope: scope})
+	}
+	return m
+}
+
+// gradleDep matches string-notation Gradle dependencies in both Groovy and
+// Kotlin DSL: implementation 'g:a:v', testImplementation("g:a:v").
+var gradleDep = regexp.MustCompile(`^\s*(\w+)\s*\(?\s*["']([^"':\s]+):([^"':\s]+)(?::([^"'\s]*))?["']`)
+
+// gradleScope maps a Gradle configuration name to a scope; unknown
+// configurations (plugin DSL calls, tasks) are not dependencies.
+func gradleScope(conf string) string {
+	switch {
+	case strings.HasPrefix(conf, "test") || strings.HasPrefix(conf, "androidTest"):
+		return ScopeTest
+	case conf == "compileOnly" || conf == "annotationProcessor" || conf == "kapt" || conf == "ksp":
+		return ScopeBuild
+	case conf == "developmentOnly":
+		return ScopeDev
+	case conf == "implementation" || conf == "api" || conf == "compile" || conf == "runtimeOnly" || conf == "runtime":
+		return ScopeRuntime
+	}
+	return ""
+}
+
+// parseGradle reads string-notation dependencies from a Gradle build script.
+// Version catalogs (libs.x) and project(":x") references are not matched.
+func parseGradle(absPath string) *manifestFile {
+	m := &manifestFile{ecosystem: EcosystemMaven}
+	ok := scanLines(absPath, func(line string) {
+		sm := gradleDep.FindStringSubmatch(line)
+		if sm == nil {
+			return
+		}
+		scope := gradleScope(sm[1])
+		if scope == "" {
+			return
+		}
+		m.deps = append(m.deps, declaredDep{name: sm[2] + ":" + sm[3], version: sm[4], scope: scope})
+	})
+	if !ok {
+		return nil
+	}
+	return m
+}
+
+// =============================================================================
+// .NET
+// =============================================================================
+
+type msbuildPackageRef struct {
+	Include           string `xml:"Include,attr"`
+	Version           string `xml:"Version,attr"`
+	VersionElem       string `xml:"Version"`
+	PrivateAssets     string `xml:"PrivateAssets,attr"`
+	PrivateAssetsElem string `xml:"PrivateAssets"`
+}
+
+// parseMSBuildProject reads PackageReference items from a .csproj (or
+// .fsproj/.vbproj). PrivateAssets="all" marks build-time tooling (analyzers,
+// source link); every package of a test project is test-scoped.
+func parseMSBuildProject(absPath string) *manifestFile {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil
+	}
+	var proj struct {
+		ItemGroups []struct {
+			PackageReferences []msbuildPackageRef `xml:"PackageReference"`
+		} `xml:"ItemGroup"`
+	}
+	if xml.Unmarshal(data, &proj) != nil {
+		return nil
+	}
+	base := filepath.Base(absPath)
+	m := &manifestFile{ecosystem: EcosystemNuGet, self: []string{strings.TrimSuffix(base, filepath.Ext(base))}}
+	testProject := strings.Contains(base, "Test")
+	for _, g := range proj.ItemGroups {
+		for _, ref := range g.PackageReferences {
+			if ref.Include == "Microsoft.NET.Test.Sdk" {
+				testProject = true
+			}
+		}
+	}
+	for _, g := range proj.ItemGroups {
+		for _, ref := range g.PackageReferences {
+			if ref.Include == "" {
+				continue
+			}
+			version := ref.Version
+			if version == "" {
+				version = strings.TrimSpace(ref.VersionElem)
+			}
+			scope := ScopeRuntime
+			switch {
+			case testProject:
+				scope = ScopeTest
+			case strings.EqualFold(ref.PrivateAssets, "all") || strings.EqualFold(strings.TrimSpace(ref.PrivateAssetsElem), "all"):
+				scope = ScopeDev
+			}
+			m.deps = append(m.deps, declaredDep{name: ref.Include, version: version, scope: scope})
+		}
+	}
+	return m
+}
+
+// parseNuGetLock reads packages.lock.json; "Project" entries are project
+// references within the solution.
+func parseNuGetLock(absPath string) *manifestFile {
+	var lock struct {
+		Dependencies map[string]map[string]struct {
+			Type     string `json:"type"`
+			Resolved string `json:"resolved"`
+		} `json:"dependencies"`
+	}
+	if !readJSON(absPath, &lock) {
+		return nil
+	}
+	m := &manifestFile{ecosystem: EcosystemNuGet, locked: make(map[string]lockedPkg)}
+	for _, framework := range sortedKeys(lock.Dependencies) {
+		for name, p := range lock.Dependencies[framework] {
+			if _, seen := m.locked[name]; !seen {
+				m.locked[name] = lockedPkg{version: p.Resolved, local: p.Type == "Project"}
+			}
+		}
+	}
+	return m
+}
+
+// =============================================================================
+// Helpers
+// =============================================================================
+
+func readJSON(absPath string, v any) bool {
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func indexOf(fields []string, s string) int {
+	for i, f := range fields {
+		if f == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// versionLess orders versions by their numeric release components
+// (v1.10.0 > v1.9.3); pre-release and build suffixes are ignored.
+func versionLess(a, b string) bool {
+	as, bs := versionFields(a), versionFields(b)
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] != bs[i] {
+			return as[i] < bs[i]
+		}
+	}
+	return len(as) < len(bs)
+}
+
+func versionFields(v string) []int {
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var out []int
+	for _, part := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		out = append(out, n)
+	}
+	return out
+}
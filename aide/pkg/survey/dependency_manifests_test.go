@@ -0,0 +1,360 @@
+package survey
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// parseFixture writes content under name in a temp dir and parses it.
+func parseFixture(t *testing.T, name, content string) *manifestFile {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	m := parseDependencyFile(p)
+	if m == nil {
+		t.Fatalf("parseDependencyFile(%s) = nil", name)
+	}
+	return m
+}
+
+func TestParseManifests(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+		ecosystem           string
+		self                []string
+		want                []declaredDep
+	}{
+		{
+			name: "Cargo.toml", file: "Cargo.toml", ecosystem: EcosystemCargo,
+			content: `[package]
+name = "app"
+
+[dependencies]
+serde = { version = "1.0", features = ["derive"] }
+anyhow = "1" # errors
+core = { path = "../core" }
+tokio.workspace = true
+
+[target.'cfg(target_os = "linux")'.dependencies]
+nix = "0.27"
+
+[dev-dependencies]
+insta = "1.34"
+
+[build-dependencies.cc]
+version = "1.0"
+
+[workspace.dependencies]
+tokio = "1.35"
+`,
+			self: []string{"app"},
+			want: []declaredDep{
+				{name: "serde", version: "1.0", scope: ScopeRuntime},
+				{name: "anyhow", version: "1", scope: ScopeRuntime},
+				{name: "core", scope: ScopeRuntime, local: true},
+				{name: "tokio", scope: ScopeRuntime},
+				{name: "nix", version: "0.27", scope: ScopeRuntime},
+				{name: "insta", version: "1.34", scope: ScopeDev},
+				{name: "cc", version: "1.0", scope: ScopeBuild},
+			},
+		},
+		{
+			name: "pyproject PEP 621", file: "pyproject.toml", ecosystem: EcosystemPyPI,
+			content: `[project]
+name = "svc"
+dependencies = [
+    "requests[socks]>=2.31,<3",
+    "PyYAML==6.0.1",
+    "mylib @ file:///src/mylib",
+]
+
+[project.optional-dependencies]
+test = ["pytest>=8"]
+fast = ["orjson"]
+
+[dependency-groups]
+dev = ["ruff"]
+`,
+			self: []string{"svc"},
+			want: []declaredDep{
+				{name: "requests", version: ">=2.31,<3", scope: ScopeRuntime},
+				{name: "PyYAML", version: "6.0.1", scope: ScopeRuntime},
+				{name: "mylib", scope: ScopeRuntime, local: true},
+				{name: "pytest", version: ">=8", scope: ScopeTest},
+				{name: "orjson", scope: ScopeRuntime},
+				{name: "ruff", scope: ScopeDev},
+			},
+		},
+		{
+			name: "pyproject Poetry", file: "pyproject.toml", ecosystem: EcosystemPyPI,
+			content: `[tool.poetry]
+name = "svc"
+
+[tool.poetry.dependencies]
+python = "^3.11"
+httpx = "^0.27"
+shared = { path = "../shared", develop = true }
+
+[tool.poetry.group.test.dependencies]
+pytest = { version = "^8.0" }
+`,
+			self: []string{"svc"},
+			want: []declaredDep{
+				{name: "httpx", version: "^0.27", scope: ScopeRuntime},
+				{name: "shared", scope: ScopeRuntime, local: true},
+				{name: "pytest", version: "^8.0", scope: ScopeTest},
+			},
+		},
+		{
+			name: "requirements", file: "requirements-dev.txt", ecosystem: EcosystemPyPI,
+			content: `# tooling
+-r requirements.txt
+-e .
+black==24.1.0  # formatter
+mypy>=1.8 ; python_version >= "3.10"
+`,
+			want: []declaredDep{
+				{name: "black", version: "24.1.0", scope: ScopeDev},
+				{name: "mypy", version: ">=1.8", scope: ScopeDev},
+			},
+		},
+		{
+			name: "pom.xml", file: "pom.xml", ecosystem: EcosystemMaven,
+			content: `<project>
+  <parent><groupId>com.acme</groupId><version>2.0.0</version></parent>
+  <artifactId>api</artifactId>
+  <properties><jackson.version>2.17.0</jackson.version></properties>
+  <dependencyManagement><dependencies>
+    <dependency><groupId>org.yaml</groupId><artifactId>snakeyaml</artifactId><version>2.2</version></dependency>
+  </dependencies></dependencyManagement>
+  <dependencies>
+    <dependency><groupId>com.fasterxml.jackson.core</groupId><artifactId>jackson-databind</artifactId><version>${jackson.version}</version></dependency>
+    <dependency><groupId>org.yaml</groupId><artifactId>snakeyaml</artifactId></dependency>
+    <dependency><groupId>org.junit.jupiter</groupId><artifactId>junit-jupiter</artifactId><version>5.10.0</version><scope>test</scope></dependency>
+  </dependencies>
+</project>`,
+			self: []string{"com.acme:api"},
+			want: []declaredDep{
+				{name: "com.fasterxml.jackson.core:jackson-databind", version: "2.17.0", scope: ScopeRuntime},
+				{name: "org.yaml:snakeyaml", version: "2.2", scope: ScopeRuntime},
+				{name: "org.junit.jupiter:junit-jupiter", version: "5.10.0", scope: ScopeTest},
+			},
+		},
+		{
+			name: "build.gradle.kts", file: "build.gradle.kts", ecosystem: EcosystemMaven,
+			content: `plugins { id("org.jetbrains.kotlin.jvm") version "1.9.22" }
+dependencies {
+    implementation("com.squareup.okhttp3:okhttp:4.12.0")
+    testImplementation("io.mockk:mockk:1.13.9")
+    compileOnly 'org.projectlombok:lombok:1.18.30'
+    implementation(project(":core"))
+    implementation(libs.kotlinx.coroutines)
+}
+`,
+			want: []declaredDep{
+				{name: "com.squareup.okhttp3:okhttp", version: "4.12.0", scope: ScopeRuntime},
+				{name: "io.mockk:mockk", version: "1.13.9", scope: ScopeTest},
+				{name: "org.projectlombok:lombok", version: "1.18.30", scope: ScopeBuild},
+			},
+		},
+		{
+			name: "csproj", file: "Api.csproj", ecosystem: EcosystemNuGet,
+			content: `<Project Sdk="Microsoft.NET.Sdk.Web">
+  <ItemGroup>
+    <PackageReference Include="Serilog" Version="3.1.1" />
+    <PackageReference Include="StyleCop.Analyzers" Version="1.1.118" PrivateAssets="all" />
+    <PackageReference Include="Dapper">
+      <Version>2.1.28</Version>
+    </PackageReference>
+    <ProjectReference Include="..\Core\Core.csproj" />
+  </ItemGroup>
+</Project>`,
+			self: []string{"Api"},
+			want: []declaredDep{
+				{name: "Serilog", version: "3.1.1", scope: ScopeRuntime},
+				{name: "StyleCop.Analyzers", version: "1.1.118", scope: ScopeDev},
+				{name: "Dapper", version: "2.1.28", scope: ScopeRuntime},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := parseFixture(t, tt.file, tt.content)
+			if m.ecosystem != tt.ecosystem {
+				t.Errorf("ecosystem = %s, want %s", m.ecosystem, tt.ecosystem)
+			}
+			if !reflect.DeepEqual(m.self, tt.self) {
+				t.Errorf("self = %v, want %v", m.self, tt.self)
+			}
+			if !reflect.DeepEqual(m.deps, tt.want) {
+				t.Errorf("deps =\n%+v\nwant\n%+v", m.deps, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLockfiles(t *testing.T) {
+	tests := []struct {
+		name, file, content string
+		want                map[string]lockedPkg
+	}{
+		{
+			name: "Cargo.lock", file: "Cargo.lock",
+			content: `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.197"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`,
+			want: map[string]lockedPkg{
+				"app":   {version: "0.1.0", local: true},
+				"serde": {version: "1.0.197"},
+			},
+		},
+		{
+			name: "uv.lock", file: "uv.lock",
+			content: `[[package]]
+name = "svc"
+version = "0.1.0"
+source = { editable = "." }
+
+[[package]]
+name = "pyyaml"
+version = "6.0.1"
+source = { registry = "https://pypi.org/simple" }
+`,
+			want: map[string]lockedPkg{
+				"svc":    {version: "0.1.0", local: true},
+				"pyyaml": {version: "6.0.1"},
+			},
+		},
+		{
+			name: "yarn classic", file: "yarn.lock",
+			content: `# yarn lockfile v1
+
+"@babel/core@^7.0.0", "@babel/core@^7.1.0":
+  version "7.24.0"
+  resolved "https://registry.yarnpkg.com/@babel/core/-/core-7.24.0.tgz"
+
+lodash@^4.17.21:
+  version "4.17.21"
+`,
+			want: map[string]lockedPkg{
+				"@babel/core": {version: "7.24.0"},
+				"lodash":      {version: "4.17.21"},
+			},
+		},
+		{
+			name: "yarn berry", file: "yarn.lock",
+			content: `__metadata:
+  version: 8
+
+"@acme/web@workspace:web":
+  version: 0.0.0-use.local
+
+"yaml@npm:^2.3.0":
+  version: 2.4.1
+  resolution: "yaml@npm:2.4.1"
+`,
+			want: map[string]lockedPkg{
+				"@acme/web": {version: "0.0.0-use.local", local: true},
+				"yaml":      {version: "2.4.1"},
+			},
+		},
+		{
+			name: "pnpm", file: "pnpm-lock.yaml",
+			content: `lockfileVersion: '6.0'
+packages:
+  /yaml@2.4.1:
+    resolution: {integrity: sha512-x}
+    dev: false
+  /@vitest/runner@1.2.0(vitest@1.2.0):
+    resolution: {integrity: sha512-y}
+    dev: true
+`,
+			want: map[string]lockedPkg{
+				"yaml":           {version: "2.4.1"},
+				"@vitest/runner": {version: "1.2.0", dev: true},
+			},
+		},
+		{
+			name: "bun", file: "bun.lock",
+			content: `{
+  "lockfileVersion": 1,
+  "packages": {
+    "@acme/lib": ["@acme/lib@workspace:packages/lib"],
+    "semver": ["semver@7.6.0", "", {}, "sha512-x"],
+    "eslint/semver": ["semver@6.3.1", "", {}, "sha512-y"],
+    "which": ["which@4.0.0", "", { "bin": { "node-which": "bin/which.js" } }, "sha512-z"],
+  },
+}`,
+			want: map[string]lockedPkg{
+				"@acme/lib": {version: "workspace:packages/lib", local: true},
+				"semver":    {version: "7.6.0"},
+				"which":     {version: "4.0.0"},
+			},
+		},
+		{
+			name: "package-lock v1", file: "package-lock.json",
+			content: `{"lockfileVersion": 1, "dependencies": {
+  "a": {"version": "1.0.0", "dependencies": {"b": {"version": "1.0.0"}}},
+  "b": {"version": "2.0.0", "dev": true}
+}}`,
+			want: map[string]lockedPkg{
+				"a": {version: "1.0.0"},
+				"b": {version: "2.0.0", dev: true},
+			},
+		},
+		{
+			name: "NuGet", file: "packages.lock.json",
+			content: `{"version": 1, "dependencies": {"net8.0": {
+  "Serilog": {"type": "Direct", "requested": "[3.1.1, )", "resolved": "3.1.1"},
+  "Microsoft.Extensions.Logging": {"type": "Transitive", "resolved": "8.0.0"},
+  "Core": {"type": "Project"}
+}}}`,
+			want: map[string]lockedPkg{
+				"Serilog":                      {version: "3.1.1"},
+				"Microsoft.Extensions.Logging": {version: "8.0.0"},
+				"Core":                         {local: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := parseFixture(t, tt.file, tt.content)
+			if !reflect.DeepEqual(m.locked, tt.want) {
+				t.Errorf("locked =\n%+v\nwant\n%+v", m.locked, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.9.3", "v1.10.0", true},
+		{"v1.10.0", "v1.9.3", false},
+		{"v1.2.0", "v1.2.0", false},
+		{"v0.0.0-20240101000000-abcdef", "v0.1.0", true},
+		{"1.2", "1.2.1", true},
+	}
+	for _, tt := range tests {
+		if got := versionLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("versionLess(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -26,6 +26,8 @@ func RunEntrypoints(rootDir string, codeSearcher CodeSearcher) (*EntrypointsResu
 }
 
 // RunEntrypointsWithRegistry is like RunEntrypoints but accepts a specific PackRegistry.
+// With a code index, detection never reads rootDir itself, so an empty
+// rootDir surveys an index built from a commit's tree.
 func RunEntrypointsWithRegistry(rootDir string, codeSearcher CodeSearcher, reg *grammar.PackRegistry) (*EntrypointsResult, error) {
 	result := &EntrypointsResult{}
 
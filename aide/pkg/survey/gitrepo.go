@@ -4,9 +4,18 @@ package survey
 
 import (
 	"fmt"
+	"io"
+	"math"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
@@ -22,7 +31,9 @@ type GitRepo struct {
 type ChurnStat struct {
 	FilePath     string
 	Commits      int
-	LinesChanged int // Total lines added + removed
+	LinesChanged int       // Total lines added + removed
+	Recent       float64   // Commits weighted by age, halving every DefaultChurnHalfLife
+	Last         time.Time // Author time of the newest change
 }
 
 // OpenGitRepo opens a git repository. Returns nil, nil if not a git repo.
@@ -90,6 +101,100 @@ func (g *GitRepo) Head() (string, error) {
 	return ref.Hash().String(), nil
 }
 
+// ResolveCommit resolves a revision (hash, abbreviated hash, branch, tag,
+// "HEAD~2", ...) to a full commit hash.
+func (g *GitRepo) ResolveCommit(rev string) (string, error) {
+	h, err := g.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %q: %w", rev, err)
+	}
+	return h.String(), nil
+}
+
+// CommitTime returns the committer time of the given commit hash.
+func (g *GitRepo) CommitTime(commit string) (time.Time, error) {
+	c, err := g.repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read commit %s: %w", commit, err)
+	}
+	return c.Committer.When, nil
+}
+
+// MaxTreeFileBytes caps the size of a blob TreeFS loads: larger files are
+// generated code, fixtures or assets that no analyzer reads usefully, and
+// the whole tree is held in memory.
+const MaxTreeFileBytes = 2 << 20
+
+// RelDir returns dir relative to the repository's worktree root, slash
+// separated ("" = the root itself) — the subtree TreeFS should load for a
+// project that sits below the repository root in a monorepo.
+func (g *GitRepo) RelDir(dir string) (string, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	root := wt.Filesystem.Root()
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the repository at %s", dir, root)
+	}
+	if rel == "." {
+		return "", nil
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// TreeFS loads the files of commit under dir (relative to the repository
+// root, "" = the whole tree) into an in-memory filesystem rooted at dir,
+// without touching the worktree. Symlinks, submodules and blobs over
+// MaxTreeFileBytes are left out.
+func (g *GitRepo) TreeFS(commit, dir string) (billy.Filesystem, error) {
+	c, err := g.repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", commit, err)
+	}
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of %s: %w", commit, err)
+	}
+	if dir != "" {
+		if tree, err = tree.Tree(dir); err != nil {
+			return nil, fmt.Errorf("%s does not exist at %s: %w", dir, ShortCommit(commit), err)
+		}
+	}
+
+	mem := memfs.New()
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if !f.Mode.IsFile() || f.Mode == filemode.Symlink || f.Size > MaxTreeFileBytes {
+			return nil
+		}
+		r, err := f.Reader()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		out, err := mem.Create(f.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, r); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tree of %s: %w", ShortCommit(commit), err)
+	}
+	return mem, nil
+}
+
 // CommitsBehind walks history from HEAD counting commits until it finds the
 // given commit hash. Returns (count, true) when found within maxWalk commits
 // (count 0 means HEAD itself), or (walked, false) when not found — the commit
@@ -120,6 +225,8 @@ func (g *GitRepo) CommitsBehind(commit string, maxWalk int) (int, bool, error) {
 }
 
 // FileChurnStats walks the commit history and aggregates per-file change statistics.
+// Recent decays each commit by its age relative to the newest commit walked,
+// so a file that was rewritten years ago ranks below one changing now.
 // maxCommits limits how far back to look (0 = DefaultMaxCommits).
 func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error) {
 	if maxCommits <= 0 {
@@ -133,6 +240,7 @@ func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error)
 	defer logIter.Close()
 
 	stats := make(map[string]*ChurnStat)
+	var newest time.Time
 	count := 0
 
 	err = logIter.ForEach(func(c *object.Commit) error {
@@ -141,6 +249,11 @@ func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error)
 		}
 		count++
 
+		when := c.Author.When
+		if newest.IsZero() || when.After(newest) {
+			newest = when
+		}
+
 		// Get file stats for this commit
 		fileStats, err := commitFileStats(c)
 		if err != nil {
@@ -148,6 +261,10 @@ func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error)
 			return nil
 		}
 
+		decay := 1.0
+		if age := newest.Sub(when); age > 0 {
+			decay = math.Pow(0.5, float64(age)/float64(DefaultChurnHalfLife))
+		}
 		for _, fs := range fileStats {
 			s, ok := stats[fs.Name]
 			if !ok {
@@ -156,6 +273,10 @@ func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error)
 			}
 			s.Commits++
 			s.LinesChanged += fs.Addition + fs.Deletion
+			s.Recent += decay
+			if when.After(s.Last) {
+				s.Last = when
+			}
 		}
 
 		return nil
@@ -168,6 +289,158 @@ func (g *GitRepo) FileChurnStats(maxCommits int) (map[string]*ChurnStat, error)
 	return stats, nil
 }
 
+// AuthorStat tracks one author's changes to a single file.
+type AuthorStat struct {
+	Name         string
+	Email        string
+	Commits      int
+	LinesChanged int       // Total lines added + removed
+	Weight       float64   // Lines changed plus one per commit, decayed by age
+	Last         time.Time // Author time of the newest change
+
+	hashes []plumbing.Hash // commits counted, so rollups can count distinct commits
+}
+
+// FileAuthorStats walks the commit history like FileChurnStats but
+// attributes each file change to the commit's author, keyed by lowercased
+// email (name when there is none). A change's weight — lines changed plus
+// one, so a one-line fix still counts — halves every halfLife of age
+// relative to the newest commit walked (0 = no decay). Merge commits and
+// bot authors ("[bot]", "dependabot") are skipped: neither reflects who
+// knows the code. It also returns the newest commit's author time.
+// maxCommits limits how far back to look (0 = DefaultMaxCommits).
+func (g *GitRepo) FileAuthorStats(maxCommits int, halfLife time.Duration) (map[string]map[string]*AuthorStat, time.Time, error) {
+	if maxCommits <= 0 {
+		maxCommits = DefaultMaxCommits
+	}
+
+	logIter, err := g.repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer logIter.Close()
+
+	stats := make(map[string]map[string]*AuthorStat)
+	var newest time.Time
+	count := 0
+
+	err = logIter.ForEach(func(c *object.Commit) error {
+		if count >= maxCommits {
+			return fmt.Errorf("stop") // Use error to break iteration
+		}
+		count++
+
+		when := c.Author.When
+		if newest.IsZero() || when.After(newest) {
+			newest = when
+		}
+		if c.NumParents() > 1 || isBotAuthor(c.Author.Name, c.Author.Email) {
+			return nil
+		}
+		fileStats, err := commitFileStats(c)
+		if err != nil {
+			return nil
+		}
+
+		decay := 1.0
+		if halfLife > 0 {
+			if age := newest.Sub(when); age > 0 {
+				decay = math.Pow(0.5, float64(age)/float64(halfLife))
+			}
+		}
+		key := strings.ToLower(strings.TrimSpace(c.Author.Email))
+		if key == "" {
+			key = c.Author.Name
+		}
+		for _, fs := range fileStats {
+			authors := stats[fs.Name]
+			if authors == nil {
+				authors = make(map[string]*AuthorStat)
+				stats[fs.Name] = authors
+			}
+			a := authors[key]
+			if a == nil {
+				a = &AuthorStat{Name: c.Author.Name, Email: c.Author.Email}
+				authors[key] = a
+			}
+			lines := fs.Addition + fs.Deletion
+			a.Commits++
+			a.hashes = append(a.hashes, c.Hash)
+			a.LinesChanged += lines
+			a.Weight += float64(lines+1) * decay
+			if when.After(a.Last) {
+				a.Last = when
+			}
+		}
+		return nil
+	})
+	// "stop" error is expected — it's our way to break the iteration
+	if err != nil && err.Error() != "stop" {
+		return nil, time.Time{}, fmt.Errorf("failed to iterate commits: %w", err)
+	}
+
+	return stats, newest, nil
+}
+
+// CommitFiles is the set of files one commit changed.
+type CommitFiles struct {
+	Hash  string
+	When  time.Time
+	Files []string
+}
+
+// CommitFileSets walks the commit history and returns the files each
+// non-merge commit changed, newest first. Merges are skipped because their
+// diff against the first parent repeats the changes of a whole branch.
+// maxCommits limits how far back to look (0 = DefaultMaxCommits).
+func (g *GitRepo) CommitFileSets(maxCommits int) ([]CommitFiles, error) {
+	if maxCommits <= 0 {
+		maxCommits = DefaultMaxCommits
+	}
+
+	logIter, err := g.repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit log: %w", err)
+	}
+	defer logIter.Close()
+
+	var commits []CommitFiles
+	count := 0
+
+	err = logIter.ForEach(func(c *object.Commit) error {
+		if count >= maxCommits {
+			return fmt.Errorf("stop") // Use error to break iteration
+		}
+		count++
+		if c.NumParents() > 1 {
+			return nil
+		}
+		fileStats, err := commitFileStats(c)
+		if err != nil || len(fileStats) == 0 {
+			return nil
+		}
+		cf := CommitFiles{Hash: c.Hash.String(), When: c.Author.When}
+		for _, fs := range fileStats {
+			cf.Files = append(cf.Files, fs.Name)
+		}
+		commits = append(commits, cf)
+		return nil
+	})
+	// "stop" error is expected — it's our way to break the iteration
+	if err != nil && err.Error() != "stop" {
+		return nil, fmt.Errorf("failed to iterate commits: %w", err)
+	}
+
+	return commits, nil
+}
+
+// isBotAuthor reports whether a commit author is an automation account.
+func isBotAuthor(name, email string) bool {
+	n, e := strings.ToLower(name), strings.ToLower(email)
+	return strings.HasSuffix(n, "[bot]") || strings.Contains(e, "[bot]@") ||
+		strings.HasPrefix(n, "dependabot") || strings.HasPrefix(n, "renovate")
+}
+
 // TopChurnFiles returns the top N files by churn score (commits × change magnitude).
 func TopChurnFiles(stats map[string]*ChurnStat, topN int) []*ChurnStat {
 	if topN <= 0 {
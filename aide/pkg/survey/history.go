@@ -0,0 +1,321 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// DefaultHistoryCommits bounds how many first-parent commits a symbol
+// history walk steps through.
+const DefaultHistoryCommits = 1000
+
+// LineSpan is a symbol's 1-indexed, inclusive line range in the working-tree
+// copy of a file, labelled with its code.HistoryKey.
+type LineSpan struct {
+	Key        string
+	Start, End int
+}
+
+// Root returns the repository's working-tree directory.
+func (g *GitRepo) Root() (string, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree: %w", err)
+	}
+	return wt.Filesystem.Root(), nil
+}
+
+// UpdateSymbolHistory returns the history of spans in relPath (slash
+// separated, relative to Root), whose working-tree content is current.
+//
+// Each commit's diff is mapped through the later versions of the file onto
+// the working-tree lines, so a commit is attributed to the symbols whose
+// current lines it added, rewrote or deleted from. Merges count as one
+// change along the first-parent line, and renames are not followed.
+//
+// prev, a history computed earlier, is returned as is when HEAD has not
+// moved and it covers every span, and is extended with just the new commits
+// when HEAD has moved on from it. Otherwise the file is walked afresh, up to
+// maxCommits commits (0 = DefaultHistoryCommits) or the commit that added it.
+func (g *GitRepo) UpdateSymbolHistory(prev *code.FileHistory, relPath string, current []byte, spans []LineSpan, maxCommits int) (*code.FileHistory, error) {
+	if maxCommits <= 0 {
+		maxCommits = DefaultHistoryCommits
+	}
+	ref, err := g.repo.Head()
+	if err != nil {
+		// Unborn HEAD: nothing committed yet.
+		return newFileHistory(relPath, "", spans), nil
+	}
+	head, err := g.repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD commit: %w", err)
+	}
+
+	covered := prev != nil
+	for _, sp := range spans {
+		if covered {
+			_, covered = prev.Symbols[sp.Key]
+		}
+	}
+	if covered && prev.Head == head.Hash.String() {
+		return prev, nil
+	}
+
+	stop := ""
+	if covered {
+		stop = prev.Head
+	}
+	h, reached, err := walkSymbolHistory(head, relPath, current, spans, stop, maxCommits)
+	if err != nil {
+		return nil, err
+	}
+	if stop == "" {
+		return h, nil
+	}
+	if !reached {
+		// History was rewritten, or is deeper than the walk: start over.
+		h, _, err = walkSymbolHistory(head, relPath, current, spans, "", maxCommits)
+		return h, err
+	}
+	for hash, info := range prev.Commits {
+		if _, ok := h.Commits[hash]; !ok {
+			h.Commits[hash] = info
+		}
+	}
+	for key, changes := range prev.Symbols {
+		h.Symbols[key] = append(h.Symbols[key], changes...)
+	}
+	return h, nil
+}
+
+func newFileHistory(relPath, head string, spans []LineSpan) *code.FileHistory {
+	h := &code.FileHistory{
+		FilePath: relPath,
+		Head:     head,
+		Commits:  make(map[string]*code.CommitInfo),
+		Symbols:  make(map[string][]code.SymbolChange, len(spans)),
+	}
+	for _, sp := range spans {
+		h.Symbols[sp.Key] = []code.SymbolChange{}
+	}
+	return h
+}
+
+// walkSymbolHistory walks first parents back from head, attributing each
+// change to relPath to the spans it touched, until it reaches stop (reported
+// as reached), the file's first commit, or maxCommits.
+func walkSymbolHistory(head *object.Commit, relPath string, current []byte, spans []LineSpan, stop string, maxCommits int) (*code.FileHistory, bool, error) {
+	h := newFileHistory(relPath, head.Hash.String(), spans)
+	content, hash, ok, err := fileAt(head, relPath)
+	if err != nil || !ok {
+		return h, false, err // Untracked: no history yet
+	}
+	lines := mapLines(content, current)
+
+	c := head
+	for walked := 0; walked < maxCommits; walked++ {
+		if c.Hash.String() == stop {
+			return h, true, nil
+		}
+		var parent *object.Commit
+		var parentContent []byte
+		var parentHash plumbing.Hash
+		var inParent bool
+		if c.NumParents() > 0 {
+			// A missing parent (shallow clone) ends the walk like a root.
+			if parent, err = c.Parent(0); err == nil {
+				if parentContent, parentHash, inParent, err = fileAt(parent, relPath); err != nil {
+					return nil, false, err
+				}
+			}
+		}
+		if inParent && parentHash == hash {
+			c = parent
+			continue
+		}
+
+		touched, parentLines := attributeChange(parentContent, content, lines, spans)
+		if len(touched) > 0 {
+			key := c.Hash.String()
+			h.Commits[key] = &code.CommitInfo{
+				Hash:    key,
+				Author:  c.Author.Name,
+				Email:   c.Author.Email,
+				When:    c.Author.When,
+				Subject: strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0]),
+			}
+			for i, ch := range touched {
+				if ch.Added+ch.Deleted == 0 {
+					continue
+				}
+				// Same-keyed spans (overloads) share one entry per commit.
+				changes := h.Symbols[spans[i].Key]
+				if n := len(changes); n > 0 && changes[n-1].Hash == key {
+					changes[n-1].Added += ch.Added
+					changes[n-1].Deleted += ch.Deleted
+					continue
+				}
+				ch.Hash = key
+				h.Symbols[spans[i].Key] = append(changes, ch)
+			}
+		}
+		if !inParent {
+			break // The file was added here
+		}
+		c, content, hash, lines = parent, parentContent, parentHash, parentLines
+	}
+	return h, false, nil
+}
+
+// fileAt returns relPath's content and blob hash in commit c; ok is false
+// when the file does not exist there.
+func fileAt(c *object.Commit, relPath string) (content []byte, hash plumbing.Hash, ok bool, err error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, plumbing.ZeroHash, false, fmt.Errorf("failed to read tree of %s: %w", c.Hash, err)
+	}
+	f, err := tree.File(relPath)
+	if errors.Is(err, object.ErrFileNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, plumbing.ZeroHash, false, nil
+	}
+	if err != nil {
+		return nil, plumbing.ZeroHash, false, fmt.Errorf("failed to read %s at %s: %w", relPath, c.Hash, err)
+	}
+	s, err := f.Contents()
+	if err != nil {
+		return nil, plumbing.ZeroHash, false, fmt.Errorf("failed to read %s at %s: %w", relPath, c.Hash, err)
+	}
+	return []byte(s), f.Hash, true, nil
+}
+
+// mapLines maps each line of old to its 1-indexed line in current, or 0
+// when it no longer exists there. Replaced lines map, in order, onto the
+// lines replacing them, so editing a line keeps its history.
+func mapLines(old, current []byte) []int {
+	var m []int
+	cur := 0
+	for _, h := range lineHunks(old, current) {
+		for k := 0; k < h.equal; k++ {
+			m = append(m, cur+k+1)
+		}
+		for k := 0; k < h.del; k++ {
+			if k < h.ins {
+				m = append(m, cur+k+1)
+			} else {
+				m = append(m, 0)
+			}
+		}
+		cur += h.equal + h.ins
+	}
+	return m
+}
+
+// attributeChange diffs a commit's version of the file (child, whose lines
+// map to the working tree through childLines) against its parent's. It
+// returns, per span, the lines the commit added and deleted inside it, and
+// the parent's own line mapping.
+//
+// Lines a hunk replaces map, in order, onto the lines that replace them, so
+// a line rewritten by several commits keeps its place in the working tree
+// and each of them is attributed. Deletions beyond the replacement count for
+// a span when the lines either side of them both lie inside it.
+func attributeChange(parent, child []byte, childLines []int, spans []LineSpan) ([]code.SymbolChange, []int) {
+	touched := make([]code.SymbolChange, len(spans))
+	hit := false
+	at := func(i int) int {
+		if i < 0 || i >= len(childLines) {
+			return 0
+		}
+		return childLines[i]
+	}
+	// count attributes a change to the spans containing working-tree lines
+	// from and to.
+	count := func(from, to, added, deleted int) {
+		if from <= 0 || to <= 0 {
+			return
+		}
+		for i, sp := range spans {
+			if sp.Start <= from && to <= sp.End {
+				touched[i].Added += added
+				touched[i].Deleted += deleted
+				hit = true
+			}
+		}
+	}
+
+	var parentLines []int
+	ci := 0
+	for _, h := range lineHunks(parent, child) {
+		for k := 0; k < h.equal; k++ {
+			parentLines = append(parentLines, at(ci+k))
+		}
+		ci += h.equal
+		for k := 0; k < h.ins; k++ {
+			w := at(ci + k)
+			count(w, w, 1, 0)
+		}
+		for k := 0; k < h.del; k++ {
+			if k < h.ins {
+				w := at(ci + k)
+				count(w, w, 0, 1)
+				parentLines = append(parentLines, w)
+			} else {
+				parentLines = append(parentLines, 0)
+			}
+		}
+		if extra := h.del - h.ins; extra > 0 {
+			count(at(ci+h.ins-1), at(ci+h.ins), 0, extra)
+		}
+		ci += h.ins
+	}
+	if !hit {
+		return nil, parentLines
+	}
+	return touched, parentLines
+}
+
+// lineHunk is a step of a line diff: equal unchanged lines, then del old
+// lines replaced by ins new ones.
+type lineHunk struct {
+	equal, del, ins int
+}
+
+// lineHunks line-diffs old against new.
+func lineHunks(old, new []byte) []lineHunk {
+	var hunks []lineHunk
+	var h lineHunk
+	for _, d := range diff.Do(string(old), string(new)) {
+		n := countLines(d.Text)
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			if h != (lineHunk{}) {
+				hunks = append(hunks, h)
+			}
+			h = lineHunk{equal: n}
+		case diffmatchpatch.DiffDelete:
+			h.del += n
+		case diffmatchpatch.DiffInsert:
+			h.ins += n
+		}
+	}
+	if h != (lineHunk{}) {
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// countLines counts the lines in a diff chunk; the last may be unterminated.
+func countLines(s string) int {
+	n := strings.Count(s, "\n")
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,70 @@
+package survey
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdateSymbolHistory(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	writeTestFile(t, dir, "a.go", "package a\n\nfunc A() {\n\treturn\n}\n\nfunc B() {\n}\n")
+	commitAll(t, repo, "add a.go")
+	writeTestFile(t, dir, "a.go", "package a\n\nfunc A() {\n\tx := 1\n\t_ = x\n\treturn\n}\n\nfunc B() {\n}\n")
+	commitAll(t, repo, "grow A\n\nlonger body")
+	writeTestFile(t, dir, "a.go", "package a\n\n// C is new.\nfunc C() {}\n\nfunc A() {\n\tx := 1\n\t_ = x\n\treturn\n}\n\nfunc B() {\n}\n")
+	commitAll(t, repo, "add C")
+
+	g, err := OpenGitRepo(dir)
+	if err != nil || g == nil {
+		t.Fatalf("OpenGitRepo: %v", err)
+	}
+	current, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := []LineSpan{{Key: "A", Start: 6, End: 10}, {Key: "B", Start: 12, End: 13}, {Key: "C", Start: 3, End: 4}}
+	h, err := g.UpdateSymbolHistory(nil, "a.go", current, spans, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subjects := func(key string) []string {
+		var out []string
+		for _, ch := range h.Symbols[key] {
+			out = append(out, h.Commits[ch.Hash].Subject)
+		}
+		return out
+	}
+	if got := subjects("A"); len(got) != 2 || got[0] != "grow A" || got[1] != "add a.go" {
+		t.Errorf("A history = %v, want [grow A, add a.go]", got)
+	}
+	if got := subjects("B"); len(got) != 1 || got[0] != "add a.go" {
+		t.Errorf("B history = %v, want [add a.go]", got)
+	}
+	if got := subjects("C"); len(got) != 1 || got[0] != "add C" {
+		t.Errorf("C history = %v, want [add C]", got)
+	}
+	if ch := h.Symbols["A"][0]; ch.Added != 2 {
+		t.Errorf("grow A added %d lines to A, want 2", ch.Added)
+	}
+
+	same, err := g.UpdateSymbolHistory(h, "a.go", current, spans, 0)
+	if err != nil || same != h {
+		t.Errorf("unchanged HEAD should reuse the history: %v", err)
+	}
+
+	writeTestFile(t, dir, "a.go", "package a\n\n// C is new.\nfunc C() {}\n\nfunc A() {\n\tx := 1\n\t_ = x\n\treturn\n}\n\nfunc B() {\n\tA()\n}\n")
+	commitAll(t, repo, "B calls A")
+	current, _ = os.ReadFile(filepath.Join(dir, "a.go"))
+	spans[1].End = 14
+	h, err = g.UpdateSymbolHistory(h, "a.go", current, spans, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := subjects("B"); len(got) != 2 || got[0] != "B calls A" || got[1] != "add a.go" {
+		t.Errorf("B history after incremental update = %v", got)
+	}
+	if got := subjects("A"); len(got) != 2 {
+		t.Errorf("A history after incremental update = %v", got)
+	}
+}
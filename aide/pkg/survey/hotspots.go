@@ -0,0 +1,260 @@
+// Package survey: hotspots.go ranks files and functions by churn times
+// complexity. Code that is both hard to follow and keeps changing is where
+// defects concentrate, so it is where refactoring effort pays off first;
+// complex code nobody touches, and busy code that stays simple, rank low.
+package survey
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Hotspot defaults.
+const (
+	// DefaultTopHotspotFunctions is how many function hotspots are kept.
+	DefaultTopHotspotFunctions = 50
+)
+
+// Hotspot scopes, recorded in entry metadata "scope".
+const (
+	HotspotScopeFile     = "file"
+	HotspotScopeFunction = "function"
+)
+
+// FunctionComplexity is the cyclomatic complexity of one function.
+type FunctionComplexity struct {
+	Name       string
+	Line       int
+	EndLine    int
+	Complexity int
+	Flagged    bool // a complexity finding reports it over the threshold
+}
+
+// ComplexitySource supplies per-function complexity for a file. It returns
+// the functions it knows about and where the numbers came from ("index",
+// "findings"); no functions means the file was not measured.
+type ComplexitySource interface {
+	FileComplexity(filePath string) ([]FunctionComplexity, string, error)
+}
+
+// HotspotsConfig configures a hotspots run.
+type HotspotsConfig struct {
+	Churn        []*Entry // churn analyzer entries: the candidate files
+	Complexity   ComplexitySource
+	MaxFunctions int // 0 = DefaultTopHotspotFunctions
+}
+
+// HotspotsResult holds the output of the hotspots analyzer.
+type HotspotsResult struct {
+	Entries    []*Entry
+	Files      int // churned files with complexity data
+	Functions  int // function hotspots kept
+	Unmeasured int // churned files with no complexity data
+}
+
+type hotspotFile struct {
+	path      string
+	churn     float64 // recent commits, or all commits for older churn entries
+	commits   string
+	last      string
+	funcs     []FunctionComplexity
+	total     int
+	source    string
+	churnNorm float64
+}
+
+// RunHotspots joins churn entries with per-function complexity. A file's
+// risk is its recent churn times its total complexity, each normalised to
+// the largest among the candidates, so 1.0 means "the busiest and most
+// complex file here". A function inherits its file's churn — history is
+// tracked per file — times its own normalised complexity.
+func RunHotspots(cfg HotspotsConfig) (*HotspotsResult, error) {
+	if cfg.MaxFunctions <= 0 {
+		cfg.MaxFunctions = DefaultTopHotspotFunctions
+	}
+	result := &HotspotsResult{}
+	if cfg.Complexity == nil {
+		return result, nil
+	}
+
+	var files []*hotspotFile
+	var maxChurn float64
+	maxTotal, maxFunc := 0, 0
+	for _, e := range cfg.Churn {
+		if e.Kind != KindChurn {
+			continue
+		}
+		churn, err := strconv.ParseFloat(e.Metadata["recent_commits"], 64)
+		if err != nil {
+			churn, _ = strconv.ParseFloat(e.Metadata["commits"], 64)
+		}
+		if churn <= 0 {
+			continue
+		}
+		funcs, source, err := cfg.Complexity.FileComplexity(e.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("hotspots analyzer: %s: %w", e.FilePath, err)
+		}
+		if len(funcs) == 0 {
+			result.Unmeasured++
+			continue
+		}
+		f := &hotspotFile{path: e.FilePath, churn: churn, commits: e.Metadata["commits"], last: e.Metadata["last_change"], funcs: funcs, source: source}
+		for _, fn := range funcs {
+			f.total += fn.Complexity
+			maxFunc = max(maxFunc, fn.Complexity)
+		}
+		maxChurn = max(maxChurn, churn)
+		maxTotal = max(maxTotal, f.total)
+		files = append(files, f)
+	}
+	if len(files) == 0 || maxTotal == 0 {
+		return result, nil
+	}
+
+	type scored struct {
+		entry *Entry
+		risk  float64
+	}
+	var fileHits, funcHits []scored
+	for _, f := range files {
+		f.churnNorm = f.churn / maxChurn
+		risk := f.churnNorm * float64(f.total) / float64(maxTotal)
+		fileHits = append(fileHits, scored{hotspotFileEntry(f, risk), risk})
+		for _, fn := range f.funcs {
+			fnRisk := f.churnNorm * float64(fn.Complexity) / float64(maxFunc)
+			funcHits = append(funcHits, scored{hotspotFunctionEntry(f, fn, fnRisk), fnRisk})
+		}
+	}
+
+	rank := func(hits []scored, limit int) {
+		sort.SliceStable(hits, func(i, j int) bool {
+			if hits[i].risk != hits[j].risk {
+				return hits[i].risk > hits[j].risk
+			}
+			return hits[i].entry.FilePath+"\x00"+hits[i].entry.Name < hits[j].entry.FilePath+"\x00"+hits[j].entry.Name
+		})
+		if limit > 0 && len(hits) > limit {
+			hits = hits[:limit]
+		}
+		for i, h := range hits {
+			h.entry.Metadata["rank"] = strconv.Itoa(i + 1)
+			result.Entries = append(result.Entries, h.entry)
+		}
+	}
+	rank(fileHits, 0)
+	rank(funcHits, cfg.MaxFunctions)
+	result.Files = len(fileHits)
+	result.Functions = min(len(funcHits), cfg.MaxFunctions)
+	return result, nil
+}
+
+func hotspotFileEntry(f *hotspotFile, risk float64) *Entry {
+	top := f.funcs[0]
+	for _, fn := range f.funcs[1:] {
+		if fn.Complexity > top.Complexity {
+			top = fn
+		}
+	}
+	return &Entry{
+		Analyzer: AnalyzerHotspots,
+		Kind:     KindHotspot,
+		Name:     f.path,
+		FilePath: f.path,
+		Title:    fmt.Sprintf("Hotspot: %s (risk %.2f)", f.path, risk),
+		Detail: fmt.Sprintf("%.1f recent commits (%s in all); complexity %d across %d functions, most in %s (%d).",
+			f.churn, f.commits, f.total, len(f.funcs), top.Name, top.Complexity),
+		Metadata: map[string]string{
+			"scope":             HotspotScopeFile,
+			"risk":              fmt.Sprintf("%.2f", risk),
+			"churn":             fmt.Sprintf("%.2f", f.churn),
+			"commits":           f.commits,
+			"last_change":       f.last,
+			"complexity":        strconv.Itoa(f.total),
+			"functions":         strconv.Itoa(len(f.funcs)),
+			"max_function":      top.Name,
+			"max_complexity":    strconv.Itoa(top.Complexity),
+			"complexity_source": f.source,
+		},
+	}
+}
+
+func hotspotFunctionEntry(f *hotspotFile, fn FunctionComplexity, risk float64) *Entry {
+	meta := map[string]string{
+		"scope":             HotspotScopeFunction,
+		"risk":              fmt.Sprintf("%.2f", risk),
+		"churn":             fmt.Sprintf("%.2f", f.churn),
+		"commits":           f.commits,
+		"last_change":       f.last,
+		"complexity":        strconv.Itoa(fn.Complexity),
+		"function":          fn.Name,
+		"line":              strconv.Itoa(fn.Line),
+		"complexity_source": f.source,
+	}
+	if fn.EndLine > 0 {
+		meta["end_line"] = strconv.Itoa(fn.EndLine)
+	}
+	if fn.Flagged {
+		meta["flagged"] = "true"
+	}
+	return &Entry{
+		Analyzer: AnalyzerHotspots,
+		Kind:     KindHotspot,
+		Name:     fn.Name,
+		FilePath: f.path,
+		Title:    fmt.Sprintf("Hotspot: %s in %s:%d (risk %.2f)", fn.Name, f.path, fn.Line, risk),
+		Detail:   fmt.Sprintf("Complexity %d in a file with %.1f recent commits (%s in all).", fn.Complexity, f.churn, f.commits),
+		Metadata: meta,
+	}
+}
+
+// Hotspot is a hotspots entry decoded for display.
+type Hotspot struct {
+	Scope      string  `json:"scope"` // HotspotScopeFile or HotspotScopeFunction
+	File       string  `json:"file"`
+	Function   string  `json:"function,omitempty"`
+	Line       int     `json:"line,omitempty"`
+	Rank       int     `json:"rank"`
+	Risk       float64 `json:"risk"`
+	Churn      float64 `json:"churn"` // recent commits, decayed by age
+	Commits    int     `json:"commits"`
+	Complexity int     `json:"complexity"` // the function's, or the file's total
+	Flagged    bool    `json:"flagged,omitempty"`
+	LastChange string  `json:"last_change,omitempty"`
+}
+
+// HotspotsFromEntries decodes hotspots entries, riskiest first; scope
+// ("file", "function", "" for both) filters them.
+func HotspotsFromEntries(entries []*Entry, scope string) []Hotspot {
+	var out []Hotspot
+	for _, e := range entries {
+		if e.Kind != KindHotspot || (scope != "" && e.Metadata["scope"] != scope) {
+			continue
+		}
+		h := Hotspot{
+			Scope:      e.Metadata["scope"],
+			File:       e.FilePath,
+			Function:   e.Metadata["function"],
+			Flagged:    e.Metadata["flagged"] == "true",
+			LastChange: e.Metadata["last_change"],
+		}
+		h.Line, _ = strconv.Atoi(e.Metadata["line"])
+		h.Rank, _ = strconv.Atoi(e.Metadata["rank"])
+		h.Risk, _ = strconv.ParseFloat(e.Metadata["risk"], 64)
+		h.Churn, _ = strconv.ParseFloat(e.Metadata["churn"], 64)
+		h.Commits, _ = strconv.Atoi(e.Metadata["commits"])
+		h.Complexity, _ = strconv.Atoi(e.Metadata["complexity"])
+		out = append(out, h)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Risk != out[j].Risk {
+			return out[i].Risk > out[j].Risk
+		}
+		if out[i].Scope != out[j].Scope {
+			return out[i].Scope == HotspotScopeFile
+		}
+		return out[i].Rank < out[j].Rank
+	})
+	return out
+}
@@ -0,0 +1,143 @@
+package survey
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeComplexity map[string][]FunctionComplexity
+
+func (f fakeComplexity) FileComplexity(filePath string) ([]FunctionComplexity, string, error) {
+	return f[filePath], "findings", nil
+}
+
+func churnEntry(path, commits, recent string) *Entry {
+	meta := map[string]string{"commits": commits, "last_change": "2026-06-01"}
+	if recent != "" {
+		meta["recent_commits"] = recent
+	}
+	return &Entry{Analyzer: AnalyzerChurn, Kind: KindChurn, Name: path, FilePath: path, Metadata: meta}
+}
+
+func TestRunHotspots(t *testing.T) {
+	churn := []*Entry{
+		churnEntry("busy_simple.go", "40", "20.00"),
+		churnEntry("busy_complex.go", "30", "15.00"),
+		churnEntry("quiet_complex.go", "30", "1.00"), // complex, but its churn is long past
+		churnEntry("README.md", "50", "25.00"),
+		churnEntry("legacy.go", "10", ""), // stored before recent_commits existed
+		{Analyzer: AnalyzerChurn, Kind: KindSubmodule, Name: "vendor/x", FilePath: "vendor/x"},
+	}
+	source := fakeComplexity{
+		"busy_simple.go":   {{Name: "small", Line: 1, Complexity: 2}},
+		"busy_complex.go":  {{Name: "parse", Line: 10, EndLine: 90, Complexity: 40, Flagged: true}, {Name: "helper", Line: 100, Complexity: 5}},
+		"quiet_complex.go": {{Name: "solve", Line: 5, Complexity: 60, Flagged: true}},
+		"legacy.go":        {{Name: "old", Line: 3, Complexity: 10}},
+	}
+
+	result, err := RunHotspots(HotspotsConfig{Churn: churn, Complexity: source, MaxFunctions: 3})
+	if err != nil {
+		t.Fatalf("RunHotspots: %v", err)
+	}
+	if result.Files != 4 || result.Functions != 3 || result.Unmeasured != 1 {
+		t.Errorf("files/functions/unmeasured = %d/%d/%d, want 4/3/1", result.Files, result.Functions, result.Unmeasured)
+	}
+
+	var files, funcs []*Entry
+	for _, e := range result.Entries {
+		if e.Kind != KindHotspot || e.Analyzer != AnalyzerHotspots {
+			t.Errorf("entry %s has kind/analyzer %s/%s", e.Name, e.Kind, e.Analyzer)
+		}
+		switch e.Metadata["scope"] {
+		case HotspotScopeFile:
+			files = append(files, e)
+		case HotspotScopeFunction:
+			funcs = append(funcs, e)
+		}
+	}
+	if len(files) != 4 || len(funcs) != 3 {
+		t.Fatalf("got %d file and %d function entries, want 4 and 3", len(files), len(funcs))
+	}
+
+	top := files[0]
+	if top.Name != "busy_complex.go" || top.Metadata["rank"] != "1" {
+		t.Fatalf("top file = %s (rank %s), want busy_complex.go", top.Name, top.Metadata["rank"])
+	}
+	if top.Metadata["complexity"] != "45" || top.Metadata["max_function"] != "parse" || top.Metadata["churn"] != "15.00" {
+		t.Errorf("top file metadata = %v", top.Metadata)
+	}
+	// Busy but simple and complex but quiet both rank below.
+	if files[3].Name != "busy_simple.go" {
+		t.Errorf("last file = %s, want busy_simple.go", files[3].Name)
+	}
+
+	if funcs[0].Name != "parse" || funcs[0].Metadata["flagged"] != "true" || funcs[0].Metadata["end_line"] != "90" {
+		t.Errorf("top function = %s %v", funcs[0].Name, funcs[0].Metadata)
+	}
+	if funcs[0].Metadata["risk"] != "0.50" {
+		t.Errorf("parse risk = %s, want 0.50 (0.75 churn x 40/60 complexity)", funcs[0].Metadata["risk"])
+	}
+}
+
+func TestRunHotspots_NoComplexity(t *testing.T) {
+	result, err := RunHotspots(HotspotsConfig{Churn: []*Entry{churnEntry("a.go", "5", "2.00")}, Complexity: fakeComplexity{}})
+	if err != nil {
+		t.Fatalf("RunHotspots: %v", err)
+	}
+	if len(result.Entries) != 0 || result.Unmeasured != 1 {
+		t.Errorf("entries/unmeasured = %d/%d, want 0/1", len(result.Entries), result.Unmeasured)
+	}
+}
+
+func TestFileChurnStats_Recent(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	// Newer than initTestRepo's commit, so it anchors the decay.
+	now := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	writeTestFile(t, dir, "old.go", "package a // 1\n")
+	commitAs(t, repo, "Alice", "alice@example.com", now.Add(-2*DefaultChurnHalfLife))
+	writeTestFile(t, dir, "old.go", "package a // 2\n")
+	commitAs(t, repo, "Alice", "alice@example.com", now.Add(-2*DefaultChurnHalfLife))
+	writeTestFile(t, dir, "new.go", "package a\n")
+	commitAs(t, repo, "Alice", "alice@example.com", now)
+
+	gitRepo, err := OpenGitRepo(dir)
+	if err != nil || gitRepo == nil {
+		t.Fatalf("OpenGitRepo: %v", err)
+	}
+	stats, err := gitRepo.FileChurnStats(0)
+	if err != nil {
+		t.Fatalf("FileChurnStats: %v", err)
+	}
+	if s := stats["new.go"]; s == nil || s.Recent != 1 || !s.Last.Equal(now) {
+		t.Errorf("new.go = %+v, want recent 1 at %s", s, now)
+	}
+	// Two commits two half-lives back count half a commit together.
+	if s := stats["old.go"]; s == nil || s.Commits != 2 || s.Recent < 0.49 || s.Recent > 0.51 {
+		t.Errorf("old.go = %+v, want 2 commits with recent 0.5", s)
+	}
+}
+
+func TestHotspotsFromEntries(t *testing.T) {
+	churn := []*Entry{churnEntry("a.go", "12", "6.00"), churnEntry("b.go", "3", "3.00")}
+	source := fakeComplexity{
+		"a.go": {{Name: "Run", Line: 7, Complexity: 20, Flagged: true}},
+		"b.go": {{Name: "Parse", Line: 2, Complexity: 30}},
+	}
+	result, err := RunHotspots(HotspotsConfig{Churn: churn, Complexity: source})
+	if err != nil {
+		t.Fatalf("RunHotspots: %v", err)
+	}
+
+	funcs := HotspotsFromEntries(result.Entries, HotspotScopeFunction)
+	if len(funcs) != 2 {
+		t.Fatalf("got %d function hotspots, want 2", len(funcs))
+	}
+	want := Hotspot{Scope: HotspotScopeFunction, File: "a.go", Function: "Run", Line: 7, Rank: 1, Risk: 0.67, Churn: 6, Commits: 12, Complexity: 20, Flagged: true, LastChange: "2026-06-01"}
+	if funcs[0] != want {
+		t.Errorf("top function hotspot = %+v, want %+v", funcs[0], want)
+	}
+	if all := HotspotsFromEntries(result.Entries, ""); len(all) != 4 || all[0].Scope != HotspotScopeFile {
+		t.Errorf("all hotspots = %+v, want 4 led by a file", all)
+	}
+}
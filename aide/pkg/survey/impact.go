@@ -0,0 +1,439 @@
+package survey
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+)
+
+// DefaultImpactDepth bounds how many caller hops impact analysis follows
+// from each changed symbol.
+const DefaultImpactDepth = 3
+
+// Change is a changed region of a file: lines Start..End (1-indexed,
+// inclusive), or the whole file when Start is 0.
+type Change struct {
+	FilePath string `json:"file"`
+	Start    int    `json:"start,omitempty"`
+	End      int    `json:"end,omitempty"`
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Start == 0:
+		return c.FilePath
+	case c.End == c.Start:
+		return fmt.Sprintf("%s:%d", c.FilePath, c.Start)
+	default:
+		return fmt.Sprintf("%s:%d-%d", c.FilePath, c.Start, c.End)
+	}
+}
+
+// ParseChangeSpec parses "path", "path:line" or "path:start-end".
+func ParseChangeSpec(spec string) (Change, error) {
+	path, lines, ok := strings.Cut(spec, ":")
+	c := Change{FilePath: filepath.ToSlash(path)}
+	if !ok || lines == "" {
+		return c, nil
+	}
+	from, to, ranged := strings.Cut(lines, "-")
+	start, err := strconv.Atoi(from)
+	if err != nil || start < 1 {
+		return c, fmt.Errorf("invalid line in %q", spec)
+	}
+	c.Start, c.End = start, start
+	if ranged {
+		if c.End, err = strconv.Atoi(to); err != nil || c.End < start {
+			return c, fmt.Errorf("invalid line range in %q", spec)
+		}
+	}
+	return c, nil
+}
+
+// ParseUnifiedDiff extracts the changed lines of each file from unified diff
+// output (git diff, diff -u), numbered as in the new version. Lines removed
+// without replacement are recorded at the line before the removal. Deleted files are reported
+// whole.
+func ParseUnifiedDiff(diff string) []Change {
+	var changes []Change
+	var file, oldFile string
+	var newLine, oldLeft, newLeft int
+	mark := func(line int) {
+		line = max(line, 1)
+		if n := len(changes); n > 0 && changes[n-1].FilePath == file && changes[n-1].Start > 0 && changes[n-1].End >= line-1 {
+			changes[n-1].End = max(changes[n-1].End, line)
+			return
+		}
+		changes = append(changes, Change{FilePath: file, Start: line, End: line})
+	}
+
+	// removed is set by removed lines until an added line replaces them or
+	// the removal ends.
+	removed := false
+	for _, l := range strings.Split(diff, "\n") {
+		if oldLeft > 0 || newLeft > 0 {
+			// Inside a hunk body.
+			switch {
+			case strings.HasPrefix(l, "+"):
+				mark(newLine)
+				removed = false
+				newLine++
+				newLeft--
+			case strings.HasPrefix(l, "-"):
+				removed = true
+				oldLeft--
+			case strings.HasPrefix(l, "\\"): // "\ No newline at end of file"
+			default:
+				if removed {
+					mark(newLine - 1)
+					removed = false
+				}
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+			if removed && oldLeft <= 0 && newLeft <= 0 {
+				mark(newLine - 1)
+				removed = false
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(l, "--- "):
+			oldFile, file = diffPath(l[4:]), ""
+		case strings.HasPrefix(l, "+++ "):
+			file = diffPath(l[4:])
+			if file == "" && oldFile != "" {
+				changes = append(changes, Change{FilePath: oldFile})
+			}
+		case strings.HasPrefix(l, "@@ ") && file != "":
+			// @@ -a,b +c,d @@
+			fields := strings.Fields(l)
+			if len(fields) < 3 {
+				continue
+			}
+			_, oldLeft = hunkRange(fields[1])
+			newLine, newLeft = hunkRange(fields[2])
+			if newLeft == 0 {
+				newLine++ // "+c,0" names the line before the removal
+			}
+		}
+	}
+	return changes
+}
+
+// hunkRange parses a hunk header range ("-a,b" or "+c"), whose count
+// defaults to 1.
+func hunkRange(r string) (start, count int) {
+	from, n, ok := strings.Cut(r[1:], ",")
+	start, _ = strconv.Atoi(from)
+	count = 1
+	if ok {
+		count, _ = strconv.Atoi(n)
+	}
+	return start, count
+}
+
+// diffPath strips a unified diff header path of its a/ or b/ prefix and
+// timestamp; /dev/null yields "".
+func diffPath(p string) string {
+	if i := strings.IndexByte(p, '\t'); i >= 0 {
+		p = p[:i]
+	}
+	p = strings.TrimSpace(p)
+	if p == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(p, "a/") || strings.HasPrefix(p, "b/") {
+		p = p[2:]
+	}
+	return p
+}
+
+// WorktreeChanges returns the uncommitted changes in the working tree,
+// staged or not, relative to HEAD. Paths are relative to Root. Untracked
+// and deleted files are reported whole.
+func (g *GitRepo) WorktreeChanges() ([]Change, error) {
+	wt, err := g.repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git status: %w", err)
+	}
+	var head *object.Commit
+	if ref, err := g.repo.Head(); err == nil {
+		if c, err := g.repo.CommitObject(ref.Hash()); err == nil {
+			head = c
+		}
+	}
+	root := wt.Filesystem.Root()
+
+	paths := make([]string, 0, len(status))
+	for p, s := range status {
+		if s.Worktree != git.Unmodified || s.Staging != git.Unmodified {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var changes []Change
+	for _, p := range paths {
+		s := status[p]
+		current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		if err != nil || s.Worktree == git.Untracked || head == nil {
+			changes = append(changes, Change{FilePath: p})
+			continue
+		}
+		old, _, ok, err := fileAt(head, p)
+		if err != nil || !ok {
+			changes = append(changes, Change{FilePath: p})
+			continue
+		}
+		changes = append(changes, changedLines(p, old, current)...)
+	}
+	return changes, nil
+}
+
+// changedLines lists the lines of current that differ from old. A pure
+// deletion is recorded at the line before it.
+func changedLines(path string, old, current []byte) []Change {
+	if bytes.Equal(old, current) {
+		return nil
+	}
+	var changes []Change
+	line := 0
+	for _, h := range lineHunks(old, current) {
+		line += h.equal
+		switch {
+		case h.ins > 0:
+			changes = append(changes, Change{FilePath: path, Start: line + 1, End: line + h.ins})
+		case h.del > 0:
+			changes = append(changes, Change{FilePath: path, Start: max(line, 1), End: max(line, 1)})
+		}
+		line += h.ins
+	}
+	return changes
+}
+
+// ImpactOptions configures AnalyzeImpact.
+type ImpactOptions struct {
+	// MaxDepth limits caller hops from each changed symbol (0 =
+	// DefaultImpactDepth).
+	MaxDepth int
+	// MaxNodes caps the caller graph of each changed symbol (0 =
+	// DefaultGraphLimit).
+	MaxNodes int
+	// Exact only follows references resolved to the changed definitions.
+	Exact bool
+	// Registry supplies test-file patterns (nil = the default registry).
+	Registry *grammar.PackRegistry
+}
+
+// ImpactSymbol is a symbol a change touches or may break.
+type ImpactSymbol struct {
+	GraphNode
+	Via  []string `json:"via,omitempty"`  // Changed symbols it depends on
+	Test bool     `json:"test,omitempty"` // Defined in a test file
+}
+
+// ImpactReport is the result of AnalyzeImpact.
+type ImpactReport struct {
+	Changes     []Change        `json:"changes"`
+	Changed     []*ImpactSymbol `json:"changed"`            // Symbols containing changed lines
+	Affected    []*ImpactSymbol `json:"affected"`           // Transitive callers and users, nearest and widest-reaching first
+	Entrypoints []*Entry        `json:"entrypoints"`        // Entry points inside impacted symbols or files
+	TestFiles   []string        `json:"test_files"`         // Test files among the changed and affected
+	Unmapped    []string        `json:"unmapped,omitempty"` // Changed files with no indexed symbol on a changed line
+}
+
+// AnalyzeImpact maps changes onto the symbols containing the changed lines
+// and walks their callers, and the users of changed types, transitively. It
+// reports the entry points among the survey entries given that sit inside
+// an impacted symbol (or, without a line, in an impacted file) and the test
+// files reached. Whole-file changes are read from rootDir to count lines.
+func AnalyzeImpact(cg CodeGrapher, rootDir string, changes []Change, entrypoints []*Entry, opts ImpactOptions) (*ImpactReport, error) {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultImpactDepth
+	}
+	if opts.Registry == nil {
+		opts.Registry = grammar.DefaultPackRegistry()
+	}
+	report := &ImpactReport{Changes: changes}
+	key := func(n GraphNode) string { return n.FilePath + ":" + n.displayName() }
+
+	// Changed symbols: the narrowest symbol on each changed line.
+	changed := make(map[string]*ImpactSymbol)
+	tests := make(map[string]bool)
+	unmapped := make(map[string]bool)
+	for _, c := range changes {
+		start, end := c.Start, c.End
+		if start == 0 {
+			data, err := os.ReadFile(filepath.Join(rootDir, filepath.FromSlash(c.FilePath)))
+			if err != nil {
+				unmapped[c.FilePath] = true
+				continue
+			}
+			start, end = 1, bytes.Count(data, []byte{'\n'})+1
+		}
+		if isTestFile(opts.Registry.Get(langForPath(opts.Registry, c.FilePath)), c.FilePath) {
+			tests[c.FilePath] = true
+		}
+		found := false
+		for line := start; line <= end; line++ {
+			h, err := cg.GetContainingSymbol(c.FilePath, line)
+			if err != nil || h == nil {
+				continue
+			}
+			found = true
+			n := hitNode(*h)
+			if _, ok := changed[key(n)]; !ok {
+				changed[key(n)] = &ImpactSymbol{GraphNode: n}
+			}
+		}
+		if !found {
+			unmapped[c.FilePath] = true
+		}
+	}
+	for _, s := range changed {
+		report.Changed = append(report.Changed, s)
+		delete(unmapped, s.FilePath)
+	}
+	sortImpact(report.Changed)
+
+	// Affected symbols: each changed symbol's transitive callers.
+	affected := make(map[string]*ImpactSymbol)
+	for _, s := range report.Changed {
+		graph, err := BuildCallGraph(cg, s.displayName(), GraphOptions{
+			MaxDepth:  opts.MaxDepth,
+			MaxNodes:  opts.MaxNodes,
+			Direction: "callers",
+			Exact:     opts.Exact,
+			TypeRefs:  true,
+			File:      s.FilePath,
+		})
+		if err != nil {
+			continue
+		}
+		for _, n := range graph.Nodes {
+			k := key(n)
+			if n.Depth == 0 || changed[k] != nil {
+				continue
+			}
+			a, ok := affected[k]
+			if !ok {
+				a = &ImpactSymbol{GraphNode: n}
+				affected[k] = a
+			} else if n.Depth < a.Depth {
+				a.Depth = n.Depth
+			}
+			a.Via = append(a.Via, s.displayName())
+		}
+	}
+	for _, a := range affected {
+		report.Affected = append(report.Affected, a)
+	}
+
+	impacted := append(append([]*ImpactSymbol(nil), report.Changed...), report.Affected...)
+	for _, s := range impacted {
+		lang := s.Language
+		if lang == "" {
+			lang = langForPath(opts.Registry, s.FilePath)
+		}
+		if isTestFile(opts.Registry.Get(lang), s.FilePath) {
+			s.Test = true
+			tests[s.FilePath] = true
+		}
+	}
+	sortImpact(report.Affected)
+
+	report.Entrypoints = affectedEntrypoints(entrypoints, impacted)
+	for f := range tests {
+		report.TestFiles = append(report.TestFiles, f)
+	}
+	sort.Strings(report.TestFiles)
+	for f := range unmapped {
+		report.Unmapped = append(report.Unmapped, f)
+	}
+	sort.Strings(report.Unmapped)
+	return report, nil
+}
+
+// hitNode converts a symbol hit to a graph node.
+func hitNode(h SymbolHit) GraphNode {
+	return GraphNode{
+		Name:          h.Name,
+		QualifiedName: h.QualifiedName,
+		Kind:          h.Kind,
+		FilePath:      h.FilePath,
+		Line:          h.Line,
+		EndLine:       h.EndLine,
+		Language:      h.Language,
+	}
+}
+
+// displayName returns the qualified name when known, else the bare name.
+func (n GraphNode) displayName() string {
+	if n.QualifiedName != "" {
+		return n.QualifiedName
+	}
+	return n.Name
+}
+
+// sortImpact ranks symbols nearest first, then by how many changed symbols
+// they depend on, non-test code before tests, then by location.
+func sortImpact(syms []*ImpactSymbol) {
+	sort.Slice(syms, func(i, j int) bool {
+		a, b := syms[i], syms[j]
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		if len(a.Via) != len(b.Via) {
+			return len(a.Via) > len(b.Via)
+		}
+		if a.Test != b.Test {
+			return !a.Test
+		}
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.Line < b.Line
+	})
+}
+
+// affectedEntrypoints returns the entry points declared inside an impacted
+// symbol, or with no line, in a file holding one.
+func affectedEntrypoints(entries []*Entry, impacted []*ImpactSymbol) []*Entry {
+	var out []*Entry
+	for _, e := range entries {
+		if e.Kind != KindEntrypoint || e.FilePath == "" {
+			continue
+		}
+		line, _ := strconv.Atoi(e.Metadata["line"])
+		for _, s := range impacted {
+			if s.FilePath != e.FilePath {
+				continue
+			}
+			if line == 0 || (s.Line <= line && line <= s.EndLine) {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// langForPath names the language of path by its extension, or "".
+func langForPath(reg *grammar.PackRegistry, path string) string {
+	lang, _ := reg.LangForExtension(filepath.Ext(path))
+	return lang
+}
@@ -0,0 +1,161 @@
+package survey
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestParseChangeSpec(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Change
+		err  bool
+	}{
+		{"a.go", Change{FilePath: "a.go"}, false},
+		{"a.go:12", Change{FilePath: "a.go", Start: 12, End: 12}, false},
+		{"pkg/a.go:3-9", Change{FilePath: "pkg/a.go", Start: 3, End: 9}, false},
+		{"a.go:x", Change{}, true},
+		{"a.go:9-3", Change{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseChangeSpec(tt.spec)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseChangeSpec(%q) error = %v", tt.spec, err)
+			continue
+		}
+		if !tt.err && got != tt.want {
+			t.Errorf("ParseChangeSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestParseUnifiedDiff(t *testing.T) {
+	diff := `diff --git a/a.go b/a.go
+--- a/a.go
++++ b/a.go
+@@ -3,6 +3,6 @@ func A() {
+ 	x := 1
+-	y := 2
++	y := 3
++	z := 4
+ 	_ = x
+--- old comment
+ 	return
+ }
+@@ -20 +21,0 @@
+-	removed()
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package gone
+-
+`
+	// The "--- old comment" line is a removed "-- old comment" line, not a
+	// file header.
+	got := ParseUnifiedDiff(diff)
+	want := []Change{
+		{FilePath: "a.go", Start: 4, End: 6},
+		{FilePath: "a.go", Start: 21, End: 21},
+		{FilePath: "gone.go"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseUnifiedDiff = %+v, want %+v", got, want)
+	}
+}
+
+func TestChangedLines(t *testing.T) {
+	old := []byte("a\nb\nc\nd\n")
+	cur := []byte("a\nB\nc\n")
+	got := changedLines("f.txt", old, cur)
+	want := []Change{
+		{FilePath: "f.txt", Start: 2, End: 2},
+		{FilePath: "f.txt", Start: 3, End: 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("changedLines = %+v, want %+v", got, want)
+	}
+}
+
+func TestAnalyzeImpact(t *testing.T) {
+	cg := newMockCodeGrapher()
+	helper := SymbolHit{Name: "helper", Kind: "function", FilePath: "util.go", Line: 5, EndLine: 15, Language: "go"}
+	handler := SymbolHit{Name: "handler", Kind: "function", FilePath: "api/handler.go", Line: 1, EndLine: 30, Language: "go"}
+	mainSym := SymbolHit{Name: "main", Kind: "function", FilePath: "main.go", Line: 1, EndLine: 10, Language: "go"}
+	testSym := SymbolHit{Name: "TestHelper", Kind: "function", FilePath: "util_test.go", Line: 3, EndLine: 8, Language: "go"}
+
+	cg.symbols["helper"] = []SymbolHit{helper, {Name: "helper", Kind: "function", FilePath: "other/util.go", Line: 1, EndLine: 2}}
+	cg.symbols["handler"] = []SymbolHit{handler}
+	for line := 7; line <= 8; line++ {
+		cg.containing["util.go:"+strconv.Itoa(line)] = &helper
+	}
+	// helper <- handler <- main, and helper <- TestHelper.
+	cg.references["helper"] = []ReferenceHit{
+		{Symbol: "helper", Kind: "call", FilePath: "api/handler.go", Line: 12},
+		{Symbol: "helper", Kind: "call", FilePath: "util_test.go", Line: 5},
+	}
+	cg.references["handler"] = []ReferenceHit{
+		{Symbol: "handler", Kind: "call", FilePath: "main.go", Line: 4},
+	}
+	cg.containing["api/handler.go:12"] = &handler
+	cg.containing["util_test.go:5"] = &testSym
+	cg.containing["main.go:4"] = &mainSym
+
+	entries := []*Entry{
+		{Kind: KindEntrypoint, Name: "main", FilePath: "main.go", Metadata: map[string]string{"line": "1"}},
+		{Kind: KindEntrypoint, Name: "other", FilePath: "cmd/other.go", Metadata: map[string]string{"line": "3"}},
+	}
+	changes := []Change{{FilePath: "util.go", Start: 7, End: 8}, {FilePath: "README.md", Start: 1, End: 1}}
+
+	report, err := AnalyzeImpact(cg, t.TempDir(), changes, entries, ImpactOptions{})
+	if err != nil {
+		t.Fatalf("AnalyzeImpact: %v", err)
+	}
+	if len(report.Changed) != 1 || report.Changed[0].Name != "helper" {
+		t.Fatalf("changed = %+v, want [helper]", report.Changed)
+	}
+	var names []string
+	for _, a := range report.Affected {
+		names = append(names, a.Name)
+	}
+	if want := []string{"handler", "TestHelper", "main"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("affected = %v, want %v", names, want)
+	}
+	if a := report.Affected[2]; a.Depth != 2 || !reflect.DeepEqual(a.Via, []string{"helper"}) {
+		t.Errorf("main = %+v, want depth 2 via helper", a)
+	}
+	if !report.Affected[1].Test {
+		t.Error("TestHelper should be marked as a test")
+	}
+	if len(report.Entrypoints) != 1 || report.Entrypoints[0].Name != "main" {
+		t.Errorf("entrypoints = %+v, want [main]", report.Entrypoints)
+	}
+	if !reflect.DeepEqual(report.TestFiles, []string{"util_test.go"}) {
+		t.Errorf("test files = %v", report.TestFiles)
+	}
+	if !reflect.DeepEqual(report.Unmapped, []string{"README.md"}) {
+		t.Errorf("unmapped = %v", report.Unmapped)
+	}
+}
+
+func TestWorktreeChanges(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	writeTestFile(t, dir, "a.go", "package a\n\nfunc A() {\n\treturn\n}\n")
+	commitAll(t, repo, "add a.go")
+	writeTestFile(t, dir, "a.go", "package a\n\nfunc A() {\n\tprintln()\n\treturn\n}\n")
+	writeTestFile(t, dir, "b.go", "package a\n")
+
+	g, err := OpenGitRepo(dir)
+	if err != nil || g == nil {
+		t.Fatalf("OpenGitRepo: %v", err)
+	}
+	got, err := g.WorktreeChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Change{{FilePath: "a.go", Start: 4, End: 4}, {FilePath: "b.go"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WorktreeChanges = %+v, want %+v", got, want)
+	}
+}
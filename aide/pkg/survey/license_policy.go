@@ -0,0 +1,167 @@
+package survey
+
+import (
+	"path"
+	"strings"
+)
+
+// License policy verdicts, least to most severe.
+const (
+	LicenseAllowed    = "allowed"     // permitted by the policy
+	LicenseUnknownUse = "unknown"     // not recognised, needs a human decision
+	LicenseNotAllowed = "not-allowed" // outside an explicit allow list
+	LicenseDenied     = "denied"      // matched by the deny list
+)
+
+var verdictRank = map[string]int{
+	LicenseAllowed:    0,
+	LicenseUnknownUse: 1,
+	LicenseNotAllowed: 2,
+	LicenseDenied:     3,
+}
+
+// LicensePolicy decides which dependency licenses a project accepts. Terms
+// are SPDX identifiers ("MIT"), globs ("GPL-*") or categories
+// ("strong-copyleft"), matched case-insensitively. Deny wins over allow;
+// with an allow list, licenses outside it are not allowed.
+type LicensePolicy struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// IsZero reports whether the policy has no terms.
+func (p LicensePolicy) IsZero() bool {
+	return len(p.Allow) == 0 && len(p.Deny) == 0
+}
+
+// DefaultLicensePolicy is the policy used when none is configured: deny
+// every category more restrictive than both the project's own licenses and
+// weak copyleft. An Apache-2.0 project thus denies GPL and AGPL
+// dependencies; a GPL-3.0 project denies only AGPL ones.
+func DefaultLicensePolicy(project []string) LicensePolicy {
+	limit := categoryRank[LicenseWeakCopyleft]
+	for _, expr := range project {
+		if r, ok := categoryRank[expressionCategory(expr)]; ok && r > limit {
+			limit = r
+		}
+	}
+	var p LicensePolicy
+	for _, c := range []string{LicenseStrongCopyleft, LicenseNetworkCopyleft} {
+		if categoryRank[c] > limit {
+			p.Deny = append(p.Deny, c)
+		}
+	}
+	return p
+}
+
+// Evaluate returns the policy verdict for an SPDX expression and the
+// reason. Of "A OR B" the most permissive alternative counts, since the
+// licensee may choose; of "A AND B" the strictest part. WITH exceptions
+// are ignored.
+func (p LicensePolicy) Evaluate(expr string) (verdict, reason string) {
+	best, bestReason := "", ""
+	for _, alt := range splitSPDX(expr, "OR") {
+		v, r := LicenseAllowed, ""
+		for _, id := range spdxIDs(alt) {
+			iv, ir := p.evaluateID(id)
+			if verdictRank[iv] > verdictRank[v] {
+				v, r = iv, ir
+			}
+		}
+		if r == "" {
+			r = alt + " is allowed"
+		}
+		if best == "" || verdictRank[v] < verdictRank[best] {
+			best, bestReason = v, r
+		}
+	}
+	if best == "" {
+		return LicenseUnknownUse, "no license identified"
+	}
+	return best, bestReason
+}
+
+func (p LicensePolicy) evaluateID(id string) (string, string) {
+	category := LicenseCategory(id)
+	if t := matchLicenseTerm(p.Deny, id, category); t != "" {
+		return LicenseDenied, id + " is denied by policy term " + t
+	}
+	if t := matchLicenseTerm(p.Allow, id, category); t != "" {
+		return LicenseAllowed, ""
+	}
+	if category == "" {
+		return LicenseUnknownUse, id + " is not a recognised license"
+	}
+	if len(p.Allow) > 0 {
+		return LicenseNotAllowed, id + " (" + category + ") is not in the allow list"
+	}
+	return LicenseAllowed, ""
+}
+
+// matchLicenseTerm returns the first term matching the SPDX id or its
+// category, "" when none does.
+func matchLicenseTerm(terms []string, id, category string) string {
+	uid := strings.ToUpper(id)
+	base := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(uid, "+"), "-ONLY"), "-OR-LATER")
+	for _, t := range terms {
+		ut := strings.ToUpper(strings.TrimSpace(t))
+		if ut == uid || ut == base || (category != "" && strings.EqualFold(t, category)) {
+			return t
+		}
+		if ok, _ := path.Match(ut, uid); ok {
+			return t
+		}
+	}
+	return ""
+}
+
+// splitSPDX splits an expression on a top-level operator, ignoring
+// parenthesised groups; "MIT OR (Apache-2.0 AND BSD-3-Clause)" splits on
+// OR into "MIT" and "(Apache-2.0 AND BSD-3-Clause)".
+func splitSPDX(expr, op string) []string {
+	var parts []string
+	depth, start := 0, 0
+	fields := strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))
+	if enclosed(fields) {
+		return splitSPDX(strings.Join(fields[1:len(fields)-1], " "), op)
+	}
+	for i, f := range fields {
+		switch {
+		case f == "(":
+			depth++
+		case f == ")":
+			depth--
+		case depth == 0 && strings.EqualFold(f, op):
+			parts = append(parts, strings.Join(fields[start:i], " "))
+			start = i + 1
+		}
+	}
+	parts = append(parts, strings.Join(fields[start:], " "))
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.NewReplacer("( ", "(", " )", ")").Replace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// enclosed reports whether one pair of parentheses wraps all of fields.
+func enclosed(fields []string) bool {
+	if len(fields) < 2 || fields[0] != "(" || fields[len(fields)-1] != ")" {
+		return false
+	}
+	depth := 0
+	for _, f := range fields[:len(fields)-1] {
+		switch f {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if depth == 0 {
+			return false
+		}
+	}
+	return true
+}
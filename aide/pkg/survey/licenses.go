@@ -0,0 +1,359 @@
+// Package survey: licenses.go inventories the licenses in a tree — the
+// project's own LICENSE/COPYING files and those of vendored and installed
+// dependencies — classifying each against a bundled corpus of SPDX
+// licenses.
+package survey
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// License categories, least to most restrictive.
+const (
+	LicensePublicDomain    = "public-domain"
+	LicensePermissive      = "permissive"
+	LicenseWeakCopyleft    = "weak-copyleft"
+	LicenseStrongCopyleft  = "strong-copyleft"
+	LicenseNetworkCopyleft = "network-copyleft"
+)
+
+// LicenseUnknown is the SPDX field of a license file the corpus does not
+// recognise.
+const LicenseUnknown = "unknown"
+
+// License scopes: whose license a file is.
+const (
+	LicenseScopeProject    = "project"
+	LicenseScopeDependency = "dependency"
+)
+
+// maxLicenseFileSize skips files too large to be a license text.
+const maxLicenseFileSize = 512 << 10
+
+var categoryRank = map[string]int{
+	LicensePublicDomain:    0,
+	LicensePermissive:      1,
+	LicenseWeakCopyleft:    2,
+	LicenseStrongCopyleft:  3,
+	LicenseNetworkCopyleft: 4,
+}
+
+//go:embed license_corpus.json
+var licenseCorpusJSON []byte
+
+// CorpusLicense is one license of the bundled corpus. A text matches when
+// every phrase of one of the Any sets appears in it and no Excludes phrase
+// does; phrases are compared after normalisation (lowercase, punctuation
+// and whitespace collapsed).
+type CorpusLicense struct {
+	SPDX     string     `json:"spdx"`
+	Name     string     `json:"name"`
+	Category string     `json:"category"`
+	Any      [][]string `json:"any"`
+	Excludes []string   `json:"excludes"`
+}
+
+var (
+	corpusOnce sync.Once
+	corpus     []CorpusLicense
+)
+
+// LicenseCorpus returns the bundled corpus with its phrases normalised.
+func LicenseCorpus() []CorpusLicense {
+	corpusOnce.Do(func() {
+		var doc struct {
+			Licenses []CorpusLicense `json:"licenses"`
+		}
+		if err := json.Unmarshal(licenseCorpusJSON, &doc); err != nil {
+			panic(fmt.Sprintf("license corpus: %v", err))
+		}
+		for i := range doc.Licenses {
+			l := &doc.Licenses[i]
+			for _, set := range l.Any {
+				for j := range set {
+					set[j] = normalizeLicenseText(set[j])
+				}
+			}
+			for j := range l.Excludes {
+				l.Excludes[j] = normalizeLicenseText(l.Excludes[j])
+			}
+		}
+		corpus = doc.Licenses
+	})
+	return corpus
+}
+
+// LicenseCategory returns the corpus category of an SPDX identifier, or ""
+// when the corpus does not know it. "-only"/"-or-later" suffixes are
+// ignored.
+func LicenseCategory(spdx string) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(spdx, "+"), "-only"), "-or-later")
+	for _, l := range LicenseCorpus() {
+		if strings.EqualFold(l.SPDX, base) {
+			return l.Category
+		}
+	}
+	return ""
+}
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+func normalizeLicenseText(s string) string {
+	return strings.TrimSpace(nonWord.ReplaceAllString(strings.ToLower(s), " "))
+}
+
+// spdxHeader captures the expression of an SPDX-License-Identifier line,
+// stopping at anything outside the expression grammar (comment closers,
+// markdown backticks).
+var spdxHeader = regexp.MustCompile(`SPDX-License-Identifier:\s*([A-Za-z0-9.+:()\- ]+)`)
+
+// ClassifyLicense identifies the license of a text: an
+// SPDX-License-Identifier line wins outright, otherwise every corpus
+// license whose phrases all appear. A file holding several licenses
+// yields an AND expression. It returns the SPDX expression (LicenseUnknown
+// when nothing matches), the method used and a confidence.
+func ClassifyLicense(text string) (spdx, method string, confidence float64) {
+	for _, line := range strings.SplitN(text, "\n", 50) {
+		if m := spdxHeader.FindStringSubmatch(line); m != nil {
+			return strings.TrimSpace(m[1]), "spdx-header", 1
+		}
+	}
+	norm := " " + normalizeLicenseText(text) + " "
+	var matched []string
+	for _, l := range LicenseCorpus() {
+		if corpusMatch(norm, l) {
+			matched = append(matched, l.SPDX)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return LicenseUnknown, "", 0
+	case 1:
+		return matched[0], "corpus", 0.9
+	}
+	return strings.Join(matched, " AND "), "corpus", 0.7
+}
+
+func corpusMatch(norm string, l CorpusLicense) bool {
+	for _, ex := range l.Excludes {
+		if strings.Contains(norm, " "+ex+" ") {
+			return false
+		}
+	}
+	for _, set := range l.Any {
+		all := true
+		for _, phrase := range set {
+			if !strings.Contains(norm, " "+phrase+" ") {
+				all = false
+				break
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// expressionCategory returns the most restrictive category among the
+// licenses of an SPDX expression, "" when none is known.
+func expressionCategory(expr string) string {
+	best, bestRank := "", -1
+	for _, id := range spdxIDs(expr) {
+		if c := LicenseCategory(id); c != "" && categoryRank[c] > bestRank {
+			best, bestRank = c, categoryRank[c]
+		}
+	}
+	return best
+}
+
+// spdxIDs lists the license identifiers of an SPDX expression, dropping
+// operators, exceptions and parentheses.
+func spdxIDs(expr string) []string {
+	var ids []string
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ").Replace(expr))
+	for i := 0; i < len(fields); i++ {
+		switch strings.ToUpper(fields[i]) {
+		case "AND", "OR":
+			continue
+		case "WITH":
+			i++ // skip the exception
+			continue
+		}
+		ids = append(ids, fields[i])
+	}
+	return ids
+}
+
+// dependencyDirs are directories whose contents are third-party code.
+var dependencyDirs = map[string]bool{
+	"node_modules":     true,
+	"bower_components": true,
+	"vendor":           true,
+	"third_party":      true,
+	"third-party":      true,
+	"thirdparty":       true,
+	"external":         true,
+	"extern":           true,
+	"Godeps":           true,
+	"Pods":             true,
+	"site-packages":    true,
+}
+
+// licenseFileName matches LICENSE, LICENCE, COPYING and UNLICENSE files
+// with an optional suffix (LICENSE.md, LICENSE-MIT, COPYING.LESSER).
+var licenseFileName = regexp.MustCompile(`(?i)^(licen[cs]e|copying|unlicense)([-._].*)?$`)
+
+// sourceExts rule out source files that merely share the name (license.go).
+var sourceExts = map[string]bool{
+	".go": true, ".js": true, ".mjs": true, ".cjs": true, ".ts": true, ".tsx": true, ".jsx": true,
+	".py": true, ".rs": true, ".java": true, ".kt": true, ".rb": true, ".php": true, ".cs": true,
+	".c": true, ".h": true, ".cpp": true, ".swift": true, ".json": true, ".yaml": true, ".yml": true,
+	".toml": true, ".xml": true, ".html": true, ".css": true, ".map": true, ".sh": true,
+}
+
+func isLicenseFile(name string) bool {
+	return licenseFileName.MatchString(name) && !sourceExts[strings.ToLower(filepath.Ext(name))]
+}
+
+// LicensesResult is the outcome of a licenses run.
+type LicensesResult struct {
+	Entries    []*Entry
+	Project    []string // SPDX expressions of the root license files
+	Dependency int      // license files in dependency directories
+	Unknown    int      // license files the corpus did not recognise
+}
+
+// RunLicenses walks rootDir — including dependency directories such as
+// node_modules and vendor, which other analyzers prune — and records one
+// KindLicense entry per license file.
+func RunLicenses(rootDir string) (*LicensesResult, error) {
+	result := &LicensesResult{}
+	err := filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := d.Name()
+		if d.IsDir() {
+			if p != rootDir && strings.HasPrefix(name, ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isLicenseFile(name) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() || info.Size() > maxLicenseFileSize {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(rootDir, p)
+		if err != nil {
+			return nil
+		}
+		e := licenseEntry(filepath.ToSlash(rel), string(data))
+		switch {
+		case e.Metadata["scope"] == LicenseScopeDependency:
+			result.Dependency++
+		case path.Dir(e.FilePath) == ".":
+			result.Project = append(result.Project, e.Name)
+		}
+		if e.Name == LicenseUnknown {
+			result.Unknown++
+		}
+		result.Entries = append(result.Entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(result.Project)
+	return result, nil
+}
+
+func licenseEntry(rel, text string) *Entry {
+	spdx, method, confidence := ClassifyLicense(text)
+	scope, pkg := licenseOwner(rel)
+	category := expressionCategory(spdx)
+	meta := map[string]string{
+		"spdx":       spdx,
+		"scope":      scope,
+		"confidence": fmt.Sprintf("%.2f", confidence),
+	}
+	if category != "" {
+		meta["category"] = category
+	}
+	if method != "" {
+		meta["method"] = method
+	}
+	if pkg != "" {
+		meta["package"] = pkg
+	}
+
+	owner := "project"
+	if pkg != "" {
+		owner = pkg
+	} else if dir := path.Dir(rel); dir != "." {
+		owner = dir
+	}
+	title := fmt.Sprintf("%s license: %s", spdx, owner)
+	if spdx == LicenseUnknown {
+		title = "Unrecognised license: " + owner
+	}
+	detail := fmt.Sprintf("%s file %s", strings.ToUpper(scope[:1])+scope[1:], rel)
+	switch method {
+	case "spdx-header":
+		detail += " declares " + spdx + " with an SPDX-License-Identifier line."
+	case "corpus":
+		detail += " matches the " + spdx + " license text."
+	default:
+		detail += " matches no license in the bundled corpus; review it by hand."
+	}
+	if category != "" {
+		detail += " Category: " + category + "."
+	}
+	return &Entry{
+		Analyzer: AnalyzerLicenses,
+		Kind:     KindLicense,
+		Name:     spdx,
+		FilePath: rel,
+		Title:    title,
+		Detail:   detail,
+		Metadata: meta,
+	}
+}
+
+// licenseOwner classifies a license file as the project's own or a
+// dependency's, naming the dependency: the path below the innermost
+// dependency directory (node_modules/@scope/pkg, vendor/github.com/x/y).
+func licenseOwner(rel string) (scope, pkg string) {
+	segs := strings.Split(path.Dir(rel), "/")
+	last := -1
+	for i, s := range segs {
+		if dependencyDirs[s] {
+			last = i
+		}
+	}
+	if last < 0 {
+		return LicenseScopeProject, ""
+	}
+	pkg = strings.Join(segs[last+1:], "/")
+	for _, suffix := range []string{".dist-info", ".egg-info"} {
+		pkg = strings.TrimSuffix(pkg, suffix)
+	}
+	return LicenseScopeDependency, pkg
+}
@@ -0,0 +1,185 @@
+package survey
+
+import (
+	"testing"
+)
+
+const (
+	mitText = `MIT License
+
+Copyright (c) 2024 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software.
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+`
+	apacheText = `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/
+
+   TERMS AND CONDITIONS FOR USE, REPRODUCTION, AND DISTRIBUTION
+`
+	gplText = `                    GNU GENERAL PUBLIC LICENSE
+                       Version 3, 29 June 2007
+
+ Copyright (C) 2007 Free Software Foundation, Inc. <https://fsf.org/>
+
+  The GNU General Public License is a free, copyleft license for
+software and other kinds of works.
+...
+But first, please read <https://www.gnu.org/licenses/why-not-lgpl.html>
+if you want to use the GNU Lesser General Public License instead.
+`
+	gpl2Text = `                    GNU GENERAL PUBLIC LICENSE
+                       Version 2, June 1991
+
+  The licenses for most software are designed to take away your
+freedom to share and change it.  By contrast, the GNU General Public
+License is intended to guarantee your freedom to share and change free
+software--to make sure the software is free for all its users.
+...
+library.  If this is what you want to do, use the GNU Lesser General
+Public License instead of this License.
+`
+	lgplText = `                  GNU LESSER GENERAL PUBLIC LICENSE
+                       Version 2.1, February 1999
+
+ This version of the GNU Lesser General Public License ... GNU General Public License
+`
+	bsd3Text = `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+* Redistributions of source code must retain the above copyright notice, this
+  list of conditions and the following disclaimer.
+* Redistributions in binary form must reproduce the above copyright notice.
+* Neither the name of the copyright holder nor the names of its
+  contributors may be used to endorse or promote products derived from
+  this software without specific prior written permission.
+`
+)
+
+func TestClassifyLicense(t *testing.T) {
+	tests := []struct {
+		name, text, spdx, method string
+	}{
+		{"mit", mitText, "MIT", "corpus"},
+		{"apache", apacheText, "Apache-2.0", "corpus"},
+		{"gpl3 mentioning lgpl", gplText, "GPL-3.0", "corpus"},
+		{"gpl2 mentioning lgpl", gpl2Text, "GPL-2.0", "corpus"},
+		{"lgpl not gpl", lgplText, "LGPL-2.1", "corpus"},
+		{"bsd3 not bsd2", bsd3Text, "BSD-3-Clause", "corpus"},
+		{"spdx header", "// SPDX-License-Identifier: MIT OR Apache-2.0\n", "MIT OR Apache-2.0", "spdx-header"},
+		{"markdown header", "`SPDX-License-Identifier: BSD-3-Clause AND MPL-2.0`\n", "BSD-3-Clause AND MPL-2.0", "spdx-header"},
+		{"dual file", mitText + "\n" + apacheText, "MIT AND Apache-2.0", "corpus"},
+		{"unknown", "All rights reserved.\n", LicenseUnknown, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spdx, method, _ := ClassifyLicense(tt.text)
+			if spdx != tt.spdx || method != tt.method {
+				t.Errorf("ClassifyLicense = %q/%q, want %q/%q", spdx, method, tt.spdx, tt.method)
+			}
+		})
+	}
+}
+
+func TestRunLicenses(t *testing.T) {
+	root := t.TempDir()
+	for rel, content := range map[string]string{
+		"LICENSE":                                   apacheText,
+		"license.go":                                "package main\n",
+		"docs/LICENSE-MIT":                          mitText,
+		"node_modules/left-pad/LICENSE":             mitText,
+		"node_modules/@acme/gpl-thing/COPYING":      gplText,
+		"node_modules/a/node_modules/b/LICENSE.txt": "All rights reserved.\n",
+		"vendor/github.com/x/y/LICENSE":             bsd3Text,
+		".git/LICENSE":                              mitText,
+	} {
+		writeTestFile(t, root, rel, content)
+	}
+
+	result, err := RunLicenses(root)
+	if err != nil {
+		t.Fatalf("RunLicenses: %v", err)
+	}
+	byFile := make(map[string]*Entry)
+	for _, e := range result.Entries {
+		if e.Kind != KindLicense || e.Analyzer != AnalyzerLicenses {
+			t.Errorf("entry %s has kind/analyzer %s/%s", e.FilePath, e.Kind, e.Analyzer)
+		}
+		byFile[e.FilePath] = e
+	}
+	if len(byFile) != 6 {
+		t.Fatalf("got %d license files, want 6: %v", len(byFile), byFile)
+	}
+	if len(result.Project) != 1 || result.Project[0] != "Apache-2.0" {
+		t.Errorf("Project = %v, want [Apache-2.0]", result.Project)
+	}
+	if result.Dependency != 4 || result.Unknown != 1 {
+		t.Errorf("Dependency/Unknown = %d/%d, want 4/1", result.Dependency, result.Unknown)
+	}
+
+	checks := []struct{ file, spdx, scope, pkg, category string }{
+		{"LICENSE", "Apache-2.0", LicenseScopeProject, "", LicensePermissive},
+		{"docs/LICENSE-MIT", "MIT", LicenseScopeProject, "", LicensePermissive},
+		{"node_modules/@acme/gpl-thing/COPYING", "GPL-3.0", LicenseScopeDependency, "@acme/gpl-thing", LicenseStrongCopyleft},
+		{"node_modules/a/node_modules/b/LICENSE.txt", LicenseUnknown, LicenseScopeDependency, "b", ""},
+		{"vendor/github.com/x/y/LICENSE", "BSD-3-Clause", LicenseScopeDependency, "github.com/x/y", LicensePermissive},
+	}
+	for _, c := range checks {
+		e := byFile[c.file]
+		if e == nil {
+			t.Errorf("%s: no entry", c.file)
+			continue
+		}
+		if e.Name != c.spdx || e.Metadata["scope"] != c.scope || e.Metadata["package"] != c.pkg || e.Metadata["category"] != c.category {
+			t.Errorf("%s: got %s scope=%s package=%s category=%s, want %s %s %s %s", c.file,
+				e.Name, e.Metadata["scope"], e.Metadata["package"], e.Metadata["category"], c.spdx, c.scope, c.pkg, c.category)
+		}
+	}
+}
+
+func TestLicensePolicyEvaluate(t *testing.T) {
+	apache := DefaultLicensePolicy([]string{"Apache-2.0"})
+	gpl := DefaultLicensePolicy([]string{"GPL-3.0"})
+	allow := LicensePolicy{Allow: []string{"permissive", "MPL-2.0"}, Deny: []string{"BSD-4*"}}
+
+	tests := []struct {
+		name   string
+		policy LicensePolicy
+		expr   string
+		want   string
+	}{
+		{"apache takes mit", apache, "MIT", LicenseAllowed},
+		{"apache takes mpl", apache, "MPL-2.0", LicenseAllowed},
+		{"apache refuses gpl", apache, "GPL-3.0-only", LicenseDenied},
+		{"apache refuses agpl", apache, "AGPL-3.0-or-later", LicenseDenied},
+		{"or picks permissive", apache, "(GPL-2.0 OR MIT)", LicenseAllowed},
+		{"and takes strictest", apache, "MIT AND GPL-2.0", LicenseDenied},
+		{"with exception ignored", apache, "GPL-2.0 WITH Classpath-exception-2.0", LicenseDenied},
+		{"gpl takes gpl", gpl, "GPL-2.0+", LicenseAllowed},
+		{"gpl refuses agpl", gpl, "AGPL-3.0", LicenseDenied},
+		{"unknown", apache, LicenseUnknown, LicenseUnknownUse},
+		{"allow list category", allow, "ISC", LicenseAllowed},
+		{"allow list id", allow, "MPL-2.0", LicenseAllowed},
+		{"outside allow list", allow, "LGPL-2.1", LicenseNotAllowed},
+		{"deny glob", allow, "BSD-4-Clause", LicenseDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := tt.policy.Evaluate(tt.expr)
+			if got != tt.want {
+				t.Errorf("Evaluate(%q) = %s (%s), want %s", tt.expr, got, reason, tt.want)
+			}
+		})
+	}
+	if len(gpl.Deny) != 1 || gpl.Deny[0] != LicenseNetworkCopyleft {
+		t.Errorf("GPL default policy = %v, want deny [network-copyleft]", gpl.Deny)
+	}
+}
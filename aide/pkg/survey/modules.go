@@ -49,7 +49,7 @@ type ModulesSource interface {
 
 // ModulesConfig configures a modules run.
 type ModulesConfig struct {
-	RootDir  string
+	RootDir  string // "" when clustering a commit's tree: set Resolver instead
 	Source   ModulesSource
 	Resolver *importresolve.Resolver // nil = constructed from RootDir
 	Previous map[string]int          // file -> community id from the prior run
@@ -390,20 +390,22 @@ func topDirs(members []string, limit int) []string {
 // community IDs are remapped to the previous assignment, the same ID means
 // the same community lineage across runs.
 type ModuleDiff struct {
-	New       []string    // labels of communities that did not exist before
-	Dissolved []string    // labels that disappeared
-	Renamed   [][2]string // {old label, new label} for the same community
-	Moved     int         // files present in both runs that changed community
+	New       []string    `json:"new,omitempty"`       // labels of communities that did not exist before
+	Dissolved []string    `json:"dissolved,omitempty"` // labels that disappeared
+	Renamed   [][2]string `json:"renamed,omitempty"`   // {old label, new label} for the same community
+	Moved     int         `json:"moved"`               // files present in both runs that changed community
+}
+
+// moduleSet is one community as a diff sees it.
+type moduleSet struct {
+	label   string
+	members map[string]bool
 }
 
 // DiffModules compares two runs' module entries by community lineage.
 func DiffModules(prevEntries, newEntries []*Entry) *ModuleDiff {
-	type meta struct {
-		label   string
-		members map[string]bool
-	}
-	parse := func(entries []*Entry) map[int]meta {
-		out := make(map[int]meta)
+	parse := func(entries []*Entry) map[int]moduleSet {
+		out := make(map[int]moduleSet)
 		for _, e := range entries {
 			if e.Analyzer != AnalyzerModules {
 				continue
@@ -414,16 +416,22 @@ func DiffModules(prevEntries, newEntries []*Entry) *ModuleDiff {
 			}
 			var members []string
 			_ = json.Unmarshal([]byte(e.Metadata["members"]), &members)
-			set := make(map[string]bool, len(members))
-			for _, m := range members {
-				set[m] = true
-			}
-			out[id] = meta{label: e.Name, members: set}
+			out[id] = newModuleSet(e.Name, members)
 		}
 		return out
 	}
-	prev, next := parse(prevEntries), parse(newEntries)
+	return diffModuleSets(parse(prevEntries), parse(newEntries))
+}
+
+func newModuleSet(label string, members []string) moduleSet {
+	set := make(map[string]bool, len(members))
+	for _, m := range members {
+		set[m] = true
+	}
+	return moduleSet{label: label, members: set}
+}
 
+func diffModuleSets(prev, next map[int]moduleSet) *ModuleDiff {
 	d := &ModuleDiff{}
 	ids := make([]int, 0, len(prev)+len(next))
 	seen := make(map[int]bool)
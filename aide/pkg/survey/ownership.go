@@ -0,0 +1,432 @@
+// Package survey: ownership.go maps who knows which code from git history —
+// primary authors, bus factor and recent owners per file and per module —
+// merged with CODEOWNERS when the repository has one.
+package survey
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Ownership defaults.
+const (
+	// DefaultOwnershipHalfLife is the age at which a change counts half.
+	DefaultOwnershipHalfLife = 180 * 24 * time.Hour
+	// DefaultRecentOwnerWindow is how far back from the newest commit an
+	// author's last change must be for them to count as a recent owner.
+	DefaultRecentOwnerWindow = 90 * 24 * time.Hour
+
+	maxOwnershipAuthors = 5    // authors listed per entry
+	maxRecentOwners     = 3    // recent owners listed per entry
+	maxOwnershipFiles   = 2000 // file entries kept, heaviest history first
+)
+
+// Ownership scopes: what an ownership entry covers.
+const (
+	OwnershipScopeFile   = "file"
+	OwnershipScopeModule = "module"
+)
+
+// codeOwnersPaths are the locations GitHub and GitLab read CODEOWNERS from.
+var codeOwnersPaths = []string{"CODEOWNERS", ".github/CODEOWNERS", ".gitlab/CODEOWNERS", "docs/CODEOWNERS"}
+
+// OwnershipConfig configures an ownership run.
+type OwnershipConfig struct {
+	RootDir    string
+	MaxCommits int      // commits to walk (0 = DefaultMaxCommits)
+	Modules    []*Entry // modules analyzer entries; files outside them group by directory
+}
+
+// OwnershipResult holds the output of the ownership analyzer.
+type OwnershipResult struct {
+	Entries      []*Entry
+	Files        int      // files with an ownership entry
+	Modules      int      // modules with an ownership entry
+	BusFactorOne []string // modules whose knowledge rests with one author
+	CodeOwners   string   // CODEOWNERS file merged in, "" when none
+}
+
+// AuthorShare is one author's share of a file's or module's weighted
+// history, as recorded in the "authors" metadata.
+type AuthorShare struct {
+	Name    string  `json:"name"`
+	Email   string  `json:"email,omitempty"`
+	Share   float64 `json:"share"`
+	Commits int     `json:"commits"`
+	Lines   int     `json:"lines"`
+	Last    string  `json:"last"`
+}
+
+// RunOwnership attributes the history of every file still in the worktree
+// to its authors and rolls the files up into modules. Changes are weighted
+// by lines and recency, so the primary owner is whoever has done the most
+// recent substantial work rather than whoever wrote the first draft.
+// Returns an empty result if the directory is not a git repo.
+func RunOwnership(cfg OwnershipConfig) (*OwnershipResult, error) {
+	gitRepo, err := OpenGitRepo(cfg.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("ownership analyzer: %w", err)
+	}
+	if gitRepo == nil {
+		return &OwnershipResult{}, nil
+	}
+	root, err := gitRepo.Root()
+	if err != nil {
+		return nil, fmt.Errorf("ownership analyzer: %w", err)
+	}
+
+	stats, newest, err := gitRepo.FileAuthorStats(cfg.MaxCommits, DefaultOwnershipHalfLife)
+	if err != nil {
+		log.Printf("survey: ownership analysis warning: %v", err)
+		return &OwnershipResult{}, nil
+	}
+
+	result := &OwnershipResult{}
+	rules, source := loadCodeOwners(root)
+	result.CodeOwners = source
+
+	membership := moduleMembership(cfg.Modules)
+	type moduleAcc struct {
+		authors map[string]*AuthorStat
+		members []string
+	}
+	modules := make(map[string]*moduleAcc)
+	type fileOwnership struct {
+		file    string
+		authors map[string]*AuthorStat
+		weight  float64
+	}
+	var files []fileOwnership
+
+	for file, authors := range stats {
+		if info, err := os.Stat(filepath.Join(root, filepath.FromSlash(file))); err != nil || !info.Mode().IsRegular() {
+			continue // deleted or renamed away
+		}
+		fo := fileOwnership{file: file, authors: authors}
+		for _, a := range authors {
+			fo.weight += a.Weight
+		}
+		files = append(files, fo)
+
+		label, ok := membership[file]
+		if !ok {
+			label = path.Dir(file)
+		}
+		m := modules[label]
+		if m == nil {
+			m = &moduleAcc{authors: make(map[string]*AuthorStat)}
+			modules[label] = m
+		}
+		m.members = append(m.members, file)
+		for key, a := range authors {
+			agg := m.authors[key]
+			if agg == nil {
+				agg = &AuthorStat{Name: a.Name, Email: a.Email}
+				m.authors[key] = agg
+			}
+			agg.hashes = append(agg.hashes, a.hashes...)
+			agg.LinesChanged += a.LinesChanged
+			agg.Weight += a.Weight
+			if a.Last.After(agg.Last) {
+				agg.Last = a.Last
+			}
+		}
+	}
+
+	labels := make([]string, 0, len(modules))
+	for label := range modules {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		m := modules[label]
+		sort.Strings(m.members)
+		for _, a := range m.authors {
+			a.Commits = distinctCommits(a.hashes)
+		}
+		dir := label
+		if _, ok := membership[m.members[0]]; ok {
+			dir = commonDirPrefix(m.members)
+		}
+		var owners []string
+		if len(rules) > 0 {
+			owners = moduleCodeOwners(rules, m.members)
+		}
+		e := ownershipEntry(OwnershipScopeModule, label, dir, m.authors, newest, owners)
+		e.Metadata["files"] = fmt.Sprintf("%d", len(m.members))
+		if e.Metadata["bus_factor"] == "1" {
+			e.Metadata["bus_factor_risk"] = "true"
+			result.BusFactorOne = append(result.BusFactorOne, label)
+		}
+		result.Entries = append(result.Entries, e)
+		result.Modules++
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].weight != files[j].weight {
+			return files[i].weight > files[j].weight
+		}
+		return files[i].file < files[j].file
+	})
+	if len(files) > maxOwnershipFiles {
+		files = files[:maxOwnershipFiles]
+	}
+	for _, fo := range files {
+		var owners []string
+		if len(rules) > 0 {
+			owners = matchCodeOwners(rules, fo.file)
+		}
+		result.Entries = append(result.Entries, ownershipEntry(OwnershipScopeFile, fo.file, fo.file, fo.authors, newest, owners))
+		result.Files++
+	}
+
+	return result, nil
+}
+
+// rankAuthors orders authors by weight and returns their shares, the bus
+// factor (fewest authors holding more than half the weight) and the recent
+// owners (authors active within DefaultRecentOwnerWindow of newest).
+func rankAuthors(authors map[string]*AuthorStat, newest time.Time) (shares []AuthorShare, busFactor int, recent []string) {
+	ranked := make([]*AuthorStat, 0, len(authors))
+	total := 0.0
+	for _, a := range authors {
+		ranked = append(ranked, a)
+		total += a.Weight
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Weight != ranked[j].Weight {
+			return ranked[i].Weight > ranked[j].Weight
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	cumulative := 0.0
+	for _, a := range ranked {
+		share := 0.0
+		if total > 0 {
+			share = a.Weight / total
+		}
+		if cumulative <= 0.5 {
+			busFactor++
+		}
+		cumulative += share
+		if len(shares) < maxOwnershipAuthors {
+			shares = append(shares, AuthorShare{
+				Name:    a.Name,
+				Email:   a.Email,
+				Share:   math.Round(share*100) / 100,
+				Commits: a.Commits,
+				Lines:   a.LinesChanged,
+				Last:    a.Last.UTC().Format("2006-01-02"),
+			})
+		}
+		if len(recent) < maxRecentOwners && newest.Sub(a.Last) <= DefaultRecentOwnerWindow {
+			recent = append(recent, a.Name)
+		}
+	}
+	return shares, busFactor, recent
+}
+
+func ownershipEntry(scope, name, filePath string, authors map[string]*AuthorStat, newest time.Time, codeOwners []string) *Entry {
+	shares, busFactor, recent := rankAuthors(authors, newest)
+	var (
+		hashes []plumbing.Hash
+		last   time.Time
+	)
+	for _, a := range authors {
+		hashes = append(hashes, a.hashes...)
+		if a.Last.After(last) {
+			last = a.Last
+		}
+	}
+	commits := distinctCommits(hashes)
+	owner := shares[0]
+	authorsJSON, _ := json.Marshal(shares)
+	meta := map[string]string{
+		"scope":        scope,
+		"owner":        owner.Name,
+		"owner_email":  owner.Email,
+		"owner_share":  fmt.Sprintf("%.2f", owner.Share),
+		"bus_factor":   fmt.Sprintf("%d", busFactor),
+		"authors":      string(authorsJSON),
+		"commits":      fmt.Sprintf("%d", commits),
+		"last_change":  last.UTC().Format("2006-01-02"),
+		"author_count": fmt.Sprintf("%d", len(authors)),
+	}
+	if len(recent) > 0 {
+		meta["recent_owners"] = strings.Join(recent, ", ")
+	}
+	if len(codeOwners) > 0 {
+		meta["codeowners"] = strings.Join(codeOwners, " ")
+	}
+
+	subject := name
+	if scope == OwnershipScopeModule {
+		subject = "Module " + name
+	}
+	title := fmt.Sprintf("%s: ask %s (%.0f%% of weighted changes), bus factor %d", subject, owner.Name, owner.Share*100, busFactor)
+	detail := fmt.Sprintf("%s by %s, last change %s.", plural(commits, "commit"), plural(len(authors), "author"), meta["last_change"])
+	if len(recent) > 0 {
+		detail += " Recently active: " + meta["recent_owners"] + "."
+	} else {
+		detail += " Nobody has changed it recently."
+	}
+	if len(codeOwners) > 0 {
+		detail += " CODEOWNERS: " + meta["codeowners"] + "."
+	}
+	return &Entry{
+		Analyzer: AnalyzerOwnership,
+		Kind:     KindOwnership,
+		Name:     name,
+		FilePath: filePath,
+		Title:    title,
+		Detail:   detail,
+		Metadata: meta,
+	}
+}
+
+// distinctCommits counts the distinct commits among hashes.
+func distinctCommits(hashes []plumbing.Hash) int {
+	seen := make(map[plumbing.Hash]bool, len(hashes))
+	for _, h := range hashes {
+		seen[h] = true
+	}
+	return len(seen)
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// codeOwnersRule is one CODEOWNERS line: a gitignore-style pattern and the
+// owners it assigns.
+type codeOwnersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// loadCodeOwners parses the first CODEOWNERS file found under root,
+// returning its rules and repo-relative path.
+func loadCodeOwners(root string) ([]codeOwnersRule, string) {
+	for _, rel := range codeOwnersPaths {
+		f, err := os.Open(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			continue
+		}
+		rules := parseCodeOwners(f)
+		_ = f.Close()
+		return rules, rel
+	}
+	return nil, ""
+}
+
+func parseCodeOwners(r io.Reader) []codeOwnersRule {
+	var rules []codeOwnersRule
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		// Skip comments and GitLab section headers ([Section], ^[Optional]).
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		fields := strings.Fields(line)
+		re, err := codeOwnersPattern(fields[0])
+		if err != nil {
+			continue
+		}
+		rules = append(rules, codeOwnersRule{pattern: re, owners: fields[1:]})
+	}
+	return rules
+}
+
+// codeOwnersPattern compiles a CODEOWNERS pattern with gitignore semantics:
+// a leading or inner slash anchors it at the root, a trailing slash matches
+// only directory contents, * and ? stay within a path segment and **
+// crosses segments.
+func codeOwnersPattern(p string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case p[i] == '*':
+			sb.WriteString("[^/]*")
+		case p[i] == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	if dirOnly {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(sb.String())
+}
+
+// matchCodeOwners returns the owners of file: the last matching rule wins,
+// as on GitHub. A matching rule with no owners unassigns the file.
+func matchCodeOwners(rules []codeOwnersRule, file string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(file) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+// moduleCodeOwners ranks the CODEOWNERS owners of a module's members by how
+// many members they own.
+func moduleCodeOwners(rules []codeOwnersRule, members []string) []string {
+	counts := make(map[string]int)
+	for _, f := range members {
+		for _, o := range matchCodeOwners(rules, f) {
+			counts[o]++
+		}
+	}
+	owners := make([]string, 0, len(counts))
+	for o := range counts {
+		owners = append(owners, o)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if counts[owners[i]] != counts[owners[j]] {
+			return counts[owners[i]] > counts[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+	return owners
+}
@@ -0,0 +1,201 @@
+package survey
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitAs stages all changes and commits them as the given author at when.
+func commitAs(t *testing.T, repo *git.Repository, name, email string, when time.Time) {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	if _, err := wt.Add("."); err != nil {
+		t.Fatalf("wt.Add: %v", err)
+	}
+	_, err = wt.Commit("change by "+name, &git.CommitOptions{
+		Author: &object.Signature{Name: name, Email: email, When: when},
+	})
+	if err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+}
+
+func TestRunOwnership(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Alice wrote store long ago; Bob has done the recent work on it.
+	writeTestFile(t, dir, "pkg/store/bolt.go", strings.Repeat("old line\n", 40))
+	writeTestFile(t, dir, "pkg/store/index.go", "package store\n")
+	commitAs(t, repo, "Alice", "alice@example.com", start)
+	writeTestFile(t, dir, "pkg/store/bolt.go", strings.Repeat("new line\n", 40))
+	commitAs(t, repo, "Bob", "Bob@Example.com", start.AddDate(1, 0, 0))
+	writeTestFile(t, dir, "pkg/store/index.go", "package store\n\nfunc Index() {}\n")
+	commitAs(t, repo, "Bob", "bob@example.com", start.AddDate(1, 0, 1))
+
+	// Only Carol has touched cli; a bot's change does not count.
+	writeTestFile(t, dir, "cmd/cli/main.go", "package main\n\nfunc main() {}\n")
+	commitAs(t, repo, "Carol", "carol@example.com", start.AddDate(1, 0, 2))
+	writeTestFile(t, dir, "cmd/cli/main.go", "package main\n\nfunc main() { run() }\n")
+	commitAs(t, repo, "dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", start.AddDate(1, 0, 3))
+
+	// A file that no longer exists gets no entry.
+	writeTestFile(t, dir, "pkg/store/gone.go", "package store\n")
+	commitAs(t, repo, "Alice", "alice@example.com", start.AddDate(1, 0, 4))
+	if _, err := mustWorktree(t, repo).Remove("pkg/store/gone.go"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	commitAs(t, repo, "Alice", "alice@example.com", start.AddDate(1, 0, 5))
+
+	writeTestFile(t, dir, ".github/CODEOWNERS", "# owners\n* @org/core\n/pkg/store/ @org/storage\ncmd/**/main.go @carol\n")
+	commitAs(t, repo, "Alice", "alice@example.com", start.AddDate(1, 0, 6))
+
+	modules := []*Entry{{
+		Analyzer: AnalyzerModules,
+		Kind:     KindModule,
+		Name:     "store",
+		Metadata: map[string]string{"members": `["pkg/store/bolt.go","pkg/store/index.go"]`},
+	}}
+	result, err := RunOwnership(OwnershipConfig{RootDir: dir, Modules: modules})
+	if err != nil {
+		t.Fatalf("RunOwnership: %v", err)
+	}
+	if result.CodeOwners != ".github/CODEOWNERS" {
+		t.Errorf("CodeOwners = %q", result.CodeOwners)
+	}
+
+	byKey := make(map[string]*Entry)
+	for _, e := range result.Entries {
+		if e.Kind != KindOwnership || e.Analyzer != AnalyzerOwnership {
+			t.Errorf("entry %s has kind/analyzer %s/%s", e.Name, e.Kind, e.Analyzer)
+		}
+		byKey[e.Metadata["scope"]+":"+e.Name] = e
+	}
+	if byKey["file:pkg/store/gone.go"] != nil {
+		t.Error("deleted file has an ownership entry")
+	}
+
+	store := byKey["module:store"]
+	if store == nil {
+		t.Fatalf("no store module entry; got %v", byKey)
+	}
+	if store.FilePath != "pkg/store" || store.Metadata["files"] != "2" || store.Metadata["commits"] != "3" {
+		t.Errorf("store path/files/commits = %s/%s/%s, want pkg/store/2/3", store.FilePath, store.Metadata["files"], store.Metadata["commits"])
+	}
+	if store.Metadata["owner"] != "Bob" || store.Metadata["bus_factor"] != "1" || store.Metadata["bus_factor_risk"] != "true" {
+		t.Errorf("store owner/bus factor = %s/%s, want Bob/1", store.Metadata["owner"], store.Metadata["bus_factor"])
+	}
+	if store.Metadata["recent_owners"] != "Bob" {
+		t.Errorf("store recent owners = %q, want Bob only", store.Metadata["recent_owners"])
+	}
+	if store.Metadata["codeowners"] != "@org/storage" {
+		t.Errorf("store codeowners = %q", store.Metadata["codeowners"])
+	}
+	var authors []AuthorShare
+	if err := json.Unmarshal([]byte(store.Metadata["authors"]), &authors); err != nil || len(authors) != 2 {
+		t.Fatalf("store authors = %s (%v)", store.Metadata["authors"], err)
+	}
+	if authors[1].Name != "Alice" || authors[1].Share <= 0 || authors[1].Share >= 0.5 {
+		t.Errorf("second author = %+v, want Alice with a minority share", authors[1])
+	}
+
+	cli := byKey["module:cmd/cli"]
+	if cli == nil {
+		t.Fatal("no directory module for cmd/cli")
+	}
+	if cli.Metadata["owner"] != "Carol" || cli.Metadata["author_count"] != "1" || cli.Metadata["codeowners"] != "@carol" {
+		t.Errorf("cli owner/authors/codeowners = %s/%s/%s", cli.Metadata["owner"], cli.Metadata["author_count"], cli.Metadata["codeowners"])
+	}
+
+	readme := byKey["file:README.md"]
+	if readme == nil || readme.Metadata["owner"] != "Test Author" || readme.Metadata["codeowners"] != "@org/core" {
+		t.Errorf("README ownership = %+v", readme)
+	}
+	if readme != nil && readme.Metadata["recent_owners"] != "" {
+		t.Errorf("README recent owners = %q, want none", readme.Metadata["recent_owners"])
+	}
+}
+
+func TestRunOwnership_NotGitRepo(t *testing.T) {
+	result, err := RunOwnership(OwnershipConfig{RootDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("RunOwnership: %v", err)
+	}
+	if len(result.Entries) != 0 {
+		t.Errorf("got %d entries outside a git repo", len(result.Entries))
+	}
+}
+
+func TestRankAuthors_BusFactor(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		weights []float64
+		want    int
+	}{
+		{"single author", []float64{10}, 1},
+		{"majority owner", []float64{60, 30, 10}, 1},
+		{"even split", []float64{50, 50}, 2},
+		{"spread", []float64{30, 30, 20, 20}, 2},
+		{"long tail", []float64{20, 20, 20, 20, 20}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authors := make(map[string]*AuthorStat)
+			for i, w := range tt.weights {
+				name := string(rune('a' + i))
+				authors[name] = &AuthorStat{Name: name, Weight: w, Last: now}
+			}
+			_, got, _ := rankAuthors(authors, now)
+			if got != tt.want {
+				t.Errorf("bus factor = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeOwnersPattern(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"*", "a/b/c.go", true},
+		{"*.go", "pkg/x/main.go", true},
+		{"*.go", "main.ts", false},
+		{"/docs/", "docs/a.md", true},
+		{"/docs/", "pkg/docs/a.md", false},
+		{"docs/", "docs/a.md", true},
+		{"apps", "x/apps/y.ts", true},
+		{"/build/logs", "build/logs/today.log", true},
+		{"pkg/*.go", "pkg/a.go", true},
+		{"pkg/*.go", "pkg/sub/a.go", false},
+		{"**/logs", "deep/er/logs/x", true},
+		{"pkg/**/test.go", "pkg/a/b/test.go", true},
+	}
+	for _, tt := range tests {
+		re, err := codeOwnersPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("codeOwnersPattern(%q): %v", tt.pattern, err)
+		}
+		if got := re.MatchString(tt.file); got != tt.want {
+			t.Errorf("%q matches %q = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func mustWorktree(t *testing.T, repo *git.Repository) *git.Worktree {
+	t.Helper()
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	return wt
+}
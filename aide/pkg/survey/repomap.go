@@ -0,0 +1,521 @@
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+// DefaultRepoMapTokens is the repo map budget when none is given.
+const DefaultRepoMapTokens = 1024
+
+// repoMapTopRefs is how many of the most-referenced symbol names seed the
+// PageRank personalization.
+const repoMapTopRefs = 100
+
+// repoMapMaxCandidates skips unresolved references whose name more than
+// this many symbols define: a call to Close or String says nothing about
+// which Close matters.
+const repoMapMaxCandidates = 10
+
+// repoMapMaxSignature caps the characters of a rendered signature.
+const repoMapMaxSignature = 120
+
+// PageRank parameters.
+const (
+	pageRankDamping    = 0.85
+	pageRankIterations = 50
+	pageRankTolerance  = 1e-6
+)
+
+// repoMapKinds are the symbol kinds a repo map lists. Variables and
+// constants still take part in the ranking, as sources of references.
+var repoMapKinds = map[string]bool{
+	code.KindFunction:  true,
+	code.KindMethod:    true,
+	code.KindClass:     true,
+	code.KindInterface: true,
+	code.KindType:      true,
+}
+
+// RepoMapSource is the part of the code index a repo map is built from.
+type RepoMapSource interface {
+	ListAllSymbols(limit int) ([]*code.Symbol, error)
+	ListAllReferences(limit int) ([]*code.Reference, error)
+	TopReferencedSymbols(limit int, kind string) ([]*code.SymbolRefCount, error)
+}
+
+// RepoMapOptions configures BuildRepoMap.
+type RepoMapOptions struct {
+	// Tokens is the budget for the rendered map (0 = DefaultRepoMapTokens).
+	Tokens int
+	// Focus lists project-relative files the map should centre on, such as
+	// those being edited: their symbols, and what they reference, rank
+	// higher.
+	Focus []string
+}
+
+// RepoMapSymbol is one symbol line of a repo map.
+type RepoMapSymbol struct {
+	Name      string  `json:"name"`
+	Kind      string  `json:"kind"`
+	Line      int     `json:"line"`
+	Signature string  `json:"signature"`
+	Rank      float64 `json:"rank"`
+}
+
+// RepoMapFile is one file of a repo map with its listed symbols, in line
+// order.
+type RepoMapFile struct {
+	Path       string           `json:"file"`
+	Module     string           `json:"module,omitempty"`     // Module cluster label, when the modules analyzer has run
+	Entrypoint string           `json:"entrypoint,omitempty"` // Entry point names in the file
+	Symbols    []*RepoMapSymbol `json:"symbols"`
+}
+
+// RepoMap is a ranked outline of the most important symbols, trimmed to a
+// token budget.
+type RepoMap struct {
+	Budget       int            `json:"budget"`
+	Tokens       int            `json:"tokens"` // Estimated tokens of Render()
+	Symbols      int            `json:"symbols"`
+	TotalSymbols int            `json:"totalSymbols"` // Listable symbols in the index
+	Files        []*RepoMapFile `json:"files"`        // Grouped by module, most important first
+
+	clustered bool // module entries were given, so files outside them get a heading too
+}
+
+// BuildRepoMap ranks the indexed symbols by PageRank over the reference
+// graph and keeps the best that fit opts.Tokens when rendered. Each
+// reference is an edge from the symbol containing it to the definition it
+// resolved to, or, unresolved, to the nearest same-named definitions in its
+// language. The random walk restarts preferentially at the most-referenced
+// names, at entry points among entries and at opts.Focus files. Module
+// entries among entries group the files.
+func BuildRepoMap(src RepoMapSource, entries []*Entry, opts RepoMapOptions) (*RepoMap, error) {
+	if opts.Tokens <= 0 {
+		opts.Tokens = DefaultRepoMapTokens
+	}
+	syms, err := src.ListAllSymbols(-1)
+	if err != nil {
+		return nil, fmt.Errorf("listing symbols: %w", err)
+	}
+	refs, err := src.ListAllReferences(-1)
+	if err != nil {
+		return nil, fmt.Errorf("listing references: %w", err)
+	}
+	top, err := src.TopReferencedSymbols(repoMapTopRefs, "")
+	if err != nil {
+		return nil, fmt.Errorf("ranking referenced symbols: %w", err)
+	}
+
+	g := newRepoGraph(syms)
+	g.addReferences(refs)
+	rank := g.pageRank(g.personalization(top, entries, opts.Focus))
+
+	modules := moduleMembership(entries)
+	entrypoints := make(map[string][]string)
+	for _, e := range entries {
+		if e.Kind == KindEntrypoint && e.FilePath != "" {
+			entrypoints[e.FilePath] = append(entrypoints[e.FilePath], e.Name)
+		}
+	}
+
+	var ranked []int
+	for i, s := range g.syms {
+		if repoMapKinds[s.Kind] {
+			ranked = append(ranked, i)
+		}
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		ra, rb := rank[ranked[a]], rank[ranked[b]]
+		if ra != rb {
+			return ra > rb
+		}
+		sa, sb := g.syms[ranked[a]], g.syms[ranked[b]]
+		if sa.FilePath != sb.FilePath {
+			return sa.FilePath < sb.FilePath
+		}
+		return sa.StartLine < sb.StartLine
+	})
+	// List a declaration captured under two kinds (a Go struct is both a
+	// type and a class) once, as the node that carries its rank.
+	seen := make(map[string]bool, len(ranked))
+	deduped := ranked[:0]
+	for _, i := range ranked {
+		s := g.syms[i]
+		key := s.FilePath + ":" + strconv.Itoa(s.StartLine) + ":" + s.Name
+		if !seen[key] {
+			seen[key] = true
+			deduped = append(deduped, i)
+		}
+	}
+	ranked = deduped
+
+	m := &RepoMap{Budget: opts.Tokens, TotalSymbols: len(ranked), clustered: len(modules) > 0}
+	m.Tokens = code.EstimateTokensForText(repoMapHeader(len(ranked), len(ranked), len(g.files), opts.Tokens))
+	files := make(map[string]*RepoMapFile)
+	groups := make(map[string]bool)
+	for _, i := range ranked {
+		s := g.syms[i]
+		ms := &RepoMapSymbol{
+			Name:      s.DisplayName(),
+			Kind:      s.Kind,
+			Line:      s.StartLine,
+			Signature: mapSignature(s),
+			Rank:      rank[i],
+		}
+		cost := code.EstimateTokens(s.FilePath, len(ms.line())+1)
+		f := files[s.FilePath]
+		if f == nil {
+			f = &RepoMapFile{Path: s.FilePath, Module: modules[s.FilePath], Entrypoint: strings.Join(entrypoints[s.FilePath], ", ")}
+			cost += code.EstimateTokensForText(f.line())
+			if heading := m.heading(f); heading != "" && !groups[heading] {
+				cost += code.EstimateTokensForText(heading)
+			}
+		}
+		if m.Tokens+cost > opts.Tokens {
+			continue
+		}
+		m.Tokens += cost
+		if files[s.FilePath] == nil {
+			files[s.FilePath] = f
+			m.Files = append(m.Files, f)
+			groups[m.heading(f)] = true
+		}
+		f.Symbols = append(f.Symbols, ms)
+		m.Symbols++
+	}
+
+	// Files arrive in order of their best symbol; keep that order, but
+	// gather each module's files under its best-ranked file, with files
+	// outside any module last.
+	groupOrder := make(map[string]int)
+	for _, f := range m.Files {
+		if _, ok := groupOrder[f.Module]; !ok && f.Module != "" {
+			groupOrder[f.Module] = len(groupOrder)
+		}
+	}
+	sort.SliceStable(m.Files, func(a, b int) bool {
+		ga, oka := groupOrder[m.Files[a].Module]
+		gb, okb := groupOrder[m.Files[b].Module]
+		if oka != okb {
+			return oka
+		}
+		return ga < gb
+	})
+	for _, f := range m.Files {
+		sort.Slice(f.Symbols, func(a, b int) bool { return f.Symbols[a].Line < f.Symbols[b].Line })
+	}
+	return m, nil
+}
+
+// Render formats the map as text: module headings, then each file with its
+// symbols' lines and signatures.
+func (m *RepoMap) Render() string {
+	var sb strings.Builder
+	sb.WriteString(repoMapHeader(m.Symbols, m.TotalSymbols, len(m.Files), m.Tokens))
+	heading := ""
+	for _, f := range m.Files {
+		if h := m.heading(f); h != heading {
+			sb.WriteString(h)
+			heading = h
+		}
+		sb.WriteString(f.line())
+		for _, s := range f.Symbols {
+			sb.WriteString(s.line())
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
+
+// repoMapHeader is the map's first line. BuildRepoMap charges it against
+// the budget before the counts are known, using totals at least as long.
+func repoMapHeader(symbols, total, files, tokens int) string {
+	return fmt.Sprintf("# Repo map: %d of %d symbols in %d files (~%d tokens)\n", symbols, total, files, tokens)
+}
+
+// heading is the module heading f is listed under, or "" when no modules
+// are known.
+func (m *RepoMap) heading(f *RepoMapFile) string {
+	switch {
+	case f.Module != "":
+		return "\n## " + f.Module + "\n"
+	case m.clustered:
+		return "\n## (no module)\n"
+	}
+	return ""
+}
+
+func (f *RepoMapFile) line() string {
+	if f.Entrypoint != "" {
+		return f.Path + " (entry: " + f.Entrypoint + ")\n"
+	}
+	return f.Path + "\n"
+}
+
+func (s *RepoMapSymbol) line() string {
+	return "  " + strconv.Itoa(s.Line) + ": " + s.Signature
+}
+
+// mapSignature is the first line of a symbol's signature, whitespace
+// collapsed and capped, or its kind and name when it has none.
+func mapSignature(s *code.Symbol) string {
+	sig, _, _ := strings.Cut(s.Signature, "\n")
+	sig = strings.Join(strings.Fields(sig), " ")
+	if sig == "" {
+		return s.Kind + " " + s.DisplayName()
+	}
+	if r := []rune(sig); len(r) > repoMapMaxSignature {
+		sig = string(r[:repoMapMaxSignature]) + "…"
+	}
+	return sig
+}
+
+// moduleMembership maps each file to the label of its module entry.
+func moduleMembership(entries []*Entry) map[string]string {
+	out := make(map[string]string)
+	for _, e := range entries {
+		if e.Kind != KindModule || e.Analyzer != AnalyzerModules {
+			continue
+		}
+		var members []string
+		if json.Unmarshal([]byte(e.Metadata["members"]), &members) != nil {
+			continue
+		}
+		for _, f := range members {
+			out[f] = e.Name
+		}
+	}
+	return out
+}
+
+// repoGraph is the directed, weighted reference graph over symbols. Each
+// file gets an extra node that stands for references outside any symbol
+// (top-level code); those nodes are ranked but never listed.
+type repoGraph struct {
+	syms   []*code.Symbol
+	files  []string
+	byID   map[string]int
+	byName map[string][]int
+	byFile map[string][]int
+	fileOf map[string]int // file -> its top-level node
+	out    []map[int]float64
+}
+
+func newRepoGraph(syms []*code.Symbol) *repoGraph {
+	g := &repoGraph{
+		syms:   syms,
+		byID:   make(map[string]int, len(syms)),
+		byName: make(map[string][]int),
+		byFile: make(map[string][]int),
+		fileOf: make(map[string]int),
+	}
+	// A declaration captured under two kinds is one node: references to
+	// either land on the first, so the two do not split its rank.
+	decl := make(map[string]int, len(syms))
+	for i, s := range syms {
+		key := s.FilePath + ":" + strconv.Itoa(s.StartLine) + ":" + s.Name
+		if first, ok := decl[key]; ok {
+			g.byID[s.ID] = first
+			continue
+		}
+		decl[key] = i
+		g.byID[s.ID] = i
+		g.byName[s.Name] = append(g.byName[s.Name], i)
+		if _, ok := g.byFile[s.FilePath]; !ok {
+			g.files = append(g.files, s.FilePath)
+		}
+		g.byFile[s.FilePath] = append(g.byFile[s.FilePath], i)
+	}
+	sort.Strings(g.files)
+	for _, f := range g.files {
+		g.fileOf[f] = len(syms) + len(g.fileOf)
+	}
+	g.out = make([]map[int]float64, len(syms)+len(g.files))
+	return g
+}
+
+// source is the node a reference at line of file comes from: the narrowest
+// symbol containing it, or the file's top-level node.
+func (g *repoGraph) source(file string, line int) (int, bool) {
+	best, span := -1, math.MaxInt
+	for _, i := range g.byFile[file] {
+		s := g.syms[i]
+		if s.StartLine <= line && line <= s.EndLine && s.EndLine-s.StartLine < span {
+			best, span = i, s.EndLine-s.StartLine
+		}
+	}
+	if best >= 0 {
+		return best, true
+	}
+	n, ok := g.fileOf[file]
+	return n, ok
+}
+
+func (g *repoGraph) addReferences(refs []*code.Reference) {
+	for _, r := range refs {
+		if r.Kind == code.RefKindImport {
+			continue
+		}
+		from, ok := g.source(r.FilePath, r.Line)
+		if !ok {
+			continue
+		}
+		if to, ok := g.byID[r.TargetSymbolID]; ok {
+			g.addEdge(from, to, 1)
+			continue
+		}
+		targets := g.candidates(r)
+		if len(targets) == 0 || len(targets) > repoMapMaxCandidates {
+			continue
+		}
+		for _, to := range targets {
+			g.addEdge(from, to, 1/float64(len(targets)))
+		}
+	}
+}
+
+// candidates are the definitions an unresolved reference may name: of
+// those in its language (or, with none, of all of that name), the ones in
+// the reference's file, else in its directory, else all of them.
+func (g *repoGraph) candidates(r *code.Reference) []int {
+	all := g.byName[r.SymbolName]
+	var lang []int
+	for _, i := range all {
+		if g.syms[i].Language == r.Language {
+			lang = append(lang, i)
+		}
+	}
+	if len(lang) == 0 {
+		lang = all
+	}
+	var sameFile, sameDir []int
+	dir := path.Dir(r.FilePath)
+	for _, i := range lang {
+		switch f := g.syms[i].FilePath; {
+		case f == r.FilePath:
+			sameFile = append(sameFile, i)
+		case path.Dir(f) == dir:
+			sameDir = append(sameDir, i)
+		}
+	}
+	switch {
+	case len(sameFile) > 0:
+		return sameFile
+	case len(sameDir) > 0:
+		return sameDir
+	}
+	return lang
+}
+
+func (g *repoGraph) addEdge(from, to int, w float64) {
+	if from == to {
+		return
+	}
+	if g.out[from] == nil {
+		g.out[from] = make(map[int]float64)
+	}
+	g.out[from][to] += w
+}
+
+// personalization is the restart distribution of the random walk: uniform,
+// plus weight on the most-referenced names, entry points and focus files.
+func (g *repoGraph) personalization(top []*code.SymbolRefCount, entries []*Entry, focus []string) []float64 {
+	p := make([]float64, len(g.out))
+	for i := range p {
+		p[i] = 1
+	}
+	for _, t := range top {
+		for _, i := range g.byName[t.Symbol] {
+			if t.File == "" || g.syms[i].FilePath == t.File {
+				p[i] += math.Log1p(float64(t.Count))
+			}
+		}
+	}
+	for _, e := range entries {
+		if e.Kind != KindEntrypoint || e.FilePath == "" {
+			continue
+		}
+		line, _ := strconv.Atoi(e.Metadata["line"])
+		if i, ok := g.source(e.FilePath, line); ok && line > 0 {
+			p[i] += 10
+			continue
+		}
+		for _, i := range g.byFile[e.FilePath] {
+			p[i] += 2
+		}
+	}
+	for _, f := range focus {
+		for _, i := range g.byFile[f] {
+			p[i] += 20
+		}
+		if n, ok := g.fileOf[f]; ok {
+			p[n] += 20
+		}
+	}
+	normalize(p)
+	return p
+}
+
+// pageRank runs personalized PageRank: dangling nodes restart along p.
+func (g *repoGraph) pageRank(p []float64) []float64 {
+	n := len(p)
+	outW := make([]float64, n)
+	for i, edges := range g.out {
+		for _, w := range edges {
+			outW[i] += w
+		}
+	}
+	rank := append([]float64(nil), p...)
+	next := make([]float64, n)
+	for range pageRankIterations {
+		dangling := 0.0
+		for i := range rank {
+			if outW[i] == 0 {
+				dangling += rank[i]
+			}
+		}
+		for i := range next {
+			next[i] = (1 - pageRankDamping + pageRankDamping*dangling) * p[i]
+		}
+		for i, edges := range g.out {
+			if outW[i] == 0 {
+				continue
+			}
+			share := pageRankDamping * rank[i] / outW[i]
+			for j, w := range edges {
+				next[j] += share * w
+			}
+		}
+		delta := 0.0
+		for i := range rank {
+			delta += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if delta < pageRankTolerance {
+			break
+		}
+	}
+	return rank
+}
+
+func normalize(v []float64) {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	if sum == 0 {
+		return
+	}
+	for i := range v {
+		v[i] /= sum
+	}
+}
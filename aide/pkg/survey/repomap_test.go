@@ -0,0 +1,149 @@
+package survey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmylchreest/aide/aide/pkg/code"
+)
+
+// mockRepoMapSource implements RepoMapSource for testing.
+type mockRepoMapSource struct {
+	symbols []*code.Symbol
+	refs    []*code.Reference
+	top     []*code.SymbolRefCount
+}
+
+func (m *mockRepoMapSource) ListAllSymbols(int) ([]*code.Symbol, error) { return m.symbols, nil }
+func (m *mockRepoMapSource) ListAllReferences(int) ([]*code.Reference, error) {
+	return m.refs, nil
+}
+func (m *mockRepoMapSource) TopReferencedSymbols(int, string) ([]*code.SymbolRefCount, error) {
+	return m.top, nil
+}
+
+// repoMapFixture is a small project: main calls Run, which calls Open and
+// Query on the store; util.go's Pad is never referenced.
+func repoMapFixture() *mockRepoMapSource {
+	sym := func(id, name, kind, file string, start, end int, sig string) *code.Symbol {
+		return &code.Symbol{ID: id, Name: name, Kind: kind, FilePath: file, StartLine: start, EndLine: end, Signature: sig, Language: "go"}
+	}
+	ref := func(name, file string, line int) *code.Reference {
+		return &code.Reference{SymbolName: name, Kind: code.RefKindCall, FilePath: file, Line: line, Language: "go"}
+	}
+	src := &mockRepoMapSource{
+		symbols: []*code.Symbol{
+			sym("main", "main", code.KindFunction, "cmd/app/main.go", 5, 9, "func main()"),
+			sym("run", "Run", code.KindFunction, "app/run.go", 3, 20, "func Run(args []string) error"),
+			sym("store", "Store", code.KindClass, "store/store.go", 1, 4, "type Store struct"),
+			sym("store-type", "Store", code.KindType, "store/store.go", 1, 4, "type Store struct"),
+			sym("open", "Open", code.KindFunction, "store/store.go", 6, 12, "func Open(path string) (*Store, error)"),
+			sym("query", "Query", code.KindMethod, "store/store.go", 14, 30, "func (s *Store) Query(q string) ([]Row, error)"),
+			sym("pad", "Pad", code.KindFunction, "util/util.go", 1, 5, "func Pad(s string, n int) string"),
+			sym("limit", "limit", code.KindConstant, "store/store.go", 32, 32, "const limit = 10"),
+		},
+		refs: []*code.Reference{
+			ref("Run", "cmd/app/main.go", 7),
+			ref("Open", "app/run.go", 5),
+			ref("Query", "app/run.go", 8),
+			ref("Query", "app/run.go", 9),
+			{SymbolName: "Store", Kind: code.RefKindTypeRef, FilePath: "store/store.go", Line: 6, Language: "go"},
+			{SymbolName: "Store", Kind: code.RefKindTypeRef, FilePath: "store/store.go", Line: 14, Language: "go"},
+		},
+		top: []*code.SymbolRefCount{
+			{Symbol: "Query", Count: 2, Kind: code.KindMethod, File: "store/store.go"},
+			{Symbol: "Store", Count: 2, Kind: code.KindClass, File: "store/store.go"},
+		},
+	}
+	return src
+}
+
+func TestBuildRepoMapRanksReferencedSymbols(t *testing.T) {
+	entries := []*Entry{
+		{Analyzer: AnalyzerEntrypoints, Kind: KindEntrypoint, Name: "main", FilePath: "cmd/app/main.go", Metadata: map[string]string{"line": "5"}},
+		{Analyzer: AnalyzerModules, Kind: KindModule, Name: "store", Metadata: map[string]string{"members": `["store/store.go"]`}},
+		{Analyzer: AnalyzerModules, Kind: KindModule, Name: "app", Metadata: map[string]string{"members": `["app/run.go","cmd/app/main.go"]`}},
+	}
+	m, err := BuildRepoMap(repoMapFixture(), entries, RepoMapOptions{Tokens: 4096})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.TotalSymbols != 6 || m.Symbols != 6 {
+		t.Fatalf("symbols = %d of %d, want 6 of 6 (constants are not listed)", m.Symbols, m.TotalSymbols)
+	}
+
+	rank := make(map[string]float64)
+	for _, f := range m.Files {
+		for _, s := range f.Symbols {
+			rank[s.Name] = s.Rank
+		}
+	}
+	if rank["Query"] <= rank["Pad"] || rank["Store"] <= rank["Pad"] || rank["Open"] <= rank["Pad"] {
+		t.Errorf("referenced symbols should outrank unreferenced Pad: %v", rank)
+	}
+	if rank["main"] <= rank["Pad"] {
+		t.Errorf("entry point main should outrank Pad: %v", rank)
+	}
+
+	// The store module holds the best-ranked symbols, so it leads; the
+	// unclustered util.go comes last.
+	if got := m.Files[0].Module; got != "store" {
+		t.Errorf("first module = %q, want store", got)
+	}
+	if last := m.Files[len(m.Files)-1]; last.Path != "util/util.go" || last.Module != "" {
+		t.Errorf("last file = %+v, want unclustered util/util.go", last)
+	}
+
+	out := m.Render()
+	for _, want := range []string{
+		"# Repo map: 6 of 6 symbols in 4 files",
+		"\n## store\nstore/store.go\n  1: type Store struct\n  6: func Open(path string) (*Store, error)\n",
+		"cmd/app/main.go (entry: main)\n  5: func main()\n",
+		"\n## (no module)\nutil/util.go\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+	if est := code.EstimateTokensForText(out); est > m.Budget {
+		t.Errorf("rendered map ~%d tokens, over budget %d", est, m.Budget)
+	}
+}
+
+func TestBuildRepoMapBudget(t *testing.T) {
+	full, err := BuildRepoMap(repoMapFixture(), nil, RepoMapOptions{Tokens: 4096})
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := full.Tokens / 2
+	m, err := BuildRepoMap(repoMapFixture(), nil, RepoMapOptions{Tokens: budget})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Tokens > budget {
+		t.Errorf("tokens = %d, over budget %d", m.Tokens, budget)
+	}
+	if m.Symbols == 0 || m.Symbols >= full.Symbols {
+		t.Errorf("symbols = %d, want some but fewer than %d", m.Symbols, full.Symbols)
+	}
+	for _, f := range m.Files {
+		for _, s := range f.Symbols {
+			if s.Name == "Pad" {
+				t.Error("unreferenced Pad kept over better-ranked symbols")
+			}
+		}
+	}
+	if strings.Contains(m.Render(), "## ") {
+		t.Error("module headings rendered without module entries")
+	}
+}
+
+func TestBuildRepoMapFocus(t *testing.T) {
+	m, err := BuildRepoMap(repoMapFixture(), nil, RepoMapOptions{Tokens: 4096, Focus: []string{"util/util.go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Files[0].Path != "util/util.go" {
+		t.Errorf("first file = %s, want the focused util/util.go", m.Files[0].Path)
+	}
+}
@@ -0,0 +1,370 @@
+// Package survey: snapshot.go records the survey's shape at each commit so
+// runs can be compared across history, not just against the previous run.
+// A snapshot is a compact summary — module membership, entrypoints, tech
+// stack, dependency counts and the busiest files — stored as one KindSnapshot entry
+// per commit under AnalyzerHistory, so it travels through every storage path
+// (BoltDB, gRPC daemon, web dashboard) like any other entry.
+package survey
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Snapshot defaults.
+const (
+	// MaxSnapshots is how many per-commit snapshots are kept; the oldest
+	// are pruned first.
+	MaxSnapshots = 100
+
+	// snapshotChurnTop is how many churned files a snapshot records.
+	snapshotChurnTop = 10
+
+	// driftThreshold is the relative change in average module size that
+	// counts as consolidating or fragmenting rather than stable.
+	driftThreshold = 0.1
+)
+
+// Drift verdicts returned by Drift.
+const (
+	DriftConsolidating = "consolidating"
+	DriftFragmenting   = "fragmenting"
+	DriftStable        = "stable"
+)
+
+// SnapshotModule is one module community at snapshot time.
+type SnapshotModule struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// Snapshot is the survey's shape at one commit.
+type Snapshot struct {
+	Commit       string           `json:"commit"`
+	CommittedAt  time.Time        `json:"committed_at"`
+	TakenAt      time.Time        `json:"taken_at"`
+	Modules      []SnapshotModule `json:"modules,omitempty"`
+	Entrypoints  []string         `json:"entrypoints,omitempty"` // entrypoint entry names, sorted
+	TechStack    []string         `json:"tech_stack,omitempty"`  // topology tech stack names, sorted
+	Dependencies int              `json:"dependencies"`          // distinct external dependency names
+	Direct       int              `json:"direct"`                // of which declared directly
+	Churn        []string         `json:"churn,omitempty"`       // busiest files, busiest first
+	// Backfilled marks a snapshot surveyed from the commit's tree after the
+	// fact ('aide survey run --at'): it records topology, entrypoints and
+	// modules only, so its dependencies and churn are unknown, not zero.
+	Backfilled bool `json:"backfilled,omitempty"`
+}
+
+// Files counts the files assigned to a module.
+func (s *Snapshot) Files() int {
+	n := 0
+	for _, m := range s.Modules {
+		n += len(m.Members)
+	}
+	return n
+}
+
+// BuildSnapshot summarises the current modules, entrypoints, tech stack,
+// dependencies and churn entries. Entries of other analyzers are ignored.
+func BuildSnapshot(commit string, committedAt, takenAt time.Time, entries []*Entry) *Snapshot {
+	s := &Snapshot{Commit: commit, CommittedAt: committedAt.UTC(), TakenAt: takenAt.UTC()}
+	tech := make(map[string]bool)
+	deps := make(map[string]bool)
+	direct := make(map[string]bool)
+	type churned struct {
+		path   string
+		recent float64
+	}
+	var churn []churned
+	for _, e := range entries {
+		switch {
+		case e.Analyzer == AnalyzerModules && e.Kind == KindModule:
+			id, err := strconv.Atoi(e.Metadata["community_id"])
+			if err != nil {
+				continue
+			}
+			var members []string
+			_ = json.Unmarshal([]byte(e.Metadata["members"]), &members)
+			sort.Strings(members)
+			s.Modules = append(s.Modules, SnapshotModule{ID: id, Name: e.Name, Members: members})
+		case e.Analyzer == AnalyzerEntrypoints && e.Kind == KindEntrypoint:
+			s.Entrypoints = append(s.Entrypoints, e.Name)
+		case e.Analyzer == AnalyzerTopology && e.Kind == KindTechStack:
+			if !tech[e.Name] {
+				tech[e.Name] = true
+				s.TechStack = append(s.TechStack, e.Name)
+			}
+		case e.Analyzer == AnalyzerDependencies && e.Kind == KindDependency:
+			key := e.Metadata["ecosystem"] + ":" + e.Name
+			deps[key] = true
+			if e.Metadata["direct"] == "true" {
+				direct[key] = true
+			}
+		case e.Analyzer == AnalyzerChurn && e.Kind == KindChurn:
+			recent, err := strconv.ParseFloat(e.Metadata["recent_commits"], 64)
+			if err != nil {
+				recent, _ = strconv.ParseFloat(e.Metadata["commits"], 64)
+			}
+			churn = append(churn, churned{e.FilePath, recent})
+		}
+	}
+	sort.Slice(s.Modules, func(i, j int) bool { return s.Modules[i].ID < s.Modules[j].ID })
+	sort.Strings(s.Entrypoints)
+	sort.Strings(s.TechStack)
+	s.Dependencies, s.Direct = len(deps), len(direct)
+	sort.SliceStable(churn, func(i, j int) bool {
+		if churn[i].recent != churn[j].recent {
+			return churn[i].recent > churn[j].recent
+		}
+		return churn[i].path < churn[j].path
+	})
+	for i := 0; i < len(churn) && i < snapshotChurnTop; i++ {
+		s.Churn = append(s.Churn, churn[i].path)
+	}
+	return s
+}
+
+// Entry renders the snapshot as a survey entry keyed by its commit.
+func (s *Snapshot) Entry() *Entry {
+	data, _ := json.Marshal(s)
+	title := fmt.Sprintf("Survey snapshot @ %s: %d modules, %d files, %d entrypoints, %d dependencies",
+		ShortCommit(s.Commit), len(s.Modules), s.Files(), len(s.Entrypoints), s.Dependencies)
+	if s.Backfilled {
+		title = fmt.Sprintf("Survey snapshot @ %s (backfilled): %d modules, %d files, %d entrypoints",
+			ShortCommit(s.Commit), len(s.Modules), s.Files(), len(s.Entrypoints))
+	}
+	return &Entry{
+		Analyzer: AnalyzerHistory,
+		Kind:     KindSnapshot,
+		Name:     s.Commit,
+		Title:    title,
+		Detail:   fmt.Sprintf("Committed %s, surveyed %s.", s.CommittedAt.Format(time.RFC3339), s.TakenAt.Format(time.RFC3339)),
+		Metadata: map[string]string{
+			"backfilled":   strconv.FormatBool(s.Backfilled),
+			"commit":       s.Commit,
+			"committed_at": s.CommittedAt.Format(time.RFC3339),
+			"taken_at":     s.TakenAt.Format(time.RFC3339),
+			"modules":      strconv.Itoa(len(s.Modules)),
+			"files":        strconv.Itoa(s.Files()),
+			"entrypoints":  strconv.Itoa(len(s.Entrypoints)),
+			"dependencies": strconv.Itoa(s.Dependencies),
+			"snapshot":     string(data),
+			MetaRunCommit:  s.Commit,
+		},
+	}
+}
+
+// SnapshotFromEntry decodes a KindSnapshot entry.
+func SnapshotFromEntry(e *Entry) (*Snapshot, error) {
+	if e.Kind != KindSnapshot {
+		return nil, fmt.Errorf("entry %s is a %s, not a snapshot", e.Name, e.Kind)
+	}
+	var s Snapshot
+	if err := json.Unmarshal([]byte(e.Metadata["snapshot"]), &s); err != nil {
+		return nil, fmt.Errorf("snapshot %s: %w", ShortCommit(e.Name), err)
+	}
+	return &s, nil
+}
+
+// SnapshotsFromEntries decodes snapshot entries, oldest commit first.
+// Undecodable entries are skipped.
+func SnapshotsFromEntries(entries []*Entry) []*Snapshot {
+	var out []*Snapshot
+	for _, e := range entries {
+		if e.Kind != KindSnapshot {
+			continue
+		}
+		if s, err := SnapshotFromEntry(e); err == nil {
+			out = append(out, s)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].CommittedAt.Equal(out[j].CommittedAt) {
+			return out[i].CommittedAt.Before(out[j].CommittedAt)
+		}
+		return out[i].TakenAt.Before(out[j].TakenAt)
+	})
+	return out
+}
+
+// FindSnapshot returns the snapshot whose commit starts with ref.
+func FindSnapshot(snaps []*Snapshot, ref string) (*Snapshot, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if ref == "" {
+		return nil, fmt.Errorf("empty commit")
+	}
+	var found *Snapshot
+	for _, s := range snaps {
+		if !strings.HasPrefix(s.Commit, ref) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("commit %q is ambiguous: matches %s and %s", ref, ShortCommit(found.Commit), ShortCommit(s.Commit))
+		}
+		found = s
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no survey snapshot for commit %q — backfill one with 'aide survey run --at=%s'", ref, ref)
+	}
+	return found, nil
+}
+
+// ShortCommit abbreviates a commit hash for display.
+func ShortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
+
+func (s *Snapshot) moduleSets() map[int]moduleSet {
+	out := make(map[int]moduleSet, len(s.Modules))
+	for _, m := range s.Modules {
+		out[m.ID] = newModuleSet(m.Name, m.Members)
+	}
+	return out
+}
+
+// SnapshotDiff describes how the survey's shape changed between two commits.
+// Module lineage is matched by community ID, which each run remaps to the
+// previous run's, so the further apart the commits the looser the match.
+type SnapshotDiff struct {
+	From               string      `json:"from"`
+	To                 string      `json:"to"`
+	ModulesFrom        int         `json:"modules_from"`
+	ModulesTo          int         `json:"modules_to"`
+	Modules            *ModuleDiff `json:"modules"`
+	EntrypointsAdded   []string    `json:"entrypoints_added,omitempty"`
+	EntrypointsRemoved []string    `json:"entrypoints_removed,omitempty"`
+	TechAdded          []string    `json:"tech_added,omitempty"`
+	TechRemoved        []string    `json:"tech_removed,omitempty"`
+	DependenciesFrom   int         `json:"dependencies_from"`
+	DependenciesTo     int         `json:"dependencies_to"`
+	ChurnEntered       []string    `json:"churn_entered,omitempty"` // files that joined the busiest list
+	ChurnLeft          []string    `json:"churn_left,omitempty"`
+	// Backfilled is set when either side is a backfilled snapshot: the
+	// dependency and churn comparisons are then left out.
+	Backfilled bool `json:"backfilled,omitempty"`
+}
+
+// DiffSnapshots compares two snapshots, from -> to.
+func DiffSnapshots(from, to *Snapshot) *SnapshotDiff {
+	d := &SnapshotDiff{
+		From:             from.Commit,
+		To:               to.Commit,
+		ModulesFrom:      len(from.Modules),
+		ModulesTo:        len(to.Modules),
+		Modules:          diffModuleSets(from.moduleSets(), to.moduleSets()),
+		DependenciesFrom: from.Dependencies,
+		DependenciesTo:   to.Dependencies,
+	}
+	d.EntrypointsAdded, d.EntrypointsRemoved = diffStrings(from.Entrypoints, to.Entrypoints)
+	d.TechAdded, d.TechRemoved = diffStrings(from.TechStack, to.TechStack)
+	if from.Backfilled || to.Backfilled {
+		d.Backfilled = true
+		d.DependenciesFrom, d.DependenciesTo = 0, 0
+		return d
+	}
+	d.ChurnEntered, d.ChurnLeft = diffStrings(from.Churn, to.Churn)
+	return d
+}
+
+// diffStrings returns the values only in b and only in a, each sorted.
+func diffStrings(a, b []string) (added, removed []string) {
+	inA := make(map[string]bool, len(a))
+	for _, v := range a {
+		inA[v] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+		if !inA[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range a {
+		if !inB[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// TrendPoint is one snapshot reduced to the numbers charted over time.
+type TrendPoint struct {
+	Commit       string    `json:"commit"`
+	CommittedAt  time.Time `json:"committed_at"`
+	Modules      int       `json:"modules"`
+	Files        int       `json:"files"`
+	LargestShare float64   `json:"largest_share"` // largest module's share of files, 0-1
+	Moved        int       `json:"moved"`         // files that changed module since the previous snapshot
+	New          int       `json:"new"`           // modules that appeared since the previous snapshot
+	Dissolved    int       `json:"dissolved"`
+	Entrypoints  int       `json:"entrypoints"`
+	Dependencies int       `json:"dependencies"`
+	Backfilled   bool      `json:"backfilled,omitempty"` // dependencies unknown
+}
+
+// SnapshotTrend reduces snapshots (oldest first) to trend points, each
+// diffed against the one before it.
+func SnapshotTrend(snaps []*Snapshot) []TrendPoint {
+	points := make([]TrendPoint, 0, len(snaps))
+	for i, s := range snaps {
+		p := TrendPoint{
+			Commit:       s.Commit,
+			CommittedAt:  s.CommittedAt,
+			Modules:      len(s.Modules),
+			Files:        s.Files(),
+			Entrypoints:  len(s.Entrypoints),
+			Dependencies: s.Dependencies,
+			Backfilled:   s.Backfilled,
+		}
+		largest := 0
+		for _, m := range s.Modules {
+			largest = max(largest, len(m.Members))
+		}
+		if p.Files > 0 {
+			p.LargestShare = float64(largest) / float64(p.Files)
+		}
+		if i > 0 {
+			d := diffModuleSets(snaps[i-1].moduleSets(), s.moduleSets())
+			p.Moved, p.New, p.Dissolved = d.Moved, len(d.New), len(d.Dissolved)
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// Drift judges the trend from its first to its last point by average module
+// size: growing modules mean the architecture is consolidating, shrinking
+// ones that it is fragmenting. Fewer than two points, or a change under
+// 10%, is stable.
+func Drift(points []TrendPoint) string {
+	if len(points) < 2 {
+		return DriftStable
+	}
+	avg := func(p TrendPoint) float64 {
+		if p.Modules == 0 {
+			return 0
+		}
+		return float64(p.Files) / float64(p.Modules)
+	}
+	first, last := avg(points[0]), avg(points[len(points)-1])
+	if first == 0 || last == 0 {
+		return DriftStable
+	}
+	switch change := (last - first) / first; {
+	case change > driftThreshold:
+		return DriftConsolidating
+	case change < -driftThreshold:
+		return DriftFragmenting
+	}
+	return DriftStable
+}
@@ -0,0 +1,151 @@
+package survey
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func snapshotModuleEntry(id, label string, members ...string) *Entry {
+	m, _ := json.Marshal(members)
+	return &Entry{
+		Analyzer: AnalyzerModules,
+		Kind:     KindModule,
+		Name:     label,
+		Metadata: map[string]string{"community_id": id, "members": string(m)},
+	}
+}
+
+func TestBuildSnapshot_RoundTrip(t *testing.T) {
+	committed := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []*Entry{
+		snapshotModuleEntry("1", "store", "store/t.go", "store/s.go"),
+		snapshotModuleEntry("0", "auth", "auth/a.go"),
+		{Analyzer: AnalyzerEntrypoints, Kind: KindEntrypoint, Name: "cmd/x/main.go:main()"},
+		{Analyzer: AnalyzerDependencies, Kind: KindDependency, Name: "yaml", Metadata: map[string]string{"ecosystem": "go", "direct": "true"}},
+		{Analyzer: AnalyzerDependencies, Kind: KindDependency, Name: "yaml", Metadata: map[string]string{"ecosystem": "go", "direct": "true"}}, // second workspace
+		{Analyzer: AnalyzerDependencies, Kind: KindDependency, Name: "x/sys", Metadata: map[string]string{"ecosystem": "go", "direct": "false"}},
+		churnEntry("quiet.go", "30", "1.00"),
+		churnEntry("busy.go", "10", "8.00"),
+		{Analyzer: AnalyzerTopology, Kind: KindTechStack, Name: "go"},
+	}
+
+	s := BuildSnapshot("abcdef1234567890", committed, committed.Add(time.Hour), entries)
+	if len(s.Modules) != 2 || s.Modules[0].Name != "auth" || s.Modules[1].Members[0] != "store/s.go" {
+		t.Errorf("modules = %+v, want auth then store with sorted members", s.Modules)
+	}
+	if s.Files() != 3 || len(s.Entrypoints) != 1 || s.Dependencies != 2 || s.Direct != 1 {
+		t.Errorf("files/entrypoints/deps/direct = %d/%d/%d/%d, want 3/1/2/1", s.Files(), len(s.Entrypoints), s.Dependencies, s.Direct)
+	}
+	if len(s.Churn) != 2 || s.Churn[0] != "busy.go" {
+		t.Errorf("churn = %v, want busy.go first (recent commits)", s.Churn)
+	}
+	if len(s.TechStack) != 1 || s.TechStack[0] != "go" {
+		t.Errorf("tech stack = %v, want [go]", s.TechStack)
+	}
+
+	e := s.Entry()
+	if e.Analyzer != AnalyzerHistory || e.Kind != KindSnapshot || e.Name != s.Commit || e.Metadata[MetaRunCommit] != s.Commit {
+		t.Errorf("entry = %s/%s %s %v", e.Analyzer, e.Kind, e.Name, e.Metadata)
+	}
+	back, err := SnapshotFromEntry(e)
+	if err != nil {
+		t.Fatalf("SnapshotFromEntry: %v", err)
+	}
+	if !back.CommittedAt.Equal(committed) || back.Files() != 3 || back.Churn[0] != "busy.go" {
+		t.Errorf("round trip = %+v", back)
+	}
+}
+
+func TestFindSnapshot(t *testing.T) {
+	snaps := []*Snapshot{{Commit: "aaa111"}, {Commit: "aab222"}, {Commit: "bbb333"}}
+	if s, err := FindSnapshot(snaps, "BBB"); err != nil || s.Commit != "bbb333" {
+		t.Errorf("FindSnapshot(BBB) = %v, %v", s, err)
+	}
+	if _, err := FindSnapshot(snaps, "aa"); err == nil {
+		t.Error("ambiguous prefix should fail")
+	}
+	if _, err := FindSnapshot(snaps, "ccc"); err == nil {
+		t.Error("unknown commit should fail")
+	}
+}
+
+func TestSnapshotTrendAndDiff(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	snap := func(commit string, at time.Time, entries ...*Entry) *Entry {
+		return BuildSnapshot(commit, at, at, entries).Entry()
+	}
+	entries := []*Entry{
+		// Stored out of order; trends run oldest first.
+		snap("c3", day.AddDate(0, 0, 2),
+			snapshotModuleEntry("0", "auth", "auth/a.go"),
+			snapshotModuleEntry("1", "store", "store/s.go"),
+			snapshotModuleEntry("2", "web", "web/w.go", "web/x.go"),
+			snapshotModuleEntry("3", "cli", "cli/c.go", "store/t.go"),
+			&Entry{Analyzer: AnalyzerEntrypoints, Kind: KindEntrypoint, Name: "cli/c.go:main()"},
+		),
+		snap("c1", day,
+			snapshotModuleEntry("0", "auth", "auth/a.go", "auth/b.go"),
+			snapshotModuleEntry("1", "store", "store/s.go", "store/t.go", "web/w.go", "web/x.go"),
+		),
+		snap("c2", day.AddDate(0, 0, 1),
+			snapshotModuleEntry("0", "auth", "auth/a.go"),
+			snapshotModuleEntry("1", "store", "store/s.go", "store/t.go", "web/w.go", "web/x.go"),
+		),
+		{Analyzer: AnalyzerHistory, Kind: KindSnapshot, Name: "broken", Metadata: map[string]string{"snapshot": "{"}},
+	}
+
+	snaps := SnapshotsFromEntries(entries)
+	if len(snaps) != 3 || snaps[0].Commit != "c1" || snaps[2].Commit != "c3" {
+		t.Fatalf("snapshots = %d, want c1..c3 oldest first", len(snaps))
+	}
+
+	points := SnapshotTrend(snaps)
+	if points[0].Modules != 2 || points[0].Files != 6 || points[0].LargestShare != 4.0/6 || points[0].Moved != 0 {
+		t.Errorf("first point = %+v", points[0])
+	}
+	if p := points[2]; p.Modules != 4 || p.New != 2 || p.Moved != 3 || p.Entrypoints != 1 {
+		t.Errorf("last point = %+v, want 4 modules, 2 new, 3 moved (web and store/t.go split out)", p)
+	}
+	if got := Drift(points); got != DriftFragmenting {
+		t.Errorf("Drift = %s, want fragmenting (3 -> 1.5 files per module)", got)
+	}
+	if got := Drift(points[:1]); got != DriftStable {
+		t.Errorf("Drift of one point = %s, want stable", got)
+	}
+
+	d := DiffSnapshots(snaps[0], snaps[2])
+	if d.ModulesFrom != 2 || d.ModulesTo != 4 || len(d.Modules.New) != 2 || d.Modules.Moved != 3 {
+		t.Errorf("diff = %+v modules %+v", d, d.Modules)
+	}
+	if len(d.EntrypointsAdded) != 1 || len(d.EntrypointsRemoved) != 0 {
+		t.Errorf("entrypoints +%v -%v", d.EntrypointsAdded, d.EntrypointsRemoved)
+	}
+}
+
+func TestDiffSnapshots_Backfilled(t *testing.T) {
+	day := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	release := BuildSnapshot("r1", day, day, []*Entry{
+		snapshotModuleEntry("0", "core", "core/a.go"),
+		{Analyzer: AnalyzerTopology, Kind: KindTechStack, Name: "go"},
+	})
+	release.Backfilled = true
+	head := BuildSnapshot("h1", day.AddDate(0, 1, 0), day, []*Entry{
+		snapshotModuleEntry("0", "core", "core/a.go"),
+		{Analyzer: AnalyzerTopology, Kind: KindTechStack, Name: "go"},
+		{Analyzer: AnalyzerTopology, Kind: KindTechStack, Name: "docker"},
+		{Analyzer: AnalyzerDependencies, Kind: KindDependency, Name: "chi", Metadata: map[string]string{"ecosystem": "go"}},
+		churnEntry("core/a.go", "3", "3.00"),
+	})
+
+	d := DiffSnapshots(release, head)
+	if !d.Backfilled || d.DependenciesTo != 0 || len(d.ChurnEntered) != 0 {
+		t.Errorf("diff = %+v, want dependencies and churn left out against a backfilled snapshot", d)
+	}
+	if len(d.TechAdded) != 1 || d.TechAdded[0] != "docker" {
+		t.Errorf("tech added = %v, want [docker]", d.TechAdded)
+	}
+	if p := SnapshotTrend([]*Snapshot{release, head}); !p[0].Backfilled || p[1].Backfilled {
+		t.Errorf("trend = %+v, want only the release marked backfilled", p)
+	}
+}
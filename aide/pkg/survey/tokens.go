@@ -24,8 +24,12 @@ const MetaEstTokens = "est_tokens"
 // aggregators treat absence as "no known cost".
 // AnnotateEstTokens is idempotent: entries that already carry an est_tokens
 // value keep it (survey-time snapshot wins, even if the file has since
-// changed on disk).
+// changed on disk). An empty rootDir — entries surveyed from a commit's tree
+// rather than the worktree — annotates nothing.
 func AnnotateEstTokens(rootDir string, entries []*Entry) {
+	if rootDir == "" {
+		return
+	}
 	for _, e := range entries {
 		if e == nil || e.FilePath == "" {
 			continue
@@ -3,7 +3,9 @@ package survey
 import (
 	"bufio"
 	"fmt"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -28,6 +30,31 @@ func RunTopology(rootDir string) (*TopologyResult, error) {
 // RunTopologyWithRegistry is like RunTopology but accepts a specific PackRegistry.
 // Useful for testing with custom marker sets.
 func RunTopologyWithRegistry(rootDir string, reg *grammar.PackRegistry) (*TopologyResult, error) {
+	ignore, _ := aideignore.New(rootDir)
+	if ignore == nil {
+		ignore = aideignore.NewFromDefaults()
+	}
+	result := runTopology(os.DirFS(rootDir), filepath.Base(rootDir), ignore, reg)
+
+	AnnotateEstTokens(rootDir, result.Entries)
+	// Child project scopes: the downward estate map.
+	result.Entries = append(result.Entries, discoverSubprojects(rootDir)...)
+
+	return result, nil
+}
+
+// RunTopologyFS detects repo structure in a tree that is not the worktree —
+// a commit's files loaded by GitRepo.TreeFS. rootName names a module found
+// at the root (the project directory's basename). Only the builtin ignore
+// defaults apply, and entries carry no token estimates or child project
+// scopes: both describe the worktree as it is now.
+func RunTopologyFS(fsys fs.FS, rootName string) (*TopologyResult, error) {
+	return runTopology(fsys, rootName, aideignore.NewFromDefaults(), grammar.DefaultPackRegistry()), nil
+}
+
+// runTopology processes every project marker against fsys. Paths are
+// slash-separated and relative to the project root.
+func runTopology(fsys fs.FS, rootName string, ignore *aideignore.Matcher, reg *grammar.PackRegistry) *TopologyResult {
 	result := &TopologyResult{}
 	markers := reg.ProjectMarkers()
 
@@ -40,9 +67,9 @@ func RunTopologyWithRegistry(rootDir string, reg *grammar.PackRegistry) (*Topolo
 		m := &markers[i]
 		switch m.Check {
 		case grammar.MarkerCheckFile:
-			result.processFileMarker(rootDir, m, foundLocations)
+			result.processFileMarker(fsys, rootName, ignore, m, foundLocations)
 		case grammar.MarkerCheckDirectory:
-			result.processDirectoryMarker(rootDir, m)
+			result.processDirectoryMarker(fsys, m)
 		}
 	}
 
@@ -50,30 +77,25 @@ func RunTopologyWithRegistry(rootDir string, reg *grammar.PackRegistry) (*Topolo
 	for i := range markers {
 		m := &markers[i]
 		if m.Check == grammar.MarkerCheckSibling {
-			result.processSiblingMarker(rootDir, m, foundLocations)
+			result.processSiblingMarker(fsys, m, foundLocations)
 		}
 	}
-
-	AnnotateEstTokens(rootDir, result.Entries)
-	// Child project scopes: the downward estate map.
-	result.Entries = append(result.Entries, discoverSubprojects(rootDir)...)
-
-	return result, nil
+	return result
 }
 
 // processFileMarker walks the filesystem looking for a file with the marker's name,
 // up to the specified MaxDepth. For each found instance, it emits a survey entry
 // and records the location for sibling marker resolution.
-func (r *TopologyResult) processFileMarker(rootDir string, m *grammar.ProjectMarker, foundLocs map[string][]string) {
+func (r *TopologyResult) processFileMarker(fsys fs.FS, rootName string, ignore *aideignore.Matcher, m *grammar.ProjectMarker, foundLocs map[string][]string) {
 	// Build skip set for this marker.
 	skipSet := make(map[string]bool, len(m.SkipPaths))
 	for _, sp := range m.SkipPaths {
 		skipSet[sp] = true
 	}
 
-	walkForFile(rootDir, m.File, m.MaxDepth, skipSet, func(path string) {
-		dir := filepath.Dir(path)
-		relPath, _ := filepath.Rel(rootDir, dir)
+	walkFS(fsys, ignore, m.MaxDepth, skipSet, func(name string) bool { return name == m.File }, func(p string) {
+		dir := path.Dir(p)
+		relPath := dir
 		if relPath == "." {
 			relPath = ""
 		}
@@ -86,16 +108,16 @@ func (r *TopologyResult) processFileMarker(rootDir string, m *grammar.ProjectMar
 
 		// Apply parse directive if present.
 		if m.Parse != nil {
-			if parsed := parseFileContent(path, m.Parse); parsed != "" {
+			if parsed := parseFileContent(fsys, p, m.Parse); parsed != "" {
 				name = parsed
 			}
 		}
 
 		// For module entries without a parsed name, use the directory basename.
 		if m.Kind == KindModule && m.Parse == nil {
-			dirName := filepath.Base(dir)
+			dirName := path.Base(dir)
 			if relPath == "" {
-				dirName = filepath.Base(rootDir)
+				dirName = rootName
 			}
 			name = dirName
 		}
@@ -129,15 +151,14 @@ func (r *TopologyResult) processFileMarker(rootDir string, m *grammar.ProjectMar
 
 // processDirectoryMarker checks for a directory at root and emits a survey entry
 // if the directory exists and is non-empty.
-func (r *TopologyResult) processDirectoryMarker(rootDir string, m *grammar.ProjectMarker) {
-	fullPath := filepath.Join(rootDir, m.File)
-	fi, err := os.Stat(fullPath)
+func (r *TopologyResult) processDirectoryMarker(fsys fs.FS, m *grammar.ProjectMarker) {
+	fi, err := fs.Stat(fsys, m.File)
 	if err != nil || !fi.IsDir() {
 		return
 	}
 
 	// Check directory is non-empty.
-	entries, err := os.ReadDir(fullPath)
+	entries, err := fs.ReadDir(fsys, m.File)
 	if err != nil || len(entries) == 0 {
 		return
 	}
@@ -160,15 +181,15 @@ func (r *TopologyResult) processDirectoryMarker(rootDir string, m *grammar.Proje
 
 // processSiblingMarker checks for a sibling file next to each location where
 // the parent marker (SiblingOf) was found.
-func (r *TopologyResult) processSiblingMarker(rootDir string, m *grammar.ProjectMarker, foundLocs map[string][]string) {
+func (r *TopologyResult) processSiblingMarker(fsys fs.FS, m *grammar.ProjectMarker, foundLocs map[string][]string) {
 	parentDirs := foundLocs[m.SiblingOf]
 	for _, dir := range parentDirs {
-		siblingPath := filepath.Join(dir, m.File)
-		if _, err := os.Stat(siblingPath); err != nil {
+		siblingPath := path.Join(dir, m.File)
+		if _, err := fs.Stat(fsys, siblingPath); err != nil {
 			continue
 		}
 
-		relPath, _ := filepath.Rel(rootDir, dir)
+		relPath := dir
 		if relPath == "." {
 			relPath = ""
 		}
@@ -177,7 +198,7 @@ func (r *TopologyResult) processSiblingMarker(rootDir string, m *grammar.Project
 
 		// Apply parse if present.
 		if m.Parse != nil {
-			if parsed := parseFileContent(siblingPath, m.Parse); parsed != "" {
+			if parsed := parseFileContent(fsys, siblingPath, m.Parse); parsed != "" {
 				name = parsed
 			}
 		}
@@ -250,43 +271,58 @@ func capitalise(s string) string {
 // target, etc.) plus a hidden-dir guard plus per-marker skipSet entries.
 // maxDepth: 0 = root only, positive = limit, -1 = unlimited.
 func walkForFile(rootDir, fileName string, maxDepth int, skipSet map[string]bool, fn func(path string)) {
+	walkFiles(rootDir, maxDepth, skipSet, func(name string) bool { return name == fileName }, fn)
+}
+
+// walkFiles is walkForFile with a filename predicate, for callers looking
+// for several manifest names in one pass. fn receives absolute paths.
+func walkFiles(rootDir string, maxDepth int, skipSet map[string]bool, match func(name string) bool, fn func(path string)) {
 	ignore, _ := aideignore.New(rootDir)
 	if ignore == nil {
 		ignore = aideignore.NewFromDefaults()
 	}
+	walkFS(os.DirFS(rootDir), ignore, maxDepth, skipSet, match, func(rel string) {
+		fn(filepath.Join(rootDir, filepath.FromSlash(rel)))
+	})
+}
 
-	_ = filepath.WalkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+// walkFS is walkFiles over an fs.FS; fn receives slash-separated paths
+// relative to the root of fsys.
+func walkFS(fsys fs.FS, ignore *aideignore.Matcher, maxDepth int, skipSet map[string]bool, match func(name string) bool, fn func(rel string)) {
+	_ = fs.WalkDir(fsys, ".", func(rel string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
+		if rel == "." {
+			return nil
+		}
 
-		rel, _ := filepath.Rel(rootDir, path)
-		depth := strings.Count(rel, string(filepath.Separator))
+		depth := strings.Count(rel, "/")
 
 		// Apply maxDepth guard: -1 means unlimited.
 		if maxDepth >= 0 && depth > maxDepth {
 			if d.IsDir() {
-				return filepath.SkipDir
+				return fs.SkipDir
 			}
 			return nil
 		}
 
 		if d.IsDir() {
 			base := d.Name()
-			// Skip hidden directories (but not the root ".").
-			if base != "." && strings.HasPrefix(base, ".") {
-				return filepath.SkipDir
+			// Skip hidden directories.
+			if strings.HasPrefix(base, ".") {
+				return fs.SkipDir
 			}
-			if rel != "." && ignore.ShouldIgnoreDir(rel) {
-				return filepath.SkipDir
+			if ignore.ShouldIgnoreDir(rel) {
+				return fs.SkipDir
 			}
 			if skipSet[base] {
-				return filepath.SkipDir
+				return fs.SkipDir
 			}
 		}
 
-		if !d.IsDir() && d.Name() == fileName {
-			fn(path)
+		if !d.IsDir() && match(d.Name()) {
+			fn(rel)
 		}
 
 		return nil
@@ -294,13 +330,13 @@ func walkForFile(rootDir, fileName string, maxDepth int, skipSet map[string]bool
 }
 
 // parseFileContent applies a MarkerParse regex to extract a value from file content.
-func parseFileContent(path string, p *grammar.MarkerParse) string {
+func parseFileContent(fsys fs.FS, name string, p *grammar.MarkerParse) string {
 	re, err := regexp.Compile(p.Regex)
 	if err != nil {
 		return ""
 	}
 
-	f, err := os.Open(path)
+	f, err := fsys.Open(name)
 	if err != nil {
 		return ""
 	}
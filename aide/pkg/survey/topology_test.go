@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/go-git/go-billy/v5/helper/iofs"
 )
 
 func TestRunTopology_GoModule(t *testing.T) {
@@ -407,3 +409,54 @@ func TestRunTopology_CICD(t *testing.T) {
 		t.Error("expected to find GitHub Actions CI/CD entry")
 	}
 }
+
+func TestRunTopologyFS_CommitTree(t *testing.T) {
+	dir, repo := initTestRepo(t)
+	writeTestFile(t, dir, "svc/go.mod", "module example.com/v1\n")
+	writeTestFile(t, dir, "svc/.github/workflows/ci.yml", "on: push\n")
+	commitAll(t, repo, "v1")
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	v1 := head.Hash().String()
+	writeTestFile(t, dir, "svc/go.mod", "module example.com/v2\n")
+	commitAll(t, repo, "v2")
+
+	g, err := OpenGitRepo(dir)
+	if err != nil || g == nil {
+		t.Fatalf("OpenGitRepo: %v", err)
+	}
+	sub, err := g.RelDir(filepath.Join(dir, "svc"))
+	if err != nil || sub != "svc" {
+		t.Fatalf("RelDir = %q, %v, want svc", sub, err)
+	}
+	tree, err := g.TreeFS(v1, sub)
+	if err != nil {
+		t.Fatalf("TreeFS: %v", err)
+	}
+	result, err := RunTopologyFS(iofs.New(tree), "svc")
+	if err != nil {
+		t.Fatalf("RunTopologyFS: %v", err)
+	}
+
+	var module, ci bool
+	for _, e := range result.Entries {
+		if e.Kind == KindModule {
+			module = e.Name == "example.com/v1" && e.FilePath == ""
+		}
+		if e.FilePath == ".github/workflows" {
+			ci = true
+		}
+		if e.Metadata[MetaEstTokens] != "" {
+			t.Errorf("entry %s carries a worktree token estimate", e.Name)
+		}
+	}
+	if !module || !ci {
+		t.Errorf("entries = %+v, want the v1 module at the tree root and the CI workflows", result.Entries)
+	}
+
+	if _, err := g.TreeFS(v1, "missing"); err == nil {
+		t.Error("TreeFS of a missing directory should fail")
+	}
+}
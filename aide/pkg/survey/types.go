@@ -14,6 +14,11 @@ const (
 	KindSubproject   = "subproject"   // Child project scope: nested VCS root or submodule checkout with its own store boundary
 	KindWorkspace    = "workspace"    // Monorepo workspace root (npm, go, cargo, etc.)
 	KindArchPattern  = "arch_pattern" // Detected architectural pattern (MVC, hexagonal, etc.)
+	KindLicense      = "license"      // LICENSE/COPYING file of the project or a dependency, classified by SPDX id
+	KindOwnership    = "ownership"    // Primary authors, bus factor and recent owners of a file or module
+	KindCoChange     = "cochange"     // Pair of files that change together in commit history (temporal coupling)
+	KindHotspot      = "hotspot"      // File or function ranked by churn times complexity
+	KindSnapshot     = "snapshot"     // Compact record of the survey's shape at one commit (history lens)
 	KindUnclassified = "unclassified" // Files not matching any known grammar pack, grouped by extension.
 	// TODO: Future enhancement — expose KindUnclassified entries via MCP tools so the
 	// calling LLM can classify unknown files. Since aide is an MCP server (not an LLM
@@ -22,10 +27,17 @@ const (
 
 // Analyzer names — who produced this entry.
 const (
-	AnalyzerTopology    = "topology"    // Repo structure, build systems, workspaces
-	AnalyzerEntrypoints = "entrypoints" // Entry point detection
-	AnalyzerChurn       = "churn"       // Git history analysis
-	AnalyzerModules     = "modules"     // Module clustering over the import/reference graph
+	AnalyzerTopology     = "topology"     // Repo structure, build systems, workspaces
+	AnalyzerEntrypoints  = "entrypoints"  // Entry point detection
+	AnalyzerChurn        = "churn"        // Git history analysis
+	AnalyzerModules      = "modules"      // Module clustering over the import/reference graph
+	AnalyzerDependencies = "dependencies" // External dependencies from manifests and lockfiles
+	AnalyzerArchitecture = "architecture" // Architectural patterns from conventions and import direction
+	AnalyzerLicenses     = "licenses"     // License files classified against the bundled SPDX corpus
+	AnalyzerOwnership    = "ownership"    // Authorship and bus factor from git history, merged with CODEOWNERS
+	AnalyzerCoChange     = "cochange"     // Temporal coupling: file pairs mined from commit history
+	AnalyzerHotspots     = "hotspots"     // Churn entries joined with per-function complexity
+	AnalyzerHistory      = "history"      // Per-commit snapshots recorded after each run, for trends and diffs
 )
 
 // Default result limits.
@@ -83,20 +95,24 @@ type CodeSearcher interface {
 
 // SymbolHit is a simplified symbol result for the entrypoints analyzer.
 type SymbolHit struct {
-	Name     string
-	Kind     string // function, method, class, interface, type
-	FilePath string
-	Line     int
-	EndLine  int // End line of the symbol (0 if unknown)
-	Language string
+	ID            string // Code index symbol ID ("" if unknown)
+	Name          string
+	QualifiedName string // e.g. "store.CodeStore.Close" ("" if unknown)
+	Kind          string // function, method, class, interface, type
+	FilePath      string
+	Line          int
+	EndLine       int // End line of the symbol (0 if unknown)
+	Language      string
 }
 
 // ReferenceHit is a simplified reference result for the entrypoints analyzer.
 type ReferenceHit struct {
-	Symbol   string // Name of the referenced symbol
-	Kind     string // call, type_ref, import
-	FilePath string
-	Line     int
+	Symbol     string // Name of the referenced symbol
+	Kind       string // call, type_ref, import
+	FilePath   string
+	Line       int
+	TargetID   string // ID of the resolved definition ("" if unresolved)
+	Confidence string // Resolution confidence: high, medium, low ("" if unresolved)
 }
 
 // CodeGrapher extends CodeSearcher with methods needed for call-graph traversal.
@@ -114,12 +130,14 @@ type CodeGrapher interface {
 
 // GraphNode represents a symbol in the call graph.
 type GraphNode struct {
-	Name     string `json:"name"`
-	Kind     string `json:"kind"`
-	FilePath string `json:"file"`
-	Line     int    `json:"line"`
-	EndLine  int    `json:"endLine,omitempty"`
-	Language string `json:"lang,omitempty"`
+	Name          string `json:"name"`
+	QualifiedName string `json:"qname,omitempty"`
+	Kind          string `json:"kind"`
+	FilePath      string `json:"file"`
+	Line          int    `json:"line"`
+	EndLine       int    `json:"endLine,omitempty"`
+	Language      string `json:"lang,omitempty"`
+	Depth         int    `json:"depth,omitempty"` // Hops from the root
 }
 
 // GraphEdge represents a call relationship between two symbols.
@@ -0,0 +1,243 @@
+package surveyrun
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5/helper/iofs"
+	"github.com/jmylchreest/aide/aide/pkg/aideignore"
+	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+	"github.com/jmylchreest/aide/aide/pkg/importresolve"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+// BackfillAnalyzers are the analyzers RunAt can run against a past commit:
+// the ones that need nothing but the files. Churn, ownership and the other
+// history analyzers describe the log up to HEAD, and dependencies and
+// licenses are not part of the snapshot.
+func BackfillAnalyzers() []string {
+	return []string{survey.AnalyzerTopology, survey.AnalyzerEntrypoints, survey.AnalyzerModules}
+}
+
+// RunAt surveys the tree of ref (a hash, branch, tag or "HEAD~3") without
+// touching the worktree or the current survey. Files are read from the
+// commit into memory, entrypoints and modules run against a temporary code
+// index built from them, and only the resulting history snapshot is stored
+// — tagged with the commit, so 'aide survey diff' can compare releases.
+// analyzers nil/empty runs all of BackfillAnalyzers. loader supplies the
+// grammars for the temporary index.
+func RunAt(rootDir, ref string, analyzers []string, surveyStore store.SurveyStore, loader grammar.Loader) ([]Result, error) {
+	if len(analyzers) == 0 || (len(analyzers) == 1 && analyzers[0] == "") {
+		analyzers = BackfillAnalyzers()
+	}
+	repo, err := survey.OpenGitRepo(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("%s is not a git repository — --at needs history to read", rootDir)
+	}
+	commit, err := repo.ResolveCommit(ref)
+	if err != nil {
+		return nil, err
+	}
+	committedAt, err := repo.CommitTime(commit)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := repo.RelDir(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := repo.TreeFS(commit, dir)
+	if err != nil {
+		return nil, err
+	}
+	fsys := iofs.New(tree)
+
+	at := &treeRun{
+		fsys:        fsys,
+		rootName:    filepath.Base(rootDir),
+		commit:      commit,
+		committedAt: committedAt,
+		surveyStore: surveyStore,
+		loader:      loader,
+	}
+	defer at.close()
+
+	results := make([]Result, 0, len(analyzers)+1)
+	var entries []*survey.Entry
+	for _, name := range analyzers {
+		res, produced := at.runOne(name)
+		results = append(results, res)
+		entries = append(entries, produced...)
+	}
+	if !at.surveyed {
+		return results, nil
+	}
+
+	snap := survey.BuildSnapshot(commit, committedAt, time.Now(), entries)
+	snap.Backfilled = true
+	res := Result{Analyzer: survey.AnalyzerHistory}
+	kept, err := storeSnapshot(surveyStore, snap)
+	if err != nil {
+		res.Err = err.Error()
+	} else {
+		res.Entries = kept
+		res.Summary = fmt.Sprintf("snapshot @ %s [%d modules, %d files, %d entrypoints, %d tech] (%d snapshots)",
+			survey.ShortCommit(commit), len(snap.Modules), snap.Files(), len(snap.Entrypoints), len(snap.TechStack), kept)
+	}
+	return append(results, res), nil
+}
+
+// treeRun is one RunAt pass over a commit's tree. The temporary code index
+// is built on first use, so a topology-only backfill parses nothing.
+type treeRun struct {
+	fsys        fs.FS
+	rootName    string
+	commit      string
+	committedAt time.Time
+	surveyStore store.SurveyStore
+	loader      grammar.Loader
+
+	index    *store.CodeStore
+	indexDir string
+	indexErr error // a failed build is not retried per analyzer
+	surveyed bool  // an analyzer succeeded, so a snapshot is worth storing
+}
+
+// runOne runs one analyzer against the tree, returning its result and the
+// entries it produced for the snapshot. Nothing is stored.
+func (t *treeRun) runOne(name string) (Result, []*survey.Entry) {
+	res := Result{Analyzer: name}
+
+	var entries []*survey.Entry
+	note := ""
+	switch name {
+	case survey.AnalyzerTopology:
+		result, err := survey.RunTopologyFS(t.fsys, t.rootName)
+		if err != nil {
+			res.Err = err.Error()
+			return res, nil
+		}
+		entries = result.Entries
+
+	case survey.AnalyzerEntrypoints:
+		cs, err := t.codeIndex()
+		if err != nil {
+			res.Err = err.Error()
+			return res, nil
+		}
+		result, err := survey.RunEntrypoints("", &codeSearcher{store: cs})
+		if err != nil {
+			res.Err = err.Error()
+			return res, nil
+		}
+		entries = result.Entries
+
+	case survey.AnalyzerModules:
+		cs, err := t.codeIndex()
+		if err != nil {
+			res.Err = err.Error()
+			return res, nil
+		}
+		// Seed IDs from the current map so a release's modules line up with
+		// today's in 'aide survey diff'.
+		current, _ := t.surveyStore.ListEntries(survey.SearchOptions{Analyzer: name, Limit: diffListLimit})
+		result, err := survey.RunModules(survey.ModulesConfig{
+			Source:   &modulesSource{store: cs},
+			Resolver: importresolve.NewFS(t.fsys),
+			Previous: survey.PreviousAssignmentFromEntries(current),
+		})
+		if err != nil {
+			res.Err = err.Error()
+			return res, nil
+		}
+		entries = result.Entries
+		note = fmt.Sprintf(" [%d modules, %d unclustered, imports resolved %d/%d]",
+			result.Communities, result.Singletons, result.ImportsResolved, result.ImportsTotal)
+
+	default:
+		res.Err = fmt.Sprintf("cannot run at a past commit (supported: %s)", strings.Join(BackfillAnalyzers(), ", "))
+		return res, nil
+	}
+
+	t.surveyed = true
+	res.Entries = len(entries)
+	res.Summary = fmt.Sprintf("%d entries @ %s%s", len(entries), survey.ShortCommit(t.commit), note)
+	return res, entries
+}
+
+// codeIndex returns the temporary code index of the tree, building it on
+// first call: every supported, non-ignored file is parsed from memory and
+// its references resolved against the tree, as 'aide code index' would.
+func (t *treeRun) codeIndex() (*store.CodeStore, error) {
+	if t.index == nil && t.indexErr == nil {
+		t.indexErr = t.buildIndex()
+	}
+	return t.index, t.indexErr
+}
+
+func (t *treeRun) buildIndex() error {
+	dir, err := os.MkdirTemp("", "aide-survey-at-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary code index: %w", err)
+	}
+	t.indexDir = dir
+	cs, err := store.NewCodeStore(filepath.Join(dir, "index.db"), filepath.Join(dir, "search"))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary code index: %w", err)
+	}
+	t.index = cs
+
+	parser := code.NewParser(t.loader)
+	defer parser.Close()
+	ignore := aideignore.NewFromDefaults()
+	err = fs.WalkDir(t.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ignore.ShouldIgnore(p, d.IsDir()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !code.SupportedFile(p) {
+			return nil
+		}
+		content, err := fs.ReadFile(t.fsys, p)
+		if err != nil {
+			return nil
+		}
+		parsed, err := parser.ParseFileContent(p, content)
+		if err != nil || parsed.Language == "" {
+			return nil
+		}
+		_, err = cs.IndexFileDelta(p, parsed.Symbols, parsed.Refs, parsed.Edges, t.committedAt, int64(len(content)))
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index %s: %w", survey.ShortCommit(t.commit), err)
+	}
+	if _, err := cs.ResolveReferenceTargets(nil, importresolve.NewFS(t.fsys).ResolveTarget); err != nil {
+		return fmt.Errorf("failed to resolve references at %s: %w", survey.ShortCommit(t.commit), err)
+	}
+	return nil
+}
+
+// close drops the temporary code index.
+func (t *treeRun) close() {
+	if t.index != nil {
+		t.index.Close()
+	}
+	if t.indexDir != "" {
+		os.RemoveAll(t.indexDir)
+	}
+}
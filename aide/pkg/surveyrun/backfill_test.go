@@ -0,0 +1,113 @@
+package surveyrun
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmylchreest/aide/aide/pkg/grammar"
+	"github.com/jmylchreest/aide/aide/pkg/store"
+	"github.com/jmylchreest/aide/aide/pkg/survey"
+)
+
+func TestRunAtBackfillsSnapshot(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("PlainInit: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	when := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(files map[string]string) string {
+		t.Helper()
+		for rel, content := range files {
+			path := filepath.Join(root, rel)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatalf("MkdirAll: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+		}
+		if _, err := wt.Add("."); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		when = when.Add(24 * time.Hour)
+		h, err := wt.Commit("release", &git.CommitOptions{Author: &object.Signature{Name: "Alice", Email: "alice@example.com", When: when}})
+		if err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+		return h.String()
+	}
+
+	v1 := commit(map[string]string{
+		"go.mod":          "module example.com/proj\n",
+		"cmd/app/main.go": "package main\n\nimport \"example.com/proj/util\"\n\nfunc main() { util.Run() }\n",
+		"util/util.go":    "package util\n\nfunc Run() {}\n",
+	})
+	commit(map[string]string{"cmd/app/main.go": "package main\n\nfunc run() {}\n"})
+	worktree, _ := os.ReadFile(filepath.Join(root, "cmd/app/main.go"))
+
+	ss, err := store.NewSurveyStore(filepath.Join(t.TempDir(), "survey"))
+	if err != nil {
+		t.Fatalf("NewSurveyStore: %v", err)
+	}
+	defer ss.Close()
+
+	loader := grammar.NewCompositeLoader(grammar.WithAutoDownload(false))
+	results, err := RunAt(root, v1[:10], nil, ss, loader)
+	if err != nil {
+		t.Fatalf("RunAt: %v", err)
+	}
+	if len(results) != len(BackfillAnalyzers())+1 {
+		t.Fatalf("results = %+v, want one per analyzer plus history", results)
+	}
+	for _, r := range results {
+		if r.Err != "" {
+			t.Errorf("%s failed: %s", r.Analyzer, r.Err)
+		}
+	}
+
+	entries, err := ss.ListEntries(survey.SearchOptions{Limit: -1})
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Analyzer != survey.AnalyzerHistory {
+		t.Fatalf("stored %d entries, want only the snapshot (current survey untouched)", len(entries))
+	}
+	snap, err := survey.SnapshotFromEntry(entries[0])
+	if err != nil {
+		t.Fatalf("SnapshotFromEntry: %v", err)
+	}
+	if snap.Commit != v1 || !snap.Backfilled || !snap.CommittedAt.Equal(when.Add(-24*time.Hour)) {
+		t.Errorf("snapshot = %s backfilled=%v at %s, want v1 at its commit time", snap.Commit, snap.Backfilled, snap.CommittedAt)
+	}
+	if !slices.Contains(snap.Entrypoints, "cmd/app/main.go:main()") {
+		t.Errorf("entrypoints = %v, want main() as it was at v1", snap.Entrypoints)
+	}
+	if len(snap.TechStack) == 0 {
+		t.Error("snapshot has no tech stack from topology")
+	}
+	if after, _ := os.ReadFile(filepath.Join(root, "cmd/app/main.go")); string(after) != string(worktree) {
+		t.Error("RunAt touched the worktree")
+	}
+
+	// Only tree analyzers can run at a past commit; nothing is stored then.
+	results, err = RunAt(root, "HEAD", []string{survey.AnalyzerChurn}, ss, loader)
+	if err != nil || len(results) != 1 || results[0].Err == "" {
+		t.Errorf("churn at HEAD = %+v, %v, want one error result", results, err)
+	}
+	if _, err := RunAt(root, "no-such-ref", nil, ss, loader); err == nil {
+		t.Error("unknown ref should fail")
+	}
+	if _, err := RunAt(t.TempDir(), "HEAD", nil, ss, loader); err == nil {
+		t.Error("RunAt outside a git repository should fail")
+	}
+}
@@ -8,9 +8,13 @@ package surveyrun
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jmylchreest/aide/aide/pkg/code"
+	"github.com/jmylchreest/aide/aide/pkg/findings"
 	"github.com/jmylchreest/aide/aide/pkg/store"
 	"github.com/jmylchreest/aide/aide/pkg/survey"
 )
@@ -29,13 +33,21 @@ type Result struct {
 
 // AllAnalyzers is the default run set.
 func AllAnalyzers() []string {
-	return []string{survey.AnalyzerTopology, survey.AnalyzerEntrypoints, survey.AnalyzerChurn, survey.AnalyzerModules}
+	return []string{survey.AnalyzerTopology, survey.AnalyzerEntrypoints, survey.AnalyzerChurn, survey.AnalyzerModules, survey.AnalyzerOwnership, survey.AnalyzerCoChange, survey.AnalyzerHotspots, survey.AnalyzerDependencies, survey.AnalyzerArchitecture, survey.AnalyzerLicenses}
 }
 
+// snapshotAnalyzers are the analyzers whose refresh records a history
+// snapshot. The snapshot also summarises topology's tech stack, which alone
+// changes too rarely to warrant one.
+var snapshotAnalyzers = []string{survey.AnalyzerModules, survey.AnalyzerEntrypoints, survey.AnalyzerDependencies, survey.AnalyzerChurn}
+
 // Run executes the named analyzers (nil/empty = all) and stores their
-// entries. codeStore may be nil: entrypoints degrades to file scanning and
-// modules reports an error result.
-func Run(rootDir string, analyzers []string, surveyStore store.SurveyStore, codeStore store.CodeIndexStore) []Result {
+// entries. codeStore may be nil: entrypoints degrades to file scanning,
+// dependencies skips importer attribution, and modules reports an error
+// result. findingsStore may be nil: hotspots then relies on complexity
+// recorded in the code index alone. In a git repository, a run that
+// refreshed any snapshotted analyzer also records a history snapshot.
+func Run(rootDir string, analyzers []string, surveyStore store.SurveyStore, codeStore store.CodeIndexStore, findingsStore store.FindingsStore) []Result {
 	if len(analyzers) == 0 || (len(analyzers) == 1 && analyzers[0] == "") {
 		analyzers = AllAnalyzers()
 	}
@@ -43,12 +55,32 @@ func Run(rootDir string, analyzers []string, surveyStore store.SurveyStore, code
 
 	results := make([]Result, 0, len(analyzers))
 	for _, name := range analyzers {
-		results = append(results, runOne(rootDir, name, headCommit, surveyStore, codeStore))
+		results = append(results, runOne(rootDir, name, headCommit, surveyStore, codeStore, findingsStore))
+	}
+	if headCommit != "" && needsSnapshot(results) {
+		results = append(results, recordSnapshot(rootDir, headCommit, surveyStore))
 	}
 	return results
 }
 
-func runOne(rootDir, name, headCommit string, surveyStore store.SurveyStore, codeStore store.CodeIndexStore) Result {
+// needsSnapshot reports whether a snapshotted analyzer succeeded and the
+// history analyzer was not already run explicitly.
+func needsSnapshot(results []Result) bool {
+	refreshed := false
+	for _, r := range results {
+		if r.Analyzer == survey.AnalyzerHistory {
+			return false
+		}
+		for _, a := range snapshotAnalyzers {
+			if r.Analyzer == a && r.Err == "" {
+				refreshed = true
+			}
+		}
+	}
+	return refreshed
+}
+
+func runOne(rootDir, name, headCommit string, surveyStore store.SurveyStore, codeStore store.CodeIndexStore, findingsStore store.FindingsStore) Result {
 	res := Result{Analyzer: name}
 
 	switch name {
@@ -109,6 +141,108 @@ func runOne(rootDir, name, headCommit string, surveyStore store.SurveyStore, cod
 		}
 		return res
 
+	case survey.AnalyzerOwnership:
+		// Runs after modules in AllAnalyzers, so ownership rolls up into the
+		// fresh clusters; without them files group by directory.
+		modules, _ := surveyStore.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerModules, Limit: diffListLimit})
+		result, err := survey.RunOwnership(survey.OwnershipConfig{RootDir: rootDir, Modules: modules})
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		note := fmt.Sprintf(" [%d modules, %d files, %d modules with bus factor 1]", result.Modules, result.Files, len(result.BusFactorOne))
+		if result.CodeOwners != "" {
+			note += " (merged " + result.CodeOwners + ")"
+		}
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerCoChange:
+		modules, _ := surveyStore.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerModules, Limit: diffListLimit})
+		result, err := survey.RunCoChange(survey.CoChangeConfig{RootDir: rootDir, Modules: modules})
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		note := fmt.Sprintf(" [%d pairs from %d commits, %d noisy commits skipped, %d cross-module]",
+			len(result.Entries), result.Commits, result.Noisy, result.CrossModule)
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerHotspots:
+		// Runs after churn in AllAnalyzers, so the candidates are fresh.
+		churn, _ := surveyStore.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerChurn, Kind: survey.KindChurn, Limit: diffListLimit})
+		if len(churn) == 0 {
+			return storeEntries(res, surveyStore, name, headCommit, nil, " (no churn data — not a git repo, or run the churn analyzer first)")
+		}
+		var cs survey.ComplexitySource = &hotspotComplexity{code: codeStore, findings: findingsStore}
+		if codeStore == nil && findingsStore == nil {
+			cs = nil
+		}
+		result, err := survey.RunHotspots(survey.HotspotsConfig{Churn: churn, Complexity: cs})
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		note := fmt.Sprintf(" [%d files, %d functions; %d churned files without complexity data]", result.Files, result.Functions, result.Unmeasured)
+		if result.Files == 0 {
+			note += " (run 'aide findings run complexity' first)"
+		}
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerDependencies:
+		cfg := survey.DependenciesConfig{RootDir: rootDir}
+		if codeStore != nil {
+			cfg.Source = &modulesSource{store: codeStore}
+		}
+		result, err := survey.RunDependencies(cfg)
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		note := fmt.Sprintf(" [%d direct, %d transitive from %d manifests]", result.Direct, result.Transitive, result.Manifests)
+		if !result.Attributed {
+			note += " (code index not available — importers not attributed)"
+		}
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerArchitecture:
+		if codeStore == nil {
+			res.Err = "code index not available — run 'aide code index' first"
+			return res
+		}
+		// Runs after modules in AllAnalyzers, so this sees the fresh clusters.
+		modules, _ := surveyStore.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerModules, Limit: diffListLimit})
+		result, err := survey.RunArchitecture(survey.ArchitectureConfig{
+			RootDir: rootDir,
+			Source:  &modulesSource{store: codeStore},
+			Modules: modules,
+		})
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		note := fmt.Sprintf(" [%d patterns, %d of %d files in a layer]", len(result.Entries), result.Classified, result.Files)
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerLicenses:
+		result, err := survey.RunLicenses(rootDir)
+		if err != nil {
+			res.Err = err.Error()
+			return res
+		}
+		project := "none"
+		if len(result.Project) > 0 {
+			project = strings.Join(result.Project, ", ")
+		}
+		note := fmt.Sprintf(" [project: %s; %d dependency licenses, %d unrecognised]", project, result.Dependency, result.Unknown)
+		return storeEntries(res, surveyStore, name, headCommit, result.Entries, note)
+
+	case survey.AnalyzerHistory:
+		if headCommit == "" {
+			res.Summary = "0 entries (not a git repository — snapshots are keyed by commit)"
+			return res
+		}
+		return recordSnapshot(rootDir, headCommit, surveyStore)
+
 	default:
 		res.Err = fmt.Sprintf("unknown analyzer: %s", name)
 		return res
@@ -143,6 +277,78 @@ func storeEntries(res Result, surveyStore store.SurveyStore, analyzer, headCommi
 	return res
 }
 
+// recordSnapshot summarises the stored modules, entrypoints, tech stack,
+// dependencies and churn as the snapshot for headCommit. Analyzers not
+// refreshed by this run contribute their stored entries, so a snapshot
+// reflects the survey as it stood when the commit was surveyed.
+func recordSnapshot(rootDir, headCommit string, surveyStore store.SurveyStore) Result {
+	res := Result{Analyzer: survey.AnalyzerHistory}
+	var current []*survey.Entry
+	for _, a := range append([]string{survey.AnalyzerTopology}, snapshotAnalyzers...) {
+		entries, err := surveyStore.ListEntries(survey.SearchOptions{Analyzer: a, Limit: diffListLimit})
+		if err != nil {
+			res.Err = fmt.Sprintf("failed to read %s entries: %v", a, err)
+			return res
+		}
+		current = append(current, entries...)
+	}
+	now := time.Now()
+	committedAt := now
+	if repo, err := survey.OpenGitRepo(rootDir); err == nil && repo != nil {
+		if t, err := repo.CommitTime(headCommit); err == nil {
+			committedAt = t
+		}
+	}
+	snap := survey.BuildSnapshot(headCommit, committedAt, now, current)
+
+	kept, err := storeSnapshot(surveyStore, snap)
+	if err != nil {
+		res.Err = err.Error()
+		return res
+	}
+	res.Entries = kept
+	res.Summary = fmt.Sprintf("snapshot @ %s [%d modules, %d files, %d entrypoints, %d dependencies] (%d snapshots)",
+		survey.ShortCommit(headCommit), len(snap.Modules), snap.Files(), len(snap.Entrypoints), snap.Dependencies, kept)
+	return res
+}
+
+// storeSnapshot adds snap, replacing any earlier snapshot of the same commit
+// and pruning the oldest beyond survey.MaxSnapshots, and returns how many
+// snapshots are kept. It deletes and adds single entries rather than
+// replacing the analyzer wholesale so it works through the gRPC adapter too,
+// which cannot replace.
+func storeSnapshot(surveyStore store.SurveyStore, snap *survey.Snapshot) (int, error) {
+	stored, err := surveyStore.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerHistory, Kind: survey.KindSnapshot, Limit: diffListLimit})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	var stale, kept []*survey.Entry
+	for _, e := range stored {
+		if e.Name == snap.Commit {
+			stale = append(stale, e)
+		} else {
+			kept = append(kept, e)
+		}
+	}
+	// RFC 3339 in UTC sorts chronologically as a string.
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Metadata["committed_at"] < kept[j].Metadata["committed_at"]
+	})
+	if len(kept) >= survey.MaxSnapshots {
+		prune := len(kept) - survey.MaxSnapshots + 1
+		stale, kept = append(stale, kept[:prune]...), kept[prune:]
+	}
+	for _, e := range stale {
+		if err := surveyStore.DeleteEntry(e.ID); err != nil {
+			return 0, fmt.Errorf("store error: %w", err)
+		}
+	}
+	if err := surveyStore.AddEntry(snap.Entry()); err != nil {
+		return 0, fmt.Errorf("store error: %w", err)
+	}
+	return len(kept) + 1, nil
+}
+
 // FormatResults renders results as the display block shared by every entry
 // point: "analyzer: summary" or "analyzer: error: ...", one per line.
 func FormatResults(results []Result) string {
@@ -178,7 +384,7 @@ func fileReferenceHits(cs store.CodeIndexStore, filePath string) ([]survey.Refer
 	}
 	hits := make([]survey.ReferenceHit, 0, len(refs))
 	for _, r := range refs {
-		hits = append(hits, survey.ReferenceHit{Symbol: r.SymbolName, Kind: r.Kind, FilePath: r.FilePath, Line: r.Line})
+		hits = append(hits, survey.ReferenceHit{Symbol: r.SymbolName, Kind: r.Kind, FilePath: r.FilePath, Line: r.Line, TargetID: r.TargetSymbolID, Confidence: r.Confidence})
 	}
 	return hits, nil
 }
@@ -193,12 +399,14 @@ func (a *codeGrapher) GetContainingSymbol(filePath string, line int) (*survey.Sy
 		return nil, err
 	}
 	return &survey.SymbolHit{
-		Name:     sym.Name,
-		Kind:     sym.Kind,
-		FilePath: sym.FilePath,
-		Line:     sym.StartLine,
-		EndLine:  sym.EndLine,
-		Language: sym.Language,
+		ID:            sym.ID,
+		Name:          sym.Name,
+		QualifiedName: sym.QualifiedName,
+		Kind:          sym.Kind,
+		FilePath:      sym.FilePath,
+		Line:          sym.StartLine,
+		EndLine:       sym.EndLine,
+		Language:      sym.Language,
 	}, nil
 }
 
@@ -216,12 +424,14 @@ func (a *codeSearcher) FindSymbols(query string, kind string, limit int) ([]surv
 	hits := make([]survey.SymbolHit, 0, len(results))
 	for _, r := range results {
 		hits = append(hits, survey.SymbolHit{
-			Name:     r.Symbol.Name,
-			Kind:     r.Symbol.Kind,
-			FilePath: r.Symbol.FilePath,
-			Line:     r.Symbol.StartLine,
-			EndLine:  r.Symbol.EndLine,
-			Language: r.Symbol.Language,
+			ID:            r.Symbol.ID,
+			Name:          r.Symbol.Name,
+			QualifiedName: r.Symbol.QualifiedName,
+			Kind:          r.Symbol.Kind,
+			FilePath:      r.Symbol.FilePath,
+			Line:          r.Symbol.StartLine,
+			EndLine:       r.Symbol.EndLine,
+			Language:      r.Symbol.Language,
 		})
 	}
 	return hits, nil
@@ -234,11 +444,70 @@ func (a *codeSearcher) FindReferences(symbolName string, kind string, limit int)
 	}
 	hits := make([]survey.ReferenceHit, 0, len(results))
 	for _, r := range results {
-		hits = append(hits, survey.ReferenceHit{Symbol: r.SymbolName, Kind: r.Kind, FilePath: r.FilePath, Line: r.Line})
+		hits = append(hits, survey.ReferenceHit{Symbol: r.SymbolName, Kind: r.Kind, FilePath: r.FilePath, Line: r.Line, TargetID: r.TargetSymbolID, Confidence: r.Confidence})
 	}
 	return hits, nil
 }
 
+// hotspotComplexity feeds the hotspots analyzer. Complexity recorded on
+// code index symbols is preferred, since it covers every function; failing
+// that, complexity findings supply the functions over the threshold. Either
+// way a function the complexity analyzer reported is marked flagged.
+type hotspotComplexity struct {
+	code     store.CodeIndexStore
+	findings store.FindingsStore
+}
+
+func (a *hotspotComplexity) FileComplexity(filePath string) ([]survey.FunctionComplexity, string, error) {
+	flagged := make(map[int]survey.FunctionComplexity) // by start line
+	if a.findings != nil {
+		// FilePath filters by substring; keep exact matches only.
+		list, err := a.findings.ListFindings(findings.SearchOptions{
+			Analyzer:        findings.AnalyzerComplexity,
+			FilePath:        filePath,
+			Limit:           1000,
+			IncludeAccepted: true,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		for _, f := range list {
+			cx, _ := strconv.Atoi(f.Metadata["complexity"])
+			if f.FilePath != filePath || cx <= 0 {
+				continue
+			}
+			flagged[f.Line] = survey.FunctionComplexity{Name: f.Metadata["function"], Line: f.Line, EndLine: f.EndLine, Complexity: cx, Flagged: true}
+		}
+	}
+
+	if a.code != nil {
+		syms, err := a.code.GetFileSymbols(filePath)
+		if err == nil {
+			var funcs []survey.FunctionComplexity
+			for _, sym := range syms {
+				if sym.Complexity <= 0 {
+					continue
+				}
+				_, ok := flagged[sym.StartLine]
+				funcs = append(funcs, survey.FunctionComplexity{Name: sym.Name, Line: sym.StartLine, EndLine: sym.EndLine, Complexity: sym.Complexity, Flagged: ok})
+			}
+			if len(funcs) > 0 {
+				return funcs, "index", nil
+			}
+		}
+	}
+
+	if len(flagged) == 0 {
+		return nil, "", nil
+	}
+	funcs := make([]survey.FunctionComplexity, 0, len(flagged))
+	for _, fn := range flagged {
+		funcs = append(funcs, fn)
+	}
+	sort.Slice(funcs, func(i, j int) bool { return funcs[i].Line < funcs[j].Line })
+	return funcs, "findings", nil
+}
+
 // modulesSource feeds the modules analyzer from the code index. The
 // symbol->files map is built once from ListAllSymbols rather than issuing a
 // search per referenced name — the analyzer asks about thousands of names.
@@ -5,7 +5,11 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/jmylchreest/aide/aide/pkg/findings"
 	"github.com/jmylchreest/aide/aide/pkg/store"
 	"github.com/jmylchreest/aide/aide/pkg/survey"
 )
@@ -27,7 +31,7 @@ func TestRunAllWithoutCodeIndex(t *testing.T) {
 	}
 	defer ss.Close()
 
-	results := Run(root, nil, ss, nil)
+	results := Run(root, nil, ss, nil, nil)
 	if len(results) != len(AllAnalyzers()) {
 		t.Fatalf("expected %d results, got %d", len(AllAnalyzers()), len(results))
 	}
@@ -67,8 +71,109 @@ func TestRunUnknownAnalyzer(t *testing.T) {
 	}
 	defer ss.Close()
 
-	results := Run(t.TempDir(), []string{"nonsense"}, ss, nil)
+	results := Run(t.TempDir(), []string{"nonsense"}, ss, nil, nil)
 	if len(results) != 1 || results[0].Err == "" {
 		t.Errorf("unknown analyzer should produce an error result, got %+v", results)
 	}
 }
+
+func TestHotspotComplexityFromFindings(t *testing.T) {
+	fs, err := store.NewFindingsStore(filepath.Join(t.TempDir(), "findings"))
+	if err != nil {
+		t.Fatalf("NewFindingsStore: %v", err)
+	}
+	defer fs.Close()
+
+	complexity := func(file string, line int, fn, cx string) *findings.Finding {
+		return &findings.Finding{
+			Analyzer: findings.AnalyzerComplexity,
+			Severity: findings.SevWarning,
+			FilePath: file,
+			Line:     line,
+			Title:    fn + " has complexity " + cx,
+			Metadata: map[string]string{"complexity": cx, "function": fn},
+		}
+	}
+	err = fs.ReplaceFindingsForAnalyzer(findings.AnalyzerComplexity, []*findings.Finding{
+		complexity("pkg/a.go", 30, "Second", "18"),
+		complexity("pkg/a.go", 10, "First", "25"),
+		complexity("cmd/pkg/a.go", 5, "Elsewhere", "40"), // substring match, other file
+	})
+	if err != nil {
+		t.Fatalf("ReplaceFindingsForAnalyzer: %v", err)
+	}
+
+	src := &hotspotComplexity{findings: fs}
+	funcs, source, err := src.FileComplexity("pkg/a.go")
+	if err != nil {
+		t.Fatalf("FileComplexity: %v", err)
+	}
+	if source != "findings" || len(funcs) != 2 {
+		t.Fatalf("got %d functions from %q, want 2 from findings: %+v", len(funcs), source, funcs)
+	}
+	if funcs[0].Name != "First" || funcs[0].Complexity != 25 || !funcs[0].Flagged || funcs[1].Name != "Second" {
+		t.Errorf("functions = %+v", funcs)
+	}
+
+	if funcs, _, _ := src.FileComplexity("pkg/b.go"); len(funcs) != 0 {
+		t.Errorf("unmeasured file returned %+v", funcs)
+	}
+}
+
+func TestRunRecordsSnapshots(t *testing.T) {
+	root := t.TempDir()
+	repo, err := git.PlainInit(root, false)
+	if err != nil {
+		t.Fatalf("PlainInit: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree: %v", err)
+	}
+	commit := func(rel, content string) string {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, rel), []byte(content), 0o644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		if _, err := wt.Add(rel); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+		h, err := wt.Commit("change "+rel, &git.CommitOptions{Author: &object.Signature{Name: "Alice", Email: "alice@example.com", When: time.Now()}})
+		if err != nil {
+			t.Fatalf("Commit: %v", err)
+		}
+		return h.String()
+	}
+
+	ss, err := store.NewSurveyStore(filepath.Join(t.TempDir(), "survey"))
+	if err != nil {
+		t.Fatalf("NewSurveyStore: %v", err)
+	}
+	defer ss.Close()
+
+	first := commit("main.go", "package main\n\nfunc main() {}\n")
+	Run(root, []string{survey.AnalyzerChurn}, ss, nil, nil)
+	results := Run(root, []string{survey.AnalyzerChurn, survey.AnalyzerTopology}, ss, nil, nil)
+	if last := results[len(results)-1]; last.Analyzer != survey.AnalyzerHistory || last.Err != "" || last.Entries != 1 {
+		t.Fatalf("history result = %+v, want one snapshot (re-runs at a commit replace it)", last)
+	}
+
+	second := commit("util.go", "package main\n")
+	Run(root, []string{survey.AnalyzerChurn}, ss, nil, nil)
+	// Topology alone is not snapshotted.
+	if results := Run(root, []string{survey.AnalyzerTopology}, ss, nil, nil); len(results) != 1 {
+		t.Errorf("topology-only run = %+v, want no history result", results)
+	}
+
+	entries, err := ss.ListEntries(survey.SearchOptions{Analyzer: survey.AnalyzerHistory, Limit: -1})
+	if err != nil {
+		t.Fatalf("ListEntries: %v", err)
+	}
+	snaps := survey.SnapshotsFromEntries(entries)
+	if len(snaps) != 2 || snaps[0].Commit != first || snaps[1].Commit != second {
+		t.Fatalf("snapshots = %d, want %s then %s", len(snaps), first[:8], second[:8])
+	}
+	if len(snaps[1].Churn) != 2 || len(snaps[0].Churn) != 1 {
+		t.Errorf("churn = %v then %v, want 1 then 2 files", snaps[0].Churn, snaps[1].Churn)
+	}
+}
@@ -428,6 +428,9 @@ export function runSessionInit(
       result.codebaseMap = data.codebase_map;
       result.codebaseMapNote = data.codebase_map_note;
     }
+    if (!isFalsy(process.env.AIDE_SURVEY_INJECT) && data.repo_map) {
+      result.repoMap = data.repo_map;
+    }
 
     const sources: InjectedSource[] = [];
     for (const m of data.global_memories) {
@@ -482,7 +485,7 @@ export function runSessionInit(
         scope: "project",
         id: `module:${mod.name}`,
         name: mod.name,
-        content: `${mod.name} — ${mod.size} files, hub: ${mod.hub}`,
+        content: `${mod.name} — ${mod.size} files, hub: ${mod.hub}${moduleOwnerSuffix(mod)}`,
       });
     }
     result.sources = sources;
@@ -508,6 +511,19 @@ function decisionOriginSuffix(
   return ` — inherited from parent **${d.origin_name}** (override with a local \`decision set ${d.topic}\`)`;
 }
 
+/**
+ * Ownership suffix for a Codebase Map line: who to ask, flagging modules
+ * whose knowledge rests with one person. Empty until the ownership survey
+ * analyzer has run.
+ */
+function moduleOwnerSuffix(
+  mod: NonNullable<SessionInitResult["codebase_map"]>[number],
+): string {
+  if (!mod.owner) return "";
+  const risk = mod.bus_factor === 1 ? " (bus factor 1)" : "";
+  return `, owner: ${mod.owner}${risk}`;
+}
+
 export function formatTimeAgo(isoTimestamp: string): string {
   try {
     const dt = new Date(isoTimestamp);
@@ -652,15 +668,30 @@ export function buildWelcomeContext(
     lines.push(`## Codebase Map${note}`);
     lines.push("");
     lines.push(
-      "Structural modules discovered by clustering the import graph — what belongs together, not just where files live. Query details via the survey MCP tools (survey_list kind=module).",
+      "Structural modules discovered by clustering the import graph — what belongs together, not just where files live. Query details via the survey MCP tools (survey_list kind=module; kind=ownership for who to ask).",
     );
     lines.push("");
     for (const mod of memories.codebaseMap) {
-      lines.push(`- **${mod.name}** — ${mod.size} files, hub: ${mod.hub}`);
+      lines.push(
+        `- **${mod.name}** — ${mod.size} files, hub: ${mod.hub}${moduleOwnerSuffix(mod)}`,
+      );
     }
     lines.push("");
   }
 
+  if (memories.repoMap) {
+    lines.push("## Repo Map");
+    lines.push("");
+    lines.push(
+      "The most important symbols, ranked by how much of the codebase references them. Read one with code_read_symbol; widen or refocus with code_repo_map.",
+    );
+    lines.push("");
+    lines.push("```");
+    lines.push(memories.repoMap.trimEnd());
+    lines.push("```");
+    lines.push("");
+  }
+
   const estate = memories.estate;
   if (estate && ((estate.parents?.length ?? 0) > 0 || (estate.subprojects?.length ?? 0) > 0)) {
     lines.push("## Estate");
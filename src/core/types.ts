@@ -118,8 +118,16 @@ export interface SessionInitResult {
     last_at: string;
     memories: Array<{ content: string; category: string }>;
   }>;
-  codebase_map?: Array<{ name: string; size: number; hub: string }>;
+  codebase_map?: Array<{
+    name: string;
+    size: number;
+    hub: string;
+    owner?: string;
+    bus_factor?: number;
+  }>;
   codebase_map_note?: string;
+  /** Rendered repo map (code.repo_map_tokens), absent when off. */
+  repo_map?: string;
 }
 
 // =============================================================================
@@ -151,9 +159,17 @@ export interface MemoryInjection {
     sessions: string[];
   };
   /** Codebase Map lines from the survey modules analyzer, largest first. */
-  codebaseMap?: Array<{ name: string; size: number; hub: string }>;
+  codebaseMap?: Array<{
+    name: string;
+    size: number;
+    hub: string;
+    owner?: string;
+    bus_factor?: number;
+  }>;
   /** Freshness note for the map header, e.g. "as of a1b2c3d4". */
   codebaseMapNote?: string;
+  /** Ranked, token-budgeted symbol outline (code_repo_map). */
+  repoMap?: string;
   /** Estate: parent projects (anchor chain) and surveyed child subprojects. */
   estate?: SessionInitResult["estate"];
   /** User-visible note when the retention sweep pruned records at init. */
@@ -142,6 +142,20 @@ describe("buildWelcomeContext codebase map", () => {
       codebaseMap: [
         { name: "observe", size: 74, hub: "aide/pkg/observe/observe.go" },
         { name: "logger", size: 65, hub: "src/lib/logger.ts" },
+        {
+          name: "store",
+          size: 40,
+          hub: "aide/pkg/store/bolt.go",
+          owner: "alice",
+          bus_factor: 1,
+        },
+        {
+          name: "survey",
+          size: 30,
+          hub: "aide/pkg/survey/types.go",
+          owner: "bob",
+          bus_factor: 3,
+        },
       ],
       codebaseMapNote: "as of a1b2c3d4 — 3 commits behind; run survey_run to refresh",
     };
@@ -154,6 +168,12 @@ describe("buildWelcomeContext codebase map", () => {
       "- **observe** — 74 files, hub: aide/pkg/observe/observe.go",
     );
     expect(ctx).toContain("- **logger** — 65 files, hub: src/lib/logger.ts");
+    expect(ctx).toContain(
+      "- **store** — 40 files, hub: aide/pkg/store/bolt.go, owner: alice (bus factor 1)",
+    );
+    expect(ctx).toContain(
+      "- **survey** — 30 files, hub: aide/pkg/survey/types.go, owner: bob\n",
+    );
     // Appended after memories/decisions, before the modes footer.
     expect(ctx.indexOf("## Codebase Map")).toBeLessThan(
       ctx.indexOf("## Available Modes"),
@@ -165,6 +185,18 @@ describe("buildWelcomeContext codebase map", () => {
     const ctx = buildWelcomeContext(state as never, emptyInjection() as never);
     expect(ctx).not.toContain("Codebase Map");
   });
+
+  it("renders the repo map as a fenced block when present", async () => {
+    const { buildWelcomeContext } = await import("../core/session-init.js");
+    const injection = {
+      ...emptyInjection(),
+      repoMap: "# Repo map: 1 of 9 symbols in 1 files (~40 tokens)\nstore/code.go\n  12: func Open() error\n",
+    };
+    const ctx = buildWelcomeContext(state as never, injection as never);
+    expect(ctx).toContain("## Repo Map");
+    expect(ctx).toContain("```\n# Repo map: 1 of 9 symbols in 1 files (~40 tokens)\nstore/code.go\n  12: func Open() error\n```");
+    expect(ctx.indexOf("## Repo Map")).toBeLessThan(ctx.indexOf("## Available Modes"));
+  });
 });
 
 describe("buildWelcomeContext decision precedence", () => {